@@ -0,0 +1,602 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	stdlog "log"
+	"maps"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/logger"
+	dlc "github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/gadget-inc/dateilager/pkg/version"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Proxy forwards TCP connections to the database and cuts them on demand, so the server sees its connections drop
+// in the middle of queries and transactions. While it is down new connections are closed as soon as they are accepted.
+type Proxy struct {
+	listener net.Listener
+	upstream string
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+	down  bool
+	cuts  int
+}
+
+func newProxy(listen, upstream string) (*Proxy, error) {
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return nil, fmt.Errorf("cannot listen on %v: %w", listen, err)
+	}
+
+	return &Proxy{
+		listener: listener,
+		upstream: upstream,
+		conns:    make(map[net.Conn]struct{}),
+	}, nil
+}
+
+func (p *Proxy) Serve() {
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		p.mu.Lock()
+		down := p.down
+		p.mu.Unlock()
+
+		if down {
+			conn.Close()
+			continue
+		}
+
+		go p.pipe(conn)
+	}
+}
+
+func (p *Proxy) pipe(conn net.Conn) {
+	upstream, err := net.Dial("tcp", p.upstream)
+	if err != nil {
+		conn.Close()
+		return
+	}
+
+	p.track(conn, upstream)
+	defer p.untrack(conn, upstream)
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(upstream, conn)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(conn, upstream)
+		done <- struct{}{}
+	}()
+
+	<-done
+}
+
+func (p *Proxy) track(conns ...net.Conn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, conn := range conns {
+		p.conns[conn] = struct{}{}
+	}
+}
+
+func (p *Proxy) untrack(conns ...net.Conn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, conn := range conns {
+		conn.Close()
+		delete(p.conns, conn)
+	}
+}
+
+// Cut closes every open connection and returns how many were closed.
+func (p *Proxy) Cut() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	count := len(p.conns)
+	for conn := range p.conns {
+		conn.Close()
+		delete(p.conns, conn)
+	}
+	p.cuts += 1
+
+	return count
+}
+
+func (p *Proxy) Cuts() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.cuts
+}
+
+func (p *Proxy) SetDown(down bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.down = down
+}
+
+func (p *Proxy) Close() {
+	p.listener.Close()
+	p.SetDown(true)
+	p.Cut()
+}
+
+type chaosOptions struct {
+	interval     time.Duration
+	outage       time.Duration
+	outageChance float64
+}
+
+// jitter returns a random duration between half and one and a half times d.
+func jitter(d time.Duration) time.Duration {
+	return d/2 + time.Duration(rand.Int63n(int64(d)+1))
+}
+
+// runChaos cuts the proxy connections at random intervals until ctx is done, sometimes keeping the proxy down for a while.
+func runChaos(ctx context.Context, proxy *Proxy, opts chaosOptions) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(jitter(opts.interval)):
+		}
+
+		count := proxy.Cut()
+		if rand.Float64() >= opts.outageChance {
+			logger.Info(ctx, "cut connections", zap.Int("count", count))
+			continue
+		}
+
+		outage := jitter(opts.outage)
+		logger.Info(ctx, "cut connections, proxy down", zap.Int("count", count), zap.Duration("outage", outage))
+		proxy.SetDown(true)
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(outage):
+		}
+		proxy.SetDown(false)
+	}
+}
+
+// Tree maps the paths of a project to their contents.
+type Tree map[string]string
+
+func readTree(dir string) (Tree, error) {
+	tree := make(Tree)
+
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if relative == ".dl" && entry.IsDir() {
+			return filepath.SkipDir
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		tree[filepath.ToSlash(relative)] = string(content)
+		return nil
+	})
+
+	return tree, err
+}
+
+func diffTrees(expected, actual Tree) []string {
+	var diffs []string
+	for path, content := range expected {
+		other, ok := actual[path]
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("missing %v", path))
+		case other != content:
+			diffs = append(diffs, fmt.Sprintf("content mismatch %v", path))
+		}
+	}
+	for path := range actual {
+		if _, ok := expected[path]; !ok {
+			diffs = append(diffs, fmt.Sprintf("unexpected %v", path))
+		}
+	}
+	return diffs
+}
+
+// retry calls fn until it succeeds, giving up after attempts calls. Under chaos any call can fail while the database
+// is unreachable, only the outcome once it is reachable again is checked.
+func retry(ctx context.Context, attempts int, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		err = fn()
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+	return err
+}
+
+// Project is the state of a project as the workload knows it: the tree written at every version it created.
+type Project struct {
+	id       int64
+	dir      string
+	latest   int64
+	versions map[int64]Tree
+}
+
+func (p *Project) tree() Tree {
+	return p.versions[p.latest]
+}
+
+// mutate returns the tree of the next version, a few files are written or removed and sometimes a whole batch is added
+// so updates span many rows.
+func (p *Project) mutate(iteration int) Tree {
+	next := maps.Clone(p.tree())
+
+	for range rand.Intn(5) + 1 {
+		path := fmt.Sprintf("f%d.txt", rand.Intn(10))
+		if _, ok := next[path]; ok && rand.Intn(3) == 0 {
+			delete(next, path)
+			continue
+		}
+		next[path] = fmt.Sprintf("iteration %d %d", iteration, rand.Int63())
+	}
+
+	if rand.Intn(5) == 0 {
+		for idx := range rand.Intn(100) + 20 {
+			next[fmt.Sprintf("batch%d/b%d.txt", iteration, idx)] = fmt.Sprintf("batch %d %d", iteration, idx)
+		}
+	}
+
+	return next
+}
+
+func writeTree(dir string, current, next Tree) error {
+	for path := range current {
+		if _, ok := next[path]; !ok {
+			err := os.Remove(filepath.Join(dir, path))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for path, content := range next {
+		if current[path] == content {
+			continue
+		}
+
+		fullPath := filepath.Join(dir, path)
+		err := os.MkdirAll(filepath.Dir(fullPath), 0755)
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(fullPath, []byte(content), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rebuildFresh checks out a version of the project into an empty dir and reads it back, retrying through the chaos.
+func rebuildFresh(ctx context.Context, client *dlc.Client, project int64, toVersion *int64, dir string) (int64, Tree, error) {
+	var result dlc.RebuildResult
+	err := retry(ctx, 50, func() error {
+		err := os.RemoveAll(dir)
+		if err == nil {
+			err = os.MkdirAll(dir, 0755)
+		}
+		if err != nil {
+			return err
+		}
+
+		result, err = client.Rebuild(ctx, project, "", toVersion, dir, nil, "", nil, false)
+		return err
+	})
+	if err != nil {
+		return -1, nil, fmt.Errorf("failed to rebuild project %d: %w", project, err)
+	}
+
+	tree, err := readTree(dir)
+	if err != nil {
+		return -1, nil, fmt.Errorf("failed to read rebuilt project %d: %w", project, err)
+	}
+
+	return result.Version, tree, nil
+}
+
+type Stats struct {
+	succeeded int
+	failed    int
+	ambiguous int
+}
+
+// runIteration updates the project once and checks the outcome. A successful update must create the next version,
+// a failed one must leave the project at its latest version or, when only the response was lost, at the next version
+// holding the whole update. Anything else is a partially committed version.
+func runIteration(ctx context.Context, client *dlc.Client, project *Project, iteration int, scratch string, stats *Stats) error {
+	next := project.mutate(iteration)
+
+	err := writeTree(project.dir, project.tree(), next)
+	if err != nil {
+		return fmt.Errorf("failed to write project %d: %w", project.id, err)
+	}
+
+	version, _, updateErr := client.Update(ctx, project.id, project.dir)
+	if updateErr == nil {
+		if version != project.latest+1 {
+			return fmt.Errorf("update of project %d created version %d, expected version %d", project.id, version, project.latest+1)
+		}
+
+		stats.succeeded += 1
+		project.latest = version
+		project.versions[version] = next
+		return nil
+	}
+
+	latest, tree, err := rebuildFresh(ctx, client, project.id, nil, scratch)
+	if err != nil {
+		return err
+	}
+
+	switch latest {
+	case project.latest:
+		if diffs := diffTrees(project.tree(), tree); len(diffs) > 0 {
+			return fmt.Errorf("failed update changed project %d at version %d: %v", project.id, latest, strings.Join(diffs, ", "))
+		}
+		stats.failed += 1
+		logger.Info(ctx, "update failed cleanly", zap.Int64("project", project.id), zap.Int64("version", latest), zap.Error(updateErr))
+
+	case project.latest + 1:
+		if diffs := diffTrees(next, tree); len(diffs) > 0 {
+			return fmt.Errorf("failed update partially committed project %d version %d: %v", project.id, latest, strings.Join(diffs, ", "))
+		}
+		stats.ambiguous += 1
+		project.latest = latest
+		project.versions[latest] = next
+		logger.Info(ctx, "update committed despite error", zap.Int64("project", project.id), zap.Int64("version", latest), zap.Error(updateErr))
+
+	default:
+		return fmt.Errorf("failed update moved project %d from version %d to %d", project.id, project.latest, latest)
+	}
+
+	// the working directory no longer matches what the server holds, check it out again
+	_, _, err = rebuildFresh(ctx, client, project.id, &project.latest, project.dir)
+	return err
+}
+
+// verifyProjects checks every version created by the workload once the chaos has stopped.
+func verifyProjects(ctx context.Context, client *dlc.Client, projects []*Project, scratch string) error {
+	for _, project := range projects {
+		latest, _, err := rebuildFresh(ctx, client, project.id, nil, scratch)
+		if err != nil {
+			return err
+		}
+		if latest != project.latest {
+			return fmt.Errorf("project %d is at version %d, expected version %d", project.id, latest, project.latest)
+		}
+
+		for version := int64(1); version <= project.latest; version++ {
+			expected, ok := project.versions[version]
+			if !ok {
+				return fmt.Errorf("project %d version %d was created without being observed", project.id, version)
+			}
+
+			_, tree, err := rebuildFresh(ctx, client, project.id, &version, scratch)
+			if err != nil {
+				return err
+			}
+			if diffs := diffTrees(expected, tree); len(diffs) > 0 {
+				return fmt.Errorf("project %d version %d does not match its update: %v", project.id, version, strings.Join(diffs, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+func chaosTest(ctx context.Context, client *dlc.Client, proxy *Proxy, projectCount, iterations int, opts chaosOptions) error {
+	logger.Info(ctx, "starting chaos test", zap.Int("projects", projectCount), zap.Int("iterations", iterations))
+
+	base, err := os.MkdirTemp("", "dl-chaos-")
+	if err != nil {
+		return fmt.Errorf("cannot create tmp dir: %w", err)
+	}
+
+	var projects []*Project
+	for idx := 1; idx <= projectCount; idx++ {
+		project := &Project{
+			id:       int64(idx),
+			dir:      filepath.Join(base, fmt.Sprint(idx)),
+			versions: map[int64]Tree{0: {}},
+		}
+
+		err = os.MkdirAll(project.dir, 0755)
+		if err != nil {
+			return fmt.Errorf("cannot create project dir: %w", err)
+		}
+
+		// the server may still be starting, wait for it before the chaos begins
+		err = retry(ctx, 150, func() error {
+			err := client.NewProject(ctx, project.id, nil, nil)
+			if status.Code(err) == codes.AlreadyExists {
+				return nil
+			}
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create project %d: %w", project.id, err)
+		}
+
+		projects = append(projects, project)
+	}
+
+	chaosCtx, stopChaos := context.WithCancel(ctx)
+	chaosDone := make(chan struct{})
+	go func() {
+		defer close(chaosDone)
+		runChaos(chaosCtx, proxy, opts)
+	}()
+
+	scratch := filepath.Join(base, "scratch")
+	var stats Stats
+
+	for iteration := 0; iteration < iterations; iteration++ {
+		project := projects[rand.Intn(len(projects))]
+
+		err = runIteration(ctx, client, project, iteration, scratch, &stats)
+		if err != nil {
+			stopChaos()
+			<-chaosDone
+			logger.Info(ctx, "working directory", zap.String("path", base))
+			return fmt.Errorf("iteration %d: %w", iteration, err)
+		}
+	}
+
+	stopChaos()
+	<-chaosDone
+
+	err = verifyProjects(ctx, client, projects, scratch)
+	if err != nil {
+		logger.Info(ctx, "working directory", zap.String("path", base))
+		return err
+	}
+
+	logger.Info(ctx, "chaos test results",
+		zap.Int("succeeded", stats.succeeded),
+		zap.Int("failed", stats.failed),
+		zap.Int("ambiguous", stats.ambiguous),
+		zap.Int("cuts", proxy.Cuts()),
+	)
+
+	os.RemoveAll(base)
+	return nil
+}
+
+func newCommand() *cobra.Command {
+	var (
+		projects     int
+		iterations   int
+		host         string
+		port         uint16
+		listen       string
+		upstream     string
+		interval     time.Duration
+		outage       time.Duration
+		outageChance float64
+	)
+
+	cmd := &cobra.Command{
+		Use:     "chaos",
+		Short:   "DateiLager chaos testing",
+		Long:    "Runs updates against a server whose database connections go through a proxy that keeps cutting them, checking every version is committed whole or not at all. The server must connect to the database through --listen.",
+		Version: version.Version,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true // silence usage when an error occurs after flags have been parsed
+
+			ctx := cmd.Context()
+
+			proxy, err := newProxy(listen, upstream)
+			if err != nil {
+				return err
+			}
+			defer proxy.Close()
+			go proxy.Serve()
+
+			client, err := dlc.NewClient(ctx, host, port)
+			if err != nil {
+				return err
+			}
+
+			return chaosTest(ctx, client, proxy, projects, iterations, chaosOptions{
+				interval:     interval,
+				outage:       outage,
+				outageChance: outageChance,
+			})
+		},
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.IntVar(&projects, "projects", 3, "How many projects to create")
+	flags.IntVar(&iterations, "iterations", 200, "How many updates to run")
+	flags.StringVar(&host, "host", "", "GRPC server hostname")
+	flags.Uint16Var(&port, "port", 5051, "GRPC server port")
+	flags.StringVar(&listen, "listen", "127.0.0.1:5433", "Address the database proxy listens on")
+	flags.StringVar(&upstream, "upstream", "127.0.0.1:5432", "Database address the proxy forwards to")
+	flags.DurationVar(&interval, "interval", 2*time.Second, "Average time between connection cuts")
+	flags.DurationVar(&outage, "outage", 500*time.Millisecond, "Average time the proxy stays down after an outage cut")
+	flags.Float64Var(&outageChance, "outage-chance", 0.3, "Chance of a cut keeping the proxy down")
+
+	return cmd
+}
+
+func main() {
+	ctx := context.Background()
+	cmd := newCommand()
+
+	err := logger.Init(zap.Config{
+		Level:       zap.NewAtomicLevelAt(zapcore.InfoLevel),
+		Development: true,
+		Encoding:    "console",
+		EncoderConfig: zapcore.EncoderConfig{
+			TimeKey:       "",
+			LevelKey:      "",
+			NameKey:       "",
+			CallerKey:     "",
+			MessageKey:    "M",
+			StacktraceKey: "",
+		},
+		OutputPaths:      []string{"stderr"},
+		ErrorOutputPaths: []string{"stderr"},
+	})
+	if err != nil {
+		stdlog.Fatal("failed to init logger", err)
+	}
+
+	err = cmd.ExecuteContext(ctx)
+	if err != nil {
+		logger.Fatal(ctx, "chaos test failed", zap.Error(err))
+	}
+
+	logger.Info(ctx, "chaos test completed")
+	_ = logger.Sync(ctx)
+}
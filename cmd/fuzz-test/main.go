@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/fs"
 	stdlog "log"
@@ -9,8 +10,11 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
+	"time"
 
+	"github.com/gadget-inc/dateilager/internal/files"
 	"github.com/gadget-inc/dateilager/internal/logger"
 	dlc "github.com/gadget-inc/dateilager/pkg/client"
 	"github.com/gadget-inc/dateilager/pkg/version"
@@ -20,7 +24,8 @@ import (
 )
 
 const (
-	charset = "aAbBcCdDeEfFgGhHiIjJkKlLmMnNoOpPqQrRsStTuUvVwWxXyYzZ0123456789"
+	charset      = "aAbBcCdDeEfFgGhHiIjJkKlLmMnNoOpPqQrRsStTuUvVwWxXyYzZ0123456789"
+	packPatterns = "^pack1/.*/,^pack2/.*/"
 )
 
 type Type int
@@ -33,6 +38,12 @@ const (
 )
 
 var (
+	// rng drives every random operation of a run, seeding it with the same value replays the same operations
+	rng = rand.New(rand.NewSource(1))
+	// stepRng picks the versions rebuilt step by step, it is kept apart from rng so replaying saved operations,
+	// which draws nothing from rng, rebuilds from the same versions
+	stepRng = rand.New(rand.NewSource(1))
+
 	Join = filepath.Join
 	// The default filesystem on MacOS is not case sensitive
 	// and in that case we need to be more careful when checking if an object exists
@@ -57,7 +68,7 @@ func typeStr(type_ Type) string {
 func randString(length int) string {
 	b := make([]byte, length)
 	for i := range b {
-		b[i] = charset[rand.Intn(len(charset))]
+		b[i] = charset[rng.Intn(len(charset))]
 	}
 	return string(b)
 }
@@ -105,6 +116,8 @@ func objectFilter(objects map[string]Type, type_ Type) []string {
 			files = append(files, path)
 		}
 	}
+	// map order is random, sort so the same seed picks the same paths
+	slices.Sort(files)
 	return files
 }
 
@@ -128,6 +141,36 @@ func objectExists(objects map[string]Type, project int64, path string) bool {
 type Operation interface {
 	Apply() error
 	String() string
+	Save() SavedOp
+}
+
+// SavedOp is the serialized form of an operation, base directories are left out so it can be replayed anywhere.
+type SavedOp struct {
+	Project int64  `json:"project"`
+	Kind    string `json:"kind"`
+	Dir     string `json:"dir,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Target  string `json:"target,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+func loadOperation(base string, saved SavedOp) (Operation, error) {
+	switch saved.Kind {
+	case "skip":
+		return SkipOp{}, nil
+	case "add-file":
+		return AddFileOp{base: base, dir: saved.Dir, name: saved.Name, content: []byte(saved.Content)}, nil
+	case "update-file":
+		return UpdateFileOp{base: base, dir: saved.Dir, name: saved.Name, content: []byte(saved.Content)}, nil
+	case "add-dir":
+		return AddDirOp{base: base, dir: saved.Dir, name: saved.Name}, nil
+	case "remove-file":
+		return RemoveFileOp{base: base, dir: saved.Dir, name: saved.Name}, nil
+	case "add-symlink":
+		return AddSymlinkOp{base: base, dir: saved.Dir, name: saved.Name, target: saved.Target}, nil
+	default:
+		return nil, fmt.Errorf("unknown operation kind: %s", saved.Kind)
+	}
 }
 
 type SkipOp struct{}
@@ -140,6 +183,10 @@ func (o SkipOp) String() string {
 	return "Skip()"
 }
 
+func (o SkipOp) Save() SavedOp {
+	return SavedOp{Kind: "skip"}
+}
+
 type AddFileOp struct {
 	base    string
 	dir     string
@@ -149,12 +196,12 @@ type AddFileOp struct {
 
 func newAddFileOp(base string, project int64) Operation {
 	dir := fmt.Sprint(project)
-	name := randString(rand.Intn(20) + 1)
+	name := randString(rng.Intn(20) + 1)
 	objects := walkDir(Join(base, dir))
 
 	dirs := objectFilter(objects, typeDirectory)
-	if len(dirs) > 0 && rand.Intn(2) == 1 {
-		dir = Join(dir, dirs[rand.Intn(len(dirs))])
+	if len(dirs) > 0 && rng.Intn(2) == 1 {
+		dir = Join(dir, dirs[rng.Intn(len(dirs))])
 	}
 
 	if objectExists(objects, project, Join(dir, name)) {
@@ -165,7 +212,7 @@ func newAddFileOp(base string, project int64) Operation {
 		base:    base,
 		dir:     dir,
 		name:    name,
-		content: []byte(randString(rand.Intn(500))),
+		content: []byte(randString(rng.Intn(500))),
 	}
 }
 
@@ -177,6 +224,10 @@ func (o AddFileOp) String() string {
 	return fmt.Sprintf("AddFile(%s, %d)", Join(o.dir, o.name), len(o.content))
 }
 
+func (o AddFileOp) Save() SavedOp {
+	return SavedOp{Kind: "add-file", Dir: o.dir, Name: o.name, Content: string(o.content)}
+}
+
 type UpdateFileOp struct {
 	base    string
 	dir     string
@@ -195,8 +246,8 @@ func newUpdateFileOp(base string, project int64) Operation {
 	return UpdateFileOp{
 		base:    base,
 		dir:     dir,
-		name:    files[rand.Intn(len(files))],
-		content: []byte(randString(rand.Intn(500))),
+		name:    files[rng.Intn(len(files))],
+		content: []byte(randString(rng.Intn(500))),
 	}
 }
 
@@ -208,6 +259,10 @@ func (o UpdateFileOp) String() string {
 	return fmt.Sprintf("UpdateFile(%s, %d)", Join(o.dir, o.name), len(o.content))
 }
 
+func (o UpdateFileOp) Save() SavedOp {
+	return SavedOp{Kind: "update-file", Dir: o.dir, Name: o.name, Content: string(o.content)}
+}
+
 type AddDirOp struct {
 	base string
 	dir  string
@@ -216,14 +271,14 @@ type AddDirOp struct {
 
 func newAddDirOp(base string, project int64) Operation {
 	dir := fmt.Sprint(project)
-	name := randString(rand.Intn(20) + 1)
+	name := randString(rng.Intn(20) + 1)
 	objects := walkDir(Join(base, dir))
 
 	dirs := objectFilter(objects, typeDirectory)
-	if rand.Intn(10) < 1 {
-		dir = Join(dir, fmt.Sprintf("pack%d", rand.Intn(2)+1))
-	} else if len(dirs) > 0 && rand.Intn(2) == 1 {
-		dir = Join(dir, dirs[rand.Intn(len(dirs))])
+	if rng.Intn(10) < 1 {
+		dir = Join(dir, fmt.Sprintf("pack%d", rng.Intn(2)+1))
+	} else if len(dirs) > 0 && rng.Intn(2) == 1 {
+		dir = Join(dir, dirs[rng.Intn(len(dirs))])
 	}
 
 	if objectExists(objects, project, Join(dir, name)) {
@@ -245,6 +300,10 @@ func (o AddDirOp) String() string {
 	return fmt.Sprintf("AddDir(%s)", Join(o.dir, o.name))
 }
 
+func (o AddDirOp) Save() SavedOp {
+	return SavedOp{Kind: "add-dir", Dir: o.dir, Name: o.name}
+}
+
 type RemoveFileOp struct {
 	base string
 	dir  string
@@ -262,7 +321,7 @@ func newRemoveFileOp(base string, project int64) Operation {
 	return RemoveFileOp{
 		base: base,
 		dir:  dir,
-		name: files[rand.Intn(len(files))],
+		name: files[rng.Intn(len(files))],
 	}
 }
 
@@ -274,6 +333,10 @@ func (o RemoveFileOp) String() string {
 	return fmt.Sprintf("RemoveFile(%s)", Join(o.dir, o.name))
 }
 
+func (o RemoveFileOp) Save() SavedOp {
+	return SavedOp{Kind: "remove-file", Dir: o.dir, Name: o.name}
+}
+
 type AddSymlinkOp struct {
 	base   string
 	dir    string
@@ -283,7 +346,7 @@ type AddSymlinkOp struct {
 
 func newAddSymlinkOp(base string, project int64) Operation {
 	dir := fmt.Sprint(project)
-	name := randString(rand.Intn(20) + 1)
+	name := randString(rng.Intn(20) + 1)
 	objects := walkDir(Join(base, dir))
 
 	dirs := objectFilter(objects, typeDirectory)
@@ -293,8 +356,8 @@ func newAddSymlinkOp(base string, project int64) Operation {
 		return SkipOp{}
 	}
 
-	if len(dirs) > 0 && rand.Intn(2) == 1 {
-		dir = Join(dir, dirs[rand.Intn(len(dirs))])
+	if len(dirs) > 0 && rng.Intn(2) == 1 {
+		dir = Join(dir, dirs[rng.Intn(len(dirs))])
 	}
 
 	if objectExists(objects, project, Join(dir, name)) {
@@ -305,7 +368,7 @@ func newAddSymlinkOp(base string, project int64) Operation {
 		base:   base,
 		dir:    dir,
 		name:   name,
-		target: files[rand.Intn(len(files))],
+		target: files[rng.Intn(len(files))],
 	}
 }
 
@@ -317,6 +380,10 @@ func (o AddSymlinkOp) String() string {
 	return fmt.Sprintf("AddSymlink(%s, %s)", Join(o.dir, o.target), Join(o.dir, o.name))
 }
 
+func (o AddSymlinkOp) Save() SavedOp {
+	return SavedOp{Kind: "add-symlink", Dir: o.dir, Name: o.name, Target: o.target}
+}
+
 type OpConstructor func(dir string, project int64) Operation
 
 var opConstructors = []OpConstructor{newAddFileOp, newUpdateFileOp, newAddDirOp, newRemoveFileOp, newAddSymlinkOp}
@@ -325,7 +392,7 @@ func randomOperation(baseDir string, project int64) Operation {
 	var operation Operation = SkipOp{}
 
 	for {
-		operation = opConstructors[rand.Intn(len(opConstructors))](baseDir, project)
+		operation = opConstructors[rng.Intn(len(opConstructors))](baseDir, project)
 		if _, isSkip := operation.(SkipOp); !isSkip {
 			break
 		}
@@ -397,13 +464,30 @@ func (d *Directories) RemoveAll() {
 	os.RemoveAll(d.randomStep)
 }
 
+// ApplyError is returned when an operation cannot be applied to the base directories, as when the minimizer dropped
+// an earlier operation it depends on.
+type ApplyError struct {
+	operation Operation
+	err       error
+}
+
+func (e ApplyError) Error() string {
+	return fmt.Sprintf("failed to apply operation %s: %v", e.operation.String(), e.err)
+}
+
+func (e ApplyError) Unwrap() error {
+	return e.err
+}
+
 func runIteration(ctx context.Context, client *dlc.Client, project int64, operation Operation, dirs *Directories) (int64, error) {
 	err := operation.Apply()
 	if err != nil {
-		return -1, fmt.Errorf("failed to apply operation %s: %w", operation.String(), err)
+		return -1, ApplyError{operation: operation, err: err}
 	}
 
+	start := time.Now()
 	version, _, err := client.Update(ctx, project, dirs.Base(project))
+	metrics.observe("update", start, err)
 	if err != nil {
 		return -1, fmt.Errorf("failed to update project %d: %w", project, err)
 	}
@@ -414,12 +498,16 @@ func runIteration(ctx context.Context, client *dlc.Client, project int64, operat
 		return -1, fmt.Errorf("failed to create reset dir %s: %w", dirs.Reset(project), err)
 	}
 
+	start = time.Now()
 	_, err = client.Rebuild(ctx, project, "", nil, dirs.Reset(project), nil, "", nil, false)
+	metrics.observe("rebuild-full", start, err)
 	if err != nil {
 		return -1, fmt.Errorf("failed to rebuild reset project %d: %w", project, err)
 	}
 
+	start = time.Now()
 	_, err = client.Rebuild(ctx, project, "", nil, dirs.OneStep(project), nil, "", nil, false)
+	metrics.observe("rebuild-incremental", start, err)
 	if err != nil {
 		return -1, fmt.Errorf("failed to rebuild continue project %d: %w", project, err)
 	}
@@ -429,12 +517,16 @@ func runIteration(ctx context.Context, client *dlc.Client, project int64, operat
 		return -1, fmt.Errorf("failed to create step dir %s: %w", dirs.RandomStep(project), err)
 	}
 
-	randomStepVersion := int64(rand.Intn(int(version)))
+	randomStepVersion := int64(stepRng.Intn(int(version)))
+	start = time.Now()
 	_, err = client.Rebuild(ctx, project, "", &randomStepVersion, dirs.RandomStep(project), nil, "", nil, false)
+	metrics.observe("rebuild-full", start, err)
 	if err != nil {
 		return -1, fmt.Errorf("failed to rebuild step project %d: %w", project, err)
 	}
+	start = time.Now()
 	_, err = client.Rebuild(ctx, project, "", &version, dirs.RandomStep(project), nil, "", nil, false)
+	metrics.observe("rebuild-incremental", start, err)
 	if err != nil {
 		return -1, fmt.Errorf("failed to rebuild step project %d: %w", project, err)
 	}
@@ -576,11 +668,127 @@ func verifyDirs(ctx context.Context, projects int, dirs *Directories, stepVersio
 	return nil
 }
 
-func fuzzTest(ctx context.Context, client *dlc.Client, projects, iterations int) error {
-	logger.Info(ctx, "starting fuzz test", zap.Int("projects", projects), zap.Int("iterations", iterations))
+// State is what a run saves to be replayed: the seed, the operations applied so far and, next to it in the base
+// directory, the project directories those operations produced.
+type State struct {
+	Seed       int64     `json:"seed"`
+	Projects   int       `json:"projects"`
+	Operations []SavedOp `json:"operations"`
+}
+
+const stateFile = "state.json"
+
+func loadState(dir string) (*State, error) {
+	data, err := os.ReadFile(filepath.Join(dir, stateFile))
+	if err != nil {
+		return nil, fmt.Errorf("cannot read state: %w", err)
+	}
+
+	var state State
+	err = json.Unmarshal(data, &state)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse state %s: %w", filepath.Join(dir, stateFile), err)
+	}
+
+	return &state, nil
+}
+
+func writeStateFile(state *State, dir string) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cannot encode state: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, stateFile), data, 0644)
+	if err != nil {
+		return fmt.Errorf("cannot write state: %w", err)
+	}
+	return nil
+}
+
+// saveState writes the state and a copy of the base directories into dir, a new temporary directory when dir is empty.
+func saveState(state *State, dirs *Directories, dir string) (string, error) {
+	if dir == "" {
+		var err error
+		dir, err = os.MkdirTemp("", "dl-ft-state-")
+		if err != nil {
+			return "", fmt.Errorf("cannot create state dir: %w", err)
+		}
+	}
+
+	err := os.RemoveAll(filepath.Join(dir, "base"))
+	if err == nil {
+		err = os.MkdirAll(filepath.Join(dir, "base"), 0755)
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot create state dir: %w", err)
+	}
+
+	err = writeStateFile(state, dir)
+	if err != nil {
+		return "", err
+	}
+
+	err = files.CopyDir(dirs.base, filepath.Join(dir, "base"))
+	if err != nil {
+		return "", fmt.Errorf("cannot copy base dirs: %w", err)
+	}
+
+	return dir, nil
+}
+
+// verifyFixtures checks that replaying the saved operations rebuilt the base directories that were saved with them.
+func verifyFixtures(ctx context.Context, projects int, dirs *Directories, resumeFrom string) error {
+	for projectIdx := 1; projectIdx <= projects; projectIdx++ {
+		project := int64(projectIdx)
+
+		matchErrors, err := compareDirs(project, filepath.Join(resumeFrom, "base", fmt.Sprint(project)), dirs.Base(project))
+		if err != nil {
+			return fmt.Errorf("failed to compare saved & base dirs: %w", err)
+		}
+		if len(matchErrors) > 0 {
+			logMatchErrors(ctx, matchErrors)
+			return fmt.Errorf("replayed operations do not match saved fixtures, project %d", project)
+		}
+	}
+	return nil
+}
+
+type fuzzOptions struct {
+	projects   int
+	iterations int
+	seed       int64
+	resumeFrom string
+	saveTo     string
+	minimize   bool
+}
+
+func fuzzTest(ctx context.Context, client *dlc.Client, opts fuzzOptions) error {
+	state := &State{Seed: opts.seed, Projects: opts.projects}
+	var resumed []SavedOp
+
+	if opts.resumeFrom != "" {
+		saved, err := loadState(opts.resumeFrom)
+		if err != nil {
+			return err
+		}
+		state.Seed = saved.Seed
+		state.Projects = saved.Projects
+		resumed = saved.Operations
+	}
+
+	if state.Seed == 0 {
+		state.Seed = time.Now().UnixNano()
+	}
+	rng = rand.New(rand.NewSource(state.Seed))
+	stepRng = rand.New(rand.NewSource(state.Seed))
+	projects := state.Projects
+
+	logger.Info(ctx, "starting fuzz test", zap.Int("projects", projects), zap.Int("iterations", opts.iterations),
+		zap.Int64("seed", state.Seed), zap.Int("resumed", len(resumed)))
 
 	for projectIdx := 1; projectIdx <= projects; projectIdx++ {
-		pattern := "^pack1/.*/,^pack2/.*/"
+		pattern := packPatterns
 		err := client.NewProject(ctx, int64(projectIdx), nil, &pattern)
 		if err != nil {
 			return err
@@ -594,36 +802,98 @@ func fuzzTest(ctx context.Context, client *dlc.Client, projects, iterations int)
 
 	var opLog []Operation
 
-	for iterIdx := 0; iterIdx < iterations; iterIdx++ {
-		project := int64(rand.Intn(projects) + 1)
+	fail := func(err error) error {
+		dirs.Log(ctx)
+		logOpLog(ctx, opLog)
+
+		path, saveErr := saveState(state, dirs, opts.saveTo)
+		if saveErr != nil {
+			logger.Info(ctx, "failed to save state", zap.Error(saveErr))
+			return err
+		}
+		logger.Info(ctx, "saved state, replay with --resume-from", zap.String("path", path))
+
+		if opts.minimize {
+			minimized, minimizeErr := minimizeFailure(ctx, client, state, path)
+			if minimizeErr != nil {
+				logger.Info(ctx, "failed to minimize", zap.Error(minimizeErr))
+			} else {
+				logger.Info(ctx, "saved minimized state and regression test", zap.String("path", minimized))
+			}
+		}
+		return err
+	}
 
-		operation := randomOperation(dirs.base, project)
+	step := func(iterIdx int, project int64, operation Operation) error {
 		opLog = append(opLog, operation)
+		saved := operation.Save()
+		saved.Project = project
+		state.Operations = append(state.Operations, saved)
 
 		stepVersion, err := runIteration(ctx, client, project, operation, dirs)
 		if err != nil {
-			dirs.Log(ctx)
-			return fmt.Errorf("failed to run iteration %d: %w", iterIdx, err)
+			return fail(fmt.Errorf("failed to run iteration %d: %w", iterIdx, err))
 		}
 
 		err = verifyDirs(ctx, projects, dirs, stepVersion)
 		if err != nil {
-			dirs.Log(ctx)
-			logOpLog(ctx, opLog)
+			return fail(err)
+		}
+		return nil
+	}
+
+	for iterIdx, saved := range resumed {
+		if saved.Project < 1 || saved.Project > int64(projects) {
+			return fmt.Errorf("saved operation %d targets unknown project %d", iterIdx, saved.Project)
+		}
+
+		operation, err := loadOperation(dirs.base, saved)
+		if err != nil {
+			return fmt.Errorf("cannot load saved operation %d: %w", iterIdx, err)
+		}
+
+		err = step(iterIdx, saved.Project, operation)
+		if err != nil {
 			return err
 		}
 	}
 
+	if len(resumed) > 0 {
+		if _, err := os.Stat(filepath.Join(opts.resumeFrom, "base")); err == nil {
+			err = verifyFixtures(ctx, projects, dirs, opts.resumeFrom)
+			if err != nil {
+				return fail(err)
+			}
+		}
+	}
+
+	for iterIdx := len(resumed); iterIdx < len(resumed)+opts.iterations; iterIdx++ {
+		project := int64(rng.Intn(projects) + 1)
+
+		err = step(iterIdx, project, randomOperation(dirs.base, project))
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.saveTo != "" {
+		path, err := saveState(state, dirs, opts.saveTo)
+		if err != nil {
+			return err
+		}
+		logger.Info(ctx, "saved state", zap.String("path", path))
+	}
+
 	dirs.RemoveAll()
 	return nil
 }
 
 func newCommand() *cobra.Command {
 	var (
-		projects   int
-		iterations int
-		host       string
-		port       uint16
+		opts     fuzzOptions
+		soakOpts soakOptions
+		host     string
+		port     uint16
 	)
 
 	cmd := &cobra.Command{
@@ -640,19 +910,40 @@ func newCommand() *cobra.Command {
 				return err
 			}
 
-			return fuzzTest(ctx, client, projects, iterations)
+			if soakOpts.duration > 0 {
+				return soakTest(ctx, client, opts.projects, opts.seed, soakOpts)
+			}
+			return fuzzTest(ctx, client, opts)
 		},
 	}
 
 	flags := cmd.PersistentFlags()
-	flags.IntVar(&projects, "projects", 5, "How many projects to create")
-	flags.IntVar(&iterations, "iterations", 1000, "How many FS operations to apply")
+	flags.IntVar(&opts.projects, "projects", 5, "How many projects to create, ignored with --resume-from")
+	flags.IntVar(&opts.iterations, "iterations", 1000, "How many random FS operations to apply, after any resumed ones")
+	flags.Int64Var(&opts.seed, "seed", 0, "Seed of the random operations, a random seed is picked and logged when 0, ignored with --resume-from")
+	flags.StringVar(&opts.resumeFrom, "resume-from", "", "State directory to replay the saved operations of before any random ones")
+	flags.StringVar(&opts.saveTo, "save-to", "", "Directory to save the state to at the end of the run, failed runs save to a temporary directory when unset")
+	flags.BoolVar(&opts.minimize, "minimize", true, "Shrink the operations of a failed run to a minimal failing sequence")
+	flags.DurationVar(&soakOpts.duration, "duration", 0, "Run in soak mode for this long, counting errors and divergences instead of stopping at the first one. The projects are deleted and recreated.")
+	flags.StringVar(&soakOpts.metricsAddr, "metrics-addr", "", "Address serving the soak metrics to Prometheus at /metrics")
+	flags.StringVar(&soakOpts.pushgateway, "pushgateway", "", "URL of a Prometheus pushgateway to push the soak metrics to")
+	flags.DurationVar(&soakOpts.pushInterval, "push-interval", 30*time.Second, "How often the soak metrics are pushed")
+	flags.StringVar(&soakOpts.job, "job", "dateilager_fuzz", "Job label of the pushed soak metrics")
+	flags.StringVar(&soakOpts.instance, "instance", hostname(), "Instance label of the pushed soak metrics")
 	flags.StringVar(&host, "host", "", "GRPC server hostname")
 	flags.Uint16Var(&port, "port", 5051, "GRPC server port")
 
 	return cmd
 }
 
+func hostname() string {
+	name, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	return name
+}
+
 func main() {
 	ctx := context.Background()
 	cmd := newCommand()
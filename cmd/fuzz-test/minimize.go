@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gadget-inc/dateilager/internal/logger"
+	dlc "github.com/gadget-inc/dateilager/pkg/client"
+	"go.uber.org/zap"
+)
+
+type outcome int
+
+const (
+	outcomePassed outcome = iota
+	outcomeFailed
+	// outcomeInvalid is a sequence that cannot be applied, an operation depends on one that was dropped
+	outcomeInvalid
+)
+
+// replay runs the operations against freshly created projects, deleting whatever the projects held before.
+func replay(ctx context.Context, client *dlc.Client, seed int64, projects int, operations []SavedOp) (outcome, error) {
+	for projectIdx := 1; projectIdx <= projects; projectIdx++ {
+		err := client.DeleteProject(ctx, int64(projectIdx))
+		if err != nil {
+			return outcomeInvalid, err
+		}
+
+		pattern := packPatterns
+		err = client.NewProject(ctx, int64(projectIdx), nil, &pattern)
+		if err != nil {
+			return outcomeInvalid, err
+		}
+	}
+
+	dirs, err := createDirs(projects)
+	if err != nil {
+		return outcomeInvalid, err
+	}
+	defer dirs.RemoveAll()
+
+	stepRng = rand.New(rand.NewSource(seed))
+
+	for _, saved := range operations {
+		operation, err := loadOperation(dirs.base, saved)
+		if err != nil {
+			return outcomeInvalid, err
+		}
+
+		stepVersion, err := runIteration(ctx, client, saved.Project, operation, dirs)
+		var applyErr ApplyError
+		if errors.As(err, &applyErr) {
+			return outcomeInvalid, nil
+		}
+		if err != nil {
+			return outcomeFailed, nil
+		}
+
+		if verifyDirs(ctx, projects, dirs, stepVersion) != nil {
+			return outcomeFailed, nil
+		}
+	}
+
+	return outcomePassed, nil
+}
+
+// minimize drops chunks of operations, halving the chunk size whenever no chunk can be dropped, for as long as the
+// remaining operations still fail.
+func minimize(ctx context.Context, client *dlc.Client, seed int64, projects int, operations []SavedOp) ([]SavedOp, error) {
+	result, err := replay(ctx, client, seed, projects, operations)
+	if err != nil {
+		return nil, err
+	}
+	if result != outcomeFailed {
+		return nil, errors.New("failure does not reproduce against fresh projects")
+	}
+
+	for chunk := len(operations) / 2; chunk >= 1; {
+		dropped := false
+
+		for start := 0; start < len(operations); {
+			candidate := slices.Concat(operations[:start], operations[min(start+chunk, len(operations)):])
+
+			result, err := replay(ctx, client, seed, projects, candidate)
+			if err != nil {
+				return nil, err
+			}
+
+			if result == outcomeFailed {
+				operations = candidate
+				dropped = true
+				logger.Info(ctx, "minimized operations", zap.Int("count", len(operations)))
+				continue
+			}
+			start += chunk
+		}
+
+		if !dropped {
+			chunk /= 2
+		}
+		chunk = min(chunk, len(operations)/2)
+	}
+
+	return operations, nil
+}
+
+// minimizeFailure shrinks the operations of a failed run and saves them with a Go test case skeleton reproducing them
+// into the minimized directory of the saved state.
+func minimizeFailure(ctx context.Context, client *dlc.Client, state *State, stateDir string) (string, error) {
+	logger.Info(ctx, "minimizing failure", zap.Int("operations", len(state.Operations)))
+
+	operations, err := minimize(ctx, client, state.Seed, state.Projects, state.Operations)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(stateDir, "minimized")
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", fmt.Errorf("cannot create minimized dir: %w", err)
+	}
+
+	err = writeStateFile(&State{Seed: state.Seed, Projects: state.Projects, Operations: operations}, dir)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "regression_test.go"), []byte(testSkeleton(operations)), 0644)
+	if err != nil {
+		return "", fmt.Errorf("cannot write regression test: %w", err)
+	}
+
+	return dir, nil
+}
+
+// testSkeleton renders the operations as a test of the test package: each operation is applied to a directory of its
+// project and written with an update, the checks failed by the fuzz tester are left to fill in.
+func testSkeleton(operations []SavedOp) string {
+	var projects []int64
+	versions := make(map[int64]int64)
+	for _, operation := range operations {
+		if _, ok := versions[operation.Project]; !ok {
+			projects = append(projects, operation.Project)
+		}
+		versions[operation.Project] = 0
+	}
+	slices.Sort(projects)
+
+	var b strings.Builder
+	w := func(format string, args ...any) {
+		fmt.Fprintf(&b, format, args...)
+		b.WriteString("\n")
+	}
+
+	w("package test")
+	w("")
+	w("import (")
+	w("\t\"os\"")
+	w("\t\"path/filepath\"")
+	w("\t\"testing\"")
+	w("")
+	w("\t\"github.com/gadget-inc/dateilager/internal/auth\"")
+	w("\tutil \"github.com/gadget-inc/dateilager/internal/testutil\"")
+	w("\t\"github.com/gadget-inc/dateilager/pkg/client\"")
+	w("\t\"github.com/stretchr/testify/require\"")
+	w(")")
+	w("")
+	w("// Generated by the fuzz tester from a minimized failing sequence of %d operations.", len(operations))
+	w("func TestFuzzRegression(t *testing.T) {")
+	w("\ttc := util.NewTestCtx(t, auth.Admin)")
+	w("\tdefer tc.Close()")
+	w("")
+	w("\tc, _, close := createTestClient(tc)")
+	w("\tdefer close()")
+	w("")
+
+	patterns := strings.Split(packPatterns, ",")
+	for _, project := range projects {
+		w("\twriteProject(tc, %d, 0, %q, %q)", project, patterns[0], patterns[1])
+		w("\tdir%d := emptyTmpDir(t)", project)
+		w("\tdefer os.RemoveAll(dir%d)", project)
+		w("")
+	}
+
+	w("\tvar err error")
+	for _, operation := range operations {
+		dir := fmt.Sprintf("dir%d", operation.Project)
+		path := Join(trimDir(operation.Dir, fmt.Sprint(operation.Project)), operation.Name)
+
+		w("")
+		switch operation.Kind {
+		case "add-file", "update-file":
+			w("\twriteFile(t, %s, %q, %q)", dir, path, operation.Content)
+		case "add-dir":
+			w("\trequire.NoError(t, os.MkdirAll(filepath.Join(%s, %q), 0755))", dir, path)
+		case "remove-file":
+			w("\trequire.NoError(t, os.Remove(filepath.Join(%s, %q)))", dir, path)
+		case "add-symlink":
+			target := Join(trimDir(operation.Dir, fmt.Sprint(operation.Project)), operation.Target)
+			w("\trequire.NoError(t, os.Symlink(filepath.Join(%s, %q), filepath.Join(%s, %q)))", dir, target, dir, path)
+		}
+
+		versions[operation.Project] += 1
+		w("\t_, _, err = c.Update(tc.Context(), %d, %s)", operation.Project, dir)
+		w("\trequire.NoError(t, err, %q)", fmt.Sprintf("update project %d to version %d", operation.Project, versions[operation.Project]))
+	}
+
+	for _, project := range projects {
+		w("")
+		w("\trebuilt%d := emptyTmpDir(t)", project)
+		w("\tdefer os.RemoveAll(rebuilt%d)", project)
+		w("\tresult%d, err := c.Rebuild(tc.Context(), %d, \"\", nil, rebuilt%d, nil, \"\", nil, true)", project, project, project)
+		w("\trequire.NoError(t, err, \"client.Rebuild\")")
+		w("\trequire.Equal(t, int64(%d), result%d.Version, \"mismatch rebuild version\")", versions[project], project)
+
+		// the updated directory is what the fuzz tester compares rebuilds against, the .dl metadata differs between them
+		w("\trequire.NoError(t, os.RemoveAll(filepath.Join(dir%d, \".dl\")))", project)
+		w("\trequire.NoError(t, os.RemoveAll(filepath.Join(rebuilt%d, \".dl\")))", project)
+		w("\trequire.NoError(t, CompareDirectories(dir%d, rebuilt%d), \"rebuilt project %d differs from its updated directory\")", project, project, project)
+	}
+
+	w("}")
+	return b.String()
+}
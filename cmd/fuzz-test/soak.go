@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/logger"
+	dlc "github.com/gadget-inc/dateilager/pkg/client"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	metricsPrefix = "dateilager_fuzz"
+	// latencyWindow is how many of the latest calls of an operation its latency percentiles are computed over
+	latencyWindow = 1000
+)
+
+var latencyQuantiles = []float64{0.5, 0.9, 0.99}
+
+// metrics is only set in soak mode, observing calls is a no-op otherwise.
+var metrics *Metrics
+
+type latencies struct {
+	window []float64
+	next   int
+	sum    float64
+	count  int64
+}
+
+func (l *latencies) add(seconds float64) {
+	if len(l.window) < latencyWindow {
+		l.window = append(l.window, seconds)
+	} else {
+		l.window[l.next] = seconds
+		l.next = (l.next + 1) % latencyWindow
+	}
+	l.sum += seconds
+	l.count += 1
+}
+
+func (l *latencies) quantile(q float64) float64 {
+	sorted := slices.Clone(l.window)
+	slices.Sort(sorted)
+	return sorted[int(q*float64(len(sorted)-1))]
+}
+
+type errorKey struct {
+	operation string
+	code      string
+}
+
+// Metrics tracks the calls made to the server and the divergences found by a soak run, and renders them in the
+// Prometheus text format.
+type Metrics struct {
+	mu          sync.Mutex
+	latencies   map[string]*latencies
+	errors      map[errorKey]int64
+	iterations  int64
+	divergences int64
+	resets      int64
+	start       time.Time
+}
+
+func newMetrics() *Metrics {
+	return &Metrics{
+		latencies: make(map[string]*latencies),
+		errors:    make(map[errorKey]int64),
+		start:     time.Now(),
+	}
+}
+
+func (m *Metrics) observe(operation string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l, ok := m.latencies[operation]
+	if !ok {
+		l = &latencies{}
+		m.latencies[operation] = l
+	}
+	l.add(time.Since(start).Seconds())
+
+	if err != nil {
+		m.errors[errorKey{operation: operation, code: status.Code(err).String()}] += 1
+	}
+}
+
+func (m *Metrics) count(counter *int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	*counter += 1
+}
+
+func (m *Metrics) Write(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	operations := make([]string, 0, len(m.latencies))
+	for operation := range m.latencies {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+
+	fmt.Fprintf(w, "# HELP %s_operation_duration_seconds Latency of the calls made to the server.\n", metricsPrefix)
+	fmt.Fprintf(w, "# TYPE %s_operation_duration_seconds summary\n", metricsPrefix)
+	for _, operation := range operations {
+		l := m.latencies[operation]
+		for _, q := range latencyQuantiles {
+			fmt.Fprintf(w, "%s_operation_duration_seconds{operation=%q,quantile=\"%g\"} %g\n", metricsPrefix, operation, q, l.quantile(q))
+		}
+		fmt.Fprintf(w, "%s_operation_duration_seconds_sum{operation=%q} %g\n", metricsPrefix, operation, l.sum)
+		fmt.Fprintf(w, "%s_operation_duration_seconds_count{operation=%q} %d\n", metricsPrefix, operation, l.count)
+	}
+
+	keys := make([]errorKey, 0, len(m.errors))
+	for key := range m.errors {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].operation != keys[j].operation {
+			return keys[i].operation < keys[j].operation
+		}
+		return keys[i].code < keys[j].code
+	})
+
+	fmt.Fprintf(w, "# HELP %s_operation_errors_total Calls to the server that returned an error.\n", metricsPrefix)
+	fmt.Fprintf(w, "# TYPE %s_operation_errors_total counter\n", metricsPrefix)
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s_operation_errors_total{operation=%q,code=%q} %d\n", metricsPrefix, key.operation, key.code, m.errors[key])
+	}
+
+	fmt.Fprintf(w, "# HELP %s_iterations_total Operations applied and written to the server.\n", metricsPrefix)
+	fmt.Fprintf(w, "# TYPE %s_iterations_total counter\n", metricsPrefix)
+	fmt.Fprintf(w, "%s_iterations_total %d\n", metricsPrefix, m.iterations)
+
+	fmt.Fprintf(w, "# HELP %s_divergences_total Rebuilt directories that did not match the written ones.\n", metricsPrefix)
+	fmt.Fprintf(w, "# TYPE %s_divergences_total counter\n", metricsPrefix)
+	fmt.Fprintf(w, "%s_divergences_total %d\n", metricsPrefix, m.divergences)
+
+	fmt.Fprintf(w, "# HELP %s_resets_total Times the projects were recreated after an error or a divergence.\n", metricsPrefix)
+	fmt.Fprintf(w, "# TYPE %s_resets_total counter\n", metricsPrefix)
+	fmt.Fprintf(w, "%s_resets_total %d\n", metricsPrefix, m.resets)
+
+	fmt.Fprintf(w, "# HELP %s_start_time_seconds Start of the soak run since the unix epoch.\n", metricsPrefix)
+	fmt.Fprintf(w, "# TYPE %s_start_time_seconds gauge\n", metricsPrefix)
+	fmt.Fprintf(w, "%s_start_time_seconds %d\n", metricsPrefix, m.start.Unix())
+}
+
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	m.Write(w)
+}
+
+// push replaces the metrics of the job and instance grouping on a Prometheus pushgateway.
+func (m *Metrics) push(ctx context.Context, gateway, job, instance string) error {
+	var body bytes.Buffer
+	m.Write(&body)
+
+	url := fmt.Sprintf("%s/metrics/job/%s/instance/%s", gateway, job, instance)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, url, &body)
+	if err != nil {
+		return fmt.Errorf("cannot create push request: %w", err)
+	}
+	request.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("cannot push metrics to %s: %w", gateway, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("cannot push metrics to %s: %s", gateway, response.Status)
+	}
+	return nil
+}
+
+type soakOptions struct {
+	duration     time.Duration
+	metricsAddr  string
+	pushgateway  string
+	pushInterval time.Duration
+	job          string
+	instance     string
+}
+
+// resetProjects deletes and recreates every project on the server along with its local directories, so the run can go
+// on from a known state after an error or a divergence.
+func resetProjects(ctx context.Context, client *dlc.Client, projects int, dirs *Directories) error {
+	for projectIdx := 1; projectIdx <= projects; projectIdx++ {
+		project := int64(projectIdx)
+
+		err := client.DeleteProject(ctx, project)
+		if err != nil {
+			return err
+		}
+
+		pattern := packPatterns
+		err = client.NewProject(ctx, project, nil, &pattern)
+		if err != nil {
+			return err
+		}
+
+		for _, dir := range []string{dirs.Base(project), dirs.Reset(project), dirs.OneStep(project), dirs.RandomStep(project)} {
+			err = os.RemoveAll(dir)
+			if err == nil {
+				err = os.MkdirAll(dir, 0755)
+			}
+			if err != nil {
+				return fmt.Errorf("cannot reset project dir %s: %w", dir, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// soakTest applies random operations until the duration is over. Unlike a regular run errors and divergences do not
+// stop it: they are counted, the projects are recreated and the run goes on. It fails at the end if any divergence
+// was found, so it can gate a release as a canary.
+func soakTest(ctx context.Context, client *dlc.Client, projects int, seed int64, opts soakOptions) error {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng = rand.New(rand.NewSource(seed))
+	stepRng = rand.New(rand.NewSource(seed))
+
+	metrics = newMetrics()
+
+	logger.Info(ctx, "starting soak test", zap.Int("projects", projects), zap.Duration("duration", opts.duration), zap.Int64("seed", seed))
+
+	if opts.metricsAddr != "" {
+		server := &http.Server{Addr: opts.metricsAddr, Handler: http.HandlerFunc(metrics.ServeHTTP)}
+		go func() {
+			err := server.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Info(ctx, "metrics server failed", zap.Error(err))
+			}
+		}()
+		defer server.Close()
+	}
+
+	if opts.pushgateway != "" {
+		pushCtx, stopPushing := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			ticker := time.NewTicker(opts.pushInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-pushCtx.Done():
+					return
+				case <-ticker.C:
+				}
+
+				err := metrics.push(pushCtx, opts.pushgateway, opts.job, opts.instance)
+				if err != nil {
+					logger.Info(ctx, "failed to push metrics", zap.Error(err))
+				}
+			}
+		}()
+
+		defer func() {
+			stopPushing()
+			<-done
+			err := metrics.push(ctx, opts.pushgateway, opts.job, opts.instance)
+			if err != nil {
+				logger.Info(ctx, "failed to push metrics", zap.Error(err))
+			}
+		}()
+	}
+
+	dirs, err := createDirs(projects)
+	if err != nil {
+		return err
+	}
+	defer dirs.RemoveAll()
+
+	err = resetProjects(ctx, client, projects, dirs)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(opts.duration)
+	for time.Now().Before(deadline) && ctx.Err() == nil {
+		project := int64(rng.Intn(projects) + 1)
+		operation := randomOperation(dirs.base, project)
+
+		stepVersion, err := runIteration(ctx, client, project, operation, dirs)
+		metrics.count(&metrics.iterations)
+
+		if err == nil {
+			err = verifyDirs(ctx, projects, dirs, stepVersion)
+			if err != nil {
+				metrics.count(&metrics.divergences)
+				logger.Info(ctx, "divergence", zap.String("op", operation.String()), zap.Error(err))
+			}
+		} else {
+			logger.Info(ctx, "iteration failed", zap.String("op", operation.String()), zap.Error(err))
+		}
+
+		if err != nil {
+			metrics.count(&metrics.resets)
+			err = resetProjects(ctx, client, projects, dirs)
+			if err != nil {
+				// the server is likely unreachable, give it a moment before the next iteration
+				logger.Info(ctx, "failed to reset projects", zap.Error(err))
+				time.Sleep(time.Second)
+			}
+		}
+	}
+
+	var summary bytes.Buffer
+	metrics.Write(&summary)
+	logger.Info(ctx, "soak test metrics\n"+summary.String())
+
+	if metrics.divergences > 0 {
+		return fmt.Errorf("soak test found %d divergences", metrics.divergences)
+	}
+	return nil
+}
@@ -69,7 +69,7 @@ main() {
 
     if [[ "${RUN_MIGRATIONS:-0}" == "1" ]]; then
         log "run migrations"
-        migrate -path "${HOME}/migrations" -database "${appdb}?sslmode=disable" up
+        "${HOME}/server" migrate --dburi "${appdb}?sslmode=disable"
     fi
 
     local log_level="${DL_LOG_LEVEL:-info}"
@@ -3,8 +3,10 @@ package auth
 import (
 	"context"
 	"crypto/ed25519"
+	"errors"
 	"fmt"
 	"strconv"
+	"sync/atomic"
 
 	"github.com/o1egl/paseto"
 )
@@ -13,6 +15,9 @@ type ctxKey string
 
 const (
 	AuthCtxKey = ctxKey("auth")
+
+	// TenantClaim is the Paseto claim scoping admin and project tokens to a tenant
+	TenantClaim = "tenant"
 )
 
 type Role int
@@ -27,6 +32,8 @@ const (
 type Auth struct {
 	Role    Role
 	Project *int64
+	// Tenant scopes the token to the projects of one tenant, nil for tokens that can reach every project
+	Tenant *string
 }
 
 func (a Auth) String() string {
@@ -34,8 +41,14 @@ func (a Auth) String() string {
 	case None:
 		return "none"
 	case Project:
+		if a.Tenant != nil {
+			return fmt.Sprintf("project[%d]@%s", *a.Project, *a.Tenant)
+		}
 		return fmt.Sprintf("project[%d]", *a.Project)
 	case Admin:
+		if a.Tenant != nil {
+			return fmt.Sprintf("admin@%s", *a.Tenant)
+		}
 		return "admin"
 	case SharedReader:
 		return "sharedReader"
@@ -51,13 +64,19 @@ var (
 )
 
 type AuthValidator struct {
-	pasetoKey ed25519.PublicKey
+	pasetoKeys atomic.Pointer[[]ed25519.PublicKey]
 }
 
-func NewAuthValidator(pasetoKey ed25519.PublicKey) *AuthValidator {
-	return &AuthValidator{
-		pasetoKey: pasetoKey,
-	}
+// NewAuthValidator accepts tokens signed by any of pasetoKeys, several keys are trusted while a key is being rotated.
+func NewAuthValidator(pasetoKeys ...ed25519.PublicKey) *AuthValidator {
+	av := &AuthValidator{}
+	av.SetKeys(pasetoKeys...)
+	return av
+}
+
+// SetKeys replaces the trusted keys, tokens being validated concurrently use either the old or the new keys.
+func (av *AuthValidator) SetKeys(pasetoKeys ...ed25519.PublicKey) {
+	av.pasetoKeys.Store(&pasetoKeys)
 }
 
 func (av *AuthValidator) Validate(ctx context.Context, token string) (Auth, error) {
@@ -66,12 +85,34 @@ func (av *AuthValidator) Validate(ctx context.Context, token string) (Auth, erro
 
 	v2 := paseto.NewV2()
 
-	err := v2.Verify(token, av.pasetoKey, &payload, &footer)
-	if err != nil {
-		return noAuth, fmt.Errorf("verify token %v: %w", token, err)
+	pasetoKeys := *av.pasetoKeys.Load()
+	if len(pasetoKeys) == 0 {
+		return noAuth, fmt.Errorf("verify token %v: no Paseto key configured", token)
+	}
+
+	// a token rejected by every key reports why each one rejected it
+	var errs []error
+	for idx, pasetoKey := range pasetoKeys {
+		err := v2.Verify(token, pasetoKey, &payload, &footer)
+		if err == nil {
+			errs = nil
+			break
+		}
+		errs = append(errs, fmt.Errorf("key %d: %w", idx, err))
+	}
+	if len(errs) > 0 {
+		return noAuth, fmt.Errorf("verify token %v: %w", token, errors.Join(errs...))
+	}
+
+	var tenant *string
+	if claim := payload.Get(TenantClaim); claim != "" {
+		tenant = &claim
 	}
 
 	if payload.Subject == "admin" {
+		if tenant != nil {
+			return Auth{Role: Admin, Tenant: tenant}, nil
+		}
 		return adminAuth, nil
 	}
 
@@ -87,5 +128,6 @@ func (av *AuthValidator) Validate(ctx context.Context, token string) (Auth, erro
 	return Auth{
 		Role:    Project,
 		Project: &project,
+		Tenant:  tenant,
 	}, nil
 }
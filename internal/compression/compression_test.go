@@ -0,0 +1,85 @@
+package compression
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/protobuf/proto"
+)
+
+// smallFilesResponse mimics a GetUnary response of a source tree: many small, similar text files
+func smallFilesResponse(tb testing.TB, count int) []byte {
+	response := &pb.GetUnaryResponse{}
+	for idx := 0; idx < count; idx++ {
+		content := []byte(fmt.Sprintf("import { helper%d } from \"../lib/helper%d\";\n\nexport default function handler%d(input) {\n  return helper%d(input, { retries: 3 });\n}\n", idx, idx%17, idx, idx))
+		response.Objects = append(response.Objects, &pb.Objekt{
+			Path:    fmt.Sprintf("app/routes/nested/route-%d.js", idx),
+			Mode:    0o644,
+			Size:    int64(len(content)),
+			Content: content,
+		})
+	}
+
+	encoded, err := proto.Marshal(response)
+	require.NoError(tb, err)
+	return encoded
+}
+
+func roundTrip(tb testing.TB, compressor encoding.Compressor, message []byte) int {
+	var compressed bytes.Buffer
+	writer, err := compressor.Compress(&compressed)
+	require.NoError(tb, err)
+	_, err = writer.Write(message)
+	require.NoError(tb, err)
+	require.NoError(tb, writer.Close())
+	size := compressed.Len()
+
+	reader, err := compressor.Decompress(&compressed)
+	require.NoError(tb, err)
+	decompressed, err := io.ReadAll(reader)
+	require.NoError(tb, err)
+	assert.Equal(tb, message, decompressed)
+
+	return size
+}
+
+func TestRegisteredCompressors(t *testing.T) {
+	message := smallFilesResponse(t, 500)
+
+	for _, name := range []string{Gzip, Zstd} {
+		compressor := encoding.GetCompressor(name)
+		require.NotNil(t, compressor, "%s should be registered", name)
+
+		// pooled encoders and decoders must be reusable
+		for attempt := 0; attempt < 3; attempt++ {
+			size := roundTrip(t, compressor, message)
+			assert.Less(t, size, len(message)/4, "%s should shrink small source files", name)
+		}
+	}
+}
+
+// BenchmarkCompressors compares the CPU time and wire size of a GetUnary response with 1000 small files,
+// run with -bench Compressors to see the ratio metric of each compressor.
+func BenchmarkCompressors(b *testing.B) {
+	message := smallFilesResponse(b, 1000)
+
+	for _, name := range []string{Gzip, Zstd} {
+		compressor := encoding.GetCompressor(name)
+
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(message)))
+			size := 0
+			for n := 0; n < b.N; n++ {
+				size = roundTrip(b, compressor, message)
+			}
+			b.ReportMetric(float64(len(message))/float64(size), "ratio")
+			b.ReportMetric(float64(size), "wire-bytes")
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/jackc/pgx/v5"
+)
+
+// AnnotateVersion records who created a project version and why, it is a no-op when both message and author are nil.
+func AnnotateVersion(ctx context.Context, tx pgx.Tx, project int64, version int64, message *string, author *string) error {
+	if message == nil && author == nil {
+		return nil
+	}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO dl.version_annotations (project, version, message, author)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (project, version)
+		DO UPDATE SET message = EXCLUDED.message, author = EXCLUDED.author
+	`, project, version, message, author)
+	if err != nil {
+		return fmt.Errorf("annotate version %v of project %v: %w", version, project, err)
+	}
+
+	return nil
+}
+
+// VersionHistory returns the annotations of a project's versions within vrange, newest first.
+// Versions created without a message or author have no entry.
+func VersionHistory(ctx context.Context, tx pgx.Tx, project int64, vrange VersionRange) ([]*pb.VersionAnnotation, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT version, message, author, created_at
+		FROM dl.version_annotations
+		WHERE project = $1
+		  AND version > $2
+		  AND version <= $3
+		ORDER BY version DESC
+	`, project, vrange.From, vrange.To)
+	if err != nil {
+		return nil, fmt.Errorf("version history query, project %v: %w", project, err)
+	}
+	defer rows.Close()
+
+	history := []*pb.VersionAnnotation{}
+
+	for rows.Next() {
+		var annotation pb.VersionAnnotation
+		var createdAt time.Time
+		err = rows.Scan(&annotation.Version, &annotation.Message, &annotation.Author, &createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("version history scan, project %v: %w", project, err)
+		}
+		annotation.CreatedAt = createdAt.UnixNano()
+		history = append(history, &annotation)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,257 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/jackc/pgx/v5"
+)
+
+// archiverLockKey identifies the advisory lock held while a server exports scheduled project archives.
+const archiverLockKey = 0x646c61726368
+
+// ArchiveCandidate is a project to archive and what its restore needs to recreate it.
+type ArchiveCandidate struct {
+	Project      int64
+	Version      int64
+	PackPatterns []string
+	Transforms   []string
+}
+
+// ArchiveRecord is an archive along with what is needed to restore or expire it.
+type ArchiveRecord struct {
+	Archive      *pb.Archive
+	Name         string
+	PackPatterns []string
+	Transforms   []string
+}
+
+// TryLockArchiver takes a transaction scoped advisory lock so only one server replica exports archives at a time.
+func TryLockArchiver(ctx context.Context, tx pgx.Tx) (bool, error) {
+	var locked bool
+	err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, archiverLockKey).Scan(&locked)
+	if err != nil {
+		return false, fmt.Errorf("TryLockArchiver query: %w", err)
+	}
+
+	return locked, nil
+}
+
+// ArchiveCandidates returns the projects having every label of selector that were not archived since since.
+func ArchiveCandidates(ctx context.Context, tx pgx.Tx, selector map[string]string, since time.Time) ([]ArchiveCandidate, error) {
+	if selector == nil {
+		selector = map[string]string{}
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT p.id, p.latest_version, coalesce(p.pack_patterns, '{}'), coalesce(p.transforms, '{}')
+		FROM dl.projects p
+		WHERE p.labels @> $1::jsonb
+		  AND NOT EXISTS (
+		    SELECT 1
+		    FROM dl.archives a
+		    WHERE a.project = p.id
+		      AND a.created_at >= $2
+		  )
+		ORDER BY p.id
+	`, selector, since)
+	if err != nil {
+		return nil, fmt.Errorf("archive candidates query: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []ArchiveCandidate
+	for rows.Next() {
+		var candidate ArchiveCandidate
+		err = rows.Scan(&candidate.Project, &candidate.Version, &candidate.PackPatterns, &candidate.Transforms)
+		if err != nil {
+			return nil, fmt.Errorf("archive candidates scan: %w", err)
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return candidates, nil
+}
+
+// WriteArchive streams the canonical s2 compressed TAR of every object of project at version into w, the format packs
+// use, and returns the number of objects in it.
+func WriteArchive(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, project int64, version int64, w io.Writer) (int64, error) {
+	packManager, err := NewPackManager(ctx, tx, project)
+	if err != nil {
+		return 0, err
+	}
+
+	objects, err := GetObjects(ctx, tx, lookup, packManager, project, VersionRange{From: 0, To: version}, &pb.ObjectQuery{Path: "", IsPrefix: true}, 0)
+	if err != nil {
+		return 0, fmt.Errorf("write archive, project %v version %v: %w", project, version, err)
+	}
+
+	tarWriter := NewTarWriterTo(w)
+	defer tarWriter.Close()
+
+	count := int64(0)
+	for {
+		object, err := objects()
+		if err == SKIP {
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("write archive, project %v version %v: %w", project, version, err)
+		}
+
+		tarObject := NewUncachedTarObject(object.Path, object.Mode, object.Size, false, object.Content)
+		tarObject.mtime = object.Mtime
+		err = tarWriter.WriteObject(&tarObject)
+		if err != nil {
+			return 0, fmt.Errorf("write archive, project %v version %v: %w", project, version, err)
+		}
+		count += 1
+	}
+
+	err = tarWriter.Finish()
+	if err != nil {
+		return 0, fmt.Errorf("write archive, project %v version %v: %w", project, version, err)
+	}
+
+	return count, nil
+}
+
+// ReadArchive returns the objects of an archive written by WriteArchive.
+func ReadArchive(content []byte) ([]*pb.Object, error) {
+	tarReader := NewTarReader()
+	defer tarReader.Close()
+
+	tarReader.FromBytes(content)
+	return unpackObjects(tarReader)
+}
+
+// RecordArchive records an archive exported to cold storage and returns its id.
+func RecordArchive(ctx context.Context, tx pgx.Tx, candidate ArchiveCandidate, name string, location string, size int64, objects int64, expiresAt *time.Time) (int64, error) {
+	var id int64
+	err := tx.QueryRow(ctx, `
+		INSERT INTO dl.archives (project, version, name, location, size, objects, pack_patterns, transforms, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`, candidate.Project, candidate.Version, name, location, size, objects, candidate.PackPatterns, candidate.Transforms, expiresAt).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("record archive, project %v version %v: %w", candidate.Project, candidate.Version, err)
+	}
+
+	return id, nil
+}
+
+const archiveColumns = `id, project, version, location, size, objects, created_at, expires_at, name, pack_patterns, transforms`
+
+func scanArchive(row pgx.Row) (ArchiveRecord, error) {
+	record := ArchiveRecord{Archive: &pb.Archive{}}
+
+	var createdAt time.Time
+	var expiresAt *time.Time
+	err := row.Scan(&record.Archive.Id, &record.Archive.Project, &record.Archive.Version, &record.Archive.Location, &record.Archive.Size,
+		&record.Archive.Objects, &createdAt, &expiresAt, &record.Name, &record.PackPatterns, &record.Transforms)
+	if err != nil {
+		return record, err
+	}
+
+	record.Archive.CreatedAt = createdAt.UnixNano()
+	if expiresAt != nil {
+		expires := expiresAt.UnixNano()
+		record.Archive.ExpiresAt = &expires
+	}
+
+	return record, nil
+}
+
+func queryArchives(ctx context.Context, tx pgx.Tx, query string, args ...any) ([]ArchiveRecord, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("archives query: %w", err)
+	}
+	defer rows.Close()
+
+	var records []ArchiveRecord
+	for rows.Next() {
+		record, err := scanArchive(rows)
+		if err != nil {
+			return nil, fmt.Errorf("archives scan: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return records, nil
+}
+
+// ListArchives returns the archives of project, or of every project when it is nil, newest first.
+func ListArchives(ctx context.Context, tx pgx.Tx, project *int64) ([]*pb.Archive, error) {
+	records, err := queryArchives(ctx, tx, `
+		SELECT `+archiveColumns+`
+		FROM dl.archives
+		WHERE $1::bigint IS NULL OR project = $1
+		ORDER BY created_at DESC, id DESC
+	`, project)
+	if err != nil {
+		return nil, err
+	}
+
+	archives := make([]*pb.Archive, 0, len(records))
+	for _, record := range records {
+		archives = append(archives, record.Archive)
+	}
+
+	return archives, nil
+}
+
+func GetArchive(ctx context.Context, tx pgx.Tx, id int64) (ArchiveRecord, error) {
+	record, err := scanArchive(tx.QueryRow(ctx, `
+		SELECT `+archiveColumns+`
+		FROM dl.archives
+		WHERE id = $1
+	`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return record, fmt.Errorf("get archive %v: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return record, fmt.Errorf("get archive %v: %w", id, err)
+	}
+
+	return record, nil
+}
+
+// ExpiredArchives returns the archives whose retention ended before now.
+func ExpiredArchives(ctx context.Context, tx pgx.Tx, now time.Time) ([]ArchiveRecord, error) {
+	return queryArchives(ctx, tx, `
+		SELECT `+archiveColumns+`
+		FROM dl.archives
+		WHERE expires_at < $1
+		ORDER BY id
+	`, now)
+}
+
+func DeleteArchive(ctx context.Context, tx pgx.Tx, id int64) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM dl.archives
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("delete archive %v: %w", id, err)
+	}
+
+	return nil
+}
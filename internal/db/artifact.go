@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/jackc/pgx/v5"
+)
+
+// BuildCheckoutArtifact stores the full GetCompress response set of a project version so identical checkouts can be
+// served without querying and packing objects again. Artifacts of older versions are dropped.
+func BuildCheckoutArtifact(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, project int64, version int64) (int, error) {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM dl.checkout_artifacts
+		WHERE project = $1
+	`, project)
+	if err != nil {
+		return 0, fmt.Errorf("delete checkout artifacts for %v: %w", project, err)
+	}
+
+	query := &pb.ObjectQuery{
+		Path:     "",
+		IsPrefix: true,
+	}
+
+	tars, err := GetTars(ctx, tx, lookup, project, nil, nil, nil, VersionRange{From: 0, To: version}, query, 0, nil, nil, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	idx := 0
+	for {
+		tar, packPath, err := tars()
+		if err == io.EOF {
+			break
+		}
+		if err == SKIP {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO dl.checkout_artifacts (project, version, idx, bytes, pack_path)
+			VALUES ($1, $2, $3, $4, $5)
+		`, project, version, idx, tar, packPath)
+		if err != nil {
+			return 0, fmt.Errorf("insert checkout artifact for %v version %v: %w", project, version, err)
+		}
+
+		idx += 1
+	}
+
+	return idx, nil
+}
+
+// GetCheckoutArtifact streams a precomputed checkout in the same order it was built, it returns ErrNotFound if there is none.
+func GetCheckoutArtifact(ctx context.Context, tx pgx.Tx, project int64, version int64) (tarStream, CloseFunc, error) {
+	var exists bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM dl.checkout_artifacts
+			WHERE project = $1
+			  AND version = $2
+		)
+	`, project, version).Scan(&exists)
+	if err != nil {
+		return nil, func(_ context.Context) {}, fmt.Errorf("checkout artifact exists for %v version %v: %w", project, version, err)
+	}
+	if !exists {
+		return nil, func(_ context.Context) {}, fmt.Errorf("checkout artifact for %v version %v: %w", project, version, ErrNotFound)
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT bytes, pack_path
+		FROM dl.checkout_artifacts
+		WHERE project = $1
+		  AND version = $2
+		ORDER BY idx
+	`, project, version)
+	closeFunc := func(_ context.Context) { rows.Close() }
+	if err != nil {
+		return nil, closeFunc, fmt.Errorf("GetCheckoutArtifact query: %w", err)
+	}
+
+	return func() ([]byte, *string, error) {
+		if !rows.Next() {
+			err := rows.Err()
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to iterate rows: %w", err)
+			}
+			return nil, nil, io.EOF
+		}
+
+		var tar []byte
+		var packPath *string
+
+		err := rows.Scan(&tar, &packPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("GetCheckoutArtifact scan: %w", err)
+		}
+
+		return tar, packPath, nil
+	}, closeFunc, nil
+}
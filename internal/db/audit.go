@@ -0,0 +1,426 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Kinds of inconsistencies reported by AuditContents.
+const (
+	AuditMissingContent  = "missing_content"
+	AuditOrphanedContent = "orphaned_content"
+	AuditSizeMismatch    = "size_mismatch"
+	AuditHashMismatch    = "hash_mismatch"
+	AuditRefsMismatch    = "refs_mismatch"
+)
+
+// Actions AuditContents took about an inconsistency, findings left as they are have none.
+const (
+	AuditRecounted   = "recounted"
+	AuditResized     = "resized"
+	AuditRestored    = "restored"
+	AuditQuarantined = "quarantined"
+	AuditCollected   = "collected"
+)
+
+// AuditFinding is an inconsistency between dl.objects and dl.contents. Findings about a row name its project, path and
+// start version, findings about a stored content do not. Expected and Actual hold the compared sizes or reference counts.
+type AuditFinding struct {
+	Kind         string
+	Hash         Hash
+	Project      *int64
+	Path         *string
+	StartVersion *int64
+	Expected     int64
+	Actual       int64
+	Action       string
+}
+
+type AuditOptions struct {
+	// fix what can be fixed from the stored data: reference counts, object sizes, contents missing but stored inline
+	// elsewhere, and collect orphaned contents
+	Repair bool
+	// move orphaned and corrupted contents to dl.quarantined_contents rather than collecting or keeping them
+	Quarantine bool
+}
+
+// AuditBatch is the result of auditing the contents following a cursor. Last is the cursor of the next batch, nil once
+// every content was audited. Collect lists the orphaned contents to remove with GcContentHashes once tx is committed.
+type AuditBatch struct {
+	Contents int64
+	Objects  int64
+	Findings []AuditFinding
+	Last     *Hash
+	Collect  []Hash
+}
+
+type auditedContent struct {
+	hash    Hash
+	length  int64
+	corrupt bool
+}
+
+type auditedObject struct {
+	project      int64
+	path         string
+	startVersion int64
+	live         bool
+	hash         Hash
+	size         int64
+	inline       []byte
+}
+
+func (o *auditedObject) finding(kind string, expected, actual int64) AuditFinding {
+	return AuditFinding{
+		Kind:         kind,
+		Hash:         o.hash,
+		Project:      &o.project,
+		Path:         &o.path,
+		StartVersion: &o.startVersion,
+		Expected:     expected,
+		Actual:       actual,
+	}
+}
+
+// AuditContents checks the next limit contents after the after hash, and every object row with a hash in the same
+// range, for inconsistencies: live objects without content, contents nothing reads, sizes or hashes not matching the
+// stored bytes and tracked references not matching the rows. Walking every batch audits the whole database.
+func AuditContents(ctx context.Context, tx pgx.Tx, decoder *ContentDecoder, encoder *ContentEncoder, after *Hash, limit int64, options AuditOptions) (AuditBatch, error) {
+	var batch AuditBatch
+
+	if options.Repair {
+		// the reference counts must not move under a repair
+		err := LockContentRefs(ctx, tx)
+		if err != nil {
+			return batch, err
+		}
+	}
+
+	var cursor Hash
+	if after != nil {
+		cursor = *after
+	}
+
+	contents, err := selectAuditedContents(ctx, tx, decoder, after == nil, cursor, limit)
+	if err != nil {
+		return batch, err
+	}
+	batch.Contents = int64(len(contents))
+
+	var upper *Hash
+	if int64(len(contents)) == limit {
+		upper = &contents[len(contents)-1].hash
+		batch.Last = upper
+	}
+
+	objects, err := selectAuditedObjects(ctx, tx, after == nil, cursor, upper)
+	if err != nil {
+		return batch, err
+	}
+	batch.Objects = int64(len(objects))
+
+	hashes := make([]Hash, 0, len(contents))
+	stored := make(map[Hash]*auditedContent, len(contents))
+	for idx := range contents {
+		hashes = append(hashes, contents[idx].hash)
+		stored[contents[idx].hash] = &contents[idx]
+	}
+
+	checks, err := CheckContentRefs(ctx, tx, hashes)
+	if err != nil {
+		return batch, err
+	}
+
+	referenced, err := selectOtherContentReferences(ctx, tx, hashes)
+	if err != nil {
+		return batch, err
+	}
+
+	var recount []Hash
+	var quarantine []AuditFinding
+	checkedOrphans := make(map[Hash]bool)
+
+	for _, check := range checks {
+		if check.Actual == 0 && !referenced[check.Hash] {
+			checkedOrphans[check.Hash] = true
+			finding := AuditFinding{Kind: AuditOrphanedContent, Hash: check.Hash}
+			if options.Quarantine {
+				quarantine = append(quarantine, finding)
+				continue
+			}
+			if options.Repair {
+				finding.Action = AuditCollected
+				batch.Collect = append(batch.Collect, check.Hash)
+			}
+			batch.Findings = append(batch.Findings, finding)
+		} else if !check.Consistent() {
+			finding := AuditFinding{Kind: AuditRefsMismatch, Hash: check.Hash, Expected: check.Actual, Actual: *check.Refs + check.Pending}
+			if options.Repair {
+				finding.Action = AuditRecounted
+				recount = append(recount, check.Hash)
+			}
+			batch.Findings = append(batch.Findings, finding)
+		}
+	}
+
+	for _, content := range contents {
+		// a corrupted orphan is quarantined once, as an orphan
+		if !content.corrupt || (checkedOrphans[content.hash] && options.Quarantine) {
+			continue
+		}
+		finding := AuditFinding{Kind: AuditHashMismatch, Hash: content.hash}
+		if options.Quarantine {
+			quarantine = append(quarantine, finding)
+			continue
+		}
+		batch.Findings = append(batch.Findings, finding)
+	}
+
+	// contents missing from dl.contents can be restored from a valid inline copy
+	inlined := make(map[Hash][]byte)
+	for idx := range objects {
+		object := &objects[idx]
+		if object.inline != nil && HashContent(object.inline) == object.hash {
+			inlined[object.hash] = object.inline
+		}
+	}
+
+	var resize []*auditedObject
+	restored := make(map[Hash]bool)
+
+	for idx := range objects {
+		object := &objects[idx]
+
+		if object.inline != nil {
+			if HashContent(object.inline) != object.hash {
+				batch.Findings = append(batch.Findings, object.finding(AuditHashMismatch, 0, 0))
+			} else if object.size != int64(len(object.inline)) {
+				finding := object.finding(AuditSizeMismatch, int64(len(object.inline)), object.size)
+				if options.Repair {
+					finding.Action = AuditResized
+					resize = append(resize, object)
+				}
+				batch.Findings = append(batch.Findings, finding)
+			}
+			continue
+		}
+
+		content, ok := stored[object.hash]
+		if !ok {
+			if !object.live {
+				continue
+			}
+			finding := object.finding(AuditMissingContent, 0, 0)
+			if _, found := inlined[object.hash]; found && options.Repair {
+				finding.Action = AuditRestored
+				restored[object.hash] = true
+			}
+			batch.Findings = append(batch.Findings, finding)
+			continue
+		}
+
+		if !content.corrupt && object.size != content.length {
+			finding := object.finding(AuditSizeMismatch, content.length, object.size)
+			if options.Repair {
+				finding.Action = AuditResized
+				resize = append(resize, object)
+			}
+			batch.Findings = append(batch.Findings, finding)
+		}
+	}
+
+	for _, finding := range quarantine {
+		_, err = tx.Exec(ctx, `
+			WITH removed AS (
+				DELETE FROM dl.contents
+				WHERE hash = ($1, $2)
+				RETURNING hash, bytes, encoding, pack_index
+			)
+			INSERT INTO dl.quarantined_contents (hash, bytes, encoding, pack_index, reason)
+			SELECT hash, bytes, encoding, pack_index, $3
+			FROM removed
+			ON CONFLICT DO NOTHING
+		`, finding.Hash.H1, finding.Hash.H2, finding.Kind)
+		if err != nil {
+			return batch, fmt.Errorf("quarantine content %v: %w", finding.Hash.Hex(), err)
+		}
+
+		_, err = tx.Exec(ctx, `
+			DELETE FROM dl.content_ref_deltas
+			WHERE hash = ($1, $2)
+		`, finding.Hash.H1, finding.Hash.H2)
+		if err != nil {
+			return batch, fmt.Errorf("quarantine content ref deltas %v: %w", finding.Hash.Hex(), err)
+		}
+
+		finding.Action = AuditQuarantined
+		batch.Findings = append(batch.Findings, finding)
+	}
+
+	_, err = RepairContentRefs(ctx, tx, recount)
+	if err != nil {
+		return batch, err
+	}
+
+	for _, object := range resize {
+		var length int64
+		if object.inline != nil {
+			length = int64(len(object.inline))
+		} else {
+			length = stored[object.hash].length
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE dl.objects
+			SET size = $1
+			WHERE project = $2
+			  AND path = $3
+			  AND start_version = $4
+		`, length, object.project, object.path, object.startVersion)
+		if err != nil {
+			return batch, fmt.Errorf("resize object, project %v, path %v, start version %v: %w", object.project, object.path, object.startVersion, err)
+		}
+	}
+
+	for hash := range restored {
+		encoded, err := encoder.Encode(inlined[hash])
+		if err != nil {
+			return batch, fmt.Errorf("encode restored content %v: %w", hash.Hex(), err)
+		}
+
+		// the references are counted again by the reconciler, deltas of the missing content may have been collected
+		_, err = tx.Exec(ctx, `
+			INSERT INTO dl.contents (hash, bytes, encoding, refs)
+			VALUES (($1, $2), $3, $4, NULL)
+			ON CONFLICT DO NOTHING
+		`, hash.H1, hash.H2, encoded, encoder.Encoding())
+		if err != nil {
+			return batch, fmt.Errorf("restore content %v: %w", hash.Hex(), err)
+		}
+	}
+
+	return batch, nil
+}
+
+func selectAuditedContents(ctx context.Context, tx pgx.Tx, decoder *ContentDecoder, first bool, cursor Hash, limit int64) ([]auditedContent, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT (hash).h1, (hash).h2, encoding, bytes
+		FROM dl.contents
+		WHERE $1::boolean OR hash > ($2, $3)::hash
+		ORDER BY hash
+		LIMIT $4
+	`, first, cursor.H1, cursor.H2, limit)
+	if err != nil {
+		return nil, fmt.Errorf("select audited contents: %w", err)
+	}
+	defer rows.Close()
+
+	var contents []auditedContent
+	for rows.Next() {
+		var content auditedContent
+		var encoding ContentEncoding
+		var bytes []byte
+		err = rows.Scan(&content.hash.H1, &content.hash.H2, &encoding, &bytes)
+		if err != nil {
+			return nil, fmt.Errorf("select audited contents scan: %w", err)
+		}
+
+		// packs are hashed as stored, loose contents once decoded
+		if HashContent(bytes) == content.hash {
+			content.length = int64(len(bytes))
+		} else {
+			decoded, err := decoder.DecodeAs(encoding, bytes)
+			if err != nil || HashContent(decoded) != content.hash {
+				content.corrupt = true
+			} else {
+				content.length = int64(len(decoded))
+			}
+		}
+
+		contents = append(contents, content)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return contents, nil
+}
+
+func selectAuditedObjects(ctx context.Context, tx pgx.Tx, first bool, cursor Hash, upper *Hash) ([]auditedObject, error) {
+	var last Hash
+	if upper != nil {
+		last = *upper
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT project, path, start_version, stop_version IS NULL, (hash).h1, (hash).h2, size, inline_content
+		FROM dl.objects
+		WHERE ($1::boolean OR hash > ($2, $3)::hash)
+		  AND ($4::boolean OR hash <= ($5, $6)::hash)
+	`, first, cursor.H1, cursor.H2, upper == nil, last.H1, last.H2)
+	if err != nil {
+		return nil, fmt.Errorf("select audited objects: %w", err)
+	}
+	defer rows.Close()
+
+	var objects []auditedObject
+	for rows.Next() {
+		var object auditedObject
+		err = rows.Scan(&object.project, &object.path, &object.startVersion, &object.live, &object.hash.H1, &object.hash.H2, &object.size, &object.inline)
+		if err != nil {
+			return nil, fmt.Errorf("select audited objects scan: %w", err)
+		}
+		objects = append(objects, object)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return objects, nil
+}
+
+// selectOtherContentReferences returns which of hashes are read by cache versions or stages rather than objects.
+func selectOtherContentReferences(ctx context.Context, tx pgx.Tx, hashes []Hash) (map[Hash]bool, error) {
+	referenced := make(map[Hash]bool)
+	if len(hashes) == 0 {
+		return referenced, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT (h.hash).h1, (h.hash).h2
+		FROM (
+			SELECT unnest(hashes) AS hash
+			FROM dl.cache_versions
+			UNION
+			SELECT hash
+			FROM dl.staged_objects
+		) h
+		WHERE h.hash = ANY($1::hash[])
+	`, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("select other content references: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hash Hash
+		err = rows.Scan(&hash.H1, &hash.H2)
+		if err != nil {
+			return nil, fmt.Errorf("select other content references scan: %w", err)
+		}
+		referenced[hash] = true
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return referenced, nil
+}
@@ -2,34 +2,286 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/gadget-inc/dateilager/internal/pb"
 	"github.com/jackc/pgx/v5"
 )
 
+// ErrCacheVersionInUse is returned when deleting the latest cache version, which clients still discover and download.
+var ErrCacheVersionInUse = errors.New("cache version in use")
+
+// CacheSources selects which packed objects are candidates for a cache version.
+type CacheSources struct {
+	Prefixes        []string
+	IncludeProjects []int64
+	ExcludeProjects []int64
+}
+
 func CreateCache(ctx context.Context, tx pgx.Tx, prefix string, count int64) (int64, error) {
+	return CreateCacheFromSources(ctx, tx, CacheSources{Prefixes: []string{prefix}}, count)
+}
+
+// CreateCacheFromSources creates a cache version from the count packs shared by the most projects among the candidates.
+// An empty list of prefixes matches every path and an empty IncludeProjects matches every project.
+func CreateCacheFromSources(ctx context.Context, tx pgx.Tx, sources CacheSources, count int64) (int64, error) {
+	patterns := make([]string, 0, len(sources.Prefixes))
+	for _, prefix := range sources.Prefixes {
+		patterns = append(patterns, fmt.Sprintf("%s%%", prefix))
+	}
+	if len(patterns) == 0 {
+		patterns = append(patterns, "%")
+	}
+
+	includeProjects := sources.IncludeProjects
+	if includeProjects == nil {
+		includeProjects = []int64{}
+	}
+	excludeProjects := sources.ExcludeProjects
+	if excludeProjects == nil {
+		excludeProjects = []int64{}
+	}
+
 	sql := `
-		WITH impactful_packed_objects AS (
+		WITH eligible_objects AS (
+			SELECT o.project, o.hash
+			FROM dl.objects o
+			WHERE o.path LIKE ANY($1::text[])
+			  AND o.packed = true
+			  AND o.stop_version IS NULL
+			  AND NOT EXISTS (
+				SELECT 1
+				FROM dl.projects p
+				WHERE p.id = o.project
+				  AND NOT p.include_in_cache
+			  )
+			  AND (cardinality($2::bigint[]) = 0 OR o.project = ANY($2::bigint[]))
+			  AND NOT (o.project = ANY($3::bigint[]))
+		), impactful_packed_objects AS (
 			SELECT hash, count(*) AS count
-			FROM dl.objects
-			WHERE path LIKE $1
-			  AND packed = true
-			  AND stop_version IS NULL
+			FROM eligible_objects
 			GROUP BY hash
 			ORDER BY count DESC
-			LIMIT $2
+			LIMIT $4
+		), new_version AS (
+			INSERT INTO dl.cache_versions (hashes)
+			SELECT COALESCE(array_agg(hash), '{}') AS hashes
+			FROM impactful_packed_objects
+			RETURNING version
+		), contributors AS (
+			INSERT INTO dl.cache_version_projects (version, project)
+			SELECT DISTINCT v.version, e.project
+			FROM new_version v, eligible_objects e
+			JOIN impactful_packed_objects i ON i.hash = e.hash
 		)
-		INSERT INTO dl.cache_versions (hashes)
-		SELECT COALESCE(array_agg(hash), '{}') AS hashes
-		FROM impactful_packed_objects
-		RETURNING version
+		SELECT version
+		FROM new_version
 	`
 
 	var version int64
-	err := tx.QueryRow(ctx, sql, fmt.Sprintf("%s%%", prefix), count).Scan(&version)
+	err := tx.QueryRow(ctx, sql, patterns, includeProjects, excludeProjects, count).Scan(&version)
 	if err != nil {
 		return 0, fmt.Errorf("CreateCache query, %w", err)
 	}
 
 	return version, nil
 }
+
+// CacheVersionProjects returns the projects whose packed objects were included in a cache version.
+func CacheVersionProjects(ctx context.Context, tx pgx.Tx, version int64) ([]int64, error) {
+	var exists bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM dl.cache_versions WHERE version = $1)
+	`, version).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("CacheVersionProjects %v: %w", version, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("CacheVersionProjects %v: %w", version, ErrNotFound)
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT project
+		FROM dl.cache_version_projects
+		WHERE version = $1
+		ORDER BY project
+	`, version)
+	if err != nil {
+		return nil, fmt.Errorf("CacheVersionProjects query, version %v: %w", version, err)
+	}
+
+	projects, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return nil, fmt.Errorf("CacheVersionProjects scan, version %v: %w", version, err)
+	}
+
+	return projects, nil
+}
+
+func ListCacheVersions(ctx context.Context, tx pgx.Tx) ([]*pb.CacheVersion, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT version, cardinality(hashes)
+		FROM dl.cache_versions
+		ORDER BY version
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("ListCacheVersions query: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []*pb.CacheVersion{}
+
+	for rows.Next() {
+		var version, count int64
+		err = rows.Scan(&version, &count)
+		if err != nil {
+			return nil, fmt.Errorf("ListCacheVersions scan: %w", err)
+		}
+		versions = append(versions, &pb.CacheVersion{Version: version, ObjectsCount: count})
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return versions, nil
+}
+
+// DeleteCacheVersion removes a cache version and returns the hashes it referenced so their content can be collected.
+func DeleteCacheVersion(ctx context.Context, tx pgx.Tx, version int64) ([]Hash, error) {
+	latest, err := LatestCacheVersion(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	if version == latest {
+		return nil, fmt.Errorf("DeleteCacheVersion %v is the latest version: %w", version, ErrCacheVersionInUse)
+	}
+
+	var exists bool
+	err = tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM dl.cache_versions WHERE version = $1)
+	`, version).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("DeleteCacheVersion %v: %w", version, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("DeleteCacheVersion %v: %w", version, ErrNotFound)
+	}
+
+	rows, err := tx.Query(ctx, `
+		WITH deleted AS (
+			DELETE FROM dl.cache_versions
+			WHERE version = $1
+			RETURNING hashes
+		)
+		SELECT h.h1, h.h2
+		FROM deleted, unnest(deleted.hashes) AS h
+	`, version)
+	if err != nil {
+		return nil, fmt.Errorf("DeleteCacheVersion query, version %v: %w", version, err)
+	}
+	defer rows.Close()
+
+	hashes := []Hash{}
+
+	for rows.Next() {
+		var hash Hash
+		err = rows.Scan(&hash.H1, &hash.H2)
+		if err != nil {
+			return nil, fmt.Errorf("DeleteCacheVersion scan, version %v: %w", version, err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// cacheSchedulerLockKey identifies the advisory lock held while a server creates a scheduled cache version.
+const cacheSchedulerLockKey = 0x646c6361636865
+
+// TryLockCacheScheduler takes a transaction scoped advisory lock so only one server replica runs a scheduled cache creation at a time.
+func TryLockCacheScheduler(ctx context.Context, tx pgx.Tx) (bool, error) {
+	var locked bool
+	err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, cacheSchedulerLockKey).Scan(&locked)
+	if err != nil {
+		return false, fmt.Errorf("TryLockCacheScheduler query: %w", err)
+	}
+
+	return locked, nil
+}
+
+// PruneCacheVersions deletes every cache version but the keep latest ones.
+// It returns how many versions were deleted and the hashes they referenced.
+func PruneCacheVersions(ctx context.Context, tx pgx.Tx, keep int64) (int64, []Hash, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT version
+		FROM dl.cache_versions
+		ORDER BY version DESC
+		OFFSET $1
+	`, keep)
+	if err != nil {
+		return 0, nil, fmt.Errorf("PruneCacheVersions query, keep %v: %w", keep, err)
+	}
+
+	versions, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return 0, nil, fmt.Errorf("PruneCacheVersions scan, keep %v: %w", keep, err)
+	}
+
+	if len(versions) == 0 {
+		return 0, nil, nil
+	}
+
+	rows, err = tx.Query(ctx, `
+		WITH deleted AS (
+			DELETE FROM dl.cache_versions
+			WHERE version = ANY($1::bigint[])
+			RETURNING hashes
+		)
+		SELECT DISTINCT h.h1, h.h2
+		FROM deleted, unnest(deleted.hashes) AS h
+	`, versions)
+	if err != nil {
+		return 0, nil, fmt.Errorf("PruneCacheVersions delete, keep %v: %w", keep, err)
+	}
+	defer rows.Close()
+
+	hashes := []Hash{}
+
+	for rows.Next() {
+		var hash Hash
+		err = rows.Scan(&hash.H1, &hash.H2)
+		if err != nil {
+			return 0, nil, fmt.Errorf("PruneCacheVersions scan hash, keep %v: %w", keep, err)
+		}
+		hashes = append(hashes, hash)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return int64(len(versions)), hashes, nil
+}
+
+// CacheVersionCreatedSince reports whether a cache version was created at or after since.
+func CacheVersionCreatedSince(ctx context.Context, tx pgx.Tx, since time.Time) (bool, error) {
+	var exists bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM dl.cache_versions WHERE created_at >= $1)
+	`, since).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("CacheVersionCreatedSince query: %w", err)
+	}
+
+	return exists, nil
+}
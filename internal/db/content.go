@@ -12,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/puddle/v2"
 	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
 	"github.com/minio/sha256-simd"
 )
 
@@ -41,6 +42,18 @@ func (h *Hash) Bytes() []byte {
 	return hash
 }
 
+// HashFromBytes is the inverse of Hash.Bytes.
+func HashFromBytes(bytes []byte) (Hash, error) {
+	var hash Hash
+	if len(bytes) != 32 {
+		return hash, fmt.Errorf("invalid hash length %v", len(bytes))
+	}
+
+	copy(hash.H1[:], bytes[0:16])
+	copy(hash.H2[:], bytes[16:32])
+	return hash, nil
+}
+
 // Stolen from Go's standard library
 // And optimized for the Hash type
 const hextable = "0123456789abcdef"
@@ -65,21 +78,66 @@ func (h *Hash) Hex() string {
 }
 
 type ContentEncoder struct {
-	buffer *bytes.Buffer
-	writer *s2.Writer
+	encoding ContentEncoding
+	buffer   *bytes.Buffer
+	writer   *s2.Writer
+	zstd     *zstd.Encoder
+	// inlineThreshold is the size up to which loose contents are stored in their object row, 0 disables inlining
+	inlineThreshold int64
 }
 
+// NewContentEncoder encodes contents with s2, the encoding every server reads.
 func NewContentEncoder() *ContentEncoder {
+	return NewContentEncoderFor(EncodingS2)
+}
+
+// NewContentEncoderFor encodes contents with encoding, unknown encodings fall back to s2.
+func NewContentEncoderFor(encoding ContentEncoding) *ContentEncoder {
+	if encoding == EncodingZstd {
+		return &ContentEncoder{
+			encoding: encoding,
+			zstd:     zstdEncoders.Get().(*zstd.Encoder),
+		}
+	}
+
 	var buffer bytes.Buffer
-	writer := s2.NewWriter(&buffer, s2.WriterConcurrency(1))
+	writer := s2SerialWriters.Get().(*s2.Writer)
+	writer.Reset(&buffer)
 
 	return &ContentEncoder{
-		buffer: &buffer,
-		writer: writer,
+		encoding: EncodingS2,
+		buffer:   &buffer,
+		writer:   writer,
 	}
 }
 
+// WithInlineThreshold makes UpdateObject store loose contents of up to threshold bytes in the object row rather than in
+// dl.contents, saving the lookup of tiny files on reads. A threshold of 0 disables inlining.
+func (c *ContentEncoder) WithInlineThreshold(threshold int64) *ContentEncoder {
+	c.inlineThreshold = threshold
+	return c
+}
+
+// Inlines reports whether a loose content of size bytes is stored in its object row.
+func (c *ContentEncoder) Inlines(size int) bool {
+	return c.inlineThreshold > 0 && int64(size) <= c.inlineThreshold
+}
+
+// InlineThreshold is the size up to which loose contents are stored in their object row, 0 when inlining is disabled.
+func (c *ContentEncoder) InlineThreshold() int64 {
+	return c.inlineThreshold
+}
+
+// Encoding is the encoding of the contents returned by Encode, to be stored alongside them.
+func (c *ContentEncoder) Encoding() ContentEncoding {
+	return c.encoding
+}
+
 func (c *ContentEncoder) Encode(content DecodedContent) (EncodedContent, error) {
+	if c.zstd != nil {
+		return c.zstd.EncodeAll(content, make([]byte, 0, len(content)/2)), nil
+	}
+
 	_, err := c.writer.Write(content)
 	if err != nil {
 		return nil, err
@@ -104,13 +162,29 @@ func (c *ContentEncoder) Encode(content DecodedContent) (EncodedContent, error)
 	return output, nil
 }
 
+// Close releases the encoder's writer back to its pool, the encoder must not be used afterwards.
 func (c *ContentEncoder) Close() error {
-	return c.writer.Close()
+	if c.zstd != nil {
+		zstdEncoders.Put(c.zstd)
+		c.zstd = nil
+		return nil
+	}
+
+	if c.writer == nil {
+		return nil
+	}
+
+	err := c.writer.Close()
+	releaseS2Writer(&s2SerialWriters, c.writer)
+	c.writer = nil
+	return err
 }
 
 type ContentDecoder struct {
-	buffer *bytes.Buffer
-	reader *s2.Reader
+	buffer  *bytes.Buffer
+	encoded *bytes.Reader
+	reader  *s2.Reader
+	zstd    *zstd.Decoder
 }
 
 func NewContentDecoder() *ContentDecoder {
@@ -118,14 +192,37 @@ func NewContentDecoder() *ContentDecoder {
 	reader := s2.NewReader(nil)
 
 	return &ContentDecoder{
-		buffer: &buffer,
-		reader: reader,
+		buffer:  &buffer,
+		encoded: bytes.NewReader(nil),
+		reader:  reader,
 	}
 }
 
+// Decode decodes a content encoded with s2.
 func (c *ContentDecoder) Decode(encoded EncodedContent) (DecodedContent, error) {
+	return c.DecodeAs(EncodingS2, encoded)
+}
+
+// DecodeAs decodes a content stored with encoding.
+func (c *ContentDecoder) DecodeAs(encoding ContentEncoding, encoded EncodedContent) (DecodedContent, error) {
+	switch encoding {
+	case EncodingS2:
+	case EncodingZstd:
+		if c.zstd == nil {
+			decoder, err := newZstdDecoder()
+			if err != nil {
+				return nil, err
+			}
+			c.zstd = decoder
+		}
+		return c.zstd.DecodeAll(encoded, []byte{})
+	default:
+		return nil, fmt.Errorf("%w %v", ErrUnknownEncoding, encoding)
+	}
+
 	c.buffer.Reset()
-	c.reader.Reset(bytes.NewReader(encoded))
+	c.encoded.Reset(encoded)
+	c.reader.Reset(c.encoded)
 
 	_, err := io.Copy(c.buffer, c.reader)
 	if err != nil {
@@ -137,6 +234,12 @@ func (c *ContentDecoder) Decode(encoded EncodedContent) (DecodedContent, error)
 	return output, nil
 }
 
+// storedContent is a content as read from dl.contents, along with its encoding.
+type storedContent struct {
+	encoding ContentEncoding
+	bytes    []byte
+}
+
 type ContentLookup struct {
 	cache    *ristretto.Cache
 	decoders *puddle.Pool[*ContentDecoder]
@@ -176,6 +279,42 @@ func NewContentLookup() (*ContentLookup, error) {
 	}, nil
 }
 
+// ProjectContent returns the content of a loose file stored by any version of project, it returns ErrNotFound when
+// none of them stored it, contents are only read back through a project that holds them.
+func (cl *ContentLookup) ProjectContent(ctx context.Context, tx pgx.Tx, project int64, hash Hash) (DecodedContent, error) {
+	var inline []byte
+	err := tx.QueryRow(ctx, `
+		SELECT inline_content
+		FROM dl.objects
+		WHERE project = $1
+		  AND hash = ($2, $3)::hash
+		  AND packed IS false
+		ORDER BY inline_content IS NULL
+		LIMIT 1
+	`, project, hash.H1, hash.H2).Scan(&inline)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("project content %v, hash %v: %w", project, hash.Hex(), ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("project content %v, hash %v: %w", project, hash.Hex(), err)
+	}
+
+	if inline != nil {
+		return inline, nil
+	}
+
+	contents, err := cl.Lookup(ctx, tx, map[Hash]bool{hash: true})
+	if err != nil {
+		return nil, fmt.Errorf("project content %v, hash %v: %w", project, hash.Hex(), err)
+	}
+
+	content, ok := contents[hash]
+	if !ok {
+		return nil, fmt.Errorf("project content %v, hash %v: %w", project, hash.Hex(), ErrNotFound)
+	}
+	return content, nil
+}
+
 func (cl *ContentLookup) Lookup(ctx context.Context, tx pgx.Tx, hashesToLookup map[Hash]bool) (map[Hash]DecodedContent, error) {
 	var notFound []Hash
 	contents := make(map[Hash]DecodedContent, len(hashesToLookup))
@@ -189,14 +328,15 @@ func (cl *ContentLookup) Lookup(ctx context.Context, tx pgx.Tx, hashesToLookup m
 	for hash, isEncoded := range hashesToLookup {
 		value, found := cl.cache.Get(hash.Hex())
 		if found {
+			stored := value.(storedContent)
 			if isEncoded {
-				decoded, err := decoder.Value().Decode(value.(EncodedContent))
+				decoded, err := decoder.Value().DecodeAs(stored.encoding, stored.bytes)
 				if err != nil {
 					return nil, fmt.Errorf("cannot decode value from cache %v: %w", hash.Hex(), err)
 				}
 				contents[hash] = decoded
 			} else {
-				contents[hash] = value.(DecodedContent)
+				contents[hash] = stored.bytes
 			}
 		} else {
 			notFound = append(notFound, hash)
@@ -205,7 +345,7 @@ func (cl *ContentLookup) Lookup(ctx context.Context, tx pgx.Tx, hashesToLookup m
 
 	if len(notFound) > 0 {
 		rows, err := tx.Query(ctx, `
-			SELECT (hash).h1, (hash).h2, bytes
+			SELECT (hash).h1, (hash).h2, encoding, bytes
 			FROM dl.contents
 			WHERE hash = ANY($1::hash[])
 		`, notFound)
@@ -215,18 +355,19 @@ func (cl *ContentLookup) Lookup(ctx context.Context, tx pgx.Tx, hashesToLookup m
 
 		for rows.Next() {
 			var hash Hash
+			var encoding ContentEncoding
 			var value []byte
 
-			err = rows.Scan(&hash.H1, &hash.H2, &value)
+			err = rows.Scan(&hash.H1, &hash.H2, &encoding, &value)
 			if err != nil {
 				return nil, fmt.Errorf("content lookup scan: %w", err)
 			}
 
-			// This is a content addressable cache, any cached value will never be updated
-			cl.cache.Set(hash.Hex(), value, int64(len(value)))
+			// This is a content addressable cache, a cached value only changes encoding when it is re-encoded
+			cl.cache.Set(hash.Hex(), storedContent{encoding: encoding, bytes: value}, int64(len(value)))
 
 			if hashesToLookup[hash] {
-				decoded, err := decoder.Value().Decode(value)
+				decoded, err := decoder.Value().DecodeAs(encoding, value)
 				if err != nil {
 					return nil, fmt.Errorf("cannot decode value from content table %v: %w", hash.Hex(), err)
 				}
@@ -240,11 +381,58 @@ func (cl *ContentLookup) Lookup(ctx context.Context, tx pgx.Tx, hashesToLookup m
 		if err != nil {
 			return nil, fmt.Errorf("failed to iterate rows: %w", err)
 		}
+
+		err = lookupInlineContents(ctx, tx, hashesToLookup, contents)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return contents, nil
 }
 
+// lookupInlineContents fills in the loose contents missing from dl.contents because they are only stored inline in
+// their objects. Inline contents are small and already decoded, they are not cached.
+func lookupInlineContents(ctx context.Context, tx pgx.Tx, hashesToLookup map[Hash]bool, contents map[Hash]DecodedContent) error {
+	var missing []Hash
+	for hash, isEncoded := range hashesToLookup {
+		if _, ok := contents[hash]; !ok && isEncoded {
+			missing = append(missing, hash)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT ON (hash) (hash).h1, (hash).h2, inline_content
+		FROM dl.objects
+		WHERE hash = ANY($1::hash[])
+		  AND inline_content IS NOT NULL
+	`, missing)
+	if err != nil {
+		return fmt.Errorf("lookup inline contents: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hash Hash
+		var content []byte
+		err = rows.Scan(&hash.H1, &hash.H2, &content)
+		if err != nil {
+			return fmt.Errorf("inline content lookup scan: %w", err)
+		}
+		contents[hash] = content
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return nil
+}
+
 func RandomContents(ctx context.Context, conn DbConnector, sample float32) ([]Hash, error) {
 	rows, err := conn.Query(ctx, fmt.Sprintf(`
 		SELECT (hash).h1, (hash).h2
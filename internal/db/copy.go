@@ -19,8 +19,8 @@ func CopyAllObjects(ctx context.Context, tx pgx.Tx, source int64, target int64)
 	}
 
 	_, err = tx.Exec(ctx, `
-		INSERT INTO dl.objects (project, start_version, stop_version, path, hash, mode, size, packed)
-		SELECT $1, start_version, stop_version, path, hash, mode, size, packed
+		INSERT INTO dl.objects (project, start_version, stop_version, path, hash, mode, size, packed, content_type, mtime, inline_content)
+		SELECT $1, start_version, stop_version, path, hash, mode, size, packed, content_type, mtime, inline_content
 		FROM dl.objects
 		WHERE project = $2
 		AND stop_version IS NULL
@@ -60,8 +60,11 @@ func CloneToProject(ctx context.Context, tx pgx.Tx, source int64, target int64,
 	sql := fmt.Sprintf(`
 		WITH live_source_objects AS (
 			%s
-		), to_remove AS (
+		), live_target_objects AS (
 			%s
+		), to_remove AS (
+			SELECT path, mode, size, is_cached, packed, deleted, h1, h2
+			FROM live_target_objects
 			EXCEPT
 			SELECT path, mode, size, is_cached, packed, deleted, h1, h2
 			FROM live_source_objects
@@ -83,8 +86,8 @@ func CloneToProject(ctx context.Context, tx pgx.Tx, source int64, target int64,
 		WITH live_source_objects AS (
 			%s
 		)
-		INSERT INTO dl.objects (project, start_version, stop_version, path, hash, mode, size, packed)
-		SELECT $%d, $%d, null, path, (h1, h2)::hash, mode, size, packed
+		INSERT INTO dl.objects (project, start_version, stop_version, path, hash, mode, size, packed, content_type, mtime, inline_content)
+		SELECT $%d, $%d, null, path, (h1, h2)::hash, mode, size, packed, content_type, mtime, inline_content
 		FROM live_source_objects
 		WHERE deleted = false
 		ON CONFLICT
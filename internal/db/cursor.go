@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var cursorCount atomic.Int64
+
+// objectCursor reads the results of an object query through a server side cursor, one batch per call to next.
+// Only the batch being streamed is held in memory, the rest of a giant project stays in Postgres until the client catches up.
+type objectCursor struct {
+	tx   pgx.Tx
+	name string
+	done bool
+}
+
+func openObjectCursor(ctx context.Context, tx pgx.Tx, queryBuilder *queryBuilder) (*objectCursor, error) {
+	name := fmt.Sprintf("dl_objects_%d", cursorCount.Add(1))
+	sql, args := queryBuilder.build()
+
+	// describe exec keeps the uniquely named statements out of the connection's statement cache
+	_, err := tx.Exec(ctx, fmt.Sprintf("DECLARE %s NO SCROLL CURSOR FOR %s", name, sql), append([]any{pgx.QueryExecModeDescribeExec}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("declare cursor %s: %w", name, err)
+	}
+
+	return &objectCursor{tx: tx, name: name}, nil
+}
+
+// next fetches up to size objects, an empty batch means the cursor is exhausted and has been closed.
+func (c *objectCursor) next(ctx context.Context, size int) ([]DbObject, error) {
+	if c.done {
+		return nil, nil
+	}
+
+	rows, err := c.tx.Query(ctx, fmt.Sprintf("FETCH %d FROM %s", size, c.name), pgx.QueryExecModeDescribeExec)
+	if err != nil {
+		return nil, fmt.Errorf("fetch cursor %s: %w", c.name, err)
+	}
+
+	dbObjects, err := scanObjects(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dbObjects) < size {
+		c.done = true
+		_, err = c.tx.Exec(ctx, fmt.Sprintf("CLOSE %s", c.name), pgx.QueryExecModeDescribeExec)
+		if err != nil {
+			return nil, fmt.Errorf("close cursor %s: %w", c.name, err)
+		}
+	}
+
+	return dbObjects, nil
+}
@@ -0,0 +1,307 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/jackc/pgx/v5"
+	"github.com/klauspost/compress/zstd"
+)
+
+// ContentEncoding is the at-rest format of a loose content, stored in dl.contents.encoding. Packs are always stored as
+// the s2 compressed tar they are served as.
+//
+// Servers read every known encoding but only write the one they are configured with, so changing it is a rolling
+// upgrade: first deploy servers able to read the new encoding everywhere, then switch them to writing it, then
+// re-encode the existing contents with ReencodeContents.
+type ContentEncoding int16
+
+const (
+	EncodingS2   ContentEncoding = 0
+	EncodingZstd ContentEncoding = 1
+)
+
+var (
+	ErrUnknownEncoding = errors.New("unknown content encoding")
+	ErrReencodeLocked  = errors.New("reencode locked by another transaction")
+)
+
+func (e ContentEncoding) String() string {
+	switch e {
+	case EncodingS2:
+		return "s2"
+	case EncodingZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("encoding(%d)", int16(e))
+	}
+}
+
+// ParseContentEncoding is the inverse of ContentEncoding.String for known encodings.
+func ParseContentEncoding(name string) (ContentEncoding, error) {
+	switch name {
+	case "s2":
+		return EncodingS2, nil
+	case "zstd":
+		return EncodingZstd, nil
+	default:
+		return EncodingS2, fmt.Errorf("%w %q, expected s2 or zstd", ErrUnknownEncoding, name)
+	}
+}
+
+// zstd encoders only encode whole contents with EncodeAll, a single one per goroutine is enough.
+var zstdEncoders = sync.Pool{
+	New: func() any {
+		encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return encoder
+	},
+}
+
+func newZstdDecoder() (*zstd.Decoder, error) {
+	return zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+}
+
+// ContentEncodingStats counts the contents stored in each encoding, packs included.
+func ContentEncodingStats(ctx context.Context, tx pgx.Tx) ([]*pb.EncodingStats, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT encoding, count(*), coalesce(sum(octet_length(bytes)), 0)::bigint
+		FROM dl.contents
+		GROUP BY encoding
+		ORDER BY encoding
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("content encoding stats: %w", err)
+	}
+	defer rows.Close()
+
+	var stats []*pb.EncodingStats
+	for rows.Next() {
+		var encoding ContentEncoding
+		var stat pb.EncodingStats
+		err = rows.Scan(&encoding, &stat.Contents, &stat.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("content encoding stats scan: %w", err)
+		}
+		stat.Encoding = encoding.String()
+		stats = append(stats, &stat)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return stats, nil
+}
+
+// ReencodeProgress tracks a re-encode of every content to Encoding, contents are walked in hash order so a job resumes
+// after the last hash it handled.
+type ReencodeProgress struct {
+	Encoding   ContentEncoding
+	Scanned    int64
+	Reencoded  int64
+	StartedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt *time.Time
+}
+
+func (p ReencodeProgress) Done() bool {
+	return p.FinishedAt != nil
+}
+
+func (p ReencodeProgress) Proto() *pb.ReencodeProgress {
+	progress := &pb.ReencodeProgress{
+		Encoding:  p.Encoding.String(),
+		Scanned:   p.Scanned,
+		Reencoded: p.Reencoded,
+		StartedAt: p.StartedAt.UnixNano(),
+		UpdatedAt: p.UpdatedAt.UnixNano(),
+	}
+	if p.FinishedAt != nil {
+		finishedAt := p.FinishedAt.UnixNano()
+		progress.FinishedAt = &finishedAt
+	}
+	return progress
+}
+
+// ListReencodeProgress returns the progress of every re-encode ever started.
+func ListReencodeProgress(ctx context.Context, tx pgx.Tx) ([]ReencodeProgress, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT encoding, scanned, reencoded, started_at, updated_at, finished_at
+		FROM dl.content_reencodes
+		ORDER BY started_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list reencode progress: %w", err)
+	}
+	defer rows.Close()
+
+	var list []ReencodeProgress
+	for rows.Next() {
+		var progress ReencodeProgress
+		err = rows.Scan(&progress.Encoding, &progress.Scanned, &progress.Reencoded, &progress.StartedAt, &progress.UpdatedAt, &progress.FinishedAt)
+		if err != nil {
+			return nil, fmt.Errorf("list reencode progress scan: %w", err)
+		}
+		list = append(list, progress)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return list, nil
+}
+
+// ReencodeContents walks the next batchSize contents of the re-encode to encoding, re-encodes the loose ones stored in
+// another encoding and records the progress. Packs, recognized by their index, their packed objects or their cache
+// versions, are left untouched. The progress row is locked until tx ends, a concurrent call from another server
+// returns ErrReencodeLocked rather than waiting for it.
+func ReencodeContents(ctx context.Context, tx pgx.Tx, decoder *ContentDecoder, encoding ContentEncoding, batchSize int64) (ReencodeProgress, error) {
+	progress := ReencodeProgress{Encoding: encoding}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO dl.content_reencodes (encoding)
+		VALUES ($1)
+		ON CONFLICT DO NOTHING
+	`, encoding)
+	if err != nil {
+		return progress, fmt.Errorf("start reencode to %v: %w", encoding, err)
+	}
+
+	var cursorH1, cursorH2 *[16]byte
+	err = tx.QueryRow(ctx, `
+		SELECT (cursor).h1, (cursor).h2, scanned, reencoded, started_at, updated_at, finished_at
+		FROM dl.content_reencodes
+		WHERE encoding = $1
+		FOR UPDATE SKIP LOCKED
+	`, encoding).Scan(&cursorH1, &cursorH2, &progress.Scanned, &progress.Reencoded, &progress.StartedAt, &progress.UpdatedAt, &progress.FinishedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return progress, fmt.Errorf("reencode to %v: %w", encoding, ErrReencodeLocked)
+	}
+	if err != nil {
+		return progress, fmt.Errorf("lock reencode to %v: %w", encoding, err)
+	}
+	if progress.Done() {
+		return progress, nil
+	}
+
+	var cursor Hash
+	if cursorH1 != nil && cursorH2 != nil {
+		cursor = Hash{H1: *cursorH1, H2: *cursorH2}
+	}
+
+	// the bytes of packs and of contents already in the target encoding are not read
+	rows, err := tx.Query(ctx, `
+		SELECT (hash).h1, (hash).h2, encoding, CASE WHEN skipped THEN NULL ELSE bytes END
+		FROM (
+			SELECT c.hash, c.encoding, c.bytes,
+			       c.encoding = $4
+			       OR c.pack_index IS NOT NULL
+			       OR EXISTS (
+					SELECT 1
+					FROM dl.objects o
+					WHERE o.hash = c.hash
+					  AND o.packed IS true
+			       )
+			       OR EXISTS (
+					SELECT 1
+					FROM dl.cache_versions cv
+					WHERE c.hash = ANY(cv.hashes)
+			       ) AS skipped
+			FROM dl.contents c
+			WHERE $1::boolean OR c.hash > ($2, $3)::hash
+			ORDER BY c.hash
+			LIMIT $5
+		) batch
+		ORDER BY hash
+	`, cursorH1 == nil, cursor.H1, cursor.H2, encoding, batchSize)
+	if err != nil {
+		return progress, fmt.Errorf("select contents to reencode to %v: %w", encoding, err)
+	}
+
+	type reencoded struct {
+		hash    Hash
+		content EncodedContent
+	}
+
+	encoder := NewContentEncoderFor(encoding)
+	defer encoder.Close()
+
+	var scanned int64
+	var batch []reencoded
+	for rows.Next() {
+		var hash Hash
+		var from ContentEncoding
+		var encoded EncodedContent
+		err = rows.Scan(&hash.H1, &hash.H2, &from, &encoded)
+		if err != nil {
+			rows.Close()
+			return progress, fmt.Errorf("scan content to reencode to %v: %w", encoding, err)
+		}
+
+		scanned += 1
+		cursor = hash
+		if encoded == nil {
+			continue
+		}
+
+		decoded, err := decoder.DecodeAs(from, encoded)
+		if err != nil {
+			rows.Close()
+			return progress, fmt.Errorf("decode content %v from %v: %w", hash.Hex(), from, err)
+		}
+
+		content, err := encoder.Encode(decoded)
+		if err != nil {
+			rows.Close()
+			return progress, fmt.Errorf("encode content %v to %v: %w", hash.Hex(), encoding, err)
+		}
+
+		batch = append(batch, reencoded{hash: hash, content: content})
+	}
+	rows.Close()
+
+	err = rows.Err()
+	if err != nil {
+		return progress, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	for _, content := range batch {
+		_, err = tx.Exec(ctx, `
+			UPDATE dl.contents
+			SET bytes = $1, encoding = $2
+			WHERE hash = ($3, $4)
+		`, content.content, encoding, content.hash.H1, content.hash.H2)
+		if err != nil {
+			return progress, fmt.Errorf("store content %v encoded to %v: %w", content.hash.Hex(), encoding, err)
+		}
+	}
+
+	progress.Scanned += scanned
+	progress.Reencoded += int64(len(batch))
+	progress.UpdatedAt = time.Now()
+	if scanned < batchSize {
+		progress.FinishedAt = &progress.UpdatedAt
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE dl.content_reencodes
+		SET cursor = CASE WHEN $1 THEN NULL ELSE ($2, $3)::hash END,
+		    scanned = $4,
+		    reencoded = $5,
+		    updated_at = $6,
+		    finished_at = $7
+		WHERE encoding = $8
+	`, cursorH1 == nil && scanned == 0, cursor.H1, cursor.H2, progress.Scanned, progress.Reencoded, progress.UpdatedAt, progress.FinishedAt, encoding)
+	if err != nil {
+		return progress, fmt.Errorf("record reencode progress to %v: %w", encoding, err)
+	}
+
+	return progress, nil
+}
@@ -0,0 +1,79 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/jackc/pgx/v5"
+)
+
+type Snapshot map[string]*pb.Object
+
+// GetSnapshot returns every live object of a project at a specific version, packed objects are unpacked.
+func GetSnapshot(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, project int64, version int64) (Snapshot, error) {
+	packManager, err := NewPackManager(ctx, tx, project)
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := GetObjects(ctx, tx, lookup, packManager, project, VersionRange{From: 0, To: version}, &pb.ObjectQuery{
+		Path:     "",
+		IsPrefix: true,
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := make(Snapshot)
+
+	for {
+		object, err := objects()
+		if err == SKIP {
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("snapshot project %v version %v: %w", project, version, err)
+		}
+
+		snapshot[object.Path] = object
+	}
+
+	return snapshot, nil
+}
+
+// SameObject returns true if both objects are missing or share the same mode and content.
+func SameObject(a, b *pb.Object) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Mode == b.Mode && bytes.Equal(a.Content, b.Content)
+}
+
+// DiffSnapshots returns the objects needed to turn before into after, removed paths are returned as deleted objects.
+func DiffSnapshots(before, after Snapshot) []*pb.Object {
+	var changes []*pb.Object
+
+	for path, object := range after {
+		if !SameObject(before[path], object) {
+			changes = append(changes, object)
+		}
+	}
+
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changes = append(changes, &pb.Object{Path: path, Deleted: true})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+
+	return changes
+}
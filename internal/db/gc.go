@@ -6,6 +6,7 @@ import (
 
 	"github.com/gadget-inc/dateilager/internal/key"
 	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -54,6 +55,57 @@ func GcProjectObjects(ctx context.Context, conn DbConnector, project int64, keep
 	return hashes, nil
 }
 
+// CountGcProjectContents returns how many contents GcProjectObjects followed by GcContentHashes would remove, without
+// removing anything.
+func CountGcProjectContents(ctx context.Context, tx pgx.Tx, project int64, keep int64, fromVersion int64) (int64, error) {
+	var count int64
+	err := tx.QueryRow(ctx, `
+		WITH latest AS (
+			SELECT latest_version AS version
+			FROM dl.projects
+			WHERE id = $1
+		), collected AS (
+			SELECT DISTINCT hash
+			FROM dl.objects
+			WHERE project = $1
+			  AND start_version > $2
+			  AND stop_version IS NOT NULL
+			  AND stop_version <= ((SELECT version FROM latest) - $3)
+		)
+		SELECT count(*)
+		FROM collected c
+		JOIN dl.contents ct
+		  ON ct.hash = c.hash
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM dl.objects o
+			WHERE o.hash = c.hash
+			  AND o.inline_content IS NULL
+			  AND NOT (
+				o.project = $1
+				AND o.start_version > $2
+				AND o.stop_version IS NOT NULL
+				AND o.stop_version <= ((SELECT version FROM latest) - $3)
+			  )
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM dl.cache_versions cv
+			WHERE c.hash = ANY(cv.hashes)
+		)
+		AND NOT EXISTS (
+			SELECT 1
+			FROM dl.staged_objects s
+			WHERE s.hash = c.hash
+		)
+	`, project, fromVersion, keep).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("CountGcProjectContents query, project %v, keep %v, from %v: %w", project, keep, fromVersion, err)
+	}
+
+	return count, nil
+}
+
 func GcProjectsObjects(ctx context.Context, conn DbConnector, projects []int64, keep int64, fromVersion int64) ([]Hash, error) {
 	hashes := []Hash{}
 
@@ -80,10 +132,31 @@ func GcContentHashes(ctx context.Context, conn DbConnector, hashes []Hash) (int6
 		WITH missing AS (
 			SELECT c.hash
 			FROM dl.contents c
-			LEFT JOIN dl.objects o
-				   ON c.hash = o.hash
 			WHERE c.hash = ANY($1::hash[])
-			AND o.hash IS NULL
+			AND CASE
+				-- counted contents are orphans once their folded and pending references add up to nothing
+				WHEN c.refs IS NOT NULL THEN c.refs + coalesce((
+					SELECT sum(d.delta)
+					FROM dl.content_ref_deltas d
+					WHERE d.hash = c.hash
+				), 0) = 0
+				ELSE NOT EXISTS (
+					SELECT 1
+					FROM dl.objects o
+					WHERE o.hash = c.hash
+					  AND o.inline_content IS NULL
+				)
+			END
+			AND NOT EXISTS (
+				SELECT 1
+				FROM dl.cache_versions cv
+				WHERE c.hash = ANY(cv.hashes)
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM dl.staged_objects s
+				WHERE s.hash = c.hash
+			)
 		)
 		DELETE FROM dl.contents
 		WHERE hash IN (SELECT hash FROM missing)
@@ -93,6 +166,33 @@ func GcContentHashes(ctx context.Context, conn DbConnector, hashes []Hash) (int6
 		}
 
 		rowsAffected += tag.RowsAffected()
+
+		_, err = conn.Exec(ctx, `
+		DELETE FROM dl.pack_entries e
+		WHERE e.hash = ANY($1::hash[])
+		AND NOT EXISTS (
+			SELECT 1
+			FROM dl.contents c
+			WHERE c.hash = e.hash
+		)
+	`, hashChunk)
+		if err != nil {
+			return 0, fmt.Errorf("GcContentHashes pack entries query, hash count %v: %w", len(hashChunk), err)
+		}
+
+		// the deltas of removed contents add up to nothing, a content stored again starts counting from scratch
+		_, err = conn.Exec(ctx, `
+		DELETE FROM dl.content_ref_deltas d
+		WHERE d.hash = ANY($1::hash[])
+		AND NOT EXISTS (
+			SELECT 1
+			FROM dl.contents c
+			WHERE c.hash = d.hash
+		)
+	`, hashChunk)
+		if err != nil {
+			return 0, fmt.Errorf("GcContentHashes ref deltas query, hash count %v: %w", len(hashChunk), err)
+		}
 	}
 
 	return rowsAffected, nil
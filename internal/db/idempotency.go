@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// IdempotencyKeyTTL is how long a key is remembered, retries arriving later than this are applied again.
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// LookupIdempotencyKey returns the version produced by the write that first used key on project.
+// The caller must hold the project's version lock so a concurrent duplicate cannot slip in between lookup and save.
+func LookupIdempotencyKey(ctx context.Context, tx pgx.Tx, project int64, key string) (int64, bool, error) {
+	var version int64
+	err := tx.QueryRow(ctx, `
+		SELECT version
+		FROM dl.idempotency_keys
+		WHERE project = $1
+		  AND key = $2
+		  AND created_at > now() - $3::interval
+	`, project, key, IdempotencyKeyTTL).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return -1, false, nil
+	}
+	if err != nil {
+		return -1, false, fmt.Errorf("lookup idempotency key, project %v: %w", project, err)
+	}
+
+	return version, true, nil
+}
+
+// SaveIdempotencyKey remembers the version produced for key and forgets the project's expired keys.
+func SaveIdempotencyKey(ctx context.Context, tx pgx.Tx, project int64, key string, version int64) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM dl.idempotency_keys
+		WHERE project = $1
+		  AND created_at <= now() - $2::interval
+	`, project, IdempotencyKeyTTL)
+	if err != nil {
+		return fmt.Errorf("delete expired idempotency keys, project %v: %w", project, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO dl.idempotency_keys (project, key, version)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (project, key)
+		DO UPDATE SET version = EXCLUDED.version, created_at = now()
+	`, project, key, version)
+	if err != nil {
+		return fmt.Errorf("save idempotency key, project %v: %w", project, err)
+	}
+
+	return nil
+}
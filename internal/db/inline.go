@@ -0,0 +1,190 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type outlinedContent struct {
+	hash    Hash
+	content []byte
+}
+
+// InlineProjectContents moves the loose contents of a project, across all versions, to where encoder stores them:
+// objects within its inline threshold get their content copied into their row and inline contents above it are moved
+// back to dl.contents. It returns how many rows were inlined and outlined along with the inlined hashes, GcContentHashes
+// removes the ones nothing else reads from dl.contents once tx is committed.
+// With dryRun nothing is written and the counts report how many rows would be moved.
+func InlineProjectContents(ctx context.Context, tx pgx.Tx, conn DbConnector, lookup *ContentLookup, encoder *ContentEncoder, project int64, dryRun bool) (int64, int64, []Hash, error) {
+	threshold := encoder.InlineThreshold()
+
+	if dryRun {
+		var inlined, outlined int64
+		err := tx.QueryRow(ctx, `
+			SELECT count(*) FILTER (WHERE inline_content IS NULL AND $2 > 0 AND size <= $2),
+			       count(*) FILTER (WHERE inline_content IS NOT NULL AND NOT ($2 > 0 AND octet_length(inline_content) <= $2))
+			FROM dl.objects
+			WHERE project = $1
+			  AND packed IS false
+		`, project, threshold).Scan(&inlined, &outlined)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("count contents to inline, project %v: %w", project, err)
+		}
+		return inlined, outlined, nil, nil
+	}
+
+	inlined, hashes, err := inlineContents(ctx, tx, lookup, encoder, project)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	outlined, err := outlineContents(ctx, tx, conn, encoder, project)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	return inlined, outlined, hashes, nil
+}
+
+func inlineContents(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, encoder *ContentEncoder, project int64) (int64, []Hash, error) {
+	threshold := encoder.InlineThreshold()
+	if threshold <= 0 {
+		return 0, nil, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT (hash).h1, (hash).h2
+		FROM dl.objects
+		WHERE project = $1
+		  AND packed IS false
+		  AND inline_content IS NULL
+		  AND size <= $2
+	`, project, threshold)
+	if err != nil {
+		return 0, nil, fmt.Errorf("select contents to inline, project %v: %w", project, err)
+	}
+
+	hashes := make(map[Hash]bool)
+	for rows.Next() {
+		var hash Hash
+		err = rows.Scan(&hash.H1, &hash.H2)
+		if err != nil {
+			rows.Close()
+			return 0, nil, fmt.Errorf("scan content to inline, project %v: %w", project, err)
+		}
+		hashes[hash] = true
+	}
+	rows.Close()
+
+	err = rows.Err()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	if len(hashes) == 0 {
+		return 0, nil, nil
+	}
+
+	contents, err := lookup.Lookup(ctx, tx, hashes)
+	if err != nil {
+		return 0, nil, fmt.Errorf("lookup contents to inline, project %v: %w", project, err)
+	}
+
+	var count int64
+	var inlined []Hash
+	for hash := range hashes {
+		content, ok := contents[hash]
+		if !ok {
+			return 0, nil, fmt.Errorf("content %v to inline missing, project %v: %w", hash.Hex(), project, ErrNotFound)
+		}
+		// the recorded size comes from the client, the content itself decides
+		if !encoder.Inlines(len(content)) {
+			continue
+		}
+		if content == nil {
+			content = []byte("")
+		}
+
+		tag, err := tx.Exec(ctx, `
+			UPDATE dl.objects
+			SET inline_content = $1
+			WHERE project = $2
+			  AND hash = ($3, $4)
+			  AND packed IS false
+			  AND inline_content IS NULL
+		`, content, project, hash.H1, hash.H2)
+		if err != nil {
+			return 0, nil, fmt.Errorf("inline content %v, project %v: %w", hash.Hex(), project, err)
+		}
+
+		count += tag.RowsAffected()
+		inlined = append(inlined, hash)
+	}
+
+	return count, inlined, nil
+}
+
+func outlineContents(ctx context.Context, tx pgx.Tx, conn DbConnector, encoder *ContentEncoder, project int64) (int64, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT ON (hash) (hash).h1, (hash).h2, inline_content
+		FROM dl.objects
+		WHERE project = $1
+		  AND inline_content IS NOT NULL
+		  AND NOT ($2 > 0 AND octet_length(inline_content) <= $2)
+	`, project, encoder.InlineThreshold())
+	if err != nil {
+		return 0, fmt.Errorf("select contents to outline, project %v: %w", project, err)
+	}
+
+	var outlines []outlinedContent
+	for rows.Next() {
+		var outline outlinedContent
+		err = rows.Scan(&outline.hash.H1, &outline.hash.H2, &outline.content)
+		if err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan content to outline, project %v: %w", project, err)
+		}
+		outlines = append(outlines, outline)
+	}
+	rows.Close()
+
+	err = rows.Err()
+	if err != nil {
+		return 0, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	var count int64
+	for _, outline := range outlines {
+		encoded, err := encoder.Encode(outline.content)
+		if err != nil {
+			return 0, fmt.Errorf("encode content %v to outline, project %v: %w", outline.hash.Hex(), project, err)
+		}
+
+		// insert the content outside the transaction like updates do, rows only stop reading it inline once tx commits
+		_, err = conn.Exec(ctx, `
+			INSERT INTO dl.contents (hash, bytes, encoding)
+			VALUES (($1, $2), $3, $4)
+			ON CONFLICT DO NOTHING
+		`, outline.hash.H1, outline.hash.H2, encoded, encoder.Encoding())
+		if err != nil {
+			return 0, fmt.Errorf("insert outlined content, hash %x-%x: %w", outline.hash.H1, outline.hash.H2, err)
+		}
+
+		tag, err := tx.Exec(ctx, `
+			UPDATE dl.objects
+			SET inline_content = NULL
+			WHERE project = $1
+			  AND hash = ($2, $3)
+			  AND inline_content IS NOT NULL
+		`, project, outline.hash.H1, outline.hash.H2)
+		if err != nil {
+			return 0, fmt.Errorf("outline content %v, project %v: %w", outline.hash.Hex(), project, err)
+		}
+
+		count += tag.RowsAffected()
+	}
+
+	return count, nil
+}
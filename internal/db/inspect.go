@@ -20,3 +20,19 @@ func TotalObjectsCount(ctx context.Context, tx pgx.Tx, project int64) (int64, er
 
 	return count, nil
 }
+
+// LiveObjectsCount counts the objects of a project at its latest version, a packed directory counts as one object.
+func LiveObjectsCount(ctx context.Context, tx pgx.Tx, project int64) (int64, error) {
+	var count int64
+	err := tx.QueryRow(ctx, `
+		SELECT count(*)
+		FROM dl.objects
+		WHERE project = $1
+		  AND stop_version IS NULL
+	`, project).Scan(&count)
+	if err != nil {
+		return -1, fmt.Errorf("live object count for project %v: %w", project, err)
+	}
+
+	return count, nil
+}
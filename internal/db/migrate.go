@@ -0,0 +1,215 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+// migrationLockKey serializes migrations of servers starting concurrently against the same database.
+const migrationLockKey = "dl.schema_migrations"
+
+var (
+	migrationFileRegexp = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+	// ErrDirtyMigration is returned when a previous migration failed halfway, the schema must be repaired by hand.
+	ErrDirtyMigration = errors.New("database schema is dirty")
+)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// LoadMigrations reads the NNNNNN_name.up.sql and NNNNNN_name.down.sql files of fsys, sorted by version.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := migrationFileRegexp.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse migration version %v: %w", entry.Name(), err)
+		}
+
+		sql, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read migration %v: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %v has two names: %v and %v", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(sql)
+		} else {
+			migration.Down = string(sql)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// MigrationVersion returns the version of the last applied migration, 0 when none were, and whether it failed halfway.
+// Versions are tracked in the same schema_migrations table as golang-migrate so both tools can be used on a database.
+func MigrationVersion(ctx context.Context, conn *pgx.Conn) (int64, bool, error) {
+	_, err := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version bigint NOT NULL PRIMARY KEY, dirty boolean NOT NULL)`)
+	if err != nil {
+		return 0, false, fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	var version int64
+	var dirty bool
+	err = conn.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("read schema_migrations: %w", err)
+	}
+
+	return max(version, 0), dirty, nil
+}
+
+// Migrate applies the up or down migrations moving the schema to target, -1 for the latest migration.
+// It returns the version the schema was at before migrating. Each migration runs outside of a transaction,
+// as golang-migrate does, and marks the schema dirty until it completes.
+func Migrate(ctx context.Context, conn *pgx.Conn, migrations []Migration, target int64) (int64, error) {
+	if target == -1 && len(migrations) > 0 {
+		target = migrations[len(migrations)-1].Version
+	}
+	if target < 0 {
+		target = 0
+	}
+	if target != 0 && !slices.ContainsFunc(migrations, func(m Migration) bool { return m.Version == target }) {
+		return 0, fmt.Errorf("migrate: unknown target version %v", target)
+	}
+
+	_, err := conn.Exec(ctx, `SELECT pg_advisory_lock(hashtext($1))`, migrationLockKey)
+	if err != nil {
+		return 0, fmt.Errorf("migrate: lock: %w", err)
+	}
+	defer func() {
+		_, _ = conn.Exec(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, migrationLockKey)
+	}()
+
+	current, dirty, err := MigrationVersion(ctx, conn)
+	if err != nil {
+		return 0, fmt.Errorf("migrate: %w", err)
+	}
+	if dirty {
+		return current, fmt.Errorf("migrate: version %v: %w", current, ErrDirtyMigration)
+	}
+
+	if target >= current {
+		for _, migration := range migrations {
+			if migration.Version <= current || migration.Version > target {
+				continue
+			}
+
+			err = applyMigration(ctx, conn, migration.Version, migration.Up, migration.Version)
+			if err != nil {
+				return current, err
+			}
+			logger.Info(ctx, "applied migration", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+		}
+		return current, nil
+	}
+
+	for idx := len(migrations) - 1; idx >= 0; idx-- {
+		migration := migrations[idx]
+		if migration.Version > current || migration.Version <= target {
+			continue
+		}
+
+		previous := int64(0)
+		if idx > 0 {
+			previous = migrations[idx-1].Version
+		}
+
+		err = applyMigration(ctx, conn, migration.Version, migration.Down, previous)
+		if err != nil {
+			return current, err
+		}
+		logger.Info(ctx, "reverted migration", zap.Int64("version", migration.Version), zap.String("name", migration.Name))
+	}
+
+	return current, nil
+}
+
+func applyMigration(ctx context.Context, conn *pgx.Conn, version int64, sql string, resultVersion int64) error {
+	err := setMigrationVersion(ctx, conn, resultVersion, true)
+	if err != nil {
+		return err
+	}
+
+	// without arguments the whole file is sent with the simple protocol, so it may hold several statements
+	_, err = conn.Exec(ctx, sql)
+	if err != nil {
+		return fmt.Errorf("migrate: run migration %v: %w", version, err)
+	}
+
+	return setMigrationVersion(ctx, conn, resultVersion, false)
+}
+
+func setMigrationVersion(ctx context.Context, conn *pgx.Conn, version int64, dirty bool) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("migrate: set version %v: %w", version, err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx, `TRUNCATE schema_migrations`)
+	if err != nil {
+		return fmt.Errorf("migrate: set version %v: %w", version, err)
+	}
+
+	// golang-migrate leaves the table empty for a schema without migrations, or stores -1 while reverting the first one
+	if version == 0 && dirty {
+		version = -1
+	}
+	if version != 0 {
+		_, err = tx.Exec(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)`, version, dirty)
+		if err != nil {
+			return fmt.Errorf("migrate: set version %v: %w", version, err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("migrate: set version %v: %w", version, err)
+	}
+
+	return nil
+}
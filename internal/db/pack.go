@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type rewrittenPack struct {
+	before  Hash
+	after   Hash
+	content []byte
+}
+
+// CanonicalizeProjectPacks rewrites every pack of a project, across all versions, into its canonical form.
+// Rows are pointed at the new content and the old content is left for GcContents to collect.
+// With dryRun nothing is written and the counts report how many packs would be rewritten.
+func CanonicalizeProjectPacks(ctx context.Context, tx pgx.Tx, conn DbConnector, project int64, dryRun bool) (int64, int64, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT (c.hash).h1, (c.hash).h2, c.bytes
+		FROM dl.objects o
+		JOIN dl.contents c
+		  ON o.hash = c.hash
+		WHERE o.project = $1
+		  AND o.packed IS true
+	`, project)
+	if err != nil {
+		return 0, 0, fmt.Errorf("select packs, project %v: %w", project, err)
+	}
+
+	var checked int64
+	var rewrites []rewrittenPack
+
+	for rows.Next() {
+		var hash Hash
+		var content []byte
+		err = rows.Scan(&hash.H1, &hash.H2, &content)
+		if err != nil {
+			rows.Close()
+			return 0, 0, fmt.Errorf("scan pack, project %v: %w", project, err)
+		}
+
+		checked += 1
+
+		canonical, err := CanonicalizePack(content)
+		if err != nil {
+			rows.Close()
+			return 0, 0, fmt.Errorf("canonicalize pack %x-%x, project %v: %w", hash.H1, hash.H2, project, err)
+		}
+
+		newHash := HashContent(canonical)
+		if newHash == hash {
+			continue
+		}
+
+		rewrites = append(rewrites, rewrittenPack{before: hash, after: newHash, content: canonical})
+	}
+	rows.Close()
+
+	err = rows.Err()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	if dryRun {
+		return checked, int64(len(rewrites)), nil
+	}
+
+	for _, rewrite := range rewrites {
+		index, err := BuildPackIndex(rewrite.content)
+		if err != nil {
+			return 0, 0, fmt.Errorf("index canonical pack, hash %x-%x: %w", rewrite.after.H1, rewrite.after.H2, err)
+		}
+
+		// insert the content outside the transaction to avoid deadlocks and to keep smaller transactions
+		_, err = conn.Exec(ctx, `
+			INSERT INTO dl.contents (hash, bytes, pack_index)
+			VALUES (($1, $2), $3, $4)
+			ON CONFLICT DO NOTHING
+		`, rewrite.after.H1, rewrite.after.H2, rewrite.content, index)
+		if err != nil {
+			return 0, 0, fmt.Errorf("insert canonical pack, hash %x-%x: %w", rewrite.after.H1, rewrite.after.H2, err)
+		}
+
+		err = InsertPackEntries(ctx, conn, rewrite.after, rewrite.content)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE dl.objects
+			SET hash = ($1, $2), size = $3
+			WHERE project = $4
+			  AND packed IS true
+			  AND hash = ($5, $6)
+		`, rewrite.after.H1, rewrite.after.H2, len(rewrite.content), project, rewrite.before.H1, rewrite.before.H2)
+		if err != nil {
+			return 0, 0, fmt.Errorf("update pack hash %x-%x, project %v: %w", rewrite.before.H1, rewrite.before.H2, project, err)
+		}
+	}
+
+	return checked, int64(len(rewrites)), nil
+}
@@ -0,0 +1,377 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/jackc/pgx/v5"
+)
+
+// packEntry describes an object stored in a pack, as recorded in dl.pack_entries.
+type packEntry struct {
+	path string
+	mode int64
+	size int64
+	hash Hash
+}
+
+func (e packEntry) stat() *pb.PathStat {
+	return &pb.PathStat{
+		Path:   e.path,
+		Exists: true,
+		Size:   e.size,
+		Mode:   e.mode,
+		Hash:   e.hash.Bytes(),
+	}
+}
+
+func readPackEntries(pack []byte) ([]packEntry, error) {
+	tarReader := NewTarReader()
+	defer tarReader.Close()
+	tarReader.FromBytes(pack)
+
+	objects, err := unpackObjects(tarReader)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]packEntry, 0, len(objects))
+	for _, object := range objects {
+		entries = append(entries, packEntry{
+			path: object.Path,
+			mode: object.Mode,
+			size: object.Size,
+			hash: HashContent(object.Content),
+		})
+	}
+
+	return entries, nil
+}
+
+// InsertPackEntries records the entries of a pack in dl.pack_entries so packed paths can be listed and stat'd without
+// decoding the pack. Entries are keyed by the pack's hash, every project storing the same pack shares them.
+func InsertPackEntries(ctx context.Context, conn DbConnector, hash Hash, pack []byte) error {
+	entries, err := readPackEntries(pack)
+	if err != nil {
+		return fmt.Errorf("read pack entries, hash %x-%x: %w", hash.H1, hash.H2, err)
+	}
+
+	paths := make([]string, 0, len(entries))
+	modes := make([]int64, 0, len(entries))
+	sizes := make([]int64, 0, len(entries))
+	hashes := make([]Hash, 0, len(entries))
+	for _, entry := range entries {
+		paths = append(paths, entry.path)
+		modes = append(modes, entry.mode)
+		sizes = append(sizes, entry.size)
+		hashes = append(hashes, entry.hash)
+	}
+
+	_, err = conn.Exec(ctx, `
+		INSERT INTO dl.pack_entries (hash, path, mode, size, content_hash)
+		SELECT ($1, $2)::hash, e.path, e.mode, e.size, e.content_hash
+		FROM unnest($3::text[], $4::bigint[], $5::bigint[], $6::hash[]) AS e(path, mode, size, content_hash)
+		ON CONFLICT DO NOTHING
+	`, hash.H1, hash.H2, paths, modes, sizes, hashes)
+	if err != nil {
+		return fmt.Errorf("insert pack entries, hash %x-%x: %w", hash.H1, hash.H2, err)
+	}
+
+	return nil
+}
+
+// IndexProjectPacks records the entries of every pack of a project, across all versions, that were written before
+// packs were indexed. With dryRun nothing is written and the counts report how many packs are missing from the index.
+func IndexProjectPacks(ctx context.Context, tx pgx.Tx, conn DbConnector, project int64, dryRun bool) (int64, int64, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT (c.hash).h1, (c.hash).h2, c.bytes, c.pack_index IS NULL
+		FROM dl.objects o
+		JOIN dl.contents c
+		  ON o.hash = c.hash
+		WHERE o.project = $1
+		  AND o.packed IS true
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM dl.pack_entries e
+			WHERE e.hash = c.hash
+		  )
+	`, project)
+	if err != nil {
+		return 0, 0, fmt.Errorf("select unindexed packs, project %v: %w", project, err)
+	}
+
+	type unindexedPack struct {
+		hash      Hash
+		content   []byte
+		noOffsets bool
+	}
+
+	var packs []unindexedPack
+	for rows.Next() {
+		var pack unindexedPack
+		err = rows.Scan(&pack.hash.H1, &pack.hash.H2, &pack.content, &pack.noOffsets)
+		if err != nil {
+			rows.Close()
+			return 0, 0, fmt.Errorf("scan unindexed pack, project %v: %w", project, err)
+		}
+		packs = append(packs, pack)
+	}
+	rows.Close()
+
+	err = rows.Err()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	var checked int64
+	err = tx.QueryRow(ctx, `
+		SELECT count(DISTINCT hash)
+		FROM dl.objects
+		WHERE project = $1
+		  AND packed IS true
+	`, project).Scan(&checked)
+	if err != nil {
+		return 0, 0, fmt.Errorf("count packs, project %v: %w", project, err)
+	}
+
+	if dryRun {
+		return checked, int64(len(packs)), nil
+	}
+
+	for _, pack := range packs {
+		err = InsertPackEntries(ctx, conn, pack.hash, pack.content)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if !pack.noOffsets {
+			continue
+		}
+
+		// packs missing from the entry index predate the offset index too, store it while the pack is at hand
+		index, err := BuildPackIndex(pack.content)
+		if err != nil {
+			return 0, 0, fmt.Errorf("index pack, hash %x-%x: %w", pack.hash.H1, pack.hash.H2, err)
+		}
+
+		_, err = conn.Exec(ctx, `
+			UPDATE dl.contents
+			SET pack_index = $3
+			WHERE hash = ($1, $2)
+			  AND pack_index IS NULL
+		`, pack.hash.H1, pack.hash.H2, index)
+		if err != nil {
+			return 0, 0, fmt.Errorf("store pack index, hash %x-%x: %w", pack.hash.H1, pack.hash.H2, err)
+		}
+	}
+
+	return checked, int64(len(packs)), nil
+}
+
+// livePack is a pack row of a project existing at a version.
+type livePack struct {
+	path    string
+	hash    Hash
+	indexed bool
+}
+
+// selectLivePacks returns the packs existing at version stored at one of paths, or under or above prefix when paths is nil.
+func selectLivePacks(ctx context.Context, tx pgx.Tx, project int64, version int64, paths []string, prefix string) ([]livePack, error) {
+	pathPredicate := "AND o.path = ANY($3::text[])"
+	args := []any{project, version, paths}
+	if paths == nil {
+		pathPredicate = "AND (o.path LIKE $3 OR starts_with($4, o.path))"
+		args = []any{project, version, prefix + "%", prefix}
+	}
+
+	rows, err := tx.Query(ctx, fmt.Sprintf(`
+		SELECT o.path, (o.hash).h1, (o.hash).h2, EXISTS (
+			SELECT 1
+			FROM dl.pack_entries e
+			WHERE e.hash = o.hash
+		)
+		FROM dl.objects o
+		WHERE o.project = $1
+		  AND o.packed IS true
+		  AND o.start_version <= $2
+		  AND (o.stop_version IS NULL OR o.stop_version > $2)
+		  %s
+	`, pathPredicate), args...)
+	if err != nil {
+		return nil, fmt.Errorf("select live packs, project %v version %v: %w", project, version, err)
+	}
+	defer rows.Close()
+
+	var packs []livePack
+	for rows.Next() {
+		var pack livePack
+		err = rows.Scan(&pack.path, &pack.hash.H1, &pack.hash.H2, &pack.indexed)
+		if err != nil {
+			return nil, fmt.Errorf("scan live pack, project %v: %w", project, err)
+		}
+		packs = append(packs, pack)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return packs, nil
+}
+
+// selectPackEntries returns the indexed entries of packs at paths, or under prefix when paths is nil.
+// When limit is positive at most limit entries are returned, ordered by path.
+func selectPackEntries(ctx context.Context, tx pgx.Tx, packs []livePack, paths []string, prefix string, limit int64) ([]packEntry, error) {
+	var hashes []Hash
+	for _, pack := range packs {
+		if pack.indexed {
+			hashes = append(hashes, pack.hash)
+		}
+	}
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	pathPredicate := "AND e.path = ANY($2::text[])"
+	var filter any = paths
+	if paths == nil {
+		pathPredicate = "AND e.path LIKE $2"
+		filter = prefix + "%"
+	}
+
+	limitClause := ""
+	if limit > 0 {
+		limitClause = fmt.Sprintf("ORDER BY e.path LIMIT %d", limit)
+	}
+
+	rows, err := tx.Query(ctx, fmt.Sprintf(`
+		SELECT e.path, e.mode, e.size, (e.content_hash).h1, (e.content_hash).h2
+		FROM dl.pack_entries e
+		WHERE e.hash = ANY($1::hash[])
+		  %s
+		%s
+	`, pathPredicate, limitClause), hashes, filter)
+	if err != nil {
+		return nil, fmt.Errorf("select pack entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []packEntry
+	for rows.Next() {
+		var entry packEntry
+		err = rows.Scan(&entry.path, &entry.mode, &entry.size, &entry.hash.H1, &entry.hash.H2)
+		if err != nil {
+			return nil, fmt.Errorf("scan pack entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return entries, nil
+}
+
+// decodePackEntries reads the entries of the packs missing from the index from their content.
+func decodePackEntries(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, packs []livePack) ([]packEntry, error) {
+	hashes := make(map[Hash]bool)
+	for _, pack := range packs {
+		if !pack.indexed {
+			hashes[pack.hash] = false
+		}
+	}
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	contents, err := lookup.Lookup(ctx, tx, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []packEntry
+	for hash := range hashes {
+		packEntries, err := readPackEntries(contents[hash])
+		if err != nil {
+			return nil, fmt.Errorf("read pack entries, hash %x-%x: %w", hash.H1, hash.H2, err)
+		}
+		entries = append(entries, packEntries...)
+	}
+
+	return entries, nil
+}
+
+// ListPaths returns the paths under prefix existing at version, loose and packed, ordered by path. At most limit paths
+// are returned and the boolean reports whether more matched. Packed paths are read from the entry index, only packs
+// written before it existed are decoded.
+func ListPaths(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, project int64, version int64, prefix string, limit int64) ([]*pb.PathStat, bool, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT o.path, o.mode, o.size, (o.hash).h1, (o.hash).h2, o.content_type
+		FROM dl.objects o
+		WHERE o.project = $1
+		  AND o.packed IS false
+		  AND o.path LIKE $2
+		  AND o.start_version <= $3
+		  AND (o.stop_version IS NULL OR o.stop_version > $3)
+		ORDER BY o.path
+		LIMIT $4
+	`, project, prefix+"%", version, limit+1)
+	if err != nil {
+		return nil, false, fmt.Errorf("list paths, project %v version %v prefix %v: %w", project, version, prefix, err)
+	}
+
+	var stats []*pb.PathStat
+	for rows.Next() {
+		var hash Hash
+		stat := &pb.PathStat{Exists: true}
+		err = rows.Scan(&stat.Path, &stat.Mode, &stat.Size, &hash.H1, &hash.H2, &stat.ContentType)
+		if err != nil {
+			rows.Close()
+			return nil, false, fmt.Errorf("list paths scan, project %v: %w", project, err)
+		}
+		stat.Hash = hash.Bytes()
+		stats = append(stats, stat)
+	}
+	rows.Close()
+
+	err = rows.Err()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	packs, err := selectLivePacks(ctx, tx, project, version, nil, prefix)
+	if err != nil {
+		return nil, false, err
+	}
+
+	entries, err := selectPackEntries(ctx, tx, packs, nil, prefix, limit+1)
+	if err != nil {
+		return nil, false, fmt.Errorf("list packed paths, project %v version %v: %w", project, version, err)
+	}
+
+	decoded, err := decodePackEntries(ctx, tx, lookup, packs)
+	if err != nil {
+		return nil, false, fmt.Errorf("list unindexed packed paths, project %v version %v: %w", project, version, err)
+	}
+
+	for _, entry := range append(entries, decoded...) {
+		if strings.HasPrefix(entry.path, prefix) {
+			stats = append(stats, entry.stat())
+		}
+	}
+
+	slices.SortFunc(stats, func(a, b *pb.PathStat) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	if int64(len(stats)) > limit {
+		return stats[:limit], true, nil
+	}
+	return stats, false, nil
+}
@@ -0,0 +1,215 @@
+package db
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/internal/transform"
+	"github.com/jackc/pgx/v5"
+	"github.com/klauspost/compress/s2"
+)
+
+var ErrInvalidPackIndex = errors.New("invalid pack index")
+
+// packIndex locates the entries of a pack without decompressing all of it: offsets maps every path to the offset of
+// its tar header in the decompressed stream and s2 maps decompressed offsets to the compressed blocks holding them.
+type packIndex struct {
+	s2      []byte
+	offsets map[string]int64
+}
+
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.count += int64(n)
+	return n, err
+}
+
+// BuildPackIndex indexes the entries of a pack, the result is stored alongside the pack content.
+func BuildPackIndex(pack []byte) ([]byte, error) {
+	s2Index, err := s2.IndexStream(bytes.NewReader(pack))
+	if err != nil {
+		return nil, fmt.Errorf("index pack stream: %w", err)
+	}
+
+	s2Reader := s2Readers.Get().(*s2.Reader)
+	defer releaseS2Reader(s2Reader)
+	s2Reader.Reset(bytes.NewReader(pack))
+
+	counter := &countingReader{reader: s2Reader}
+	tarReader := tar.NewReader(counter)
+
+	encoded := binary.AppendUvarint(nil, uint64(len(s2Index)))
+	encoded = append(encoded, s2Index...)
+
+	for {
+		// entries start on a block boundary once the padding of the previous content is skipped
+		offset := (counter.count + 511) / 512 * 512
+
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return encoded, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("index pack entry: %w", err)
+		}
+
+		_, err = io.Copy(io.Discard, tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("index pack entry %v: %w", header.Name, err)
+		}
+
+		encoded = binary.AppendUvarint(encoded, uint64(len(header.Name)))
+		encoded = append(encoded, header.Name...)
+		encoded = binary.AppendUvarint(encoded, uint64(offset))
+	}
+}
+
+func decodePackIndex(encoded []byte) (*packIndex, error) {
+	readUvarint := func() (uint64, error) {
+		value, n := binary.Uvarint(encoded)
+		if n <= 0 {
+			return 0, ErrInvalidPackIndex
+		}
+		encoded = encoded[n:]
+		return value, nil
+	}
+
+	readBytes := func() ([]byte, error) {
+		length, err := readUvarint()
+		if err != nil || uint64(len(encoded)) < length {
+			return nil, ErrInvalidPackIndex
+		}
+		value := encoded[:length]
+		encoded = encoded[length:]
+		return value, nil
+	}
+
+	s2Index, err := readBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	index := &packIndex{s2: s2Index, offsets: make(map[string]int64)}
+	for len(encoded) > 0 {
+		path, err := readBytes()
+		if err != nil {
+			return nil, err
+		}
+
+		offset, err := readUvarint()
+		if err != nil {
+			return nil, err
+		}
+
+		index.offsets[string(path)] = int64(offset)
+	}
+
+	return index, nil
+}
+
+// loadPackIndex returns the index of a pack, reading it from the pack's content row. Packs written before indexes were
+// stored are indexed on the fly, either way the index is kept in the content cache.
+func (cl *ContentLookup) loadPackIndex(ctx context.Context, tx pgx.Tx, hash Hash, pack []byte) (*packIndex, error) {
+	cacheKey := "pack_index:" + hash.Hex()
+	if value, found := cl.cache.Get(cacheKey); found {
+		return value.(*packIndex), nil
+	}
+
+	var encoded []byte
+	err := tx.QueryRow(ctx, `
+		SELECT pack_index
+		FROM dl.contents
+		WHERE hash = ($1, $2)
+	`, hash.H1, hash.H2).Scan(&encoded)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("select pack index %v: %w", hash.Hex(), err)
+	}
+
+	if encoded == nil {
+		encoded, err = BuildPackIndex(pack)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	index, err := decodePackIndex(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode pack index %v: %w", hash.Hex(), err)
+	}
+
+	cl.cache.Set(cacheKey, index, int64(len(encoded)))
+	return index, nil
+}
+
+// extract writes the entries of pack at paths to tarWriter, seeking straight to each of them.
+// Paths missing from the pack are written as deleted objects so a client holding an older copy removes it.
+// Entries are written through transforms when it is set.
+func (i *packIndex) extract(pack []byte, paths []string, tarWriter *TarWriter, transforms *transform.Set, transformVars map[string]string) error {
+	s2Reader := s2Readers.Get().(*s2.Reader)
+	defer releaseS2Reader(s2Reader)
+	s2Reader.Reset(bytes.NewReader(pack))
+
+	reader, err := s2Reader.ReadSeeker(true, i.s2)
+	if err != nil {
+		return fmt.Errorf("seek pack: %w", err)
+	}
+
+	for _, path := range paths {
+		offset, ok := i.offsets[path]
+		if !ok {
+			tarObject := NewUncachedTarObject(path, 0, 0, true, nil)
+			err = tarWriter.WriteObject(&tarObject)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		_, err = reader.Seek(offset, io.SeekStart)
+		if err != nil {
+			return fmt.Errorf("seek pack entry %v: %w", path, err)
+		}
+
+		tarReader := tar.NewReader(reader)
+		header, err := tarReader.Next()
+		if err != nil {
+			return fmt.Errorf("read pack entry %v: %w", path, err)
+		}
+		if header.Name != path {
+			return fmt.Errorf("read pack entry %v: found %v at its offset: %w", path, header.Name, ErrInvalidPackIndex)
+		}
+
+		content := make([]byte, header.Size)
+		_, err = io.ReadFull(tarReader, content)
+		if err != nil {
+			return fmt.Errorf("read pack entry %v: %w", path, err)
+		}
+
+		object := pb.ObjectFromTarHeader(header, content)
+		if transforms != nil {
+			object, err = transforms.Apply(object, transformVars)
+			if err != nil {
+				return err
+			}
+		}
+
+		tarObject := NewUncachedTarObject(object.Path, object.Mode, object.Size, false, object.Content)
+		err = tarWriter.WriteObject(&tarObject)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,273 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/jackc/pgx/v5"
+)
+
+type pathRow struct {
+	path  string
+	start int64
+	stop  *int64
+	hash  Hash
+	mode  int64
+}
+
+func (r pathRow) end() int64 {
+	if r.stop == nil {
+		return math.MaxInt64
+	}
+	return *r.stop
+}
+
+func (r pathRow) overlaps(other pathRow) bool {
+	return r.start < other.end() && other.start < r.end()
+}
+
+// covers reports whether rows hold the same object as row over every version row is live.
+func covers(row pathRow, rows []pathRow) bool {
+	slices.SortFunc(rows, func(a, b pathRow) int {
+		return cmp.Compare(a.start, b.start)
+	})
+
+	cursor := row.start
+	for _, other := range rows {
+		if other.hash != row.hash || other.mode != row.mode || other.start > cursor {
+			return false
+		}
+		cursor = max(cursor, other.end())
+	}
+
+	return cursor >= row.end()
+}
+
+func scanPathRows(rows pgx.Rows) ([]pathRow, error) {
+	var result []pathRow
+	for rows.Next() {
+		var row pathRow
+		err := rows.Scan(&row.path, &row.start, &row.stop, &row.hash.H1, &row.hash.H2, &row.mode)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// RepairProjectPaths moves every row of a project stored under a non canonical spelling of its path to the canonical one,
+// across all versions. A row is renamed when nothing is stored at the canonical path over its versions, removed when the
+// canonical path holds the same object over all of them and reported as a conflict otherwise. Rows at an absolute path
+// whose relative spelling is live over the same versions are reported as ambiguous and left as they are.
+// Renames are done in place without a new version: clients already wrote both spellings to the same file.
+// With dryRun nothing is written and the repairs report what would be done.
+func RepairProjectPaths(ctx context.Context, tx pgx.Tx, project int64, dryRun bool) (int64, []*pb.PathRepair, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT path, start_version, stop_version, (hash).h1, (hash).h2, mode
+		FROM dl.objects
+		WHERE project = $1
+		  AND (path ~ '(^|/)\.(/|$)' OR path LIKE '%//%')
+		ORDER BY path, start_version
+	`, project)
+	if err != nil {
+		return 0, nil, fmt.Errorf("select non canonical paths, project %v: %w", project, err)
+	}
+
+	candidates, err := scanPathRows(rows)
+	rows.Close()
+	if err != nil {
+		return 0, nil, fmt.Errorf("scan non canonical paths, project %v: %w", project, err)
+	}
+
+	// rows renamed by earlier repairs, they are only missing from the table in a dry run
+	renamed := make(map[string][]pathRow)
+
+	var checked int64
+	var repairs []*pb.PathRepair
+
+	for _, row := range candidates {
+		canonical, err := pb.CanonicalPath(row.path)
+		if err != nil || canonical == row.path {
+			continue
+		}
+		checked += 1
+
+		existing, err := tx.Query(ctx, `
+			SELECT path, start_version, stop_version, (hash).h1, (hash).h2, mode
+			FROM dl.objects
+			WHERE project = $1
+			  AND path = $2
+			  AND start_version < $3
+			  AND (stop_version IS NULL OR stop_version > $4)
+		`, project, canonical, row.end(), row.start)
+		if err != nil {
+			return 0, nil, fmt.Errorf("select canonical path %v, project %v: %w", canonical, project, err)
+		}
+
+		overlapping, err := scanPathRows(existing)
+		existing.Close()
+		if err != nil {
+			return 0, nil, fmt.Errorf("scan canonical path %v, project %v: %w", canonical, project, err)
+		}
+
+		for _, other := range renamed[canonical] {
+			if other.overlaps(row) {
+				overlapping = append(overlapping, other)
+			}
+		}
+
+		repair := &pb.PathRepair{
+			Path:          row.path,
+			CanonicalPath: canonical,
+			StartVersion:  row.start,
+			StopVersion:   row.stop,
+		}
+
+		switch {
+		case len(overlapping) == 0:
+			repair.Action = pb.PathRepair_RENAMED
+			if dryRun {
+				row.path = canonical
+				renamed[canonical] = append(renamed[canonical], row)
+				break
+			}
+
+			_, err = tx.Exec(ctx, `
+				UPDATE dl.objects
+				SET path = $1
+				WHERE project = $2
+				  AND path = $3
+				  AND start_version = $4
+			`, canonical, project, row.path, row.start)
+			if err != nil {
+				return 0, nil, fmt.Errorf("rename path %v to %v, project %v: %w", row.path, canonical, project, err)
+			}
+
+		case covers(row, overlapping):
+			repair.Action = pb.PathRepair_MERGED
+			if dryRun {
+				break
+			}
+
+			_, err = tx.Exec(ctx, `
+				DELETE FROM dl.objects
+				WHERE project = $1
+				  AND path = $2
+				  AND start_version = $3
+			`, project, row.path, row.start)
+			if err != nil {
+				return 0, nil, fmt.Errorf("remove duplicate path %v of %v, project %v: %w", row.path, canonical, project, err)
+			}
+
+		default:
+			repair.Action = pb.PathRepair_CONFLICT
+		}
+
+		repairs = append(repairs, repair)
+	}
+
+	ambiguous, err := ambiguousPathRepairs(ctx, tx, project)
+	if err != nil {
+		return 0, nil, err
+	}
+	repairs = append(repairs, ambiguous...)
+
+	return checked, repairs, nil
+}
+
+// ambiguousPathRepairs reports the rows stored at an absolute path while the relative spelling of the same path is live
+// over some of their versions. Both spellings are canonical so neither is rewritten, but they check out to the same file.
+func ambiguousPathRepairs(ctx context.Context, tx pgx.Tx, project int64) ([]*pb.PathRepair, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT a.path, a.start_version, a.stop_version, (a.hash).h1, (a.hash).h2, a.mode
+		FROM dl.objects a
+		WHERE a.project = $1
+		  AND a.path LIKE '/%'
+		  AND EXISTS (
+			SELECT 1
+			FROM dl.objects r
+			WHERE r.project = a.project
+			  AND r.path = substr(a.path, 2)
+			  AND (a.stop_version IS NULL OR r.start_version < a.stop_version)
+			  AND (r.stop_version IS NULL OR r.stop_version > a.start_version)
+		  )
+		ORDER BY a.path, a.start_version
+	`, project)
+	if err != nil {
+		return nil, fmt.Errorf("select ambiguous paths, project %v: %w", project, err)
+	}
+
+	ambiguous, err := scanPathRows(rows)
+	rows.Close()
+	if err != nil {
+		return nil, fmt.Errorf("scan ambiguous paths, project %v: %w", project, err)
+	}
+
+	repairs := make([]*pb.PathRepair, 0, len(ambiguous))
+	for _, row := range ambiguous {
+		repairs = append(repairs, &pb.PathRepair{
+			Path:          row.path,
+			CanonicalPath: row.path[1:],
+			StartVersion:  row.start,
+			StopVersion:   row.stop,
+			Action:        pb.PathRepair_AMBIGUOUS,
+		})
+	}
+
+	return repairs, nil
+}
+
+// ValidateProjectPaths checks every path ever stored in a project and returns how many were checked along with up to
+// limit paths that fail pb.ValidatePath, so rows written before paths were validated can be found before they break a checkout.
+func ValidateProjectPaths(ctx context.Context, tx pgx.Tx, project int64, limit int64) (int64, []*pb.InvalidPath, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT path, bool_or(stop_version IS NULL)
+		FROM dl.objects
+		WHERE project = $1
+		GROUP BY path
+		ORDER BY path
+	`, project)
+	if err != nil {
+		return 0, nil, fmt.Errorf("select paths, project %v: %w", project, err)
+	}
+	defer rows.Close()
+
+	var checked int64
+	var invalid []*pb.InvalidPath
+
+	for rows.Next() {
+		var path []byte
+		var live bool
+		err = rows.Scan(&path, &live)
+		if err != nil {
+			return 0, nil, fmt.Errorf("scan path, project %v: %w", project, err)
+		}
+
+		checked += 1
+
+		var pathErr *pb.PathError
+		if !errors.As(pb.ValidatePath(string(path)), &pathErr) || int64(len(invalid)) >= limit {
+			continue
+		}
+
+		invalid = append(invalid, &pb.InvalidPath{
+			Path:        path,
+			Reason:      pathErr.Reason,
+			Description: pathErr.Description,
+			Live:        live,
+		})
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return checked, invalid, nil
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"sync"
+
+	"github.com/klauspost/compress/s2"
+)
+
+// S2 readers and writers hold buffers the size of a block, a megabyte by default, so they are pooled rather than
+// allocated for every request, pack or content they encode or decode.
+var (
+	s2Readers = sync.Pool{
+		New: func() any { return s2.NewReader(nil) },
+	}
+	s2Writers = sync.Pool{
+		New: func() any { return s2.NewWriter(nil) },
+	}
+	// s2SerialWriters encode small contents, where compressing blocks concurrently is not worth its goroutine
+	s2SerialWriters = sync.Pool{
+		New: func() any { return s2.NewWriter(nil, s2.WriterConcurrency(1)) },
+	}
+)
+
+// releaseS2Writer hands a closed writer back to its pool, it must not be used afterwards.
+func releaseS2Writer(pool *sync.Pool, writer *s2.Writer) {
+	writer.Reset(nil)
+	pool.Put(writer)
+}
+
+// releaseS2Reader hands a reader back to the pool, it must not be used afterwards.
+func releaseS2Reader(reader *s2.Reader) {
+	reader.Reset(nil)
+	s2Readers.Put(reader)
+}
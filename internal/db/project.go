@@ -9,11 +9,11 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
-func CreateProject(ctx context.Context, tx pgx.Tx, project int64, packPatterns []string) error {
+func CreateProject(ctx context.Context, tx pgx.Tx, project int64, packPatterns []string, transforms []string) error {
 	_, err := tx.Exec(ctx, `
-		INSERT INTO dl.projects (id, latest_version, pack_patterns)
-		VALUES ($1, 0, $2)
-	`, project, packPatterns)
+		INSERT INTO dl.projects (id, latest_version, pack_patterns, transforms)
+		VALUES ($1, 0, $2, $3)
+	`, project, packPatterns, transforms)
 
 	var projectExistsError = errors.New("ERROR: duplicate key value violates unique constraint \"projects_pkey\" (SQLSTATE 23505)")
 
@@ -28,6 +28,20 @@ func CreateProject(ctx context.Context, tx pgx.Tx, project int64, packPatterns [
 	return nil
 }
 
+// SetProjectTemplate records the project a new project was created from, projects sharing a template form a family in content stats.
+func SetProjectTemplate(ctx context.Context, tx pgx.Tx, project int64, template int64) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE dl.projects
+		SET template = $2
+		WHERE id = $1
+	`, project, template)
+	if err != nil {
+		return fmt.Errorf("set project template %v: %w", project, err)
+	}
+
+	return nil
+}
+
 func DeleteProject(ctx context.Context, tx pgx.Tx, project int64) error {
 	_, err := tx.Exec(ctx, `
 		DELETE FROM dl.objects
@@ -37,6 +51,38 @@ func DeleteProject(ctx context.Context, tx pgx.Tx, project int64) error {
 		return fmt.Errorf("delete objects for %v %w", project, err)
 	}
 
+	_, err = tx.Exec(ctx, `
+		DELETE FROM dl.checkout_artifacts
+		WHERE project = $1
+	`, project)
+	if err != nil {
+		return fmt.Errorf("delete checkout artifacts for %v %w", project, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM dl.version_annotations
+		WHERE project = $1
+	`, project)
+	if err != nil {
+		return fmt.Errorf("delete version annotations for %v %w", project, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM dl.idempotency_keys
+		WHERE project = $1
+	`, project)
+	if err != nil {
+		return fmt.Errorf("delete idempotency keys for %v %w", project, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM dl.staged_objects
+		WHERE project = $1
+	`, project)
+	if err != nil {
+		return fmt.Errorf("delete staged objects for %v %w", project, err)
+	}
+
 	_, err = tx.Exec(ctx, `
 		DELETE FROM dl.projects
 		WHERE id = $1
@@ -48,11 +94,17 @@ func DeleteProject(ctx context.Context, tx pgx.Tx, project int64) error {
 	return nil
 }
 
-func ListProjects(ctx context.Context, tx pgx.Tx) ([]*pb.Project, error) {
+// ListProjects returns the projects having every label of selector, all of them when it is empty.
+func ListProjects(ctx context.Context, tx pgx.Tx, selector map[string]string) ([]*pb.Project, error) {
+	if selector == nil {
+		selector = map[string]string{}
+	}
+
 	rows, err := tx.Query(ctx, `
-		SELECT id, latest_version
+		SELECT id, latest_version, labels, include_in_cache, tenant, frozen, frozen_reason
 		FROM dl.projects
-	`)
+		WHERE labels @> $1::jsonb
+	`, selector)
 	if err != nil {
 		return nil, fmt.Errorf("snapshotProjects query: %w", err)
 	}
@@ -61,11 +113,22 @@ func ListProjects(ctx context.Context, tx pgx.Tx) ([]*pb.Project, error) {
 
 	for rows.Next() {
 		var id, version int64
-		err = rows.Scan(&id, &version)
+		var labels map[string]string
+		var includeInCache, frozen bool
+		var tenant, frozenReason *string
+		err = rows.Scan(&id, &version, &labels, &includeInCache, &tenant, &frozen, &frozenReason)
 		if err != nil {
 			return nil, fmt.Errorf("snapshotProjects scan: %w", err)
 		}
-		projects = append(projects, &pb.Project{Id: id, Version: version})
+		projects = append(projects, &pb.Project{
+			Id:             id,
+			Version:        version,
+			Labels:         labels,
+			IncludeInCache: includeInCache,
+			Tenant:         tenant,
+			Frozen:         frozen,
+			FrozenReason:   frozenReason,
+		})
 	}
 
 	err = rows.Err()
@@ -76,6 +139,129 @@ func ListProjects(ctx context.Context, tx pgx.Tx) ([]*pb.Project, error) {
 	return projects, nil
 }
 
+// SetProjectLabels adds or overwrites labels and removes the keys in remove, it returns the resulting labels.
+func SetProjectLabels(ctx context.Context, tx pgx.Tx, project int64, labels map[string]string, remove []string) (map[string]string, error) {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	if remove == nil {
+		remove = []string{}
+	}
+
+	var result map[string]string
+	err := tx.QueryRow(ctx, `
+		UPDATE dl.projects
+		SET labels = (labels - $3::text[]) || $2::jsonb
+		WHERE id = $1
+		RETURNING labels
+	`, project, labels, remove).Scan(&result)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("set project labels %v: %w", project, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("set project labels %v: %w", project, err)
+	}
+
+	return result, nil
+}
+
+func GetProjectLabels(ctx context.Context, tx pgx.Tx, project int64) (map[string]string, error) {
+	var labels map[string]string
+	err := tx.QueryRow(ctx, `
+		SELECT labels
+		FROM dl.projects
+		WHERE id = $1
+	`, project).Scan(&labels)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("get project labels %v: %w", project, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get project labels %v: %w", project, err)
+	}
+
+	return labels, nil
+}
+
+// SetProjectCacheInclusion sets whether the packed objects of a project may be included in shared cache versions.
+// Cache versions created before opting out keep their content, CacheVersionProjects lists the ones a project contributed to.
+func SetProjectCacheInclusion(ctx context.Context, tx pgx.Tx, project int64, include bool) error {
+	tag, err := tx.Exec(ctx, `
+		UPDATE dl.projects
+		SET include_in_cache = $2
+		WHERE id = $1
+	`, project, include)
+	if err != nil {
+		return fmt.Errorf("set project cache inclusion %v: %w", project, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("set project cache inclusion %v: %w", project, ErrNotFound)
+	}
+
+	return nil
+}
+
+// SetProjectFrozen makes a project read-only or writable again, the reason is kept until the project is unfrozen.
+func SetProjectFrozen(ctx context.Context, tx pgx.Tx, project int64, frozen bool, reason *string) error {
+	if !frozen {
+		reason = nil
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE dl.projects
+		SET frozen = $2, frozen_reason = $3
+		WHERE id = $1
+	`, project, frozen, reason)
+	if err != nil {
+		return fmt.Errorf("set project frozen %v: %w", project, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("set project frozen %v: %w", project, ErrNotFound)
+	}
+
+	return nil
+}
+
+// SetProjectTransforms replaces the checkout transforms of a project.
+func SetProjectTransforms(ctx context.Context, tx pgx.Tx, project int64, transforms []string) error {
+	if len(transforms) == 0 {
+		transforms = nil
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE dl.projects
+		SET transforms = $2
+		WHERE id = $1
+	`, project, transforms)
+	if err != nil {
+		return fmt.Errorf("set project transforms %v: %w", project, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("set project transforms %v: %w", project, ErrNotFound)
+	}
+
+	return nil
+}
+
+// ProjectFrozen returns whether a project is frozen and the reason it was frozen for.
+func ProjectFrozen(ctx context.Context, tx pgx.Tx, project int64) (bool, *string, error) {
+	var frozen bool
+	var reason *string
+
+	err := tx.QueryRow(ctx, `
+		SELECT frozen, frozen_reason
+		FROM dl.projects
+		WHERE id = $1
+	`, project).Scan(&frozen, &reason)
+	if err == pgx.ErrNoRows {
+		return false, nil, fmt.Errorf("project frozen %v: %w", project, ErrNotFound)
+	}
+	if err != nil {
+		return false, nil, fmt.Errorf("project frozen %v: %w", project, err)
+	}
+
+	return frozen, reason, nil
+}
+
 func RandomProjects(ctx context.Context, conn DbConnector, sample float32) ([]int64, error) {
 	var projects []int64
 
@@ -132,6 +318,24 @@ func getLatestVersion(ctx context.Context, tx pgx.Tx, project int64) (int64, err
 	return latestVersion, nil
 }
 
+// getVersionBounds returns the latest version of a project and the baseline its history was squashed into.
+func getVersionBounds(ctx context.Context, tx pgx.Tx, project int64) (int64, int64, error) {
+	var latestVersion, baselineVersion int64
+
+	err := tx.QueryRow(ctx, `
+		SELECT latest_version, baseline_version
+		FROM dl.projects WHERE id = $1
+	`, project).Scan(&latestVersion, &baselineVersion)
+	if err == pgx.ErrNoRows {
+		return -1, 0, fmt.Errorf("get version bounds for %v: %w", project, ErrNotFound)
+	}
+	if err != nil {
+		return -1, 0, fmt.Errorf("get version bounds for %v: %w", project, err)
+	}
+
+	return latestVersion, baselineVersion, nil
+}
+
 func LockLatestVersion(ctx context.Context, tx pgx.Tx, project int64) (int64, error) {
 	var latestVersion int64
 
@@ -150,6 +354,35 @@ func LockLatestVersion(ctx context.Context, tx pgx.Tx, project int64) (int64, er
 	return latestVersion, nil
 }
 
+// LockWritableVersion locks the latest version of a project about to be written, it fails with ErrProjectFrozen
+// when the project is frozen.
+func LockWritableVersion(ctx context.Context, tx pgx.Tx, project int64) (int64, error) {
+	var latestVersion int64
+	var frozen bool
+	var reason *string
+
+	err := tx.QueryRow(ctx, `
+		SELECT latest_version, frozen, frozen_reason
+		FROM dl.projects WHERE id = $1
+		FOR UPDATE
+	`, project).Scan(&latestVersion, &frozen, &reason)
+	if err == pgx.ErrNoRows {
+		return -1, fmt.Errorf("lock latest version for %v: %w", project, ErrNotFound)
+	}
+	if err != nil {
+		return -1, fmt.Errorf("lock latest version for %v: %w", project, err)
+	}
+
+	if frozen {
+		if reason != nil {
+			return -1, fmt.Errorf("project %v: %w: %v", project, ErrProjectFrozen, *reason)
+		}
+		return -1, fmt.Errorf("project %v: %w", project, ErrProjectFrozen)
+	}
+
+	return latestVersion, nil
+}
+
 func HasSamePackPattern(ctx context.Context, tx pgx.Tx, project_1 int64, project_2 int64) (bool, error) {
 	var samePackPatterns bool
 
@@ -163,3 +396,66 @@ func HasSamePackPattern(ctx context.Context, tx pgx.Tx, project_1 int64, project
 
 	return samePackPatterns, nil
 }
+
+func ProjectTransforms(ctx context.Context, tx pgx.Tx, project int64) ([]string, error) {
+	var transforms []string
+
+	err := tx.QueryRow(ctx, `
+		SELECT transforms
+		FROM dl.projects
+		WHERE id = $1
+	`, project).Scan(&transforms)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("project transforms %v: %w", project, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("project transforms %v: %w", project, err)
+	}
+
+	return transforms, nil
+}
+
+// ProjectPolicy returns the restrictions updates of a project must follow.
+func ProjectPolicy(ctx context.Context, tx pgx.Tx, project int64) (*pb.ProjectPolicy, error) {
+	policy := &pb.ProjectPolicy{}
+
+	err := tx.QueryRow(ctx, `
+		SELECT max_file_size, forbidden_paths
+		FROM dl.projects
+		WHERE id = $1
+	`, project).Scan(&policy.MaxFileSize, &policy.ForbiddenPaths)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("project policy %v: %w", project, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("project policy %v: %w", project, err)
+	}
+
+	return policy, nil
+}
+
+// SetProjectPolicy replaces the restrictions updates of a project must follow.
+func SetProjectPolicy(ctx context.Context, tx pgx.Tx, project int64, policy *pb.ProjectPolicy) error {
+	if policy == nil {
+		policy = &pb.ProjectPolicy{}
+	}
+
+	forbiddenPaths := policy.ForbiddenPaths
+	if forbiddenPaths == nil {
+		forbiddenPaths = []string{}
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE dl.projects
+		SET max_file_size = $2, forbidden_paths = $3
+		WHERE id = $1
+	`, project, policy.MaxFileSize, forbiddenPaths)
+	if err != nil {
+		return fmt.Errorf("set project policy %v: %w", project, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("set project policy %v: %w", project, ErrNotFound)
+	}
+
+	return nil
+}
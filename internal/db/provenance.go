@@ -0,0 +1,73 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/jackc/pgx/v5"
+)
+
+// FindContentReferences lists the paths of every project holding the content hash, loose or inside an indexed pack.
+// Only live references are returned unless history is set. Consecutive versions of a packed path are merged into a
+// single reference, its pack being rewritten whenever a sibling changes. The bool reports whether more than limit rows
+// matched.
+func FindContentReferences(ctx context.Context, tx pgx.Tx, hash Hash, history bool, limit int64) ([]*pb.ContentReference, bool, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT project, path, start_version, stop_version, false
+		FROM dl.objects
+		WHERE hash = ($1, $2)::hash
+		  AND packed IS false
+		  AND ($3 OR stop_version IS NULL)
+		UNION ALL
+		SELECT o.project, e.path, o.start_version, o.stop_version, true
+		FROM dl.pack_entries e
+		JOIN dl.objects o
+		  ON o.hash = e.hash
+		 AND o.packed IS true
+		WHERE e.content_hash = ($1, $2)::hash
+		  AND ($3 OR o.stop_version IS NULL)
+		ORDER BY 1, 2, 3
+		LIMIT $4
+	`, hash.H1, hash.H2, history, limit+1)
+	if err != nil {
+		return nil, false, fmt.Errorf("find content references, hash %x-%x: %w", hash.H1, hash.H2, err)
+	}
+	defer rows.Close()
+
+	var references []*pb.ContentReference
+	var count int64
+	truncated := false
+
+	for rows.Next() {
+		count += 1
+		if count > limit {
+			truncated = true
+			break
+		}
+
+		var reference pb.ContentReference
+		err = rows.Scan(&reference.Project, &reference.Path, &reference.StartVersion, &reference.StopVersion, &reference.Packed)
+		if err != nil {
+			return nil, false, fmt.Errorf("find content references scan, hash %x-%x: %w", hash.H1, hash.H2, err)
+		}
+
+		if len(references) > 0 {
+			previous := references[len(references)-1]
+			if previous.Packed && reference.Packed && previous.Project == reference.Project && previous.Path == reference.Path &&
+				previous.StopVersion != nil && *previous.StopVersion == reference.StartVersion {
+				previous.StopVersion = reference.StopVersion
+				continue
+			}
+		}
+
+		references = append(references, &reference)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return references, truncated, nil
+}
@@ -1,14 +1,18 @@
 package db
 
 import (
+	"archive/tar"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/internal/transform"
 	"github.com/jackc/pgx/v5"
 )
 
@@ -19,25 +23,37 @@ const (
 
 var (
 	//lint:ignore ST1012 All caps name to mimic io.EOF
-	SKIP        = errors.New("Skip")
+	SKIP = errors.New("Skip")
+	//lint:ignore ST1012 All caps name to mimic io.EOF
+	KNOWN       = errors.New("Known")
 	ErrNotFound = errors.New("resource not found")
+	// ErrVersionSquashed is returned for version ranges reaching below the baseline a project's history was squashed into
+	ErrVersionSquashed = errors.New("version squashed into the project baseline")
+	// ErrProjectFrozen is returned when writing to a project made read-only with SetProjectFrozen
+	ErrProjectFrozen = errors.New("project is frozen")
 )
 
 type EncodedContent = []byte
 type DecodedContent = []byte
 
 type DbObject struct {
-	hash    Hash
-	path    string
-	mode    int64
-	size    int64
-	deleted bool
-	cached  bool
-	packed  bool
+	hash        Hash
+	path        string
+	mode        int64
+	size        int64
+	deleted     bool
+	cached      bool
+	packed      bool
+	contentType *string
+	mtime       *int64
+	omitted     bool
+	known       bool
+	// inline is the decoded content of small loose objects stored in their row, nil when it is in dl.contents
+	inline []byte
 }
 
 func (d *DbObject) ToTarObject(content []byte) TarObject {
-	if d.cached {
+	if d.cached || d.known {
 		content = d.hash.Bytes()
 	}
 
@@ -48,6 +64,8 @@ func (d *DbObject) ToTarObject(content []byte) TarObject {
 		deleted: d.deleted,
 		cached:  d.cached,
 		packed:  d.packed,
+		known:   d.known,
+		mtime:   d.mtime,
 		content: content,
 	}
 }
@@ -60,6 +78,15 @@ type VersionRange struct {
 func NewVersionRange(ctx context.Context, tx pgx.Tx, project int64, from *int64, to *int64) (VersionRange, error) {
 	vrange := VersionRange{}
 
+	latest, baseline, err := getVersionBounds(ctx, tx, project)
+	// ranges with an explicit end never required the project to exist
+	if errors.Is(err, ErrNotFound) && to != nil {
+		err = nil
+	}
+	if err != nil {
+		return vrange, err
+	}
+
 	if from == nil {
 		vrange.From = 0
 	} else {
@@ -67,15 +94,16 @@ func NewVersionRange(ctx context.Context, tx pgx.Tx, project int64, from *int64,
 	}
 
 	if to == nil {
-		latest, err := getLatestVersion(ctx, tx, project)
-		if err != nil {
-			return vrange, err
-		}
 		vrange.To = latest
 	} else {
 		vrange.To = *to
 	}
 
+	// changes below the baseline are gone, a range starting there would miss the deletions squashed away
+	if (vrange.From > 0 && vrange.From < baseline) || vrange.To < baseline {
+		return vrange, fmt.Errorf("project %v range %v-%v below baseline %v: %w", project, vrange.From, vrange.To, baseline, ErrVersionSquashed)
+	}
+
 	return vrange, nil
 }
 
@@ -101,6 +129,13 @@ func unpackObjects(tarReader *TarReader) ([]*pb.Object, error) {
 }
 
 func filterObject(path string, objectQuery *pb.ObjectQuery, object *pb.Object) (*pb.Object, error) {
+	if len(objectQuery.Paths) > 0 {
+		if slices.Contains(objectQuery.Paths, object.Path) {
+			return object, nil
+		}
+		return nil, SKIP
+	}
+
 	if objectQuery.IsPrefix && strings.HasPrefix(object.Path, path) {
 		return object, nil
 	}
@@ -118,6 +153,11 @@ func executeQuery(ctx context.Context, tx pgx.Tx, queryBuilder *queryBuilder) ([
 	if err != nil {
 		return nil, err
 	}
+
+	return scanObjects(rows)
+}
+
+func scanObjects(rows pgx.Rows) ([]DbObject, error) {
 	defer rows.Close()
 
 	var dbObjects []DbObject
@@ -125,7 +165,7 @@ func executeQuery(ctx context.Context, tx pgx.Tx, queryBuilder *queryBuilder) ([
 	for rows.Next() {
 		var object DbObject
 
-		err := rows.Scan(&object.path, &object.mode, &object.size, &object.cached, &object.packed, &object.deleted, &object.hash.H1, &object.hash.H2)
+		err := rows.Scan(&object.path, &object.mode, &object.size, &object.cached, &object.packed, &object.deleted, &object.hash.H1, &object.hash.H2, &object.contentType, &object.mtime, &object.inline)
 		if err != nil {
 			return nil, err
 		}
@@ -133,7 +173,7 @@ func executeQuery(ctx context.Context, tx pgx.Tx, queryBuilder *queryBuilder) ([
 		dbObjects = append(dbObjects, object)
 	}
 
-	err = rows.Err()
+	err := rows.Err()
 	if err != nil {
 		return nil, fmt.Errorf("failed to iterate rows: %w", err)
 	}
@@ -141,13 +181,144 @@ func executeQuery(ctx context.Context, tx pgx.Tx, queryBuilder *queryBuilder) ([
 	return dbObjects, nil
 }
 
-func loadChunk(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, dbObjects []DbObject, startIdx int, chunkSize int) ([]DecodedContent, error) {
-	hashes := make(map[Hash]bool, chunkSize)
+// omitLargeObjects flags objects whose content is larger than maxContentSize so that their content is never loaded.
+// Packed and cached objects are always sent, a maxContentSize of 0 disables the limit.
+func omitLargeObjects(dbObjects []DbObject, maxContentSize int64) {
+	if maxContentSize <= 0 {
+		return
+	}
+
+	for idx := range dbObjects {
+		object := &dbObjects[idx]
+		if !object.deleted && !object.packed && !object.cached && !object.known && object.size > maxContentSize {
+			object.omitted = true
+		}
+	}
+}
+
+// MaxKnownHashes bounds how many known hashes a GetCompress request can advertise
+const MaxKnownHashes = 250_000
+
+// markKnownObjects flags the regular files whose content the client already has, only their hash is sent.
+func markKnownObjects(dbObjects []DbObject, knownHashes [][]byte) {
+	if len(knownHashes) == 0 {
+		return
+	}
+
+	known := make(map[Hash]bool, len(knownHashes))
+	for _, bytes := range knownHashes {
+		hash, err := HashFromBytes(bytes)
+		if err == nil {
+			known[hash] = true
+		}
+	}
+
+	for idx := range dbObjects {
+		object := &dbObjects[idx]
+		if object.deleted || object.packed || object.cached || pb.TarTypeFromMode(fs.FileMode(object.mode)) != tar.TypeReg {
+			continue
+		}
+		object.known = known[object.hash]
+	}
+}
+
+// unmarkTransformed clears the known flag of the files rewritten by transforms, the client's copy of their content is
+// the stored one and not the transformed one.
+func unmarkTransformed(dbObjects []DbObject, transforms *transform.Set) {
+	if transforms == nil {
+		return
+	}
+
+	for idx := range dbObjects {
+		object := &dbObjects[idx]
+		if object.known && transforms.Matches(object.path) {
+			object.known = false
+		}
+	}
+}
+
+// transformContent applies transforms to the content of a loose regular file.
+func transformContent(dbObject *DbObject, content []byte, transforms *transform.Set, transformVars map[string]string) ([]byte, error) {
+	if transforms == nil || dbObject.deleted || dbObject.cached || dbObject.known {
+		return content, nil
+	}
+
+	object, err := transforms.Apply(&pb.Object{Path: dbObject.path, Mode: dbObject.mode, Size: dbObject.size, Content: content}, transformVars)
+	if err != nil {
+		return nil, err
+	}
+	return object.Content, nil
+}
+
+// transformPack applies transforms to the entries of a pack, it returns the input unchanged if none of them matched.
+func transformPack(pack []byte, transforms *transform.Set, transformVars map[string]string) ([]byte, error) {
+	if transforms == nil || len(pack) == 0 {
+		return pack, nil
+	}
+
+	reader := NewTarReader()
+	defer reader.Close()
+	reader.FromBytes(pack)
+
+	objects, err := unpackObjects(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := false
+	for idx, object := range objects {
+		transformed, err := transforms.Apply(object, transformVars)
+		if err != nil {
+			return nil, err
+		}
+		if transformed != object {
+			objects[idx] = transformed
+			changed = true
+		}
+	}
+
+	if !changed {
+		return pack, nil
+	}
+
+	idx := 0
+	return packObjects(func() (*pb.Object, error) {
+		if idx >= len(objects) {
+			return nil, io.EOF
+		}
+		idx += 1
+		return objects[idx-1], nil
+	})
+}
+
+// markKnownPacks flags the packs sent whole whose content the client already has, only their hash is sent.
+func markKnownPacks(dbObjects []DbObject, knownPacks [][]byte, packedPaths map[string][]string) {
+	if len(knownPacks) == 0 {
+		return
+	}
 
-	for idx := 0; idx < chunkSize && idx+startIdx < len(dbObjects); idx++ {
-		dbObject := dbObjects[idx+startIdx]
+	known := make(map[Hash]bool, len(knownPacks))
+	for _, bytes := range knownPacks {
+		hash, err := HashFromBytes(bytes)
+		if err == nil {
+			known[hash] = true
+		}
+	}
+
+	for idx := range dbObjects {
+		object := &dbObjects[idx]
+		if object.deleted || !object.packed || object.cached || len(packedPaths[object.path]) > 0 {
+			continue
+		}
+		object.known = known[object.hash]
+	}
+}
 
-		if !dbObject.cached {
+func loadChunk(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, dbObjects []DbObject) ([]DecodedContent, error) {
+	hashes := make(map[Hash]bool, len(dbObjects))
+
+	for _, dbObject := range dbObjects {
+		if !dbObject.cached && !dbObject.known && !dbObject.omitted && dbObject.inline == nil {
 			hashes[dbObject.hash] = !dbObject.packed
 		}
 	}
@@ -159,10 +330,13 @@ func loadChunk(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, dbObjects
 
 	var decoded []DecodedContent
 
-	for idx := 0; idx < chunkSize && idx+startIdx < len(dbObjects); idx++ {
-		dbObject := dbObjects[idx+startIdx]
-		if dbObject.cached {
+	for _, dbObject := range dbObjects {
+		if dbObject.cached || dbObject.known {
 			decoded = append(decoded, dbObject.hash.Bytes())
+		} else if dbObject.omitted {
+			decoded = append(decoded, nil)
+		} else if dbObject.inline != nil {
+			decoded = append(decoded, dbObject.inline)
 		} else {
 			decoded = append(decoded, uncachedContents[dbObject.hash])
 		}
@@ -173,7 +347,23 @@ func loadChunk(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, dbObjects
 
 type ObjectStream func() (*pb.Object, error)
 
-func GetObjects(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, packManager *PackManager, project int64, vrange VersionRange, objectQuery *pb.ObjectQuery) (ObjectStream, error) {
+// ResumePoint is the last object sent by an interrupted object stream.
+type ResumePoint struct {
+	Path    string
+	Deleted bool
+}
+
+// GetObjects streams the objects matching objectQuery, they are fetched from a cursor chunkSize at a time.
+// The next chunk is only fetched and its contents loaded once the caller has consumed the current one,
+// so a slow client blocked in stream flow control holds back the reads instead of letting them pile up in memory.
+func GetObjects(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, packManager *PackManager, project int64, vrange VersionRange, objectQuery *pb.ObjectQuery, maxContentSize int64) (ObjectStream, error) {
+	return GetObjectsAfter(ctx, tx, lookup, packManager, project, vrange, objectQuery, maxContentSize, nil)
+}
+
+// GetObjectsAfter is like GetObjects but streams only the objects following after, which must have been returned by
+// the same query over the same version range. Rows sorting before after are left out of the query, the ones of the
+// pack holding after are read again and skipped up to it.
+func GetObjectsAfter(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, packManager *PackManager, project int64, vrange VersionRange, objectQuery *pb.ObjectQuery, maxContentSize int64, after *ResumePoint) (ObjectStream, error) {
 	packParent := packManager.IsPathPacked(objectQuery.Path)
 	originalPath := objectQuery.Path
 	if packParent != nil {
@@ -181,17 +371,55 @@ func GetObjects(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, packManag
 	}
 
 	builder := newQueryBuilder(project, vrange, objectQuery)
-	dbObjects, err := executeQuery(ctx, tx, builder)
+	if len(objectQuery.Paths) > 0 {
+		builder = newQueryBuilder(project, vrange, &pb.ObjectQuery{Paths: packManager.QueryPaths(objectQuery.Paths)})
+	}
+
+	resumeRow := ""
+	if after != nil {
+		resumeRow = after.Path
+		if parent := packManager.IsPathPacked(after.Path); parent != nil {
+			resumeRow = *parent
+		}
+		builder.withResumeFrom(resumeRow, after.Deleted)
+	}
+
+	objects, err := getObjects(ctx, tx, lookup, project, vrange, builder, originalPath, objectQuery, maxContentSize)
+	if err != nil || after == nil {
+		return objects, err
+	}
+
+	skipping := true
+	return func() (*pb.Object, error) {
+		object, err := objects()
+		if err != nil || !skipping {
+			return object, err
+		}
+
+		if object.Path == after.Path && object.Deleted == after.Deleted {
+			skipping = false
+			return nil, SKIP
+		}
+
+		// the first rows are after itself or the pack holding it, anything else means after is gone and nothing is skipped
+		if resumeRow != after.Path && strings.HasPrefix(object.Path, resumeRow) {
+			return nil, SKIP
+		}
+
+		skipping = false
+		return object, nil
+	}, nil
+}
+
+func getObjects(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, project int64, vrange VersionRange, builder *queryBuilder, originalPath string, objectQuery *pb.ObjectQuery, maxContentSize int64) (ObjectStream, error) {
+	cursor, err := openObjectCursor(ctx, tx, builder)
 	if err != nil {
 		return nil, fmt.Errorf("get objects query, project %v vrange %v: %w", project, vrange, err)
 	}
 
+	var dbObjects []DbObject
+	var chunk []DecodedContent
 	idx := 0
-	chunkIdx := 0
-	chunk, err := loadChunk(ctx, tx, lookup, dbObjects, idx, chunkSize)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load chunk: %w", err)
-	}
 
 	var packBuffer []*pb.Object
 	tarReader := NewTarReader()
@@ -204,22 +432,27 @@ func GetObjects(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, packManag
 		}
 
 		if idx >= len(dbObjects) {
-			return nil, io.EOF
-		}
+			dbObjects, err = cursor.next(ctx, chunkSize)
+			if err != nil {
+				return nil, fmt.Errorf("get objects query, project %v vrange %v: %w", project, vrange, err)
+			}
+			if len(dbObjects) == 0 {
+				tarReader.Close()
+				return nil, io.EOF
+			}
+			omitLargeObjects(dbObjects, maxContentSize)
 
-		if chunkIdx >= len(chunk) {
-			chunkIdx = 0
-			chunk, err = loadChunk(ctx, tx, lookup, dbObjects, idx, chunkSize)
+			idx = 0
+			chunk, err = loadChunk(ctx, tx, lookup, dbObjects)
 			if err != nil {
 				return nil, fmt.Errorf("failed to load chunk: %w", err)
 			}
 		}
 
 		dbObject := dbObjects[idx]
-		content := chunk[chunkIdx]
+		content := chunk[idx]
 
 		idx += 1
-		chunkIdx += 1
 
 		if dbObject.cached {
 			return nil, fmt.Errorf("getObjects scan, project %v vrange %v: returned non-nil hash when queried without cache", project, vrange)
@@ -238,34 +471,59 @@ func GetObjects(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, packManag
 		}
 
 		return filterObject(originalPath, objectQuery, &pb.Object{
-			Path:    dbObject.path,
-			Mode:    dbObject.mode,
-			Size:    dbObject.size,
-			Deleted: dbObject.deleted,
-			Content: content,
+			Path:           dbObject.path,
+			Mode:           dbObject.mode,
+			Size:           dbObject.size,
+			Deleted:        dbObject.deleted,
+			Content:        content,
+			ContentType:    dbObject.contentType,
+			ContentOmitted: dbObject.omitted,
+			Mtime:          dbObject.mtime,
 		})
 	}, nil
 }
 
 type tarStream func() ([]byte, *string, error)
 
-func GetTars(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, project int64, cacheVersions []int64, vrange VersionRange, objectQuery *pb.ObjectQuery) (tarStream, error) {
+// GetTars streams the objects as tars, objects larger than maxContentSize are left out and reported to onOmitted.
+// Regular files whose hash is in knownHashes are sent as hash only TarKnown entries.
+// Packs are sent whole unless packedPaths lists paths within them, only those entries are then extracted into the tar.
+// Whole packs whose hash is in knownPacks are returned as their hash along with the KNOWN error.
+// Regular files, within packs or not, are written through transforms when it is set, files it rewrites are never sent
+// as known. Cached and known packs hold the stored contents, callers must not ask for them along with transforms.
+func GetTars(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, project int64, cacheVersions []int64, knownHashes [][]byte, knownPacks [][]byte, vrange VersionRange, objectQuery *pb.ObjectQuery, maxContentSize int64, packedPaths map[string][]string, transforms *transform.Set, transformVars map[string]string, onOmitted func(*pb.Object)) (tarStream, error) {
 	builder := newQueryBuilder(project, vrange, objectQuery).withCacheVersions(cacheVersions)
-	dbObjects, err := executeQuery(ctx, tx, builder)
+	cursor, err := openObjectCursor(ctx, tx, builder)
 	if err != nil {
 		return nil, fmt.Errorf("get tars query, project %v vrange %v: %w", project, vrange, err)
 	}
 
+	var dbObjects []DbObject
+	var chunk []DecodedContent
 	idx := 0
-	chunkIdx := 0
-	chunk, err := loadChunk(ctx, tx, lookup, dbObjects, idx, chunkSize)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load chunk: %w", err)
-	}
 
 	tarWriter := NewTarWriter()
 
 	return func() ([]byte, *string, error) {
+		if idx >= len(dbObjects) && !cursor.done {
+			dbObjects, err = cursor.next(ctx, chunkSize)
+			if err != nil {
+				tarWriter.Close()
+				return nil, nil, fmt.Errorf("get tars query, project %v vrange %v: %w", project, vrange, err)
+			}
+			markKnownObjects(dbObjects, knownHashes)
+			unmarkTransformed(dbObjects, transforms)
+			markKnownPacks(dbObjects, knownPacks, packedPaths)
+			omitLargeObjects(dbObjects, maxContentSize)
+
+			idx = 0
+			chunk, err = loadChunk(ctx, tx, lookup, dbObjects)
+			if err != nil {
+				tarWriter.Close()
+				return nil, nil, fmt.Errorf("failed to load chunk: %w", err)
+			}
+		}
+
 		if idx >= len(dbObjects) {
 			if tarWriter.Size() > 0 {
 				bytes, err := tarWriter.BytesAndReset()
@@ -279,30 +537,58 @@ func GetTars(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, project int6
 			return nil, nil, io.EOF
 		}
 
-		if chunkIdx >= len(chunk) {
-			chunkIdx = 0
-			chunk, err = loadChunk(ctx, tx, lookup, dbObjects, idx, chunkSize)
-			if err != nil {
-				tarWriter.Close()
-				return nil, nil, fmt.Errorf("failed to load chunk: %w", err)
-			}
-		}
-
 		dbObject := dbObjects[idx]
-		content := chunk[chunkIdx]
+		content := chunk[idx]
 
 		idx += 1
-		chunkIdx += 1
 
-		if dbObject.packed && !dbObject.cached {
+		if dbObject.omitted {
+			onOmitted(&pb.Object{
+				Path:           dbObject.path,
+				Mode:           dbObject.mode,
+				Size:           dbObject.size,
+				ContentType:    dbObject.contentType,
+				ContentOmitted: true,
+				Mtime:          dbObject.mtime,
+			})
+			return nil, nil, SKIP
+		}
+
+		if dbObject.packed && !dbObject.cached && (dbObject.deleted || len(packedPaths[dbObject.path]) == 0) {
+			if dbObject.known {
+				return content, &dbObject.path, KNOWN
+			}
+
+			content, err = transformPack(content, transforms, transformVars)
+			if err != nil {
+				tarWriter.Close()
+				return nil, nil, fmt.Errorf("transform pack %v, project %v: %w", dbObject.path, project, err)
+			}
 			return content, &dbObject.path, nil
 		}
 
-		tarObject := dbObject.ToTarObject(content)
-		err = tarWriter.WriteObject(&tarObject)
-		if err != nil {
-			tarWriter.Close()
-			return nil, nil, err
+		if dbObject.packed && !dbObject.cached {
+			index, err := lookup.loadPackIndex(ctx, tx, dbObject.hash, content)
+			if err == nil {
+				err = index.extract(content, packedPaths[dbObject.path], tarWriter, transforms, transformVars)
+			}
+			if err != nil {
+				tarWriter.Close()
+				return nil, nil, fmt.Errorf("extract from pack %v, project %v: %w", dbObject.path, project, err)
+			}
+		} else {
+			content, err = transformContent(&dbObject, content, transforms, transformVars)
+			if err != nil {
+				tarWriter.Close()
+				return nil, nil, fmt.Errorf("transform %v, project %v: %w", dbObject.path, project, err)
+			}
+
+			tarObject := dbObject.ToTarObject(content)
+			err = tarWriter.WriteObject(&tarObject)
+			if err != nil {
+				tarWriter.Close()
+				return nil, nil, err
+			}
 		}
 
 		if tarWriter.Size() > TargetTarSize {
@@ -319,7 +605,8 @@ func GetTars(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, project int6
 
 type cacheTarStream func() (int64, []byte, *Hash, error)
 
-func GetCacheTars(ctx context.Context, tx pgx.Tx) (cacheTarStream, CloseFunc, error) {
+// LatestCacheVersion returns the most recent cache version, or 0 when no cache has been created.
+func LatestCacheVersion(ctx context.Context, tx pgx.Tx) (int64, error) {
 	var version int64
 
 	err := tx.QueryRow(ctx, `
@@ -329,10 +616,35 @@ func GetCacheTars(ctx context.Context, tx pgx.Tx) (cacheTarStream, CloseFunc, er
 		LIMIT 1
 	`).Scan(&version)
 	if err == pgx.ErrNoRows {
-		return func() (int64, []byte, *Hash, error) { return 0, nil, nil, io.EOF }, func(_ context.Context) {}, nil
+		return 0, nil
 	}
 	if err != nil {
-		return nil, func(_ context.Context) {}, fmt.Errorf("GetCacheTars latest cache version: %w", err)
+		return -1, fmt.Errorf("latest cache version: %w", err)
+	}
+
+	return version, nil
+}
+
+// GetCacheTars streams the packs of a cache version, packs whose hash is in knownHashes are skipped.
+// The stream reports the cache version along with io.EOF so callers can tell it even when every pack was skipped.
+func GetCacheTars(ctx context.Context, tx pgx.Tx, version int64, knownHashes [][]byte) (cacheTarStream, CloseFunc, error) {
+	var exists bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM dl.cache_versions WHERE version = $1)
+	`, version).Scan(&exists)
+	if err != nil {
+		return nil, func(_ context.Context) {}, fmt.Errorf("GetCacheTars cache version %v: %w", version, err)
+	}
+	if !exists {
+		return nil, func(_ context.Context) {}, fmt.Errorf("GetCacheTars cache version %v: %w", version, ErrNotFound)
+	}
+
+	known := []Hash{}
+	for _, bytes := range knownHashes {
+		hash, err := HashFromBytes(bytes)
+		if err == nil {
+			known = append(known, hash)
+		}
 	}
 
 	rows, err := tx.Query(ctx, `
@@ -345,7 +657,8 @@ func GetCacheTars(ctx context.Context, tx pgx.Tx) (cacheTarStream, CloseFunc, er
 		FROM version_hashes h
 		JOIN dl.contents c
 		  ON h.hash = c.hash
-	`, version)
+		WHERE NOT (h.hash = ANY($2::hash[]))
+	`, version, known)
 	closeFunc := func(_ context.Context) { rows.Close() }
 	if err != nil {
 		return nil, closeFunc, fmt.Errorf("GetCacheTars query: %w", err)
@@ -357,7 +670,7 @@ func GetCacheTars(ctx context.Context, tx pgx.Tx) (cacheTarStream, CloseFunc, er
 			if err != nil {
 				return 0, nil, nil, fmt.Errorf("failed to iterate rows: %w", err)
 			}
-			return 0, nil, nil, io.EOF
+			return version, nil, nil, io.EOF
 		}
 
 		var hash Hash
@@ -398,6 +711,53 @@ func NewPackManager(ctx context.Context, tx pgx.Tx, project int64) (*PackManager
 	}, nil
 }
 
+// QueryPaths replaces every packed path by its pack parent so that the returned paths can be queried directly.
+func (p *PackManager) QueryPaths(paths []string) []string {
+	seen := make(map[string]bool, len(paths))
+	var queryPaths []string
+
+	for _, path := range paths {
+		packParent := p.IsPathPacked(path)
+		if packParent != nil {
+			path = *packParent
+		}
+
+		if !seen[path] {
+			seen[path] = true
+			queryPaths = append(queryPaths, path)
+		}
+	}
+
+	return queryPaths
+}
+
+// PackedPaths groups the paths within packs by their pack parent, so only those entries are read from each pack.
+// Packs also requested as a whole are left out.
+func (p *PackManager) PackedPaths(paths []string) map[string][]string {
+	packedPaths := make(map[string][]string)
+	whole := make(map[string]bool)
+
+	for _, path := range paths {
+		packParent := p.IsPathPacked(path)
+		if packParent == nil {
+			continue
+		}
+
+		if *packParent == path {
+			whole[path] = true
+			continue
+		}
+
+		packedPaths[*packParent] = append(packedPaths[*packParent], path)
+	}
+
+	for parent := range whole {
+		delete(packedPaths, parent)
+	}
+
+	return packedPaths
+}
+
 func (p *PackManager) IsPathPacked(path string) *string {
 	currentPath := ""
 
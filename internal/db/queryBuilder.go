@@ -12,9 +12,16 @@ type queryBuilder struct {
 	vrange        VersionRange
 	objectQuery   *pb.ObjectQuery
 	cacheVersions []int64
+	resume        *resumeFrom
 	argsOffset    int
 }
 
+// resumeFrom restricts the results to the rows sorting at or after path, in the updated or the removed objects
+type resumeFrom struct {
+	path    string
+	deleted bool
+}
+
 func newQueryBuilder(project int64, vrange VersionRange, objectQuery *pb.ObjectQuery) *queryBuilder {
 	return &queryBuilder{
 		project:       project,
@@ -30,6 +37,23 @@ func (qb *queryBuilder) withCacheVersions(cacheVersions []int64) *queryBuilder {
 	return qb
 }
 
+func (qb *queryBuilder) withResumeFrom(path string, deleted bool) *queryBuilder {
+	qb.resume = &resumeFrom{path: path, deleted: deleted}
+	return qb
+}
+
+// resumePredicates filter the updated and the removed objects of a resumed query, updated objects all come before
+// removed ones so a query resumed within the removed objects skips every updated object.
+func (qb *queryBuilder) resumePredicates() (string, string) {
+	if qb.resume == nil {
+		return "", ""
+	}
+	if qb.resume.deleted {
+		return "WHERE false", "WHERE path >= __resume_path__"
+	}
+	return "WHERE path >= __resume_path__", ""
+}
+
 func (qb *queryBuilder) withArgsOffset(offset int) *queryBuilder {
 	qb.argsOffset = offset
 	return qb
@@ -71,7 +95,7 @@ func (qb *queryBuilder) possibleObjectsCTE(withRemovals bool) string {
 
 func (qb *queryBuilder) updatedObjectsCTE() string {
 	template := `
-			SELECT o.path, o.mode, o.size, %s, o.packed, false AS deleted, o.hash
+			SELECT o.path, o.mode, o.size, %s, o.packed, false AS deleted, o.hash, o.content_type, o.mtime, o.inline_content
 			FROM possible_objects o
 			%s
 			WHERE o.project = __project__
@@ -92,7 +116,9 @@ func (qb *queryBuilder) updatedObjectsCTE() string {
 	}
 
 	pathPredicate := ""
-	if qb.objectQuery.Path != "" {
+	if len(qb.objectQuery.Paths) > 0 {
+		pathPredicate = "AND o.path = ANY(__paths__::text[])"
+	} else if qb.objectQuery.Path != "" {
 		if qb.objectQuery.IsPrefix {
 			pathPredicate = "AND o.path LIKE __path__"
 		} else {
@@ -110,7 +136,7 @@ func (qb *queryBuilder) updatedObjectsCTE() string {
 
 func (qb *queryBuilder) removedObjectsCTE() string {
 	template := `
-			SELECT o.path, o.mode, 0 AS size, o.packed, true AS deleted, '(00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000)'::hash AS hash
+			SELECT o.path, o.mode, 0 AS size, o.packed, true AS deleted, '(00000000-0000-0000-0000-000000000000,00000000-0000-0000-0000-000000000000)'::hash AS hash, NULL::text AS content_type, NULL::bigint AS mtime, NULL::bytea AS inline_content
 			FROM possible_objects o
 			WHERE o.project = __project__
 			AND o.start_version <= __start_version__
@@ -163,10 +189,12 @@ func (qb *queryBuilder) queryWithoutRemovals() string {
 		), `, qb.cachedObjectHashesCTE())
 	}
 
-	selectStatement := `
-		SELECT path, mode, size, is_cached, packed, deleted, (hash).h1, (hash).h2
+	updatedPredicate, _ := qb.resumePredicates()
+	selectStatement := fmt.Sprintf(`
+		SELECT path, mode, size, is_cached, packed, deleted, (hash).h1, (hash).h2, content_type, mtime, inline_content
 		FROM updated_objects
-	`
+		%s
+	`, updatedPredicate)
 
 	return fmt.Sprintf(template, qb.possibleObjectsCTE(false), cacheCte, qb.updatedObjectsCTE(), selectStatement)
 }
@@ -190,13 +218,16 @@ func (qb *queryBuilder) queryWithRemovals() string {
 		), `, qb.cachedObjectHashesCTE())
 	}
 
-	selectStatement := `
-		SELECT path, mode, size, is_cached, packed, deleted, (hash).h1, (hash).h2
+	updatedPredicate, removedPredicate := qb.resumePredicates()
+	selectStatement := fmt.Sprintf(`
+		SELECT path, mode, size, is_cached, packed, deleted, (hash).h1, (hash).h2, content_type, mtime, inline_content
 		FROM updated_objects
+		%s
 		UNION ALL
-		SELECT path, mode, size, false, packed, deleted, (hash).h1, (hash).h2
+		SELECT path, mode, size, false, packed, deleted, (hash).h1, (hash).h2, content_type, mtime, inline_content
 		FROM removed_objects
-	`
+		%s
+	`, updatedPredicate, removedPredicate)
 	return fmt.Sprintf(template, qb.possibleObjectsCTE(true), cacheCte, qb.updatedObjectsCTE(), qb.removedObjectsCTE(), selectStatement)
 }
 
@@ -204,7 +235,7 @@ func (qb *queryBuilder) replaceQueryArgs(query string) (string, []any) {
 	argNames := []string{"__project__", "__start_version__", "__stop_version__"}
 	args := []any{qb.project, qb.vrange.From, qb.vrange.To}
 
-	if qb.objectQuery.Path != "" {
+	if len(qb.objectQuery.Paths) == 0 && qb.objectQuery.Path != "" {
 		argNames = append(argNames, "__path__")
 
 		if qb.objectQuery.IsPrefix {
@@ -214,6 +245,11 @@ func (qb *queryBuilder) replaceQueryArgs(query string) (string, []any) {
 		}
 	}
 
+	if len(qb.objectQuery.Paths) > 0 {
+		argNames = append(argNames, "__paths__")
+		args = append(args, qb.objectQuery.Paths)
+	}
+
 	if len(qb.objectQuery.Ignores) > 0 {
 		argNames = append(argNames, "__ignores__")
 
@@ -230,6 +266,11 @@ func (qb *queryBuilder) replaceQueryArgs(query string) (string, []any) {
 		args = append(args, qb.cacheVersions)
 	}
 
+	if qb.resume != nil {
+		argNames = append(argNames, "__resume_path__")
+		args = append(args, qb.resume.path)
+	}
+
 	for idx, name := range argNames {
 		query = strings.ReplaceAll(query, name, fmt.Sprintf("$%d", qb.argsOffset+idx+1))
 	}
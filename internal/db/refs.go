@@ -0,0 +1,184 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// contentRefsLockKey identifies the advisory lock held while a server folds the reference deltas into dl.contents.
+const contentRefsLockKey = 0x646c72656673
+
+// TryLockContentRefs takes a transaction scoped advisory lock so only one server replica reconciles references at a time.
+func TryLockContentRefs(ctx context.Context, tx pgx.Tx) (bool, error) {
+	var locked bool
+	err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, contentRefsLockKey).Scan(&locked)
+	if err != nil {
+		return false, fmt.Errorf("TryLockContentRefs query: %w", err)
+	}
+
+	return locked, nil
+}
+
+// LockContentRefs waits for the reconciler lock, repairs must not race a batch folding the deltas they account for.
+func LockContentRefs(ctx context.Context, tx pgx.Tx) error {
+	_, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, contentRefsLockKey)
+	if err != nil {
+		return fmt.Errorf("LockContentRefs query: %w", err)
+	}
+
+	return nil
+}
+
+// ReconcileContentRefs folds the oldest batchSize reference deltas of counted contents into dl.contents.refs and counts
+// the references of up to batchSize contents stored before references were tracked. It returns how many contents were
+// updated by each. Deltas and counts are read in the same statement, so a delta is either counted or left pending.
+func ReconcileContentRefs(ctx context.Context, tx pgx.Tx, batchSize int64) (int64, int64, error) {
+	folded, err := tx.Exec(ctx, `
+		WITH batch AS (
+			SELECT d.id
+			FROM dl.content_ref_deltas d
+			JOIN dl.contents c
+			  ON c.hash = d.hash
+			WHERE c.refs IS NOT NULL
+			ORDER BY d.id
+			LIMIT $1
+		), folded AS (
+			DELETE FROM dl.content_ref_deltas
+			WHERE id IN (SELECT id FROM batch)
+			RETURNING hash, delta
+		), sums AS (
+			SELECT hash, sum(delta)::bigint AS delta
+			FROM folded
+			GROUP BY hash
+		)
+		UPDATE dl.contents c
+		SET refs = c.refs + s.delta
+		FROM sums s
+		WHERE c.hash = s.hash
+	`, batchSize)
+	if err != nil {
+		return 0, 0, fmt.Errorf("fold content ref deltas: %w", err)
+	}
+
+	counted, err := tx.Exec(ctx, `
+		WITH uncounted AS (
+			SELECT hash
+			FROM dl.contents
+			WHERE refs IS NULL
+			LIMIT $1
+		), dropped AS (
+			DELETE FROM dl.content_ref_deltas
+			WHERE hash IN (SELECT hash FROM uncounted)
+		)
+		UPDATE dl.contents c
+		SET refs = (
+			SELECT count(*)
+			FROM dl.objects o
+			WHERE o.hash = c.hash
+			  AND o.inline_content IS NULL
+		)
+		WHERE c.hash IN (SELECT hash FROM uncounted)
+	`, batchSize)
+	if err != nil {
+		return 0, 0, fmt.Errorf("count content refs: %w", err)
+	}
+
+	return folded.RowsAffected(), counted.RowsAffected(), nil
+}
+
+// ContentRefCheck compares the tracked references of a content, its folded count plus its pending deltas, with the
+// rows actually reading it. Refs is nil for contents the reconciler did not count yet.
+type ContentRefCheck struct {
+	Hash    Hash
+	Refs    *int64
+	Pending int64
+	Actual  int64
+}
+
+func (c ContentRefCheck) Consistent() bool {
+	return c.Refs == nil || *c.Refs+c.Pending == c.Actual
+}
+
+// CheckContentRefs validates the tracked references of the contents of hashes against dl.objects and returns the check
+// of every stored content.
+func CheckContentRefs(ctx context.Context, tx pgx.Tx, hashes []Hash) ([]ContentRefCheck, error) {
+	var checks []ContentRefCheck
+
+	for _, hashChunk := range chunk(hashes, 500) {
+		rows, err := tx.Query(ctx, `
+			SELECT (c.hash).h1, (c.hash).h2, c.refs,
+			       coalesce((
+					SELECT sum(d.delta)
+					FROM dl.content_ref_deltas d
+					WHERE d.hash = c.hash
+			       ), 0)::bigint,
+			       (
+					SELECT count(*)
+					FROM dl.objects o
+					WHERE o.hash = c.hash
+					  AND o.inline_content IS NULL
+			       )
+			FROM dl.contents c
+			WHERE c.hash = ANY($1::hash[])
+		`, hashChunk)
+		if err != nil {
+			return nil, fmt.Errorf("check content refs, hash count %v: %w", len(hashChunk), err)
+		}
+
+		for rows.Next() {
+			var check ContentRefCheck
+			err = rows.Scan(&check.Hash.H1, &check.Hash.H2, &check.Refs, &check.Pending, &check.Actual)
+			if err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("check content refs scan: %w", err)
+			}
+			checks = append(checks, check)
+		}
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate rows: %w", err)
+		}
+	}
+
+	return checks, nil
+}
+
+// RepairContentRefs resets the folded count of the contents of hashes so it adds up to their rows with the pending
+// deltas, and returns how many were changed. The caller must hold LockContentRefs.
+func RepairContentRefs(ctx context.Context, tx pgx.Tx, hashes []Hash) (int64, error) {
+	if len(hashes) == 0 {
+		return 0, nil
+	}
+
+	tag, err := tx.Exec(ctx, `
+		WITH truth AS (
+			SELECT c.hash,
+			       (
+					SELECT count(*)
+					FROM dl.objects o
+					WHERE o.hash = c.hash
+					  AND o.inline_content IS NULL
+			       ) - coalesce((
+					SELECT sum(d.delta)
+					FROM dl.content_ref_deltas d
+					WHERE d.hash = c.hash
+			       ), 0)::bigint AS refs
+			FROM dl.contents c
+			WHERE c.hash = ANY($1::hash[])
+		)
+		UPDATE dl.contents c
+		SET refs = t.refs
+		FROM truth t
+		WHERE c.hash = t.hash
+		  AND c.refs IS DISTINCT FROM t.refs
+	`, hashes)
+	if err != nil {
+		return 0, fmt.Errorf("repair content refs, hash count %v: %w", len(hashes), err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -23,6 +23,56 @@ func ResetAll(ctx context.Context, tx pgx.Tx) error {
 		return fmt.Errorf("truncate contents: %w", err)
 	}
 
+	_, err = tx.Exec(ctx, "TRUNCATE dl.pack_entries;")
+	if err != nil {
+		return fmt.Errorf("truncate pack entries: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "TRUNCATE dl.checkout_artifacts;")
+	if err != nil {
+		return fmt.Errorf("truncate checkout artifacts: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "TRUNCATE dl.version_annotations;")
+	if err != nil {
+		return fmt.Errorf("truncate version annotations: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "TRUNCATE dl.idempotency_keys;")
+	if err != nil {
+		return fmt.Errorf("truncate idempotency keys: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "TRUNCATE dl.content_scans;")
+	if err != nil {
+		return fmt.Errorf("truncate content scans: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "TRUNCATE dl.content_reencodes;")
+	if err != nil {
+		return fmt.Errorf("truncate content reencodes: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "TRUNCATE dl.staged_objects;")
+	if err != nil {
+		return fmt.Errorf("truncate staged objects: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "TRUNCATE dl.archives;")
+	if err != nil {
+		return fmt.Errorf("truncate archives: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "TRUNCATE dl.content_ref_deltas;")
+	if err != nil {
+		return fmt.Errorf("truncate content ref deltas: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "TRUNCATE dl.quarantined_contents;")
+	if err != nil {
+		return fmt.Errorf("truncate quarantined contents: %w", err)
+	}
+
 	return nil
 }
 
@@ -55,6 +105,33 @@ func ResetProject(ctx context.Context, tx pgx.Tx, project, version int64) error
 		return fmt.Errorf("reset objects for %v with stop_version %v: %w", project, version, err)
 	}
 
+	_, err = tx.Exec(ctx, `
+		DELETE FROM dl.checkout_artifacts
+		WHERE project = $1
+		  AND version > $2
+	`, project, version)
+	if err != nil {
+		return fmt.Errorf("reset checkout artifacts for %v above version %v: %w", project, version, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM dl.version_annotations
+		WHERE project = $1
+		  AND version > $2
+	`, project, version)
+	if err != nil {
+		return fmt.Errorf("reset version annotations for %v above version %v: %w", project, version, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM dl.idempotency_keys
+		WHERE project = $1
+		  AND version > $2
+	`, project, version)
+	if err != nil {
+		return fmt.Errorf("reset idempotency keys for %v above version %v: %w", project, version, err)
+	}
+
 	return nil
 }
 
@@ -75,5 +152,13 @@ func DropOtherProjects(ctx context.Context, tx pgx.Tx, projects []int64) error {
 		return fmt.Errorf("drop objects from other projects: %w", err)
 	}
 
+	_, err = tx.Exec(ctx, `
+		DELETE FROM dl.staged_objects
+		WHERE project != ALL($1::bigint[])
+	`, projects)
+	if err != nil {
+		return fmt.Errorf("drop staged objects from other projects: %w", err)
+	}
+
 	return nil
 }
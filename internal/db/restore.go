@@ -0,0 +1,113 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// LastLiveVersions returns the latest version up to version at which each of paths existed, paths that never existed
+// are left out. Loose paths are found from the version they were deleted at, packed paths by reading the previous
+// contents of their pack, newest first, until the path shows up in one of them.
+func LastLiveVersions(ctx context.Context, tx pgx.Tx, packManager *PackManager, project int64, version int64, paths []string) (map[string]int64, error) {
+	versions := make(map[string]int64)
+
+	var loose []string
+	packed := make(map[string][]string)
+	for _, path := range paths {
+		parent := packManager.IsPathPacked(path)
+		if parent != nil && *parent != path {
+			packed[*parent] = append(packed[*parent], path)
+			continue
+		}
+		loose = append(loose, path)
+	}
+
+	if len(loose) > 0 {
+		rows, err := tx.Query(ctx, `
+			SELECT path, max(stop_version) - 1
+			FROM dl.objects
+			WHERE project = $1
+			  AND path = ANY($2)
+			  AND packed IS false
+			  AND stop_version <= $3
+			GROUP BY path
+		`, project, loose, version)
+		if err != nil {
+			return nil, fmt.Errorf("last live versions, project %v: %w", project, err)
+		}
+
+		for rows.Next() {
+			var path string
+			var lastVersion int64
+			err = rows.Scan(&path, &lastVersion)
+			if err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("last live versions scan, project %v: %w", project, err)
+			}
+			versions[path] = lastVersion
+		}
+		rows.Close()
+
+		err = rows.Err()
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate rows: %w", err)
+		}
+	}
+
+	for parent, packedPaths := range packed {
+		err := lastPackedVersions(ctx, tx, project, version, parent, packedPaths, versions)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return versions, nil
+}
+
+func lastPackedVersions(ctx context.Context, tx pgx.Tx, project int64, version int64, parent string, paths []string, versions map[string]int64) error {
+	rows, err := tx.Query(ctx, `
+		SELECT o.stop_version, c.bytes
+		FROM dl.objects o
+		JOIN dl.contents c
+		  ON o.hash = c.hash
+		WHERE o.project = $1
+		  AND o.path = $2
+		  AND o.packed IS true
+		  AND o.stop_version <= $3
+		ORDER BY o.stop_version DESC
+	`, project, parent, version)
+	if err != nil {
+		return fmt.Errorf("last live packed versions, project %v, parent %v: %w", project, parent, err)
+	}
+	defer rows.Close()
+
+	missing := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		missing[path] = true
+	}
+
+	for len(missing) > 0 && rows.Next() {
+		var stopVersion int64
+		var pack []byte
+		err = rows.Scan(&stopVersion, &pack)
+		if err != nil {
+			return fmt.Errorf("last live packed versions scan, project %v, parent %v: %w", project, parent, err)
+		}
+
+		entries, err := readPackEntries(pack)
+		if err != nil {
+			return fmt.Errorf("last live packed versions, project %v, parent %v: %w", project, parent, err)
+		}
+
+		for _, entry := range entries {
+			if missing[entry.path] {
+				versions[entry.path] = stopVersion - 1
+				delete(missing, entry.path)
+			}
+		}
+	}
+
+	return rows.Err()
+}
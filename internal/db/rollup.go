@@ -0,0 +1,157 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/ristretto"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/jackc/pgx/v5"
+)
+
+// rollupTTL bounds how long a cached rollup is served, versions only change when a rollback or the re-creation of a
+// project reuses their number.
+const rollupTTL = 5 * time.Minute
+
+// DirectoryRollups caches the size of the directories of project versions, computing them on the first request.
+type DirectoryRollups struct {
+	cache *ristretto.Cache
+}
+
+func NewDirectoryRollups() (*DirectoryRollups, error) {
+	cache, err := ristretto.NewCache(&ristretto.Config{
+		NumCounters: 100_000,
+		MaxCost:     1_000_000,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DirectoryRollups{cache: cache}, nil
+}
+
+// Rollup returns the number of files and their total size for every entry directly under prefix at version, directories
+// end with a slash. Rollups are cached when r is not nil.
+func (r *DirectoryRollups) Rollup(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, project int64, version int64, prefix string) ([]*pb.DirectorySize, error) {
+	cacheKey := fmt.Sprintf("%d:%d:%s", project, version, prefix)
+	if r != nil {
+		if value, found := r.cache.Get(cacheKey); found {
+			return value.([]*pb.DirectorySize), nil
+		}
+	}
+
+	sizes, err := DirectorySizes(ctx, tx, lookup, project, version, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if r != nil {
+		r.cache.SetWithTTL(cacheKey, sizes, int64(len(sizes))+1, rollupTTL)
+	}
+
+	return sizes, nil
+}
+
+// rollupEntry is the entry directly under prefix holding path, a directory when a slash follows its name.
+// It must stay in line with the regular expression DirectorySizes groups loose objects with.
+func rollupEntry(prefix string, path string) string {
+	rest := path[len(prefix):]
+
+	end := 0
+	if strings.HasPrefix(rest, "/") {
+		end = 1
+	}
+	if idx := strings.IndexByte(rest[end:], '/'); idx >= 0 {
+		end += idx + 1
+	} else {
+		end = len(rest)
+	}
+
+	return prefix + rest[:end]
+}
+
+// DirectorySizes rolls up the loose and packed files under prefix existing at version per entry directly under prefix.
+// Directory objects are not counted. Packed files are read from the entry index, only packs written before it existed
+// are decoded.
+func DirectorySizes(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, project int64, version int64, prefix string) ([]*pb.DirectorySize, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT $2 || substring(substr(o.path, length($2) + 1) FROM '^/?[^/]*/?') AS entry,
+		       count(*) FILTER (WHERE right(o.path, 1) != '/'),
+		       coalesce(sum(o.size) FILTER (WHERE right(o.path, 1) != '/'), 0)::bigint
+		FROM dl.objects o
+		WHERE o.project = $1
+		  AND o.packed IS false
+		  AND o.path LIKE $3
+		  AND o.path != $2
+		  AND o.start_version <= $4
+		  AND (o.stop_version IS NULL OR o.stop_version > $4)
+		GROUP BY entry
+	`, project, prefix, prefix+"%", version)
+	if err != nil {
+		return nil, fmt.Errorf("directory sizes, project %v version %v prefix %v: %w", project, version, prefix, err)
+	}
+
+	entries := make(map[string]*pb.DirectorySize)
+	for rows.Next() {
+		size := &pb.DirectorySize{}
+		err = rows.Scan(&size.Path, &size.Files, &size.Bytes)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("directory sizes scan, project %v: %w", project, err)
+		}
+		entries[size.Path] = size
+	}
+	rows.Close()
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	packs, err := selectLivePacks(ctx, tx, project, version, nil, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	indexed, err := selectPackEntries(ctx, tx, packs, nil, prefix, 0)
+	if err != nil {
+		return nil, fmt.Errorf("packed directory sizes, project %v version %v: %w", project, version, err)
+	}
+
+	decoded, err := decodePackEntries(ctx, tx, lookup, packs)
+	if err != nil {
+		return nil, fmt.Errorf("unindexed packed directory sizes, project %v version %v: %w", project, version, err)
+	}
+
+	for _, packed := range append(indexed, decoded...) {
+		if !strings.HasPrefix(packed.path, prefix) || packed.path == prefix {
+			continue
+		}
+
+		name := rollupEntry(prefix, packed.path)
+		size, ok := entries[name]
+		if !ok {
+			size = &pb.DirectorySize{Path: name}
+			entries[name] = size
+		}
+
+		if !strings.HasSuffix(packed.path, "/") {
+			size.Files += 1
+			size.Bytes += packed.size
+		}
+	}
+
+	sizes := make([]*pb.DirectorySize, 0, len(entries))
+	for _, size := range entries {
+		sizes = append(sizes, size)
+	}
+	slices.SortFunc(sizes, func(a, b *pb.DirectorySize) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return sizes, nil
+}
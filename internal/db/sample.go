@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/jackc/pgx/v5"
+)
+
+// SampleObjects sends a random sample of up to count live objects of project, or of every project when project is nil.
+// A sampled pack stands for one of its objects picked at random. Without includeContent no content is sent, otherwise
+// contents larger than maxContentSize are left out and flagged as omitted.
+func SampleObjects(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, project *int64, count int64, includeContent bool, maxContentSize int64, send func(int64, *pb.Objekt) error) error {
+	rows, err := tx.Query(ctx, `
+		SELECT project, path, mode, size, packed, (hash).h1, (hash).h2, content_type, mtime, inline_content
+		FROM dl.objects
+		WHERE stop_version IS NULL
+		  AND ($1::bigint IS NULL OR project = $1)
+		ORDER BY random()
+		LIMIT $2
+	`, project, count)
+	if err != nil {
+		return fmt.Errorf("sample objects query: %w", err)
+	}
+
+	var projects []int64
+	var dbObjects []DbObject
+	for rows.Next() {
+		var objectProject int64
+		var object DbObject
+		err = rows.Scan(&objectProject, &object.path, &object.mode, &object.size, &object.packed, &object.hash.H1, &object.hash.H2, &object.contentType, &object.mtime, &object.inline)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("sample objects scan: %w", err)
+		}
+
+		projects = append(projects, objectProject)
+		dbObjects = append(dbObjects, object)
+	}
+	rows.Close()
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	tarReader := NewTarReader()
+	defer tarReader.Close()
+
+	for start := 0; start < len(dbObjects); start += chunkSize {
+		batch := dbObjects[start:min(start+chunkSize, len(dbObjects))]
+		for idx := range batch {
+			// packs are always loaded to pick one of their objects
+			batch[idx].omitted = !batch[idx].packed && (!includeContent || (maxContentSize > 0 && batch[idx].size > maxContentSize))
+		}
+
+		chunk, err := loadChunk(ctx, tx, lookup, batch)
+		if err != nil {
+			return fmt.Errorf("sample objects load chunk: %w", err)
+		}
+
+		for idx, dbObject := range batch {
+			object := &pb.Objekt{
+				Path:        dbObject.path,
+				Mode:        dbObject.mode,
+				Size:        dbObject.size,
+				Content:     chunk[idx],
+				ContentType: dbObject.contentType,
+				Mtime:       dbObject.mtime,
+			}
+
+			if dbObject.packed {
+				tarReader.FromBytes(chunk[idx])
+				packed, err := unpackObjects(tarReader)
+				if err != nil {
+					return fmt.Errorf("sample objects unpack %v: %w", dbObject.path, err)
+				}
+				if len(packed) == 0 {
+					continue
+				}
+				object = packed[rand.IntN(len(packed))]
+			}
+
+			if !includeContent {
+				object.Content = nil
+			} else if maxContentSize > 0 && object.Size > maxContentSize {
+				object.Content = nil
+				object.ContentOmitted = true
+			}
+
+			err = send(projects[start+idx], object)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
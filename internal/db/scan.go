@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ScanPending  = "pending"
+	ScanClean    = "clean"
+	ScanInfected = "infected"
+	ScanFailed   = "failed"
+)
+
+// MaxScanAttempts is how many times a content scan is retried before it is marked as failed
+const MaxScanAttempts = 3
+
+// ContentScan is a queued content hash waiting for the content scanner.
+type ContentScan struct {
+	Hash   Hash
+	Packed bool
+}
+
+// QueueContentScans queues the contents written by a project version that were never queued before.
+func QueueContentScans(ctx context.Context, tx pgx.Tx, project int64, version int64) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO dl.content_scans (hash, packed)
+		SELECT DISTINCT ON (hash) hash, packed
+		FROM dl.objects
+		WHERE project = $1
+		  AND start_version = $2
+		ON CONFLICT (hash) DO NOTHING
+	`, project, version)
+	if err != nil {
+		return fmt.Errorf("queue content scans, project %v version %v: %w", project, version, err)
+	}
+
+	return nil
+}
+
+// ClaimContentScans locks up to limit pending scans, oldest first, until tx ends.
+// Scans locked by another transaction are skipped so that several servers can share the queue.
+func ClaimContentScans(ctx context.Context, tx pgx.Tx, limit int64) ([]ContentScan, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT (hash).h1, (hash).h2, packed
+		FROM dl.content_scans
+		WHERE status = $1
+		ORDER BY queued_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, ScanPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("claim content scans: %w", err)
+	}
+	defer rows.Close()
+
+	var scans []ContentScan
+	for rows.Next() {
+		var scan ContentScan
+		err = rows.Scan(&scan.Hash.H1, &scan.Hash.H2, &scan.Packed)
+		if err != nil {
+			return nil, fmt.Errorf("claim content scans scan: %w", err)
+		}
+		scans = append(scans, scan)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return scans, nil
+}
+
+// RecordContentScan stores the verdict of scanner on a content, signature is only set for infected contents.
+func RecordContentScan(ctx context.Context, tx pgx.Tx, hash Hash, scanner string, infected bool, signature string) error {
+	status := ScanClean
+	var signaturePtr *string
+	if infected {
+		status = ScanInfected
+		signaturePtr = &signature
+	}
+
+	_, err := tx.Exec(ctx, `
+		UPDATE dl.content_scans
+		SET status = $3, scanner = $4, signature = $5, error = NULL, attempts = attempts + 1, scanned_at = now()
+		WHERE hash = ($1, $2)
+	`, hash.H1, hash.H2, status, scanner, signaturePtr)
+	if err != nil {
+		return fmt.Errorf("record content scan %v: %w", hash.Hex(), err)
+	}
+
+	return nil
+}
+
+// RecordContentScanError keeps a content queued after a failed scan, it is marked as failed after MaxScanAttempts.
+func RecordContentScanError(ctx context.Context, tx pgx.Tx, hash Hash, scanner string, scanErr error) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE dl.content_scans
+		SET status = CASE WHEN attempts + 1 >= $5 THEN $6 ELSE status END,
+		    scanner = $3, error = $4, attempts = attempts + 1, scanned_at = now()
+		WHERE hash = ($1, $2)
+	`, hash.H1, hash.H2, scanner, scanErr.Error(), MaxScanAttempts, ScanFailed)
+	if err != nil {
+		return fmt.Errorf("record content scan error %v: %w", hash.Hex(), err)
+	}
+
+	return nil
+}
+
+// ScanStatus counts the objects of a project version by the scan status of their content and lists the infected ones.
+func ScanStatus(ctx context.Context, tx pgx.Tx, project int64, version int64) (*pb.ScanStatusResponse, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT o.path, (o.hash).h1, (o.hash).h2, coalesce(s.status, 'unscanned'), coalesce(s.signature, '')
+		FROM dl.objects o
+		LEFT JOIN dl.content_scans s ON s.hash = o.hash
+		WHERE o.project = $1
+		  AND o.start_version <= $2
+		  AND (o.stop_version IS NULL OR o.stop_version > $2)
+		ORDER BY o.path
+	`, project, version)
+	if err != nil {
+		return nil, fmt.Errorf("scan status query, project %v version %v: %w", project, version, err)
+	}
+	defer rows.Close()
+
+	response := &pb.ScanStatusResponse{Version: version}
+
+	for rows.Next() {
+		var path, status, signature string
+		var hash Hash
+		err = rows.Scan(&path, &hash.H1, &hash.H2, &status, &signature)
+		if err != nil {
+			return nil, fmt.Errorf("scan status scan, project %v: %w", project, err)
+		}
+
+		switch status {
+		case ScanPending:
+			response.Pending += 1
+		case ScanClean:
+			response.Clean += 1
+		case ScanInfected:
+			response.Infected += 1
+			response.Findings = append(response.Findings, &pb.ScanFinding{Path: path, Hash: hash.Bytes(), Signature: signature})
+		case ScanFailed:
+			response.Failed += 1
+		default:
+			response.Unscanned += 1
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return response, nil
+}
+
+// LoadScanContents loads the contents to scan, a pack is split into the objects it holds so each of them is scanned on its own.
+// Loose contents are returned as a single object without a path.
+func LoadScanContents(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, scans []ContentScan) (map[Hash][]*pb.Objekt, error) {
+	hashes := make(map[Hash]bool, len(scans))
+	for _, scan := range scans {
+		hashes[scan.Hash] = !scan.Packed
+	}
+
+	contents, err := lookup.Lookup(ctx, tx, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("load scan contents: %w", err)
+	}
+
+	tarReader := NewTarReader()
+	defer tarReader.Close()
+	objects := make(map[Hash][]*pb.Objekt, len(scans))
+
+	for _, scan := range scans {
+		content, ok := contents[scan.Hash]
+		if !ok {
+			// garbage collected since it was queued, nothing references it anymore
+			continue
+		}
+
+		if !scan.Packed {
+			objects[scan.Hash] = []*pb.Objekt{{Content: content, Size: int64(len(content))}}
+			continue
+		}
+
+		tarReader.FromBytes(content)
+		objects[scan.Hash], err = unpackObjects(tarReader)
+		if err != nil {
+			return nil, fmt.Errorf("load scan contents %v: %w", scan.Hash.Hex(), err)
+		}
+	}
+
+	return objects, nil
+}
+
+// DropContentScan removes a content from the scan queue.
+func DropContentScan(ctx context.Context, tx pgx.Tx, hash Hash) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM dl.content_scans
+		WHERE hash = ($1, $2)
+	`, hash.H1, hash.H2)
+	if err != nil {
+		return fmt.Errorf("drop content scan %v: %w", hash.Hex(), err)
+	}
+
+	return nil
+}
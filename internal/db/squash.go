@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type SquashResult struct {
+	Baseline int64
+	// objects only visible below the baseline, their contents can be garbage collected
+	Removed []Hash
+	// objects visible at the baseline that now start there
+	Rewritten int64
+}
+
+// SquashProjectHistory collapses every version of a project below baseline into baseline itself.
+// The files at baseline and every later version are unchanged, older versions can no longer be read or rolled back to.
+// The caller must hold the project's version lock.
+func SquashProjectHistory(ctx context.Context, tx pgx.Tx, project int64, baseline int64) (SquashResult, error) {
+	result := SquashResult{Baseline: baseline}
+
+	latest, current, err := getVersionBounds(ctx, tx, project)
+	if err != nil {
+		return result, err
+	}
+	if baseline > latest {
+		return result, fmt.Errorf("squash project %v to %v: baseline is above latest version %v", project, baseline, latest)
+	}
+	if baseline <= current {
+		result.Baseline = current
+		return result, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+		DELETE FROM dl.objects
+		WHERE project = $1
+		  AND stop_version <= $2
+		RETURNING (hash).h1, (hash).h2
+	`, project, baseline)
+	if err != nil {
+		return result, fmt.Errorf("squash project %v, delete objects below %v: %w", project, baseline, err)
+	}
+
+	result.Removed, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (Hash, error) {
+		var hash Hash
+		err := row.Scan(&hash.H1, &hash.H2)
+		return hash, err
+	})
+	if err != nil {
+		return result, fmt.Errorf("squash project %v, scan deleted objects: %w", project, err)
+	}
+
+	// a single object per path is visible at the baseline so moving their start cannot collide
+	tag, err := tx.Exec(ctx, `
+		UPDATE dl.objects
+		SET start_version = $2
+		WHERE project = $1
+		  AND start_version < $2
+	`, project, baseline)
+	if err != nil {
+		return result, fmt.Errorf("squash project %v, rewrite objects below %v: %w", project, baseline, err)
+	}
+	result.Rewritten = tag.RowsAffected()
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM dl.checkout_artifacts
+		WHERE project = $1
+		  AND version < $2
+	`, project, baseline)
+	if err != nil {
+		return result, fmt.Errorf("squash project %v, delete checkout artifacts below %v: %w", project, baseline, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM dl.version_annotations
+		WHERE project = $1
+		  AND version < $2
+	`, project, baseline)
+	if err != nil {
+		return result, fmt.Errorf("squash project %v, delete version annotations below %v: %w", project, baseline, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE dl.projects
+		SET baseline_version = $2
+		WHERE id = $1
+	`, project, baseline)
+	if err != nil {
+		return result, fmt.Errorf("squash project %v, set baseline %v: %w", project, baseline, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/jackc/pgx/v5"
+)
+
+// StageObject adds object to the named stage of project, replacing what was staged at its path. The content is stored
+// right away so committing the stage only has to record the objects.
+func StageObject(ctx context.Context, tx pgx.Tx, conn DbConnector, encoder *ContentEncoder, project int64, stage string, object *pb.Object) error {
+	var hash Hash
+	if !object.Deleted {
+		content := object.Content
+		if content == nil {
+			content = []byte("")
+		}
+		hash = HashContent(content)
+
+		encoded, err := encoder.Encode(content)
+		if err != nil {
+			return fmt.Errorf("encode staged content, project %v, stage %v, path %v: %w", project, stage, object.Path, err)
+		}
+
+		// insert the content outside the transaction like updates do, it is kept from collection by the staged object
+		_, err = conn.Exec(ctx, `
+			INSERT INTO dl.contents (hash, bytes, encoding)
+			VALUES (($1, $2), $3, $4)
+			ON CONFLICT DO NOTHING
+		`, hash.H1, hash.H2, encoded, encoder.Encoding())
+		if err != nil {
+			return fmt.Errorf("insert staged content, hash %x-%x: %w", hash.H1, hash.H2, err)
+		}
+	}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO dl.staged_objects (project, stage, path, hash, mode, size, deleted, content_type, mtime)
+		VALUES ($1, $2, $3, ($4, $5), $6, $7, $8, $9, $10)
+		ON CONFLICT (project, stage, path)
+		DO UPDATE SET hash = EXCLUDED.hash,
+		              mode = EXCLUDED.mode,
+		              size = EXCLUDED.size,
+		              deleted = EXCLUDED.deleted,
+		              content_type = EXCLUDED.content_type,
+		              mtime = EXCLUDED.mtime,
+		              staged_at = now()
+	`, project, stage, object.Path, hash.H1, hash.H2, object.Mode, object.Size, object.Deleted, object.ContentType, object.Mtime)
+	if err != nil {
+		return fmt.Errorf("stage object, project %v, stage %v, path %v: %w", project, stage, object.Path, err)
+	}
+
+	return nil
+}
+
+type stagedObject struct {
+	object *pb.Object
+	hash   Hash
+}
+
+func selectStagedObjects(ctx context.Context, tx pgx.Tx, project int64, stage string) ([]stagedObject, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT path, mode, size, deleted, (hash).h1, (hash).h2, content_type, mtime
+		FROM dl.staged_objects
+		WHERE project = $1
+		  AND stage = $2
+		ORDER BY path
+	`, project, stage)
+	if err != nil {
+		return nil, fmt.Errorf("select staged objects, project %v, stage %v: %w", project, stage, err)
+	}
+	defer rows.Close()
+
+	var staged []stagedObject
+	for rows.Next() {
+		object := stagedObject{object: &pb.Object{}}
+		err = rows.Scan(&object.object.Path, &object.object.Mode, &object.object.Size, &object.object.Deleted, &object.hash.H1, &object.hash.H2, &object.object.ContentType, &object.object.Mtime)
+		if err != nil {
+			return nil, fmt.Errorf("scan staged object, project %v, stage %v: %w", project, stage, err)
+		}
+		staged = append(staged, object)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return staged, nil
+}
+
+// ListStagedObjects describes the objects of a stage ordered by path, staged deletions do not exist.
+func ListStagedObjects(ctx context.Context, tx pgx.Tx, project int64, stage string) ([]*pb.PathStat, error) {
+	staged, err := selectStagedObjects(ctx, tx, project, stage)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]*pb.PathStat, 0, len(staged))
+	for _, object := range staged {
+		stat := &pb.PathStat{
+			Path:        object.object.Path,
+			Exists:      !object.object.Deleted,
+			ContentType: object.object.ContentType,
+		}
+		if !object.object.Deleted {
+			stat.Size = object.object.Size
+			stat.Mode = object.object.Mode
+			stat.Hash = object.hash.Bytes()
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
+
+// StagedObjects returns the objects of a stage with their content, ordered by path.
+func StagedObjects(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, project int64, stage string) ([]*pb.Object, error) {
+	staged, err := selectStagedObjects(ctx, tx, project, stage)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make(map[Hash]bool, len(staged))
+	for _, object := range staged {
+		if !object.object.Deleted {
+			hashes[object.hash] = true
+		}
+	}
+
+	contents, err := lookup.Lookup(ctx, tx, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("lookup staged contents, project %v, stage %v: %w", project, stage, err)
+	}
+
+	objects := make([]*pb.Object, 0, len(staged))
+	for _, object := range staged {
+		if !object.object.Deleted {
+			content, ok := contents[object.hash]
+			if !ok {
+				return nil, fmt.Errorf("staged content of %v missing, project %v, stage %v: %w", object.object.Path, project, stage, ErrNotFound)
+			}
+			object.object.Content = content
+		}
+		objects = append(objects, object.object)
+	}
+
+	return objects, nil
+}
+
+// DiscardStage drops every object of a stage and returns how many there were.
+func DiscardStage(ctx context.Context, tx pgx.Tx, project int64, stage string) (int64, error) {
+	tag, err := tx.Exec(ctx, `
+		DELETE FROM dl.staged_objects
+		WHERE project = $1
+		  AND stage = $2
+	`, project, stage)
+	if err != nil {
+		return 0, fmt.Errorf("discard stage, project %v, stage %v: %w", project, stage, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
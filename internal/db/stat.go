@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/jackc/pgx/v5"
+)
+
+// StatPaths returns the metadata of paths at a version, in the order they were requested. Loose objects are described
+// from their row alone, packed paths from the entries recorded for their pack.
+func StatPaths(ctx context.Context, tx pgx.Tx, lookup *ContentLookup, packManager *PackManager, project int64, version int64, paths []string) ([]*pb.PathStat, error) {
+	vrange := VersionRange{From: 0, To: version}
+	found := make(map[string]*pb.PathStat, len(paths))
+
+	var loose, packed []string
+	for _, path := range paths {
+		if packManager.IsPathPacked(path) != nil {
+			packed = append(packed, path)
+		} else {
+			loose = append(loose, path)
+		}
+	}
+
+	if len(loose) > 0 {
+		dbObjects, err := executeQuery(ctx, tx, newQueryBuilder(project, vrange, &pb.ObjectQuery{Paths: loose}))
+		if err != nil {
+			return nil, fmt.Errorf("stat paths query, project %v version %v: %w", project, version, err)
+		}
+
+		for _, dbObject := range dbObjects {
+			if dbObject.deleted {
+				continue
+			}
+
+			found[dbObject.path] = &pb.PathStat{
+				Path:        dbObject.path,
+				Exists:      true,
+				Size:        dbObject.size,
+				Mode:        dbObject.mode,
+				Hash:        dbObject.hash.Bytes(),
+				ContentType: dbObject.contentType,
+			}
+		}
+	}
+
+	if len(packed) > 0 {
+		packs, err := selectLivePacks(ctx, tx, project, version, packManager.QueryPaths(packed), "")
+		if err != nil {
+			return nil, err
+		}
+
+		entries, err := selectPackEntries(ctx, tx, packs, packed, "", 0)
+		if err != nil {
+			return nil, fmt.Errorf("stat packed paths, project %v version %v: %w", project, version, err)
+		}
+
+		decoded, err := decodePackEntries(ctx, tx, lookup, packs)
+		if err != nil {
+			return nil, fmt.Errorf("stat unindexed packed paths, project %v version %v: %w", project, version, err)
+		}
+
+		requested := make(map[string]bool, len(packed))
+		for _, path := range packed {
+			requested[path] = true
+		}
+
+		for _, entry := range append(entries, decoded...) {
+			if requested[entry.path] {
+				found[entry.path] = entry.stat()
+			}
+		}
+	}
+
+	stats := make([]*pb.PathStat, 0, len(paths))
+	for _, path := range paths {
+		stat, ok := found[path]
+		if !ok {
+			stat = &pb.PathStat{Path: path}
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
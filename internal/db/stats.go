@@ -0,0 +1,139 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/jackc/pgx/v5"
+)
+
+func newDedupStats(projects, objects, logicalBytes, uniqueBytes int64) *pb.DedupStats {
+	stats := &pb.DedupStats{
+		Projects:     projects,
+		Objects:      objects,
+		LogicalBytes: logicalBytes,
+		UniqueBytes:  uniqueBytes,
+	}
+	if uniqueBytes > 0 {
+		stats.DedupRatio = float64(logicalBytes) / float64(uniqueBytes)
+	}
+	return stats
+}
+
+// TotalDedupStats describes the live objects of every project, along with the stored size of their unique contents.
+func TotalDedupStats(ctx context.Context, tx pgx.Tx) (*pb.DedupStats, int64, error) {
+	var projects, objects, logicalBytes, uniqueBytes, storedBytes int64
+
+	err := tx.QueryRow(ctx, `
+		WITH live AS (
+			SELECT project, hash, size
+			FROM dl.objects
+			WHERE stop_version IS NULL
+		), unique_contents AS (
+			SELECT hash, max(size) AS size
+			FROM live
+			GROUP BY hash
+		)
+		SELECT (SELECT count(DISTINCT project) FROM live),
+		       (SELECT count(*) FROM live),
+		       (SELECT coalesce(sum(size), 0)::bigint FROM live),
+		       (SELECT coalesce(sum(size), 0)::bigint FROM unique_contents),
+		       (SELECT coalesce(sum(octet_length(c.bytes)), 0)::bigint FROM dl.contents c JOIN unique_contents u ON c.hash = u.hash)
+	`).Scan(&projects, &objects, &logicalBytes, &uniqueBytes, &storedBytes)
+	if err != nil {
+		return nil, 0, fmt.Errorf("total dedup stats: %w", err)
+	}
+
+	return newDedupStats(projects, objects, logicalBytes, uniqueBytes), storedBytes, nil
+}
+
+// TopSharedContents returns the contents referenced by the most projects, then by the most live objects.
+func TopSharedContents(ctx context.Context, tx pgx.Tx, limit int64) ([]*pb.SharedContent, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT (hash).h1, (hash).h2, max(size), count(*) AS refs, count(DISTINCT project) AS projects
+		FROM dl.objects
+		WHERE stop_version IS NULL
+		GROUP BY hash
+		ORDER BY projects DESC, refs DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("top shared contents: %w", err)
+	}
+	defer rows.Close()
+
+	var contents []*pb.SharedContent
+	for rows.Next() {
+		var hash Hash
+		var content pb.SharedContent
+		err = rows.Scan(&hash.H1, &hash.H2, &content.Size, &content.References, &content.Projects)
+		if err != nil {
+			return nil, fmt.Errorf("top shared contents scan: %w", err)
+		}
+		content.Hash = hash.Bytes()
+		contents = append(contents, &content)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return contents, nil
+}
+
+// TemplateFamilyDedupStats describes the live objects of every template and the projects created from it.
+func TemplateFamilyDedupStats(ctx context.Context, tx pgx.Tx) ([]*pb.TemplateFamilyStats, error) {
+	rows, err := tx.Query(ctx, `
+		WITH families AS (
+			SELECT template AS family, id AS project
+			FROM dl.projects
+			WHERE template IS NOT NULL
+			UNION
+			SELECT template AS family, template AS project
+			FROM dl.projects
+			WHERE template IS NOT NULL
+		), live AS (
+			SELECT f.family, f.project, o.hash, o.size
+			FROM families f
+			JOIN dl.objects o
+			  ON o.project = f.project
+			 AND o.stop_version IS NULL
+		), unique_contents AS (
+			SELECT family, hash, max(size) AS size
+			FROM live
+			GROUP BY family, hash
+		)
+		SELECT l.family, count(DISTINCT l.project), count(*), sum(l.size)::bigint,
+		       (SELECT sum(u.size)::bigint FROM unique_contents u WHERE u.family = l.family)
+		FROM live l
+		GROUP BY l.family
+		ORDER BY l.family
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("template family dedup stats: %w", err)
+	}
+	defer rows.Close()
+
+	var families []*pb.TemplateFamilyStats
+	for rows.Next() {
+		var template, projects, objects, logicalBytes, uniqueBytes int64
+		err = rows.Scan(&template, &projects, &objects, &logicalBytes, &uniqueBytes)
+		if err != nil {
+			return nil, fmt.Errorf("template family dedup stats scan: %w", err)
+		}
+
+		families = append(families, &pb.TemplateFamilyStats{
+			Template: template,
+			Stats:    newDedupStats(projects, objects, logicalBytes, uniqueBytes),
+		})
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return families, nil
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/fs"
 	"sort"
+	"time"
 
 	"github.com/gadget-inc/dateilager/internal/pb"
 	"github.com/klauspost/compress/s2"
@@ -17,6 +18,9 @@ var (
 	ErrEmptyPack = errors.New("empty object stream to pack")
 )
 
+// Tar entries carry no ownership and a fixed timestamp so identical objects always produce identical bytes
+var canonicalModTime = time.Unix(0, 0)
+
 type TarWriter struct {
 	size      int
 	buffer    *bytes.Buffer
@@ -26,7 +30,8 @@ type TarWriter struct {
 
 func NewTarWriter() *TarWriter {
 	var buffer bytes.Buffer
-	s2Writer := s2.NewWriter(&buffer)
+	s2Writer := s2Writers.Get().(*s2.Writer)
+	s2Writer.Reset(&buffer)
 
 	return &TarWriter{
 		size:      0,
@@ -36,6 +41,36 @@ func NewTarWriter() *TarWriter {
 	}
 }
 
+// NewTarWriterTo returns a TarWriter streaming its TAR into w instead of buffering it, Finish must be called once every
+// object is written.
+func NewTarWriterTo(w io.Writer) *TarWriter {
+	s2Writer := s2Writers.Get().(*s2.Writer)
+	s2Writer.Reset(w)
+
+	return &TarWriter{
+		size:      0,
+		s2Writer:  s2Writer,
+		tarWriter: tar.NewWriter(s2Writer),
+	}
+}
+
+// Finish ends the TAR streamed by a writer from NewTarWriterTo and releases it, the TarWriter must not be used afterwards.
+func (t *TarWriter) Finish() error {
+	err := t.tarWriter.Close()
+	if err != nil {
+		return fmt.Errorf("close TarWriter.tarWriter: %w", err)
+	}
+
+	err = t.s2Writer.Close()
+	if err != nil {
+		return fmt.Errorf("close TarWriter.s2Writer: %w", err)
+	}
+
+	releaseS2Writer(&s2Writers, t.s2Writer)
+	t.s2Writer = nil
+	return nil
+}
+
 func (t *TarWriter) BytesAndReset() ([]byte, error) {
 	err := t.tarWriter.Close()
 	if err != nil {
@@ -74,9 +109,18 @@ func (t *TarWriter) WriteObject(object *TarObject) error {
 		Mode:     int64(object.FileMode().Perm()),
 		Typeflag: typeFlag,
 		Size:     size,
+		Uid:      0,
+		Gid:      0,
+		Uname:    "",
+		Gname:    "",
+		ModTime:  canonicalModTime,
 		Format:   tar.FormatPAX,
 	}
 
+	if object.mtime != nil {
+		header.ModTime = time.Unix(0, *object.mtime)
+	}
+
 	if typeFlag == tar.TypeSymlink {
 		header.Linkname = string(object.content)
 	}
@@ -98,8 +142,17 @@ func (t *TarWriter) WriteObject(object *TarObject) error {
 	return nil
 }
 
+// Close releases the writer's s2 writer back to its pool, the TarWriter must not be used afterwards.
+// Bytes returned by BytesAndReset stay valid.
 func (t *TarWriter) Close() error {
-	return errors.Join(t.s2Writer.Close(), t.tarWriter.Close())
+	if t.s2Writer == nil {
+		return nil
+	}
+
+	err := errors.Join(t.s2Writer.Close(), t.tarWriter.Close())
+	releaseS2Writer(&s2Writers, t.s2Writer)
+	t.s2Writer = nil
+	return err
 }
 
 type TarObject struct {
@@ -109,6 +162,8 @@ type TarObject struct {
 	deleted bool
 	cached  bool
 	packed  bool
+	known   bool
+	mtime   *int64
 	content []byte
 }
 
@@ -120,6 +175,8 @@ func NewUncachedTarObject(path string, mode int64, size int64, deleted bool, con
 		deleted,
 		false,
 		false,
+		false,
+		nil,
 		content,
 	}
 }
@@ -136,19 +193,25 @@ func (o *TarObject) TarType() byte {
 	if o.cached {
 		return pb.TarCached
 	}
+	if o.known {
+		return pb.TarKnown
+	}
 
 	return pb.TarTypeFromMode(o.FileMode())
 }
 
 type TarReader struct {
-	buffer    *bytes.Buffer
+	buffer    *bytes.Reader
 	s2Reader  *s2.Reader
 	tarReader *tar.Reader
+	// remaining is the size of the content of the current entry
+	remaining int64
 }
 
 func NewTarReader() *TarReader {
-	buffer := &bytes.Buffer{}
-	s2Reader := s2.NewReader(buffer)
+	buffer := bytes.NewReader(nil)
+	s2Reader := s2Readers.Get().(*s2.Reader)
+	s2Reader.Reset(buffer)
 
 	return &TarReader{
 		buffer:    buffer,
@@ -157,37 +220,59 @@ func NewTarReader() *TarReader {
 	}
 }
 
+// FromBytes starts reading content, which is read in place and must not be modified until the reader is done with it.
 func (t *TarReader) FromBytes(content []byte) {
-	t.buffer.Reset()
-	t.buffer.Write(content)
+	t.buffer.Reset(content)
 	t.s2Reader.Reset(t.buffer)
 	t.tarReader = tar.NewReader(t.s2Reader)
+	t.remaining = 0
 }
 
 func (t *TarReader) Next() (*tar.Header, error) {
-	return t.tarReader.Next()
+	header, err := t.tarReader.Next()
+	t.remaining = 0
+	if header != nil {
+		t.remaining = header.Size
+	}
+	return header, err
 }
 
 func (t *TarReader) ReadContent() ([]byte, error) {
-	var buffer bytes.Buffer
-	_, err := io.Copy(&buffer, t.tarReader)
+	if t.remaining <= 0 {
+		return nil, nil
+	}
+
+	content := make([]byte, t.remaining)
+	_, err := io.ReadFull(t.tarReader, content)
+	t.remaining = 0
 	if err != nil {
 		return nil, fmt.Errorf("read content from TarReader: %w", err)
 	}
 
-	return buffer.Bytes(), nil
+	return content, nil
 }
 
 func (t *TarReader) CopyContent(buffer io.Writer) error {
 	_, err := io.Copy(buffer, t.tarReader)
+	t.remaining = 0
 	return err
 }
 
-func packObjects(objects ObjectStream) ([]byte, error) {
-	contentWriter := NewTarWriter()
-	defer contentWriter.Close()
+// Close releases the reader's s2 reader back to its pool, the TarReader must not be used afterwards.
+func (t *TarReader) Close() {
+	if t.s2Reader == nil {
+		return
+	}
 
-	empty := true
+	releaseS2Reader(t.s2Reader)
+	t.s2Reader = nil
+	t.tarReader = nil
+}
+
+// packObjects writes a canonical pack: entries are sorted by path and mtimes are dropped,
+// so the same logical content always hashes the same no matter how the pack was built.
+func packObjects(objects ObjectStream) ([]byte, error) {
+	var packed []*pb.Object
 
 	for {
 		object, err := objects()
@@ -201,19 +286,26 @@ func packObjects(objects ObjectStream) ([]byte, error) {
 			return nil, err
 		}
 
-		empty = false
+		packed = append(packed, object)
+	}
 
+	if len(packed) == 0 {
+		return nil, ErrEmptyPack
+	}
+
+	sort.Slice(packed, func(i, j int) bool { return packed[i].Path < packed[j].Path })
+
+	contentWriter := NewTarWriter()
+	defer contentWriter.Close()
+
+	for _, object := range packed {
 		tarObj := NewUncachedTarObject(object.Path, object.Mode, object.Size, object.Deleted, object.Content)
-		err = contentWriter.WriteObject(&tarObj)
+		err := contentWriter.WriteObject(&tarObj)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	if empty {
-		return nil, ErrEmptyPack
-	}
-
 	contentTar, err := contentWriter.BytesAndReset()
 	if err != nil {
 		return nil, err
@@ -227,6 +319,7 @@ func updateObjects(before []byte, updates []*pb.Object) ([]byte, error) {
 	idxHint := 0
 
 	reader := NewTarReader()
+	defer reader.Close()
 	reader.FromBytes(before)
 	readerObjectsRemaining := true
 
@@ -280,6 +373,35 @@ func updateObjects(before []byte, updates []*pb.Object) ([]byte, error) {
 	return packObjects(stream)
 }
 
+// CanonicalizePack rewrites a pack in canonical form, it returns the input unchanged if it already is.
+func CanonicalizePack(before []byte) ([]byte, error) {
+	reader := NewTarReader()
+	defer reader.Close()
+	reader.FromBytes(before)
+
+	objects, err := unpackObjects(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := 0
+	canonical, err := packObjects(func() (*pb.Object, error) {
+		if idx >= len(objects) {
+			return nil, io.EOF
+		}
+		idx += 1
+		return objects[idx-1], nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(before, canonical) {
+		return before, nil
+	}
+	return canonical, nil
+}
+
 func findUpdate(updates []*pb.Object, path string) *pb.Object {
 	for _, object := range updates {
 		if path == object.Path {
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ScopeToTenant restricts the rest of the transaction to the projects of tenant and the rows that belong to them.
+// The transaction switches to the dl_tenant role whose row level security policies compare projects to the dl.tenant setting,
+// so the guard holds whichever query runs next and even when the server connects as a superuser.
+func ScopeToTenant(ctx context.Context, tx pgx.Tx, tenant string) error {
+	_, err := tx.Exec(ctx, "SET LOCAL ROLE dl_tenant")
+	if err != nil {
+		return fmt.Errorf("scope to tenant %v, set role: %w", tenant, err)
+	}
+
+	_, err = tx.Exec(ctx, "SELECT set_config('dl.tenant', $1, true)", tenant)
+	if err != nil {
+		return fmt.Errorf("scope to tenant %v, set config: %w", tenant, err)
+	}
+
+	return nil
+}
+
+// UnscopeTenant reverts ScopeToTenant for a transaction that keeps being used afterwards.
+func UnscopeTenant(ctx context.Context, tx pgx.Tx) error {
+	_, err := tx.Exec(ctx, "RESET ROLE")
+	if err != nil {
+		return fmt.Errorf("unscope tenant, reset role: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, "SELECT set_config('dl.tenant', '', true)")
+	if err != nil {
+		return fmt.Errorf("unscope tenant, set config: %w", err)
+	}
+
+	return nil
+}
+
+// SetProjectTenant assigns a project to a tenant, tenant scoped transactions cannot move a project out of their tenant.
+func SetProjectTenant(ctx context.Context, tx pgx.Tx, project int64, tenant string) error {
+	tag, err := tx.Exec(ctx, `
+		UPDATE dl.projects
+		SET tenant = $2
+		WHERE id = $1
+	`, project, tenant)
+	if err != nil {
+		return fmt.Errorf("set project tenant %v: %w", project, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("set project tenant %v: %w", project, ErrNotFound)
+	}
+
+	return nil
+}
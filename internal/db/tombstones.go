@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// A tombstone is a stopped object row whose path was deleted rather than replaced by a newer row.
+const tombstonePredicate = `
+	o.stop_version IS NOT NULL
+	AND NOT EXISTS (
+		SELECT 1
+		FROM dl.objects n
+		WHERE n.project = o.project
+		  AND n.path = o.path
+		  AND n.start_version = o.stop_version
+	)
+`
+
+func TombstonesCount(ctx context.Context, tx pgx.Tx, project int64) (int64, error) {
+	var count int64
+	err := tx.QueryRow(ctx, fmt.Sprintf(`
+		SELECT count(*)
+		FROM dl.objects o
+		WHERE o.project = $1
+		  AND %s
+	`, tombstonePredicate), project).Scan(&count)
+	if err != nil {
+		return -1, fmt.Errorf("tombstones count for project %v: %w", project, err)
+	}
+
+	return count, nil
+}
+
+// ListTombstones returns the paths under prefix that were deleted at or before version and do not exist at version,
+// along with the version of their latest deletion.
+func ListTombstones(ctx context.Context, tx pgx.Tx, project int64, version int64, prefix string, limit int64) ([]*pb.Tombstone, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT ON (o.path) o.path, o.stop_version, o.mode, o.size
+		FROM dl.objects o
+		WHERE o.project = $1
+		  AND o.path LIKE $2
+		  AND o.stop_version <= $3
+		  AND NOT EXISTS (
+			SELECT 1
+			FROM dl.objects l
+			WHERE l.project = o.project
+			  AND l.path = o.path
+			  AND l.start_version <= $3
+			  AND (l.stop_version IS NULL OR l.stop_version > $3)
+		  )
+		ORDER BY o.path, o.stop_version DESC
+		LIMIT $4
+	`, project, prefix+"%", version, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list tombstones, project %v, version %v, prefix %v: %w", project, version, prefix, err)
+	}
+	defer rows.Close()
+
+	var tombstones []*pb.Tombstone
+	for rows.Next() {
+		var tombstone pb.Tombstone
+		err = rows.Scan(&tombstone.Path, &tombstone.DeletedVersion, &tombstone.Mode, &tombstone.Size)
+		if err != nil {
+			return nil, fmt.Errorf("list tombstones scan, project %v: %w", project, err)
+		}
+		tombstones = append(tombstones, &tombstone)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return tombstones, nil
+}
+
+// PurgeTombstones removes the tombstones under prefix of paths deleted before beforeVersion and returns the hashes they referenced.
+// Like GcProjectObjects, ranges starting below beforeVersion no longer report these deletions.
+func PurgeTombstones(ctx context.Context, conn DbConnector, project int64, prefix string, beforeVersion int64) ([]Hash, error) {
+	ctx, span := telemetry.Start(ctx, "gc.purge-tombstones", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Prefix.Attribute(prefix),
+		key.Version.Attribute(beforeVersion),
+	))
+	defer span.End()
+
+	hashes := []Hash{}
+
+	rows, err := conn.Query(ctx, fmt.Sprintf(`
+		DELETE FROM dl.objects o
+		WHERE o.project = $1
+		  AND o.path LIKE $2
+		  AND o.stop_version < $3
+		  AND %s
+		RETURNING (o.hash).h1, (o.hash).h2
+	`, tombstonePredicate), project, prefix+"%", beforeVersion)
+	if err != nil {
+		return hashes, fmt.Errorf("PurgeTombstones query, project %v, prefix %v, before %v: %w", project, prefix, beforeVersion, err)
+	}
+
+	for rows.Next() {
+		var hash Hash
+		err = rows.Scan(&hash.H1, &hash.H2)
+		if err != nil {
+			return hashes, fmt.Errorf("PurgeTombstones scan %v: %w", project, err)
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	return hashes, nil
+}
@@ -23,14 +23,20 @@ func UpdateLatestVersion(ctx context.Context, tx pgx.Tx, project int64, version
 	return nil
 }
 
+// DeleteObject removes the object at path, a path without a trailing slash also removes a directory of that name.
 func DeleteObject(ctx context.Context, tx pgx.Tx, project int64, version int64, path string) error {
+	paths := []string{path}
+	if !pb.IsDirPath(path) {
+		paths = append(paths, path+"/")
+	}
+
 	_, err := tx.Exec(ctx, `
 		UPDATE dl.objects
 		SET stop_version = $1
 		WHERE project = $2
-		  AND path = $3
+		  AND path = ANY($3)
 		  AND stop_version IS NULL
-	`, version, project, path)
+	`, version, project, paths)
 	if err != nil {
 		return fmt.Errorf("delete object, project %v, version %v, path %v: %w", project, version, path, err)
 	}
@@ -46,28 +52,34 @@ func UpdateObject(ctx context.Context, tx pgx.Tx, conn DbConnector, encoder *Con
 	}
 	hash := HashContent(content)
 
-	encoded, err := encoder.Encode(content)
-	if err != nil {
-		return false, fmt.Errorf("encode updated content, project %v, version %v, path %v: %w", project, version, object.Path, err)
-	}
+	// small contents are stored in the object row, they never reach dl.contents
+	var inline []byte
+	if encoder.Inlines(len(content)) {
+		inline = content
+	} else {
+		encoded, err := encoder.Encode(content)
+		if err != nil {
+			return false, fmt.Errorf("encode updated content, project %v, version %v, path %v: %w", project, version, object.Path, err)
+		}
 
-	// insert the content outside the transaction to avoid deadlocks and to keep smaller transactions
-	_, err = conn.Exec(ctx, `
-		INSERT INTO dl.contents (hash, bytes)
-		VALUES (($1, $2), $3)
-		ON CONFLICT DO NOTHING
-	`, hash.H1, hash.H2, encoded)
-	if err != nil {
-		return false, fmt.Errorf("insert objects content, hash %x-%x: %w", hash.H1, hash.H2, err)
+		// insert the content outside the transaction to avoid deadlocks and to keep smaller transactions
+		_, err = conn.Exec(ctx, `
+			INSERT INTO dl.contents (hash, bytes, encoding)
+			VALUES (($1, $2), $3, $4)
+			ON CONFLICT DO NOTHING
+		`, hash.H1, hash.H2, encoded, encoder.Encoding())
+		if err != nil {
+			return false, fmt.Errorf("insert objects content, hash %x-%x: %w", hash.H1, hash.H2, err)
+		}
 	}
 
 	rows, err := tx.Query(ctx, `
-		INSERT INTO dl.objects (project, start_version, stop_version, path, hash, mode, size, packed)
-		VALUES ($1, $2, NULL, $3, ($4, $5), $6, $7, $8)
+		INSERT INTO dl.objects (project, start_version, stop_version, path, hash, mode, size, packed, content_type, mtime, inline_content)
+		VALUES ($1, $2, NULL, $3, ($4, $5), $6, $7, $8, $9, $10, $11)
 		ON CONFLICT
 	       DO NOTHING
 		RETURNING project
-	`, project, version, object.Path, hash.H1, hash.H2, object.Mode, object.Size, false)
+	`, project, version, object.Path, hash.H1, hash.H2, object.Mode, object.Size, false, object.ContentType, object.Mtime, inline)
 	if err != nil {
 		return false, fmt.Errorf("insert new object, project %v, version %v, path %v: %w", project, version, object.Path, err)
 	}
@@ -160,17 +172,27 @@ func UpdatePackedObjects(ctx context.Context, tx pgx.Tx, conn DbConnector, proje
 	`, version, project, parent)
 
 	if shouldInsert {
+		index, err := BuildPackIndex(updated)
+		if err != nil {
+			return false, fmt.Errorf("index packed content, hash %x-%x: %w", newHash.H1, newHash.H2, err)
+		}
+
 		// insert the content outside the transaction to avoid deadlocks and to keep smaller transactions
 		_, err = conn.Exec(ctx, `
-			INSERT INTO dl.contents (hash, bytes)
-			VALUES (($1, $2), $3)
+			INSERT INTO dl.contents (hash, bytes, pack_index)
+			VALUES (($1, $2), $3, $4)
 			ON CONFLICT DO NOTHING
-		`, newHash.H1, newHash.H2, updated)
+		`, newHash.H1, newHash.H2, updated, index)
 
 		if err != nil {
 			return false, fmt.Errorf("insert packed content, hash %x-%x: %w", newHash.H1, newHash.H2, err)
 		}
 
+		err = InsertPackEntries(ctx, conn, newHash, updated)
+		if err != nil {
+			return false, err
+		}
+
 		batch.Queue(`
 			INSERT INTO dl.objects (project, start_version, stop_version, path, hash, mode, size, packed)
 			VALUES ($1, $2, NULL, $3, ($4, $5), $6, $7, $8)
@@ -195,3 +217,77 @@ func UpdatePackedObjects(ctx context.Context, tx pgx.Tx, conn DbConnector, proje
 	// content did change
 	return true, nil
 }
+
+// IdenticalObjects reports for each object whether the latest version of the project already has the same content and mode at its path.
+func IdenticalObjects(ctx context.Context, tx pgx.Tx, project int64, objects []*pb.ObjectHash) ([]bool, error) {
+	paths := make([]string, len(objects))
+	for idx, object := range objects {
+		paths[idx] = object.Path
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT path, mode, (hash).h1, (hash).h2
+		FROM dl.objects
+		WHERE project = $1
+		  AND path = ANY($2)
+		  AND packed IS false
+		  AND stop_version IS NULL
+	`, project, paths)
+	if err != nil {
+		return nil, fmt.Errorf("select latest objects, project %v: %w", project, err)
+	}
+	defer rows.Close()
+
+	type latestObject struct {
+		mode int64
+		hash Hash
+	}
+	latest := make(map[string]latestObject, len(objects))
+
+	for rows.Next() {
+		var path string
+		var object latestObject
+		err = rows.Scan(&path, &object.mode, &object.hash.H1, &object.hash.H2)
+		if err != nil {
+			return nil, fmt.Errorf("scan latest object, project %v: %w", project, err)
+		}
+		latest[path] = object
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate rows: %w", err)
+	}
+
+	identical := make([]bool, len(objects))
+	for idx, object := range objects {
+		hash, err := HashFromBytes(object.Hash)
+		if err != nil {
+			continue
+		}
+
+		existing, ok := latest[object.Path]
+		identical[idx] = ok && existing.hash == hash && existing.mode == object.Mode
+	}
+
+	return identical, nil
+}
+
+// PathChangedSince reports whether the object at path was written or deleted after version.
+func PathChangedSince(ctx context.Context, tx pgx.Tx, project int64, path string, version int64) (bool, error) {
+	var changed bool
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM dl.objects
+			WHERE project = $1
+			  AND path = $2
+			  AND (start_version > $3 OR stop_version > $3)
+		)
+	`, project, path, version).Scan(&changed)
+	if err != nil {
+		return false, fmt.Errorf("check changes of %v since version %v, project %v: %w", path, version, project, err)
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,91 @@
+package files
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+// Durability controls how the files written by WriteTar are flushed to disk.
+type Durability int
+
+const (
+	// DurabilityNone leaves flushing to the operating system.
+	DurabilityNone Durability = iota
+	// DurabilityBatched flushes the filesystem once every batch of files and once more when writing is done.
+	DurabilityBatched
+	// DurabilityFull flushes every file before it is renamed into place and the filesystem when writing is done.
+	DurabilityFull
+)
+
+// DefaultSyncBatchSize is how many files are written between two flushes with DurabilityBatched.
+const DefaultSyncBatchSize = 1000
+
+func ParseDurability(value string) (Durability, error) {
+	switch value {
+	case "none":
+		return DurabilityNone, nil
+	case "batched":
+		return DurabilityBatched, nil
+	case "full":
+		return DurabilityFull, nil
+	default:
+		return DurabilityNone, fmt.Errorf("invalid durability %q, expected none, batched or full", value)
+	}
+}
+
+func (d Durability) String() string {
+	switch d {
+	case DurabilityBatched:
+		return "batched"
+	case DurabilityFull:
+		return "full"
+	default:
+		return "none"
+	}
+}
+
+// Syncer flushes the files written under a directory according to a Durability. It is shared by every WriteTar call
+// of a checkout, a nil Syncer flushes nothing.
+type Syncer struct {
+	dir        string
+	durability Durability
+	batchSize  int64
+	written    atomic.Int64
+}
+
+// NewSyncer returns a Syncer for the files written under dir, a batchSize of 0 or less uses DefaultSyncBatchSize.
+func NewSyncer(dir string, durability Durability, batchSize int) *Syncer {
+	if batchSize <= 0 {
+		batchSize = DefaultSyncBatchSize
+	}
+	return &Syncer{dir: dir, durability: durability, batchSize: int64(batchSize)}
+}
+
+// syncFile flushes a file that was just written, before it is renamed into place.
+func (s *Syncer) syncFile(file *os.File) error {
+	if s == nil || s.durability != DurabilityFull {
+		return nil
+	}
+	return file.Sync()
+}
+
+// fileWritten counts a written file and flushes the filesystem when it completes a batch.
+func (s *Syncer) fileWritten() error {
+	if s == nil || s.durability != DurabilityBatched {
+		return nil
+	}
+
+	if s.written.Add(1)%s.batchSize == 0 {
+		return syncFilesystem(s.dir)
+	}
+	return nil
+}
+
+// Finish flushes the filesystem once every file has been written.
+func (s *Syncer) Finish() error {
+	if s == nil || s.durability == DurabilityNone {
+		return nil
+	}
+	return syncFilesystem(s.dir)
+}
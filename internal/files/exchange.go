@@ -0,0 +1,30 @@
+//go:build !linux
+
+package files
+
+import (
+	"fmt"
+	"os"
+)
+
+// ExchangeDirs swaps the directories at a and b, both must exist on the same filesystem.
+// Without renameat2 the swap takes two renames and b is briefly missing in between.
+func ExchangeDirs(a, b string) error {
+	tmp := b + ".dl-exchange"
+	err := os.Rename(b, tmp)
+	if err != nil {
+		return fmt.Errorf("rename %v to %v: %w", b, tmp, err)
+	}
+
+	err = os.Rename(a, b)
+	if err != nil {
+		_ = os.Rename(tmp, b)
+		return fmt.Errorf("rename %v to %v: %w", a, b, err)
+	}
+
+	err = os.Rename(tmp, a)
+	if err != nil {
+		return fmt.Errorf("rename %v to %v: %w", tmp, a, err)
+	}
+	return nil
+}
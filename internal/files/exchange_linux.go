@@ -0,0 +1,18 @@
+//go:build linux
+
+package files
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// ExchangeDirs atomically swaps the directories at a and b, both must exist on the same filesystem.
+func ExchangeDirs(a, b string) error {
+	err := unix.Renameat2(unix.AT_FDCWD, a, unix.AT_FDCWD, b, unix.RENAME_EXCHANGE)
+	if err != nil {
+		return fmt.Errorf("exchange %v and %v: %w", a, b, err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package files
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+)
+
+// Files smaller than this are cheaper to download again than to advertise on every rebuild
+const objectCacheMinSize = 4 * 1024
+
+// ErrReflinkUnsupported is returned by Reflink when the platform or the filesystem cannot clone files.
+var ErrReflinkUnsupported = errors.New("reflinks are not supported")
+
+// ObjectCache stores the content of regular files by hash so it can be shared between rebuilds of different projects.
+type ObjectCache struct {
+	dir string
+	// noReflink is set once cloning an object failed as unsupported, every later copy goes straight to a byte copy
+	noReflink atomic.Bool
+}
+
+func NewObjectCache(dir string) (*ObjectCache, error) {
+	err := os.MkdirAll(dir, 0775)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create object cache dir %v: %w", dir, err)
+	}
+
+	return &ObjectCache{dir: dir}, nil
+}
+
+// Hashes lists the hashes of every object in the cache.
+func (c *ObjectCache) Hashes() ([][]byte, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read object cache dir %v: %w", c.dir, err)
+	}
+
+	var hashes [][]byte
+	for _, entry := range entries {
+		hash, err := hex.DecodeString(entry.Name())
+		if err != nil || len(hash) != 32 {
+			continue
+		}
+		hashes = append(hashes, hash)
+	}
+
+	return hashes, nil
+}
+
+func (c *ObjectCache) path(hash []byte) string {
+	return filepath.Join(c.dir, hex.EncodeToString(hash))
+}
+
+// Store adds content to the cache, it is written to a temporary file first so concurrent rebuilds never see partial objects.
+func (c *ObjectCache) Store(content []byte) error {
+	if len(content) < objectCacheMinSize {
+		return nil
+	}
+
+	hash := db.HashContent(content)
+	path := c.path(hash.Bytes())
+	if fileExists(path) {
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(c.dir, "tmp_")
+	if err != nil {
+		return fmt.Errorf("cannot create object cache tmp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(content)
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("cannot write object cache tmp file %v: %w", tmp.Name(), err)
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return fmt.Errorf("cannot close object cache tmp file %v: %w", tmp.Name(), err)
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return fmt.Errorf("cannot rename object cache tmp file to %v: %w", path, err)
+	}
+
+	return nil
+}
+
+// CopyTo writes a copy of a cached object to path, objects are copied rather than hardlinked as the files are then edited in place.
+// Objects are cloned when the filesystem supports it, as on APFS, btrfs or xfs, so no data is copied until either file changes.
+func (c *ObjectCache) CopyTo(hash []byte, path string, mode fs.FileMode, syncer *Syncer) error {
+	dir := filepath.Dir(path)
+	_, err := retryFileErrors(dir, func() (interface{}, error) {
+		return nil, os.MkdirAll(dir, 0777)
+	})
+	if err != nil {
+		return fmt.Errorf("mkdir -p %v: %w", dir, err)
+	}
+
+	if !c.noReflink.Load() {
+		err = c.cloneTo(hash, path, mode, syncer)
+		if err == nil {
+			return nil
+		}
+		if errors.Is(err, ErrReflinkUnsupported) {
+			c.noReflink.Store(true)
+		}
+	}
+
+	source, err := os.Open(c.path(hash))
+	if err != nil {
+		return fmt.Errorf("open cached object %x: %w", hash, err)
+	}
+	defer source.Close()
+
+	return writeFileAtomic(path, mode, syncer, func(file *os.File) error {
+		_, err := io.Copy(file, source)
+		if err != nil {
+			return fmt.Errorf("copy cached object %x to %v: %w", hash, path, err)
+		}
+		return nil
+	})
+}
+
+// cloneTo reflinks a cached object to a temporary file renamed over path, like writeFileAtomic does for copies.
+func (c *ObjectCache) cloneTo(hash []byte, path string, mode fs.FileMode, syncer *Syncer) error {
+	tmpPath := filepath.Join(filepath.Dir(path), partialFilePrefix+filepath.Base(path)+"-c"+strconv.FormatInt(time.Now().UnixNano(), 36))
+
+	err := Reflink(c.path(hash), tmpPath)
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tmpPath, mode)
+	if err != nil {
+		err = fmt.Errorf("chmod %v on disk: %w", path, err)
+	}
+
+	if err == nil && syncer != nil && syncer.durability == DurabilityFull {
+		var file *os.File
+		file, err = os.Open(tmpPath)
+		if err == nil {
+			err = syncer.syncFile(file)
+			file.Close()
+		}
+		if err != nil {
+			err = fmt.Errorf("sync %v: %w", path, err)
+		}
+	}
+
+	if err == nil {
+		_, err = retryFileErrors(path, func() (interface{}, error) {
+			return nil, os.Rename(tmpPath, path)
+		})
+		if err != nil {
+			err = fmt.Errorf("rename %v to %v: %w", tmpPath, path, err)
+		}
+	}
+
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return nil
+}
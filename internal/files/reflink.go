@@ -0,0 +1,12 @@
+//go:build !linux && !darwin
+
+package files
+
+import (
+	"fmt"
+)
+
+// Reflink is unsupported on this platform, it always fails with ErrReflinkUnsupported.
+func Reflink(oldname, newname string) error {
+	return fmt.Errorf("clone %v to %v: %w", oldname, newname, ErrReflinkUnsupported)
+}
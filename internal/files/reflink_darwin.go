@@ -0,0 +1,23 @@
+//go:build darwin
+
+package files
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// Reflink creates newname as a copy on write clone of oldname with clonefile(2), so no data is copied on APFS.
+// It fails with ErrReflinkUnsupported when the filesystem cannot clone files, newname must not exist.
+func Reflink(oldname, newname string) error {
+	err := unix.Clonefile(oldname, newname, unix.CLONE_NOFOLLOW)
+	if errors.Is(err, unix.ENOTSUP) || errors.Is(err, unix.EXDEV) {
+		return fmt.Errorf("clonefile %v to %v: %w", oldname, newname, ErrReflinkUnsupported)
+	}
+	if err != nil {
+		return fmt.Errorf("clonefile %v to %v: %w", oldname, newname, err)
+	}
+	return nil
+}
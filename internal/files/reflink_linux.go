@@ -0,0 +1,44 @@
+//go:build linux
+
+package files
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// Reflink creates newname as a copy on write clone of oldname with the FICLONE ioctl, so no data is copied on
+// filesystems sharing extents like btrfs and xfs. It fails with ErrReflinkUnsupported when the filesystem cannot
+// clone files, newname must not exist.
+func Reflink(oldname, newname string) error {
+	source, err := os.Open(oldname)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	dest, err := os.OpenFile(newname, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+
+	err = unix.IoctlFileClone(int(dest.Fd()), int(source.Fd()))
+	closeErr := dest.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(newname)
+	}
+
+	if errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.EXDEV) || errors.Is(err, unix.EINVAL) || errors.Is(err, unix.ENOTTY) {
+		return fmt.Errorf("ficlone %v to %v: %w", oldname, newname, ErrReflinkUnsupported)
+	}
+	if err != nil {
+		return fmt.Errorf("ficlone %v to %v: %w", oldname, newname, err)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package files
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// syncFilesystem flushes every filesystem, there is no call limited to the one holding dir.
+func syncFilesystem(dir string) error {
+	unix.Sync()
+	return nil
+}
@@ -0,0 +1,25 @@
+//go:build linux
+
+package files
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// syncFilesystem flushes the filesystem holding dir with a single syncfs call.
+func syncFilesystem(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("open %v to sync: %w", dir, err)
+	}
+	defer file.Close()
+
+	err = unix.Syncfs(int(file.Fd()))
+	if err != nil {
+		return fmt.Errorf("syncfs %v: %w", dir, err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+//go:build !linux
+
+package files
+
+import (
+	"errors"
+	"os"
+)
+
+// UringWriter is only available on Linux, NewUringWriter always fails elsewhere so files are written directly.
+type UringWriter struct{}
+
+func NewUringWriter(syncer *Syncer) (*UringWriter, error) {
+	return nil, errors.New("io_uring is only available on linux")
+}
+
+func (w *UringWriter) Close() {}
+
+func (w *UringWriter) Write(path string, mode os.FileMode, content []byte) error {
+	return errors.New("io_uring is only available on linux")
+}
+
+func (w *UringWriter) Flush() error {
+	return nil
+}
+
+func (w *UringWriter) Discard() {}
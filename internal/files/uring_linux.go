@@ -0,0 +1,383 @@
+//go:build linux
+
+package files
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	uringEntries = 256
+
+	uringOpFsync    = 3
+	uringOpClose    = 19
+	uringOpWrite    = 23
+	uringOpRenameat = 35
+
+	uringSqeIOLink        = 1 << 2
+	uringEnterGetEvents   = 1 << 0
+	uringFeatSingleMmap   = 1 << 0
+	uringOffSqRing        = 0
+	uringOffCqRing        = 0x8000000
+	uringOffSqes          = 0x10000000
+	uringSqeSize          = 64
+	uringCqeSize          = 16
+	uringMaxOpsPerFile    = 4
+	uringUserDataOpOffset = 2
+)
+
+type uringSqringOffsets struct {
+	head, tail, ringMask, ringEntries, flags, dropped, array, resv1 uint32
+	userAddr                                                        uint64
+}
+
+type uringCqringOffsets struct {
+	head, tail, ringMask, ringEntries, overflow, cqes, flags, resv1 uint32
+	userAddr                                                        uint64
+}
+
+type uringParams struct {
+	sqEntries, cqEntries, flags, sqThreadCPU, sqThreadIdle, features, wqFd uint32
+	resv                                                                   [3]uint32
+	sqOff                                                                  uringSqringOffsets
+	cqOff                                                                  uringCqringOffsets
+}
+
+type uringSqe struct {
+	opcode      uint8
+	flags       uint8
+	ioprio      uint16
+	fd          int32
+	off         uint64
+	addr        uint64
+	len         uint32
+	opFlags     uint32
+	userData    uint64
+	bufIndex    uint16
+	personality uint16
+	spliceFdIn  int32
+	addr3       uint64
+	pad         uint64
+}
+
+type uringCqe struct {
+	userData uint64
+	res      int32
+	flags    uint32
+}
+
+// ring is a minimal io_uring submission and completion queue pair.
+type ring struct {
+	fd     int
+	rings  []byte
+	cqMem  []byte
+	sqeMem []byte
+	params uringParams
+}
+
+func (r *ring) u32(mem []byte, offset uint32) *uint32 {
+	return (*uint32)(unsafe.Pointer(&mem[offset]))
+}
+
+func newRing(entries uint32) (*ring, error) {
+	r := &ring{}
+
+	fd, _, errno := unix.Syscall(unix.SYS_IO_URING_SETUP, uintptr(entries), uintptr(unsafe.Pointer(&r.params)), 0)
+	if errno != 0 {
+		return nil, fmt.Errorf("io_uring_setup: %w", errno)
+	}
+	r.fd = int(fd)
+
+	sqSize := r.params.sqOff.array + r.params.sqEntries*4
+	cqSize := r.params.cqOff.cqes + r.params.cqEntries*uringCqeSize
+	if r.params.features&uringFeatSingleMmap != 0 {
+		sqSize = max(sqSize, cqSize)
+	}
+
+	var err error
+	r.rings, err = unix.Mmap(r.fd, uringOffSqRing, int(sqSize), unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED|unix.MAP_POPULATE)
+	if err != nil {
+		r.close()
+		return nil, fmt.Errorf("mmap io_uring sq ring: %w", err)
+	}
+
+	r.cqMem = r.rings
+	if r.params.features&uringFeatSingleMmap == 0 {
+		r.cqMem, err = unix.Mmap(r.fd, uringOffCqRing, int(cqSize), unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED|unix.MAP_POPULATE)
+		if err != nil {
+			r.close()
+			return nil, fmt.Errorf("mmap io_uring cq ring: %w", err)
+		}
+	}
+
+	r.sqeMem, err = unix.Mmap(r.fd, uringOffSqes, int(r.params.sqEntries*uringSqeSize), unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED|unix.MAP_POPULATE)
+	if err != nil {
+		r.close()
+		return nil, fmt.Errorf("mmap io_uring sqes: %w", err)
+	}
+
+	return r, nil
+}
+
+func (r *ring) close() {
+	if r.sqeMem != nil {
+		_ = unix.Munmap(r.sqeMem)
+	}
+	if r.cqMem != nil && len(r.cqMem) > 0 && &r.cqMem[0] != &r.rings[0] {
+		_ = unix.Munmap(r.cqMem)
+	}
+	if r.rings != nil {
+		_ = unix.Munmap(r.rings)
+	}
+	_ = unix.Close(r.fd)
+}
+
+// push queues sqes, the caller makes sure they fit in the submission queue.
+func (r *ring) push(sqes []uringSqe) {
+	mask := *r.u32(r.rings, r.params.sqOff.ringMask)
+	tail := atomic.LoadUint32(r.u32(r.rings, r.params.sqOff.tail))
+
+	for _, sqe := range sqes {
+		idx := tail & mask
+		*(*uringSqe)(unsafe.Pointer(&r.sqeMem[idx*uringSqeSize])) = sqe
+		*r.u32(r.rings, r.params.sqOff.array+idx*4) = idx
+		tail++
+	}
+
+	atomic.StoreUint32(r.u32(r.rings, r.params.sqOff.tail), tail)
+}
+
+// submitAndWait submits count queued sqes and calls complete for each of their count completions.
+func (r *ring) submitAndWait(count uint32, complete func(uringCqe)) error {
+	submitted := uint32(0)
+	completed := uint32(0)
+
+	for completed < count {
+		toSubmit := count - submitted
+		n, _, errno := unix.Syscall6(unix.SYS_IO_URING_ENTER, uintptr(r.fd), uintptr(toSubmit), 1, uringEnterGetEvents, 0, 0)
+		if errno == unix.EINTR || errno == unix.EAGAIN || errno == unix.EBUSY {
+			continue
+		}
+		if errno != 0 {
+			return fmt.Errorf("io_uring_enter: %w", errno)
+		}
+		submitted += uint32(n)
+
+		mask := *r.u32(r.cqMem, r.params.cqOff.ringMask)
+		head := atomic.LoadUint32(r.u32(r.cqMem, r.params.cqOff.head))
+		tail := atomic.LoadUint32(r.u32(r.cqMem, r.params.cqOff.tail))
+
+		for ; head != tail; head++ {
+			cqe := *(*uringCqe)(unsafe.Pointer(&r.cqMem[r.params.cqOff.cqes+(head&mask)*uringCqeSize]))
+			complete(cqe)
+			completed++
+		}
+
+		atomic.StoreUint32(r.u32(r.cqMem, r.params.cqOff.head), head)
+	}
+
+	return nil
+}
+
+type uringFile struct {
+	path    string
+	tmpPath []byte
+	dest    []byte
+	content []byte
+	fd      int
+	closed  bool
+	renamed bool
+	err     error
+}
+
+// UringWriter writes regular files in bulk through io_uring: each file is opened and chmoded directly, then its
+// write, close and rename into place are queued and submitted with the other queued files in a single call.
+// Files are written atomically like the direct writes, through a temporary file renamed over the destination.
+type UringWriter struct {
+	ring    *ring
+	syncer  *Syncer
+	pending []*uringFile
+	queued  []uringSqe
+	counter uint64
+}
+
+// NewUringWriter sets up an io_uring for bulk file writes, it fails where io_uring is unavailable or forbidden,
+// as in containers whose seccomp profile blocks it, in which case files should be written directly.
+func NewUringWriter(syncer *Syncer) (*UringWriter, error) {
+	r, err := newRing(uringEntries)
+	if err != nil {
+		return nil, err
+	}
+	return &UringWriter{ring: r, syncer: syncer}, nil
+}
+
+// Close releases the ring, files still queued were never flushed and are discarded.
+func (w *UringWriter) Close() {
+	if w == nil {
+		return
+	}
+	w.Discard()
+	w.ring.close()
+}
+
+// Discard drops every queued file without submitting it: their temporary files are closed and removed
+// and nothing is renamed into place.
+func (w *UringWriter) Discard() {
+	if w == nil {
+		return
+	}
+
+	for _, file := range w.pending {
+		_ = unix.Close(file.fd)
+		_ = os.Remove(string(file.tmpPath[:len(file.tmpPath)-1]))
+	}
+	w.pending = nil
+	w.queued = nil
+}
+
+// Write queues the write of content to path, the file is only in place once Flush returns.
+func (w *UringWriter) Write(path string, mode os.FileMode, content []byte) error {
+	if len(w.queued)+uringMaxOpsPerFile > int(w.ring.params.sqEntries) {
+		err := w.Flush()
+		if err != nil {
+			return err
+		}
+	}
+
+	w.counter++
+	tmpPath := filepath.Join(filepath.Dir(path), partialFilePrefix+filepath.Base(path)+"-u"+strconv.FormatInt(time.Now().UnixNano(), 36)+strconv.FormatUint(w.counter, 36))
+
+	fd, err := unix.Open(tmpPath, unix.O_WRONLY|unix.O_CREAT|unix.O_EXCL|unix.O_CLOEXEC, uint32(mode.Perm()))
+	if err != nil {
+		return fmt.Errorf("create temporary file for %v: %w", path, err)
+	}
+
+	err = unix.Fchmod(fd, uint32(mode.Perm()))
+	if err != nil {
+		_ = unix.Close(fd)
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("chmod %v on disk: %w", path, err)
+	}
+
+	file := &uringFile{
+		path:    path,
+		tmpPath: append([]byte(tmpPath), 0),
+		dest:    append([]byte(path), 0),
+		content: content,
+		fd:      fd,
+	}
+	idx := uint64(len(w.pending))
+	w.pending = append(w.pending, file)
+
+	userData := func(op uint64) uint64 {
+		return idx<<uringUserDataOpOffset | op
+	}
+
+	atFdcwd := int32(unix.AT_FDCWD)
+	write := uringSqe{opcode: uringOpWrite, flags: uringSqeIOLink, fd: int32(fd), len: uint32(len(content)), userData: userData(0)}
+	if len(content) > 0 {
+		write.addr = uint64(uintptr(unsafe.Pointer(&content[0])))
+	}
+	w.queued = append(w.queued, write)
+
+	if w.syncer != nil && w.syncer.durability == DurabilityFull {
+		w.queued = append(w.queued, uringSqe{opcode: uringOpFsync, flags: uringSqeIOLink, fd: int32(fd), userData: userData(1)})
+	}
+
+	w.queued = append(w.queued,
+		uringSqe{opcode: uringOpClose, flags: uringSqeIOLink, fd: int32(fd), userData: userData(2)},
+		uringSqe{
+			opcode:   uringOpRenameat,
+			fd:       unix.AT_FDCWD,
+			addr:     uint64(uintptr(unsafe.Pointer(&file.tmpPath[0]))),
+			len:      uint32(atFdcwd),
+			off:      uint64(uintptr(unsafe.Pointer(&file.dest[0]))),
+			userData: userData(3),
+		},
+	)
+
+	return nil
+}
+
+// Flush submits every queued file and waits for them to be in place, returning the first error met.
+func (w *UringWriter) Flush() error {
+	if w == nil || len(w.queued) == 0 {
+		return nil
+	}
+
+	pending := w.pending
+	w.ring.push(w.queued)
+	count := uint32(len(w.queued))
+	w.pending = nil
+	w.queued = nil
+
+	err := w.ring.submitAndWait(count, func(cqe uringCqe) {
+		file := pending[cqe.userData>>uringUserDataOpOffset]
+		op := cqe.userData & (1<<uringUserDataOpOffset - 1)
+
+		switch {
+		case op == 2 && cqe.res >= 0:
+			file.closed = true
+		case op == 3 && cqe.res >= 0:
+			file.renamed = true
+		case op == 3:
+			// something other than a file is in the way, the rename is retried below like writeFileAtomic does
+			return
+		}
+
+		if cqe.res < 0 && file.err == nil && syscall.Errno(-cqe.res) != unix.ECANCELED {
+			file.err = fmt.Errorf("write %v to disk: %w", file.path, syscall.Errno(-cqe.res))
+		}
+		if op == 0 && cqe.res >= 0 && int(cqe.res) != len(file.content) && file.err == nil {
+			file.err = fmt.Errorf("write %v to disk: short write of %d out of %d bytes", file.path, cqe.res, len(file.content))
+		}
+	})
+
+	var errs []error
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	for _, file := range pending {
+		if !file.closed {
+			_ = unix.Close(file.fd)
+		}
+		tmpPath := string(file.tmpPath[:len(file.tmpPath)-1])
+
+		if file.err == nil && err == nil && file.closed && !file.renamed {
+			_, file.err = retryFileErrors(file.path, func() (interface{}, error) {
+				return nil, os.Rename(tmpPath, file.path)
+			})
+			if file.err != nil {
+				file.err = fmt.Errorf("rename %v to %v: %w", tmpPath, file.path, file.err)
+			}
+		}
+
+		if file.err != nil || err != nil {
+			_ = os.Remove(tmpPath)
+			if file.err != nil {
+				errs = append(errs, file.err)
+			}
+			continue
+		}
+
+		err := w.syncer.fileWritten()
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package files
 
 import (
 	"archive/tar"
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charlievieth/fastwalk"
 	"github.com/gadget-inc/dateilager/internal/db"
@@ -60,6 +62,64 @@ func (f *FileMatcher) Match(filename string) bool {
 	return result
 }
 
+// SparseProfile limits which paths of a project are materialized in a directory, similar to git sparse-checkout.
+// Patterns are globs, patterns starting with ! exclude paths and lines starting with # are comments.
+type SparseProfile struct {
+	includes []glob.Glob
+	excludes []glob.Glob
+}
+
+func NewSparseProfile(patterns []string) (*SparseProfile, error) {
+	profile := SparseProfile{}
+
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		exclude := strings.HasPrefix(pattern, "!")
+		pattern = strings.TrimPrefix(pattern, "!")
+
+		compiled, err := glob.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing sparse pattern %v: %w", pattern, err)
+		}
+
+		if exclude {
+			profile.excludes = append(profile.excludes, compiled)
+		} else {
+			profile.includes = append(profile.includes, compiled)
+		}
+	}
+
+	return &profile, nil
+}
+
+func (s *SparseProfile) Match(path string) bool {
+	if s == nil {
+		return true
+	}
+
+	result := len(s.includes) == 0
+	for _, include := range s.includes {
+		if include.Match(path) {
+			result = true
+			break
+		}
+	}
+
+	if result {
+		for _, exclude := range s.excludes {
+			if exclude.Match(path) {
+				return false
+			}
+		}
+	}
+
+	return result
+}
+
 func fileExists(path string) bool {
 	_, err := os.Lstat(path)
 	return err == nil
@@ -81,10 +141,74 @@ func retryFileErrors[R any](path string, fn retryableFileOperation[R]) (R, error
 	return result, err
 }
 
-func writeObject(rootDir string, cacheObjectsDir string, reader *db.TarReader, header *tar.Header, existingDirs map[string]bool) error {
+// partialFilePrefix marks the temporary files content is written to before being renamed over its destination.
+const partialFilePrefix = ".dl-partial-"
+
+// writeFileAtomic writes a file through a temporary file in the same directory which is renamed over path once complete,
+// so an interrupted write never leaves a truncated file at path. The temporary file is removed when write fails.
+// It is flushed before the rename when syncer asks for it.
+func writeFileAtomic(path string, mode fs.FileMode, syncer *Syncer, write func(*os.File) error) error {
+	file, err := os.CreateTemp(filepath.Dir(path), partialFilePrefix+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("create temporary file for %v: %w", path, err)
+	}
+	tmpPath := file.Name()
+
+	err = write(file)
+	if err == nil {
+		err = file.Chmod(mode)
+		if err != nil {
+			err = fmt.Errorf("chmod %v on disk: %w", path, err)
+		}
+	}
+
+	if err == nil {
+		err = syncer.syncFile(file)
+		if err != nil {
+			err = fmt.Errorf("sync %v: %w", path, err)
+		}
+	}
+
+	closeErr := file.Close()
+	if err == nil && closeErr != nil {
+		err = fmt.Errorf("close %v: %w", path, closeErr)
+	}
+
+	if err == nil {
+		_, err = retryFileErrors(path, func() (interface{}, error) {
+			return nil, os.Rename(tmpPath, path)
+		})
+		if err != nil {
+			err = fmt.Errorf("rename %v to %v: %w", tmpPath, path, err)
+		}
+	}
+
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return nil
+}
+
+func writeObject(rootDir string, cacheObjectsDir string, objectCache *ObjectCache, reader *db.TarReader, header *tar.Header, existingDirs map[string]bool, syncer *Syncer) error {
 	path := filepath.Join(rootDir, header.Name)
 
 	switch header.Typeflag {
+	case pb.TarKnown:
+		if objectCache == nil {
+			return fmt.Errorf("received cached object %v without an object cache", header.Name)
+		}
+		hash, err := reader.ReadContent()
+		if err != nil {
+			return err
+		}
+		err = objectCache.CopyTo(hash, path, os.FileMode(header.Mode), syncer)
+		if err != nil {
+			return err
+		}
+		return syncer.fileWritten()
+
 	case pb.TarCached:
 		content, err := reader.ReadContent()
 		if err != nil {
@@ -95,11 +219,9 @@ func writeObject(rootDir string, cacheObjectsDir string, reader *db.TarReader, h
 
 	case tar.TypeReg:
 		dir := filepath.Dir(path)
-		createdDir := false
 
 		if _, exists := existingDirs[dir]; !exists {
 			_, err := retryFileErrors(dir, func() (interface{}, error) {
-				createdDir = true
 				return nil, os.MkdirAll(dir, 0777)
 			})
 			if err != nil {
@@ -108,38 +230,34 @@ func writeObject(rootDir string, cacheObjectsDir string, reader *db.TarReader, h
 			existingDirs[dir] = true
 		}
 
-		file, err := retryFileErrors(path, func() (*os.File, error) {
-			return os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, os.FileMode(header.Mode))
-		})
-		if err != nil {
-			return fmt.Errorf("open file %v: %w", path, err)
-		}
-
-		err = PreAllocate(file, header.Size)
-		if err != nil {
-			return fmt.Errorf("failed to pre allocate %v: %w", path, err)
-		}
-		err = reader.CopyContent(file)
-		if err != nil {
-			return fmt.Errorf("write %v to disk: %w", path, err)
-		}
-
-		// If we created the dir while writing this file, we know it is a new file with the correct mode
-		if !createdDir {
-			info, err := file.Stat()
+		err := writeFileAtomic(path, os.FileMode(header.Mode), syncer, func(file *os.File) error {
+			err := PreAllocate(file, header.Size)
 			if err != nil {
-				return fmt.Errorf("stat %v: %w", path, err)
+				return fmt.Errorf("failed to pre allocate %v: %w", path, err)
 			}
 
-			if info.Mode() != os.FileMode(header.Mode) {
-				err = file.Chmod(os.FileMode(header.Mode))
+			if objectCache != nil {
+				content, err := reader.ReadContent()
 				if err != nil {
-					return fmt.Errorf("chmod %v on disk: %w", path, err)
+					return fmt.Errorf("read %v content: %w", path, err)
 				}
+				_, err = file.Write(content)
+				if err != nil {
+					return fmt.Errorf("write %v to disk: %w", path, err)
+				}
+				return objectCache.Store(content)
 			}
-		}
 
-		file.Close()
+			err = reader.CopyContent(file)
+			if err != nil {
+				return fmt.Errorf("write %v to disk: %w", path, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return syncer.fileWritten()
 
 	case tar.TypeDir:
 		if _, exists := existingDirs[path]; !exists {
@@ -152,6 +270,21 @@ func writeObject(rootDir string, cacheObjectsDir string, reader *db.TarReader, h
 			existingDirs[path] = true
 		}
 
+		perm := fs.FileMode(header.Mode).Perm()
+		if perm != 0 {
+			info, err := os.Stat(path)
+			if err != nil {
+				return fmt.Errorf("stat %v: %w", path, err)
+			}
+
+			if info.Mode().Perm() != perm {
+				err = os.Chmod(path, perm)
+				if err != nil {
+					return fmt.Errorf("chmod %v on disk: %w", path, err)
+				}
+			}
+		}
+
 	case tar.TypeSymlink:
 		return makeSymlink(header.Linkname, path)
 
@@ -175,6 +308,69 @@ func writeObject(rootDir string, cacheObjectsDir string, reader *db.TarReader, h
 	return nil
 }
 
+// queueObject queues the write of a regular file to uring, its parent directory is created directly.
+func queueObject(rootDir string, objectCache *ObjectCache, reader *db.TarReader, header *tar.Header, existingDirs map[string]bool, uring *UringWriter) error {
+	path := filepath.Join(rootDir, header.Name)
+	dir := filepath.Dir(path)
+
+	if _, exists := existingDirs[dir]; !exists {
+		_, err := retryFileErrors(dir, func() (interface{}, error) {
+			return nil, os.MkdirAll(dir, 0777)
+		})
+		if err != nil {
+			return fmt.Errorf("mkdir -p %v: %w", dir, err)
+		}
+		existingDirs[dir] = true
+	}
+
+	content, err := reader.ReadContent()
+	if err != nil {
+		return fmt.Errorf("read %v content: %w", path, err)
+	}
+
+	if objectCache != nil {
+		err = objectCache.Store(content)
+		if err != nil {
+			return err
+		}
+	}
+
+	return uring.Write(path, os.FileMode(header.Mode), content)
+}
+
+// WriteStub creates a sparse placeholder with the mode and size of a file whose content has not been fetched yet.
+func WriteStub(rootDir string, name string, mode fs.FileMode, size int64) error {
+	path := filepath.Join(rootDir, name)
+	dir := filepath.Dir(path)
+
+	_, err := retryFileErrors(dir, func() (interface{}, error) {
+		return nil, os.MkdirAll(dir, 0777)
+	})
+	if err != nil {
+		return fmt.Errorf("mkdir -p %v: %w", dir, err)
+	}
+
+	file, err := retryFileErrors(path, func() (*os.File, error) {
+		return os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_TRUNC, mode.Perm())
+	})
+	if err != nil {
+		return fmt.Errorf("open stub %v: %w", path, err)
+	}
+	defer file.Close()
+
+	err = file.Truncate(size)
+	if err != nil {
+		return fmt.Errorf("truncate stub %v: %w", path, err)
+	}
+
+	err = file.Chmod(mode.Perm())
+	if err != nil {
+		return fmt.Errorf("chmod stub %v: %w", path, err)
+	}
+
+	return nil
+}
+
 func makeSymlink(oldname, newname string) error {
 	dir := filepath.Dir(newname)
 	_, err := retryFileErrors(dir, func() (interface{}, error) {
@@ -249,7 +445,124 @@ func HardlinkDir(olddir, newdir string) error {
 	})
 }
 
-func WriteTar(finalDir string, cacheObjectsDir string, reader *db.TarReader, packPath *string, matcher *FileMatcher) (uint32, bool, error) {
+// CopyDir copies the tree of olddir into newdir, merging with what newdir already holds. Unlike HardlinkDir the copies
+// share nothing with olddir, so they can be written to without altering it. Modes and modification times are kept.
+func CopyDir(olddir, newdir string) error {
+	rootInfo, err := os.Lstat(olddir)
+	if err != nil {
+		return fmt.Errorf("cannot stat olddir %v: %w", olddir, err)
+	}
+
+	err = os.MkdirAll(newdir, rootInfo.Mode().Perm())
+	if err != nil {
+		return fmt.Errorf("cannot create new root dir %v: %w", newdir, err)
+	}
+
+	fastwalkConf := fastwalk.DefaultConfig.Copy()
+	fastwalkConf.Sort = fastwalk.SortDirsFirst
+
+	return fastwalk.Walk(fastwalkConf, olddir, func(oldpath string, d os.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to walk dir: %v, %w", oldpath, err)
+		}
+
+		newpath := filepath.Join(newdir, strings.TrimPrefix(oldpath, olddir))
+		if newpath == newdir {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("unable to get file info %v: %w", oldpath, err)
+		}
+
+		switch {
+		case d.IsDir():
+			err = os.MkdirAll(newpath, info.Mode().Perm())
+			if err != nil {
+				return fmt.Errorf("cannot create dir %v: %w", newpath, err)
+			}
+			return nil
+
+		case d.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(oldpath)
+			if err != nil {
+				return fmt.Errorf("cannot read link %v: %w", oldpath, err)
+			}
+			return makeSymlink(target, newpath)
+
+		default:
+			err = writeFileAtomic(newpath, info.Mode().Perm(), nil, func(file *os.File) error {
+				source, err := os.Open(oldpath)
+				if err != nil {
+					return err
+				}
+				defer source.Close()
+
+				_, err = io.Copy(file, source)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+
+			// keep the modification time so summaries written alongside the tree still match it
+			return os.Chtimes(newpath, info.ModTime(), info.ModTime())
+		}
+	})
+}
+
+// restoreMtime sets the modification time of a regular file to the one recorded in its header.
+// Cached objects are hardlinks shared with other projects and are left alone.
+func restoreMtime(rootDir string, header *tar.Header) error {
+	if header.Typeflag != tar.TypeReg && header.Typeflag != pb.TarKnown {
+		return nil
+	}
+
+	mtime := pb.MtimeFromTarHeader(header)
+	if mtime == nil {
+		return nil
+	}
+
+	path := filepath.Join(rootDir, header.Name)
+	modTime := time.Unix(0, *mtime)
+	err := os.Chtimes(path, modTime, modTime)
+	if err != nil {
+		return fmt.Errorf("chtimes %v on disk: %w", path, err)
+	}
+
+	return nil
+}
+
+// WriteTarOptions configure WriteTar, the zero value writes every object of the TAR and leaves flushing to the OS.
+type WriteTarOptions struct {
+	// ObjectCache holds the contents of cached objects, before falling back to the cache objects dir
+	ObjectCache *ObjectCache
+	// Matcher reports whether every written file matched it
+	Matcher *FileMatcher
+	// Sparse skips the objects outside of a sparse checkout profile
+	Sparse *SparseProfile
+	// RestoreMtimes sets the mtimes stored in the TAR on the written files
+	RestoreMtimes bool
+	// Syncer flushes the written files, its Finish must be called once every TAR of a checkout is written
+	Syncer *Syncer
+	// Uring writes consecutive regular files in bulk, they are all in place once WriteTar returns without error and none
+	// of those still queued are when it fails
+	Uring *UringWriter
+}
+
+// WriteTar writes the objects of a TAR into finalDir and returns how many were written.
+// Regular files are replaced atomically and writing stops between objects once ctx is cancelled,
+// so every file of finalDir holds either its previous or its new content.
+func WriteTar(ctx context.Context, finalDir string, cacheObjectsDir string, reader *db.TarReader, packPath *string, options WriteTarOptions) (uint32, bool, error) {
+	count, fileMatch, err := writeTar(ctx, finalDir, cacheObjectsDir, reader, packPath, options)
+	if err != nil {
+		options.Uring.Discard()
+	}
+	return count, fileMatch, err
+}
+
+func writeTar(ctx context.Context, finalDir string, cacheObjectsDir string, reader *db.TarReader, packPath *string, options WriteTarOptions) (uint32, bool, error) {
 	var count uint32
 	dir := finalDir
 
@@ -267,6 +580,11 @@ func WriteTar(finalDir string, cacheObjectsDir string, reader *db.TarReader, pac
 	}
 
 	for {
+		err := ctx.Err()
+		if err != nil {
+			return count, false, err
+		}
+
 		header, err := reader.Next()
 		if err == io.EOF {
 			break
@@ -275,18 +593,49 @@ func WriteTar(finalDir string, cacheObjectsDir string, reader *db.TarReader, pac
 			return count, false, fmt.Errorf("read next TAR header: %w", err)
 		}
 
-		if matcher != nil && !matcher.Match(header.Name) {
+		if !options.Sparse.Match(header.Name) {
+			continue
+		}
+
+		if options.Matcher != nil && !options.Matcher.Match(header.Name) {
 			fileMatch = false
 		}
 
-		err = writeObject(dir, cacheObjectsDir, reader, header, existingDirs)
+		if options.Uring != nil && !options.RestoreMtimes && header.Typeflag == tar.TypeReg {
+			err = queueObject(dir, options.ObjectCache, reader, header, existingDirs, options.Uring)
+			if err != nil {
+				return count, false, err
+			}
+			count += 1
+			continue
+		}
+
+		// queued files must be in place before anything else touches the tree
+		err = options.Uring.Flush()
 		if err != nil {
 			return count, false, err
 		}
 
+		err = writeObject(dir, cacheObjectsDir, options.ObjectCache, reader, header, existingDirs, options.Syncer)
+		if err != nil {
+			return count, false, err
+		}
+
+		if options.RestoreMtimes {
+			err = restoreMtime(dir, header)
+			if err != nil {
+				return count, false, err
+			}
+		}
+
 		count += 1
 	}
 
+	err := options.Uring.Flush()
+	if err != nil {
+		return count, false, err
+	}
+
 	if packPath != nil && dir != finalDir {
 		path := filepath.Join(finalDir, *packPath)
 		err := os.RemoveAll(path)
@@ -33,6 +33,7 @@ const (
 	Socket            = StringKey("dl.socket")
 	State             = StringKey("dl.state")
 	Template          = Int64pKey("dl.template")
+	TombstonesCount   = Int64Key("dl.tombstones_count")
 	ToVersion         = Int64pKey("dl.to_version")
 	TotalObjectsCount = Int64Key("dl.total_objects_count")
 	Version           = Int64Key("dl.version")
@@ -44,6 +45,33 @@ const (
 	CachePath         = StringKey("dl.cache_path")
 	VolumeID          = StringKey("dl.volume_id")
 	TargetPath        = StringKey("dl.target_path")
+	Targets           = Int64SliceKey("dl.targets")
+	SkippedCount      = IntKey("dl.skipped_count")
+	Transforms        = StringSliceKey("dl.transforms")
+	MaxContentSize    = Int64Key("dl.max_content_size")
+	OmittedCount      = IntKey("dl.omitted_count")
+	KnownHashesCount  = IntKey("dl.known_hashes_count")
+	Prefixes          = StringSliceKey("dl.prefixes")
+	IncludeProjects   = Int64SliceKey("dl.include_projects")
+	ExcludeProjects   = Int64SliceKey("dl.exclude_projects")
+	CacheCreated      = BoolKey("dl.cache_created")
+	PrunedCount       = Int64Key("dl.pruned_count")
+	Bytes             = Int64Key("dl.bytes")
+	IncludeInCache    = BoolKey("dl.include_in_cache")
+	Frozen            = BoolKey("dl.frozen")
+	Scanner           = StringKey("dl.scanner")
+	InfectedCount     = Int64Key("dl.infected_count")
+	FailedCount       = Int64Key("dl.failed_count")
+	Attempt           = IntKey("dl.attempt")
+	ContentHash       = StringKey("dl.content_hash")
+	Encoding          = StringKey("dl.encoding")
+	ReencodedCount    = Int64Key("dl.reencoded_count")
+	BaseVersion       = Int64pKey("dl.base_version")
+	Stage             = StringKey("dl.stage")
+	Archive           = Int64Key("dl.archive")
+	Location          = StringKey("dl.location")
+	FoldedCount       = Int64Key("dl.folded_count")
+	CountedCount      = Int64Key("dl.counted_count")
 )
 
 var (
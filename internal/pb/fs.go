@@ -7,11 +7,14 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
+	"unicode/utf8"
 )
 
 const (
 	TarDeleted = 'D'
 	TarCached  = 'C'
+	TarKnown   = 'O'
 )
 
 func TarTypeFromMode(mode fs.FileMode) byte {
@@ -26,6 +29,116 @@ func TarTypeFromMode(mode fs.FileMode) byte {
 	return tar.TypeReg
 }
 
+// IsDirPath reports whether a path names a directory, directories are the only objects whose path ends with a slash.
+func IsDirPath(path string) bool {
+	return strings.HasSuffix(path, "/")
+}
+
+const (
+	// MaxPathLength bounds the bytes of an object path, the PATH_MAX of Linux
+	MaxPathLength = 4096
+	// MaxSegmentLength bounds the bytes of a single path segment, the NAME_MAX of most filesystems
+	MaxSegmentLength = 255
+)
+
+const (
+	InvalidUTF8      = "INVALID_UTF8"
+	NulByte          = "NUL_BYTE"
+	PathTooLong      = "PATH_TOO_LONG"
+	SegmentTooLong   = "SEGMENT_TOO_LONG"
+	TraversalSegment = "TRAVERSAL_SEGMENT"
+)
+
+// PathError describes why a path cannot be stored, Reason is one of the reason constants above.
+type PathError struct {
+	Path        string
+	Reason      string
+	Description string
+}
+
+func (e *PathError) Error() string {
+	return fmt.Sprintf("invalid path %q: %v", e.Path, e.Description)
+}
+
+// ValidatePath rejects paths that cannot be checked out safely: invalid UTF-8, NUL bytes, paths or segments longer
+// than filesystems allow and `..` segments which would escape the checkout directory.
+func ValidatePath(path string) error {
+	switch {
+	case !utf8.ValidString(path):
+		return &PathError{Path: path, Reason: InvalidUTF8, Description: "not valid UTF-8"}
+	case strings.IndexByte(path, 0) != -1:
+		return &PathError{Path: path, Reason: NulByte, Description: "contains a NUL byte"}
+	case len(path) > MaxPathLength:
+		return &PathError{Path: path, Reason: PathTooLong, Description: fmt.Sprintf("%d bytes long, at most %d are allowed", len(path), MaxPathLength)}
+	}
+
+	for _, segment := range strings.Split(path, "/") {
+		if segment == ".." {
+			return &PathError{Path: path, Reason: TraversalSegment, Description: "contains a .. segment"}
+		}
+		if len(segment) > MaxSegmentLength {
+			return &PathError{Path: path, Reason: SegmentTooLong, Description: fmt.Sprintf("has a %d bytes long segment, at most %d are allowed", len(segment), MaxSegmentLength)}
+		}
+	}
+
+	return nil
+}
+
+// CanonicalPath spells a path the one way it is stored: without empty or `.` segments. A leading slash is kept as
+// clients store paths either relative or absolute, a trailing slash is kept as it marks directories.
+// `foo`, `./foo` and `foo//` all name the same file as `foo`, `/foo//bar` names the same file as `/foo/bar`.
+func CanonicalPath(path string) (string, error) {
+	segments := strings.Split(path, "/")
+	kept := segments[:0]
+	for _, segment := range segments {
+		if segment != "" && segment != "." {
+			kept = append(kept, segment)
+		}
+	}
+
+	if len(kept) == 0 {
+		return "", fmt.Errorf("invalid path %q: no segments", path)
+	}
+
+	canonical := strings.Join(kept, "/")
+	if strings.HasPrefix(path, "/") {
+		canonical = "/" + canonical
+	}
+	if IsDirPath(path) {
+		canonical += "/"
+	}
+	return canonical, nil
+}
+
+// NormalizeObject puts an object in its canonical form: the path is canonical, directories carry fs.ModeDir, a trailing
+// slash and no content. Any other object with a trailing slash is rejected. The type of deleted objects is unknown so
+// only their path is canonicalized.
+func NormalizeObject(object *Object) error {
+	path, err := CanonicalPath(object.Path)
+	if err != nil {
+		return err
+	}
+	object.Path = path
+
+	if object.Deleted {
+		return nil
+	}
+
+	mode := fs.FileMode(object.Mode)
+	switch {
+	case mode.IsDir():
+		if !IsDirPath(object.Path) {
+			object.Path += "/"
+		}
+		object.Size = 0
+		object.Content = nil
+	case IsDirPath(object.Path):
+		return fmt.Errorf("invalid object %v: only directories can have a trailing slash, got mode %v", object.Path, mode)
+	}
+
+	return nil
+}
+
 func ObjectFromFilePath(directory, path string) (*Object, error) {
 	fullPath := filepath.Join(directory, path)
 
@@ -53,6 +166,9 @@ func ObjectFromFilePath(directory, path string) (*Object, error) {
 		}
 	case tar.TypeDir:
 		content = []byte("")
+		if !IsDirPath(path) {
+			path += "/"
+		}
 	case tar.TypeSymlink:
 		target, err := os.Readlink(fullPath)
 		if err != nil {
@@ -64,16 +180,20 @@ func ObjectFromFilePath(directory, path string) (*Object, error) {
 		content = []byte(target)
 	}
 
+	mtime := info.ModTime().UnixNano()
+
 	return &Object{
 		Path:    path,
 		Mode:    int64(info.Mode()),
 		Size:    int64(len(content)),
 		Deleted: false,
 		Content: content,
+		Mtime:   &mtime,
 	}, nil
 }
 
 func ObjectFromTarHeader(header *tar.Header, content []byte) *Object {
+	path := header.Name
 	mode := header.Mode
 	size := header.Size
 
@@ -81,6 +201,9 @@ func ObjectFromTarHeader(header *tar.Header, content []byte) *Object {
 	case tar.TypeDir:
 		mode |= int64(fs.ModeDir)
 		size = 0
+		if !IsDirPath(path) {
+			path += "/"
+		}
 	case tar.TypeSymlink:
 		mode |= int64(fs.ModeSymlink)
 		content = []byte(header.Linkname)
@@ -88,10 +211,21 @@ func ObjectFromTarHeader(header *tar.Header, content []byte) *Object {
 	}
 
 	return &Object{
-		Path:    header.Name,
+		Path:    path,
 		Mode:    mode,
 		Size:    size,
 		Deleted: false,
 		Content: content,
+		Mtime:   MtimeFromTarHeader(header),
 	}
 }
+
+// MtimeFromTarHeader returns the modification time recorded in a header, tars written without one carry the unix epoch.
+func MtimeFromTarHeader(header *tar.Header) *int64 {
+	if header.ModTime.IsZero() || header.ModTime.Unix() <= 0 {
+		return nil
+	}
+
+	mtime := header.ModTime.UnixNano()
+	return &mtime
+}
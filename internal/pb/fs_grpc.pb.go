@@ -19,22 +19,68 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Fs_NewProject_FullMethodName       = "/pb.Fs/NewProject"
-	Fs_DeleteProject_FullMethodName    = "/pb.Fs/DeleteProject"
-	Fs_ListProjects_FullMethodName     = "/pb.Fs/ListProjects"
-	Fs_Get_FullMethodName              = "/pb.Fs/Get"
-	Fs_GetCompress_FullMethodName      = "/pb.Fs/GetCompress"
-	Fs_GetUnary_FullMethodName         = "/pb.Fs/GetUnary"
-	Fs_Update_FullMethodName           = "/pb.Fs/Update"
-	Fs_Rollback_FullMethodName         = "/pb.Fs/Rollback"
-	Fs_Inspect_FullMethodName          = "/pb.Fs/Inspect"
-	Fs_Snapshot_FullMethodName         = "/pb.Fs/Snapshot"
-	Fs_Reset_FullMethodName            = "/pb.Fs/Reset"
-	Fs_GcProject_FullMethodName        = "/pb.Fs/GcProject"
-	Fs_GcRandomProjects_FullMethodName = "/pb.Fs/GcRandomProjects"
-	Fs_GcContents_FullMethodName       = "/pb.Fs/GcContents"
-	Fs_CloneToProject_FullMethodName   = "/pb.Fs/CloneToProject"
-	Fs_GetCache_FullMethodName         = "/pb.Fs/GetCache"
+	Fs_NewProject_FullMethodName               = "/pb.Fs/NewProject"
+	Fs_DeleteProject_FullMethodName            = "/pb.Fs/DeleteProject"
+	Fs_ListProjects_FullMethodName             = "/pb.Fs/ListProjects"
+	Fs_SetProjectLabels_FullMethodName         = "/pb.Fs/SetProjectLabels"
+	Fs_GetProjectLabels_FullMethodName         = "/pb.Fs/GetProjectLabels"
+	Fs_SetProjectCacheInclusion_FullMethodName = "/pb.Fs/SetProjectCacheInclusion"
+	Fs_SetProjectPolicy_FullMethodName         = "/pb.Fs/SetProjectPolicy"
+	Fs_GetProjectPolicy_FullMethodName         = "/pb.Fs/GetProjectPolicy"
+	Fs_SetProjectFrozen_FullMethodName         = "/pb.Fs/SetProjectFrozen"
+	Fs_SetProjectTransforms_FullMethodName     = "/pb.Fs/SetProjectTransforms"
+	Fs_Get_FullMethodName                      = "/pb.Fs/Get"
+	Fs_GetCompress_FullMethodName              = "/pb.Fs/GetCompress"
+	Fs_GetUnary_FullMethodName                 = "/pb.Fs/GetUnary"
+	Fs_Update_FullMethodName                   = "/pb.Fs/Update"
+	Fs_PutObject_FullMethodName                = "/pb.Fs/PutObject"
+	Fs_DeleteObject_FullMethodName             = "/pb.Fs/DeleteObject"
+	Fs_Stage_FullMethodName                    = "/pb.Fs/Stage"
+	Fs_ListStaged_FullMethodName               = "/pb.Fs/ListStaged"
+	Fs_CommitStage_FullMethodName              = "/pb.Fs/CommitStage"
+	Fs_DiscardStage_FullMethodName             = "/pb.Fs/DiscardStage"
+	Fs_Rollback_FullMethodName                 = "/pb.Fs/Rollback"
+	Fs_RestorePaths_FullMethodName             = "/pb.Fs/RestorePaths"
+	Fs_History_FullMethodName                  = "/pb.Fs/History"
+	Fs_Inspect_FullMethodName                  = "/pb.Fs/Inspect"
+	Fs_Snapshot_FullMethodName                 = "/pb.Fs/Snapshot"
+	Fs_Reset_FullMethodName                    = "/pb.Fs/Reset"
+	Fs_GcProject_FullMethodName                = "/pb.Fs/GcProject"
+	Fs_GcRandomProjects_FullMethodName         = "/pb.Fs/GcRandomProjects"
+	Fs_SquashHistory_FullMethodName            = "/pb.Fs/SquashHistory"
+	Fs_ListTombstones_FullMethodName           = "/pb.Fs/ListTombstones"
+	Fs_PurgeDeleted_FullMethodName             = "/pb.Fs/PurgeDeleted"
+	Fs_GcContents_FullMethodName               = "/pb.Fs/GcContents"
+	Fs_CheckContentRefs_FullMethodName         = "/pb.Fs/CheckContentRefs"
+	Fs_AuditContents_FullMethodName            = "/pb.Fs/AuditContents"
+	Fs_CanonicalizePacks_FullMethodName        = "/pb.Fs/CanonicalizePacks"
+	Fs_IndexPacks_FullMethodName               = "/pb.Fs/IndexPacks"
+	Fs_InlineContents_FullMethodName           = "/pb.Fs/InlineContents"
+	Fs_RepairPaths_FullMethodName              = "/pb.Fs/RepairPaths"
+	Fs_ValidatePaths_FullMethodName            = "/pb.Fs/ValidatePaths"
+	Fs_CloneToProject_FullMethodName           = "/pb.Fs/CloneToProject"
+	Fs_CreateCache_FullMethodName              = "/pb.Fs/CreateCache"
+	Fs_ListCacheVersions_FullMethodName        = "/pb.Fs/ListCacheVersions"
+	Fs_DeleteCacheVersion_FullMethodName       = "/pb.Fs/DeleteCacheVersion"
+	Fs_GetCacheVersionProjects_FullMethodName  = "/pb.Fs/GetCacheVersionProjects"
+	Fs_GetCacheVersion_FullMethodName          = "/pb.Fs/GetCacheVersion"
+	Fs_GetCache_FullMethodName                 = "/pb.Fs/GetCache"
+	Fs_FanOutUpdate_FullMethodName             = "/pb.Fs/FanOutUpdate"
+	Fs_Diff_FullMethodName                     = "/pb.Fs/Diff"
+	Fs_StatPaths_FullMethodName                = "/pb.Fs/StatPaths"
+	Fs_ListPaths_FullMethodName                = "/pb.Fs/ListPaths"
+	Fs_DirectorySizes_FullMethodName           = "/pb.Fs/DirectorySizes"
+	Fs_CaptureHeapProfile_FullMethodName       = "/pb.Fs/CaptureHeapProfile"
+	Fs_ContentStats_FullMethodName             = "/pb.Fs/ContentStats"
+	Fs_FindContent_FullMethodName              = "/pb.Fs/FindContent"
+	Fs_ContentEncodingStatus_FullMethodName    = "/pb.Fs/ContentEncodingStatus"
+	Fs_CheckUpdate_FullMethodName              = "/pb.Fs/CheckUpdate"
+	Fs_SampleObjects_FullMethodName            = "/pb.Fs/SampleObjects"
+	Fs_ScanStatus_FullMethodName               = "/pb.Fs/ScanStatus"
+	Fs_ReadSnapshot_FullMethodName             = "/pb.Fs/ReadSnapshot"
+	Fs_ProvisionProject_FullMethodName         = "/pb.Fs/ProvisionProject"
+	Fs_ListArchives_FullMethodName             = "/pb.Fs/ListArchives"
+	Fs_RestoreArchive_FullMethodName           = "/pb.Fs/RestoreArchive"
 )
 
 // FsClient is the client API for Fs service.
@@ -44,19 +90,65 @@ type FsClient interface {
 	NewProject(ctx context.Context, in *NewProjectRequest, opts ...grpc.CallOption) (*NewProjectResponse, error)
 	DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*DeleteProjectResponse, error)
 	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
+	SetProjectLabels(ctx context.Context, in *SetProjectLabelsRequest, opts ...grpc.CallOption) (*SetProjectLabelsResponse, error)
+	GetProjectLabels(ctx context.Context, in *GetProjectLabelsRequest, opts ...grpc.CallOption) (*GetProjectLabelsResponse, error)
+	SetProjectCacheInclusion(ctx context.Context, in *SetProjectCacheInclusionRequest, opts ...grpc.CallOption) (*SetProjectCacheInclusionResponse, error)
+	SetProjectPolicy(ctx context.Context, in *SetProjectPolicyRequest, opts ...grpc.CallOption) (*SetProjectPolicyResponse, error)
+	GetProjectPolicy(ctx context.Context, in *GetProjectPolicyRequest, opts ...grpc.CallOption) (*GetProjectPolicyResponse, error)
+	SetProjectFrozen(ctx context.Context, in *SetProjectFrozenRequest, opts ...grpc.CallOption) (*SetProjectFrozenResponse, error)
+	SetProjectTransforms(ctx context.Context, in *SetProjectTransformsRequest, opts ...grpc.CallOption) (*SetProjectTransformsResponse, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (Fs_GetClient, error)
 	GetCompress(ctx context.Context, in *GetCompressRequest, opts ...grpc.CallOption) (Fs_GetCompressClient, error)
 	GetUnary(ctx context.Context, in *GetUnaryRequest, opts ...grpc.CallOption) (*GetUnaryResponse, error)
 	Update(ctx context.Context, opts ...grpc.CallOption) (Fs_UpdateClient, error)
+	PutObject(ctx context.Context, in *PutObjectRequest, opts ...grpc.CallOption) (*PutObjectResponse, error)
+	DeleteObject(ctx context.Context, in *DeleteObjectRequest, opts ...grpc.CallOption) (*DeleteObjectResponse, error)
+	Stage(ctx context.Context, opts ...grpc.CallOption) (Fs_StageClient, error)
+	ListStaged(ctx context.Context, in *ListStagedRequest, opts ...grpc.CallOption) (*ListStagedResponse, error)
+	CommitStage(ctx context.Context, in *CommitStageRequest, opts ...grpc.CallOption) (*CommitStageResponse, error)
+	DiscardStage(ctx context.Context, in *DiscardStageRequest, opts ...grpc.CallOption) (*DiscardStageResponse, error)
 	Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*RollbackResponse, error)
+	RestorePaths(ctx context.Context, in *RestorePathsRequest, opts ...grpc.CallOption) (*RestorePathsResponse, error)
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
 	Inspect(ctx context.Context, in *InspectRequest, opts ...grpc.CallOption) (*InspectResponse, error)
 	Snapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*SnapshotResponse, error)
 	Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*ResetResponse, error)
 	GcProject(ctx context.Context, in *GcProjectRequest, opts ...grpc.CallOption) (*GcProjectResponse, error)
 	GcRandomProjects(ctx context.Context, in *GcRandomProjectsRequest, opts ...grpc.CallOption) (*GcRandomProjectsResponse, error)
+	SquashHistory(ctx context.Context, in *SquashHistoryRequest, opts ...grpc.CallOption) (*SquashHistoryResponse, error)
+	ListTombstones(ctx context.Context, in *ListTombstonesRequest, opts ...grpc.CallOption) (*ListTombstonesResponse, error)
+	PurgeDeleted(ctx context.Context, in *PurgeDeletedRequest, opts ...grpc.CallOption) (*PurgeDeletedResponse, error)
 	GcContents(ctx context.Context, in *GcContentsRequest, opts ...grpc.CallOption) (*GcContentsResponse, error)
+	CheckContentRefs(ctx context.Context, in *CheckContentRefsRequest, opts ...grpc.CallOption) (*CheckContentRefsResponse, error)
+	AuditContents(ctx context.Context, in *AuditContentsRequest, opts ...grpc.CallOption) (*AuditContentsResponse, error)
+	CanonicalizePacks(ctx context.Context, in *CanonicalizePacksRequest, opts ...grpc.CallOption) (*CanonicalizePacksResponse, error)
+	IndexPacks(ctx context.Context, in *IndexPacksRequest, opts ...grpc.CallOption) (*IndexPacksResponse, error)
+	InlineContents(ctx context.Context, in *InlineContentsRequest, opts ...grpc.CallOption) (*InlineContentsResponse, error)
+	RepairPaths(ctx context.Context, in *RepairPathsRequest, opts ...grpc.CallOption) (*RepairPathsResponse, error)
+	ValidatePaths(ctx context.Context, in *ValidatePathsRequest, opts ...grpc.CallOption) (*ValidatePathsResponse, error)
 	CloneToProject(ctx context.Context, in *CloneToProjectRequest, opts ...grpc.CallOption) (*CloneToProjectResponse, error)
+	CreateCache(ctx context.Context, in *CreateCacheRequest, opts ...grpc.CallOption) (*CreateCacheResponse, error)
+	ListCacheVersions(ctx context.Context, in *ListCacheVersionsRequest, opts ...grpc.CallOption) (*ListCacheVersionsResponse, error)
+	DeleteCacheVersion(ctx context.Context, in *DeleteCacheVersionRequest, opts ...grpc.CallOption) (*DeleteCacheVersionResponse, error)
+	GetCacheVersionProjects(ctx context.Context, in *GetCacheVersionProjectsRequest, opts ...grpc.CallOption) (*GetCacheVersionProjectsResponse, error)
+	GetCacheVersion(ctx context.Context, in *GetCacheVersionRequest, opts ...grpc.CallOption) (*GetCacheVersionResponse, error)
 	GetCache(ctx context.Context, in *GetCacheRequest, opts ...grpc.CallOption) (Fs_GetCacheClient, error)
+	FanOutUpdate(ctx context.Context, in *FanOutUpdateRequest, opts ...grpc.CallOption) (Fs_FanOutUpdateClient, error)
+	Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (Fs_DiffClient, error)
+	StatPaths(ctx context.Context, in *StatPathsRequest, opts ...grpc.CallOption) (*StatPathsResponse, error)
+	ListPaths(ctx context.Context, in *ListPathsRequest, opts ...grpc.CallOption) (*ListPathsResponse, error)
+	DirectorySizes(ctx context.Context, in *DirectorySizesRequest, opts ...grpc.CallOption) (*DirectorySizesResponse, error)
+	CaptureHeapProfile(ctx context.Context, in *CaptureHeapProfileRequest, opts ...grpc.CallOption) (*CaptureHeapProfileResponse, error)
+	ContentStats(ctx context.Context, in *ContentStatsRequest, opts ...grpc.CallOption) (*ContentStatsResponse, error)
+	FindContent(ctx context.Context, in *FindContentRequest, opts ...grpc.CallOption) (*FindContentResponse, error)
+	ContentEncodingStatus(ctx context.Context, in *ContentEncodingStatusRequest, opts ...grpc.CallOption) (*ContentEncodingStatusResponse, error)
+	CheckUpdate(ctx context.Context, in *CheckUpdateRequest, opts ...grpc.CallOption) (*CheckUpdateResponse, error)
+	SampleObjects(ctx context.Context, in *SampleObjectsRequest, opts ...grpc.CallOption) (Fs_SampleObjectsClient, error)
+	ScanStatus(ctx context.Context, in *ScanStatusRequest, opts ...grpc.CallOption) (*ScanStatusResponse, error)
+	ReadSnapshot(ctx context.Context, in *ReadSnapshotRequest, opts ...grpc.CallOption) (*ReadSnapshotResponse, error)
+	ProvisionProject(ctx context.Context, in *ProvisionProjectRequest, opts ...grpc.CallOption) (*ProvisionProjectResponse, error)
+	ListArchives(ctx context.Context, in *ListArchivesRequest, opts ...grpc.CallOption) (*ListArchivesResponse, error)
+	RestoreArchive(ctx context.Context, in *RestoreArchiveRequest, opts ...grpc.CallOption) (*RestoreArchiveResponse, error)
 }
 
 type fsClient struct {
@@ -94,6 +186,69 @@ func (c *fsClient) ListProjects(ctx context.Context, in *ListProjectsRequest, op
 	return out, nil
 }
 
+func (c *fsClient) SetProjectLabels(ctx context.Context, in *SetProjectLabelsRequest, opts ...grpc.CallOption) (*SetProjectLabelsResponse, error) {
+	out := new(SetProjectLabelsResponse)
+	err := c.cc.Invoke(ctx, Fs_SetProjectLabels_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) GetProjectLabels(ctx context.Context, in *GetProjectLabelsRequest, opts ...grpc.CallOption) (*GetProjectLabelsResponse, error) {
+	out := new(GetProjectLabelsResponse)
+	err := c.cc.Invoke(ctx, Fs_GetProjectLabels_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) SetProjectCacheInclusion(ctx context.Context, in *SetProjectCacheInclusionRequest, opts ...grpc.CallOption) (*SetProjectCacheInclusionResponse, error) {
+	out := new(SetProjectCacheInclusionResponse)
+	err := c.cc.Invoke(ctx, Fs_SetProjectCacheInclusion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) SetProjectPolicy(ctx context.Context, in *SetProjectPolicyRequest, opts ...grpc.CallOption) (*SetProjectPolicyResponse, error) {
+	out := new(SetProjectPolicyResponse)
+	err := c.cc.Invoke(ctx, Fs_SetProjectPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) GetProjectPolicy(ctx context.Context, in *GetProjectPolicyRequest, opts ...grpc.CallOption) (*GetProjectPolicyResponse, error) {
+	out := new(GetProjectPolicyResponse)
+	err := c.cc.Invoke(ctx, Fs_GetProjectPolicy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) SetProjectFrozen(ctx context.Context, in *SetProjectFrozenRequest, opts ...grpc.CallOption) (*SetProjectFrozenResponse, error) {
+	out := new(SetProjectFrozenResponse)
+	err := c.cc.Invoke(ctx, Fs_SetProjectFrozen_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) SetProjectTransforms(ctx context.Context, in *SetProjectTransformsRequest, opts ...grpc.CallOption) (*SetProjectTransformsResponse, error) {
+	out := new(SetProjectTransformsResponse)
+	err := c.cc.Invoke(ctx, Fs_SetProjectTransforms_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fsClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (Fs_GetClient, error) {
 	stream, err := c.cc.NewStream(ctx, &Fs_ServiceDesc.Streams[0], Fs_Get_FullMethodName, opts...)
 	if err != nil {
@@ -201,6 +356,85 @@ func (x *fsUpdateClient) CloseAndRecv() (*UpdateResponse, error) {
 	return m, nil
 }
 
+func (c *fsClient) PutObject(ctx context.Context, in *PutObjectRequest, opts ...grpc.CallOption) (*PutObjectResponse, error) {
+	out := new(PutObjectResponse)
+	err := c.cc.Invoke(ctx, Fs_PutObject_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) DeleteObject(ctx context.Context, in *DeleteObjectRequest, opts ...grpc.CallOption) (*DeleteObjectResponse, error) {
+	out := new(DeleteObjectResponse)
+	err := c.cc.Invoke(ctx, Fs_DeleteObject_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) Stage(ctx context.Context, opts ...grpc.CallOption) (Fs_StageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Fs_ServiceDesc.Streams[3], Fs_Stage_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fsStageClient{stream}
+	return x, nil
+}
+
+type Fs_StageClient interface {
+	Send(*StageRequest) error
+	CloseAndRecv() (*StageResponse, error)
+	grpc.ClientStream
+}
+
+type fsStageClient struct {
+	grpc.ClientStream
+}
+
+func (x *fsStageClient) Send(m *StageRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *fsStageClient) CloseAndRecv() (*StageResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(StageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fsClient) ListStaged(ctx context.Context, in *ListStagedRequest, opts ...grpc.CallOption) (*ListStagedResponse, error) {
+	out := new(ListStagedResponse)
+	err := c.cc.Invoke(ctx, Fs_ListStaged_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) CommitStage(ctx context.Context, in *CommitStageRequest, opts ...grpc.CallOption) (*CommitStageResponse, error) {
+	out := new(CommitStageResponse)
+	err := c.cc.Invoke(ctx, Fs_CommitStage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) DiscardStage(ctx context.Context, in *DiscardStageRequest, opts ...grpc.CallOption) (*DiscardStageResponse, error) {
+	out := new(DiscardStageResponse)
+	err := c.cc.Invoke(ctx, Fs_DiscardStage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fsClient) Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*RollbackResponse, error) {
 	out := new(RollbackResponse)
 	err := c.cc.Invoke(ctx, Fs_Rollback_FullMethodName, in, out, opts...)
@@ -210,6 +444,24 @@ func (c *fsClient) Rollback(ctx context.Context, in *RollbackRequest, opts ...gr
 	return out, nil
 }
 
+func (c *fsClient) RestorePaths(ctx context.Context, in *RestorePathsRequest, opts ...grpc.CallOption) (*RestorePathsResponse, error) {
+	out := new(RestorePathsResponse)
+	err := c.cc.Invoke(ctx, Fs_RestorePaths_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
+	out := new(HistoryResponse)
+	err := c.cc.Invoke(ctx, Fs_History_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fsClient) Inspect(ctx context.Context, in *InspectRequest, opts ...grpc.CallOption) (*InspectResponse, error) {
 	out := new(InspectResponse)
 	err := c.cc.Invoke(ctx, Fs_Inspect_FullMethodName, in, out, opts...)
@@ -255,6 +507,33 @@ func (c *fsClient) GcRandomProjects(ctx context.Context, in *GcRandomProjectsReq
 	return out, nil
 }
 
+func (c *fsClient) SquashHistory(ctx context.Context, in *SquashHistoryRequest, opts ...grpc.CallOption) (*SquashHistoryResponse, error) {
+	out := new(SquashHistoryResponse)
+	err := c.cc.Invoke(ctx, Fs_SquashHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) ListTombstones(ctx context.Context, in *ListTombstonesRequest, opts ...grpc.CallOption) (*ListTombstonesResponse, error) {
+	out := new(ListTombstonesResponse)
+	err := c.cc.Invoke(ctx, Fs_ListTombstones_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) PurgeDeleted(ctx context.Context, in *PurgeDeletedRequest, opts ...grpc.CallOption) (*PurgeDeletedResponse, error) {
+	out := new(PurgeDeletedResponse)
+	err := c.cc.Invoke(ctx, Fs_PurgeDeleted_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fsClient) GcContents(ctx context.Context, in *GcContentsRequest, opts ...grpc.CallOption) (*GcContentsResponse, error) {
 	out := new(GcContentsResponse)
 	err := c.cc.Invoke(ctx, Fs_GcContents_FullMethodName, in, out, opts...)
@@ -264,6 +543,69 @@ func (c *fsClient) GcContents(ctx context.Context, in *GcContentsRequest, opts .
 	return out, nil
 }
 
+func (c *fsClient) CheckContentRefs(ctx context.Context, in *CheckContentRefsRequest, opts ...grpc.CallOption) (*CheckContentRefsResponse, error) {
+	out := new(CheckContentRefsResponse)
+	err := c.cc.Invoke(ctx, Fs_CheckContentRefs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) AuditContents(ctx context.Context, in *AuditContentsRequest, opts ...grpc.CallOption) (*AuditContentsResponse, error) {
+	out := new(AuditContentsResponse)
+	err := c.cc.Invoke(ctx, Fs_AuditContents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) CanonicalizePacks(ctx context.Context, in *CanonicalizePacksRequest, opts ...grpc.CallOption) (*CanonicalizePacksResponse, error) {
+	out := new(CanonicalizePacksResponse)
+	err := c.cc.Invoke(ctx, Fs_CanonicalizePacks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) IndexPacks(ctx context.Context, in *IndexPacksRequest, opts ...grpc.CallOption) (*IndexPacksResponse, error) {
+	out := new(IndexPacksResponse)
+	err := c.cc.Invoke(ctx, Fs_IndexPacks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) InlineContents(ctx context.Context, in *InlineContentsRequest, opts ...grpc.CallOption) (*InlineContentsResponse, error) {
+	out := new(InlineContentsResponse)
+	err := c.cc.Invoke(ctx, Fs_InlineContents_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) RepairPaths(ctx context.Context, in *RepairPathsRequest, opts ...grpc.CallOption) (*RepairPathsResponse, error) {
+	out := new(RepairPathsResponse)
+	err := c.cc.Invoke(ctx, Fs_RepairPaths_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) ValidatePaths(ctx context.Context, in *ValidatePathsRequest, opts ...grpc.CallOption) (*ValidatePathsResponse, error) {
+	out := new(ValidatePathsResponse)
+	err := c.cc.Invoke(ctx, Fs_ValidatePaths_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fsClient) CloneToProject(ctx context.Context, in *CloneToProjectRequest, opts ...grpc.CallOption) (*CloneToProjectResponse, error) {
 	out := new(CloneToProjectResponse)
 	err := c.cc.Invoke(ctx, Fs_CloneToProject_FullMethodName, in, out, opts...)
@@ -273,8 +615,53 @@ func (c *fsClient) CloneToProject(ctx context.Context, in *CloneToProjectRequest
 	return out, nil
 }
 
+func (c *fsClient) CreateCache(ctx context.Context, in *CreateCacheRequest, opts ...grpc.CallOption) (*CreateCacheResponse, error) {
+	out := new(CreateCacheResponse)
+	err := c.cc.Invoke(ctx, Fs_CreateCache_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) ListCacheVersions(ctx context.Context, in *ListCacheVersionsRequest, opts ...grpc.CallOption) (*ListCacheVersionsResponse, error) {
+	out := new(ListCacheVersionsResponse)
+	err := c.cc.Invoke(ctx, Fs_ListCacheVersions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) DeleteCacheVersion(ctx context.Context, in *DeleteCacheVersionRequest, opts ...grpc.CallOption) (*DeleteCacheVersionResponse, error) {
+	out := new(DeleteCacheVersionResponse)
+	err := c.cc.Invoke(ctx, Fs_DeleteCacheVersion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) GetCacheVersionProjects(ctx context.Context, in *GetCacheVersionProjectsRequest, opts ...grpc.CallOption) (*GetCacheVersionProjectsResponse, error) {
+	out := new(GetCacheVersionProjectsResponse)
+	err := c.cc.Invoke(ctx, Fs_GetCacheVersionProjects_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) GetCacheVersion(ctx context.Context, in *GetCacheVersionRequest, opts ...grpc.CallOption) (*GetCacheVersionResponse, error) {
+	out := new(GetCacheVersionResponse)
+	err := c.cc.Invoke(ctx, Fs_GetCacheVersion_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fsClient) GetCache(ctx context.Context, in *GetCacheRequest, opts ...grpc.CallOption) (Fs_GetCacheClient, error) {
-	stream, err := c.cc.NewStream(ctx, &Fs_ServiceDesc.Streams[3], Fs_GetCache_FullMethodName, opts...)
+	stream, err := c.cc.NewStream(ctx, &Fs_ServiceDesc.Streams[4], Fs_GetCache_FullMethodName, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -305,43 +692,323 @@ func (x *fsGetCacheClient) Recv() (*GetCacheResponse, error) {
 	return m, nil
 }
 
-// FsServer is the server API for Fs service.
-// All implementations must embed UnimplementedFsServer
-// for forward compatibility
-type FsServer interface {
-	NewProject(context.Context, *NewProjectRequest) (*NewProjectResponse, error)
-	DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error)
-	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
-	Get(*GetRequest, Fs_GetServer) error
-	GetCompress(*GetCompressRequest, Fs_GetCompressServer) error
-	GetUnary(context.Context, *GetUnaryRequest) (*GetUnaryResponse, error)
-	Update(Fs_UpdateServer) error
-	Rollback(context.Context, *RollbackRequest) (*RollbackResponse, error)
-	Inspect(context.Context, *InspectRequest) (*InspectResponse, error)
-	Snapshot(context.Context, *SnapshotRequest) (*SnapshotResponse, error)
-	Reset(context.Context, *ResetRequest) (*ResetResponse, error)
-	GcProject(context.Context, *GcProjectRequest) (*GcProjectResponse, error)
-	GcRandomProjects(context.Context, *GcRandomProjectsRequest) (*GcRandomProjectsResponse, error)
-	GcContents(context.Context, *GcContentsRequest) (*GcContentsResponse, error)
-	CloneToProject(context.Context, *CloneToProjectRequest) (*CloneToProjectResponse, error)
-	GetCache(*GetCacheRequest, Fs_GetCacheServer) error
-	mustEmbedUnimplementedFsServer()
+func (c *fsClient) FanOutUpdate(ctx context.Context, in *FanOutUpdateRequest, opts ...grpc.CallOption) (Fs_FanOutUpdateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Fs_ServiceDesc.Streams[5], Fs_FanOutUpdate_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fsFanOutUpdateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-// UnimplementedFsServer must be embedded to have forward compatible implementations.
-type UnimplementedFsServer struct {
+type Fs_FanOutUpdateClient interface {
+	Recv() (*FanOutUpdateResponse, error)
+	grpc.ClientStream
 }
 
-func (UnimplementedFsServer) NewProject(context.Context, *NewProjectRequest) (*NewProjectResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method NewProject not implemented")
+type fsFanOutUpdateClient struct {
+	grpc.ClientStream
 }
-func (UnimplementedFsServer) DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteProject not implemented")
+
+func (x *fsFanOutUpdateClient) Recv() (*FanOutUpdateResponse, error) {
+	m := new(FanOutUpdateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
-func (UnimplementedFsServer) ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
+
+func (c *fsClient) Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (Fs_DiffClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Fs_ServiceDesc.Streams[6], Fs_Diff_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fsDiffClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
-func (UnimplementedFsServer) Get(*GetRequest, Fs_GetServer) error {
+
+type Fs_DiffClient interface {
+	Recv() (*DiffResponse, error)
+	grpc.ClientStream
+}
+
+type fsDiffClient struct {
+	grpc.ClientStream
+}
+
+func (x *fsDiffClient) Recv() (*DiffResponse, error) {
+	m := new(DiffResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fsClient) StatPaths(ctx context.Context, in *StatPathsRequest, opts ...grpc.CallOption) (*StatPathsResponse, error) {
+	out := new(StatPathsResponse)
+	err := c.cc.Invoke(ctx, Fs_StatPaths_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) ListPaths(ctx context.Context, in *ListPathsRequest, opts ...grpc.CallOption) (*ListPathsResponse, error) {
+	out := new(ListPathsResponse)
+	err := c.cc.Invoke(ctx, Fs_ListPaths_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) DirectorySizes(ctx context.Context, in *DirectorySizesRequest, opts ...grpc.CallOption) (*DirectorySizesResponse, error) {
+	out := new(DirectorySizesResponse)
+	err := c.cc.Invoke(ctx, Fs_DirectorySizes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) CaptureHeapProfile(ctx context.Context, in *CaptureHeapProfileRequest, opts ...grpc.CallOption) (*CaptureHeapProfileResponse, error) {
+	out := new(CaptureHeapProfileResponse)
+	err := c.cc.Invoke(ctx, Fs_CaptureHeapProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) ContentStats(ctx context.Context, in *ContentStatsRequest, opts ...grpc.CallOption) (*ContentStatsResponse, error) {
+	out := new(ContentStatsResponse)
+	err := c.cc.Invoke(ctx, Fs_ContentStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) FindContent(ctx context.Context, in *FindContentRequest, opts ...grpc.CallOption) (*FindContentResponse, error) {
+	out := new(FindContentResponse)
+	err := c.cc.Invoke(ctx, Fs_FindContent_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) ContentEncodingStatus(ctx context.Context, in *ContentEncodingStatusRequest, opts ...grpc.CallOption) (*ContentEncodingStatusResponse, error) {
+	out := new(ContentEncodingStatusResponse)
+	err := c.cc.Invoke(ctx, Fs_ContentEncodingStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) CheckUpdate(ctx context.Context, in *CheckUpdateRequest, opts ...grpc.CallOption) (*CheckUpdateResponse, error) {
+	out := new(CheckUpdateResponse)
+	err := c.cc.Invoke(ctx, Fs_CheckUpdate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) SampleObjects(ctx context.Context, in *SampleObjectsRequest, opts ...grpc.CallOption) (Fs_SampleObjectsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Fs_ServiceDesc.Streams[7], Fs_SampleObjects_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fsSampleObjectsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Fs_SampleObjectsClient interface {
+	Recv() (*SampleObjectsResponse, error)
+	grpc.ClientStream
+}
+
+type fsSampleObjectsClient struct {
+	grpc.ClientStream
+}
+
+func (x *fsSampleObjectsClient) Recv() (*SampleObjectsResponse, error) {
+	m := new(SampleObjectsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fsClient) ScanStatus(ctx context.Context, in *ScanStatusRequest, opts ...grpc.CallOption) (*ScanStatusResponse, error) {
+	out := new(ScanStatusResponse)
+	err := c.cc.Invoke(ctx, Fs_ScanStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) ReadSnapshot(ctx context.Context, in *ReadSnapshotRequest, opts ...grpc.CallOption) (*ReadSnapshotResponse, error) {
+	out := new(ReadSnapshotResponse)
+	err := c.cc.Invoke(ctx, Fs_ReadSnapshot_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) ProvisionProject(ctx context.Context, in *ProvisionProjectRequest, opts ...grpc.CallOption) (*ProvisionProjectResponse, error) {
+	out := new(ProvisionProjectResponse)
+	err := c.cc.Invoke(ctx, Fs_ProvisionProject_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) ListArchives(ctx context.Context, in *ListArchivesRequest, opts ...grpc.CallOption) (*ListArchivesResponse, error) {
+	out := new(ListArchivesResponse)
+	err := c.cc.Invoke(ctx, Fs_ListArchives_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fsClient) RestoreArchive(ctx context.Context, in *RestoreArchiveRequest, opts ...grpc.CallOption) (*RestoreArchiveResponse, error) {
+	out := new(RestoreArchiveResponse)
+	err := c.cc.Invoke(ctx, Fs_RestoreArchive_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FsServer is the server API for Fs service.
+// All implementations must embed UnimplementedFsServer
+// for forward compatibility
+type FsServer interface {
+	NewProject(context.Context, *NewProjectRequest) (*NewProjectResponse, error)
+	DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error)
+	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
+	SetProjectLabels(context.Context, *SetProjectLabelsRequest) (*SetProjectLabelsResponse, error)
+	GetProjectLabels(context.Context, *GetProjectLabelsRequest) (*GetProjectLabelsResponse, error)
+	SetProjectCacheInclusion(context.Context, *SetProjectCacheInclusionRequest) (*SetProjectCacheInclusionResponse, error)
+	SetProjectPolicy(context.Context, *SetProjectPolicyRequest) (*SetProjectPolicyResponse, error)
+	GetProjectPolicy(context.Context, *GetProjectPolicyRequest) (*GetProjectPolicyResponse, error)
+	SetProjectFrozen(context.Context, *SetProjectFrozenRequest) (*SetProjectFrozenResponse, error)
+	SetProjectTransforms(context.Context, *SetProjectTransformsRequest) (*SetProjectTransformsResponse, error)
+	Get(*GetRequest, Fs_GetServer) error
+	GetCompress(*GetCompressRequest, Fs_GetCompressServer) error
+	GetUnary(context.Context, *GetUnaryRequest) (*GetUnaryResponse, error)
+	Update(Fs_UpdateServer) error
+	PutObject(context.Context, *PutObjectRequest) (*PutObjectResponse, error)
+	DeleteObject(context.Context, *DeleteObjectRequest) (*DeleteObjectResponse, error)
+	Stage(Fs_StageServer) error
+	ListStaged(context.Context, *ListStagedRequest) (*ListStagedResponse, error)
+	CommitStage(context.Context, *CommitStageRequest) (*CommitStageResponse, error)
+	DiscardStage(context.Context, *DiscardStageRequest) (*DiscardStageResponse, error)
+	Rollback(context.Context, *RollbackRequest) (*RollbackResponse, error)
+	RestorePaths(context.Context, *RestorePathsRequest) (*RestorePathsResponse, error)
+	History(context.Context, *HistoryRequest) (*HistoryResponse, error)
+	Inspect(context.Context, *InspectRequest) (*InspectResponse, error)
+	Snapshot(context.Context, *SnapshotRequest) (*SnapshotResponse, error)
+	Reset(context.Context, *ResetRequest) (*ResetResponse, error)
+	GcProject(context.Context, *GcProjectRequest) (*GcProjectResponse, error)
+	GcRandomProjects(context.Context, *GcRandomProjectsRequest) (*GcRandomProjectsResponse, error)
+	SquashHistory(context.Context, *SquashHistoryRequest) (*SquashHistoryResponse, error)
+	ListTombstones(context.Context, *ListTombstonesRequest) (*ListTombstonesResponse, error)
+	PurgeDeleted(context.Context, *PurgeDeletedRequest) (*PurgeDeletedResponse, error)
+	GcContents(context.Context, *GcContentsRequest) (*GcContentsResponse, error)
+	CheckContentRefs(context.Context, *CheckContentRefsRequest) (*CheckContentRefsResponse, error)
+	AuditContents(context.Context, *AuditContentsRequest) (*AuditContentsResponse, error)
+	CanonicalizePacks(context.Context, *CanonicalizePacksRequest) (*CanonicalizePacksResponse, error)
+	IndexPacks(context.Context, *IndexPacksRequest) (*IndexPacksResponse, error)
+	InlineContents(context.Context, *InlineContentsRequest) (*InlineContentsResponse, error)
+	RepairPaths(context.Context, *RepairPathsRequest) (*RepairPathsResponse, error)
+	ValidatePaths(context.Context, *ValidatePathsRequest) (*ValidatePathsResponse, error)
+	CloneToProject(context.Context, *CloneToProjectRequest) (*CloneToProjectResponse, error)
+	CreateCache(context.Context, *CreateCacheRequest) (*CreateCacheResponse, error)
+	ListCacheVersions(context.Context, *ListCacheVersionsRequest) (*ListCacheVersionsResponse, error)
+	DeleteCacheVersion(context.Context, *DeleteCacheVersionRequest) (*DeleteCacheVersionResponse, error)
+	GetCacheVersionProjects(context.Context, *GetCacheVersionProjectsRequest) (*GetCacheVersionProjectsResponse, error)
+	GetCacheVersion(context.Context, *GetCacheVersionRequest) (*GetCacheVersionResponse, error)
+	GetCache(*GetCacheRequest, Fs_GetCacheServer) error
+	FanOutUpdate(*FanOutUpdateRequest, Fs_FanOutUpdateServer) error
+	Diff(*DiffRequest, Fs_DiffServer) error
+	StatPaths(context.Context, *StatPathsRequest) (*StatPathsResponse, error)
+	ListPaths(context.Context, *ListPathsRequest) (*ListPathsResponse, error)
+	DirectorySizes(context.Context, *DirectorySizesRequest) (*DirectorySizesResponse, error)
+	CaptureHeapProfile(context.Context, *CaptureHeapProfileRequest) (*CaptureHeapProfileResponse, error)
+	ContentStats(context.Context, *ContentStatsRequest) (*ContentStatsResponse, error)
+	FindContent(context.Context, *FindContentRequest) (*FindContentResponse, error)
+	ContentEncodingStatus(context.Context, *ContentEncodingStatusRequest) (*ContentEncodingStatusResponse, error)
+	CheckUpdate(context.Context, *CheckUpdateRequest) (*CheckUpdateResponse, error)
+	SampleObjects(*SampleObjectsRequest, Fs_SampleObjectsServer) error
+	ScanStatus(context.Context, *ScanStatusRequest) (*ScanStatusResponse, error)
+	ReadSnapshot(context.Context, *ReadSnapshotRequest) (*ReadSnapshotResponse, error)
+	ProvisionProject(context.Context, *ProvisionProjectRequest) (*ProvisionProjectResponse, error)
+	ListArchives(context.Context, *ListArchivesRequest) (*ListArchivesResponse, error)
+	RestoreArchive(context.Context, *RestoreArchiveRequest) (*RestoreArchiveResponse, error)
+	mustEmbedUnimplementedFsServer()
+}
+
+// UnimplementedFsServer must be embedded to have forward compatible implementations.
+type UnimplementedFsServer struct {
+}
+
+func (UnimplementedFsServer) NewProject(context.Context, *NewProjectRequest) (*NewProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewProject not implemented")
+}
+func (UnimplementedFsServer) DeleteProject(context.Context, *DeleteProjectRequest) (*DeleteProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProject not implemented")
+}
+func (UnimplementedFsServer) ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
+}
+func (UnimplementedFsServer) SetProjectLabels(context.Context, *SetProjectLabelsRequest) (*SetProjectLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProjectLabels not implemented")
+}
+func (UnimplementedFsServer) GetProjectLabels(context.Context, *GetProjectLabelsRequest) (*GetProjectLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProjectLabels not implemented")
+}
+func (UnimplementedFsServer) SetProjectCacheInclusion(context.Context, *SetProjectCacheInclusionRequest) (*SetProjectCacheInclusionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProjectCacheInclusion not implemented")
+}
+func (UnimplementedFsServer) SetProjectPolicy(context.Context, *SetProjectPolicyRequest) (*SetProjectPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProjectPolicy not implemented")
+}
+func (UnimplementedFsServer) GetProjectPolicy(context.Context, *GetProjectPolicyRequest) (*GetProjectPolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProjectPolicy not implemented")
+}
+func (UnimplementedFsServer) SetProjectFrozen(context.Context, *SetProjectFrozenRequest) (*SetProjectFrozenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProjectFrozen not implemented")
+}
+func (UnimplementedFsServer) SetProjectTransforms(context.Context, *SetProjectTransformsRequest) (*SetProjectTransformsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProjectTransforms not implemented")
+}
+func (UnimplementedFsServer) Get(*GetRequest, Fs_GetServer) error {
 	return status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
 func (UnimplementedFsServer) GetCompress(*GetCompressRequest, Fs_GetCompressServer) error {
@@ -353,9 +1020,33 @@ func (UnimplementedFsServer) GetUnary(context.Context, *GetUnaryRequest) (*GetUn
 func (UnimplementedFsServer) Update(Fs_UpdateServer) error {
 	return status.Errorf(codes.Unimplemented, "method Update not implemented")
 }
+func (UnimplementedFsServer) PutObject(context.Context, *PutObjectRequest) (*PutObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PutObject not implemented")
+}
+func (UnimplementedFsServer) DeleteObject(context.Context, *DeleteObjectRequest) (*DeleteObjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteObject not implemented")
+}
+func (UnimplementedFsServer) Stage(Fs_StageServer) error {
+	return status.Errorf(codes.Unimplemented, "method Stage not implemented")
+}
+func (UnimplementedFsServer) ListStaged(context.Context, *ListStagedRequest) (*ListStagedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStaged not implemented")
+}
+func (UnimplementedFsServer) CommitStage(context.Context, *CommitStageRequest) (*CommitStageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitStage not implemented")
+}
+func (UnimplementedFsServer) DiscardStage(context.Context, *DiscardStageRequest) (*DiscardStageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscardStage not implemented")
+}
 func (UnimplementedFsServer) Rollback(context.Context, *RollbackRequest) (*RollbackResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Rollback not implemented")
 }
+func (UnimplementedFsServer) RestorePaths(context.Context, *RestorePathsRequest) (*RestorePathsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestorePaths not implemented")
+}
+func (UnimplementedFsServer) History(context.Context, *HistoryRequest) (*HistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method History not implemented")
+}
 func (UnimplementedFsServer) Inspect(context.Context, *InspectRequest) (*InspectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Inspect not implemented")
 }
@@ -371,15 +1062,108 @@ func (UnimplementedFsServer) GcProject(context.Context, *GcProjectRequest) (*GcP
 func (UnimplementedFsServer) GcRandomProjects(context.Context, *GcRandomProjectsRequest) (*GcRandomProjectsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GcRandomProjects not implemented")
 }
+func (UnimplementedFsServer) SquashHistory(context.Context, *SquashHistoryRequest) (*SquashHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SquashHistory not implemented")
+}
+func (UnimplementedFsServer) ListTombstones(context.Context, *ListTombstonesRequest) (*ListTombstonesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTombstones not implemented")
+}
+func (UnimplementedFsServer) PurgeDeleted(context.Context, *PurgeDeletedRequest) (*PurgeDeletedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeDeleted not implemented")
+}
 func (UnimplementedFsServer) GcContents(context.Context, *GcContentsRequest) (*GcContentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GcContents not implemented")
 }
+func (UnimplementedFsServer) CheckContentRefs(context.Context, *CheckContentRefsRequest) (*CheckContentRefsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckContentRefs not implemented")
+}
+func (UnimplementedFsServer) AuditContents(context.Context, *AuditContentsRequest) (*AuditContentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuditContents not implemented")
+}
+func (UnimplementedFsServer) CanonicalizePacks(context.Context, *CanonicalizePacksRequest) (*CanonicalizePacksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CanonicalizePacks not implemented")
+}
+func (UnimplementedFsServer) IndexPacks(context.Context, *IndexPacksRequest) (*IndexPacksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IndexPacks not implemented")
+}
+func (UnimplementedFsServer) InlineContents(context.Context, *InlineContentsRequest) (*InlineContentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InlineContents not implemented")
+}
+func (UnimplementedFsServer) RepairPaths(context.Context, *RepairPathsRequest) (*RepairPathsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RepairPaths not implemented")
+}
+func (UnimplementedFsServer) ValidatePaths(context.Context, *ValidatePathsRequest) (*ValidatePathsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidatePaths not implemented")
+}
 func (UnimplementedFsServer) CloneToProject(context.Context, *CloneToProjectRequest) (*CloneToProjectResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CloneToProject not implemented")
 }
+func (UnimplementedFsServer) CreateCache(context.Context, *CreateCacheRequest) (*CreateCacheResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCache not implemented")
+}
+func (UnimplementedFsServer) ListCacheVersions(context.Context, *ListCacheVersionsRequest) (*ListCacheVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCacheVersions not implemented")
+}
+func (UnimplementedFsServer) DeleteCacheVersion(context.Context, *DeleteCacheVersionRequest) (*DeleteCacheVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCacheVersion not implemented")
+}
+func (UnimplementedFsServer) GetCacheVersionProjects(context.Context, *GetCacheVersionProjectsRequest) (*GetCacheVersionProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCacheVersionProjects not implemented")
+}
+func (UnimplementedFsServer) GetCacheVersion(context.Context, *GetCacheVersionRequest) (*GetCacheVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCacheVersion not implemented")
+}
 func (UnimplementedFsServer) GetCache(*GetCacheRequest, Fs_GetCacheServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetCache not implemented")
 }
+func (UnimplementedFsServer) FanOutUpdate(*FanOutUpdateRequest, Fs_FanOutUpdateServer) error {
+	return status.Errorf(codes.Unimplemented, "method FanOutUpdate not implemented")
+}
+func (UnimplementedFsServer) Diff(*DiffRequest, Fs_DiffServer) error {
+	return status.Errorf(codes.Unimplemented, "method Diff not implemented")
+}
+func (UnimplementedFsServer) StatPaths(context.Context, *StatPathsRequest) (*StatPathsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StatPaths not implemented")
+}
+func (UnimplementedFsServer) ListPaths(context.Context, *ListPathsRequest) (*ListPathsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPaths not implemented")
+}
+func (UnimplementedFsServer) DirectorySizes(context.Context, *DirectorySizesRequest) (*DirectorySizesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DirectorySizes not implemented")
+}
+func (UnimplementedFsServer) CaptureHeapProfile(context.Context, *CaptureHeapProfileRequest) (*CaptureHeapProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CaptureHeapProfile not implemented")
+}
+func (UnimplementedFsServer) ContentStats(context.Context, *ContentStatsRequest) (*ContentStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ContentStats not implemented")
+}
+func (UnimplementedFsServer) FindContent(context.Context, *FindContentRequest) (*FindContentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindContent not implemented")
+}
+func (UnimplementedFsServer) ContentEncodingStatus(context.Context, *ContentEncodingStatusRequest) (*ContentEncodingStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ContentEncodingStatus not implemented")
+}
+func (UnimplementedFsServer) CheckUpdate(context.Context, *CheckUpdateRequest) (*CheckUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckUpdate not implemented")
+}
+func (UnimplementedFsServer) SampleObjects(*SampleObjectsRequest, Fs_SampleObjectsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SampleObjects not implemented")
+}
+func (UnimplementedFsServer) ScanStatus(context.Context, *ScanStatusRequest) (*ScanStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScanStatus not implemented")
+}
+func (UnimplementedFsServer) ReadSnapshot(context.Context, *ReadSnapshotRequest) (*ReadSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadSnapshot not implemented")
+}
+func (UnimplementedFsServer) ProvisionProject(context.Context, *ProvisionProjectRequest) (*ProvisionProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProvisionProject not implemented")
+}
+func (UnimplementedFsServer) ListArchives(context.Context, *ListArchivesRequest) (*ListArchivesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArchives not implemented")
+}
+func (UnimplementedFsServer) RestoreArchive(context.Context, *RestoreArchiveRequest) (*RestoreArchiveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreArchive not implemented")
+}
 func (UnimplementedFsServer) mustEmbedUnimplementedFsServer() {}
 
 // UnsafeFsServer may be embedded to opt out of forward compatibility for this service.
@@ -447,255 +1231,1100 @@ func _Fs_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Fs_Get_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetRequest)
+func _Fs_SetProjectLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProjectLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).SetProjectLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_SetProjectLabels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).SetProjectLabels(ctx, req.(*SetProjectLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_GetProjectLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).GetProjectLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_GetProjectLabels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).GetProjectLabels(ctx, req.(*GetProjectLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_SetProjectCacheInclusion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProjectCacheInclusionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).SetProjectCacheInclusion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_SetProjectCacheInclusion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).SetProjectCacheInclusion(ctx, req.(*SetProjectCacheInclusionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_SetProjectPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProjectPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).SetProjectPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_SetProjectPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).SetProjectPolicy(ctx, req.(*SetProjectPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_GetProjectPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).GetProjectPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_GetProjectPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).GetProjectPolicy(ctx, req.(*GetProjectPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_SetProjectFrozen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProjectFrozenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).SetProjectFrozen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_SetProjectFrozen_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).SetProjectFrozen(ctx, req.(*SetProjectFrozenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_SetProjectTransforms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProjectTransformsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).SetProjectTransforms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_SetProjectTransforms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).SetProjectTransforms(ctx, req.(*SetProjectTransformsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_Get_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FsServer).Get(m, &fsGetServer{stream})
+}
+
+type Fs_GetServer interface {
+	Send(*GetResponse) error
+	grpc.ServerStream
+}
+
+type fsGetServer struct {
+	grpc.ServerStream
+}
+
+func (x *fsGetServer) Send(m *GetResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Fs_GetCompress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetCompressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FsServer).GetCompress(m, &fsGetCompressServer{stream})
+}
+
+type Fs_GetCompressServer interface {
+	Send(*GetCompressResponse) error
+	grpc.ServerStream
+}
+
+type fsGetCompressServer struct {
+	grpc.ServerStream
+}
+
+func (x *fsGetCompressServer) Send(m *GetCompressResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Fs_GetUnary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).GetUnary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_GetUnary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).GetUnary(ctx, req.(*GetUnaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_Update_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FsServer).Update(&fsUpdateServer{stream})
+}
+
+type Fs_UpdateServer interface {
+	SendAndClose(*UpdateResponse) error
+	Recv() (*UpdateRequest, error)
+	grpc.ServerStream
+}
+
+type fsUpdateServer struct {
+	grpc.ServerStream
+}
+
+func (x *fsUpdateServer) SendAndClose(m *UpdateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *fsUpdateServer) Recv() (*UpdateRequest, error) {
+	m := new(UpdateRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Fs_PutObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).PutObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_PutObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).PutObject(ctx, req.(*PutObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_DeleteObject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteObjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).DeleteObject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_DeleteObject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).DeleteObject(ctx, req.(*DeleteObjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_Stage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FsServer).Stage(&fsStageServer{stream})
+}
+
+type Fs_StageServer interface {
+	SendAndClose(*StageResponse) error
+	Recv() (*StageRequest, error)
+	grpc.ServerStream
+}
+
+type fsStageServer struct {
+	grpc.ServerStream
+}
+
+func (x *fsStageServer) SendAndClose(m *StageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *fsStageServer) Recv() (*StageRequest, error) {
+	m := new(StageRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Fs_ListStaged_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStagedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).ListStaged(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_ListStaged_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).ListStaged(ctx, req.(*ListStagedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_CommitStage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitStageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).CommitStage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_CommitStage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).CommitStage(ctx, req.(*CommitStageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_DiscardStage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscardStageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).DiscardStage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_DiscardStage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).DiscardStage(ctx, req.(*DiscardStageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_Rollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).Rollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_Rollback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).Rollback(ctx, req.(*RollbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_RestorePaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestorePathsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).RestorePaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_RestorePaths_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).RestorePaths(ctx, req.(*RestorePathsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_History_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).History(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).Inspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_Inspect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).Inspect(ctx, req.(*InspectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_Snapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).Snapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_Snapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).Snapshot(ctx, req.(*SnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).Reset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_Reset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).Reset(ctx, req.(*ResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_GcProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GcProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).GcProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_GcProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).GcProject(ctx, req.(*GcProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_GcRandomProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GcRandomProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).GcRandomProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_GcRandomProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).GcRandomProjects(ctx, req.(*GcRandomProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_SquashHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SquashHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).SquashHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_SquashHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).SquashHistory(ctx, req.(*SquashHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_ListTombstones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTombstonesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).ListTombstones(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_ListTombstones_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).ListTombstones(ctx, req.(*ListTombstonesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_PurgeDeleted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeDeletedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).PurgeDeleted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_PurgeDeleted_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).PurgeDeleted(ctx, req.(*PurgeDeletedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_GcContents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GcContentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).GcContents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_GcContents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).GcContents(ctx, req.(*GcContentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_CheckContentRefs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckContentRefsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).CheckContentRefs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_CheckContentRefs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).CheckContentRefs(ctx, req.(*CheckContentRefsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_AuditContents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditContentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).AuditContents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_AuditContents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).AuditContents(ctx, req.(*AuditContentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_CanonicalizePacks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CanonicalizePacksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).CanonicalizePacks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_CanonicalizePacks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).CanonicalizePacks(ctx, req.(*CanonicalizePacksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_IndexPacks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IndexPacksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).IndexPacks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_IndexPacks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).IndexPacks(ctx, req.(*IndexPacksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_InlineContents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InlineContentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).InlineContents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_InlineContents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).InlineContents(ctx, req.(*InlineContentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_RepairPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepairPathsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).RepairPaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_RepairPaths_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).RepairPaths(ctx, req.(*RepairPathsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_ValidatePaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatePathsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).ValidatePaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_ValidatePaths_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).ValidatePaths(ctx, req.(*ValidatePathsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_CloneToProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneToProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).CloneToProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_CloneToProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).CloneToProject(ctx, req.(*CloneToProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_CreateCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCacheRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).CreateCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_CreateCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).CreateCache(ctx, req.(*CreateCacheRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_ListCacheVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCacheVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).ListCacheVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_ListCacheVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).ListCacheVersions(ctx, req.(*ListCacheVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_DeleteCacheVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCacheVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).DeleteCacheVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_DeleteCacheVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).DeleteCacheVersion(ctx, req.(*DeleteCacheVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_GetCacheVersionProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCacheVersionProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).GetCacheVersionProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_GetCacheVersionProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).GetCacheVersionProjects(ctx, req.(*GetCacheVersionProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_GetCacheVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCacheVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).GetCacheVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_GetCacheVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).GetCacheVersion(ctx, req.(*GetCacheVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fs_GetCache_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetCacheRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FsServer).GetCache(m, &fsGetCacheServer{stream})
+}
+
+type Fs_GetCacheServer interface {
+	Send(*GetCacheResponse) error
+	grpc.ServerStream
+}
+
+type fsGetCacheServer struct {
+	grpc.ServerStream
+}
+
+func (x *fsGetCacheServer) Send(m *GetCacheResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Fs_FanOutUpdate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FanOutUpdateRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(FsServer).Get(m, &fsGetServer{stream})
+	return srv.(FsServer).FanOutUpdate(m, &fsFanOutUpdateServer{stream})
 }
 
-type Fs_GetServer interface {
-	Send(*GetResponse) error
+type Fs_FanOutUpdateServer interface {
+	Send(*FanOutUpdateResponse) error
 	grpc.ServerStream
 }
 
-type fsGetServer struct {
+type fsFanOutUpdateServer struct {
 	grpc.ServerStream
 }
 
-func (x *fsGetServer) Send(m *GetResponse) error {
+func (x *fsFanOutUpdateServer) Send(m *FanOutUpdateResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Fs_GetCompress_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetCompressRequest)
+func _Fs_Diff_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DiffRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(FsServer).GetCompress(m, &fsGetCompressServer{stream})
+	return srv.(FsServer).Diff(m, &fsDiffServer{stream})
 }
 
-type Fs_GetCompressServer interface {
-	Send(*GetCompressResponse) error
+type Fs_DiffServer interface {
+	Send(*DiffResponse) error
 	grpc.ServerStream
 }
 
-type fsGetCompressServer struct {
+type fsDiffServer struct {
 	grpc.ServerStream
 }
 
-func (x *fsGetCompressServer) Send(m *GetCompressResponse) error {
+func (x *fsDiffServer) Send(m *DiffResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Fs_GetUnary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUnaryRequest)
+func _Fs_StatPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatPathsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FsServer).GetUnary(ctx, in)
+		return srv.(FsServer).StatPaths(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Fs_GetUnary_FullMethodName,
+		FullMethod: Fs_StatPaths_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FsServer).GetUnary(ctx, req.(*GetUnaryRequest))
+		return srv.(FsServer).StatPaths(ctx, req.(*StatPathsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Fs_Update_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(FsServer).Update(&fsUpdateServer{stream})
-}
-
-type Fs_UpdateServer interface {
-	SendAndClose(*UpdateResponse) error
-	Recv() (*UpdateRequest, error)
-	grpc.ServerStream
-}
-
-type fsUpdateServer struct {
-	grpc.ServerStream
-}
-
-func (x *fsUpdateServer) SendAndClose(m *UpdateResponse) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func (x *fsUpdateServer) Recv() (*UpdateRequest, error) {
-	m := new(UpdateRequest)
-	if err := x.ServerStream.RecvMsg(m); err != nil {
+func _Fs_ListPaths_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPathsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return m, nil
+	if interceptor == nil {
+		return srv.(FsServer).ListPaths(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_ListPaths_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).ListPaths(ctx, req.(*ListPathsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _Fs_Rollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RollbackRequest)
+func _Fs_DirectorySizes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DirectorySizesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FsServer).Rollback(ctx, in)
+		return srv.(FsServer).DirectorySizes(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Fs_Rollback_FullMethodName,
+		FullMethod: Fs_DirectorySizes_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FsServer).Rollback(ctx, req.(*RollbackRequest))
+		return srv.(FsServer).DirectorySizes(ctx, req.(*DirectorySizesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Fs_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(InspectRequest)
+func _Fs_CaptureHeapProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureHeapProfileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FsServer).Inspect(ctx, in)
+		return srv.(FsServer).CaptureHeapProfile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Fs_Inspect_FullMethodName,
+		FullMethod: Fs_CaptureHeapProfile_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FsServer).Inspect(ctx, req.(*InspectRequest))
+		return srv.(FsServer).CaptureHeapProfile(ctx, req.(*CaptureHeapProfileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Fs_Snapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SnapshotRequest)
+func _Fs_ContentStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FsServer).Snapshot(ctx, in)
+		return srv.(FsServer).ContentStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Fs_Snapshot_FullMethodName,
+		FullMethod: Fs_ContentStats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FsServer).Snapshot(ctx, req.(*SnapshotRequest))
+		return srv.(FsServer).ContentStats(ctx, req.(*ContentStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Fs_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ResetRequest)
+func _Fs_FindContent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindContentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FsServer).Reset(ctx, in)
+		return srv.(FsServer).FindContent(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Fs_Reset_FullMethodName,
+		FullMethod: Fs_FindContent_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FsServer).Reset(ctx, req.(*ResetRequest))
+		return srv.(FsServer).FindContent(ctx, req.(*FindContentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Fs_GcProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GcProjectRequest)
+func _Fs_ContentEncodingStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContentEncodingStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FsServer).GcProject(ctx, in)
+		return srv.(FsServer).ContentEncodingStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Fs_GcProject_FullMethodName,
+		FullMethod: Fs_ContentEncodingStatus_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FsServer).GcProject(ctx, req.(*GcProjectRequest))
+		return srv.(FsServer).ContentEncodingStatus(ctx, req.(*ContentEncodingStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Fs_GcRandomProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GcRandomProjectsRequest)
+func _Fs_CheckUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckUpdateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FsServer).GcRandomProjects(ctx, in)
+		return srv.(FsServer).CheckUpdate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Fs_GcRandomProjects_FullMethodName,
+		FullMethod: Fs_CheckUpdate_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FsServer).GcRandomProjects(ctx, req.(*GcRandomProjectsRequest))
+		return srv.(FsServer).CheckUpdate(ctx, req.(*CheckUpdateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Fs_GcContents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GcContentsRequest)
+func _Fs_SampleObjects_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SampleObjectsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FsServer).SampleObjects(m, &fsSampleObjectsServer{stream})
+}
+
+type Fs_SampleObjectsServer interface {
+	Send(*SampleObjectsResponse) error
+	grpc.ServerStream
+}
+
+type fsSampleObjectsServer struct {
+	grpc.ServerStream
+}
+
+func (x *fsSampleObjectsServer) Send(m *SampleObjectsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Fs_ScanStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScanStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FsServer).GcContents(ctx, in)
+		return srv.(FsServer).ScanStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Fs_GcContents_FullMethodName,
+		FullMethod: Fs_ScanStatus_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FsServer).GcContents(ctx, req.(*GcContentsRequest))
+		return srv.(FsServer).ScanStatus(ctx, req.(*ScanStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Fs_CloneToProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CloneToProjectRequest)
+func _Fs_ReadSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadSnapshotRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FsServer).CloneToProject(ctx, in)
+		return srv.(FsServer).ReadSnapshot(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: Fs_CloneToProject_FullMethodName,
+		FullMethod: Fs_ReadSnapshot_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FsServer).CloneToProject(ctx, req.(*CloneToProjectRequest))
+		return srv.(FsServer).ReadSnapshot(ctx, req.(*ReadSnapshotRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Fs_GetCache_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetCacheRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+func _Fs_ProvisionProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProvisionProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return srv.(FsServer).GetCache(m, &fsGetCacheServer{stream})
-}
-
-type Fs_GetCacheServer interface {
-	Send(*GetCacheResponse) error
-	grpc.ServerStream
+	if interceptor == nil {
+		return srv.(FsServer).ProvisionProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_ProvisionProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).ProvisionProject(ctx, req.(*ProvisionProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type fsGetCacheServer struct {
-	grpc.ServerStream
+func _Fs_ListArchives_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArchivesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).ListArchives(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_ListArchives_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).ListArchives(ctx, req.(*ListArchivesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (x *fsGetCacheServer) Send(m *GetCacheResponse) error {
-	return x.ServerStream.SendMsg(m)
+func _Fs_RestoreArchive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreArchiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FsServer).RestoreArchive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fs_RestoreArchive_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FsServer).RestoreArchive(ctx, req.(*RestoreArchiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
 // Fs_ServiceDesc is the grpc.ServiceDesc for Fs service.
@@ -717,14 +2346,70 @@ var Fs_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListProjects",
 			Handler:    _Fs_ListProjects_Handler,
 		},
+		{
+			MethodName: "SetProjectLabels",
+			Handler:    _Fs_SetProjectLabels_Handler,
+		},
+		{
+			MethodName: "GetProjectLabels",
+			Handler:    _Fs_GetProjectLabels_Handler,
+		},
+		{
+			MethodName: "SetProjectCacheInclusion",
+			Handler:    _Fs_SetProjectCacheInclusion_Handler,
+		},
+		{
+			MethodName: "SetProjectPolicy",
+			Handler:    _Fs_SetProjectPolicy_Handler,
+		},
+		{
+			MethodName: "GetProjectPolicy",
+			Handler:    _Fs_GetProjectPolicy_Handler,
+		},
+		{
+			MethodName: "SetProjectFrozen",
+			Handler:    _Fs_SetProjectFrozen_Handler,
+		},
+		{
+			MethodName: "SetProjectTransforms",
+			Handler:    _Fs_SetProjectTransforms_Handler,
+		},
 		{
 			MethodName: "GetUnary",
 			Handler:    _Fs_GetUnary_Handler,
 		},
+		{
+			MethodName: "PutObject",
+			Handler:    _Fs_PutObject_Handler,
+		},
+		{
+			MethodName: "DeleteObject",
+			Handler:    _Fs_DeleteObject_Handler,
+		},
+		{
+			MethodName: "ListStaged",
+			Handler:    _Fs_ListStaged_Handler,
+		},
+		{
+			MethodName: "CommitStage",
+			Handler:    _Fs_CommitStage_Handler,
+		},
+		{
+			MethodName: "DiscardStage",
+			Handler:    _Fs_DiscardStage_Handler,
+		},
 		{
 			MethodName: "Rollback",
 			Handler:    _Fs_Rollback_Handler,
 		},
+		{
+			MethodName: "RestorePaths",
+			Handler:    _Fs_RestorePaths_Handler,
+		},
+		{
+			MethodName: "History",
+			Handler:    _Fs_History_Handler,
+		},
 		{
 			MethodName: "Inspect",
 			Handler:    _Fs_Inspect_Handler,
@@ -745,14 +2430,126 @@ var Fs_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GcRandomProjects",
 			Handler:    _Fs_GcRandomProjects_Handler,
 		},
+		{
+			MethodName: "SquashHistory",
+			Handler:    _Fs_SquashHistory_Handler,
+		},
+		{
+			MethodName: "ListTombstones",
+			Handler:    _Fs_ListTombstones_Handler,
+		},
+		{
+			MethodName: "PurgeDeleted",
+			Handler:    _Fs_PurgeDeleted_Handler,
+		},
 		{
 			MethodName: "GcContents",
 			Handler:    _Fs_GcContents_Handler,
 		},
+		{
+			MethodName: "CheckContentRefs",
+			Handler:    _Fs_CheckContentRefs_Handler,
+		},
+		{
+			MethodName: "AuditContents",
+			Handler:    _Fs_AuditContents_Handler,
+		},
+		{
+			MethodName: "CanonicalizePacks",
+			Handler:    _Fs_CanonicalizePacks_Handler,
+		},
+		{
+			MethodName: "IndexPacks",
+			Handler:    _Fs_IndexPacks_Handler,
+		},
+		{
+			MethodName: "InlineContents",
+			Handler:    _Fs_InlineContents_Handler,
+		},
+		{
+			MethodName: "RepairPaths",
+			Handler:    _Fs_RepairPaths_Handler,
+		},
+		{
+			MethodName: "ValidatePaths",
+			Handler:    _Fs_ValidatePaths_Handler,
+		},
 		{
 			MethodName: "CloneToProject",
 			Handler:    _Fs_CloneToProject_Handler,
 		},
+		{
+			MethodName: "CreateCache",
+			Handler:    _Fs_CreateCache_Handler,
+		},
+		{
+			MethodName: "ListCacheVersions",
+			Handler:    _Fs_ListCacheVersions_Handler,
+		},
+		{
+			MethodName: "DeleteCacheVersion",
+			Handler:    _Fs_DeleteCacheVersion_Handler,
+		},
+		{
+			MethodName: "GetCacheVersionProjects",
+			Handler:    _Fs_GetCacheVersionProjects_Handler,
+		},
+		{
+			MethodName: "GetCacheVersion",
+			Handler:    _Fs_GetCacheVersion_Handler,
+		},
+		{
+			MethodName: "StatPaths",
+			Handler:    _Fs_StatPaths_Handler,
+		},
+		{
+			MethodName: "ListPaths",
+			Handler:    _Fs_ListPaths_Handler,
+		},
+		{
+			MethodName: "DirectorySizes",
+			Handler:    _Fs_DirectorySizes_Handler,
+		},
+		{
+			MethodName: "CaptureHeapProfile",
+			Handler:    _Fs_CaptureHeapProfile_Handler,
+		},
+		{
+			MethodName: "ContentStats",
+			Handler:    _Fs_ContentStats_Handler,
+		},
+		{
+			MethodName: "FindContent",
+			Handler:    _Fs_FindContent_Handler,
+		},
+		{
+			MethodName: "ContentEncodingStatus",
+			Handler:    _Fs_ContentEncodingStatus_Handler,
+		},
+		{
+			MethodName: "CheckUpdate",
+			Handler:    _Fs_CheckUpdate_Handler,
+		},
+		{
+			MethodName: "ScanStatus",
+			Handler:    _Fs_ScanStatus_Handler,
+		},
+		{
+			MethodName: "ReadSnapshot",
+			Handler:    _Fs_ReadSnapshot_Handler,
+		},
+		{
+			MethodName: "ProvisionProject",
+			Handler:    _Fs_ProvisionProject_Handler,
+		},
+		{
+			MethodName: "ListArchives",
+			Handler:    _Fs_ListArchives_Handler,
+		},
+		{
+			MethodName: "RestoreArchive",
+			Handler:    _Fs_RestoreArchive_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -770,11 +2567,31 @@ var Fs_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Fs_Update_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Stage",
+			Handler:       _Fs_Stage_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "GetCache",
 			Handler:       _Fs_GetCache_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "FanOutUpdate",
+			Handler:       _Fs_FanOutUpdate_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Diff",
+			Handler:       _Fs_Diff_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SampleObjects",
+			Handler:       _Fs_SampleObjects_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "internal/pb/fs.proto",
 }
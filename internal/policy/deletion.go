@@ -0,0 +1,46 @@
+package policy
+
+import "fmt"
+
+const MassDeletion = "MASS_DELETION"
+
+// DeletionGuard flags updates removing a suspicious share of a project's live objects, as a buggy client syncing an
+// empty or partial directory would. Packed directories count as a single object. A nil *DeletionGuard flags nothing.
+type DeletionGuard struct {
+	// MaxRatio is the share of the live objects, between 0 and 1, an update may remove
+	MaxRatio float64
+	// MinObjects is the number of live objects below which projects are not guarded, small projects legitimately
+	// change wholesale
+	MinObjects int64
+}
+
+func (g *DeletionGuard) Validate() error {
+	if g == nil {
+		return nil
+	}
+	if g.MaxRatio <= 0 || g.MaxRatio >= 1 {
+		return fmt.Errorf("invalid deletion guard ratio %v, expected between 0 and 1 exclusive", g.MaxRatio)
+	}
+	if g.MinObjects < 0 {
+		return fmt.Errorf("invalid deletion guard minimum %v, expected a positive number of objects", g.MinObjects)
+	}
+	return nil
+}
+
+// Check returns a violation when going from before to after live objects removes more than the allowed share of them.
+func (g *DeletionGuard) Check(before, after int64) *Violation {
+	if g == nil || before == 0 || before < g.MinObjects || after >= before {
+		return nil
+	}
+
+	removed := before - after
+	if float64(removed)/float64(before) <= g.MaxRatio {
+		return nil
+	}
+
+	return &Violation{
+		Type:        MassDeletion,
+		Path:        "/",
+		Description: fmt.Sprintf("removes %d of %d live objects, more than %.0f%%, resend it with force to apply it", removed, before, g.MaxRatio*100),
+	}
+}
@@ -0,0 +1,88 @@
+package policy
+
+import (
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gobwas/glob"
+)
+
+const (
+	MaxFileSize   = "MAX_FILE_SIZE"
+	ForbiddenPath = "FORBIDDEN_PATH"
+)
+
+// Violation describes why an updated object breaks its project's policy.
+type Violation struct {
+	Type        string
+	Path        string
+	Description string
+}
+
+type forbidden struct {
+	spec    string
+	pattern glob.Glob
+}
+
+type Policy struct {
+	maxFileSize *int64
+	forbidden   []forbidden
+}
+
+// New compiles a project policy, a nil policy accepts every object.
+func New(policy *pb.ProjectPolicy) (*Policy, error) {
+	compiled := &Policy{}
+	if policy == nil {
+		return compiled, nil
+	}
+
+	if policy.MaxFileSize != nil {
+		if *policy.MaxFileSize < 0 {
+			return nil, fmt.Errorf("invalid max file size %d: cannot be negative", *policy.MaxFileSize)
+		}
+		compiled.maxFileSize = policy.MaxFileSize
+	}
+
+	for _, spec := range policy.ForbiddenPaths {
+		pattern, err := glob.Compile(spec, '/')
+		if err != nil {
+			return nil, fmt.Errorf("invalid forbidden path %q: %w", spec, err)
+		}
+		compiled.forbidden = append(compiled.forbidden, forbidden{spec: spec, pattern: pattern})
+	}
+
+	return compiled, nil
+}
+
+func (p *Policy) Empty() bool {
+	return p.maxFileSize == nil && len(p.forbidden) == 0
+}
+
+// Check returns the violations of writing size bytes of content at path, deletions are always allowed.
+func (p *Policy) Check(path string, size int64, deleted bool) []Violation {
+	if deleted {
+		return nil
+	}
+
+	var violations []Violation
+
+	if p.maxFileSize != nil && size > *p.maxFileSize {
+		violations = append(violations, Violation{
+			Type:        MaxFileSize,
+			Path:        path,
+			Description: fmt.Sprintf("%d bytes exceeds the maximum file size of %d bytes", size, *p.maxFileSize),
+		})
+	}
+
+	for _, rule := range p.forbidden {
+		if rule.pattern.Match(path) {
+			violations = append(violations, Violation{
+				Type:        ForbiddenPath,
+				Path:        path,
+				Description: fmt.Sprintf("matches the forbidden path %q", rule.spec),
+			})
+		}
+	}
+
+	return violations
+}
@@ -0,0 +1,136 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gadget-inc/dateilager/internal/logger"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Baggage is propagated on every call whether tracing is enabled or not, so values like a deploy or sandbox ID
+// reach the server logs either way.
+var baggagePropagator = propagation.Baggage{}
+
+// WithBaggage adds the key value pairs to the baggage of ctx, replacing the members with the same keys.
+func WithBaggage(ctx context.Context, values map[string]string) (context.Context, error) {
+	bag := baggage.FromContext(ctx)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		member, err := baggage.NewMember(key, values[key])
+		if err != nil {
+			return ctx, fmt.Errorf("invalid baggage %s=%s: %w", key, values[key], err)
+		}
+
+		bag, err = bag.SetMember(member)
+		if err != nil {
+			return ctx, fmt.Errorf("invalid baggage %s=%s: %w", key, values[key], err)
+		}
+	}
+
+	return baggage.ContextWithBaggage(ctx, bag), nil
+}
+
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func injectBaggage(ctx context.Context) context.Context {
+	if baggage.FromContext(ctx).Len() == 0 {
+		return ctx
+	}
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if ok {
+		md = md.Copy()
+	} else {
+		md = metadata.MD{}
+	}
+
+	baggagePropagator.Inject(ctx, metadataCarrier(md))
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// BaggageDialOptions install the client interceptors sending the baggage of the call's context along with it.
+func BaggageDialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(injectBaggage(ctx), method, req, reply, cc, opts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(injectBaggage(ctx), desc, cc, method, opts...)
+		}),
+	}
+}
+
+// extractBaggage adds the baggage sent by the client to ctx and attaches its members to the call's span and logs.
+func extractBaggage(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	ctx = baggagePropagator.Extract(ctx, metadataCarrier(md))
+	members := baggage.FromContext(ctx).Members()
+	if len(members) == 0 {
+		return ctx
+	}
+
+	attributes := make([]attribute.KeyValue, 0, len(members))
+	fields := make([]zap.Field, 0, len(members))
+	for _, member := range members {
+		attributes = append(attributes, attribute.String("baggage."+member.Key(), member.Value()))
+		fields = append(fields, zap.String("baggage."+member.Key(), member.Value()))
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attributes...)
+	return logger.With(ctx, fields...)
+}
+
+// BaggageUnaryServerInterceptor must run once the span of the call is started and before the call is logged.
+func BaggageUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(extractBaggage(ctx), req)
+	}
+}
+
+// BaggageStreamServerInterceptor must run once the span of the call is started and before the call is logged.
+func BaggageStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = extractBaggage(stream.Context())
+		return handler(srv, wrapped)
+	}
+}
@@ -0,0 +1,81 @@
+package telemetry
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+type costCtxKey struct{}
+
+// Cost accumulates the resources used to serve a request. It is safe to update from every goroutine of the request.
+type Cost struct {
+	dbTime    atomic.Int64
+	dbQueries atomic.Int64
+	dbRows    atomic.Int64
+	sentBytes atomic.Int64
+}
+
+// CostSnapshot is the value of a Cost at one point in time.
+type CostSnapshot struct {
+	DbTime    time.Duration
+	DbQueries int64
+	DbRows    int64
+	SentBytes int64
+}
+
+// Trailer keys the cost of a request is sent to the client in.
+const (
+	CostDbTimeTrailer    = "dl-cost-db-ms"
+	CostDbQueriesTrailer = "dl-cost-db-queries"
+	CostDbRowsTrailer    = "dl-cost-db-rows"
+	CostSentBytesTrailer = "dl-cost-sent-bytes"
+)
+
+// WithCost starts accounting the cost of the request served with ctx.
+func WithCost(ctx context.Context) (context.Context, *Cost) {
+	cost := &Cost{}
+	return context.WithValue(ctx, costCtxKey{}, cost), cost
+}
+
+// CostFromContext returns the cost accounted for ctx, nil outside of a request.
+func CostFromContext(ctx context.Context) *Cost {
+	cost, _ := ctx.Value(costCtxKey{}).(*Cost)
+	return cost
+}
+
+// AddSentBytes accounts for a response message of size bytes.
+func (c *Cost) AddSentBytes(size int) {
+	c.sentBytes.Add(int64(size))
+}
+
+func (c *Cost) Snapshot() CostSnapshot {
+	return CostSnapshot{
+		DbTime:    time.Duration(c.dbTime.Load()),
+		DbQueries: c.dbQueries.Load(),
+		DbRows:    c.dbRows.Load(),
+		SentBytes: c.sentBytes.Load(),
+	}
+}
+
+func (s CostSnapshot) Trailer() metadata.MD {
+	return metadata.Pairs(
+		CostDbTimeTrailer, strconv.FormatInt(s.DbTime.Milliseconds(), 10),
+		CostDbQueriesTrailer, strconv.FormatInt(s.DbQueries, 10),
+		CostDbRowsTrailer, strconv.FormatInt(s.DbRows, 10),
+		CostSentBytesTrailer, strconv.FormatInt(s.SentBytes, 10),
+	)
+}
+
+func (s CostSnapshot) Fields() []zap.Field {
+	return []zap.Field{
+		zap.Duration("cost.db_time", s.DbTime),
+		zap.Int64("cost.db_queries", s.DbQueries),
+		zap.Int64("cost.db_rows", s.DbRows),
+		zap.Int64("cost.sent_bytes", s.SentBytes),
+	}
+}
@@ -3,6 +3,7 @@ package telemetry
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel/attribute"
@@ -27,18 +28,24 @@ var (
 )
 
 func (t *pgxTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
-	return t.start(ctx, "pgx.query", conn.Config(), semconv.DBStatementKey.String(strings.Trim(data.SQL, " ")))
+	return t.start(withQueryStart(ctx), "pgx.query", conn.Config(), semconv.DBStatementKey.String(strings.Trim(data.SQL, " ")))
 }
 
 func (t *pgxTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	accountQuery(ctx, 1, data.CommandTag.RowsAffected())
 	t.end(ctx, data.Err, attribute.String("pgx.command-tag", data.CommandTag.String()))
 }
 
 func (t *pgxTracer) TraceBatchStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
-	return t.start(ctx, "pgx.batch", conn.Config(), attribute.Int("pgx.batch.size", data.Batch.Len()))
+	return t.start(withQueryStart(ctx), "pgx.batch", conn.Config(), attribute.Int("pgx.batch.size", data.Batch.Len()))
 }
 
 func (t *pgxTracer) TraceBatchQuery(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchQueryData) {
+	if cost := CostFromContext(ctx); cost != nil {
+		cost.dbQueries.Add(1)
+		cost.dbRows.Add(data.CommandTag.RowsAffected())
+	}
+
 	span := trace.SpanFromContext(ctx)
 	if !span.IsRecording() {
 		return
@@ -58,17 +65,20 @@ func (t *pgxTracer) TraceBatchQuery(ctx context.Context, conn *pgx.Conn, data pg
 }
 
 func (t *pgxTracer) TraceBatchEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchEndData) {
+	// the queries of the batch were counted as they completed
+	accountQuery(ctx, 0, 0)
 	t.end(ctx, data.Err)
 }
 
 func (t *pgxTracer) TraceCopyFromStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
-	return t.start(ctx, "pgx.copy-from", conn.Config(),
+	return t.start(withQueryStart(ctx), "pgx.copy-from", conn.Config(),
 		semconv.DBSQLTableKey.String(data.TableName.Sanitize()),
 		attribute.StringSlice("db.sql.columns", data.ColumnNames),
 	)
 }
 
 func (t *pgxTracer) TraceCopyFromEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	accountQuery(ctx, 1, data.CommandTag.RowsAffected())
 	t.end(ctx, data.Err, attribute.String("pgx.command-tag", data.CommandTag.String()))
 }
 
@@ -88,6 +98,28 @@ func (t *pgxTracer) TraceConnectEnd(ctx context.Context, data pgx.TraceConnectEn
 	t.end(ctx, data.Err)
 }
 
+type queryStartCtxKey struct{}
+
+// withQueryStart records when a query started so its duration is added to the cost of the request running it.
+func withQueryStart(ctx context.Context) context.Context {
+	if CostFromContext(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, queryStartCtxKey{}, time.Now())
+}
+
+func accountQuery(ctx context.Context, queries int64, rows int64) {
+	cost := CostFromContext(ctx)
+	start, ok := ctx.Value(queryStartCtxKey{}).(time.Time)
+	if cost == nil || !ok {
+		return
+	}
+
+	cost.dbTime.Add(int64(time.Since(start)))
+	cost.dbQueries.Add(queries)
+	cost.dbRows.Add(rows)
+}
+
 func (t *pgxTracer) attrs(config *pgx.ConnConfig, attributes ...attribute.KeyValue) []attribute.KeyValue {
 	attrs := []attribute.KeyValue{
 		semconv.DBSystemPostgreSQL,
@@ -1,10 +1,71 @@
 package telemetry
 
 import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"sync/atomic"
+
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 )
 
+// SamplingConfig sets the share of traces sampled, from 0 to 1, per project with Default for every other trace.
+type SamplingConfig struct {
+	Default  float64           `yaml:"default"`
+	Projects map[int64]float64 `yaml:"projects"`
+}
+
+func (c *SamplingConfig) Validate() error {
+	if c.Default < 0 || c.Default > 1 {
+		return fmt.Errorf("invalid default sampling rate %v, expected between 0 and 1", c.Default)
+	}
+	for project, rate := range c.Projects {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("invalid sampling rate %v for project %d, expected between 0 and 1", rate, project)
+		}
+	}
+	return nil
+}
+
+func (c *SamplingConfig) rate(ctx context.Context) float64 {
+	project, ok := ProjectFromContext(ctx)
+	if ok {
+		if rate, ok := c.Projects[project]; ok {
+			return rate
+		}
+	}
+	return c.Default
+}
+
+var sampling atomic.Pointer[SamplingConfig]
+
+// SetSampling swaps the sampling rates applied to new root spans, a nil config samples every trace.
+func SetSampling(config *SamplingConfig) {
+	sampling.Store(config)
+}
+
+// SamplesProjects reports whether any project has its own sampling rate, only then do calls need to be tagged with
+// their project.
+func SamplesProjects() bool {
+	config := sampling.Load()
+	return config != nil && len(config.Projects) > 0
+}
+
+type projectCtxKeyType struct{}
+
+var projectCtxKey = projectCtxKeyType{}
+
+// WithProject tags ctx with the project of a call, spans started from it are sampled at the rate of that project.
+func WithProject(ctx context.Context, project int64) context.Context {
+	return context.WithValue(ctx, projectCtxKey, project)
+}
+
+func ProjectFromContext(ctx context.Context) (int64, bool) {
+	project, ok := ctx.Value(projectCtxKey).(int64)
+	return project, ok
+}
+
 type sampler struct{}
 
 func (s sampler) ShouldSample(params sdktrace.SamplingParameters) sdktrace.SamplingResult {
@@ -16,6 +77,14 @@ func (s sampler) ShouldSample(params sdktrace.SamplingParameters) sdktrace.Sampl
 		}
 	}
 
+	config := sampling.Load()
+	if config != nil && !sampledTraceID(params.TraceID, config.rate(params.ParentContext)) {
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.Drop,
+			Tracestate: psc.TraceState(),
+		}
+	}
+
 	return sdktrace.SamplingResult{
 		Decision:   sdktrace.RecordAndSample,
 		Tracestate: psc.TraceState(),
@@ -25,3 +94,15 @@ func (s sampler) ShouldSample(params sdktrace.SamplingParameters) sdktrace.Sampl
 func (s sampler) Description() string {
 	return "DateilagerSampler"
 }
+
+// sampledTraceID keeps rate of the traces based on their ID alone, like the TraceIDRatioBased sampler does, so every
+// service sampling at the same rate keeps the same traces.
+func sampledTraceID(traceID trace.TraceID, rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+	if rate <= 0 {
+		return false
+	}
+	return binary.BigEndian.Uint64(traceID[8:16])>>1 < uint64(rate*(1<<63))
+}
@@ -17,14 +17,14 @@ import (
 )
 
 type TestCtx struct {
-	t      *testing.T
+	t      testing.TB
 	log    *zap.Logger
 	dbConn *DbTestConnector
 	lookup *db.ContentLookup
 	ctx    context.Context
 }
 
-func NewTestCtx(t *testing.T, role auth.Role, projects ...int64) TestCtx {
+func NewTestCtx(t testing.TB, role auth.Role, projects ...int64) TestCtx {
 	var project *int64
 	if len(projects) > 0 {
 		project = &projects[0]
@@ -80,7 +80,7 @@ func (tc *TestCtx) Connect() pgx.Tx {
 	return tx
 }
 
-func (tc *TestCtx) T() *testing.T {
+func (tc *TestCtx) T() testing.TB {
 	return tc.t
 }
 
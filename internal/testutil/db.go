@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/gadget-inc/dateilager/internal/auth"
 	"github.com/gadget-inc/dateilager/internal/db"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -43,6 +44,16 @@ func (d *DbTestConnector) Connect(ctx context.Context) (pgx.Tx, db.CloseFunc, er
 		return nil, nil, err
 	}
 	d.innerTx = innerTx
+
+	if ctxAuth, ok := ctx.Value(auth.AuthCtxKey).(auth.Auth); ok && ctxAuth.Tenant != nil {
+		err = db.ScopeToTenant(ctx, innerTx, *ctxAuth.Tenant)
+		if err != nil {
+			return nil, nil, err
+		}
+		// SET LOCAL outlives the savepoint, reset it so the next call runs unscoped
+		return innerTx, func(ctx context.Context) { _ = db.UnscopeTenant(ctx, d.tx) }, nil
+	}
+
 	return innerTx, func(context.Context) {}, nil
 }
 
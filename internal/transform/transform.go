@@ -0,0 +1,210 @@
+package transform
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gobwas/glob"
+)
+
+// Func rewrites the content of a single file, vars are the values supplied by the caller of Get.
+type Func func(content []byte, vars map[string]string) ([]byte, error)
+
+var (
+	sourceMapLine       = regexp.MustCompile(`(?m)^[ \t]*//[#@] sourceMappingURL=.*(\r?\n)?`)
+	sourceMapComment    = regexp.MustCompile(`/\*[#@] sourceMappingURL=[^*]*\*/`)
+	templatePlaceholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+)
+
+// builtins is the safe set of transformations a project can be configured with.
+var builtins = map[string]Func{
+	"strip-sourcemaps": stripSourceMaps,
+	"template":         expandTemplate,
+	"lfs-pointer":      lfsPointer,
+}
+
+// lfsPointerVersion starts every git-lfs pointer file, see https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md
+const lfsPointerVersion = "version https://git-lfs.github.com/spec/v1\n"
+
+func stripSourceMaps(content []byte, _ map[string]string) ([]byte, error) {
+	content = sourceMapLine.ReplaceAll(content, nil)
+	return sourceMapComment.ReplaceAll(content, nil), nil
+}
+
+// expandTemplate replaces ${NAME} placeholders with their value, unknown names are left untouched.
+func expandTemplate(content []byte, vars map[string]string) ([]byte, error) {
+	return templatePlaceholder.ReplaceAllFunc(content, func(match []byte) []byte {
+		name := string(templatePlaceholder.FindSubmatch(match)[1])
+		if value, ok := vars[name]; ok {
+			return []byte(value)
+		}
+		return match
+	}), nil
+}
+
+// lfsPointer replaces a file by the git-lfs pointer to its content, so a checkout holding the real bytes can be committed
+// to a repository tracking the file with git-lfs. Files already holding a pointer are left untouched.
+// Pointers sent back in updates are resolved to the content they reference, see Set.LfsPointer.
+func lfsPointer(content []byte, _ map[string]string) ([]byte, error) {
+	if bytes.HasPrefix(content, []byte(lfsPointerVersion)) {
+		return content, nil
+	}
+
+	oid := sha256.Sum256(content)
+	return []byte(fmt.Sprintf("%soid sha256:%s\nsize %d\n", lfsPointerVersion, hex.EncodeToString(oid[:]), len(content))), nil
+}
+
+// maxLfsPointerSize bounds the size of git-lfs pointer files, larger files are never parsed as pointers
+const maxLfsPointerSize = 1024
+
+// parseLfsPointer returns the sha256 oid and the size of the content a git-lfs pointer references, ok is false for
+// content that is not a pointer.
+func parseLfsPointer(content []byte) ([]byte, int64, bool) {
+	if len(content) > maxLfsPointerSize || !bytes.HasPrefix(content, []byte(lfsPointerVersion)) || !bytes.HasSuffix(content, []byte("\n")) {
+		return nil, 0, false
+	}
+
+	var oid []byte
+	size := int64(-1)
+
+	lines := strings.Split(strings.TrimSuffix(string(content[len(lfsPointerVersion):]), "\n"), "\n")
+	for _, line := range lines {
+		name, value, found := strings.Cut(line, " ")
+		if !found {
+			return nil, 0, false
+		}
+
+		switch name {
+		case "oid":
+			hexOid, found := strings.CutPrefix(value, "sha256:")
+			if !found {
+				return nil, 0, false
+			}
+			decoded, err := hex.DecodeString(hexOid)
+			if err != nil || len(decoded) != sha256.Size {
+				return nil, 0, false
+			}
+			oid = decoded
+		case "size":
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || parsed < 0 {
+				return nil, 0, false
+			}
+			size = parsed
+		}
+	}
+
+	if oid == nil || size < 0 {
+		return nil, 0, false
+	}
+	return oid, size, true
+}
+
+type rule struct {
+	name    string
+	pattern glob.Glob
+	fn      Func
+}
+
+type Set struct {
+	rules []rule
+}
+
+// NewSet parses transformation specs of the form "<name>:<glob>", e.g. "strip-sourcemaps:**.js".
+func NewSet(specs []string) (*Set, error) {
+	set := &Set{}
+
+	for _, spec := range specs {
+		name, pattern, found := strings.Cut(spec, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid transform %q: expected <name>:<glob>", spec)
+		}
+
+		fn, ok := builtins[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid transform %q: unknown transform %v", spec, name)
+		}
+
+		compiled, err := glob.Compile(pattern, '/')
+		if err != nil {
+			return nil, fmt.Errorf("invalid transform %q: %w", spec, err)
+		}
+
+		set.rules = append(set.rules, rule{name: name, pattern: compiled, fn: fn})
+	}
+
+	return set, nil
+}
+
+func (s *Set) Empty() bool {
+	return len(s.rules) == 0
+}
+
+// Matches reports whether any transformation applies to path.
+func (s *Set) Matches(path string) bool {
+	for _, rule := range s.rules {
+		if rule.pattern.Match(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// LfsPointer returns the sha256 oid and the size of the content referenced by a regular file holding a git-lfs pointer
+// at a path the set serves as a pointer, ok is false for every other object. Such pointers sent back by clients stand
+// for the content they reference, which is the content DateiLager stores.
+func (s *Set) LfsPointer(object *pb.Object) ([]byte, int64, bool) {
+	if object.Deleted || pb.TarTypeFromMode(fs.FileMode(object.Mode)) != tar.TypeReg {
+		return nil, 0, false
+	}
+
+	for _, rule := range s.rules {
+		if rule.name == "lfs-pointer" && rule.pattern.Match(object.Path) {
+			return parseLfsPointer(object.Content)
+		}
+	}
+	return nil, 0, false
+}
+
+// Apply runs every matching transformation, in configuration order, on a regular file object.
+func (s *Set) Apply(object *pb.Object, vars map[string]string) (*pb.Object, error) {
+	if object.Deleted || object.Content == nil || pb.TarTypeFromMode(fs.FileMode(object.Mode)) != tar.TypeReg {
+		return object, nil
+	}
+
+	content := object.Content
+	transformed := false
+
+	for _, rule := range s.rules {
+		if !rule.pattern.Match(object.Path) {
+			continue
+		}
+
+		var err error
+		content, err = rule.fn(content, vars)
+		if err != nil {
+			return nil, fmt.Errorf("transform %v on %v: %w", rule.name, object.Path, err)
+		}
+		transformed = true
+	}
+
+	if !transformed {
+		return object, nil
+	}
+
+	return &pb.Object{
+		Path:    object.Path,
+		Mode:    object.Mode,
+		Size:    int64(len(content)),
+		Deleted: object.Deleted,
+		Content: content,
+	}, nil
+}
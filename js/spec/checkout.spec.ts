@@ -0,0 +1,49 @@
+import * as fs from "fs";
+import path from "path";
+import { CorruptVersionFileError, encodeContent, readVersionFile, rebuildCheckout, updateCheckout, writeVersionFile } from "../src";
+import { grpcClient, tmpdir } from "./util";
+
+describe("checkout helpers", () => {
+  afterEach(async () => {
+    await grpcClient.deleteProject(1337n);
+  });
+
+  it("reads and writes the version file like the go client", async () => {
+    const dir = tmpdir();
+    expect(await readVersionFile(dir)).toBe(0n);
+
+    await writeVersionFile(dir, 7n);
+    expect(await readVersionFile(dir)).toBe(7n);
+
+    fs.writeFileSync(path.join(dir, ".dl", "version"), "garbage");
+    await expect(readVersionFile(dir)).rejects.toBeInstanceOf(CorruptVersionFileError);
+  });
+
+  it("can rebuild and update a checkout", async () => {
+    await grpcClient.newProject(1337n, []);
+    const content = encodeContent("a v1");
+    await grpcClient.updateObject(1337n, {
+      path: "a",
+      mode: 0o644n,
+      content,
+      size: BigInt(content.length),
+      deleted: false,
+      contentOmitted: false,
+    });
+
+    const dir = tmpdir();
+    const rebuilt = await rebuildCheckout(grpcClient, 1337n, dir);
+    expect(rebuilt).toEqual({ version: 1n, count: 1 });
+    expect(fs.readFileSync(path.join(dir, "a")).toString()).toBe("a v1");
+
+    fs.writeFileSync(path.join(dir, "b"), "b v2");
+    fs.rmSync(path.join(dir, "a"));
+
+    const updated = await updateCheckout(grpcClient, 1337n, dir, ["a", "b"]);
+    expect(updated).toEqual({ version: 2n, count: 2 });
+    expect(await readVersionFile(dir)).toBe(2n);
+
+    const response = await grpcClient.getObjects(1337n, "");
+    expect(response.objects.map((object) => object.path)).toEqual(["b"]);
+  });
+});
@@ -0,0 +1,281 @@
+import fs from "fs/promises";
+import path from "path";
+import { trace } from "./internal/telemetry";
+import type { DateiLagerGrpcClient, Objekt } from "./grpc-client";
+import { CorruptVersionFileError, MissingVersionFileError } from "./utils/errors";
+
+/**
+ * The directory holding the metadata of a checkout, it is never sent to the server.
+ */
+export const METADATA_DIR = ".dl";
+
+const VERSION_FILE = path.join(METADATA_DIR, "version");
+const SUMMARY_FILE = path.join(METADATA_DIR, "sum.s2");
+
+// Go's fs.FileMode type bits, as stored in the mode of objects
+const MODE_DIR = 1n << 31n;
+const MODE_SYMLINK = 1n << 27n;
+const MODE_PERM = 0o777n;
+
+/**
+ * The result of a checkout operation.
+ */
+export interface CheckoutResult {
+  /**
+   * The version the directory is at.
+   */
+  version: bigint;
+
+  /**
+   * The number of paths written or removed.
+   */
+  count: number;
+}
+
+/**
+ * Read the version a directory was last rebuilt or updated to, like the Go client does.
+ * @param dir The checkout directory.
+ * @returns   The version, 0 for a directory that was never rebuilt.
+ * @throws {MissingVersionFileError} If the directory has metadata from a previous rebuild but no version.
+ * @throws {CorruptVersionFileError} If the version file does not hold a valid version.
+ */
+export async function readVersionFile(dir: string): Promise<bigint> {
+  let contents: string;
+  try {
+    contents = await fs.readFile(path.join(dir, VERSION_FILE), "utf8");
+  } catch (error) {
+    if (!isNotFound(error)) {
+      throw new CorruptVersionFileError(`cannot read ${path.join(dir, VERSION_FILE)}: ${String(error)}`);
+    }
+    if (await exists(path.join(dir, SUMMARY_FILE))) {
+      throw new MissingVersionFileError(`${path.join(dir, METADATA_DIR)} has a summary but no version`);
+    }
+    return 0n;
+  }
+
+  const trimmed = contents.trim();
+  if (!/^\d+$/.test(trimmed)) {
+    throw new CorruptVersionFileError(`invalid version ${JSON.stringify(contents)} in ${path.join(dir, VERSION_FILE)}`);
+  }
+  return BigInt(trimmed);
+}
+
+/**
+ * Record the version a directory is at.
+ * @param dir     The checkout directory.
+ * @param version The version of the project the directory holds.
+ */
+export async function writeVersionFile(dir: string, version: bigint): Promise<void> {
+  await fs.mkdir(path.join(dir, METADATA_DIR), { recursive: true, mode: 0o775 });
+  await fs.writeFile(path.join(dir, VERSION_FILE), String(version), { mode: 0o755 });
+}
+
+/**
+ * The directory holding the packed objects of a cache, shared by every project.
+ * @param cacheRootDir The root of the cache.
+ * @returns            The objects directory.
+ */
+export function cacheObjectsDir(cacheRootDir: string): string {
+  return path.join(cacheRootDir, "objects");
+}
+
+/**
+ * The directory holding regular file contents by hash, shared between rebuilds of every project.
+ * @param cacheRootDir The root of the cache.
+ * @returns            The loose objects directory.
+ */
+export function cacheLooseObjectsDir(cacheRootDir: string): string {
+  return path.join(cacheRootDir, "loose");
+}
+
+/**
+ * List the cache versions already downloaded into a cache.
+ * @param cacheRootDir The root of the cache.
+ * @returns            The available cache versions, empty when there is no cache.
+ */
+export async function readCacheVersionFile(cacheRootDir: string): Promise<bigint[]> {
+  let contents: string;
+  try {
+    contents = await fs.readFile(path.join(cacheRootDir, "versions"), "utf8");
+  } catch {
+    return [];
+  }
+
+  return contents
+    .split("\n")
+    .filter((line) => /^\d+$/.test(line))
+    .map((line) => BigInt(line));
+}
+
+/**
+ * Bring a directory from the version in its version file to a later version of the project.
+ *
+ * Unlike the binary client no summary is recorded, changes made to the directory afterwards must be sent with {@link updateCheckout}.
+ * @param client         The client used to read the project.
+ * @param project        The id of the project.
+ * @param dir            The checkout directory.
+ * @param options        Object of options.
+ * @param options.to     The version to rebuild to, defaults to the latest version.
+ * @param options.prefix Only rebuild the paths under this prefix.
+ * @returns              The version the directory is at and the number of paths changed.
+ */
+export async function rebuildCheckout(
+  client: DateiLagerGrpcClient,
+  project: bigint,
+  dir: string,
+  options?: { to?: bigint; prefix?: string }
+): Promise<CheckoutResult> {
+  return await trace(
+    "dateilager-checkout.rebuild",
+    {
+      attributes: {
+        "dl.project": String(project),
+        "dl.directory": dir,
+        "dl.to_version": String(options?.to),
+      },
+    },
+    async () => {
+      const from = await readVersionFile(dir);
+      const to = options?.to ?? (await client.readSnapshot(project)).version;
+      if (to == from) {
+        return { version: from, count: 0 };
+      }
+
+      let count = 0;
+      for await (const object of client.listObjects(project, options?.prefix ?? "", [], from, to)) {
+        await writeObject(dir, object);
+        count++;
+      }
+
+      await writeVersionFile(dir, to);
+      return { version: to, count };
+    }
+  );
+}
+
+/**
+ * Send the given paths of a directory as a new version of the project, paths missing from the directory are deleted.
+ *
+ * The version file is moved forward when the update is the only change since the directory's version,
+ * otherwise the directory catches up on the other changes with {@link rebuildCheckout}.
+ * @param client  The client used to update the project.
+ * @param project The id of the project.
+ * @param dir     The checkout directory.
+ * @param paths   The paths, relative to {@link dir}, that changed.
+ * @returns       The version the directory is at and the number of paths sent.
+ */
+export async function updateCheckout(client: DateiLagerGrpcClient, project: bigint, dir: string, paths: string[]): Promise<CheckoutResult> {
+  return await trace(
+    "dateilager-checkout.update",
+    {
+      attributes: {
+        "dl.project": String(project),
+        "dl.directory": dir,
+      },
+    },
+    async () => {
+      const from = await readVersionFile(dir);
+      if (paths.length == 0) {
+        return { version: from, count: 0 };
+      }
+
+      const stream = client.updateObjects(project);
+      for (const relative of paths) {
+        await stream.send(await readObject(dir, relative));
+      }
+
+      const version = await stream.complete();
+      if (version === null) {
+        return { version: from, count: 0 };
+      }
+
+      if (version == from + 1n) {
+        await writeVersionFile(dir, version);
+      } else {
+        await rebuildCheckout(client, project, dir, { to: version });
+      }
+
+      return { version, count: paths.length };
+    }
+  );
+}
+
+async function writeObject(dir: string, object: Objekt): Promise<void> {
+  const target = path.join(dir, object.path);
+
+  if (object.deleted) {
+    await fs.rm(target, { recursive: true, force: true });
+    return;
+  }
+
+  if (object.contentOmitted) {
+    throw new Error(`content of ${object.path} was omitted by the server`);
+  }
+
+  await fs.mkdir(path.dirname(target), { recursive: true });
+  const mode = Number(object.mode & MODE_PERM);
+
+  if ((object.mode & MODE_DIR) != 0n) {
+    await fs.mkdir(target, { recursive: true, mode });
+  } else if ((object.mode & MODE_SYMLINK) != 0n) {
+    await fs.rm(target, { force: true });
+    await fs.symlink(Buffer.from(object.content ?? new Uint8Array()).toString(), target);
+  } else {
+    await fs.rm(target, { recursive: true, force: true });
+    await fs.writeFile(target, object.content ?? new Uint8Array(), { mode });
+  }
+}
+
+async function readObject(dir: string, relative: string): Promise<Objekt> {
+  const target = path.join(dir, relative);
+
+  let stat;
+  try {
+    stat = await fs.lstat(target);
+  } catch (error) {
+    if (isNotFound(error)) {
+      return { path: relative, mode: 0n, size: 0n, deleted: true, contentOmitted: false };
+    }
+    throw error;
+  }
+
+  const perm = BigInt(stat.mode) & MODE_PERM;
+
+  if (stat.isDirectory()) {
+    return {
+      path: relative.endsWith("/") ? relative : `${relative}/`,
+      mode: MODE_DIR | perm,
+      size: 0n,
+      deleted: false,
+      contentOmitted: false,
+    };
+  }
+
+  if (stat.isSymbolicLink()) {
+    const content = Buffer.from(await fs.readlink(target));
+    return {
+      path: relative,
+      mode: MODE_SYMLINK | perm,
+      size: BigInt(content.length),
+      deleted: false,
+      content,
+      contentOmitted: false,
+    };
+  }
+
+  const content = await fs.readFile(target);
+  return { path: relative, mode: perm, size: BigInt(content.length), deleted: false, content, contentOmitted: false };
+}
+
+async function exists(file: string): Promise<boolean> {
+  try {
+    await fs.access(file);
+    return true;
+  } catch {
+    return false;
+  }
+}
+
+function isNotFound(error: unknown): boolean {
+  return typeof error == "object" && error !== null && (error as NodeJS.ErrnoException).code == "ENOENT";
+}
@@ -6,10 +6,18 @@ import { GrpcTransport } from "@protobuf-ts/grpc-transport";
 import { RpcError, type ClientStreamingCall, type RpcOptions } from "@protobuf-ts/runtime-rpc";
 import { TextDecoder, TextEncoder } from "util";
 import { trace, tracer } from "./internal/telemetry";
-import type { CloneToProjectResponse, GetUnaryResponse, Objekt, Project, UpdateRequest, UpdateResponse } from "./pb/fs_pb";
+import type {
+  CloneToProjectResponse,
+  GetUnaryResponse,
+  Objekt,
+  Project,
+  ReadSnapshotResponse,
+  UpdateRequest,
+  UpdateResponse,
+} from "./pb/fs_pb";
 import { FsClient } from "./pb/fs_pb.client";
 import { ProjectAlreadyExistsError } from "./utils/errors";
-export type { Objekt, Project };
+export type { Objekt, Project, ReadSnapshotResponse };
 
 /**
  * Options for {@link DateiLagerGrpcClient}.
@@ -338,6 +346,26 @@ export class DateiLagerGrpcClient {
     return await stream.complete();
   }
 
+  /**
+   * Read the latest version of a project along with a token pinning later reads to it.
+   * @param project The id of the project.
+   * @returns       The project, its latest version and the snapshot token.
+   */
+  public async readSnapshot(project: bigint): Promise<ReadSnapshotResponse> {
+    return await trace(
+      "dateilager-grpc-client.read-snapshot",
+      {
+        attributes: {
+          "dl.project": String(project),
+        },
+      },
+      async () => {
+        const call = await this._client.readSnapshot({ project }, this._rpcOptions());
+        return call.response;
+      }
+    );
+  }
+
   /**
    * Rollback a project.
    * @param project The id of the project.
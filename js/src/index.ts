@@ -1,3 +1,4 @@
 export * from "./binary-client";
+export * from "./checkout";
 export * from "./grpc-client";
 export * from "./utils/errors";
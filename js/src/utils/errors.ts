@@ -2,3 +2,13 @@
  * Thrown when a project already exists in the DL server
  */
 export class ProjectAlreadyExistsError extends Error {}
+
+/**
+ * Thrown when a checkout directory's version file cannot be read or does not hold a valid version
+ */
+export class CorruptVersionFileError extends Error {}
+
+/**
+ * Thrown when a checkout directory holds metadata from a previous rebuild but no version file
+ */
+export class MissingVersionFileError extends Error {}
@@ -0,0 +1,2 @@
+ALTER TABLE dl.projects
+DROP COLUMN transforms;
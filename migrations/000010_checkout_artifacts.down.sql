@@ -0,0 +1 @@
+DROP TABLE dl.checkout_artifacts;
@@ -0,0 +1,8 @@
+CREATE TABLE dl.checkout_artifacts (
+    project       bigint     NOT NULL,
+    version       bigint     NOT NULL,
+    idx           integer    NOT NULL,
+    bytes         bytea      NOT NULL,
+    pack_path     text,
+    PRIMARY KEY (project, version, idx)
+);
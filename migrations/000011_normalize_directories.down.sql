@@ -0,0 +1,6 @@
+-- The rows fixed up by the up migration cannot be told apart from the ones written normalized
+DO $$
+BEGIN
+  RAISE EXCEPTION 'migration 000011_normalize_directories is irreversible';
+END
+$$;
@@ -0,0 +1,21 @@
+-- Directories are stored with a trailing slash and the directory mode bit, fix up rows written before this was enforced.
+-- Rows are left alone when a slashed row of the same directory is live in any of their versions.
+UPDATE dl.objects o
+SET path = o.path || '/'
+WHERE o.packed = false
+  AND (o.mode & 2147483648) != 0
+  AND o.path NOT LIKE '%/'
+  AND NOT EXISTS (
+    SELECT 1
+    FROM dl.objects d
+    WHERE d.project = o.project
+      AND d.path = o.path || '/'
+      AND d.start_version < COALESCE(o.stop_version, 9223372036854775807)
+      AND o.start_version < COALESCE(d.stop_version, 9223372036854775807)
+  );
+
+UPDATE dl.objects
+SET mode = mode | 2147483648
+WHERE packed = false
+  AND path LIKE '%/'
+  AND (mode & 2147483648) = 0;
@@ -0,0 +1,2 @@
+ALTER TABLE dl.objects
+DROP COLUMN mtime;
@@ -0,0 +1,2 @@
+ALTER TABLE dl.objects
+ADD COLUMN mtime bigint;
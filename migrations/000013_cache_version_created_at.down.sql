@@ -0,0 +1,2 @@
+ALTER TABLE dl.cache_versions
+DROP COLUMN created_at;
@@ -0,0 +1,2 @@
+ALTER TABLE dl.cache_versions
+ADD COLUMN created_at timestamptz NOT NULL DEFAULT now();
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS dl.projects_labels_idx;
+
+ALTER TABLE dl.projects
+DROP COLUMN labels;
@@ -0,0 +1,4 @@
+ALTER TABLE dl.projects
+ADD COLUMN labels jsonb NOT NULL DEFAULT '{}';
+
+CREATE INDEX projects_labels_idx ON dl.projects USING gin (labels jsonb_path_ops);
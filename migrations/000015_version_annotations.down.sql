@@ -0,0 +1 @@
+DROP TABLE dl.version_annotations;
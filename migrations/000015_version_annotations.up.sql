@@ -0,0 +1,8 @@
+CREATE TABLE dl.version_annotations (
+    project       bigint       NOT NULL,
+    version       bigint       NOT NULL,
+    message       text,
+    author        text,
+    created_at    timestamptz  NOT NULL DEFAULT now(),
+    PRIMARY KEY (project, version)
+);
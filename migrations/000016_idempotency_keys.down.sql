@@ -0,0 +1 @@
+DROP TABLE dl.idempotency_keys;
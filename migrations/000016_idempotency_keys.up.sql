@@ -0,0 +1,7 @@
+CREATE TABLE dl.idempotency_keys (
+    project       bigint       NOT NULL,
+    key           text         NOT NULL,
+    version       bigint       NOT NULL,
+    created_at    timestamptz  NOT NULL DEFAULT now(),
+    PRIMARY KEY (project, key)
+);
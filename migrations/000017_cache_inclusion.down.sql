@@ -0,0 +1,4 @@
+DROP TABLE dl.cache_version_projects;
+
+ALTER TABLE dl.projects
+DROP COLUMN include_in_cache;
@@ -0,0 +1,8 @@
+ALTER TABLE dl.projects
+ADD COLUMN include_in_cache boolean NOT NULL DEFAULT true;
+
+CREATE TABLE dl.cache_version_projects (
+    version       bigint  NOT NULL REFERENCES dl.cache_versions (version) ON DELETE CASCADE,
+    project       bigint  NOT NULL,
+    PRIMARY KEY (version, project)
+);
@@ -0,0 +1,27 @@
+DROP POLICY idempotency_keys_tenant ON dl.idempotency_keys;
+ALTER TABLE dl.idempotency_keys DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY version_annotations_tenant ON dl.version_annotations;
+ALTER TABLE dl.version_annotations DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY checkout_artifacts_tenant ON dl.checkout_artifacts;
+ALTER TABLE dl.checkout_artifacts DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY objects_tenant ON dl.objects;
+ALTER TABLE dl.objects DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY projects_tenant ON dl.projects;
+ALTER TABLE dl.projects DISABLE ROW LEVEL SECURITY;
+
+DROP FUNCTION dl.current_tenant();
+
+ALTER DEFAULT PRIVILEGES IN SCHEMA dl REVOKE USAGE, SELECT ON SEQUENCES FROM dl_tenant;
+ALTER DEFAULT PRIVILEGES IN SCHEMA dl REVOKE SELECT, INSERT, UPDATE, DELETE ON TABLES FROM dl_tenant;
+REVOKE ALL ON ALL SEQUENCES IN SCHEMA dl FROM dl_tenant;
+REVOKE ALL ON ALL TABLES IN SCHEMA dl FROM dl_tenant;
+REVOKE USAGE ON SCHEMA dl FROM dl_tenant;
+
+DROP INDEX IF EXISTS dl.projects_tenant_idx;
+
+ALTER TABLE dl.projects
+DROP COLUMN tenant;
@@ -0,0 +1,46 @@
+ALTER TABLE dl.projects
+ADD COLUMN tenant text DEFAULT NULLIF(current_setting('dl.tenant', true), '');
+
+CREATE INDEX projects_tenant_idx ON dl.projects (tenant);
+
+-- Transactions of tenant scoped tokens switch to this role, row level security is never bypassed by it
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'dl_tenant') THEN
+        CREATE ROLE dl_tenant NOLOGIN;
+    END IF;
+END
+$$;
+
+GRANT dl_tenant TO CURRENT_USER;
+GRANT USAGE ON SCHEMA dl TO dl_tenant;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA dl TO dl_tenant;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA dl TO dl_tenant;
+ALTER DEFAULT PRIVILEGES IN SCHEMA dl GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO dl_tenant;
+ALTER DEFAULT PRIVILEGES IN SCHEMA dl GRANT USAGE, SELECT ON SEQUENCES TO dl_tenant;
+
+CREATE FUNCTION dl.current_tenant() RETURNS text AS $$
+    SELECT NULLIF(current_setting('dl.tenant', true), '')
+$$ LANGUAGE sql STABLE;
+
+ALTER TABLE dl.projects ENABLE ROW LEVEL SECURITY;
+CREATE POLICY projects_tenant ON dl.projects TO dl_tenant
+    USING (tenant = dl.current_tenant())
+    WITH CHECK (tenant = dl.current_tenant());
+
+-- Project scoped tables are visible when their project is, the projects policy applies inside the subquery
+ALTER TABLE dl.objects ENABLE ROW LEVEL SECURITY;
+CREATE POLICY objects_tenant ON dl.objects TO dl_tenant
+    USING (EXISTS (SELECT 1 FROM dl.projects p WHERE p.id = objects.project));
+
+ALTER TABLE dl.checkout_artifacts ENABLE ROW LEVEL SECURITY;
+CREATE POLICY checkout_artifacts_tenant ON dl.checkout_artifacts TO dl_tenant
+    USING (EXISTS (SELECT 1 FROM dl.projects p WHERE p.id = checkout_artifacts.project));
+
+ALTER TABLE dl.version_annotations ENABLE ROW LEVEL SECURITY;
+CREATE POLICY version_annotations_tenant ON dl.version_annotations TO dl_tenant
+    USING (EXISTS (SELECT 1 FROM dl.projects p WHERE p.id = version_annotations.project));
+
+ALTER TABLE dl.idempotency_keys ENABLE ROW LEVEL SECURITY;
+CREATE POLICY idempotency_keys_tenant ON dl.idempotency_keys TO dl_tenant
+    USING (EXISTS (SELECT 1 FROM dl.projects p WHERE p.id = idempotency_keys.project));
@@ -0,0 +1,2 @@
+ALTER TABLE dl.projects
+ADD COLUMN baseline_version bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE dl.projects
+ADD COLUMN template bigint;
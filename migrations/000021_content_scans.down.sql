@@ -0,0 +1 @@
+DROP TABLE dl.content_scans;
@@ -0,0 +1,13 @@
+CREATE TABLE dl.content_scans (
+    hash        hash         PRIMARY KEY,
+    packed      boolean      NOT NULL DEFAULT false,
+    status      text         NOT NULL DEFAULT 'pending',
+    scanner     text,
+    signature   text,
+    error       text,
+    attempts    integer      NOT NULL DEFAULT 0,
+    queued_at   timestamptz  NOT NULL DEFAULT now(),
+    scanned_at  timestamptz
+);
+
+CREATE INDEX content_scans_pending_idx ON dl.content_scans (queued_at) WHERE status = 'pending';
@@ -0,0 +1,3 @@
+ALTER TABLE dl.projects
+DROP COLUMN forbidden_paths,
+DROP COLUMN max_file_size;
@@ -0,0 +1,3 @@
+ALTER TABLE dl.projects
+ADD COLUMN max_file_size bigint,
+ADD COLUMN forbidden_paths text[] NOT NULL DEFAULT '{}';
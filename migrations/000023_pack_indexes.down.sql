@@ -0,0 +1,2 @@
+ALTER TABLE dl.contents
+DROP COLUMN pack_index;
@@ -0,0 +1,2 @@
+ALTER TABLE dl.contents
+ADD COLUMN pack_index bytea;
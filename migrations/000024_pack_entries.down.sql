@@ -0,0 +1 @@
+DROP TABLE dl.pack_entries;
@@ -0,0 +1,9 @@
+CREATE TABLE dl.pack_entries (
+    hash          hash    NOT NULL,
+    path          text    NOT NULL,
+    mode          bigint  NOT NULL,
+    size          bigint  NOT NULL,
+    content_hash  hash    NOT NULL
+);
+
+CREATE UNIQUE INDEX pack_entries_hash_path_idx ON dl.pack_entries (hash, path text_pattern_ops);
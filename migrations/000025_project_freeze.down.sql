@@ -0,0 +1,3 @@
+ALTER TABLE dl.projects
+DROP COLUMN frozen_reason,
+DROP COLUMN frozen;
@@ -0,0 +1,3 @@
+ALTER TABLE dl.projects
+ADD COLUMN frozen boolean NOT NULL DEFAULT false,
+ADD COLUMN frozen_reason text;
@@ -0,0 +1 @@
+DROP INDEX CONCURRENTLY IF EXISTS pack_entries_content_hash_idx;
@@ -0,0 +1 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS pack_entries_content_hash_idx ON dl.pack_entries (content_hash);
@@ -0,0 +1,3 @@
+DROP TABLE dl.content_reencodes;
+
+ALTER TABLE dl.contents DROP COLUMN encoding;
@@ -0,0 +1,11 @@
+ALTER TABLE dl.contents ADD COLUMN encoding smallint NOT NULL DEFAULT 0;
+
+CREATE TABLE dl.content_reencodes (
+    encoding     smallint     PRIMARY KEY,
+    cursor       hash,
+    scanned      bigint       NOT NULL DEFAULT 0,
+    reencoded    bigint       NOT NULL DEFAULT 0,
+    started_at   timestamptz  NOT NULL DEFAULT now(),
+    updated_at   timestamptz  NOT NULL DEFAULT now(),
+    finished_at  timestamptz
+);
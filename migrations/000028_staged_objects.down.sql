@@ -0,0 +1 @@
+DROP TABLE dl.staged_objects;
@@ -0,0 +1,15 @@
+CREATE TABLE dl.staged_objects (
+    project       bigint       NOT NULL,
+    stage         text         NOT NULL,
+    path          text         NOT NULL,
+    hash          hash         NOT NULL,
+    mode          bigint       NOT NULL,
+    size          bigint       NOT NULL,
+    deleted       boolean      NOT NULL,
+    content_type  text,
+    mtime         bigint,
+    staged_at     timestamptz  NOT NULL DEFAULT now(),
+    PRIMARY KEY (project, stage, path)
+);
+
+CREATE INDEX staged_objects_hash_idx ON dl.staged_objects (hash);
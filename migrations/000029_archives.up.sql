@@ -0,0 +1,16 @@
+CREATE TABLE dl.archives (
+    id             bigserial    PRIMARY KEY,
+    project        bigint       NOT NULL,
+    version        bigint       NOT NULL,
+    name           text         NOT NULL,
+    location       text         NOT NULL,
+    size           bigint       NOT NULL,
+    objects        bigint       NOT NULL,
+    pack_patterns  text[]       NOT NULL,
+    transforms     text[]       NOT NULL,
+    created_at     timestamptz  NOT NULL DEFAULT now(),
+    expires_at     timestamptz
+);
+
+CREATE INDEX archives_project_idx ON dl.archives (project, created_at);
+CREATE INDEX archives_expires_at_idx ON dl.archives (expires_at);
@@ -0,0 +1 @@
+ALTER TABLE dl.objects DROP COLUMN inline_content;
@@ -0,0 +1,2 @@
+-- decoded contents of small loose objects, stored in the row instead of dl.contents
+ALTER TABLE dl.objects ADD COLUMN inline_content bytea;
@@ -0,0 +1,13 @@
+DROP TRIGGER objects_updated_content_refs ON dl.objects;
+DROP TRIGGER objects_deleted_content_refs ON dl.objects;
+DROP TRIGGER objects_inserted_content_refs ON dl.objects;
+
+DROP FUNCTION dl.record_updated_content_refs();
+DROP FUNCTION dl.record_deleted_content_refs();
+DROP FUNCTION dl.record_inserted_content_refs();
+
+DROP TABLE dl.content_ref_deltas;
+
+DROP INDEX dl.contents_uncounted_refs_idx;
+
+ALTER TABLE dl.contents DROP COLUMN refs;
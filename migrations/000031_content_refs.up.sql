@@ -0,0 +1,72 @@
+-- number of dl.objects rows reading each content from dl.contents, NULL until the reconciler first counts it
+ALTER TABLE dl.contents ADD COLUMN refs bigint;
+ALTER TABLE dl.contents ALTER COLUMN refs SET DEFAULT 0;
+
+CREATE INDEX contents_uncounted_refs_idx ON dl.contents (hash) WHERE refs IS NULL;
+
+-- changes to the references, appended by the dl.objects triggers and folded into dl.contents.refs by the reconciler
+-- so concurrent updates never contend on the row of a shared content
+CREATE TABLE dl.content_ref_deltas (
+    id     bigserial  PRIMARY KEY,
+    hash   hash       NOT NULL,
+    delta  bigint     NOT NULL
+);
+
+CREATE INDEX content_ref_deltas_hash_idx ON dl.content_ref_deltas (hash);
+
+CREATE FUNCTION dl.record_inserted_content_refs() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO dl.content_ref_deltas (hash, delta)
+    SELECT hash, count(*)
+    FROM inserted_objects
+    WHERE inline_content IS NULL
+    GROUP BY hash;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION dl.record_deleted_content_refs() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO dl.content_ref_deltas (hash, delta)
+    SELECT hash, -count(*)
+    FROM deleted_objects
+    WHERE inline_content IS NULL
+    GROUP BY hash;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+-- most updates only set stop_version, they net out to no delta at all
+CREATE FUNCTION dl.record_updated_content_refs() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO dl.content_ref_deltas (hash, delta)
+    SELECT hash, sum(delta)
+    FROM (
+        SELECT hash, -1 AS delta
+        FROM old_objects
+        WHERE inline_content IS NULL
+        UNION ALL
+        SELECT hash, 1 AS delta
+        FROM new_objects
+        WHERE inline_content IS NULL
+    ) changes
+    GROUP BY hash
+    HAVING sum(delta) != 0;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER objects_inserted_content_refs
+    AFTER INSERT ON dl.objects
+    REFERENCING NEW TABLE AS inserted_objects
+    FOR EACH STATEMENT EXECUTE FUNCTION dl.record_inserted_content_refs();
+
+CREATE TRIGGER objects_deleted_content_refs
+    AFTER DELETE ON dl.objects
+    REFERENCING OLD TABLE AS deleted_objects
+    FOR EACH STATEMENT EXECUTE FUNCTION dl.record_deleted_content_refs();
+
+CREATE TRIGGER objects_updated_content_refs
+    AFTER UPDATE ON dl.objects
+    REFERENCING OLD TABLE AS old_objects NEW TABLE AS new_objects
+    FOR EACH STATEMENT EXECUTE FUNCTION dl.record_updated_content_refs();
@@ -0,0 +1 @@
+DROP TABLE dl.quarantined_contents;
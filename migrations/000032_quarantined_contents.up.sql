@@ -0,0 +1,10 @@
+-- contents taken out of dl.contents by the content audit, kept so they can be inspected or put back by hand with NULL
+-- refs for the reconciler to count them again
+CREATE TABLE dl.quarantined_contents (
+    hash            hash         PRIMARY KEY,
+    bytes           bytea        NOT NULL,
+    encoding        smallint     NOT NULL,
+    pack_index      bytea,
+    reason          text         NOT NULL,
+    quarantined_at  timestamptz  NOT NULL DEFAULT now()
+);
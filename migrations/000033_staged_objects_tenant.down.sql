@@ -0,0 +1,2 @@
+DROP POLICY staged_objects_tenant ON dl.staged_objects;
+ALTER TABLE dl.staged_objects DISABLE ROW LEVEL SECURITY;
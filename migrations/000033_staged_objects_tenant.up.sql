@@ -0,0 +1,3 @@
+ALTER TABLE dl.staged_objects ENABLE ROW LEVEL SECURITY;
+CREATE POLICY staged_objects_tenant ON dl.staged_objects TO dl_tenant
+    USING (EXISTS (SELECT 1 FROM dl.projects p WHERE p.id = staged_objects.project));
@@ -0,0 +1,5 @@
+DROP POLICY archives_tenant ON dl.archives;
+ALTER TABLE dl.archives DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY cache_version_projects_tenant ON dl.cache_version_projects;
+ALTER TABLE dl.cache_version_projects DISABLE ROW LEVEL SECURITY;
@@ -0,0 +1,10 @@
+-- Every table with a project column is visible when its project is, like the tables covered in 000018.
+-- Content addressed tables (contents, pack_entries, content_scans) are shared and deduplicated across tenants,
+-- they are only reached through the hashes of visible objects.
+ALTER TABLE dl.cache_version_projects ENABLE ROW LEVEL SECURITY;
+CREATE POLICY cache_version_projects_tenant ON dl.cache_version_projects TO dl_tenant
+    USING (EXISTS (SELECT 1 FROM dl.projects p WHERE p.id = cache_version_projects.project));
+
+ALTER TABLE dl.archives ENABLE ROW LEVEL SECURITY;
+CREATE POLICY archives_tenant ON dl.archives TO dl_tenant
+    USING (EXISTS (SELECT 1 FROM dl.projects p WHERE p.id = archives.project));
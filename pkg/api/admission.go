@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// dbLatencyWeight is how much each observation moves the smoothed database latency.
+const dbLatencyWeight = 0.3
+
+// AdmissionController sheds new Update streams while too many are in flight or the database is slow to respond,
+// so that clients back off early instead of piling up transactions that hold project locks until they time out.
+// A nil *AdmissionController admits everything.
+type AdmissionController struct {
+	// MaxInFlight is the number of concurrent Update streams above which new ones are rejected, 0 for no limit
+	MaxInFlight int64
+	// MaxDbLatency is the smoothed database latency above which new Update streams are rejected, 0 for no limit
+	MaxDbLatency time.Duration
+	// RetryAfter is the delay rejected clients are told to wait before retrying
+	RetryAfter time.Duration
+
+	inFlight atomic.Int64
+
+	mu        sync.Mutex
+	dbLatency time.Duration
+}
+
+// Admit reserves a slot for an Update stream, the returned release func must be called once the stream is done.
+// Rejections are RESOURCE_EXHAUSTED errors carrying the RetryAfter delay both as a RetryInfo detail and a retry-after header.
+func (a *AdmissionController) Admit(ctx context.Context) (func(), error) {
+	if a == nil {
+		return func() {}, nil
+	}
+
+	inFlight := a.inFlight.Add(1)
+	release := func() { a.inFlight.Add(-1) }
+
+	var reason string
+	if a.MaxInFlight > 0 && inFlight > a.MaxInFlight {
+		reason = fmt.Sprintf("%d updates in flight", inFlight-1)
+	} else if latency := a.DbLatency(); a.MaxDbLatency > 0 && latency > a.MaxDbLatency {
+		reason = fmt.Sprintf("database latency %v", latency.Round(time.Millisecond))
+	}
+
+	if reason == "" {
+		return release, nil
+	}
+	release()
+
+	logger.Warn(ctx, "FS.Update[Shed]", zap.String("reason", reason), zap.Duration("retry_after", a.RetryAfter))
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int((a.RetryAfter+time.Second-1)/time.Second))))
+
+	st := status.Newf(codes.ResourceExhausted, "FS update rejected under load (%s), retry after %v", reason, a.RetryAfter)
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(a.RetryAfter)})
+	if err == nil {
+		st = detailed
+	}
+
+	return nil, st.Err()
+}
+
+// ObserveDbLatency folds a measured database round trip into the smoothed latency.
+func (a *AdmissionController) ObserveDbLatency(latency time.Duration) {
+	if a == nil {
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.dbLatency == 0 {
+		a.dbLatency = latency
+		return
+	}
+	a.dbLatency = time.Duration(dbLatencyWeight*float64(latency) + (1-dbLatencyWeight)*float64(a.dbLatency))
+}
+
+func (a *AdmissionController) DbLatency() time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.dbLatency
+}
+
+func (a *AdmissionController) InFlight() int64 {
+	return a.inFlight.Load()
+}
+
+// MonitorDb measures the database latency every interval until ctx is done, so the latency keeps being tracked while every Update is shed.
+// A probe that fails or times out counts as taking the whole interval.
+func (a *AdmissionController) MonitorDb(ctx context.Context, dbConn db.DbConnector, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				probeCtx, cancel := context.WithTimeout(ctx, interval)
+				start := time.Now()
+				_, err := dbConn.Exec(probeCtx, "SELECT 1")
+				latency := time.Since(start)
+				cancel()
+
+				if err != nil {
+					latency = interval
+				}
+				a.ObserveDbLatency(latency)
+			}
+		}
+	}()
+}
@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/pkg/archive"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// requireArchiveAuth only lets admins of every tenant reach archives, they are kept for disaster recovery and outlive
+// the projects they were taken from.
+func requireArchiveAuth(ctx context.Context) error {
+	err := requireAdminAuth(ctx)
+	if err != nil {
+		return err
+	}
+
+	if tenantFromContext(ctx) != nil {
+		return status.Error(codes.PermissionDenied, "FS archives are not available to tenant scoped admins")
+	}
+
+	return nil
+}
+
+func (f *Fs) ListArchives(ctx context.Context, req *pb.ListArchivesRequest) (*pb.ListArchivesResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.GetProject()),
+	)
+
+	err := requireArchiveAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	archives, err := db.ListArchives(ctx, tx, req.Project)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS list archives: %v", err)
+	}
+
+	return &pb.ListArchivesResponse{Archives: archives}, nil
+}
+
+// RestoreArchive recreates the project version of an archive, with its pack patterns and transforms, in a project that
+// does not exist yet. Restoring into a new project lets the damaged one be inspected before it is replaced.
+func (f *Fs) RestoreArchive(ctx context.Context, req *pb.RestoreArchiveRequest) (*pb.RestoreArchiveResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Archive.Attribute(req.Archive),
+		key.Project.Attribute(req.GetProject()),
+	)
+
+	err := requireArchiveAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.ArchiveStore == nil {
+		return nil, status.Error(codes.FailedPrecondition, "FS restore archive: no archive store configured")
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	record, err := db.GetArchive(ctx, tx, req.Archive)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS restore archive: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS restore archive: %v", err)
+	}
+
+	project := record.Archive.Project
+	if req.Project != nil {
+		project = *req.Project
+	}
+
+	logger.Debug(ctx, "FS.RestoreArchive[Init]", key.Archive.Field(req.Archive), key.Project.Field(project), key.Location.Field(record.Archive.Location))
+
+	content, err := f.ArchiveStore.Get(ctx, record.Name)
+	if errors.Is(err, archive.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS restore archive %v: %v", req.Archive, err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS restore archive %v: %v", req.Archive, err)
+	}
+
+	objects, err := db.ReadArchive(content)
+	if err != nil {
+		return nil, status.Errorf(codes.DataLoss, "FS restore archive %v: %v", req.Archive, err)
+	}
+
+	err = db.CreateProject(ctx, tx, project, record.PackPatterns, record.Transforms)
+	if err != nil {
+		rpcErrorCode := codes.Internal
+		if err.Error() == "project id already exists" {
+			rpcErrorCode = codes.AlreadyExists
+		}
+		return nil, status.Errorf(rpcErrorCode, "FS restore archive %v into project %v: %v", req.Archive, project, err)
+	}
+
+	packManager, err := db.NewPackManager(ctx, tx, project)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS create packed cache: %v", err)
+	}
+
+	contentEncoder := f.newContentEncoder()
+	defer contentEncoder.Close()
+
+	version := int64(1)
+	packedBuffer := make(map[string][]*pb.Object)
+	for _, object := range objects {
+		packParent := packManager.IsPathPacked(object.Path)
+		if packParent != nil {
+			packedBuffer[*packParent] = append(packedBuffer[*packParent], object)
+			continue
+		}
+
+		_, err = db.UpdateObject(ctx, tx, f.DbConn, contentEncoder, project, version, object)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS restore archive %v: %v", req.Archive, err)
+		}
+	}
+
+	for parent, packedObjects := range packedBuffer {
+		_, err = db.UpdatePackedObjects(ctx, tx, f.DbConn, project, version, parent, packedObjects)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS restore archive %v packed objects for %v: %v", req.Archive, parent, err)
+		}
+	}
+
+	err = db.UpdateLatestVersion(ctx, tx, project, version)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS restore archive %v latest version: %v", req.Archive, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS restore archive commit tx: %v", err)
+	}
+	logger.Info(ctx, "FS.RestoreArchive[Commit]", key.Archive.Field(req.Archive), key.Project.Field(project), key.ObjectsCount.Field(len(objects)))
+
+	return &pb.RestoreArchiveResponse{Project: project, Version: version, Objects: int64(len(objects))}, nil
+}
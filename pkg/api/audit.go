@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultAuditLimit is the number of contents audited by a batch when the request does not say
+const defaultAuditLimit = 1000
+
+// AuditContents checks one batch of contents, and the objects reading them, for inconsistencies between dl.objects and
+// dl.contents. Clients walk every batch by passing back the returned cursor to audit the whole database.
+func (f *Fs) AuditContents(ctx context.Context, req *pb.AuditContentsRequest) (*pb.AuditContentsResponse, error) {
+	err := requireGlobalAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var after *db.Hash
+	if len(req.After) > 0 {
+		hash, err := db.HashFromBytes(req.After)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "FS audit contents invalid cursor: %v", err)
+		}
+		after = &hash
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.AuditContents[Init]", zap.Bool("repair", req.Repair), zap.Bool("quarantine", req.Quarantine))
+
+	contentEncoder := f.newContentEncoder()
+	defer contentEncoder.Close()
+
+	batch, err := db.AuditContents(ctx, tx, db.NewContentDecoder(), contentEncoder, after, limit, db.AuditOptions{
+		Repair:     req.Repair,
+		Quarantine: req.Quarantine,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS audit contents: %v", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS audit contents commit tx: %v", err)
+	}
+
+	response := &pb.AuditContentsResponse{
+		Contents: batch.Contents,
+		Objects:  batch.Objects,
+	}
+	if batch.Last != nil {
+		response.Next = batch.Last.Bytes()
+	}
+
+	for _, finding := range batch.Findings {
+		response.Findings = append(response.Findings, &pb.AuditFinding{
+			Kind:         finding.Kind,
+			Hash:         finding.Hash.Bytes(),
+			Project:      finding.Project,
+			Path:         finding.Path,
+			StartVersion: finding.StartVersion,
+			Expected:     finding.Expected,
+			Actual:       finding.Actual,
+			Action:       finding.Action,
+		})
+	}
+
+	// gc checks the orphans again, a content read since the audit is kept
+	response.Collected, err = db.GcContentHashes(ctx, f.DbConn, batch.Collect)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS audit contents gc: %v", err)
+	}
+
+	logger.Debug(ctx, "FS.AuditContents[Done]", key.Count.Field(batch.Contents), zap.Int("findings", len(batch.Findings)))
+
+	return response, nil
+}
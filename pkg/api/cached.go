@@ -29,6 +29,12 @@ const (
 	DriverName = "com.gadget.dateilager.cached"
 )
 
+// CachedTemplate is a project version layered on top of the cache, such as a framework skeleton every app starts from.
+type CachedTemplate struct {
+	Project int64
+	Version int64
+}
+
 type Cached struct {
 	pb.UnimplementedCachedServer
 	csi.UnimplementedIdentityServer
@@ -39,6 +45,9 @@ type Cached struct {
 	Client      *client.Client
 	StagingPath string
 
+	// Template is written into the writable root of volumes published with placeCacheAtPath, nil when none is configured
+	Template *CachedTemplate
+
 	// the current version of the cache on disk
 	currentVersion int64
 }
@@ -67,12 +76,29 @@ func (c *Cached) Prepare(ctx context.Context) error {
 		return err
 	}
 
-	c.currentVersion = version
-
 	logger.Info(ctx, "downloaded golden copy", key.DurationMS.Field(time.Since(start)), key.Version.Field(version), key.Count.Field(int64(count)))
+
+	if c.Template != nil {
+		start = time.Now()
+
+		// packed objects shared with the cache are hardlinked from the staging path rather than downloaded again
+		result, err := c.Client.Rebuild(ctx, c.Template.Project, "", &c.Template.Version, c.templatePath(), nil, c.StagingPath, nil, true)
+		if err != nil {
+			return fmt.Errorf("failed to download template project %d version %d: %w", c.Template.Project, c.Template.Version, err)
+		}
+
+		logger.Info(ctx, "downloaded template", key.DurationMS.Field(time.Since(start)), key.Project.Field(c.Template.Project), key.Version.Field(result.Version), key.Count.Field(int64(result.Count)))
+	}
+
+	c.currentVersion = version
 	return nil
 }
 
+// templatePath is where the template is staged, next to the cache so it is not part of the cache volumes
+func (c *Cached) templatePath() string {
+	return c.StagingPath + ".template"
+}
+
 // GetPluginInfo returns metadata of the plugin
 func (c *Cached) GetPluginInfo(ctx context.Context, req *csi.GetPluginInfoRequest) (*csi.GetPluginInfoResponse, error) {
 	resp := &csi.GetPluginInfoResponse{
@@ -167,6 +193,14 @@ func (c *Cached) NodePublishVolume(ctx context.Context, req *csi.NodePublishVolu
 		return nil, fmt.Errorf("failed to change ownership of target directory %s: %s", targetPath, err)
 	}
 
+	if c.Template != nil && cachePath != targetPath {
+		// the template is copied rather than hardlinked, pods write to their app files
+		err := files.CopyDir(c.templatePath(), targetPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy template to target directory %s: %s", targetPath, err)
+		}
+	}
+
 	version, err := c.writeCache(cachePath)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PutObject writes a single object in a new version, without the client streaming an Update. It is meant for editors
+// changing one file at a time, BaseVersion guards against overwriting a change made since the editor read the file.
+func (f *Fs) PutObject(ctx context.Context, req *pb.PutObjectRequest) (*pb.PutObjectResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.BaseVersion.Attribute(req.BaseVersion),
+	)
+
+	if req.Object == nil || req.Object.Deleted {
+		return nil, status.Error(codes.InvalidArgument, "Invalid PutObjectRequest: an object to write is required, use DeleteObject to delete it")
+	}
+
+	if req.Object.Size == 0 {
+		req.Object.Size = int64(len(req.Object.Content))
+	}
+
+	version, err := f.editObject(ctx, "PutObject", req.Project, req.Object, req.BaseVersion, req.Message, req.Author)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.PutObjectResponse{Version: version}, nil
+}
+
+// DeleteObject deletes a single path in a new version, see PutObject.
+func (f *Fs) DeleteObject(ctx context.Context, req *pb.DeleteObjectRequest) (*pb.DeleteObjectResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.BaseVersion.Attribute(req.BaseVersion),
+		key.ObjectPath.Attribute(req.Path),
+	)
+
+	version, err := f.editObject(ctx, "DeleteObject", req.Project, &pb.Object{Path: req.Path, Deleted: true}, req.BaseVersion, req.Message, req.Author)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteObjectResponse{Version: version}, nil
+}
+
+// editObject writes or deletes object in the version following the latest one and returns it. A write of the content
+// already stored at the path does not create a version, the latest one is returned.
+func (f *Fs) editObject(ctx context.Context, rpc string, reqProject int64, object *pb.Object, baseVersion *int64, message *string, author *string) (int64, error) {
+	project, err := requireProjectAuth(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	if project > -1 && reqProject != project {
+		return -1, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	}
+
+	err = pb.ValidatePath(object.Path)
+	if err != nil {
+		return -1, status.Errorf(codes.InvalidArgument, "Invalid %vRequest: %v", rpc, err)
+	}
+
+	err = pb.NormalizeObject(object)
+	if err != nil {
+		return -1, status.Errorf(codes.InvalidArgument, "Invalid %vRequest: %v", rpc, err)
+	}
+
+	release, err := f.UpdateAdmission.Admit(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer release()
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return -1, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS."+rpc+"[Init]", key.Project.Field(reqProject), key.ObjectPath.Field(object.Path))
+
+	latestVersion, err := db.LockWritableVersion(ctx, tx, reqProject)
+	if errors.Is(err, db.ErrNotFound) {
+		return -1, status.Errorf(codes.NotFound, "FS %v: %v", rpc, err)
+	}
+	if errors.Is(err, db.ErrProjectFrozen) {
+		return -1, status.Errorf(codes.FailedPrecondition, "FS %v: %v", rpc, err)
+	}
+	if err != nil {
+		return -1, status.Errorf(codes.Internal, "FS %v lock latest version: %v", rpc, err)
+	}
+
+	packManager, err := db.NewPackManager(ctx, tx, reqProject)
+	if err != nil {
+		return -1, status.Errorf(codes.Internal, "FS create packed cache: %v", err)
+	}
+
+	updatePolicy, err := f.projectPolicy(ctx, tx, reqProject)
+	if err != nil {
+		return -1, err
+	}
+
+	transforms, err := f.transformSet(ctx, tx, reqProject, true)
+	if err != nil {
+		return -1, err
+	}
+
+	err = f.resolveLfsPointer(ctx, tx, rpc, reqProject, transforms, object)
+	if err != nil {
+		return -1, err
+	}
+
+	violations := updatePolicy.Check(object.Path, max(object.Size, int64(len(object.Content))), object.Deleted)
+	if len(violations) > 0 {
+		return -1, policyViolationsError(reqProject, violations)
+	}
+
+	// a packed path is stored in its pack, any change to the pack since the base version is a conflict
+	storedPath := object.Path
+	packParent := packManager.IsPathPacked(object.Path)
+	if packParent != nil {
+		storedPath = *packParent
+	}
+
+	if baseVersion != nil {
+		if *baseVersion > latestVersion {
+			return -1, status.Errorf(codes.InvalidArgument, "Invalid %vRequest: base version %v is after the latest version %v", rpc, *baseVersion, latestVersion)
+		}
+
+		changed, err := db.PathChangedSince(ctx, tx, reqProject, storedPath, *baseVersion)
+		if err != nil {
+			return -1, status.Errorf(codes.Internal, "FS %v: %v", rpc, err)
+		}
+		if changed {
+			return -1, status.Errorf(codes.FailedPrecondition, "FS %v: %v changed since version %v", rpc, object.Path, *baseVersion)
+		}
+	}
+
+	if object.Deleted {
+		stats, err := db.StatPaths(ctx, tx, f.ContentLookup, packManager, reqProject, latestVersion, []string{object.Path})
+		if err != nil {
+			return -1, status.Errorf(codes.Internal, "FS %v: %v", rpc, err)
+		}
+		if !stats[0].Exists {
+			return -1, status.Errorf(codes.NotFound, "FS %v: %v does not exist at version %v", rpc, object.Path, latestVersion)
+		}
+	} else if f.DetectContentType && packParent == nil {
+		object.ContentType = detectContentType(object)
+	}
+
+	nextVersion := latestVersion + 1
+	changed := true
+
+	switch {
+	case packParent != nil:
+		changed, err = db.UpdatePackedObjects(ctx, tx, f.DbConn, reqProject, nextVersion, *packParent, []*pb.Object{object})
+	case object.Deleted:
+		err = db.DeleteObject(ctx, tx, reqProject, nextVersion, object.Path)
+	default:
+		contentEncoder := f.newContentEncoder()
+		defer contentEncoder.Close()
+
+		changed, err = db.UpdateObject(ctx, tx, f.DbConn, contentEncoder, reqProject, nextVersion, object)
+	}
+	if err != nil {
+		return -1, status.Errorf(codes.Internal, "FS %v: %v", rpc, err)
+	}
+
+	if !changed {
+		return latestVersion, nil
+	}
+
+	err = db.UpdateLatestVersion(ctx, tx, reqProject, nextVersion)
+	if err != nil {
+		return -1, status.Errorf(codes.Internal, "FS %v latest version: %v", rpc, err)
+	}
+
+	err = db.AnnotateVersion(ctx, tx, reqProject, nextVersion, message, author)
+	if err != nil {
+		return -1, status.Errorf(codes.Internal, "FS %v annotate version: %v", rpc, err)
+	}
+
+	if f.QueueContentScans {
+		err = db.QueueContentScans(ctx, tx, reqProject, nextVersion)
+		if err != nil {
+			return -1, status.Errorf(codes.Internal, "FS %v: %v", rpc, err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return -1, status.Errorf(codes.Internal, "FS %v commit tx: %v", rpc, err)
+	}
+	logger.Info(ctx, "FS."+rpc+"[Commit]", key.Project.Field(reqProject), key.Version.Field(nextVersion), key.ObjectPath.Field(object.Path))
+
+	f.precomputeCheckout(ctx, reqProject, nextVersion)
+
+	return nextVersion, nil
+}
@@ -1,10 +1,24 @@
 package api
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"hash"
 	"io"
+	"io/fs"
+	"net/http"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gadget-inc/dateilager/internal/auth"
 	"github.com/gadget-inc/dateilager/internal/db"
@@ -12,8 +26,15 @@ import (
 	"github.com/gadget-inc/dateilager/internal/key"
 	"github.com/gadget-inc/dateilager/internal/logger"
 	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/internal/policy"
 	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"github.com/gadget-inc/dateilager/internal/transform"
+	"github.com/gadget-inc/dateilager/pkg/archive"
+	"github.com/jackc/pgx/v5"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -32,6 +53,21 @@ func requireAdminAuth(ctx context.Context) error {
 	return status.Errorf(codes.PermissionDenied, "FS endpoint requires admin access")
 }
 
+// requireGlobalAdminAuth guards endpoints that reach across every project, which tenant scoped admins may not call.
+func requireGlobalAdminAuth(ctx context.Context) error {
+	ctxAuth := ctx.Value(auth.AuthCtxKey).(auth.Auth)
+
+	if ctxAuth.Role == auth.Admin && ctxAuth.Tenant == nil {
+		return nil
+	}
+
+	return status.Errorf(codes.PermissionDenied, "FS endpoint requires global admin access")
+}
+
+func tenantFromContext(ctx context.Context) *string {
+	return ctx.Value(auth.AuthCtxKey).(auth.Auth).Tenant
+}
+
 func requireProjectAuth(ctx context.Context) (int64, error) {
 	ctxAuth := ctx.Value(auth.AuthCtxKey).(auth.Auth)
 
@@ -70,6 +106,76 @@ type Fs struct {
 	Env           environment.Env
 	DbConn        db.DbConnector
 	ContentLookup *db.ContentLookup
+
+	// DetectContentType enables MIME type detection of updated objects
+	DetectContentType bool
+
+	// MaxContentSendSize is a hard cap on the size of object contents sent by read RPCs, 0 means unlimited
+	MaxContentSendSize int64
+
+	// PrecomputeCheckouts stores the full checkout of every committed version so it can be served without re-packing
+	PrecomputeCheckouts bool
+
+	// UpdateAdmission sheds Update streams under database pressure, nil to admit every update
+	UpdateAdmission *AdmissionController
+
+	// QueueContentScans queues the contents of every committed version for the content scanner
+	QueueContentScans bool
+
+	// ResponseCompressors lists, by preference, the gRPC compressors GetUnary, ListProjects and History responses may use
+	// when the client advertises them, responses are sent uncompressed when empty
+	ResponseCompressors []string
+
+	// DeletionGuard rejects updates removing too many of a project's objects unless they are forced, nil to accept them
+	DeletionGuard *policy.DeletionGuard
+
+	// ContentEncoding is the encoding new loose contents are stored in, contents stored in any known encoding are read
+	ContentEncoding db.ContentEncoding
+
+	// InlineContentThreshold is the size up to which new loose contents are stored in their object row, 0 disables inlining
+	InlineContentThreshold int64
+
+	// DirectoryRollups caches the directory sizes computed by DirectorySizes, nil to compute them on every call
+	DirectoryRollups *db.DirectoryRollups
+
+	// ArchiveStore holds the project archives exported by the server's archiver, nil disables RestoreArchive
+	ArchiveStore archive.Store
+}
+
+// newContentEncoder encodes the contents written by a request the way the server is configured to store them.
+func (f *Fs) newContentEncoder() *db.ContentEncoder {
+	return db.NewContentEncoderFor(f.ContentEncoding).WithInlineThreshold(f.InlineContentThreshold)
+}
+
+// compressResponse compresses the response of a unary call with the preferred compressor the client accepts.
+// Clients that do not advertise any of them, including older ones, keep getting uncompressed responses.
+func (f *Fs) compressResponse(ctx context.Context) {
+	if len(f.ResponseCompressors) == 0 {
+		return
+	}
+
+	accepted, err := grpc.ClientSupportedCompressors(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, name := range f.ResponseCompressors {
+		if slices.Contains(accepted, name) {
+			_ = grpc.SetSendCompressor(ctx, name)
+			return
+		}
+	}
+}
+
+// maxContentSendSize returns the effective content size limit of a read request, the server cap cannot be raised by clients.
+func (f *Fs) maxContentSendSize(requested *int64) int64 {
+	if requested == nil || *requested <= 0 {
+		return f.MaxContentSendSize
+	}
+	if f.MaxContentSendSize > 0 && f.MaxContentSendSize < *requested {
+		return f.MaxContentSendSize
+	}
+	return *requested
 }
 
 func (f *Fs) NewProject(ctx context.Context, req *pb.NewProjectRequest) (*pb.NewProjectResponse, error) {
@@ -77,6 +183,7 @@ func (f *Fs) NewProject(ctx context.Context, req *pb.NewProjectRequest) (*pb.New
 		key.Project.Attribute(req.Id),
 		key.Template.Attribute(req.Template),
 		key.PackPatterns.Attribute(req.PackPatterns),
+		key.Transforms.Attribute(req.Transforms),
 	)
 
 	err := requireAdminAuth(ctx)
@@ -84,6 +191,20 @@ func (f *Fs) NewProject(ctx context.Context, req *pb.NewProjectRequest) (*pb.New
 		return nil, err
 	}
 
+	_, err = transform.NewSet(req.Transforms)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "FS new project %v: %v", req.Id, err)
+	}
+
+	err = validateIdempotencyKey("NewProjectRequest", req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if tenant := tenantFromContext(ctx); tenant != nil && req.Tenant != nil && *req.Tenant != *tenant {
+		return nil, status.Errorf(codes.PermissionDenied, "FS new project %v: cannot create a project for another tenant", req.Id)
+	}
+
 	tx, close, err := f.DbConn.Connect(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
@@ -95,7 +216,18 @@ func (f *Fs) NewProject(ctx context.Context, req *pb.NewProjectRequest) (*pb.New
 		key.Template.Field(req.Template),
 	)
 
-	err = db.CreateProject(ctx, tx, req.Id, req.PackPatterns)
+	if req.IdempotencyKey != nil {
+		_, found, err := db.LookupIdempotencyKey(ctx, tx, req.Id, *req.IdempotencyKey)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS new project %v: %v", req.Id, err)
+		}
+		if found {
+			logger.Info(ctx, "FS.NewProject[Replayed]", key.Project.Field(req.Id))
+			return &pb.NewProjectResponse{}, nil
+		}
+	}
+
+	err = db.CreateProject(ctx, tx, req.Id, req.PackPatterns, req.Transforms)
 	if err != nil {
 		rpcErrorCode := codes.Internal
 		if err.Error() == "project id already exists" {
@@ -104,11 +236,37 @@ func (f *Fs) NewProject(ctx context.Context, req *pb.NewProjectRequest) (*pb.New
 		return nil, status.Errorf(rpcErrorCode, "FS new project %v, %v", req.Id, err)
 	}
 
+	if req.IncludeInCache != nil {
+		err = db.SetProjectCacheInclusion(ctx, tx, req.Id, *req.IncludeInCache)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS new project %v: %v", req.Id, err)
+		}
+	}
+
+	if req.Tenant != nil {
+		err = db.SetProjectTenant(ctx, tx, req.Id, *req.Tenant)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS new project %v: %v", req.Id, err)
+		}
+	}
+
 	if req.Template != nil {
 		err = db.CopyAllObjects(ctx, tx, *req.Template, req.Id)
 		if err != nil {
 			return nil, status.Errorf(codes.Internal, "FS new project copy from template %v to %v, %v", req.Template, req.Id, err)
 		}
+
+		err = db.SetProjectTemplate(ctx, tx, req.Id, *req.Template)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS new project %v: %v", req.Id, err)
+		}
+	}
+
+	if req.IdempotencyKey != nil {
+		err = db.SaveIdempotencyKey(ctx, tx, req.Id, *req.IdempotencyKey, 0)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS new project %v: %v", req.Id, err)
+		}
 	}
 
 	err = tx.Commit(ctx)
@@ -142,6 +300,11 @@ func (f *Fs) CloneToProject(ctx context.Context, req *pb.CloneToProjectRequest)
 	}
 	defer close(ctx)
 
+	err = validateIdempotencyKey("CloneToProjectRequest", req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
 	logger.Debug(ctx, "FS.CloneToProject[Init]", key.Project.Field(req.Source))
 
 	samePackPatterns, err := db.HasSamePackPattern(ctx, tx, req.Source, req.Target)
@@ -153,11 +316,27 @@ func (f *Fs) CloneToProject(ctx context.Context, req *pb.CloneToProjectRequest)
 		return nil, status.Errorf(codes.InvalidArgument, "FS projects have different pack patterns: %v", err)
 	}
 
-	latestVersion, err := db.LockLatestVersion(ctx, tx, req.Target)
+	latestVersion, err := db.LockWritableVersion(ctx, tx, req.Target)
+	if errors.Is(err, db.ErrProjectFrozen) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS copy to project: %v", err)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "FS copy to project could not lock target (%d) version: %v", req.Target, err)
 	}
 
+	if req.IdempotencyKey != nil {
+		version, found, err := db.LookupIdempotencyKey(ctx, tx, req.Target, *req.IdempotencyKey)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS copy to project (%d): %v", req.Target, err)
+		}
+		if found {
+			logger.Info(ctx, "FS.CloneToProject[Replayed]", key.CloneToProject.Field(req.Target), key.Version.Field(version))
+			return &pb.CloneToProjectResponse{
+				LatestVersion: version,
+			}, nil
+		}
+	}
+
 	newVersion := latestVersion + 1
 
 	logger.Debug(ctx, "FS.CloneToProject[Query]", key.Project.Field(req.Source), key.CloneToProject.Field(req.Target), key.Version.Field(req.Version))
@@ -172,6 +351,18 @@ func (f *Fs) CloneToProject(ctx context.Context, req *pb.CloneToProjectRequest)
 		return nil, status.Errorf(codes.Internal, "FS copy to project could not update target (%d) to latest version (%d): %v", req.Target, newVersion, err)
 	}
 
+	err = db.AnnotateVersion(ctx, tx, req.Target, newVersion, req.Message, req.Author)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS copy to project could not annotate target (%d) version (%d): %v", req.Target, newVersion, err)
+	}
+
+	if req.IdempotencyKey != nil {
+		err = db.SaveIdempotencyKey(ctx, tx, req.Target, *req.IdempotencyKey, newVersion)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS copy to project (%d): %v", req.Target, err)
+		}
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "FS clone commit tx: %v", err)
@@ -220,15 +411,17 @@ func (f *Fs) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb
 		return nil, err
 	}
 
+	f.compressResponse(ctx)
+
 	tx, close, err := f.DbConn.Connect(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
 	}
 	defer close(ctx)
 
-	logger.Debug(ctx, "FS.ListProjects[Query]")
+	logger.Debug(ctx, "FS.ListProjects[Query]", zap.Any("selector", req.LabelSelector))
 
-	projects, err := db.ListProjects(ctx, tx)
+	projects, err := db.ListProjects(ctx, tx, req.LabelSelector)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "FS snapshot: %v", err)
 	}
@@ -238,203 +431,135 @@ func (f *Fs) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb
 	}, nil
 }
 
-func validateObjectQuery(query *pb.ObjectQuery) error {
-	if !query.IsPrefix && len(query.Ignores) > 0 {
-		return status.Error(codes.InvalidArgument, "Invalid ObjectQuery: cannot mix unprefixed queries with ignore predicates")
-	}
-
-	for _, ignore := range query.Ignores {
-		if !strings.HasPrefix(ignore, query.Path) {
-			return status.Errorf(codes.InvalidArgument, "Invalid ObjectQuery: ignore pattern (%v) must fully include the path predicate (%v)", ignore, query.Path)
-		}
-	}
-
-	return nil
-}
-
-func (f *Fs) Get(req *pb.GetRequest, stream pb.Fs_GetServer) error {
-	ctx := stream.Context()
+func (f *Fs) SetProjectLabels(ctx context.Context, req *pb.SetProjectLabelsRequest) (*pb.SetProjectLabelsResponse, error) {
 	trace.SpanFromContext(ctx).SetAttributes(
 		key.Project.Attribute(req.Project),
-		key.FromVersion.Attribute(req.FromVersion),
-		key.ToVersion.Attribute(req.ToVersion),
 	)
 
-	project, err := requireProjectAuth(ctx)
+	err := requireAdminAuth(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if project > -1 && req.Project != project {
-		return status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	for label := range req.Labels {
+		if label == "" {
+			return nil, status.Error(codes.InvalidArgument, "Invalid SetProjectLabelsRequest: label keys cannot be empty")
+		}
 	}
 
 	tx, close, err := f.DbConn.Connect(ctx)
 	if err != nil {
-		return status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
 	}
 	defer close(ctx)
 
-	vrange, err := db.NewVersionRange(ctx, tx, req.Project, req.FromVersion, req.ToVersion)
+	logger.Debug(ctx, "FS.SetProjectLabels[Init]", key.Project.Field(req.Project), zap.Any("labels", req.Labels), zap.Strings("remove", req.Remove))
+
+	labels, err := db.SetProjectLabels(ctx, tx, req.Project, req.Labels, req.Remove)
 	if errors.Is(err, db.ErrNotFound) {
-		return status.Errorf(codes.NotFound, "FS get missing latest version: %v", err)
+		return nil, status.Errorf(codes.NotFound, "FS set project labels: %v", err)
 	}
 	if err != nil {
-		return status.Errorf(codes.Internal, "FS get latest version: %v", err)
+		return nil, status.Errorf(codes.Internal, "FS set project labels: %v", err)
 	}
 
-	logger.Debug(ctx, "FS.Get[Init]",
-		key.Project.Field(req.Project),
-		key.FromVersion.Field(&vrange.From),
-		key.ToVersion.Field(&vrange.To),
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS set project labels commit tx: %v", err)
+	}
+	logger.Debug(ctx, "FS.SetProjectLabels[Commit]", key.Project.Field(req.Project))
+
+	return &pb.SetProjectLabelsResponse{
+		Labels: labels,
+	}, nil
+}
+
+func (f *Fs) SetProjectCacheInclusion(ctx context.Context, req *pb.SetProjectCacheInclusionRequest) (*pb.SetProjectCacheInclusionResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.IncludeInCache.Attribute(req.Include),
 	)
 
-	packManager, err := db.NewPackManager(ctx, tx, req.Project)
+	err := requireAdminAuth(ctx)
 	if err != nil {
-		return status.Errorf(codes.Internal, "FS create packed cache: %v", err)
+		return nil, err
 	}
 
-	for _, query := range req.Queries {
-		err = validateObjectQuery(query)
-		if err != nil {
-			return err
-		}
-
-		logger.Info(ctx, "FS.Get[Query]",
-			key.Project.Field(req.Project),
-			key.FromVersion.Field(&vrange.From),
-			key.ToVersion.Field(&vrange.To),
-			key.QueryPath.Field(query.Path),
-			key.QueryIsPrefix.Field(query.IsPrefix),
-			key.QueryIgnores.Field(query.Ignores),
-		)
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
 
-		objects, err := db.GetObjects(ctx, tx, f.ContentLookup, packManager, req.Project, vrange, query)
-		if err != nil {
-			return status.Errorf(codes.Internal, "FS get objects: %v", err)
-		}
+	logger.Debug(ctx, "FS.SetProjectCacheInclusion[Init]", key.Project.Field(req.Project), key.IncludeInCache.Field(req.Include))
 
-		for {
-			object, err := objects()
-			if err == db.SKIP {
-				continue
-			}
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				return status.Errorf(codes.Internal, "FS get next object: %v", err)
-			}
+	err = db.SetProjectCacheInclusion(ctx, tx, req.Project, req.Include)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS set project cache inclusion: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS set project cache inclusion: %v", err)
+	}
 
-			err = stream.Send(&pb.GetResponse{Version: vrange.To, Object: object})
-			if err != nil {
-				return status.Errorf(codes.Internal, "FS send GetResponse: %v", err)
-			}
-		}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS set project cache inclusion commit tx: %v", err)
 	}
+	logger.Debug(ctx, "FS.SetProjectCacheInclusion[Commit]", key.Project.Field(req.Project))
 
-	return nil
+	return &pb.SetProjectCacheInclusionResponse{}, nil
 }
 
-func (f *Fs) GetCompress(req *pb.GetCompressRequest, stream pb.Fs_GetCompressServer) error {
-	ctx := stream.Context()
+func (f *Fs) SetProjectFrozen(ctx context.Context, req *pb.SetProjectFrozenRequest) (*pb.SetProjectFrozenResponse, error) {
 	trace.SpanFromContext(ctx).SetAttributes(
 		key.Project.Attribute(req.Project),
-		key.FromVersion.Attribute(req.FromVersion),
-		key.ToVersion.Attribute(req.ToVersion),
+		key.Frozen.Attribute(req.Frozen),
 	)
 
-	project, err := requireProjectAuth(ctx)
+	err := requireAdminAuth(ctx)
 	if err != nil {
-		return err
-	}
-
-	if project > -1 && req.Project != project {
-		return status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+		return nil, err
 	}
 
 	tx, close, err := f.DbConn.Connect(ctx)
 	if err != nil {
-		return status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
 	}
 	defer close(ctx)
 
-	vrange, err := db.NewVersionRange(ctx, tx, req.Project, req.FromVersion, req.ToVersion)
+	logger.Debug(ctx, "FS.SetProjectFrozen[Init]", key.Project.Field(req.Project), key.Frozen.Field(req.Frozen))
+
+	err = db.SetProjectFrozen(ctx, tx, req.Project, req.Frozen, req.Reason)
 	if errors.Is(err, db.ErrNotFound) {
-		return status.Errorf(codes.NotFound, "FS get compress missing latest version: %v", err)
+		return nil, status.Errorf(codes.NotFound, "FS set project frozen: %v", err)
 	}
 	if err != nil {
-		return status.Errorf(codes.Internal, "FS get compress latest version: %v", err)
+		return nil, status.Errorf(codes.Internal, "FS set project frozen: %v", err)
 	}
 
-	logger.Debug(ctx, "FS.GetCompress[Init]",
-		key.Project.Field(req.Project),
-		key.FromVersion.Field(&vrange.From),
-		key.ToVersion.Field(&vrange.To),
-		key.CacheVersions.Field(req.AvailableCacheVersions),
-	)
-
-	for _, query := range req.Queries {
-		err = validateObjectQuery(query)
-		if err != nil {
-			return err
-		}
-
-		logger.Info(ctx, "FS.GetCompress[Query]",
-			key.Project.Field(req.Project),
-			key.FromVersion.Field(&vrange.From),
-			key.ToVersion.Field(&vrange.To),
-			key.QueryPath.Field(query.Path),
-			key.QueryIsPrefix.Field(query.IsPrefix),
-			key.QueryIgnores.Field(query.Ignores),
-		)
-
-		tars, err := db.GetTars(ctx, tx, f.ContentLookup, req.Project, req.AvailableCacheVersions, vrange, query)
-		if err != nil {
-			return status.Errorf(codes.Internal, "FS get tars: %v", err)
-		}
-
-		for {
-			tar, packPath, err := tars()
-			if err == io.EOF {
-				break
-			}
-			if err == db.SKIP {
-				continue
-			}
-			if err != nil {
-				return status.Errorf(codes.Internal, "FS get next tar: %v", err)
-			}
-
-			err = stream.Send(&pb.GetCompressResponse{
-				Version:  vrange.To,
-				Format:   pb.GetCompressResponse_S2_TAR,
-				Bytes:    tar,
-				PackPath: packPath,
-			})
-			if err != nil {
-				return status.Errorf(codes.Internal, "FS send GetCompressResponse: %v", err)
-			}
-		}
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS set project frozen commit tx: %v", err)
 	}
+	logger.Info(ctx, "FS.SetProjectFrozen[Commit]", key.Project.Field(req.Project), key.Frozen.Field(req.Frozen))
 
-	return nil
+	return &pb.SetProjectFrozenResponse{}, nil
 }
 
-func (f *Fs) GetUnary(ctx context.Context, req *pb.GetUnaryRequest) (*pb.GetUnaryResponse, error) {
+func (f *Fs) SetProjectTransforms(ctx context.Context, req *pb.SetProjectTransformsRequest) (*pb.SetProjectTransformsResponse, error) {
 	trace.SpanFromContext(ctx).SetAttributes(
 		key.Project.Attribute(req.Project),
-		key.FromVersion.Attribute(req.FromVersion),
-		key.ToVersion.Attribute(req.ToVersion),
+		key.Transforms.Attribute(req.Transforms),
 	)
 
-	project, err := requireProjectAuth(ctx)
+	err := requireAdminAuth(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if project > -1 && req.Project != project {
-		return nil, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	_, err = transform.NewSet(req.Transforms)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "FS set project transforms %v: %v", req.Project, err)
 	}
 
 	tx, close, err := f.DbConn.Connect(ctx)
@@ -443,338 +568,3033 @@ func (f *Fs) GetUnary(ctx context.Context, req *pb.GetUnaryRequest) (*pb.GetUnar
 	}
 	defer close(ctx)
 
-	vrange, err := db.NewVersionRange(ctx, tx, req.Project, req.FromVersion, req.ToVersion)
+	logger.Debug(ctx, "FS.SetProjectTransforms[Init]", key.Project.Field(req.Project), key.Transforms.Field(req.Transforms))
+
+	err = db.SetProjectTransforms(ctx, tx, req.Project, req.Transforms)
 	if errors.Is(err, db.ErrNotFound) {
-		return nil, status.Errorf(codes.NotFound, "FS get missing latest version: %v", err)
+		return nil, status.Errorf(codes.NotFound, "FS set project transforms: %v", err)
 	}
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS get latest version: %v", err)
+		return nil, status.Errorf(codes.Internal, "FS set project transforms: %v", err)
 	}
 
-	logger.Debug(ctx, "FS.GetUnary[Init]",
-		key.Project.Field(req.Project),
-		key.FromVersion.Field(&vrange.From),
-		key.ToVersion.Field(&vrange.To),
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS set project transforms commit tx: %v", err)
+	}
+	logger.Info(ctx, "FS.SetProjectTransforms[Commit]", key.Project.Field(req.Project), key.Transforms.Field(req.Transforms))
+
+	return &pb.SetProjectTransformsResponse{}, nil
+}
+
+func (f *Fs) SetProjectPolicy(ctx context.Context, req *pb.SetProjectPolicyRequest) (*pb.SetProjectPolicyResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
 	)
 
-	packManager, err := db.NewPackManager(ctx, tx, req.Project)
+	err := requireAdminAuth(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS create packed cache: %v", err)
+		return nil, err
 	}
 
-	var response pb.GetUnaryResponse
+	_, err = policy.New(req.Policy)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "FS set project policy: %v", err)
+	}
 
-	for _, query := range req.Queries {
-		err = validateObjectQuery(query)
-		if err != nil {
-			return nil, err
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.SetProjectPolicy[Init]", key.Project.Field(req.Project))
+
+	err = db.SetProjectPolicy(ctx, tx, req.Project, req.Policy)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS set project policy: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS set project policy: %v", err)
+	}
+
+	updated, err := db.ProjectPolicy(ctx, tx, req.Project)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS set project policy: %v", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS set project policy commit tx: %v", err)
+	}
+	logger.Debug(ctx, "FS.SetProjectPolicy[Commit]", key.Project.Field(req.Project))
+
+	return &pb.SetProjectPolicyResponse{Policy: updated}, nil
+}
+
+func (f *Fs) GetProjectPolicy(ctx context.Context, req *pb.GetProjectPolicyRequest) (*pb.GetProjectPolicyResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+	)
+
+	project, err := requireProjectAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if project > -1 && req.Project != project {
+		return nil, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.GetProjectPolicy[Query]", key.Project.Field(req.Project))
+
+	projectPolicy, err := db.ProjectPolicy(ctx, tx, req.Project)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS get project policy: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS get project policy: %v", err)
+	}
+
+	return &pb.GetProjectPolicyResponse{Policy: projectPolicy}, nil
+}
+
+func (f *Fs) GetProjectLabels(ctx context.Context, req *pb.GetProjectLabelsRequest) (*pb.GetProjectLabelsResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+	)
+
+	err := requireAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.GetProjectLabels[Query]", key.Project.Field(req.Project))
+
+	labels, err := db.GetProjectLabels(ctx, tx, req.Project)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS get project labels: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS get project labels: %v", err)
+	}
+
+	return &pb.GetProjectLabelsResponse{
+		Labels: labels,
+	}, nil
+}
+
+// pathsBatchSize bounds the number of explicit paths looked up by a single database query
+const pathsBatchSize = 500
+
+func validateObjectQuery(query *pb.ObjectQuery) error {
+	if len(query.Paths) > 0 && (query.Path != "" || query.IsPrefix || len(query.Ignores) > 0) {
+		return status.Error(codes.InvalidArgument, "Invalid ObjectQuery: cannot mix explicit paths with path, prefix or ignore predicates")
+	}
+
+	if !query.IsPrefix && len(query.Ignores) > 0 {
+		return status.Error(codes.InvalidArgument, "Invalid ObjectQuery: cannot mix unprefixed queries with ignore predicates")
+	}
+
+	for _, ignore := range query.Ignores {
+		if !strings.HasPrefix(ignore, query.Path) {
+			return status.Errorf(codes.InvalidArgument, "Invalid ObjectQuery: ignore pattern (%v) must fully include the path predicate (%v)", ignore, query.Path)
 		}
+	}
 
-		logger.Info(ctx, "FS.GetUnary[Query]",
-			key.Project.Field(req.Project),
-			key.FromVersion.Field(&vrange.From),
-			key.ToVersion.Field(&vrange.To),
-			key.QueryPath.Field(query.Path),
-			key.QueryIsPrefix.Field(query.IsPrefix),
-			key.QueryIgnores.Field(query.Ignores),
-		)
+	return nil
+}
 
-		objects, err := db.GetObjects(ctx, tx, f.ContentLookup, packManager, req.Project, vrange, query)
+func validateKnownHashes(request string, hashes [][]byte) error {
+	if len(hashes) > db.MaxKnownHashes {
+		return status.Errorf(codes.InvalidArgument, "Invalid %v: too many known hashes (%v > %v)", request, len(hashes), db.MaxKnownHashes)
+	}
+
+	for _, hash := range hashes {
+		_, err := db.HashFromBytes(hash)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "FS get objects: %v", err)
+			return status.Errorf(codes.InvalidArgument, "Invalid %v: known hash %x: %v", request, hash, err)
 		}
+	}
 
-		for {
-			object, err := objects()
-			if err == db.SKIP {
-				continue
-			}
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				return nil, status.Errorf(codes.Internal, "FS get next object: %v", err)
-			}
+	return nil
+}
 
-			response.Version = vrange.To
-			response.Objects = append(response.Objects, object)
+// maxIdempotencyKeyLength bounds the size of client provided idempotency keys
+const maxIdempotencyKeyLength = 256
+
+func validateIdempotencyKey(request string, idempotencyKey *string) error {
+	if idempotencyKey == nil {
+		return nil
+	}
+
+	if *idempotencyKey == "" || len(*idempotencyKey) > maxIdempotencyKeyLength {
+		return status.Errorf(codes.InvalidArgument, "Invalid %v: idempotency key must be between 1 and %v bytes", request, maxIdempotencyKeyLength)
+	}
+
+	return nil
+}
+
+// batchQueries splits queries with many explicit paths into multiple smaller queries.
+func batchQueries(queries []*pb.ObjectQuery) []*pb.ObjectQuery {
+	var batched []*pb.ObjectQuery
+
+	for _, query := range queries {
+		if len(query.Paths) <= pathsBatchSize {
+			batched = append(batched, query)
+			continue
+		}
+
+		for start := 0; start < len(query.Paths); start += pathsBatchSize {
+			end := min(start+pathsBatchSize, len(query.Paths))
+			batched = append(batched, &pb.ObjectQuery{Paths: query.Paths[start:end]})
 		}
 	}
 
-	return &response, nil
+	return batched
 }
 
-func (f *Fs) Update(stream pb.Fs_UpdateServer) error {
+// transformSet loads the project's configured transformations, it returns nil when the request did not ask for them.
+func (f *Fs) transformSet(ctx context.Context, tx pgx.Tx, project int64, enabled bool) (*transform.Set, error) {
+	if !enabled {
+		return nil, nil
+	}
+
+	specs, err := db.ProjectTransforms(ctx, tx, project)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS load project transforms: %v", err)
+	}
+
+	transforms, err := transform.NewSet(specs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS parse project transforms: %v", err)
+	}
+
+	if transforms.Empty() {
+		return nil, nil
+	}
+
+	return transforms, nil
+}
+
+// projectPolicy loads and compiles the policy updates of project must follow.
+func (f *Fs) projectPolicy(ctx context.Context, tx pgx.Tx, project int64) (*policy.Policy, error) {
+	spec, err := db.ProjectPolicy(ctx, tx, project)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS load project policy: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS load project policy: %v", err)
+	}
+
+	compiled, err := policy.New(spec)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS parse project policy: %v", err)
+	}
+
+	return compiled, nil
+}
+
+// maxReportedViolations bounds the violations detailed in a rejected update so the error stays small
+const maxReportedViolations = 100
+
+// policyViolationsError rejects an update breaking its project's policy, the PreconditionFailure details list the violations.
+func policyViolationsError(project int64, violations []policy.Violation) error {
+	st := status.Newf(codes.FailedPrecondition, "FS update: %d object(s) violate the policy of project %v, first: %v %v",
+		len(violations), project, violations[0].Path, violations[0].Description)
+
+	failure := &errdetails.PreconditionFailure{}
+	for _, violation := range violations[:min(len(violations), maxReportedViolations)] {
+		failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        violation.Type,
+			Subject:     violation.Path,
+			Description: violation.Description,
+		})
+	}
+
+	detailed, err := st.WithDetails(failure)
+	if err == nil {
+		st = detailed
+	}
+
+	return st.Err()
+}
+
+// massDeletionError rejects an update flagged by the deletion guard, the violation is detailed like policy violations.
+func massDeletionError(project int64, violation *policy.Violation) error {
+	st := status.Newf(codes.FailedPrecondition, "FS update of project %v %v", project, violation.Description)
+
+	detailed, err := st.WithDetails(&errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{{
+			Type:        violation.Type,
+			Subject:     violation.Path,
+			Description: violation.Description,
+		}},
+	})
+	if err == nil {
+		st = detailed
+	}
+
+	return st.Err()
+}
+
+// invalidPathsError rejects an update sending paths that cannot be stored, every path is detailed as a field violation
+// whose description starts with the reason the path was rejected for.
+func invalidPathsError(paths []*pb.PathError) error {
+	st := status.Newf(codes.InvalidArgument, "FS update: %d object(s) have an invalid path, first: %v", len(paths), paths[0])
+
+	badRequest := &errdetails.BadRequest{}
+	for _, path := range paths[:min(len(paths), maxReportedViolations)] {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       "object.path",
+			Description: fmt.Sprintf("%v: %v", path.Reason, path.Error()),
+		})
+	}
+
+	detailed, err := st.WithDetails(badRequest)
+	if err == nil {
+		st = detailed
+	}
+
+	return st.Err()
+}
+
+// resolveLfsPointer replaces a git-lfs pointer sent for a path the project serves as a pointer by the content it
+// references, so checkouts pushed back from git keep their real bytes. Only contents the project already stores in
+// loose files are resolved, pointers to any other content are rejected and the real bytes must be sent instead.
+func (f *Fs) resolveLfsPointer(ctx context.Context, tx pgx.Tx, rpc string, project int64, transforms *transform.Set, object *pb.Object) error {
+	if transforms == nil {
+		return nil
+	}
+
+	oid, size, ok := transforms.LfsPointer(object)
+	if !ok {
+		return nil
+	}
+
+	hash, err := db.HashFromBytes(oid)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "FS %v: git-lfs pointer at %v: %v", rpc, object.Path, err)
+	}
+
+	content, err := f.ContentLookup.ProjectContent(ctx, tx, project, hash)
+	if errors.Is(err, db.ErrNotFound) || (err == nil && int64(len(content)) != size) {
+		return status.Errorf(codes.FailedPrecondition, "FS %v: %v is a git-lfs pointer to content project %v does not store, its content must be sent", rpc, object.Path, project)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "FS %v: resolve git-lfs pointer at %v: %v", rpc, object.Path, err)
+	}
+
+	object.Content = content
+	object.Size = size
+	return nil
+}
+
+func (f *Fs) Get(req *pb.GetRequest, stream pb.Fs_GetServer) error {
 	ctx := stream.Context()
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.FromVersion.Attribute(req.FromVersion),
+		key.ToVersion.Attribute(req.ToVersion),
+	)
 
 	project, err := requireProjectAuth(ctx)
 	if err != nil {
 		return err
 	}
 
+	if project > -1 && req.Project != project {
+		return status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	}
+
+	toVersion, err := pinToSnapshot("GetRequest", req.Project, req.SnapshotToken, req.ToVersion)
+	if err != nil {
+		return err
+	}
+
+	resume, toVersion, err := pinToResumeCursor(req.Project, req.ResumeCursor, req.FromVersion, toVersion)
+	if err != nil {
+		return err
+	}
+
 	tx, close, err := f.DbConn.Connect(ctx)
 	if err != nil {
 		return status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
 	}
 	defer close(ctx)
 
-	contentEncoder := db.NewContentEncoder()
-	defer contentEncoder.Close()
+	vrange, err := db.NewVersionRange(ctx, tx, req.Project, req.FromVersion, toVersion)
+	if errors.Is(err, db.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "FS get missing latest version: %v", err)
+	}
+	if errors.Is(err, db.ErrVersionSquashed) {
+		return status.Errorf(codes.FailedPrecondition, "FS get: %v", err)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "FS get latest version: %v", err)
+	}
 
-	var packManager *db.PackManager
+	maxContentSize := f.maxContentSendSize(req.MaxContentSendSize)
 
-	var objectBuffer []*pb.Object
-	packedBuffer := make(map[string][]*pb.Object)
+	logger.Debug(ctx, "FS.Get[Init]",
+		key.Project.Field(req.Project),
+		key.FromVersion.Field(&vrange.From),
+		key.ToVersion.Field(&vrange.To),
+		key.MaxContentSize.Field(maxContentSize),
+	)
 
-	err = telemetry.Trace(ctx, "receive-update-objects", func(ctx context.Context, span trace.Span) error {
-		for {
-			req, err := stream.Recv()
-			if err == io.EOF {
-				break
-			}
-			if err != nil {
-				errStatus, _ := status.FromError(err)
-				return status.Errorf(errStatus.Code(), "FS receive update request: %v", err)
-			}
+	packManager, err := db.NewPackManager(ctx, tx, req.Project)
+	if err != nil {
+		return status.Errorf(codes.Internal, "FS create packed cache: %v", err)
+	}
 
-			// project can be -1 if the client authenticated using an Admin token
-			if project == -1 {
-				project = req.Project
-			}
-			if project != req.Project {
-				return status.Errorf(codes.InvalidArgument, "initial project %v, next project %v: %v", project, req.Project, ErrMultipleProjectsPerUpdate)
+	transforms, err := f.transformSet(ctx, tx, req.Project, req.Transform)
+	if err != nil {
+		return err
+	}
+
+	for idx, query := range batchQueries(req.Queries) {
+		err = validateObjectQuery(query)
+		if err != nil {
+			return err
+		}
+
+		var after *db.ResumePoint
+		if resume != nil {
+			if idx < resume.query {
+				continue
 			}
+			if idx == resume.query {
+				after = &resume.after
+			}
+		}
 
-			// We can only create the pack manager once we have the project ID and that requires a least one stream message
+		logger.Info(ctx, "FS.Get[Query]",
+			key.Project.Field(req.Project),
+			key.FromVersion.Field(&vrange.From),
+			key.ToVersion.Field(&vrange.To),
+			key.QueryPath.Field(query.Path),
+			key.QueryIsPrefix.Field(query.IsPrefix),
+			key.QueryIgnores.Field(query.Ignores),
+		)
+
+		objects, err := db.GetObjectsAfter(ctx, tx, f.ContentLookup, packManager, req.Project, vrange, query, maxContentSize, after)
+		if err != nil {
+			return status.Errorf(codes.Internal, "FS get objects: %v", err)
+		}
+
+		for {
+			object, err := objects()
+			if err == db.SKIP {
+				continue
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return status.Errorf(codes.Internal, "FS get next object: %v", err)
+			}
+
+			// the cursor points at the stored object, transformations may rename it
+			cursor := resumeCursor{
+				project: req.Project,
+				vrange:  vrange,
+				query:   idx,
+				after:   db.ResumePoint{Path: object.Path, Deleted: object.Deleted},
+			}
+
+			if transforms != nil {
+				object, err = transforms.Apply(object, req.TransformVars)
+				if err != nil {
+					return status.Errorf(codes.Internal, "FS get transform object: %v", err)
+				}
+			}
+
+			err = stream.Send(&pb.GetResponse{Version: vrange.To, Object: object, Cursor: cursor.encode()})
+			if err != nil {
+				return status.Errorf(codes.Internal, "FS send GetResponse: %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (f *Fs) GetCompress(req *pb.GetCompressRequest, stream pb.Fs_GetCompressServer) error {
+	ctx := stream.Context()
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.FromVersion.Attribute(req.FromVersion),
+		key.ToVersion.Attribute(req.ToVersion),
+		key.KnownHashesCount.Attribute(len(req.KnownHashes)),
+	)
+
+	project, err := requireProjectAuth(ctx)
+	if err != nil {
+		return err
+	}
+
+	if project > -1 && req.Project != project {
+		return status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	}
+
+	err = validateKnownHashes("GetCompressRequest", req.KnownHashes)
+	if err != nil {
+		return err
+	}
+
+	err = validateKnownHashes("GetCompressRequest", req.KnownPacks)
+	if err != nil {
+		return err
+	}
+
+	toVersion, err := pinToSnapshot("GetCompressRequest", req.Project, req.SnapshotToken, req.ToVersion)
+	if err != nil {
+		return err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	vrange, err := db.NewVersionRange(ctx, tx, req.Project, req.FromVersion, toVersion)
+	if errors.Is(err, db.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "FS get compress missing latest version: %v", err)
+	}
+	if errors.Is(err, db.ErrVersionSquashed) {
+		return status.Errorf(codes.FailedPrecondition, "FS get compress: %v", err)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "FS get compress latest version: %v", err)
+	}
+
+	maxContentSize := f.maxContentSendSize(req.MaxContentSendSize)
+
+	logger.Debug(ctx, "FS.GetCompress[Init]",
+		key.Project.Field(req.Project),
+		key.FromVersion.Field(&vrange.From),
+		key.ToVersion.Field(&vrange.To),
+		key.CacheVersions.Field(req.AvailableCacheVersions),
+		key.KnownHashesCount.Field(len(req.KnownHashes)),
+		key.MaxContentSize.Field(maxContentSize),
+	)
+
+	transforms, err := f.transformSet(ctx, tx, req.Project, req.Transform)
+	if err != nil {
+		return err
+	}
+
+	// cached and known packs are the stored packs, transformed checkouts get every pack sent whole
+	cacheVersions := req.AvailableCacheVersions
+	knownPacks := req.KnownPacks
+	if transforms != nil {
+		cacheVersions = nil
+		knownPacks = nil
+	}
+
+	if transforms == nil && isFullCheckout(req, vrange, maxContentSize) {
+		served, err := f.sendCheckoutArtifact(ctx, tx, req.Project, vrange.To, stream)
+		if err != nil {
+			return err
+		}
+		if served {
+			return nil
+		}
+	}
+
+	var packManager *db.PackManager
+	var omitted []*pb.Object
+
+	for _, query := range batchQueries(req.Queries) {
+		err = validateObjectQuery(query)
+		if err != nil {
+			return err
+		}
+
+		// Tars are built from database rows, explicit paths within a pack are fetched through their pack parent
+		// and only their entries are extracted from it
+		var packedPaths map[string][]string
+		if len(query.Paths) > 0 {
 			if packManager == nil {
-				packManager, err = db.NewPackManager(ctx, tx, project)
+				packManager, err = db.NewPackManager(ctx, tx, req.Project)
 				if err != nil {
 					return status.Errorf(codes.Internal, "FS create packed cache: %v", err)
 				}
-
-				span.SetAttributes(
-					key.Project.Attribute(project),
-				)
 			}
 
-			packParent := packManager.IsPathPacked(req.Object.Path)
-			if packParent != nil {
-				packedBuffer[*packParent] = append(packedBuffer[*packParent], req.Object)
+			packedPaths = packManager.PackedPaths(query.Paths)
+			query = &pb.ObjectQuery{Paths: packManager.QueryPaths(query.Paths)}
+		}
+
+		logger.Info(ctx, "FS.GetCompress[Query]",
+			key.Project.Field(req.Project),
+			key.FromVersion.Field(&vrange.From),
+			key.ToVersion.Field(&vrange.To),
+			key.QueryPath.Field(query.Path),
+			key.QueryIsPrefix.Field(query.IsPrefix),
+			key.QueryIgnores.Field(query.Ignores),
+		)
+
+		tars, err := db.GetTars(ctx, tx, f.ContentLookup, req.Project, cacheVersions, req.KnownHashes, knownPacks, vrange, query, maxContentSize, packedPaths, transforms, req.TransformVars, func(object *pb.Object) {
+			omitted = append(omitted, object)
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "FS get tars: %v", err)
+		}
+
+		for {
+			tar, packPath, err := tars()
+			if err == io.EOF {
+				break
+			}
+			if err == db.SKIP {
+				continue
+			}
+			if err == db.KNOWN {
+				err = stream.Send(&pb.GetCompressResponse{
+					Version:  vrange.To,
+					Format:   pb.GetCompressResponse_S2_TAR,
+					PackPath: packPath,
+					Hash:     tar,
+					Known:    true,
+				})
+				if err != nil {
+					return status.Errorf(codes.Internal, "FS send GetCompressResponse: %v", err)
+				}
 				continue
 			}
+			if err != nil {
+				return status.Errorf(codes.Internal, "FS get next tar: %v", err)
+			}
+
+			hash := db.HashContent(tar)
+			err = stream.Send(&pb.GetCompressResponse{
+				Version:  vrange.To,
+				Format:   pb.GetCompressResponse_S2_TAR,
+				Bytes:    tar,
+				PackPath: packPath,
+				Hash:     hash.Bytes(),
+			})
+			if err != nil {
+				return status.Errorf(codes.Internal, "FS send GetCompressResponse: %v", err)
+			}
+		}
+	}
+
+	if len(omitted) > 0 {
+		err = stream.Send(&pb.GetCompressResponse{
+			Version: vrange.To,
+			Format:  pb.GetCompressResponse_S2_TAR,
+			Omitted: omitted,
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "FS send GetCompressResponse: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// isFullCheckout reports whether a GetCompress request asks for the plain checkout of a whole project, which is what
+// precomputed checkout artifacts contain.
+func isFullCheckout(req *pb.GetCompressRequest, vrange db.VersionRange, maxContentSize int64) bool {
+	if vrange.From != 0 || maxContentSize > 0 || len(req.AvailableCacheVersions) > 0 || len(req.KnownHashes) > 0 || len(req.KnownPacks) > 0 || len(req.Queries) != 1 {
+		return false
+	}
+
+	query := req.Queries[0]
+	return query.Path == "" && query.IsPrefix && len(query.Ignores) == 0 && len(query.Paths) == 0
+}
+
+func (f *Fs) sendCheckoutArtifact(ctx context.Context, tx pgx.Tx, project int64, version int64, stream pb.Fs_GetCompressServer) (bool, error) {
+	tars, closeArtifact, err := db.GetCheckoutArtifact(ctx, tx, project, version)
+	defer closeArtifact(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "FS get checkout artifact: %v", err)
+	}
+
+	logger.Debug(ctx, "FS.GetCompress[Artifact]", key.Project.Field(project), key.Version.Field(version))
+
+	for {
+		tar, packPath, err := tars()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return false, status.Errorf(codes.Internal, "FS get next artifact tar: %v", err)
+		}
+
+		hash := db.HashContent(tar)
+		err = stream.Send(&pb.GetCompressResponse{
+			Version:  version,
+			Format:   pb.GetCompressResponse_S2_TAR,
+			Bytes:    tar,
+			PackPath: packPath,
+			Hash:     hash.Bytes(),
+		})
+		if err != nil {
+			return false, status.Errorf(codes.Internal, "FS send GetCompressResponse: %v", err)
+		}
+	}
+
+	return true, nil
+}
+
+// precomputeCheckout builds the checkout artifact of a freshly committed version in the background.
+func (f *Fs) precomputeCheckout(ctx context.Context, project int64, version int64) {
+	if !f.PrecomputeCheckouts {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	go func() {
+		tx, close, err := f.DbConn.Connect(ctx)
+		if err != nil {
+			logger.Error(ctx, "FS.PrecomputeCheckout[Connect]", key.Project.Field(project), zap.Error(err))
+			return
+		}
+		defer close(ctx)
+
+		count, err := db.BuildCheckoutArtifact(ctx, tx, f.ContentLookup, project, version)
+		if err != nil {
+			logger.Error(ctx, "FS.PrecomputeCheckout[Build]", key.Project.Field(project), key.Version.Field(version), zap.Error(err))
+			return
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			logger.Error(ctx, "FS.PrecomputeCheckout[Commit]", key.Project.Field(project), key.Version.Field(version), zap.Error(err))
+			return
+		}
+
+		logger.Debug(ctx, "FS.PrecomputeCheckout[Done]", key.Project.Field(project), key.Version.Field(version), key.Count.Field(int64(count)))
+	}()
+}
+
+func (f *Fs) GetUnary(ctx context.Context, req *pb.GetUnaryRequest) (*pb.GetUnaryResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.FromVersion.Attribute(req.FromVersion),
+		key.ToVersion.Attribute(req.ToVersion),
+	)
+
+	project, err := requireProjectAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if project > -1 && req.Project != project {
+		return nil, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	}
+
+	toVersion, err := pinToSnapshot("GetUnaryRequest", req.Project, req.SnapshotToken, req.ToVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	f.compressResponse(ctx)
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	vrange, err := db.NewVersionRange(ctx, tx, req.Project, req.FromVersion, toVersion)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS get missing latest version: %v", err)
+	}
+	if errors.Is(err, db.ErrVersionSquashed) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS get: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS get latest version: %v", err)
+	}
+
+	maxContentSize := f.maxContentSendSize(req.MaxContentSendSize)
+
+	logger.Debug(ctx, "FS.GetUnary[Init]",
+		key.Project.Field(req.Project),
+		key.FromVersion.Field(&vrange.From),
+		key.ToVersion.Field(&vrange.To),
+		key.MaxContentSize.Field(maxContentSize),
+	)
+
+	packManager, err := db.NewPackManager(ctx, tx, req.Project)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS create packed cache: %v", err)
+	}
+
+	transforms, err := f.transformSet(ctx, tx, req.Project, req.Transform)
+	if err != nil {
+		return nil, err
+	}
+
+	var response pb.GetUnaryResponse
+	notModified := len(req.Queries) > 0
+
+	for idx, requestQuery := range req.Queries {
+		var queryObjects []*pb.Object
+		digest := sha256.New()
+
+		for _, query := range batchQueries([]*pb.ObjectQuery{requestQuery}) {
+			err = validateObjectQuery(query)
+			if err != nil {
+				return nil, err
+			}
+
+			logger.Info(ctx, "FS.GetUnary[Query]",
+				key.Project.Field(req.Project),
+				key.FromVersion.Field(&vrange.From),
+				key.ToVersion.Field(&vrange.To),
+				key.QueryPath.Field(query.Path),
+				key.QueryIsPrefix.Field(query.IsPrefix),
+				key.QueryIgnores.Field(query.Ignores),
+			)
+
+			objects, err := db.GetObjects(ctx, tx, f.ContentLookup, packManager, req.Project, vrange, query, maxContentSize)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "FS get objects: %v", err)
+			}
+
+			for {
+				object, err := objects()
+				if err == db.SKIP {
+					continue
+				}
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					return nil, status.Errorf(codes.Internal, "FS get next object: %v", err)
+				}
+
+				if transforms != nil {
+					object, err = transforms.Apply(object, req.TransformVars)
+					if err != nil {
+						return nil, status.Errorf(codes.Internal, "FS get transform object: %v", err)
+					}
+				}
+
+				writeObjectDigest(digest, object)
+				queryObjects = append(queryObjects, object)
+			}
+		}
+
+		etag := hex.EncodeToString(digest.Sum(nil))
+		response.Etags = append(response.Etags, etag)
+
+		if idx < len(req.IfNoneMatch) && req.IfNoneMatch[idx] == etag {
+			continue
+		}
+
+		notModified = false
+		if len(queryObjects) > 0 {
+			response.Version = vrange.To
+			response.Objects = append(response.Objects, queryObjects...)
+		}
+	}
+
+	if notModified {
+		response.Version = vrange.To
+		response.NotModified = true
+	}
+
+	return &response, nil
+}
+
+// writeObjectDigest adds every field of an object to a query's etag digest, optional fields are prefixed with
+// whether they are set so an unset field never digests like an empty one.
+func writeObjectDigest(digest hash.Hash, object *pb.Object) {
+	var header [8 * 3]byte
+	binary.LittleEndian.PutUint64(header[0:8], uint64(object.Mode))
+	binary.LittleEndian.PutUint64(header[8:16], uint64(object.Size))
+	binary.LittleEndian.PutUint64(header[16:24], uint64(len(object.Content)))
+
+	digest.Write([]byte(object.Path))
+	digest.Write([]byte{0})
+	digest.Write(header[:])
+	writeDigestFlag(digest, object.Deleted)
+	writeDigestFlag(digest, object.ContentOmitted)
+
+	writeDigestFlag(digest, object.Mtime != nil)
+	if object.Mtime != nil {
+		var mtime [8]byte
+		binary.LittleEndian.PutUint64(mtime[:], uint64(*object.Mtime))
+		digest.Write(mtime[:])
+	}
+
+	writeDigestFlag(digest, object.ContentType != nil)
+	if object.ContentType != nil {
+		digest.Write([]byte(*object.ContentType))
+		digest.Write([]byte{0})
+	}
+
+	writeDigestFlag(digest, object.Content != nil)
+	digest.Write(object.Content)
+}
+
+func writeDigestFlag(digest hash.Hash, flag bool) {
+	if flag {
+		digest.Write([]byte{1})
+	} else {
+		digest.Write([]byte{0})
+	}
+}
+
+func (f *Fs) Update(stream pb.Fs_UpdateServer) error {
+	ctx := stream.Context()
+
+	project, err := requireProjectAuth(ctx)
+	if err != nil {
+		return err
+	}
+
+	release, err := f.UpdateAdmission.Admit(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	connectStart := time.Now()
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+	f.UpdateAdmission.ObserveDbLatency(time.Since(connectStart))
+
+	contentEncoder := f.newContentEncoder()
+	defer contentEncoder.Close()
+
+	var packManager *db.PackManager
+
+	var objectBuffer []*pb.Object
+	packedBuffer := make(map[string][]*pb.Object)
+	var hashBuffer []*pb.ObjectHash
+
+	var message, author, idempotencyKey *string
+	force := false
+
+	var updatePolicy *policy.Policy
+	var violations []policy.Violation
+	var transforms *transform.Set
+
+	var invalidPaths []*pb.PathError
+
+	// how each canonical path was spelled by the client, two spellings of a path in one update are ambiguous
+	spellings := make(map[string]string)
+
+	err = telemetry.Trace(ctx, "receive-update-objects", func(ctx context.Context, span trace.Span) error {
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				errStatus, _ := status.FromError(err)
+				return status.Errorf(errStatus.Code(), "FS receive update request: %v", err)
+			}
+
+			// project can be -1 if the client authenticated using an Admin token
+			if project == -1 {
+				project = req.Project
+			}
+			if project != req.Project {
+				return status.Errorf(codes.InvalidArgument, "initial project %v, next project %v: %v", project, req.Project, ErrMultipleProjectsPerUpdate)
+			}
+
+			if message == nil && author == nil {
+				message, author = req.Message, req.Author
+			}
+			if !force && req.GetForce() {
+				force = true
+			}
+			if idempotencyKey == nil && req.IdempotencyKey != nil {
+				err = validateIdempotencyKey("UpdateRequest", req.IdempotencyKey)
+				if err != nil {
+					return err
+				}
+				idempotencyKey = req.IdempotencyKey
+			}
+
+			// We can only create the pack manager once we have the project ID and that requires a least one stream message
+			if packManager == nil {
+				packManager, err = db.NewPackManager(ctx, tx, project)
+				if err != nil {
+					return status.Errorf(codes.Internal, "FS create packed cache: %v", err)
+				}
+
+				updatePolicy, err = f.projectPolicy(ctx, tx, project)
+				if err != nil {
+					return err
+				}
+
+				transforms, err = f.transformSet(ctx, tx, project, true)
+				if err != nil {
+					return err
+				}
+
+				span.SetAttributes(
+					key.Project.Attribute(project),
+				)
+			}
+
+			var pathErr *pb.PathError
+			if errors.As(pb.ValidatePath(req.Object.Path), &pathErr) {
+				invalidPaths = append(invalidPaths, pathErr)
+				continue
+			}
+
+			rawPath := req.Object.Path
+			err = pb.NormalizeObject(req.Object)
+			if err != nil {
+				return status.Errorf(codes.InvalidArgument, "FS update: %v", err)
+			}
+
+			if spelling, ok := spellings[req.Object.Path]; ok && spelling != rawPath {
+				return status.Errorf(codes.InvalidArgument, "FS update: %q and %q are the same path %v", spelling, rawPath, req.Object.Path)
+			}
+			spellings[req.Object.Path] = rawPath
+
+			err = f.resolveLfsPointer(ctx, tx, "update", project, transforms, req.Object)
+			if err != nil {
+				return err
+			}
+
+			violations = append(violations, updatePolicy.Check(req.Object.Path, max(req.Object.Size, int64(len(req.Object.Content))), req.Object.Deleted)...)
+
+			packParent := packManager.IsPathPacked(req.Object.Path)
+
+			if req.Hash != nil {
+				_, err = db.HashFromBytes(req.Hash)
+				if err != nil || packParent != nil || req.Object.Deleted || len(req.Object.Content) > 0 {
+					return status.Errorf(codes.InvalidArgument, "FS update: object %v sent by hash must be a loose file without content", req.Object.Path)
+				}
+				hashBuffer = append(hashBuffer, &pb.ObjectHash{Path: req.Object.Path, Mode: req.Object.Mode, Hash: req.Hash})
+				continue
+			}
+
+			if packParent != nil {
+				packedBuffer[*packParent] = append(packedBuffer[*packParent], req.Object)
+				continue
+			}
+
+			objectBuffer = append(objectBuffer, req.Object)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(invalidPaths) > 0 {
+		return invalidPathsError(invalidPaths)
+	}
+
+	if len(violations) > 0 {
+		return policyViolationsError(project, violations)
+	}
+
+	// No updates were received from the stream which prevented us from detecting the project and version
+	if len(objectBuffer) == 0 && len(packedBuffer) == 0 && len(hashBuffer) == 0 {
+		logger.Info(ctx, "FS.Update[Empty]")
+		return stream.SendAndClose(&pb.UpdateResponse{Version: -1})
+	}
+
+	latestVersion := int64(-1)
+	nextVersion := int64(-1)
+	shouldUpdateVersion := false
+
+	latestVersion, err = db.LockWritableVersion(ctx, tx, project)
+	if errors.Is(err, db.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "FS update missing latest version: %v", err)
+	}
+	if errors.Is(err, db.ErrProjectFrozen) {
+		return status.Errorf(codes.FailedPrecondition, "FS update: %v", err)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "FS update lock latest version: %v", err)
+	}
+
+	if idempotencyKey != nil {
+		version, found, err := db.LookupIdempotencyKey(ctx, tx, project, *idempotencyKey)
+		if err != nil {
+			return status.Errorf(codes.Internal, "FS update: %v", err)
+		}
+		if found {
+			logger.Info(ctx, "FS.Update[Replayed]", key.Project.Field(project), key.Version.Field(version))
+			return stream.SendAndClose(&pb.UpdateResponse{Version: version})
+		}
+	}
+
+	// objects sent by hash are no-ops, as long as nothing changed them since the client checked
+	if len(hashBuffer) > 0 {
+		identical, err := db.IdenticalObjects(ctx, tx, project, hashBuffer)
+		if err != nil {
+			return status.Errorf(codes.Internal, "FS update: %v", err)
+		}
+
+		for idx, object := range hashBuffer {
+			if !identical[idx] {
+				return status.Errorf(codes.FailedPrecondition, "FS update: %v changed since it was checked, its content must be sent", object.Path)
+			}
+		}
+	}
+
+	guarded := f.DeletionGuard != nil && !force
+	liveObjects := int64(0)
+	if guarded {
+		liveObjects, err = db.LiveObjectsCount(ctx, tx, project)
+		if err != nil {
+			return status.Errorf(codes.Internal, "FS update: %v", err)
+		}
+	}
+
+	err = telemetry.Trace(ctx, "update-objects", func(ctx context.Context, span trace.Span) error {
+		nextVersion = latestVersion + 1
+		logger.Info(ctx, "FS.Update[Init]", key.Project.Field(project), key.Version.Field(nextVersion))
+
+		for _, object := range objectBuffer {
+			logger.Debug(ctx, "FS.Update[Object]",
+				key.Project.Field(project),
+				key.Version.Field(nextVersion),
+				key.ObjectPath.Field(object.Path),
+			)
+
+			if object.Deleted {
+				err = db.DeleteObject(ctx, tx, project, nextVersion, object.Path)
+				shouldUpdateVersion = true
+			} else {
+				if f.DetectContentType {
+					object.ContentType = detectContentType(object)
+				}
+
+				var contentChanged bool
+				contentChanged, err = db.UpdateObject(ctx, tx, f.DbConn, contentEncoder, project, nextVersion, object)
+
+				if contentChanged {
+					shouldUpdateVersion = true
+				}
+			}
+
+			if err != nil {
+				return status.Errorf(codes.Internal, "FS update: %v", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = telemetry.Trace(ctx, "update-packed-objects", func(ctx context.Context, span trace.Span) error {
+		for parent, objects := range packedBuffer {
+			logger.Debug(ctx, "FS.Update[PackedObject]",
+				key.Project.Field(project),
+				key.Version.Field(nextVersion),
+				key.ObjectsParent.Field(parent),
+				key.ObjectsCount.Field(len(objects)),
+			)
+
+			contentChanged, err := db.UpdatePackedObjects(ctx, tx, f.DbConn, project, nextVersion, parent, objects)
+			if err != nil {
+				return status.Errorf(codes.Internal, "FS update packed objects for %v: %v", parent, err)
+			}
+
+			if contentChanged {
+				shouldUpdateVersion = true
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !shouldUpdateVersion {
+		return stream.SendAndClose(&pb.UpdateResponse{Version: latestVersion})
+	}
+
+	if guarded {
+		remainingObjects, err := db.LiveObjectsCount(ctx, tx, project)
+		if err != nil {
+			return status.Errorf(codes.Internal, "FS update: %v", err)
+		}
+
+		violation := f.DeletionGuard.Check(liveObjects, remainingObjects)
+		if violation != nil {
+			logger.Warn(ctx, "FS.Update[DeletionGuard]", key.Project.Field(project), key.Version.Field(nextVersion), zap.String("reason", violation.Description))
+			return massDeletionError(project, violation)
+		}
+	}
+
+	err = db.UpdateLatestVersion(ctx, tx, project, nextVersion)
+	if err != nil {
+		return status.Errorf(codes.Internal, "FS update latest version: %v", err)
+	}
+
+	err = db.AnnotateVersion(ctx, tx, project, nextVersion, message, author)
+	if err != nil {
+		return status.Errorf(codes.Internal, "FS update annotate version: %v", err)
+	}
+
+	if idempotencyKey != nil {
+		err = db.SaveIdempotencyKey(ctx, tx, project, *idempotencyKey, nextVersion)
+		if err != nil {
+			return status.Errorf(codes.Internal, "FS update: %v", err)
+		}
+	}
+
+	if f.QueueContentScans {
+		err = db.QueueContentScans(ctx, tx, project, nextVersion)
+		if err != nil {
+			return status.Errorf(codes.Internal, "FS update: %v", err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "FS update commit tx: %v", err)
+	}
+
+	logger.Debug(ctx, "FS.Update[Commit]", key.Project.Field(project), key.Version.Field(nextVersion))
+
+	f.precomputeCheckout(ctx, project, nextVersion)
+
+	return stream.SendAndClose(&pb.UpdateResponse{Version: nextVersion})
+}
+
+// maxCheckUpdateObjects bounds the number of objects a single CheckUpdate call can check
+const maxCheckUpdateObjects = 10_000
+
+// CheckUpdate tells an updating client which objects it must send the content of, the others can be sent by hash alone.
+func (f *Fs) CheckUpdate(ctx context.Context, req *pb.CheckUpdateRequest) (*pb.CheckUpdateResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.ObjectsCount.Attribute(len(req.Objects)),
+	)
+
+	project, err := requireProjectAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if project > -1 && req.Project != project {
+		return nil, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	}
+
+	if len(req.Objects) > maxCheckUpdateObjects {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid CheckUpdateRequest: too many objects (%v > %v)", len(req.Objects), maxCheckUpdateObjects)
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.CheckUpdate[Init]",
+		key.Project.Field(req.Project),
+		key.ObjectsCount.Field(len(req.Objects)),
+	)
+
+	packManager, err := db.NewPackManager(ctx, tx, req.Project)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS create packed cache: %v", err)
+	}
+
+	response := &pb.CheckUpdateResponse{ContentNeeded: make([]bool, len(req.Objects))}
+
+	// packed objects are rewritten along with their whole pack, their content is always needed
+	var loose []*pb.ObjectHash
+	var looseIdx []int
+	for idx, object := range req.Objects {
+		response.ContentNeeded[idx] = true
+		if packManager.IsPathPacked(object.Path) == nil {
+			loose = append(loose, object)
+			looseIdx = append(looseIdx, idx)
+		}
+	}
+
+	for start := 0; start < len(loose); start += pathsBatchSize {
+		end := min(start+pathsBatchSize, len(loose))
+
+		identical, err := db.IdenticalObjects(ctx, tx, req.Project, loose[start:end])
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS check update: %v", err)
+		}
+
+		for idx, same := range identical {
+			response.ContentNeeded[looseIdx[start+idx]] = !same
+		}
+	}
+
+	return response, nil
+}
+
+// extensionContentTypes maps file extensions to MIME types. It is fixed rather than read from the host's mime.types so
+// every server detects the same type for the same file.
+var extensionContentTypes = map[string]string{
+	".avif":  "image/avif",
+	".css":   "text/css; charset=utf-8",
+	".csv":   "text/csv; charset=utf-8",
+	".gif":   "image/gif",
+	".gz":    "application/gzip",
+	".htm":   "text/html; charset=utf-8",
+	".html":  "text/html; charset=utf-8",
+	".ico":   "image/x-icon",
+	".jpeg":  "image/jpeg",
+	".jpg":   "image/jpeg",
+	".js":    "text/javascript; charset=utf-8",
+	".json":  "application/json",
+	".map":   "application/json",
+	".md":    "text/markdown; charset=utf-8",
+	".mjs":   "text/javascript; charset=utf-8",
+	".mp3":   "audio/mpeg",
+	".mp4":   "video/mp4",
+	".otf":   "font/otf",
+	".pdf":   "application/pdf",
+	".png":   "image/png",
+	".svg":   "image/svg+xml",
+	".ttf":   "font/ttf",
+	".txt":   "text/plain; charset=utf-8",
+	".wasm":  "application/wasm",
+	".webm":  "video/webm",
+	".webp":  "image/webp",
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+	".xml":   "text/xml; charset=utf-8",
+	".yaml":  "application/yaml",
+	".yml":   "application/yaml",
+	".zip":   "application/zip",
+}
+
+// detectContentType guesses the MIME type of a regular file from its extension, falling back to sniffing its content.
+func detectContentType(object *pb.Object) *string {
+	if pb.TarTypeFromMode(fs.FileMode(object.Mode)) != tar.TypeReg {
+		return nil
+	}
+
+	contentType, ok := extensionContentTypes[strings.ToLower(filepath.Ext(object.Path))]
+	if !ok {
+		contentType = http.DetectContentType(object.Content)
+	}
+
+	return &contentType
+}
+
+func (f *Fs) Rollback(ctx context.Context, req *pb.RollbackRequest) (*pb.RollbackResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+	)
+
+	err := requireAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.Rollback[Project]", key.Project.Field(req.Project), key.Version.Field(req.Version))
+
+	_, err = db.LockWritableVersion(ctx, tx, req.Project)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS rollback: %v", err)
+	}
+	if errors.Is(err, db.ErrProjectFrozen) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS rollback: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS rollback project %v: %v", req.Project, err)
+	}
+
+	_, err = db.NewVersionRange(ctx, tx, req.Project, nil, &req.Version)
+	if errors.Is(err, db.ErrVersionSquashed) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS rollback: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS rollback project %v: %v", req.Project, err)
+	}
+
+	err = db.ResetProject(ctx, tx, req.Project, req.Version)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS rollback project %v: %v", req.Project, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS rollback commit tx: %v", err)
+	}
+	logger.Debug(ctx, "FS.Rollback[Commit]")
+
+	return &pb.RollbackResponse{}, nil
+}
+
+func (f *Fs) History(ctx context.Context, req *pb.HistoryRequest) (*pb.HistoryResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.FromVersion.Attribute(req.FromVersion),
+		key.ToVersion.Attribute(req.ToVersion),
+	)
+
+	project, err := requireProjectAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if project > -1 && req.Project != project {
+		return nil, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	}
+
+	f.compressResponse(ctx)
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	vrange, err := db.NewVersionRange(ctx, tx, req.Project, req.FromVersion, req.ToVersion)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS history missing latest version: %v", err)
+	}
+	if errors.Is(err, db.ErrVersionSquashed) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS history: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS history NewVersionRange: %v", err)
+	}
+
+	logger.Debug(ctx, "FS.History[Query]", key.Project.Field(req.Project), key.FromVersion.Field(&vrange.From), key.ToVersion.Field(&vrange.To))
+
+	history, err := db.VersionHistory(ctx, tx, req.Project, vrange)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS history: %v", err)
+	}
+
+	return &pb.HistoryResponse{
+		Versions: history,
+	}, nil
+}
+
+func (f *Fs) Inspect(ctx context.Context, req *pb.InspectRequest) (*pb.InspectResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+	)
+
+	err := requireAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.Inspect[Query]", key.Project.Field(req.Project))
+
+	vrange, err := db.NewVersionRange(ctx, tx, req.Project, nil, nil)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS inspect missing latest version: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS inspect latest version: %v", err)
+	}
+
+	packManager, err := db.NewPackManager(ctx, tx, req.Project)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS create packed cache: %v", err)
+	}
+
+	query := &pb.ObjectQuery{
+		Path:     "",
+		IsPrefix: true,
+	}
+	objects, err := db.GetObjects(ctx, tx, f.ContentLookup, packManager, req.Project, vrange, query, 0)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS get objects: %v", err)
+	}
+
+	live_objects_count := int64(0)
+	for {
+		_, err := objects()
+		if err == db.SKIP {
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS get next object: %v", err)
+		}
+
+		live_objects_count += 1
+	}
+
+	total_objects_count, err := db.TotalObjectsCount(ctx, tx, req.Project)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS inspect project: %v", err)
+	}
+
+	tombstones_count, err := db.TombstonesCount(ctx, tx, req.Project)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS inspect project: %v", err)
+	}
+
+	frozen, frozenReason, err := db.ProjectFrozen(ctx, tx, req.Project)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS inspect project: %v", err)
+	}
+
+	return &pb.InspectResponse{
+		Project:           req.Project,
+		LatestVersion:     vrange.To,
+		LiveObjectsCount:  live_objects_count,
+		TotalObjectsCount: total_objects_count,
+		TombstonesCount:   tombstones_count,
+		Frozen:            frozen,
+		FrozenReason:      frozenReason,
+	}, nil
+}
+
+func (f *Fs) Snapshot(ctx context.Context, req *pb.SnapshotRequest) (*pb.SnapshotResponse, error) {
+	if f.Env != environment.Dev && f.Env != environment.Test {
+		return nil, status.Errorf(codes.Unimplemented, "FS snapshot only implemented in dev and test environments")
+	}
+
+	err := requireGlobalAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.Snapshot[Query]")
+
+	projects, err := db.ListProjects(ctx, tx, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS snapshot: %v", err)
+	}
+
+	return &pb.SnapshotResponse{
+		Projects: projects,
+	}, nil
+}
+
+func (f *Fs) Reset(ctx context.Context, req *pb.ResetRequest) (*pb.ResetResponse, error) {
+	if f.Env != environment.Dev && f.Env != environment.Test {
+		return nil, status.Errorf(codes.Unimplemented, "FS reset only implemented in dev and test environments")
+	}
+
+	err := requireGlobalAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.Reset[Init]")
+
+	if len(req.Projects) == 0 {
+		logger.Debug(ctx, "FS.Reset[All]")
+
+		err = db.ResetAll(ctx, tx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS reset all: %v", err)
+		}
+	} else {
+		var projects []int64
+
+		for _, project := range req.Projects {
+			logger.Debug(ctx, "FS.Reset[Project]", key.Project.Field(project.Id), key.Version.Field(project.Version))
+			err = db.ResetProject(ctx, tx, project.Id, project.Version)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "FS reset project %v: %v", project.Id, err)
+			}
+			projects = append(projects, project.Id)
+		}
+
+		err = db.DropOtherProjects(ctx, tx, projects)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS reset drop others: %v", err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS reset commit tx: %v", err)
+	}
+	logger.Debug(ctx, "FS.Reset[Commit]")
+
+	return &pb.ResetResponse{}, nil
+}
+
+func (f *Fs) GcProject(ctx context.Context, req *pb.GcProjectRequest) (*pb.GcProjectResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.KeepVersions.Attribute(req.KeepVersions),
+		key.FromVersion.Attribute(req.FromVersion),
+	)
+
+	err := requireAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.KeepVersions <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid GC KeepVersions: cannot keep 0 versions")
+	}
+
+	logger.Debug(ctx, "FS.GcProject[Init]", key.Project.Field(req.Project))
+
+	fromVersion := int64(0)
+	if req.FromVersion != nil {
+		fromVersion = *req.FromVersion
+	}
+
+	if req.DryRun {
+		tx, close, err := f.DbConn.Connect(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+		}
+		defer close(ctx)
+
+		count, err := db.CountGcProjectContents(ctx, tx, req.Project, req.KeepVersions, fromVersion)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS gc project dry run %v: %v", req.Project, err)
+		}
+
+		return &pb.GcProjectResponse{
+			Count:   count,
+			Project: req.Project,
+		}, nil
+	}
+
+	hashes, err := db.GcProjectObjects(ctx, f.DbConn, req.Project, req.KeepVersions, fromVersion)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS gc project objects %v: %v", req.Project, err)
+	}
+
+	count, err := db.GcContentHashes(ctx, f.DbConn, hashes)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS gc content hashes %v: %v", req.Project, err)
+	}
+
+	return &pb.GcProjectResponse{
+		Count:   count,
+		Project: req.Project,
+	}, nil
+}
+
+// maxTombstones bounds the number of tombstones a single ListTombstones call returns
+const maxTombstones = 10_000
+
+func (f *Fs) ListTombstones(ctx context.Context, req *pb.ListTombstonesRequest) (*pb.ListTombstonesResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.ToVersion.Attribute(req.Version),
+		key.Prefix.Attribute(req.Prefix),
+	)
+
+	err := requireAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int64(maxTombstones)
+	if req.Limit != nil {
+		if *req.Limit <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "Invalid ListTombstones limit: must be positive")
+		}
+		limit = min(*req.Limit, maxTombstones)
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	vrange, err := db.NewVersionRange(ctx, tx, req.Project, nil, req.Version)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS list tombstones missing latest version: %v", err)
+	}
+	if errors.Is(err, db.ErrVersionSquashed) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS list tombstones: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS list tombstones latest version: %v", err)
+	}
+
+	logger.Debug(ctx, "FS.ListTombstones[Query]",
+		key.Project.Field(req.Project),
+		key.ToVersion.Field(&vrange.To),
+		key.Prefix.Field(req.Prefix),
+	)
+
+	tombstones, err := db.ListTombstones(ctx, tx, req.Project, vrange.To, req.Prefix, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS list tombstones: %v", err)
+	}
+
+	return &pb.ListTombstonesResponse{
+		Version:    vrange.To,
+		Tombstones: tombstones,
+	}, nil
+}
+
+// PurgeDeleted removes the tombstones of paths under a prefix deleted before a version, for projects churning through temporary directories.
+func (f *Fs) PurgeDeleted(ctx context.Context, req *pb.PurgeDeletedRequest) (*pb.PurgeDeletedResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.Prefix.Attribute(req.Prefix),
+		key.Version.Attribute(req.BeforeVersion),
+	)
+
+	err := requireAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Prefix == "" {
+		return nil, status.Error(codes.InvalidArgument, "Invalid PurgeDeleted prefix: a prefix is required, use GcProject to collect the whole project")
+	}
+	if req.BeforeVersion <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid PurgeDeleted BeforeVersion: must be positive")
+	}
+
+	logger.Debug(ctx, "FS.PurgeDeleted[Init]",
+		key.Project.Field(req.Project),
+		key.Prefix.Field(req.Prefix),
+		key.Version.Field(req.BeforeVersion),
+	)
+
+	hashes, err := db.PurgeTombstones(ctx, f.DbConn, req.Project, req.Prefix, req.BeforeVersion)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS purge tombstones %v: %v", req.Project, err)
+	}
+
+	count, err := db.GcContentHashes(ctx, f.DbConn, hashes)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS gc content hashes %v: %v", req.Project, err)
+	}
+
+	return &pb.PurgeDeletedResponse{
+		Project:       req.Project,
+		PurgedObjects: int64(len(hashes)),
+		Count:         count,
+	}, nil
+}
+
+// SquashHistory collapses the versions of a project below a baseline into the baseline, so ancient projects keep fewer object rows.
+// The baseline is either given explicitly or the latest version minus the number of versions to keep intact.
+func (f *Fs) SquashHistory(ctx context.Context, req *pb.SquashHistoryRequest) (*pb.SquashHistoryResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.Version.Attribute(req.GetVersion()),
+		key.KeepVersions.Attribute(req.GetKeepVersions()),
+	)
+
+	err := requireAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if (req.Version == nil) == (req.KeepVersions == nil) {
+		return nil, status.Error(codes.InvalidArgument, "Invalid squash: exactly one of version and keep_versions is required")
+	}
+	if req.KeepVersions != nil && *req.KeepVersions <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid squash KeepVersions: cannot keep 0 versions")
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.SquashHistory[Init]", key.Project.Field(req.Project))
+
+	latest, err := db.LockWritableVersion(ctx, tx, req.Project)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS squash history: project %v not found", req.Project)
+	}
+	if errors.Is(err, db.ErrProjectFrozen) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS squash history: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS squash history could not lock project %v: %v", req.Project, err)
+	}
+
+	baseline := req.GetVersion()
+	if req.KeepVersions != nil {
+		baseline = max(latest-*req.KeepVersions, 0)
+	}
+	if baseline < 0 || baseline > latest {
+		return nil, status.Errorf(codes.InvalidArgument, "FS squash history %v: version %v outside of 0-%v", req.Project, baseline, latest)
+	}
+
+	result, err := db.SquashProjectHistory(ctx, tx, req.Project, baseline)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS squash history %v: %v", req.Project, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS squash history commit tx: %v", err)
+	}
+
+	count, err := db.GcContentHashes(ctx, f.DbConn, result.Removed)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS squash history gc content hashes %v: %v", req.Project, err)
+	}
+
+	logger.Debug(ctx, "FS.SquashHistory[Commit]",
+		key.Project.Field(req.Project),
+		key.Version.Field(result.Baseline),
+	)
+
+	return &pb.SquashHistoryResponse{
+		BaselineVersion:  result.Baseline,
+		RemovedObjects:   int64(len(result.Removed)),
+		RewrittenObjects: result.Rewritten,
+		Count:            count,
+	}, nil
+}
+
+func (f *Fs) GcRandomProjects(ctx context.Context, req *pb.GcRandomProjectsRequest) (*pb.GcRandomProjectsResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.SampleRate.Attribute(req.Sample),
+		key.KeepVersions.Attribute(req.KeepVersions),
+		key.FromVersion.Attribute(req.FromVersion),
+	)
+
+	ctx, span := telemetry.Start(ctx, "fs.gc-random-projects")
+	defer span.End()
+
+	err := requireGlobalAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.KeepVersions <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Invalid GC KeepVersions: cannot keep 0 versions")
+	}
+
+	logger.Debug(ctx, "FS.GcRandomProjects[Init]", key.SampleRate.Field(req.Sample))
+
+	fromVersion := int64(0)
+	if req.FromVersion != nil {
+		fromVersion = *req.FromVersion
+	}
+
+	projects, err := db.RandomProjects(ctx, f.DbConn, req.Sample)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS gc random projects %f: %v", req.Sample, err)
+	}
+
+	hashes, err := db.GcProjectsObjects(ctx, f.DbConn, projects, req.KeepVersions, fromVersion)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS gc random project objects: %v", err)
+	}
+
+	count, err := db.GcContentHashes(ctx, f.DbConn, hashes)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS gc random content hashes: %v", err)
+	}
+
+	return &pb.GcRandomProjectsResponse{
+		Count:    count,
+		Projects: projects,
+	}, nil
+}
+
+func (f *Fs) GcContents(ctx context.Context, req *pb.GcContentsRequest) (*pb.GcContentsResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.SampleRate.Attribute(req.Sample),
+	)
+
+	err := requireGlobalAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug(ctx, "FS.GcContents[Init]")
+
+	hashes, err := db.RandomContents(ctx, f.DbConn, req.Sample)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS gc random contents %f: %v", req.Sample, err)
+	}
+
+	count, err := db.GcContentHashes(ctx, f.DbConn, hashes)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS gc random content hashes: %v", err)
+	}
+
+	return &pb.GcContentsResponse{
+		Count: count,
+	}, nil
+}
+
+// CheckContentRefs validates the references tracked for a random sample of contents against the objects reading them,
+// garbage collection trusts them to find orphaned contents.
+func (f *Fs) CheckContentRefs(ctx context.Context, req *pb.CheckContentRefsRequest) (*pb.CheckContentRefsResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.SampleRate.Attribute(req.Sample),
+	)
+
+	err := requireGlobalAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug(ctx, "FS.CheckContentRefs[Init]", zap.Bool("repair", req.Repair))
+
+	hashes, err := db.RandomContents(ctx, f.DbConn, req.Sample)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS check content refs random contents %f: %v", req.Sample, err)
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	if req.Repair {
+		err = db.LockContentRefs(ctx, tx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS check content refs lock: %v", err)
+		}
+	}
+
+	checks, err := db.CheckContentRefs(ctx, tx, hashes)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS check content refs: %v", err)
+	}
+
+	response := &pb.CheckContentRefsResponse{
+		Checked: int64(len(checks)),
+	}
+
+	var inconsistent []db.Hash
+	for _, check := range checks {
+		if check.Refs == nil {
+			response.Uncounted += 1
+			continue
+		}
+		if check.Consistent() {
+			continue
+		}
+
+		response.Mismatches = append(response.Mismatches, &pb.ContentRefMismatch{
+			Hash:    check.Hash.Bytes(),
+			Refs:    *check.Refs,
+			Pending: check.Pending,
+			Actual:  check.Actual,
+		})
+		inconsistent = append(inconsistent, check.Hash)
+	}
+
+	if req.Repair {
+		response.Repaired, err = db.RepairContentRefs(ctx, tx, inconsistent)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS repair content refs: %v", err)
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS repair content refs commit tx: %v", err)
+		}
+	}
+
+	logger.Debug(ctx, "FS.CheckContentRefs[Done]", key.Count.Field(response.Checked), zap.Int("mismatches", len(response.Mismatches)))
+
+	return response, nil
+}
+
+// CaptureHeapProfile returns a heap profile of the server process, to diagnose memory growth without restarting it.
+func (f *Fs) CaptureHeapProfile(ctx context.Context, req *pb.CaptureHeapProfileRequest) (*pb.CaptureHeapProfileResponse, error) {
+	err := requireGlobalAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug(ctx, "FS.CaptureHeapProfile[Init]", zap.Bool("gc", req.Gc))
+
+	if req.Gc {
+		runtime.GC()
+	}
+
+	var profile bytes.Buffer
+	err = pprof.WriteHeapProfile(&profile)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS capture heap profile: %v", err)
+	}
+
+	return &pb.CaptureHeapProfileResponse{
+		Profile: profile.Bytes(),
+	}, nil
+}
+
+// maxTopContents bounds the number of shared contents a single ContentStats call reports
+const maxTopContents = 1000
+
+// ContentStats reports how much content is shared between projects, in total and within each template family.
+func (f *Fs) ContentStats(ctx context.Context, req *pb.ContentStatsRequest) (*pb.ContentStatsResponse, error) {
+	err := requireGlobalAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	top := int64(20)
+	if req.Top != nil {
+		if *req.Top < 0 {
+			return nil, status.Error(codes.InvalidArgument, "Invalid ContentStats top: cannot be negative")
+		}
+		top = min(*req.Top, maxTopContents)
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.ContentStats[Query]")
+
+	total, storedBytes, err := db.TotalDedupStats(ctx, tx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS content stats: %v", err)
+	}
+
+	topContents, err := db.TopSharedContents(ctx, tx, top)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS content stats: %v", err)
+	}
+
+	families, err := db.TemplateFamilyDedupStats(ctx, tx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS content stats: %v", err)
+	}
+
+	return &pb.ContentStatsResponse{
+		Total:       total,
+		StoredBytes: storedBytes,
+		TopContents: topContents,
+		Families:    families,
+	}, nil
+}
+
+// maxContentReferences bounds the number of references a single FindContent call reports
+const maxContentReferences = 10_000
+
+func (f *Fs) FindContent(ctx context.Context, req *pb.FindContentRequest) (*pb.FindContentResponse, error) {
+	err := requireGlobalAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := db.HashFromBytes(req.Hash)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid FindContent hash: %v", err)
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.ContentHash.Attribute(hash.Hex()),
+	)
+
+	limit := int64(1000)
+	if req.Limit != nil {
+		if *req.Limit <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "Invalid FindContent limit: must be positive")
+		}
+		limit = min(*req.Limit, maxContentReferences)
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.FindContent[Query]", key.ContentHash.Field(hash.Hex()))
+
+	references, truncated, err := db.FindContentReferences(ctx, tx, hash, req.IncludeHistory, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS find content: %v", err)
+	}
+
+	return &pb.FindContentResponse{
+		References: references,
+		Truncated:  truncated,
+	}, nil
+}
+
+func (f *Fs) ContentEncodingStatus(ctx context.Context, req *pb.ContentEncodingStatusRequest) (*pb.ContentEncodingStatusResponse, error) {
+	err := requireGlobalAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.ContentEncodingStatus[Query]")
+
+	encodings, err := db.ContentEncodingStats(ctx, tx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS content encoding status: %v", err)
+	}
+
+	reencodes, err := db.ListReencodeProgress(ctx, tx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS content encoding status: %v", err)
+	}
+
+	response := &pb.ContentEncodingStatusResponse{
+		WriteEncoding: f.ContentEncoding.String(),
+		Encodings:     encodings,
+	}
+	for _, progress := range reencodes {
+		response.Reencodes = append(response.Reencodes, progress.Proto())
+	}
+
+	return response, nil
+}
+
+func (f *Fs) CanonicalizePacks(ctx context.Context, req *pb.CanonicalizePacksRequest) (*pb.CanonicalizePacksResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+	)
+
+	err := requireAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.CanonicalizePacks[Init]", key.Project.Field(req.Project))
+
+	// Block concurrent updates from writing packs while they are being rewritten
+	_, err = db.LockLatestVersion(ctx, tx, req.Project)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS canonicalize packs: project %v not found", req.Project)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS canonicalize packs could not lock project %v: %v", req.Project, err)
+	}
+
+	checked, rewritten, err := db.CanonicalizeProjectPacks(ctx, tx, f.DbConn, req.Project, req.DryRun)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS canonicalize packs %v: %v", req.Project, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS canonicalize packs commit tx: %v", err)
+	}
+	logger.Debug(ctx, "FS.CanonicalizePacks[Commit]", key.Project.Field(req.Project), key.Count.Field(rewritten))
+
+	return &pb.CanonicalizePacksResponse{
+		Checked:   checked,
+		Rewritten: rewritten,
+	}, nil
+}
+
+// IndexPacks records the entries of the packs of a project written before packs were indexed, so they can be listed
+// and stat'd without being decoded.
+func (f *Fs) IndexPacks(ctx context.Context, req *pb.IndexPacksRequest) (*pb.IndexPacksResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+	)
+
+	err := requireAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.IndexPacks[Init]", key.Project.Field(req.Project))
+
+	// No lock is needed: entries are keyed by content hash and object rows are left untouched
+	checked, indexed, err := db.IndexProjectPacks(ctx, tx, f.DbConn, req.Project, req.DryRun)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS index packs %v: %v", req.Project, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS index packs commit tx: %v", err)
+	}
+	logger.Debug(ctx, "FS.IndexPacks[Commit]", key.Project.Field(req.Project), key.Count.Field(indexed))
+
+	return &pb.IndexPacksResponse{
+		Checked: checked,
+		Indexed: indexed,
+	}, nil
+}
+
+// InlineContents moves the loose contents of a project to where the server's inline threshold stores them, so projects
+// written before the threshold was set or changed read their small files from their rows too.
+func (f *Fs) InlineContents(ctx context.Context, req *pb.InlineContentsRequest) (*pb.InlineContentsResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+	)
+
+	err := requireAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.InlineContents[Init]", key.Project.Field(req.Project))
+
+	// Block concurrent updates from writing rows while their contents are being moved
+	_, err = db.LockLatestVersion(ctx, tx, req.Project)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS inline contents: project %v not found", req.Project)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS inline contents could not lock project %v: %v", req.Project, err)
+	}
+
+	contentEncoder := f.newContentEncoder()
+	defer contentEncoder.Close()
+
+	inlined, outlined, hashes, err := db.InlineProjectContents(ctx, tx, f.DbConn, f.ContentLookup, contentEncoder, req.Project, req.DryRun)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS inline contents %v: %v", req.Project, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS inline contents commit tx: %v", err)
+	}
+	logger.Debug(ctx, "FS.InlineContents[Commit]", key.Project.Field(req.Project), key.Count.Field(inlined))
+
+	// the inlined contents are only read from dl.contents by other projects' rows now, if any
+	_, err = db.GcContentHashes(ctx, f.DbConn, hashes)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS inline contents gc %v: %v", req.Project, err)
+	}
+
+	return &pb.InlineContentsResponse{
+		Inlined:  inlined,
+		Outlined: outlined,
+	}, nil
+}
+
+// defaultCacheCount is the number of packs included in a new cache version when the request does not say
+const defaultCacheCount = 100
+
+func (f *Fs) CreateCache(ctx context.Context, req *pb.CreateCacheRequest) (*pb.CreateCacheResponse, error) {
+	prefixes := req.Prefixes
+	if req.Prefix != "" || len(prefixes) == 0 {
+		prefixes = append([]string{req.Prefix}, prefixes...)
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Prefixes.Attribute(prefixes),
+		key.IncludeProjects.Attribute(req.IncludeProjects),
+		key.ExcludeProjects.Attribute(req.ExcludeProjects),
+	)
+
+	err := requireGlobalAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	count := int64(defaultCacheCount)
+	if req.Count != nil {
+		count = *req.Count
+	}
+	if count <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid CreateCacheRequest: count must be positive, got %v", count)
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.CreateCache[Init]", key.Prefixes.Field(prefixes), key.IncludeProjects.Field(req.IncludeProjects), key.ExcludeProjects.Field(req.ExcludeProjects), key.Count.Field(count))
+
+	version, err := db.CreateCacheFromSources(ctx, tx, db.CacheSources{
+		Prefixes:        prefixes,
+		IncludeProjects: req.IncludeProjects,
+		ExcludeProjects: req.ExcludeProjects,
+	}, count)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS create cache: %v", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS create cache commit tx: %v", err)
+	}
+	logger.Debug(ctx, "FS.CreateCache[Commit]", key.Version.Field(version))
+
+	return &pb.CreateCacheResponse{
+		Version: version,
+	}, nil
+}
+
+func (f *Fs) ListCacheVersions(ctx context.Context, req *pb.ListCacheVersionsRequest) (*pb.ListCacheVersionsResponse, error) {
+	err := requireGlobalAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.ListCacheVersions[Query]")
+
+	versions, err := db.ListCacheVersions(ctx, tx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS list cache versions: %v", err)
+	}
+
+	return &pb.ListCacheVersionsResponse{
+		Versions: versions,
+	}, nil
+}
+
+func (f *Fs) DeleteCacheVersion(ctx context.Context, req *pb.DeleteCacheVersionRequest) (*pb.DeleteCacheVersionResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Version.Attribute(req.Version),
+	)
+
+	err := requireGlobalAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.DeleteCacheVersion[Init]", key.Version.Field(req.Version))
+
+	hashes, err := db.DeleteCacheVersion(ctx, tx, req.Version)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS delete cache version: %v", err)
+	}
+	if errors.Is(err, db.ErrCacheVersionInUse) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS delete cache version: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS delete cache version: %v", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS delete cache version commit tx: %v", err)
+	}
+	logger.Debug(ctx, "FS.DeleteCacheVersion[Commit]", key.Version.Field(req.Version))
+
+	count, err := db.GcContentHashes(ctx, f.DbConn, hashes)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS gc cache version content hashes: %v", err)
+	}
+
+	return &pb.DeleteCacheVersionResponse{
+		Count: count,
+	}, nil
+}
+
+func (f *Fs) GetCacheVersionProjects(ctx context.Context, req *pb.GetCacheVersionProjectsRequest) (*pb.GetCacheVersionProjectsResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Version.Attribute(req.Version),
+	)
+
+	err := requireGlobalAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.GetCacheVersionProjects[Query]", key.Version.Field(req.Version))
+
+	projects, err := db.CacheVersionProjects(ctx, tx, req.Version)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS get cache version projects: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS get cache version projects: %v", err)
+	}
+
+	return &pb.GetCacheVersionProjectsResponse{
+		Projects: projects,
+	}, nil
+}
+
+func (f *Fs) GetCacheVersion(ctx context.Context, req *pb.GetCacheVersionRequest) (*pb.GetCacheVersionResponse, error) {
+	err := requireSharedReaderAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	version, err := db.LatestCacheVersion(ctx, tx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS get cache version: %v", err)
+	}
+
+	return &pb.GetCacheVersionResponse{
+		Version: version,
+	}, nil
+}
+
+func (f *Fs) RepairPaths(ctx context.Context, req *pb.RepairPathsRequest) (*pb.RepairPathsResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+	)
+
+	err := requireAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.RepairPaths[Init]", key.Project.Field(req.Project))
+
+	// Block concurrent updates from writing rows at the paths being repaired
+	lock := db.LockWritableVersion
+	if req.DryRun {
+		lock = db.LockLatestVersion
+	}
+
+	_, err = lock(ctx, tx, req.Project)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS repair paths: project %v not found", req.Project)
+	}
+	if errors.Is(err, db.ErrProjectFrozen) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS repair paths: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS repair paths could not lock project %v: %v", req.Project, err)
+	}
+
+	checked, repairs, err := db.RepairProjectPaths(ctx, tx, req.Project, req.DryRun)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS repair paths %v: %v", req.Project, err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS repair paths commit tx: %v", err)
+	}
+	logger.Debug(ctx, "FS.RepairPaths[Commit]", key.Project.Field(req.Project), key.Count.Field(checked))
+
+	return &pb.RepairPathsResponse{
+		Checked: checked,
+		Repairs: repairs,
+	}, nil
+}
+
+// defaultInvalidPathsLimit bounds the invalid paths returned by ValidatePaths when the request sets no limit
+const defaultInvalidPathsLimit = 1000
+
+func (f *Fs) ValidatePaths(ctx context.Context, req *pb.ValidatePathsRequest) (*pb.ValidatePathsResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+	)
+
+	project, err := requireProjectAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if project > -1 && req.Project != project {
+		return nil, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	}
+
+	limit := int64(defaultInvalidPathsLimit)
+	if req.Limit != nil {
+		if *req.Limit <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "FS validate paths: limit must be positive, got %v", *req.Limit)
+		}
+		limit = *req.Limit
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.ValidatePaths[Query]", key.Project.Field(req.Project))
+
+	checked, invalid, err := db.ValidateProjectPaths(ctx, tx, req.Project, limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS validate paths %v: %v", req.Project, err)
+	}
+
+	return &pb.ValidatePathsResponse{
+		Checked: checked,
+		Invalid: invalid,
+	}, nil
+}
+
+func (f *Fs) GetCache(req *pb.GetCacheRequest, stream pb.Fs_GetCacheServer) error {
+	ctx := stream.Context()
+	trace.SpanFromContext(ctx)
+
+	err := requireSharedReaderAuth(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	err = validateKnownHashes("GetCacheRequest", req.KnownHashes)
+	if err != nil {
+		return err
+	}
+
+	var version int64
+	if req.Version != nil {
+		version = *req.Version
+	} else {
+		version, err = db.LatestCacheVersion(ctx, tx)
+		if err != nil {
+			return status.Errorf(codes.Internal, "FS get cache version: %v", err)
+		}
+		if version == 0 {
+			return nil
+		}
+	}
+
+	logger.Debug(ctx, "FS.GetCache[Init]", key.Version.Field(version), key.KnownHashesCount.Field(len(req.KnownHashes)))
+
+	tars, closeFunc, err := db.GetCacheTars(ctx, tx, version, req.KnownHashes)
+	defer closeFunc(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "FS get cached tars: %v", err)
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "FS get cached tars: %v", err)
+	}
+
+	sent := false
+	for {
+		version, tar, hash, err := tars()
+		if err == io.EOF {
+			// Every pack is already known to the client, it still needs the version to record it
+			if !sent && version > 0 {
+				err = stream.Send(&pb.GetCacheResponse{
+					Version: version,
+					Format:  pb.GetCacheResponse_S2_TAR,
+				})
+				if err != nil {
+					return status.Errorf(codes.Internal, "FS send GetCacheResponse: %v", err)
+				}
+			}
+			break
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "FS get next tar: %v", err)
+		}
 
-			objectBuffer = append(objectBuffer, req.Object)
+		err = stream.Send(&pb.GetCacheResponse{
+			Version: version,
+			Format:  pb.GetCacheResponse_S2_TAR,
+			Bytes:   tar,
+			Hash:    hash.Bytes(),
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "FS send GetCacheResponse: %v", err)
 		}
+		sent = true
+	}
 
-		return nil
-	})
+	return nil
+}
+
+func (f *Fs) FanOutUpdate(req *pb.FanOutUpdateRequest, stream pb.Fs_FanOutUpdateServer) error {
+	ctx := stream.Context()
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Source),
+		key.FromVersion.Attribute(&req.FromVersion),
+		key.ToVersion.Attribute(req.ToVersion),
+		key.Targets.Attribute(req.Targets),
+	)
+
+	err := requireAdminAuth(ctx)
 	if err != nil {
 		return err
 	}
 
-	// No updates were received from the stream which prevented us from detecting the project and version
-	if len(objectBuffer) == 0 && len(packedBuffer) == 0 {
-		logger.Info(ctx, "FS.Update[Empty]")
-		return stream.SendAndClose(&pb.UpdateResponse{Version: -1})
+	base, changes, err := f.sourceChanges(ctx, req)
+	if err != nil {
+		return err
 	}
 
-	latestVersion := int64(-1)
-	nextVersion := int64(-1)
-	shouldUpdateVersion := false
+	logger.Info(ctx, "FS.FanOutUpdate[Init]",
+		key.Project.Field(req.Source),
+		key.FromVersion.Field(&req.FromVersion),
+		key.ToVersion.Field(req.ToVersion),
+		key.ObjectsCount.Field(len(changes)),
+	)
 
-	err = telemetry.Trace(ctx, "update-objects", func(ctx context.Context, span trace.Span) error {
-		latestVersion, err = db.LockLatestVersion(ctx, tx, project)
-		if errors.Is(err, db.ErrNotFound) {
-			return status.Errorf(codes.NotFound, "FS update missing latest version: %v", err)
-		}
+	for _, target := range req.Targets {
+		response, err := f.fanOutToProject(ctx, target, base, changes)
 		if err != nil {
-			return status.Errorf(codes.Internal, "FS update lock latest version: %v", err)
+			// A failing target must not prevent the remaining targets from being updated
+			logger.Warn(ctx, "FS.FanOutUpdate[Error]", key.Project.Field(target), zap.Error(err))
+			message := err.Error()
+			response = &pb.FanOutUpdateResponse{Project: target, Version: -1, Error: &message}
 		}
 
-		nextVersion = latestVersion + 1
-		logger.Info(ctx, "FS.Update[Init]", key.Project.Field(project), key.Version.Field(nextVersion))
+		err = stream.Send(response)
+		if err != nil {
+			return status.Errorf(codes.Internal, "FS send FanOutUpdateResponse: %v", err)
+		}
+	}
 
-		for _, object := range objectBuffer {
-			logger.Debug(ctx, "FS.Update[Object]",
-				key.Project.Field(project),
-				key.Version.Field(nextVersion),
-				key.ObjectPath.Field(object.Path),
-			)
+	return nil
+}
 
-			if object.Deleted {
-				err = db.DeleteObject(ctx, tx, project, nextVersion, object.Path)
-				shouldUpdateVersion = true
-			} else {
-				var contentChanged bool
-				contentChanged, err = db.UpdateObject(ctx, tx, f.DbConn, contentEncoder, project, nextVersion, object)
+func (f *Fs) sourceChanges(ctx context.Context, req *pb.FanOutUpdateRequest) (db.Snapshot, []*pb.Object, error) {
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
 
-				if contentChanged {
-					shouldUpdateVersion = true
-				}
-			}
+	vrange, err := db.NewVersionRange(ctx, tx, req.Source, &req.FromVersion, req.ToVersion)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, nil, status.Errorf(codes.NotFound, "FS fan out missing source latest version: %v", err)
+	}
+	if errors.Is(err, db.ErrVersionSquashed) {
+		return nil, nil, status.Errorf(codes.FailedPrecondition, "FS fan out: %v", err)
+	}
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "FS fan out source latest version: %v", err)
+	}
 
-			if err != nil {
-				return status.Errorf(codes.Internal, "FS update: %v", err)
-			}
-		}
+	if vrange.From > vrange.To {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "FS fan out from version %v is after to version %v", vrange.From, vrange.To)
+	}
 
-		return nil
-	})
+	base, err := db.GetSnapshot(ctx, tx, f.ContentLookup, req.Source, vrange.From)
 	if err != nil {
-		return err
+		return nil, nil, status.Errorf(codes.Internal, "FS fan out source snapshot at %v: %v", vrange.From, err)
 	}
 
-	err = telemetry.Trace(ctx, "update-packed-objects", func(ctx context.Context, span trace.Span) error {
-		for parent, objects := range packedBuffer {
-			logger.Debug(ctx, "FS.Update[PackedObject]",
-				key.Project.Field(project),
-				key.Version.Field(nextVersion),
-				key.ObjectsParent.Field(parent),
-				key.ObjectsCount.Field(len(objects)),
-			)
-
-			contentChanged, err := db.UpdatePackedObjects(ctx, tx, f.DbConn, project, nextVersion, parent, objects)
-			if err != nil {
-				return status.Errorf(codes.Internal, "FS update packed objects for %v: %v", parent, err)
-			}
+	updated, err := db.GetSnapshot(ctx, tx, f.ContentLookup, req.Source, vrange.To)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "FS fan out source snapshot at %v: %v", vrange.To, err)
+	}
 
-			if contentChanged {
-				shouldUpdateVersion = true
-			}
-		}
+	return base, db.DiffSnapshots(base, updated), nil
+}
 
-		return nil
-	})
+func (f *Fs) fanOutToProject(ctx context.Context, project int64, base db.Snapshot, changes []*pb.Object) (*pb.FanOutUpdateResponse, error) {
+	tx, close, err := f.DbConn.Connect(ctx)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("db connection unavailable: %w", err)
 	}
+	defer close(ctx)
 
-	if !shouldUpdateVersion {
-		return stream.SendAndClose(&pb.UpdateResponse{Version: latestVersion})
+	latestVersion, err := db.LockWritableVersion(ctx, tx, project)
+	if err != nil {
+		return nil, fmt.Errorf("lock latest version: %w", err)
 	}
+	nextVersion := latestVersion + 1
 
-	err = db.UpdateLatestVersion(ctx, tx, project, nextVersion)
+	current, err := db.GetSnapshot(ctx, tx, f.ContentLookup, project, latestVersion)
 	if err != nil {
-		return status.Errorf(codes.Internal, "FS update latest version: %v", err)
+		return nil, err
 	}
 
-	err = tx.Commit(ctx)
+	packManager, err := db.NewPackManager(ctx, tx, project)
 	if err != nil {
-		return status.Errorf(codes.Internal, "FS update commit tx: %v", err)
+		return nil, fmt.Errorf("create packed cache: %w", err)
 	}
 
-	logger.Debug(ctx, "FS.Update[Commit]", key.Project.Field(project), key.Version.Field(nextVersion))
+	contentEncoder := f.newContentEncoder()
+	defer contentEncoder.Close()
 
-	return stream.SendAndClose(&pb.UpdateResponse{Version: nextVersion})
-}
+	response := &pb.FanOutUpdateResponse{Project: project, Version: latestVersion}
+	packedBuffer := make(map[string][]*pb.Object)
 
-func (f *Fs) Rollback(ctx context.Context, req *pb.RollbackRequest) (*pb.RollbackResponse, error) {
-	trace.SpanFromContext(ctx).SetAttributes(
-		key.Project.Attribute(req.Project),
-	)
+	for _, change := range changes {
+		existing := current[change.Path]
 
-	err := requireAdminAuth(ctx)
-	if err != nil {
-		return nil, err
+		var wanted *pb.Object
+		if !change.Deleted {
+			wanted = change
+		}
+
+		// The target already matches the updated source
+		if db.SameObject(existing, wanted) {
+			continue
+		}
+
+		// The target has diverged from the base version on this path, keep its own changes
+		if !db.SameObject(existing, base[change.Path]) {
+			response.SkippedPaths = append(response.SkippedPaths, change.Path)
+			continue
+		}
+
+		packParent := packManager.IsPathPacked(change.Path)
+		if packParent != nil {
+			packedBuffer[*packParent] = append(packedBuffer[*packParent], change)
+			continue
+		}
+
+		if change.Deleted {
+			err = db.DeleteObject(ctx, tx, project, nextVersion, change.Path)
+		} else {
+			_, err = db.UpdateObject(ctx, tx, f.DbConn, contentEncoder, project, nextVersion, change)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		response.UpdatedCount += 1
 	}
 
-	tx, close, err := f.DbConn.Connect(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	for parent, objects := range packedBuffer {
+		_, err := db.UpdatePackedObjects(ctx, tx, f.DbConn, project, nextVersion, parent, objects)
+		if err != nil {
+			return nil, fmt.Errorf("update packed objects for %v: %w", parent, err)
+		}
+
+		response.UpdatedCount += int64(len(objects))
 	}
-	defer close(ctx)
 
-	logger.Debug(ctx, "FS.Rollback[Project]", key.Project.Field(req.Project), key.Version.Field(req.Version))
+	logger.Debug(ctx, "FS.FanOutUpdate[Project]",
+		key.Project.Field(project),
+		key.Version.Field(nextVersion),
+		key.Count.Field(response.UpdatedCount),
+		key.SkippedCount.Field(len(response.SkippedPaths)),
+	)
 
-	err = db.ResetProject(ctx, tx, req.Project, req.Version)
+	if response.UpdatedCount == 0 {
+		return response, nil
+	}
+
+	err = db.UpdateLatestVersion(ctx, tx, project, nextVersion)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS rollback project %v: %v", req.Project, err)
+		return nil, fmt.Errorf("update latest version: %w", err)
+	}
+
+	if f.QueueContentScans {
+		err = db.QueueContentScans(ctx, tx, project, nextVersion)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	err = tx.Commit(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS rollback commit tx: %v", err)
+		return nil, fmt.Errorf("commit tx: %w", err)
 	}
-	logger.Debug(ctx, "FS.Rollback[Commit]")
 
-	return &pb.RollbackResponse{}, nil
+	response.Version = nextVersion
+	return response, nil
 }
 
-func (f *Fs) Inspect(ctx context.Context, req *pb.InspectRequest) (*pb.InspectResponse, error) {
+const (
+	defaultMaxInlineDiffSize = 64 * 1024
+	binarySniffSize          = 8000
+)
+
+var textualContentTypes = []string{"application/json", "application/javascript", "application/xml", "image/svg+xml"}
+
+// isBinary uses the stored content type when available and otherwise looks for a NUL byte, like git does.
+func isBinary(object *pb.Object) bool {
+	if object.ContentType != nil {
+		mediaType, _, _ := strings.Cut(*object.ContentType, ";")
+		if strings.HasPrefix(mediaType, "text/") || slices.Contains(textualContentTypes, mediaType) {
+			return false
+		}
+		if mediaType != "application/octet-stream" {
+			return true
+		}
+	}
+
+	return bytes.IndexByte(object.Content[:min(len(object.Content), binarySniffSize)], 0) != -1
+}
+
+func (f *Fs) Diff(req *pb.DiffRequest, stream pb.Fs_DiffServer) error {
+	ctx := stream.Context()
 	trace.SpanFromContext(ctx).SetAttributes(
 		key.Project.Attribute(req.Project),
+		key.FromVersion.Attribute(&req.FromVersion),
+		key.ToVersion.Attribute(req.ToVersion),
 	)
 
-	err := requireAdminAuth(ctx)
+	project, err := requireProjectAuth(ctx)
 	if err != nil {
-		return nil, err
+		return err
+	}
+
+	if project > -1 && req.Project != project {
+		return status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
 	}
 
 	tx, close, err := f.DbConn.Connect(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+		return status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
 	}
 	defer close(ctx)
 
-	logger.Debug(ctx, "FS.Inspect[Query]", key.Project.Field(req.Project))
-
-	vrange, err := db.NewVersionRange(ctx, tx, req.Project, nil, nil)
+	vrange, err := db.NewVersionRange(ctx, tx, req.Project, &req.FromVersion, req.ToVersion)
 	if errors.Is(err, db.ErrNotFound) {
-		return nil, status.Errorf(codes.NotFound, "FS inspect missing latest version: %v", err)
+		return status.Errorf(codes.NotFound, "FS diff missing latest version: %v", err)
+	}
+	if errors.Is(err, db.ErrVersionSquashed) {
+		return status.Errorf(codes.FailedPrecondition, "FS diff: %v", err)
 	}
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS inspect latest version: %v", err)
+		return status.Errorf(codes.Internal, "FS diff latest version: %v", err)
+	}
+
+	maxInlineSize := int64(defaultMaxInlineDiffSize)
+	if req.MaxInlineSize != nil {
+		maxInlineSize = *req.MaxInlineSize
 	}
 
+	logger.Debug(ctx, "FS.Diff[Init]",
+		key.Project.Field(req.Project),
+		key.FromVersion.Field(&vrange.From),
+		key.ToVersion.Field(&vrange.To),
+	)
+
 	packManager, err := db.NewPackManager(ctx, tx, req.Project)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS create packed cache: %v", err)
+		return status.Errorf(codes.Internal, "FS create packed cache: %v", err)
 	}
 
-	query := &pb.ObjectQuery{
-		Path:     "",
-		IsPrefix: true,
+	queries := req.Queries
+	if len(queries) == 0 {
+		queries = []*pb.ObjectQuery{{Path: "", IsPrefix: true}}
+	}
+
+	for _, query := range batchQueries(queries) {
+		err = validateObjectQuery(query)
+		if err != nil {
+			return err
+		}
+
+		var changes []*pb.Object
+		err = readObjects(ctx, tx, f.ContentLookup, packManager, req.Project, vrange, query, func(object *pb.Object) {
+			changes = append(changes, object)
+		})
+		if err != nil {
+			return status.Errorf(codes.Internal, "FS diff changed objects: %v", err)
+		}
+
+		if len(changes) == 0 {
+			continue
+		}
+
+		previous := make(map[string]*pb.Object)
+		if vrange.From > 0 {
+			var paths []string
+			for _, change := range changes {
+				paths = append(paths, change.Path)
+			}
+
+			for _, batch := range batchQueries([]*pb.ObjectQuery{{Paths: paths}}) {
+				err = readObjects(ctx, tx, f.ContentLookup, packManager, req.Project, db.VersionRange{From: 0, To: vrange.From}, batch, func(object *pb.Object) {
+					previous[object.Path] = object
+				})
+				if err != nil {
+					return status.Errorf(codes.Internal, "FS diff previous objects: %v", err)
+				}
+			}
+		}
+
+		for _, change := range changes {
+			old := previous[change.Path]
+
+			response := &pb.DiffResponse{
+				Version: vrange.To,
+				Path:    change.Path,
+			}
+
+			switch {
+			case change.Deleted:
+				if old == nil {
+					continue
+				}
+				response.Change = pb.DiffResponse_DELETED
+			case old == nil:
+				response.Change = pb.DiffResponse_ADDED
+			case db.SameObject(old, change):
+				// Unchanged files of an updated pack
+				continue
+			case bytes.Equal(old.Content, change.Content):
+				response.Change = pb.DiffResponse_MODE_CHANGED
+			default:
+				response.Change = pb.DiffResponse_MODIFIED
+			}
+
+			inline := true
+			if old != nil {
+				response.OldMode = old.Mode
+				response.OldSize = int64(len(old.Content))
+				response.Binary = isBinary(old)
+				inline = response.OldSize <= maxInlineSize
+			}
+			if !change.Deleted {
+				response.NewMode = change.Mode
+				response.NewSize = int64(len(change.Content))
+				response.Binary = response.Binary || isBinary(change)
+				inline = inline && response.NewSize <= maxInlineSize
+			}
+
+			response.Inline = inline && !response.Binary
+			if response.Inline {
+				if old != nil {
+					response.OldContent = old.Content
+				}
+				if !change.Deleted {
+					response.NewContent = change.Content
+				}
+			}
+
+			err = stream.Send(response)
+			if err != nil {
+				return status.Errorf(codes.Internal, "FS send DiffResponse: %v", err)
+			}
+		}
 	}
-	objects, err := db.GetObjects(ctx, tx, f.ContentLookup, packManager, req.Project, vrange, query)
+
+	return nil
+}
+
+func readObjects(ctx context.Context, tx pgx.Tx, lookup *db.ContentLookup, packManager *db.PackManager, project int64, vrange db.VersionRange, query *pb.ObjectQuery, fn func(*pb.Object)) error {
+	objects, err := db.GetObjects(ctx, tx, lookup, packManager, project, vrange, query, 0)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS get objects: %v", err)
+		return err
 	}
 
-	live_objects_count := int64(0)
 	for {
-		_, err := objects()
+		object, err := objects()
 		if err == db.SKIP {
 			continue
 		}
 		if err == io.EOF {
-			break
+			return nil
 		}
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "FS get next object: %v", err)
+			return err
 		}
 
-		live_objects_count += 1
+		fn(object)
 	}
+}
 
-	total_objects_count, err := db.TotalObjectsCount(ctx, tx, req.Project)
+// maxStatPaths bounds the number of paths a single StatPaths call can describe
+const maxStatPaths = 10_000
+
+func (f *Fs) StatPaths(ctx context.Context, req *pb.StatPathsRequest) (*pb.StatPathsResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.ToVersion.Attribute(req.Version),
+		key.ObjectsCount.Attribute(len(req.Paths)),
+	)
+
+	project, err := requireProjectAuth(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS inspect project: %v", err)
+		return nil, err
 	}
 
-	return &pb.InspectResponse{
-		Project:           req.Project,
-		LatestVersion:     vrange.To,
-		LiveObjectsCount:  live_objects_count,
-		TotalObjectsCount: total_objects_count,
-	}, nil
-}
+	if project > -1 && req.Project != project {
+		return nil, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	}
 
-func (f *Fs) Snapshot(ctx context.Context, req *pb.SnapshotRequest) (*pb.SnapshotResponse, error) {
-	if f.Env != environment.Dev && f.Env != environment.Test {
-		return nil, status.Errorf(codes.Unimplemented, "FS snapshot only implemented in dev and test environments")
+	if len(req.Paths) > maxStatPaths {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid StatPathsRequest: too many paths (%v > %v)", len(req.Paths), maxStatPaths)
 	}
 
-	err := requireAdminAuth(ctx)
+	version, err := pinToSnapshot("StatPathsRequest", req.Project, req.SnapshotToken, req.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -785,24 +3605,72 @@ func (f *Fs) Snapshot(ctx context.Context, req *pb.SnapshotRequest) (*pb.Snapsho
 	}
 	defer close(ctx)
 
-	logger.Debug(ctx, "FS.Snapshot[Query]")
+	vrange, err := db.NewVersionRange(ctx, tx, req.Project, nil, version)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS stat paths missing latest version: %v", err)
+	}
+	if errors.Is(err, db.ErrVersionSquashed) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS stat paths: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS stat paths latest version: %v", err)
+	}
+
+	logger.Debug(ctx, "FS.StatPaths[Init]",
+		key.Project.Field(req.Project),
+		key.ToVersion.Field(&vrange.To),
+		key.ObjectsCount.Field(len(req.Paths)),
+	)
 
-	projects, err := db.ListProjects(ctx, tx)
+	packManager, err := db.NewPackManager(ctx, tx, req.Project)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS snapshot: %v", err)
+		return nil, status.Errorf(codes.Internal, "FS create packed cache: %v", err)
 	}
 
-	return &pb.SnapshotResponse{
-		Projects: projects,
-	}, nil
+	response := &pb.StatPathsResponse{Version: vrange.To}
+
+	for start := 0; start < len(req.Paths); start += pathsBatchSize {
+		end := min(start+pathsBatchSize, len(req.Paths))
+
+		stats, err := db.StatPaths(ctx, tx, f.ContentLookup, packManager, req.Project, vrange.To, req.Paths[start:end])
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS stat paths: %v", err)
+		}
+
+		response.Stats = append(response.Stats, stats...)
+	}
+
+	return response, nil
 }
 
-func (f *Fs) Reset(ctx context.Context, req *pb.ResetRequest) (*pb.ResetResponse, error) {
-	if f.Env != environment.Dev && f.Env != environment.Test {
-		return nil, status.Errorf(codes.Unimplemented, "FS reset only implemented in dev and test environments")
+// maxListPaths bounds the number of paths a single ListPaths call returns
+const maxListPaths = 10_000
+
+func (f *Fs) ListPaths(ctx context.Context, req *pb.ListPathsRequest) (*pb.ListPathsResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.ToVersion.Attribute(req.Version),
+		key.Prefix.Attribute(req.Prefix),
+	)
+
+	project, err := requireProjectAuth(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	err := requireAdminAuth(ctx)
+	if project > -1 && req.Project != project {
+		return nil, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	}
+
+	limit := int64(maxListPaths)
+	if req.Limit != nil {
+		if *req.Limit <= 0 {
+			return nil, status.Error(codes.InvalidArgument, "Invalid ListPaths limit: must be positive")
+		}
+		limit = min(*req.Limit, maxListPaths)
+	}
+
+	version, err := pinToSnapshot("ListPathsRequest", req.Project, req.SnapshotToken, req.Version)
 	if err != nil {
 		return nil, err
 	}
@@ -813,197 +3681,237 @@ func (f *Fs) Reset(ctx context.Context, req *pb.ResetRequest) (*pb.ResetResponse
 	}
 	defer close(ctx)
 
-	logger.Debug(ctx, "FS.Reset[Init]")
-
-	if len(req.Projects) == 0 {
-		logger.Debug(ctx, "FS.Reset[All]")
-
-		err = db.ResetAll(ctx, tx)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "FS reset all: %v", err)
-		}
-	} else {
-		var projects []int64
-
-		for _, project := range req.Projects {
-			logger.Debug(ctx, "FS.Reset[Project]", key.Project.Field(project.Id), key.Version.Field(project.Version))
-			err = db.ResetProject(ctx, tx, project.Id, project.Version)
-			if err != nil {
-				return nil, status.Errorf(codes.Internal, "FS reset project %v: %v", project.Id, err)
-			}
-			projects = append(projects, project.Id)
-		}
-
-		err = db.DropOtherProjects(ctx, tx, projects)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "FS reset drop others: %v", err)
-		}
+	vrange, err := db.NewVersionRange(ctx, tx, req.Project, nil, version)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS list paths missing latest version: %v", err)
+	}
+	if errors.Is(err, db.ErrVersionSquashed) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS list paths: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS list paths latest version: %v", err)
 	}
 
-	err = tx.Commit(ctx)
+	logger.Debug(ctx, "FS.ListPaths[Query]",
+		key.Project.Field(req.Project),
+		key.ToVersion.Field(&vrange.To),
+		key.Prefix.Field(req.Prefix),
+	)
+
+	stats, truncated, err := db.ListPaths(ctx, tx, f.ContentLookup, req.Project, vrange.To, req.Prefix, limit)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS reset commit tx: %v", err)
+		return nil, status.Errorf(codes.Internal, "FS list paths: %v", err)
 	}
-	logger.Debug(ctx, "FS.Reset[Commit]")
 
-	return &pb.ResetResponse{}, nil
+	return &pb.ListPathsResponse{
+		Version:   vrange.To,
+		Stats:     stats,
+		Truncated: truncated,
+	}, nil
 }
 
-func (f *Fs) GcProject(ctx context.Context, req *pb.GcProjectRequest) (*pb.GcProjectResponse, error) {
+func (f *Fs) DirectorySizes(ctx context.Context, req *pb.DirectorySizesRequest) (*pb.DirectorySizesResponse, error) {
 	trace.SpanFromContext(ctx).SetAttributes(
 		key.Project.Attribute(req.Project),
-		key.KeepVersions.Attribute(req.KeepVersions),
-		key.FromVersion.Attribute(req.FromVersion),
+		key.ToVersion.Attribute(req.Version),
+		key.Prefix.Attribute(req.Prefix),
 	)
 
-	err := requireAdminAuth(ctx)
+	project, err := requireProjectAuth(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if req.KeepVersions <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "Invalid GC KeepVersions: cannot keep 0 versions")
+	if project > -1 && req.Project != project {
+		return nil, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
 	}
 
-	logger.Debug(ctx, "FS.GcProject[Init]", key.Project.Field(req.Project))
+	version, err := pinToSnapshot("DirectorySizesRequest", req.Project, req.SnapshotToken, req.Version)
+	if err != nil {
+		return nil, err
+	}
 
-	fromVersion := int64(0)
-	if req.FromVersion != nil {
-		fromVersion = *req.FromVersion
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
 	}
+	defer close(ctx)
 
-	hashes, err := db.GcProjectObjects(ctx, f.DbConn, req.Project, req.KeepVersions, fromVersion)
+	vrange, err := db.NewVersionRange(ctx, tx, req.Project, nil, version)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS directory sizes missing latest version: %v", err)
+	}
+	if errors.Is(err, db.ErrVersionSquashed) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS directory sizes: %v", err)
+	}
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS gc project objects %v: %v", req.Project, err)
+		return nil, status.Errorf(codes.Internal, "FS directory sizes latest version: %v", err)
 	}
 
-	count, err := db.GcContentHashes(ctx, f.DbConn, hashes)
+	logger.Debug(ctx, "FS.DirectorySizes[Query]",
+		key.Project.Field(req.Project),
+		key.ToVersion.Field(&vrange.To),
+		key.Prefix.Field(req.Prefix),
+	)
+
+	directories, err := f.DirectoryRollups.Rollup(ctx, tx, f.ContentLookup, req.Project, vrange.To, req.Prefix)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS gc content hashes %v: %v", req.Project, err)
+		return nil, status.Errorf(codes.Internal, "FS directory sizes: %v", err)
 	}
 
-	return &pb.GcProjectResponse{
-		Count:   count,
-		Project: req.Project,
-	}, nil
+	response := &pb.DirectorySizesResponse{
+		Version:     vrange.To,
+		Directories: directories,
+	}
+	for _, directory := range directories {
+		response.Files += directory.Files
+		response.Bytes += directory.Bytes
+	}
+
+	return response, nil
 }
 
-func (f *Fs) GcRandomProjects(ctx context.Context, req *pb.GcRandomProjectsRequest) (*pb.GcRandomProjectsResponse, error) {
+// maxSampleObjects bounds the number of objects a single SampleObjects call can return
+const maxSampleObjects = 1000
+
+func (f *Fs) SampleObjects(req *pb.SampleObjectsRequest, stream pb.Fs_SampleObjectsServer) error {
+	ctx := stream.Context()
 	trace.SpanFromContext(ctx).SetAttributes(
-		key.SampleRate.Attribute(req.Sample),
-		key.KeepVersions.Attribute(req.KeepVersions),
-		key.FromVersion.Attribute(req.FromVersion),
+		key.ObjectsCount.Attribute(int(req.Count)),
 	)
 
-	ctx, span := telemetry.Start(ctx, "fs.gc-random-projects")
-	defer span.End()
+	if req.Project != nil {
+		trace.SpanFromContext(ctx).SetAttributes(key.Project.Attribute(*req.Project))
 
-	err := requireAdminAuth(ctx)
-	if err != nil {
-		return nil, err
-	}
+		project, err := requireProjectAuth(ctx)
+		if err != nil {
+			return err
+		}
 
-	if req.KeepVersions <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "Invalid GC KeepVersions: cannot keep 0 versions")
+		if project > -1 && *req.Project != project {
+			return status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+		}
+	} else {
+		// tenant scoped admins only ever see the objects of their own projects
+		err := requireAdminAuth(ctx)
+		if err != nil {
+			return err
+		}
 	}
 
-	logger.Debug(ctx, "FS.GcRandomProjects[Init]", key.SampleRate.Field(req.Sample))
-
-	fromVersion := int64(0)
-	if req.FromVersion != nil {
-		fromVersion = *req.FromVersion
+	if req.Count <= 0 {
+		return status.Error(codes.InvalidArgument, "Invalid SampleObjects count: must be positive")
 	}
-
-	projects, err := db.RandomProjects(ctx, f.DbConn, req.Sample)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS gc random projects %f: %v", req.Sample, err)
+	if req.Count > maxSampleObjects {
+		return status.Errorf(codes.InvalidArgument, "Invalid SampleObjects count: %d exceeds the limit of %d", req.Count, maxSampleObjects)
 	}
 
-	hashes, err := db.GcProjectsObjects(ctx, f.DbConn, projects, req.KeepVersions, fromVersion)
+	tx, close, err := f.DbConn.Connect(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS gc random project objects: %v", err)
+		return status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
 	}
+	defer close(ctx)
 
-	count, err := db.GcContentHashes(ctx, f.DbConn, hashes)
+	maxContentSize := f.maxContentSendSize(&req.MaxContentSize)
+
+	logger.Debug(ctx, "FS.SampleObjects[Init]",
+		key.ObjectsCount.Field(int(req.Count)),
+		key.MaxContentSize.Field(maxContentSize),
+	)
+
+	err = db.SampleObjects(ctx, tx, f.ContentLookup, req.Project, req.Count, req.IncludeContent, maxContentSize, func(project int64, object *pb.Objekt) error {
+		return stream.Send(&pb.SampleObjectsResponse{Project: project, Object: object})
+	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS gc random content hashes: %v", err)
+		return status.Errorf(codes.Internal, "FS sample objects: %v", err)
 	}
 
-	return &pb.GcRandomProjectsResponse{
-		Count:    count,
-		Projects: projects,
-	}, nil
+	return nil
 }
 
-func (f *Fs) GcContents(ctx context.Context, req *pb.GcContentsRequest) (*pb.GcContentsResponse, error) {
+func (f *Fs) ScanStatus(ctx context.Context, req *pb.ScanStatusRequest) (*pb.ScanStatusResponse, error) {
 	trace.SpanFromContext(ctx).SetAttributes(
-		key.SampleRate.Attribute(req.Sample),
+		key.Project.Attribute(req.Project),
+		key.ToVersion.Attribute(req.Version),
 	)
 
-	err := requireAdminAuth(ctx)
+	project, err := requireProjectAuth(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	logger.Debug(ctx, "FS.GcContents[Init]")
+	if project > -1 && req.Project != project {
+		return nil, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	}
 
-	hashes, err := db.RandomContents(ctx, f.DbConn, req.Sample)
+	tx, close, err := f.DbConn.Connect(ctx)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS gc random contents %f: %v", req.Sample, err)
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
 	}
+	defer close(ctx)
 
-	count, err := db.GcContentHashes(ctx, f.DbConn, hashes)
+	vrange, err := db.NewVersionRange(ctx, tx, req.Project, nil, req.Version)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS scan status missing latest version: %v", err)
+	}
+	if errors.Is(err, db.ErrVersionSquashed) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS scan status: %v", err)
+	}
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "FS gc random content hashes: %v", err)
+		return nil, status.Errorf(codes.Internal, "FS scan status latest version: %v", err)
 	}
 
-	return &pb.GcContentsResponse{
-		Count: count,
-	}, nil
+	logger.Debug(ctx, "FS.ScanStatus[Query]",
+		key.Project.Field(req.Project),
+		key.ToVersion.Field(&vrange.To),
+	)
+
+	response, err := db.ScanStatus(ctx, tx, req.Project, vrange.To)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS scan status: %v", err)
+	}
+
+	return response, nil
 }
 
-func (f *Fs) GetCache(req *pb.GetCacheRequest, stream pb.Fs_GetCacheServer) error {
-	ctx := stream.Context()
-	trace.SpanFromContext(ctx)
+// ReadSnapshot returns the latest version of a project along with a token pinning later reads to it,
+// so that reads spread over several calls all see the same version even if updates land in between.
+func (f *Fs) ReadSnapshot(ctx context.Context, req *pb.ReadSnapshotRequest) (*pb.ReadSnapshotResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+	)
 
-	err := requireSharedReaderAuth(ctx)
+	project, err := requireProjectAuth(ctx)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if project > -1 && req.Project != project {
+		return nil, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
 	}
 
 	tx, close, err := f.DbConn.Connect(ctx)
 	if err != nil {
-		return status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
 	}
 	defer close(ctx)
 
-	logger.Debug(ctx, "FS.GetCache[Init]")
-
-	tars, closeFunc, err := db.GetCacheTars(ctx, tx)
-	defer closeFunc(ctx)
+	vrange, err := db.NewVersionRange(ctx, tx, req.Project, nil, nil)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS read snapshot missing latest version: %v", err)
+	}
 	if err != nil {
-		return status.Errorf(codes.Internal, "FS get cached tars: %v", err)
+		return nil, status.Errorf(codes.Internal, "FS read snapshot latest version: %v", err)
 	}
 
-	for {
-		version, tar, hash, err := tars()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return status.Errorf(codes.Internal, "FS get next tar: %v", err)
-		}
-
-		err = stream.Send(&pb.GetCacheResponse{
-			Version: version,
-			Format:  pb.GetCacheResponse_S2_TAR,
-			Bytes:   tar,
-			Hash:    hash.Bytes(),
-		})
-		if err != nil {
-			return status.Errorf(codes.Internal, "FS send GetCacheResponse: %v", err)
-		}
-	}
+	logger.Debug(ctx, "FS.ReadSnapshot[Query]",
+		key.Project.Field(req.Project),
+		key.Version.Field(vrange.To),
+	)
 
-	return nil
+	return &pb.ReadSnapshotResponse{
+		Project: req.Project,
+		Version: vrange.To,
+		Token:   encodeSnapshotToken(req.Project, vrange.To),
+	}, nil
 }
@@ -0,0 +1,252 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"github.com/gadget-inc/dateilager/internal/transform"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// provisionAttempts bounds how many times a provisioning transaction aborted by a conflict is run
+	provisionAttempts = 3
+	provisionBackoff  = 50 * time.Millisecond
+)
+
+// isTransactionConflict reports whether err is a serialization failure or a deadlock, which a retry of the whole
+// transaction can get past.
+func isTransactionConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && (pgErr.Code == "40001" || pgErr.Code == "40P01")
+}
+
+// ProvisionProject creates a project, clones a template into it and labels it in a single transaction, so a failure
+// at any step leaves nothing behind. Transactions aborted by a conflict with another one are retried.
+func (f *Fs) ProvisionProject(ctx context.Context, req *pb.ProvisionProjectRequest) (*pb.ProvisionProjectResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Id),
+		key.Template.Attribute(req.Template),
+		key.PackPatterns.Attribute(req.PackPatterns),
+		key.Transforms.Attribute(req.Transforms),
+	)
+
+	err := requireAdminAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = transform.NewSet(req.Transforms)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "FS provision project %v: %v", req.Id, err)
+	}
+
+	err = validateIdempotencyKey("ProvisionProjectRequest", req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	for label := range req.Labels {
+		if label == "" {
+			return nil, status.Error(codes.InvalidArgument, "Invalid ProvisionProjectRequest: label keys cannot be empty")
+		}
+	}
+
+	if req.Template == nil && req.TemplateVersion != nil {
+		return nil, status.Error(codes.InvalidArgument, "Invalid ProvisionProjectRequest: template version without a template")
+	}
+
+	if tenant := tenantFromContext(ctx); tenant != nil && req.Tenant != nil && *req.Tenant != *tenant {
+		return nil, status.Errorf(codes.PermissionDenied, "FS provision project %v: cannot create a project for another tenant", req.Id)
+	}
+
+	for attempt := 1; ; attempt++ {
+		response, err := f.provisionProject(ctx, req)
+		if err == nil || !isTransactionConflict(err) {
+			return response, err
+		}
+
+		if attempt == provisionAttempts {
+			return nil, status.Errorf(codes.Aborted, "FS provision project %v: %v", req.Id, err)
+		}
+
+		logger.Info(ctx, "FS.ProvisionProject[Retry]", key.Project.Field(req.Id), key.Attempt.Field(attempt), zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-time.After(time.Duration(attempt) * provisionBackoff):
+		}
+	}
+}
+
+// provisionProject runs one provisioning transaction. Transaction conflicts are returned as is for the caller to
+// retry, every other error is a gRPC status.
+func (f *Fs) provisionProject(ctx context.Context, req *pb.ProvisionProjectRequest) (*pb.ProvisionProjectResponse, error) {
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.ProvisionProject[Init]",
+		key.Project.Field(req.Id),
+		key.Template.Field(req.Template),
+	)
+
+	internal := func(err error) error {
+		if isTransactionConflict(err) {
+			return err
+		}
+		return status.Errorf(codes.Internal, "FS provision project %v: %v", req.Id, err)
+	}
+
+	if req.IdempotencyKey != nil {
+		version, found, err := db.LookupIdempotencyKey(ctx, tx, req.Id, *req.IdempotencyKey)
+		if err != nil {
+			return nil, internal(err)
+		}
+		if found {
+			labels, err := db.GetProjectLabels(ctx, tx, req.Id)
+			if err != nil {
+				return nil, internal(err)
+			}
+
+			logger.Info(ctx, "FS.ProvisionProject[Replayed]", key.Project.Field(req.Id), key.Version.Field(version))
+			return &pb.ProvisionProjectResponse{Version: version, Labels: labels}, nil
+		}
+	}
+
+	err = telemetry.Trace(ctx, "provision-new-project", func(ctx context.Context, _ trace.Span) error {
+		err := db.CreateProject(ctx, tx, req.Id, req.PackPatterns, req.Transforms)
+		if err != nil {
+			if err.Error() == "project id already exists" {
+				return status.Errorf(codes.AlreadyExists, "FS provision project %v, %v", req.Id, err)
+			}
+			return internal(err)
+		}
+
+		if req.IncludeInCache != nil {
+			err = db.SetProjectCacheInclusion(ctx, tx, req.Id, *req.IncludeInCache)
+			if err != nil {
+				return internal(err)
+			}
+		}
+
+		if req.Tenant != nil {
+			err = db.SetProjectTenant(ctx, tx, req.Id, *req.Tenant)
+			if err != nil {
+				return internal(err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var version int64
+	if req.Template != nil {
+		err = telemetry.Trace(ctx, "provision-clone-template", func(ctx context.Context, span trace.Span) error {
+			samePackPatterns, err := db.HasSamePackPattern(ctx, tx, *req.Template, req.Id)
+			if err != nil {
+				return internal(err)
+			}
+			if !samePackPatterns {
+				return status.Errorf(codes.InvalidArgument, "FS provision project %v: template %v has different pack patterns", req.Id, *req.Template)
+			}
+
+			vrange, err := db.NewVersionRange(ctx, tx, *req.Template, nil, req.TemplateVersion)
+			if errors.Is(err, db.ErrNotFound) {
+				return status.Errorf(codes.NotFound, "FS provision project %v: template %v not found", req.Id, *req.Template)
+			}
+			if errors.Is(err, db.ErrVersionSquashed) {
+				return status.Errorf(codes.FailedPrecondition, "FS provision project %v: %v", req.Id, err)
+			}
+			if err != nil {
+				return internal(err)
+			}
+			span.SetAttributes(key.Version.Attribute(vrange.To))
+
+			if vrange.To == 0 {
+				// an empty template has nothing to clone, the project starts empty like a new one
+				err = db.SetProjectTemplate(ctx, tx, req.Id, *req.Template)
+				if err != nil {
+					return internal(err)
+				}
+				return nil
+			}
+
+			version = 1
+			err = db.CloneToProject(ctx, tx, *req.Template, req.Id, vrange.To, version)
+			if err != nil {
+				return internal(err)
+			}
+
+			err = db.UpdateLatestVersion(ctx, tx, req.Id, version)
+			if err != nil {
+				return internal(err)
+			}
+
+			err = db.AnnotateVersion(ctx, tx, req.Id, version, req.Message, req.Author)
+			if err != nil {
+				return internal(err)
+			}
+
+			err = db.SetProjectTemplate(ctx, tx, req.Id, *req.Template)
+			if err != nil {
+				return internal(err)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var labels map[string]string
+	err = telemetry.Trace(ctx, "provision-set-labels", func(ctx context.Context, _ trace.Span) error {
+		var err error
+		labels, err = db.SetProjectLabels(ctx, tx, req.Id, req.Labels, nil)
+		if err != nil {
+			return internal(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if req.IdempotencyKey != nil {
+		err = db.SaveIdempotencyKey(ctx, tx, req.Id, *req.IdempotencyKey, version)
+		if err != nil {
+			return nil, internal(err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		if isTransactionConflict(err) {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "FS provision project commit tx: %v", err)
+	}
+	logger.Debug(ctx, "FS.ProvisionProject[Commit]", key.Project.Field(req.Id), key.Version.Field(version))
+
+	return &pb.ProvisionProjectResponse{
+		Version: version,
+		Labels:  labels,
+	}, nil
+}
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxRestorePaths bounds the number of paths a single RestorePaths call restores
+const maxRestorePaths = 1_000
+
+// RestorePaths re-adds deleted paths in a new version. Each path is restored as it was at the last version it existed
+// at, or at FromVersion when it is set. Paths that exist at the latest version are rejected, restoring them would
+// overwrite their current content.
+func (f *Fs) RestorePaths(ctx context.Context, req *pb.RestorePathsRequest) (*pb.RestorePathsResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.FromVersion.Attribute(req.FromVersion),
+		key.ObjectsCount.Attribute(len(req.Paths)),
+	)
+
+	project, err := requireProjectAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if project > -1 && req.Project != project {
+		return nil, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	}
+
+	if len(req.Paths) == 0 || len(req.Paths) > maxRestorePaths {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid RestorePathsRequest: between 1 and %v paths must be restored, got %v", maxRestorePaths, len(req.Paths))
+	}
+
+	var paths []string
+	for _, path := range req.Paths {
+		err = pb.ValidatePath(path)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid RestorePathsRequest: %v", err)
+		}
+
+		canonical, err := pb.CanonicalPath(path)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid RestorePathsRequest: %v", err)
+		}
+		if !slices.Contains(paths, canonical) {
+			paths = append(paths, canonical)
+		}
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.RestorePaths[Init]", key.Project.Field(req.Project), key.FromVersion.Field(req.FromVersion), key.ObjectsCount.Field(len(paths)))
+
+	latestVersion, err := db.LockWritableVersion(ctx, tx, req.Project)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS restore paths: %v", err)
+	}
+	if errors.Is(err, db.ErrProjectFrozen) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS restore paths: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS restore paths: %v", err)
+	}
+
+	packManager, err := db.NewPackManager(ctx, tx, req.Project)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS create packed cache: %v", err)
+	}
+
+	stats, err := db.StatPaths(ctx, tx, f.ContentLookup, packManager, req.Project, latestVersion, paths)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS restore paths: %v", err)
+	}
+
+	var live []string
+	for _, stat := range stats {
+		if stat.Exists {
+			live = append(live, stat.Path)
+		}
+	}
+	if len(live) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS restore paths: %v exist at version %v", strings.Join(live, ", "), latestVersion)
+	}
+
+	versions := make(map[string]int64, len(paths))
+	if req.FromVersion != nil {
+		if *req.FromVersion > latestVersion {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid RestorePathsRequest: version %v is after the latest version %v", *req.FromVersion, latestVersion)
+		}
+
+		_, err = db.NewVersionRange(ctx, tx, req.Project, nil, req.FromVersion)
+		if errors.Is(err, db.ErrVersionSquashed) {
+			return nil, status.Errorf(codes.FailedPrecondition, "FS restore paths: %v", err)
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS restore paths: %v", err)
+		}
+
+		for _, path := range paths {
+			versions[path] = *req.FromVersion
+		}
+	} else {
+		versions, err = db.LastLiveVersions(ctx, tx, packManager, req.Project, latestVersion, paths)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS restore paths: %v", err)
+		}
+	}
+
+	// paths restored from the same version are read together
+	byVersion := make(map[int64][]string)
+	var missing []string
+	for _, path := range paths {
+		version, ok := versions[path]
+		if !ok {
+			missing = append(missing, path)
+			continue
+		}
+		byVersion[version] = append(byVersion[version], path)
+	}
+
+	objects := make(map[string]*pb.Object, len(paths))
+	for version, versionPaths := range byVersion {
+		stream, err := db.GetObjects(ctx, tx, f.ContentLookup, packManager, req.Project, db.VersionRange{From: 0, To: version}, &pb.ObjectQuery{Paths: versionPaths}, 0)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS restore paths: %v", err)
+		}
+
+		for {
+			object, err := stream()
+			if err == db.SKIP {
+				continue
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "FS restore paths: %v", err)
+			}
+
+			if !object.Deleted {
+				objects[object.Path] = object
+			}
+		}
+
+		for _, path := range versionPaths {
+			if _, ok := objects[path]; !ok {
+				missing = append(missing, path)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return nil, status.Errorf(codes.NotFound, "FS restore paths: no version of project %v to restore %v from", req.Project, strings.Join(missing, ", "))
+	}
+
+	nextVersion := latestVersion + 1
+	response := &pb.RestorePathsResponse{Version: nextVersion}
+
+	contentEncoder := f.newContentEncoder()
+	defer contentEncoder.Close()
+
+	packedBuffer := make(map[string][]*pb.Object)
+	for _, path := range paths {
+		object := objects[path]
+		response.Restored = append(response.Restored, &pb.RestoredPath{Path: path, FromVersion: versions[path]})
+
+		packParent := packManager.IsPathPacked(path)
+		if packParent != nil {
+			packedBuffer[*packParent] = append(packedBuffer[*packParent], object)
+			continue
+		}
+
+		_, err = db.UpdateObject(ctx, tx, f.DbConn, contentEncoder, req.Project, nextVersion, object)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS restore paths: %v", err)
+		}
+	}
+
+	for parent, packedObjects := range packedBuffer {
+		_, err = db.UpdatePackedObjects(ctx, tx, f.DbConn, req.Project, nextVersion, parent, packedObjects)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS restore packed paths for %v: %v", parent, err)
+		}
+	}
+
+	err = db.UpdateLatestVersion(ctx, tx, req.Project, nextVersion)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS restore paths latest version: %v", err)
+	}
+
+	err = db.AnnotateVersion(ctx, tx, req.Project, nextVersion, req.Message, req.Author)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS restore paths annotate version: %v", err)
+	}
+
+	if f.QueueContentScans {
+		err = db.QueueContentScans(ctx, tx, req.Project, nextVersion)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS restore paths: %v", err)
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS restore paths commit tx: %v", err)
+	}
+	logger.Info(ctx, "FS.RestorePaths[Commit]", key.Project.Field(req.Project), key.Version.Field(nextVersion), key.ObjectsCount.Field(len(paths)))
+
+	f.precomputeCheckout(ctx, req.Project, nextVersion)
+
+	return response, nil
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// resumeCursorPrefix versions the cursor format, cursors are opaque to clients
+const resumeCursorPrefix = "c1"
+
+// resumeCursor is the position of an object in a Get stream: the version range read, the index of the batched query
+// it was returned by and the object itself.
+type resumeCursor struct {
+	project int64
+	vrange  db.VersionRange
+	query   int
+	after   db.ResumePoint
+}
+
+func (c resumeCursor) encode() string {
+	cursor := fmt.Sprintf("%s:%d:%d:%d:%d:%t:%s", resumeCursorPrefix, c.project, c.vrange.From, c.vrange.To, c.query, c.after.Deleted, c.after.Path)
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor))
+}
+
+func decodeResumeCursor(cursor string) (resumeCursor, error) {
+	var decoded resumeCursor
+
+	bytes, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return decoded, fmt.Errorf("malformed resume cursor: %w", err)
+	}
+
+	// the path comes last, it may contain separators
+	parts := strings.SplitN(string(bytes), ":", 7)
+	if len(parts) != 7 || parts[0] != resumeCursorPrefix {
+		return decoded, fmt.Errorf("malformed resume cursor")
+	}
+
+	var ints [4]int64
+	for idx := range ints {
+		ints[idx], err = strconv.ParseInt(parts[idx+1], 10, 64)
+		if err != nil {
+			return decoded, fmt.Errorf("malformed resume cursor: %w", err)
+		}
+	}
+
+	deleted, err := strconv.ParseBool(parts[5])
+	if err != nil {
+		return decoded, fmt.Errorf("malformed resume cursor: %w", err)
+	}
+
+	decoded.project = ints[0]
+	decoded.vrange = db.VersionRange{From: ints[1], To: ints[2]}
+	decoded.query = int(ints[3])
+	decoded.after = db.ResumePoint{Path: parts[6], Deleted: deleted}
+
+	return decoded, nil
+}
+
+// pinToResumeCursor decodes the cursor a Get stream is resumed from and returns it along with the version the read
+// must stop at. The request must read the same project and versions as the interrupted stream.
+func pinToResumeCursor(project int64, cursor *string, fromVersion *int64, toVersion *int64) (*resumeCursor, *int64, error) {
+	if cursor == nil {
+		return nil, toVersion, nil
+	}
+
+	decoded, err := decodeResumeCursor(*cursor)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "Invalid GetRequest: %v", err)
+	}
+
+	if decoded.project != project {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "Invalid GetRequest: resume cursor of project %v used to read project %v", decoded.project, project)
+	}
+
+	from := int64(0)
+	if fromVersion != nil {
+		from = *fromVersion
+	}
+	if from != decoded.vrange.From || (toVersion != nil && *toVersion != decoded.vrange.To) {
+		return nil, nil, status.Errorf(codes.InvalidArgument, "Invalid GetRequest: versions conflict with resume cursor range %v-%v", decoded.vrange.From, decoded.vrange.To)
+	}
+
+	return &decoded, &decoded.vrange.To, nil
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// snapshotTokenPrefix versions the token format, tokens are opaque to clients
+const snapshotTokenPrefix = "s1"
+
+func encodeSnapshotToken(project int64, version int64) string {
+	token := fmt.Sprintf("%s:%d:%d", snapshotTokenPrefix, project, version)
+	return base64.RawURLEncoding.EncodeToString([]byte(token))
+}
+
+func decodeSnapshotToken(token string) (int64, int64, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed snapshot token: %w", err)
+	}
+
+	parts := strings.Split(string(decoded), ":")
+	if len(parts) != 3 || parts[0] != snapshotTokenPrefix {
+		return 0, 0, fmt.Errorf("malformed snapshot token")
+	}
+
+	project, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed snapshot token project: %w", err)
+	}
+
+	version, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("malformed snapshot token version: %w", err)
+	}
+
+	return project, version, nil
+}
+
+// pinToSnapshot returns the version a read of project must stop at, the one of token when set and toVersion otherwise.
+func pinToSnapshot(request string, project int64, token *string, toVersion *int64) (*int64, error) {
+	if token == nil {
+		return toVersion, nil
+	}
+
+	tokenProject, version, err := decodeSnapshotToken(*token)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid %v: %v", request, err)
+	}
+
+	if tokenProject != project {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid %v: snapshot token of project %v used to read project %v", request, tokenProject, project)
+	}
+
+	if toVersion != nil && *toVersion != version {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid %v: version %v conflicts with snapshot version %v", request, *toVersion, version)
+	}
+
+	return &version, nil
+}
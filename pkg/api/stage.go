@@ -0,0 +1,401 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/internal/policy"
+	"github.com/gadget-inc/dateilager/internal/transform"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxStageNameLength bounds the length of stage names
+const maxStageNameLength = 128
+
+func validateStage(request string, stage string) error {
+	if stage == "" || len(stage) > maxStageNameLength {
+		return status.Errorf(codes.InvalidArgument, "Invalid %v: stage name must be between 1 and %v bytes", request, maxStageNameLength)
+	}
+	return nil
+}
+
+// Stage accumulates objects in a named stage of a project without creating a version. Stages are committed into a
+// single version with CommitStage, or dropped with DiscardStage. Objects are checked against the project's policy as
+// they are staged.
+func (f *Fs) Stage(stream pb.Fs_StageServer) error {
+	ctx := stream.Context()
+
+	project, err := requireProjectAuth(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	contentEncoder := f.newContentEncoder()
+	defer contentEncoder.Close()
+
+	var stage string
+	var stagePolicy *policy.Policy
+	var transforms *transform.Set
+	staged := int64(0)
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errStatus, _ := status.FromError(err)
+			return status.Errorf(errStatus.Code(), "FS receive stage request: %v", err)
+		}
+
+		// project can be -1 if the client authenticated using an Admin token
+		if project == -1 {
+			project = req.Project
+		}
+		if project != req.Project {
+			return status.Errorf(codes.InvalidArgument, "initial project %v, next project %v: %v", project, req.Project, ErrMultipleProjectsPerUpdate)
+		}
+
+		if stagePolicy == nil {
+			err = validateStage("StageRequest", req.Stage)
+			if err != nil {
+				return err
+			}
+			stage = req.Stage
+
+			_, err = db.LockWritableVersion(ctx, tx, project)
+			if errors.Is(err, db.ErrNotFound) {
+				return status.Errorf(codes.NotFound, "FS stage: %v", err)
+			}
+			if errors.Is(err, db.ErrProjectFrozen) {
+				return status.Errorf(codes.FailedPrecondition, "FS stage: %v", err)
+			}
+			if err != nil {
+				return status.Errorf(codes.Internal, "FS stage lock latest version: %v", err)
+			}
+
+			stagePolicy, err = f.projectPolicy(ctx, tx, project)
+			if err != nil {
+				return err
+			}
+
+			transforms, err = f.transformSet(ctx, tx, project, true)
+			if err != nil {
+				return err
+			}
+
+			trace.SpanFromContext(ctx).SetAttributes(key.Project.Attribute(project), key.Stage.Attribute(stage))
+		}
+		if req.Stage != stage {
+			return status.Errorf(codes.InvalidArgument, "Invalid StageRequest: initial stage %v, next stage %v", stage, req.Stage)
+		}
+
+		if req.Object == nil {
+			return status.Error(codes.InvalidArgument, "Invalid StageRequest: an object is required")
+		}
+
+		err = pb.ValidatePath(req.Object.Path)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "Invalid StageRequest: %v", err)
+		}
+
+		err = pb.NormalizeObject(req.Object)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "Invalid StageRequest: %v", err)
+		}
+
+		err = f.resolveLfsPointer(ctx, tx, "stage", project, transforms, req.Object)
+		if err != nil {
+			return err
+		}
+
+		violations := stagePolicy.Check(req.Object.Path, max(req.Object.Size, int64(len(req.Object.Content))), req.Object.Deleted)
+		if len(violations) > 0 {
+			return policyViolationsError(project, violations)
+		}
+
+		if f.DetectContentType && !req.Object.Deleted {
+			req.Object.ContentType = detectContentType(req.Object)
+		}
+
+		err = db.StageObject(ctx, tx, f.DbConn, contentEncoder, project, stage, req.Object)
+		if err != nil {
+			return status.Errorf(codes.Internal, "FS stage: %v", err)
+		}
+		staged += 1
+	}
+
+	if staged == 0 {
+		return stream.SendAndClose(&pb.StageResponse{Staged: 0})
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "FS stage commit tx: %v", err)
+	}
+	logger.Info(ctx, "FS.Stage[Commit]", key.Project.Field(project), key.Stage.Field(stage), key.ObjectsCount.Field(int(staged)))
+
+	return stream.SendAndClose(&pb.StageResponse{Staged: staged})
+}
+
+func (f *Fs) ListStaged(ctx context.Context, req *pb.ListStagedRequest) (*pb.ListStagedResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.Stage.Attribute(req.Stage),
+	)
+
+	project, err := requireProjectAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if project > -1 && req.Project != project {
+		return nil, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	}
+
+	err = validateStage("ListStagedRequest", req.Stage)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.ListStaged[Query]", key.Project.Field(req.Project), key.Stage.Field(req.Stage))
+
+	objects, err := db.ListStagedObjects(ctx, tx, req.Project, req.Stage)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS list staged: %v", err)
+	}
+
+	return &pb.ListStagedResponse{Objects: objects}, nil
+}
+
+// CommitStage writes every object of a stage in a single new version and drops the stage, atomically.
+func (f *Fs) CommitStage(ctx context.Context, req *pb.CommitStageRequest) (*pb.CommitStageResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.Stage.Attribute(req.Stage),
+	)
+
+	project, err := requireProjectAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if project > -1 && req.Project != project {
+		return nil, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	}
+
+	err = validateStage("CommitStageRequest", req.Stage)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := f.UpdateAdmission.Admit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	logger.Debug(ctx, "FS.CommitStage[Init]", key.Project.Field(req.Project), key.Stage.Field(req.Stage))
+
+	latestVersion, err := db.LockWritableVersion(ctx, tx, req.Project)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS commit stage: %v", err)
+	}
+	if errors.Is(err, db.ErrProjectFrozen) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS commit stage: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS commit stage lock latest version: %v", err)
+	}
+
+	objects, err := db.StagedObjects(ctx, tx, f.ContentLookup, req.Project, req.Stage)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS commit stage: %v", err)
+	}
+	if len(objects) == 0 {
+		return nil, status.Errorf(codes.NotFound, "FS commit stage: stage %v of project %v is empty", req.Stage, req.Project)
+	}
+
+	packManager, err := db.NewPackManager(ctx, tx, req.Project)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS create packed cache: %v", err)
+	}
+
+	guarded := f.DeletionGuard != nil && !req.Force
+	liveObjects := int64(0)
+	if guarded {
+		liveObjects, err = db.LiveObjectsCount(ctx, tx, req.Project)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS commit stage: %v", err)
+		}
+	}
+
+	contentEncoder := f.newContentEncoder()
+	defer contentEncoder.Close()
+
+	nextVersion := latestVersion + 1
+	shouldUpdateVersion := false
+
+	packedBuffer := make(map[string][]*pb.Object)
+	for _, object := range objects {
+		packParent := packManager.IsPathPacked(object.Path)
+		if packParent != nil {
+			packedBuffer[*packParent] = append(packedBuffer[*packParent], object)
+			continue
+		}
+
+		if object.Deleted {
+			err = db.DeleteObject(ctx, tx, req.Project, nextVersion, object.Path)
+			shouldUpdateVersion = true
+		} else {
+			var contentChanged bool
+			contentChanged, err = db.UpdateObject(ctx, tx, f.DbConn, contentEncoder, req.Project, nextVersion, object)
+			if contentChanged {
+				shouldUpdateVersion = true
+			}
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS commit stage: %v", err)
+		}
+	}
+
+	for parent, packedObjects := range packedBuffer {
+		contentChanged, err := db.UpdatePackedObjects(ctx, tx, f.DbConn, req.Project, nextVersion, parent, packedObjects)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS commit stage packed objects for %v: %v", parent, err)
+		}
+		if contentChanged {
+			shouldUpdateVersion = true
+		}
+	}
+
+	_, err = db.DiscardStage(ctx, tx, req.Project, req.Stage)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS commit stage: %v", err)
+	}
+
+	response := &pb.CommitStageResponse{Version: latestVersion, Count: int64(len(objects))}
+
+	if shouldUpdateVersion {
+		if guarded {
+			remainingObjects, err := db.LiveObjectsCount(ctx, tx, req.Project)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "FS commit stage: %v", err)
+			}
+
+			violation := f.DeletionGuard.Check(liveObjects, remainingObjects)
+			if violation != nil {
+				logger.Warn(ctx, "FS.CommitStage[DeletionGuard]", key.Project.Field(req.Project), key.Version.Field(nextVersion), zap.String("reason", violation.Description))
+				return nil, massDeletionError(req.Project, violation)
+			}
+		}
+
+		err = db.UpdateLatestVersion(ctx, tx, req.Project, nextVersion)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS commit stage latest version: %v", err)
+		}
+
+		err = db.AnnotateVersion(ctx, tx, req.Project, nextVersion, req.Message, req.Author)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "FS commit stage annotate version: %v", err)
+		}
+
+		if f.QueueContentScans {
+			err = db.QueueContentScans(ctx, tx, req.Project, nextVersion)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "FS commit stage: %v", err)
+			}
+		}
+
+		response.Version = nextVersion
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS commit stage commit tx: %v", err)
+	}
+	logger.Info(ctx, "FS.CommitStage[Commit]", key.Project.Field(req.Project), key.Stage.Field(req.Stage), key.Version.Field(response.Version), key.ObjectsCount.Field(len(objects)))
+
+	if shouldUpdateVersion {
+		f.precomputeCheckout(ctx, req.Project, nextVersion)
+	}
+
+	return response, nil
+}
+
+func (f *Fs) DiscardStage(ctx context.Context, req *pb.DiscardStageRequest) (*pb.DiscardStageResponse, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
+		key.Project.Attribute(req.Project),
+		key.Stage.Attribute(req.Stage),
+	)
+
+	project, err := requireProjectAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if project > -1 && req.Project != project {
+		return nil, status.Errorf(codes.PermissionDenied, "Mismatch project authorization and request")
+	}
+
+	err = validateStage("DiscardStageRequest", req.Stage)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, close, err := f.DbConn.Connect(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "FS db connection unavailable: %v", err)
+	}
+	defer close(ctx)
+
+	_, err = db.LockWritableVersion(ctx, tx, req.Project)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "FS discard stage: %v", err)
+	}
+	if errors.Is(err, db.ErrProjectFrozen) {
+		return nil, status.Errorf(codes.FailedPrecondition, "FS discard stage: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS discard stage lock latest version: %v", err)
+	}
+
+	discarded, err := db.DiscardStage(ctx, tx, req.Project, req.Stage)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS discard stage: %v", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "FS discard stage commit tx: %v", err)
+	}
+	logger.Info(ctx, "FS.DiscardStage[Commit]", key.Project.Field(req.Project), key.Stage.Field(req.Stage), key.Count.Field(discarded))
+
+	return &pb.DiscardStageResponse{Discarded: discarded}, nil
+}
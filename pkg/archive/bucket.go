@@ -0,0 +1,113 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// Bucket stores artifacts in an S3 compatible bucket through the AWS SDK. Requests use path style URLs so custom
+// endpoints work without DNS setup, and artifacts are uploaded in parts so they never have to fit in memory.
+type Bucket struct {
+	// Scheme is s3 or gs, it only shows in the locations returned by Put
+	Scheme    string
+	Name      string
+	Prefix    string
+	AccessKey string
+	SecretKey string
+	Token     string
+	Region    string
+	Endpoint  string
+	Client    *http.Client
+
+	once     sync.Once
+	s3Client *s3.Client
+}
+
+func (b *Bucket) key(name string) string {
+	if b.Prefix == "" {
+		return name
+	}
+	return b.Prefix + "/" + name
+}
+
+func (b *Bucket) client() *s3.Client {
+	b.once.Do(func() {
+		b.s3Client = s3.New(s3.Options{
+			Region:       b.Region,
+			BaseEndpoint: aws.String(strings.TrimRight(b.Endpoint, "/")),
+			UsePathStyle: true,
+			Credentials:  credentials.NewStaticCredentialsProvider(b.AccessKey, b.SecretKey, b.Token),
+			// GCS rejects the checksum headers the SDK sends by default
+			RequestChecksumCalculation: aws.RequestChecksumCalculationWhenRequired,
+			ResponseChecksumValidation: aws.ResponseChecksumValidationWhenRequired,
+		}, func(o *s3.Options) {
+			if b.Client != nil {
+				o.HTTPClient = b.Client
+			}
+		})
+	})
+	return b.s3Client
+}
+
+func (b *Bucket) Put(ctx context.Context, name string, content io.Reader) (string, error) {
+	// the uploader switches to a multipart upload past its part size and aborts it if a part fails
+	uploader := manager.NewUploader(b.client())
+
+	_, err := uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(b.Name),
+		Key:    aws.String(b.key(name)),
+		Body:   content,
+	})
+	if err != nil {
+		return "", fmt.Errorf("archive bucket put %v: %w", name, err)
+	}
+
+	return fmt.Sprintf("%s://%s/%s", b.Scheme, b.Name, b.key(name)), nil
+}
+
+func (b *Bucket) Get(ctx context.Context, name string) ([]byte, error) {
+	output, err := b.client().GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.Name),
+		Key:    aws.String(b.key(name)),
+	})
+	if isNotFound(err) {
+		return nil, fmt.Errorf("archive bucket get %v: %w", name, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("archive bucket get %v: %w", name, err)
+	}
+	defer output.Body.Close()
+
+	content, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, fmt.Errorf("archive bucket get %v: %w", name, err)
+	}
+	return content, nil
+}
+
+func (b *Bucket) Delete(ctx context.Context, name string) error {
+	_, err := b.client().DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.Name),
+		Key:    aws.String(b.key(name)),
+	})
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("archive bucket delete %v: %w", name, err)
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var responseErr *awshttp.ResponseError
+	return errors.As(err, &responseErr) && responseErr.HTTPStatusCode() == http.StatusNotFound
+}
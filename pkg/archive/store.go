@@ -0,0 +1,150 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotFound is returned when reading an artifact missing from a store.
+var ErrNotFound = errors.New("artifact not found")
+
+// Store keeps project snapshots outside of the database, names are slash separated keys relative to the store.
+type Store interface {
+	// Put streams an artifact into the store and returns its location, a URL identifying it outside of the store
+	Put(ctx context.Context, name string, content io.Reader) (string, error)
+	Get(ctx context.Context, name string) ([]byte, error)
+	Delete(ctx context.Context, name string) error
+}
+
+// Open returns the store at rawURL, which is either a local directory, a file:// URL, an s3://bucket/prefix URL or a
+// gs://bucket/prefix URL. Buckets authenticate with the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY of the environment,
+// HMAC keys for GCS. The region and endpoint query parameters override the region and endpoint of s3 URLs.
+func Open(rawURL string) (Store, error) {
+	if !strings.Contains(rawURL, "://") {
+		return NewDir(rawURL), nil
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive store %v: %w", rawURL, err)
+	}
+
+	switch parsed.Scheme {
+	case "file":
+		return NewDir(parsed.Path), nil
+	case "s3", "gs":
+		if parsed.Host == "" {
+			return nil, fmt.Errorf("invalid archive store %v: missing bucket", rawURL)
+		}
+
+		bucket := Bucket{
+			Scheme:    parsed.Scheme,
+			Name:      parsed.Host,
+			Prefix:    strings.Trim(parsed.Path, "/"),
+			AccessKey: os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			Token:     os.Getenv("AWS_SESSION_TOKEN"),
+			Region:    parsed.Query().Get("region"),
+			Endpoint:  parsed.Query().Get("endpoint"),
+		}
+
+		if bucket.Region == "" {
+			bucket.Region = os.Getenv("AWS_REGION")
+		}
+		if parsed.Scheme == "gs" {
+			// GCS serves the S3 XML API to HMAC keys
+			bucket.Region = "auto"
+			if bucket.Endpoint == "" {
+				bucket.Endpoint = "https://storage.googleapis.com"
+			}
+		}
+		if bucket.Region == "" {
+			bucket.Region = "us-east-1"
+		}
+		if bucket.Endpoint == "" {
+			bucket.Endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", bucket.Region)
+		}
+
+		if bucket.AccessKey == "" || bucket.SecretKey == "" {
+			return nil, fmt.Errorf("archive store %v: AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set", rawURL)
+		}
+
+		return &bucket, nil
+	default:
+		return nil, fmt.Errorf("invalid archive store %v: unsupported scheme %v", rawURL, parsed.Scheme)
+	}
+}
+
+// Dir stores artifacts as files under a local directory, e.g. a mounted bucket.
+type Dir struct {
+	Root string
+}
+
+func NewDir(root string) *Dir {
+	return &Dir{Root: root}
+}
+
+func (d *Dir) path(name string) string {
+	return filepath.Join(d.Root, filepath.FromSlash(name))
+}
+
+func (d *Dir) Put(ctx context.Context, name string, content io.Reader) (string, error) {
+	path := d.path(name)
+
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return "", fmt.Errorf("archive dir put %v: %w", name, err)
+	}
+
+	// write through a temporary file so a crash never leaves a truncated artifact behind
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("archive dir put %v: %w", name, err)
+	}
+
+	_, err = io.Copy(file, content)
+	if err != nil {
+		file.Close()
+		os.Remove(tmp)
+		return "", fmt.Errorf("archive dir put %v: %w", name, err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		os.Remove(tmp)
+		return "", fmt.Errorf("archive dir put %v: %w", name, err)
+	}
+
+	err = os.Rename(tmp, path)
+	if err != nil {
+		return "", fmt.Errorf("archive dir put %v: %w", name, err)
+	}
+
+	return (&url.URL{Scheme: "file", Path: path}).String(), nil
+}
+
+func (d *Dir) Get(ctx context.Context, name string) ([]byte, error) {
+	content, err := os.ReadFile(d.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("archive dir get %v: %w", name, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("archive dir get %v: %w", name, err)
+	}
+	return content, nil
+}
+
+func (d *Dir) Delete(ctx context.Context, name string) error {
+	err := os.Remove(d.path(name))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("archive dir delete %v: %w", name, err)
+	}
+	return nil
+}
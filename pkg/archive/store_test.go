@@ -0,0 +1,198 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewDir(t.TempDir())
+
+	location, err := store.Put(ctx, "1/snapshot.tar.s2", strings.NewReader("snapshot"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(location, "file://"))
+
+	content, err := store.Get(ctx, "1/snapshot.tar.s2")
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot", string(content))
+
+	require.NoError(t, store.Delete(ctx, "1/snapshot.tar.s2"))
+	require.NoError(t, store.Delete(ctx, "1/snapshot.tar.s2"), "deleting a missing artifact")
+
+	_, err = store.Get(ctx, "1/snapshot.tar.s2")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+// fakeBucket serves path style object and multipart upload requests, rejecting the ones without a signature
+func fakeBucket(t *testing.T) *httptest.Server {
+	var lock sync.Mutex
+	objects := make(map[string][]byte)
+	uploads := make(map[string]map[int][]byte)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		path := r.URL.EscapedPath()
+		query := r.URL.Query()
+		uploadId := query.Get("uploadId")
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && query.Has("uploads"):
+			uploadId = fmt.Sprintf("upload-%d", len(uploads)+1)
+			uploads[uploadId] = make(map[int][]byte)
+			fmt.Fprintf(w, "<InitiateMultipartUploadResult><UploadId>%s</UploadId></InitiateMultipartUploadResult>", uploadId)
+		case r.Method == http.MethodPut && uploadId != "":
+			part, _ := strconv.Atoi(query.Get("partNumber"))
+			uploads[uploadId][part] = body
+			w.Header().Set("ETag", fmt.Sprintf("\"%d\"", part))
+		case r.Method == http.MethodPost && uploadId != "":
+			var content []byte
+			for part := 1; part <= len(uploads[uploadId]); part++ {
+				content = append(content, uploads[uploadId][part]...)
+			}
+			objects[path] = content
+			delete(uploads, uploadId)
+			fmt.Fprint(w, "<CompleteMultipartUploadResult><ETag>\"complete\"</ETag></CompleteMultipartUploadResult>")
+		case r.Method == http.MethodDelete && uploadId != "":
+			delete(uploads, uploadId)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPut:
+			objects[path] = body
+		case r.Method == http.MethodGet:
+			content, ok := objects[path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(content)
+		case r.Method == http.MethodDelete:
+			delete(objects, path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestBucketStore(t *testing.T) {
+	ctx := context.Background()
+	server := fakeBucket(t)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "access")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	store, err := Open("s3://archives/dateilager?endpoint=" + server.URL)
+	require.NoError(t, err)
+
+	location, err := store.Put(ctx, "1/a snapshot.tar.s2", strings.NewReader("snapshot"))
+	require.NoError(t, err)
+	assert.Equal(t, "s3://archives/dateilager/1/a snapshot.tar.s2", location)
+
+	content, err := store.Get(ctx, "1/a snapshot.tar.s2")
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot", string(content))
+
+	require.NoError(t, store.Delete(ctx, "1/a snapshot.tar.s2"))
+
+	_, err = store.Get(ctx, "1/a snapshot.tar.s2")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestBucketStoreMultipart(t *testing.T) {
+	ctx := context.Background()
+	server := fakeBucket(t)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "access")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	store, err := Open("s3://archives?endpoint=" + server.URL)
+	require.NoError(t, err)
+
+	// larger than the 5MiB parts so the upload is split
+	snapshot := bytes.Repeat([]byte("0123456789abcdef"), 12*1024*1024/16)
+
+	_, err = store.Put(ctx, "1/snapshot.tar.s2", io.MultiReader(bytes.NewReader(snapshot)))
+	require.NoError(t, err)
+
+	content, err := store.Get(ctx, "1/snapshot.tar.s2")
+	require.NoError(t, err)
+	assert.Equal(t, snapshot, content)
+}
+
+// TestBucketStoreEndpoint runs against a real S3 compatible endpoint, e.g. a local MinIO, when
+// DL_TEST_ARCHIVE_BUCKET is set to an s3:// or gs:// store URL.
+func TestBucketStoreEndpoint(t *testing.T) {
+	rawURL := os.Getenv("DL_TEST_ARCHIVE_BUCKET")
+	if rawURL == "" {
+		t.Skip("DL_TEST_ARCHIVE_BUCKET is not set")
+	}
+
+	ctx := context.Background()
+	store, err := Open(rawURL)
+	require.NoError(t, err)
+
+	name := fmt.Sprintf("test/%d.tar.s2", time.Now().UnixNano())
+	snapshot := bytes.Repeat([]byte("0123456789abcdef"), 6*1024*1024/16)
+
+	_, err = store.Put(ctx, name, io.MultiReader(bytes.NewReader(snapshot)))
+	require.NoError(t, err)
+
+	content, err := store.Get(ctx, name)
+	require.NoError(t, err)
+	assert.Equal(t, snapshot, content)
+
+	require.NoError(t, store.Delete(ctx, name))
+
+	_, err = store.Get(ctx, name)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestOpen(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "access")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	store, err := Open("/var/archives")
+	require.NoError(t, err)
+	assert.Equal(t, &Dir{Root: "/var/archives"}, store)
+
+	store, err = Open("gs://archives/dl")
+	require.NoError(t, err)
+	bucket := store.(*Bucket)
+	assert.Equal(t, "https://storage.googleapis.com", bucket.Endpoint)
+	assert.Equal(t, "dl", bucket.Prefix)
+
+	store, err = Open("s3://archives?region=eu-west-1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://s3.eu-west-1.amazonaws.com", store.(*Bucket).Endpoint)
+
+	_, err = Open("ftp://archives")
+	assert.Error(t, err)
+
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	_, err = Open("s3://archives")
+	assert.Error(t, err, "buckets require credentials")
+}
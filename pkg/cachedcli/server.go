@@ -11,6 +11,7 @@ import (
 	"os"
 	"os/signal"
 	"runtime/pprof"
+	"strconv"
 	"syscall"
 
 	"github.com/gadget-inc/dateilager/internal/environment"
@@ -96,12 +97,18 @@ func NewCacheDaemonCommand() *cobra.Command {
 				return err
 			}
 
+			template, err := templateFromEnv()
+			if err != nil {
+				return err
+			}
+
 			s := cached.NewServer(ctx)
 
 			cached := &api.Cached{
 				Env:         env,
 				Client:      cl,
 				StagingPath: stagingPath,
+				Template:    template,
 			}
 
 			logger.Info(ctx, "register Cached")
@@ -199,6 +206,29 @@ func CacheDaemonExecute() {
 	}
 }
 
+// templateFromEnv reads the project version layered on top of the cache from DL_CACHED_TEMPLATE_PROJECT and
+// DL_CACHED_TEMPLATE_VERSION, there is no template when the project is unset. The version must be pinned so every
+// node serves the same template.
+func templateFromEnv() (*api.CachedTemplate, error) {
+	project, ok := os.LookupEnv("DL_CACHED_TEMPLATE_PROJECT")
+	if !ok || project == "" {
+		return nil, nil
+	}
+
+	projectID, err := strconv.ParseInt(project, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DL_CACHED_TEMPLATE_PROJECT %q: %w", project, err)
+	}
+
+	version := os.Getenv("DL_CACHED_TEMPLATE_VERSION")
+	versionID, err := strconv.ParseInt(version, 10, 64)
+	if err != nil || versionID <= 0 {
+		return nil, fmt.Errorf("invalid DL_CACHED_TEMPLATE_VERSION %q: a positive version is required with DL_CACHED_TEMPLATE_PROJECT", version)
+	}
+
+	return &api.CachedTemplate{Project: projectID, Version: versionID}, nil
+}
+
 func healthzHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdArchives() *cobra.Command {
+	var project *int64
+
+	cmd := &cobra.Command{
+		Use:   "archives",
+		Short: "List the project snapshots exported to the archive store, newest first",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			if *project == -1 {
+				project = nil
+			}
+
+			archives, err := c.ListArchives(ctx, project)
+			if err != nil {
+				return fmt.Errorf("could not list archives: %w", err)
+			}
+
+			for _, archive := range archives {
+				expires := "never"
+				if archive.ExpiresAt != nil {
+					expires = time.Unix(0, *archive.ExpiresAt).UTC().Format(time.RFC3339)
+				}
+
+				fmt.Printf("%d\t%d\t%d\t%s\t%s\t%d\t%s\n",
+					archive.Id,
+					archive.Project,
+					archive.Version,
+					time.Unix(0, archive.CreatedAt).UTC().Format(time.RFC3339),
+					expires,
+					archive.Size,
+					archive.Location,
+				)
+			}
+			return nil
+		},
+	}
+
+	project = cmd.Flags().Int64("project", -1, "Only list the archives of this project (optional)")
+
+	return cmd
+}
+
+func NewCmdRestoreArchive() *cobra.Command {
+	var (
+		id      int64
+		project *int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore-archive",
+		Short: "Recreate an archived project version in a project that does not exist yet",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			if *project == -1 {
+				project = nil
+			}
+
+			restored, err := c.RestoreArchive(ctx, id, project)
+			if err != nil {
+				return fmt.Errorf("could not restore archive: %w", err)
+			}
+
+			fmt.Printf("%d\t%d\t%d\n", restored.Project, restored.Version, restored.Objects)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&id, "archive", -1, "Archive ID (required)")
+	project = cmd.Flags().Int64("project", -1, "Project ID to restore into, defaults to the archived project (optional)")
+
+	_ = cmd.MarkFlagRequired("archive")
+
+	return cmd
+}
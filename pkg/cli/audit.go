@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type AuditFinding struct {
+	Kind         string  `json:"kind"`
+	Hash         string  `json:"hash"`
+	Project      *int64  `json:"project,omitempty"`
+	Path         *string `json:"path,omitempty"`
+	StartVersion *int64  `json:"startVersion,omitempty"`
+	Expected     int64   `json:"expected"`
+	Actual       int64   `json:"actual"`
+	Action       string  `json:"action,omitempty"`
+}
+
+type AuditReport struct {
+	Contents  int64            `json:"contents"`
+	Objects   int64            `json:"objects"`
+	Collected int64            `json:"collected"`
+	Summary   map[string]int64 `json:"summary"`
+	Findings  []AuditFinding   `json:"findings"`
+}
+
+func NewCmdAuditContents() *cobra.Command {
+	var (
+		batchSize  int64
+		repair     bool
+		quarantine bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "audit-contents",
+		Short: "Audit every object and content for missing, orphaned or corrupted contents and wrong sizes or reference counts",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			report := AuditReport{
+				Summary:  map[string]int64{},
+				Findings: []AuditFinding{},
+			}
+
+			var after []byte
+			for {
+				response, err := c.AuditContents(ctx, after, batchSize, repair, quarantine)
+				if err != nil {
+					return fmt.Errorf("could not audit contents: %w", err)
+				}
+
+				report.Contents += response.Contents
+				report.Objects += response.Objects
+				report.Collected += response.Collected
+				for _, finding := range response.Findings {
+					report.Summary[finding.Kind] += 1
+					report.Findings = append(report.Findings, AuditFinding{
+						Kind:         finding.Kind,
+						Hash:         hex.EncodeToString(finding.Hash),
+						Project:      finding.Project,
+						Path:         finding.Path,
+						StartVersion: finding.StartVersion,
+						Expected:     finding.Expected,
+						Actual:       finding.Actual,
+						Action:       finding.Action,
+					})
+				}
+
+				if len(response.Next) == 0 {
+					break
+				}
+				after = response.Next
+			}
+
+			encoded, err := json.Marshal(report)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&batchSize, "batch-size", 1000, "Number of contents audited per request")
+	cmd.Flags().BoolVar(&repair, "repair", false, "Fix reference counts and object sizes, restore contents stored inline elsewhere and collect orphaned contents")
+	cmd.Flags().BoolVar(&quarantine, "quarantine", false, "Move orphaned and corrupted contents to the quarantine rather than collecting or keeping them")
+
+	return cmd
+}
@@ -0,0 +1,251 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdCache() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage a local cache directory",
+	}
+
+	cmd.AddCommand(NewCmdCacheCreate())
+	cmd.AddCommand(NewCmdCacheList())
+	cmd.AddCommand(NewCmdCacheDelete())
+	cmd.AddCommand(NewCmdCacheProjects())
+	cmd.AddCommand(NewCmdCacheInclusion("opt-in", true))
+	cmd.AddCommand(NewCmdCacheInclusion("opt-out", false))
+	cmd.AddCommand(NewCmdCachePull())
+	cmd.AddCommand(NewCmdCacheUnlock())
+
+	return cmd
+}
+
+func NewCmdCacheCreate() *cobra.Command {
+	var (
+		prefixes        []string
+		includeProjects []int64
+		excludeProjects []int64
+		count           *int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a new cache version from the packs most shared between the selected projects",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if *count == -1 {
+				count = nil
+			}
+
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			version, err := c.CreateCache(ctx, prefixes, includeProjects, excludeProjects, count)
+			if err != nil {
+				return fmt.Errorf("could not create cache: %w", err)
+			}
+
+			logger.Info(ctx, "cache created", key.Version.Field(version))
+			fmt.Println(version)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&prefixes, "prefix", nil, "Only include packs under these path prefixes (repeatable)")
+	cmd.Flags().Int64SliceVar(&includeProjects, "include-projects", nil, "Comma separated list of the only project IDs to take packs from")
+	cmd.Flags().Int64SliceVar(&excludeProjects, "exclude-projects", nil, "Comma separated list of project IDs to never take packs from")
+	count = cmd.Flags().Int64("count", -1, "Number of packs to include (optional)")
+
+	return cmd
+}
+
+func NewCmdCacheList() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the cache versions available on the server",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			versions, err := c.ListCacheVersions(ctx)
+			if err != nil {
+				return fmt.Errorf("could not list cache versions: %w", err)
+			}
+
+			for _, version := range versions {
+				fmt.Printf("%d\t%d objects\n", version.Version, version.ObjectsCount)
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func NewCmdCacheDelete() *cobra.Command {
+	var (
+		version int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete a cache version from the server and reclaim its unreferenced content",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			count, err := c.DeleteCacheVersion(ctx, version)
+			if err != nil {
+				return fmt.Errorf("could not delete cache version %v: %w", version, err)
+			}
+
+			logger.Info(ctx, "cache version deleted", key.Version.Field(version), key.Count.Field(count))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&version, "version", -1, "Cache version to delete (required)")
+
+	_ = cmd.MarkFlagRequired("version")
+
+	return cmd
+}
+
+func NewCmdCacheProjects() *cobra.Command {
+	var (
+		version int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "projects",
+		Short: "List the projects whose packs were included in a cache version",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			projects, err := c.GetCacheVersionProjects(ctx, version)
+			if err != nil {
+				return fmt.Errorf("could not get projects of cache version %v: %w", version, err)
+			}
+
+			for _, project := range projects {
+				fmt.Println(project)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&version, "version", -1, "Cache version (required)")
+
+	_ = cmd.MarkFlagRequired("version")
+
+	return cmd
+}
+
+func NewCmdCacheInclusion(use string, include bool) *cobra.Command {
+	var (
+		project int64
+	)
+
+	short := "Allow the packs of a project to be included in new cache versions"
+	if !include {
+		short = "Keep the packs of a project out of new cache versions"
+	}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			err := c.SetProjectCacheInclusion(ctx, project, include)
+			if err != nil {
+				return fmt.Errorf("could not set cache inclusion of project %v: %w", project, err)
+			}
+
+			logger.Info(ctx, "project cache inclusion set", key.Project.Field(project), key.IncludeInCache.Field(include))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
+
+func NewCmdCachePull() *cobra.Command {
+	var (
+		path             string
+		progressInterval time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "pull",
+		Short: "Download the latest cache version into a directory",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			ctx, stopProgress := logProgress(ctx, "pulling cache", progressInterval)
+			version, count, err := c.GetCache(ctx, path)
+			progress := stopProgress()
+			if err != nil {
+				return fmt.Errorf("could not pull cache: %w", err)
+			}
+
+			logger.Info(ctx, "cache pulled", key.Version.Field(version), key.DiffCount.Field(count), key.Bytes.Field(progress.Bytes))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "path", "", "Cache directory")
+	cmd.Flags().DurationVar(&progressInterval, "progress-interval", 5*time.Second, "Interval between progress log lines (0 to disable)")
+
+	_ = cmd.MarkFlagRequired("path")
+
+	return cmd
+}
+
+func NewCmdCacheUnlock() *cobra.Command {
+	var (
+		path  string
+		force bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "unlock",
+		Short: "Remove the lock of a cache directory left behind by a crashed process",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			info, err := client.UnlockCache(path, force)
+			if err != nil {
+				return fmt.Errorf("could not unlock cache %v: %w", path, err)
+			}
+
+			encoded, err := json.Marshal(info)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&path, "path", "", "Cache directory")
+	cmd.Flags().BoolVar(&force, "force", false, "Remove the lock even if its holder still looks alive")
+
+	_ = cmd.MarkFlagRequired("path")
+
+	return cmd
+}
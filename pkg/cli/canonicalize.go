@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type CanonicalizePacksResult struct {
+	Checked   int64 `json:"checked"`
+	Rewritten int64 `json:"rewritten"`
+}
+
+func NewCmdCanonicalizePacks() *cobra.Command {
+	var (
+		project int64
+		dryRun  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "canonicalize-packs",
+		Short: "Rewrite the packs of a project so identical content always has the same hash",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			checked, rewritten, err := c.CanonicalizePacks(ctx, project, dryRun)
+			if err != nil {
+				return fmt.Errorf("could not canonicalize packs of project %v: %w", project, err)
+			}
+
+			encoded, err := json.Marshal(CanonicalizePacksResult{Checked: checked, Rewritten: rewritten})
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only report how many packs are not canonical")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
+
+type IndexPacksResult struct {
+	Checked int64 `json:"checked"`
+	Indexed int64 `json:"indexed"`
+}
+
+func NewCmdIndexPacks() *cobra.Command {
+	var (
+		project int64
+		dryRun  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "index-packs",
+		Short: "Record the entries of packs written before packs were indexed, so they can be listed without being decoded",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			checked, indexed, err := c.IndexPacks(ctx, project, dryRun)
+			if err != nil {
+				return fmt.Errorf("could not index packs of project %v: %w", project, err)
+			}
+
+			encoded, err := json.Marshal(IndexPacksResult{Checked: checked, Indexed: indexed})
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only report how many packs are missing from the index")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"maps"
+	"os"
 	"strings"
 	"time"
 
@@ -20,6 +22,9 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
+// serverOptional annotates the commands able to run without a server, they get no client when --host is not set
+const serverOptional = "server-optional"
+
 var (
 	shutdownTelemetry func()
 	span              trace.Span
@@ -31,10 +36,25 @@ func NewClientCommand() *cobra.Command {
 		encoding     string
 		tracing      bool
 		otelContext  string
+		baggage      map[string]string
 		host         string
 		port         uint16
 		timeout      uint
 		headlessHost string
+		maxSendSize  int64
+		uploadRate   int64
+		downloadRate int64
+		dialTimeout  time.Duration
+		rpcTimeout   time.Duration
+		keepalive    time.Duration
+		keepaliveAck time.Duration
+		retries      int
+		retryBackoff time.Duration
+		retryBudget  time.Duration
+
+		maxMessageSize int
+		windowSize     int32
+		connWindowSize int32
 	)
 
 	var cancel context.CancelFunc
@@ -78,13 +98,38 @@ func NewClientCommand() *cobra.Command {
 				ctx = otel.GetTextMapPropagator().Extract(ctx, mapCarrier)
 			}
 
+			values, err := envBaggage("DL_BAGGAGE")
+			if err != nil {
+				return err
+			}
+			maps.Copy(values, baggage)
+
+			ctx, err = telemetry.WithBaggage(ctx, values)
+			if err != nil {
+				return err
+			}
+
 			ctx, span = telemetry.Start(ctx, "cmd.main")
 
 			if host == "" {
+				if cmd.Annotations[serverOptional] == "true" {
+					cmd.SetContext(ctx)
+					return nil
+				}
 				return fmt.Errorf("required flag(s) \"host\" not set")
 			}
 
-			cl, err := client.NewClient(ctx, host, port, client.WithheadlessHost(headlessHost))
+			retryPolicy := client.DefaultRetryPolicy
+			retryPolicy.MaxAttempts = 0
+			if retries > 0 {
+				retryPolicy.MaxAttempts = retries + 1
+			}
+			retryPolicy.InitialBackoff = retryBackoff
+			retryPolicy.Budget = retryBudget
+
+			cl, err := client.NewClient(ctx, host, port, client.WithheadlessHost(headlessHost), client.WithMaxContentSendSize(maxSendSize), client.WithMaxUploadRate(uploadRate), client.WithMaxDownloadRate(downloadRate),
+				client.WithDialTimeout(dialTimeout), client.WithRPCTimeout(rpcTimeout), client.WithKeepalive(keepalive, keepaliveAck), client.WithRetryPolicy(retryPolicy),
+				client.WithMaxMessageSize(maxMessageSize), client.WithWindowSizes(windowSize, connWindowSize))
 			if err != nil {
 				return err
 			}
@@ -109,27 +154,119 @@ func NewClientCommand() *cobra.Command {
 	flags.StringVar(&encoding, "log-encoding", "console", "Log encoding (console | json)")
 	flags.BoolVar(&tracing, "tracing", false, "Whether tracing is enabled")
 	flags.StringVar(&otelContext, "otel-context", "", "Open Telemetry context")
+	flags.StringToStringVar(&baggage, "baggage", nil, "Key value pairs like deploy-id=abc sent as Open Telemetry baggage with every call, the server adds them to its logs and spans (env DL_BAGGAGE, overridden per key)")
 
-	flags.StringVar(&host, "host", "", "GRPC server hostname")
+	flags.StringVar(&host, "host", os.Getenv("DL_HOST"), "GRPC server hostname (env DL_HOST)")
 	flags.Uint16Var(&port, "port", 5051, "GRPC server port")
 	flags.StringVar(&headlessHost, "headless-host", "", "Alternative headless hostname to use for round robin connections")
 	flags.UintVar(&timeout, "timeout", 0, "GRPC client timeout (ms)")
-
-	_ = cmd.MarkFlagRequired("host")
+	flags.DurationVar(&dialTimeout, "dial-timeout", envDuration("DL_DIAL_TIMEOUT", client.DEFAULT_DIAL_TIMEOUT), "How long to wait for the connection to the server (env DL_DIAL_TIMEOUT)")
+	flags.DurationVar(&rpcTimeout, "rpc-timeout", envDuration("DL_RPC_TIMEOUT", 0), "Deadline of each GRPC call, streams included (0 for none, env DL_RPC_TIMEOUT)")
+	flags.DurationVar(&keepalive, "keepalive-time", envDuration("DL_KEEPALIVE_TIME", client.DEFAULT_KEEPALIVE_TIME), "Interval between keepalive pings on an idle connection (env DL_KEEPALIVE_TIME)")
+	flags.DurationVar(&keepaliveAck, "keepalive-timeout", envDuration("DL_KEEPALIVE_TIMEOUT", client.DEFAULT_KEEPALIVE_TIMEOUT), "How long to wait for a keepalive ping ack before closing the connection (env DL_KEEPALIVE_TIMEOUT)")
+	flags.IntVar(&retries, "max-retries", int(envInt64("DL_MAX_RETRIES", 0)), "Retry rebuilds, reads, cache downloads and idempotent updates this many times on transient errors (0 keeps the per command defaults, env DL_MAX_RETRIES)")
+	flags.DurationVar(&retryBackoff, "retry-backoff", envDuration("DL_RETRY_BACKOFF", client.DefaultRetryPolicy.InitialBackoff), "Delay before the first retry, doubled for every further retry (env DL_RETRY_BACKOFF)")
+	flags.DurationVar(&retryBudget, "retry-budget", envDuration("DL_RETRY_BUDGET", 0), "Stop retrying an operation once this much time went by since its first attempt (0 for no limit, env DL_RETRY_BUDGET)")
+	flags.Int64Var(&maxSendSize, "max-content-send-size", 0, "Leave out the content of objects larger than this many bytes on reads (0 for no limit)")
+	flags.Int64Var(&uploadRate, "max-upload-rate", 0, "Maximum bytes per second sent to the server (0 for no limit)")
+	flags.Int64Var(&downloadRate, "max-download-rate", 0, "Maximum bytes per second received from the server (0 for no limit)")
+	flags.IntVar(&maxMessageSize, "max-message-size", int(envInt64("DL_MAX_MESSAGE_SIZE", client.MAX_MESSAGE_SIZE)), "Largest gRPC message in bytes the client sends or accepts (env DL_MAX_MESSAGE_SIZE)")
+	flags.Int32Var(&windowSize, "initial-window-size", int32(envInt64("DL_INITIAL_WINDOW_SIZE", client.INITIAL_WINDOW_SIZE)), "HTTP/2 flow control window in bytes of each stream, at least 64KB (env DL_INITIAL_WINDOW_SIZE)")
+	flags.Int32Var(&connWindowSize, "initial-conn-window-size", int32(envInt64("DL_INITIAL_CONN_WINDOW_SIZE", client.INITIAL_CONN_WINDOW_SIZE)), "HTTP/2 flow control window in bytes of the connection, at least the initial-window-size (env DL_INITIAL_CONN_WINDOW_SIZE)")
 
 	cmd.AddCommand(NewCmdGet())
 	cmd.AddCommand(NewCmdInspect())
+	cmd.AddCommand(NewCmdHistory())
+	cmd.AddCommand(NewCmdArchives())
+	cmd.AddCommand(NewCmdRestoreArchive())
 	cmd.AddCommand(NewCmdNew())
+	cmd.AddCommand(NewCmdProvision())
+	cmd.AddCommand(NewCmdLabels())
 	cmd.AddCommand(NewCmdRebuild())
+	cmd.AddCommand(NewCmdFetch())
 	cmd.AddCommand(NewCmdReset())
+	cmd.AddCommand(NewCmdRestore())
+	cmd.AddCommand(NewCmdPut())
+	cmd.AddCommand(NewCmdRemove())
+	cmd.AddCommand(NewCmdStage())
+	cmd.AddCommand(NewCmdStaged())
+	cmd.AddCommand(NewCmdCommitStage())
+	cmd.AddCommand(NewCmdDiscardStage())
 	cmd.AddCommand(NewCmdSnapshot())
 	cmd.AddCommand(NewCmdUpdate())
 	cmd.AddCommand(NewCmdGc())
+	cmd.AddCommand(NewCmdSquash())
+	cmd.AddCommand(NewCmdTombstones())
+	cmd.AddCommand(NewCmdPurgeDeleted())
+	cmd.AddCommand(NewCmdCanonicalizePacks())
+	cmd.AddCommand(NewCmdIndexPacks())
+	cmd.AddCommand(NewCmdInlineContents())
+	cmd.AddCommand(NewCmdRepairPaths())
+	cmd.AddCommand(NewCmdValidatePaths())
 	cmd.AddCommand(NewCmdGetCache())
+	cmd.AddCommand(NewCmdCache())
+	cmd.AddCommand(NewCmdDevtools())
+	cmd.AddCommand(NewCmdExport())
+	cmd.AddCommand(NewCmdExportOCI())
+	cmd.AddCommand(NewCmdFanOut())
+	cmd.AddCommand(NewCmdDiff())
+	cmd.AddCommand(NewCmdStat())
+	cmd.AddCommand(NewCmdList())
+	cmd.AddCommand(NewCmdDirectorySizes())
+	cmd.AddCommand(NewCmdHeapProfile())
+	cmd.AddCommand(NewCmdContentStats())
+	cmd.AddCommand(NewCmdCheckContentRefs())
+	cmd.AddCommand(NewCmdAuditContents())
+	cmd.AddCommand(NewCmdFindContent())
+	cmd.AddCommand(NewCmdContentEncoding())
+	cmd.AddCommand(NewCmdSample())
+	cmd.AddCommand(NewCmdScanStatus())
+	cmd.AddCommand(NewCmdPolicy())
+	cmd.AddCommand(NewCmdFreeze())
+	cmd.AddCommand(NewCmdUnfreeze())
+	cmd.AddCommand(NewCmdSetTransforms())
+	cmd.AddCommand(NewCmdReadSnapshot())
+	cmd.AddCommand(NewCmdHydrate())
+	cmd.AddCommand(NewCmdPending())
+	cmd.AddCommand(NewCmdSelfTest())
 
 	return cmd
 }
 
+// envDuration parses the duration in the environment variable name, fallback when it is unset or invalid.
+func envDuration(name string, fallback time.Duration) time.Duration {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fallback
+	}
+	return duration
+}
+
+// envBaggage parses the comma separated key=value pairs in the environment variable name.
+func envBaggage(name string) (map[string]string, error) {
+	values := make(map[string]string)
+
+	value := os.Getenv(name)
+	if value == "" {
+		return values, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid %s entry %q, expected key=value", name, pair)
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return values, nil
+}
+
 func ClientExecute() {
 	ctx := context.Background()
 	cmd := NewClientCommand()
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdContentEncoding() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "content-encoding",
+		Short: "Report how many contents are stored in each encoding and the progress of their re-encodes",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			response, err := c.ContentEncodingStatus(ctx)
+			if err != nil {
+				return fmt.Errorf("could not get content encoding status: %w", err)
+			}
+
+			fmt.Printf("writing %s\n", response.WriteEncoding)
+			for _, stats := range response.Encodings {
+				fmt.Printf("%s\t%d contents\t%d bytes\n", stats.Encoding, stats.Contents, stats.Bytes)
+			}
+
+			for _, progress := range response.Reencodes {
+				state := "running"
+				if progress.FinishedAt != nil {
+					state = fmt.Sprintf("finished %s", time.Unix(0, *progress.FinishedAt).Format(time.RFC3339))
+				}
+				fmt.Printf("reencode to %s: %d scanned, %d reencoded, started %s, %s\n",
+					progress.Encoding, progress.Scanned, progress.Reencoded, time.Unix(0, progress.StartedAt).Format(time.RFC3339), state)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type ContentRefMismatch struct {
+	Hash    string `json:"hash"`
+	Refs    int64  `json:"refs"`
+	Pending int64  `json:"pending"`
+	Actual  int64  `json:"actual"`
+}
+
+type CheckContentRefsResult struct {
+	Checked    int64                `json:"checked"`
+	Uncounted  int64                `json:"uncounted"`
+	Mismatches []ContentRefMismatch `json:"mismatches"`
+	Repaired   int64                `json:"repaired"`
+}
+
+func NewCmdCheckContentRefs() *cobra.Command {
+	var (
+		sample float32
+		repair bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "check-content-refs",
+		Short: "Validate the references tracked for a sample of the contents against the objects reading them",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			response, err := c.CheckContentRefs(ctx, sample, repair)
+			if err != nil {
+				return fmt.Errorf("could not check content refs: %w", err)
+			}
+
+			result := CheckContentRefsResult{
+				Checked:    response.Checked,
+				Uncounted:  response.Uncounted,
+				Mismatches: []ContentRefMismatch{},
+				Repaired:   response.Repaired,
+			}
+			for _, mismatch := range response.Mismatches {
+				result.Mismatches = append(result.Mismatches, ContentRefMismatch{
+					Hash:    hex.EncodeToString(mismatch.Hash),
+					Refs:    mismatch.Refs,
+					Pending: mismatch.Pending,
+					Actual:  mismatch.Actual,
+				})
+			}
+
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Float32Var(&sample, "sample", 1, "Percent of the contents to check")
+	cmd.Flags().BoolVar(&repair, "repair", false, "Reset the tracked references of the inconsistent contents")
+
+	return cmd
+}
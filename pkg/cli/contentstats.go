@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type DedupStats struct {
+	Projects     int64   `json:"projects"`
+	Objects      int64   `json:"objects"`
+	LogicalBytes int64   `json:"logicalBytes"`
+	UniqueBytes  int64   `json:"uniqueBytes"`
+	DedupRatio   float64 `json:"dedupRatio"`
+}
+
+type SharedContent struct {
+	Hash       string `json:"hash"`
+	Size       int64  `json:"size"`
+	References int64  `json:"references"`
+	Projects   int64  `json:"projects"`
+}
+
+type TemplateFamilyStats struct {
+	Template int64 `json:"template"`
+	DedupStats
+}
+
+type ContentStatsResult struct {
+	Total       DedupStats            `json:"total"`
+	StoredBytes int64                 `json:"storedBytes"`
+	TopContents []SharedContent       `json:"topContents"`
+	Families    []TemplateFamilyStats `json:"families"`
+}
+
+func dedupStats(stats *pb.DedupStats) DedupStats {
+	return DedupStats{
+		Projects:     stats.GetProjects(),
+		Objects:      stats.GetObjects(),
+		LogicalBytes: stats.GetLogicalBytes(),
+		UniqueBytes:  stats.GetUniqueBytes(),
+		DedupRatio:   stats.GetDedupRatio(),
+	}
+}
+
+func NewCmdContentStats() *cobra.Command {
+	var top int64
+
+	cmd := &cobra.Command{
+		Use:   "content-stats",
+		Short: "Report how much content is shared between projects and within template families",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			response, err := c.ContentStats(ctx, top)
+			if err != nil {
+				return fmt.Errorf("could not get content stats: %w", err)
+			}
+
+			result := ContentStatsResult{
+				Total:       dedupStats(response.Total),
+				StoredBytes: response.StoredBytes,
+				TopContents: []SharedContent{},
+				Families:    []TemplateFamilyStats{},
+			}
+			for _, content := range response.TopContents {
+				result.TopContents = append(result.TopContents, SharedContent{
+					Hash:       hex.EncodeToString(content.Hash),
+					Size:       content.Size,
+					References: content.References,
+					Projects:   content.Projects,
+				})
+			}
+			for _, family := range response.Families {
+				result.Families = append(result.Families, TemplateFamilyStats{Template: family.Template, DedupStats: dedupStats(family.Stats)})
+			}
+
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&top, "top", 20, "Number of most shared contents to report")
+
+	return cmd
+}
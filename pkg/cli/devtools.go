@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/gadget-inc/dateilager/pkg/devtools"
+	"github.com/gadget-inc/dateilager/pkg/server"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdDevtools() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "devtools",
+		Short: "Tools for local development and performance work",
+	}
+
+	cmd.AddCommand(NewCmdDevtoolsGenerate())
+
+	return cmd
+}
+
+func NewCmdDevtoolsGenerate() *cobra.Command {
+	var (
+		project int64
+		dbUri   string
+		shape   devtools.Shape
+	)
+
+	cmd := &cobra.Command{
+		Use:         "generate",
+		Short:       "Create a synthetic project of a given shape, the same shape and seed always create the same project",
+		Annotations: map[string]string{serverOptional: "true"},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			err := shape.Validate()
+			if err != nil {
+				return fmt.Errorf("invalid shape: %w", err)
+			}
+
+			var result devtools.GenerateResult
+			if dbUri != "" {
+				dbConn, err := server.NewDbPoolConnector(ctx, dbUri)
+				if err != nil {
+					return fmt.Errorf("cannot connect to DB %s: %w", dbUri, err)
+				}
+				defer dbConn.Close()
+
+				result, err = devtools.GenerateWithDb(ctx, dbConn, project, shape)
+				if err != nil {
+					return fmt.Errorf("could not generate project: %w", err)
+				}
+			} else {
+				c := client.FromContext(ctx)
+				if c == nil {
+					return fmt.Errorf("either --host or --dburi must be set")
+				}
+
+				result, err = devtools.GenerateWithClient(ctx, c, project, shape)
+				if err != nil {
+					return fmt.Errorf("could not generate project: %w", err)
+				}
+			}
+
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "ID of the project to create (required)")
+	cmd.Flags().StringVar(&dbUri, "dburi", "", "Write directly to this Postgres URI rather than through the server")
+	cmd.Flags().IntVar(&shape.Files, "files", 1000, "Number of files in the first version")
+	cmd.Flags().Int64Var(&shape.MinSize, "min-size", 16, "Smallest file size in bytes")
+	cmd.Flags().Int64Var(&shape.MaxSize, "max-size", 64*1024, "Largest file size in bytes, sizes are log uniform between the bounds")
+	cmd.Flags().IntVar(&shape.Depth, "depth", 4, "Deepest directory level files are placed at")
+	cmd.Flags().Float64Var(&shape.PackRatio, "pack-ratio", 0, "Fraction of the files placed within packed directories")
+	cmd.Flags().IntVar(&shape.Versions, "versions", 1, "Number of versions written")
+	cmd.Flags().Float64Var(&shape.Churn, "churn", 0.05, "Fraction of the live files added, changed or removed by each version after the first")
+	cmd.Flags().Int64Var(&shape.Seed, "seed", 1, "Seed of the random choices")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdDiff() *cobra.Command {
+	var (
+		project       int64
+		from          int64
+		to            *int64
+		prefix        string
+		maxInlineSize *int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "List the files changed between two versions of a project",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if *to == -1 {
+				to = nil
+			}
+			if *maxInlineSize == -1 {
+				maxInlineSize = nil
+			}
+
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			diffs, err := c.Diff(ctx, project, prefix, from, to, maxInlineSize)
+			if err != nil {
+				return fmt.Errorf("could not diff project %v: %w", project, err)
+			}
+
+			for _, diff := range diffs {
+				fmt.Println(formatDiff(diff))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().Int64Var(&from, "from", 0, "From version ID")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Search prefix")
+	to = cmd.Flags().Int64("to", -1, "To version ID (optional)")
+	maxInlineSize = cmd.Flags().Int64("max-inline-size", -1, "Largest text file, in bytes, diffed inline (optional)")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
+
+func formatDiff(diff *pb.DiffResponse) string {
+	header := fmt.Sprintf("%s %s", diff.Change, diff.Path)
+
+	switch {
+	case diff.Change == pb.DiffResponse_MODE_CHANGED:
+		return fmt.Sprintf("%s: mode changed (%v → %v)", header, fs.FileMode(diff.OldMode), fs.FileMode(diff.NewMode))
+	case diff.Binary:
+		return fmt.Sprintf("%s: binary file changed (size %d → %d)", header, diff.OldSize, diff.NewSize)
+	case !diff.Inline:
+		return fmt.Sprintf("%s: file too large to diff inline (size %d → %d)", header, diff.OldSize, diff.NewSize)
+	default:
+		return fmt.Sprintf("%s: text file changed (size %d → %d)", header, diff.OldSize, diff.NewSize)
+	}
+}
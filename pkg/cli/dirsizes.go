@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdDirectorySizes() *cobra.Command {
+	var (
+		project int64
+		version *int64
+		prefix  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "dir-sizes",
+		Short: "Show the file count and total size of every directory directly under a prefix, packed files included",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if *version == -1 {
+				version = nil
+			}
+
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			response, err := c.DirectorySizes(ctx, project, version, prefix)
+			if err != nil {
+				return fmt.Errorf("could not get directory sizes: %w", err)
+			}
+
+			for _, directory := range response.Directories {
+				fmt.Printf("%s\t%d\t%d\n", directory.Path, directory.Files, directory.Bytes)
+			}
+			fmt.Printf("total\t%d\t%d\n", response.Files, response.Bytes)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Only roll up paths under this prefix")
+	version = cmd.Flags().Int64("version", -1, "Version ID (optional)")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
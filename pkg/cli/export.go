@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdExport() *cobra.Command {
+	var (
+		projects       string
+		from           *int64
+		to             *int64
+		dir            string
+		maxContentSize int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export project object metadata as Parquet files partitioned by project",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if *from == -1 {
+				from = nil
+			}
+			if *to == -1 {
+				to = nil
+			}
+
+			var projectIds []int64
+			if projects != "" {
+				for _, project := range strings.Split(projects, ",") {
+					id, err := strconv.ParseInt(strings.TrimSpace(project), 10, 64)
+					if err != nil {
+						return fmt.Errorf("invalid project id %v: %w", project, err)
+					}
+					projectIds = append(projectIds, id)
+				}
+			}
+
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			results, err := c.Export(ctx, projectIds, client.VersionRange{From: from, To: to}, dir, maxContentSize)
+			if err != nil {
+				return fmt.Errorf("could not export projects: %w", err)
+			}
+
+			for _, result := range results {
+				logger.Info(ctx, "exported project",
+					key.Project.Field(result.Project),
+					key.Version.Field(result.Version),
+					key.Count.Field(result.Count),
+				)
+			}
+
+			encoded, err := json.Marshal(results)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&projects, "projects", "", "Comma separated list of project IDs (defaults to all projects)")
+	cmd.Flags().StringVar(&dir, "dir", "", "Local output directory (required), object stores are not written to directly")
+	cmd.Flags().Int64Var(&maxContentSize, "max-content-size", 0, "Include text contents up to this size in bytes (0 disables contents)")
+	from = cmd.Flags().Int64("from", -1, "From version ID (optional)")
+	to = cmd.Flags().Int64("to", -1, "To version ID (optional)")
+
+	_ = cmd.MarkFlagRequired("dir")
+
+	return cmd
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdExportOCI() *cobra.Command {
+	var (
+		project int64
+		to      *int64
+		prefix  string
+		dir     string
+		base    string
+		ref     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export-oci",
+		Short: "Export a project version as an OCI image layer, optionally appended to a base image",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if *to == -1 {
+				to = nil
+			}
+
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			result, err := c.ExportOCI(ctx, project, prefix, to, dir, base, ref)
+			if err != nil {
+				return fmt.Errorf("could not export project as OCI image: %w", err)
+			}
+
+			logger.Info(ctx, "exported OCI layer",
+				key.Project.Field(project),
+				key.Version.Field(result.Version),
+				key.DiffCount.Field(result.Count),
+				key.Directory.Field(dir),
+			)
+
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Only include the objects under this prefix")
+	cmd.Flags().StringVar(&dir, "dir", "", "Output OCI image layout directory (required)")
+	cmd.Flags().StringVar(&base, "base", "", "OCI image layout directory holding the base image to append the layer to (optional)")
+	cmd.Flags().StringVar(&ref, "ref", "", "Reference name of the image in the output layout index, e.g. latest (optional)")
+	to = cmd.Flags().Int64("to", -1, "To version ID (optional)")
+
+	_ = cmd.MarkFlagRequired("project")
+	_ = cmd.MarkFlagRequired("dir")
+
+	return cmd
+}
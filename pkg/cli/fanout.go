@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdFanOut() *cobra.Command {
+	var (
+		source  int64
+		from    int64
+		to      *int64
+		targets []int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "fanout",
+		Short: "Apply the changes made to a source project between two versions onto many target projects",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if *to == -1 {
+				to = nil
+			}
+
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			var encodeErr error
+			_, err := c.FanOutUpdate(ctx, source, from, to, targets, func(response *pb.FanOutUpdateResponse) {
+				encoded, err := json.Marshal(response)
+				if err != nil {
+					encodeErr = fmt.Errorf("could not marshal result: %w", err)
+					return
+				}
+				fmt.Println(string(encoded))
+			})
+			if err != nil {
+				return fmt.Errorf("could not fan out project %v: %w", source, err)
+			}
+
+			return encodeErr
+		},
+	}
+
+	cmd.Flags().Int64Var(&source, "source", -1, "Source project ID (required)")
+	cmd.Flags().Int64Var(&from, "from", -1, "Base source version the targets were created from (required)")
+	cmd.Flags().Int64SliceVar(&targets, "targets", nil, "Comma separated list of target project IDs (required)")
+	to = cmd.Flags().Int64("to", -1, "Source version to apply (defaults to latest)")
+
+	_ = cmd.MarkFlagRequired("source")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("targets")
+
+	return cmd
+}
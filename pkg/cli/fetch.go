@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdFetch() *cobra.Command {
+	var (
+		project  int64
+		dir      string
+		cacheDir string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "fetch [paths...]",
+		Short: "Fetch the content of files left out or stubbed by a rebuild, all of them when no paths are given",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			result, err := c.FetchOmitted(ctx, project, dir, cacheDir, args)
+			if err != nil {
+				return fmt.Errorf("could not fetch omitted files: %w", err)
+			}
+
+			logger.Info(ctx, "fetched omitted files",
+				key.Project.Field(project),
+				key.Directory.Field(dir),
+				key.Version.Field(result.Version),
+				key.DiffCount.Field(result.Count),
+				key.OmittedCount.Field(len(result.Omitted)),
+			)
+
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory previously rebuilt with a max content send size or lazy threshold (required)")
+	cmd.Flags().StringVar(&cacheDir, "cachedir", "", "Path where the cache folder is mounted")
+
+	_ = cmd.MarkFlagRequired("project")
+	_ = cmd.MarkFlagRequired("dir")
+
+	return cmd
+}
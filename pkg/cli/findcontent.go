@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type ContentReference struct {
+	Project      int64  `json:"project"`
+	Path         string `json:"path"`
+	StartVersion int64  `json:"startVersion"`
+	StopVersion  *int64 `json:"stopVersion,omitempty"`
+	Packed       bool   `json:"packed"`
+}
+
+type FindContentResult struct {
+	References []ContentReference `json:"references"`
+	Truncated  bool               `json:"truncated"`
+}
+
+func NewCmdFindContent() *cobra.Command {
+	var (
+		hash    string
+		history bool
+		limit   int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "find-content",
+		Short: "List the projects and paths holding a content hash, e.g. to find every environment containing a vulnerable file",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			decoded, err := hex.DecodeString(hash)
+			if err != nil {
+				return fmt.Errorf("invalid hash %v: %w", hash, err)
+			}
+
+			var limitPtr *int64
+			if limit != -1 {
+				limitPtr = &limit
+			}
+
+			references, truncated, err := c.FindContent(ctx, decoded, history, limitPtr)
+			if err != nil {
+				return fmt.Errorf("could not find content: %w", err)
+			}
+
+			result := FindContentResult{References: []ContentReference{}, Truncated: truncated}
+			for _, reference := range references {
+				result.References = append(result.References, ContentReference{
+					Project:      reference.Project,
+					Path:         reference.Path,
+					StartVersion: reference.StartVersion,
+					StopVersion:  reference.StopVersion,
+					Packed:       reference.Packed,
+				})
+			}
+
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&hash, "hash", "", "Hex encoded sha256 of the content (required)")
+	cmd.Flags().BoolVar(&history, "history", false, "Also list the versions at which past paths held the content")
+	cmd.Flags().Int64Var(&limit, "limit", -1, "Number of references to return (defaults to the server's limit)")
+
+	_ = cmd.MarkFlagRequired("hash")
+
+	return cmd
+}
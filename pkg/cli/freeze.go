@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdFreeze() *cobra.Command {
+	var (
+		project int64
+		reason  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "freeze",
+		Short: "Make a project read-only, its updates are rejected while reads go on",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			var reasonPtr *string
+			if reason != "" {
+				reasonPtr = &reason
+			}
+
+			err := c.SetProjectFrozen(ctx, project, true, reasonPtr)
+			if err != nil {
+				return fmt.Errorf("could not freeze project %v: %w", project, err)
+			}
+
+			logger.Info(ctx, "project frozen", key.Project.Field(project))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&reason, "reason", "", "Why the project is frozen, included in the errors of rejected writes")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
+
+func NewCmdUnfreeze() *cobra.Command {
+	var (
+		project int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "unfreeze",
+		Short: "Make a frozen project writable again",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			err := c.SetProjectFrozen(ctx, project, false, nil)
+			if err != nil {
+				return fmt.Errorf("could not unfreeze project %v: %w", project, err)
+			}
+
+			logger.Info(ctx, "project unfrozen", key.Project.Field(project))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
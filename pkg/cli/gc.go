@@ -19,6 +19,7 @@ func NewCmdGc() *cobra.Command {
 		keep    int64
 		from    *int64
 		sample  float32
+		dryRun  bool
 	)
 
 	cmd := &cobra.Command{
@@ -51,7 +52,7 @@ func NewCmdGc() *cobra.Command {
 					from = nil
 				}
 
-				count, err = c.GcProject(ctx, project, keep, from)
+				count, err = c.GcProject(ctx, project, keep, from, dryRun)
 				if err != nil {
 					return fmt.Errorf("could not gc project %v: %w", project, err)
 				}
@@ -90,6 +91,7 @@ func NewCmdGc() *cobra.Command {
 	cmd.Flags().Int64Var(&keep, "keep", -1, "Amount of versions to keep (used by project and random-projects mode)")
 	from = cmd.Flags().Int64("from", -1, "Delete as of this version (used by project and random-projects mode)")
 	cmd.Flags().Float32Var(&sample, "sample", -1, "Percent of rows to sample (used by contents and random-projects mode)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only count the contents that would be removed (used by project mode)")
 
 	_ = cmd.MarkFlagRequired("mode")
 
@@ -5,16 +5,20 @@ import (
 
 	"github.com/gadget-inc/dateilager/internal/key"
 	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/pb"
 	"github.com/gadget-inc/dateilager/pkg/client"
 	"github.com/spf13/cobra"
 )
 
 func NewCmdGet() *cobra.Command {
 	var (
-		project int64
-		to      *int64
-		from    *int64
-		prefix  string
+		project   int64
+		to        *int64
+		from      *int64
+		prefix    string
+		vars      map[string]string
+		transform bool
+		snapshot  string
 	)
 
 	cmd := &cobra.Command{
@@ -30,10 +34,19 @@ func NewCmdGet() *cobra.Command {
 			vrange := client.VersionRange{From: from, To: to}
 
 			ctx := cmd.Context()
+			if snapshot != "" {
+				ctx = client.WithReadSnapshot(ctx, &pb.ReadSnapshotResponse{Project: project, Token: snapshot})
+			}
 
 			client := client.FromContext(ctx)
 
-			objects, err := client.Get(ctx, project, prefix, nil, vrange)
+			var objects []*pb.Object
+			var err error
+			if transform || len(vars) > 0 {
+				objects, err = client.GetTransformed(ctx, project, prefix, nil, vrange, vars)
+			} else {
+				objects, err = client.Get(ctx, project, prefix, nil, vrange)
+			}
 			if err != nil {
 				return fmt.Errorf("could not fetch data: %w", err)
 			}
@@ -49,8 +62,11 @@ func NewCmdGet() *cobra.Command {
 
 	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
 	cmd.Flags().StringVar(&prefix, "prefix", "", "Search prefix")
+	cmd.Flags().BoolVar(&transform, "transform", false, "Apply the project's checkout transforms")
+	cmd.Flags().StringToStringVar(&vars, "transform-var", nil, "Template variables used by the checkout transforms (implies --transform)")
 	from = cmd.Flags().Int64("from", -1, "From version ID (optional)")
 	to = cmd.Flags().Int64("to", -1, "To version ID (optional)")
+	cmd.Flags().StringVar(&snapshot, "snapshot-token", "", "Read the version pinned by a read-snapshot token (optional)")
 
 	_ = cmd.MarkFlagRequired("project")
 
@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"time"
+
 	"github.com/gadget-inc/dateilager/internal/key"
 	"github.com/gadget-inc/dateilager/internal/logger"
 	"github.com/gadget-inc/dateilager/pkg/client"
@@ -9,7 +11,8 @@ import (
 
 func NewCmdGetCache() *cobra.Command {
 	var (
-		path string
+		path             string
+		progressInterval time.Duration
 	)
 
 	cmd := &cobra.Command{
@@ -18,18 +21,21 @@ func NewCmdGetCache() *cobra.Command {
 			ctx := cmd.Context()
 			c := client.FromContext(ctx)
 
-			version, _, err := c.GetCache(ctx, path)
+			ctx, stopProgress := logProgress(ctx, "building cache", progressInterval)
+			version, count, err := c.GetCache(ctx, path)
+			progress := stopProgress()
 			if err != nil {
 				return err
 			}
 
-			logger.Info(ctx, "cache built", key.Version.Field(version))
+			logger.Info(ctx, "cache built", key.Version.Field(version), key.DiffCount.Field(count), key.Bytes.Field(progress.Bytes))
 
 			return nil
 		},
 	}
 
 	cmd.Flags().StringVar(&path, "path", "", "Cache directory")
+	cmd.Flags().DurationVar(&progressInterval, "progress-interval", 5*time.Second, "Interval between progress log lines (0 to disable)")
 
 	_ = cmd.MarkFlagRequired("path")
 
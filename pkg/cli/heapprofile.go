@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func NewCmdHeapProfile() *cobra.Command {
+	var (
+		output string
+		gc     bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "heap-profile",
+		Short: "Capture a heap profile of the server, to inspect with go tool pprof",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			profile, err := c.CaptureHeapProfile(ctx, gc)
+			if err != nil {
+				return fmt.Errorf("could not capture heap profile: %w", err)
+			}
+
+			err = os.WriteFile(output, profile, 0644)
+			if err != nil {
+				return fmt.Errorf("could not write heap profile %s: %w", output, err)
+			}
+
+			logger.Info(ctx, "heap profile captured", zap.String("path", output), zap.Int("bytes", len(profile)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "heap.pb.gz", "Path to write the profile to")
+	cmd.Flags().BoolVar(&gc, "gc", false, "Run a garbage collection before capturing so only live objects are reported")
+
+	return cmd
+}
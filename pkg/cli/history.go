@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdHistory() *cobra.Command {
+	var (
+		project int64
+		to      *int64
+		from    *int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the annotated versions of a project, newest first",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			if *from == -1 {
+				from = nil
+			}
+			if *to == -1 {
+				to = nil
+			}
+
+			history, err := c.History(ctx, project, client.VersionRange{From: from, To: to})
+			if err != nil {
+				return fmt.Errorf("could not get history of project %v: %w", project, err)
+			}
+
+			for _, annotation := range history {
+				fmt.Printf("%d\t%s\t%s\t%s\n",
+					annotation.Version,
+					time.Unix(0, annotation.CreatedAt).UTC().Format(time.RFC3339),
+					annotation.GetAuthor(),
+					annotation.GetMessage(),
+				)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	from = cmd.Flags().Int64("from", -1, "From version ID (optional)")
+	to = cmd.Flags().Int64("to", -1, "To version ID (optional)")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
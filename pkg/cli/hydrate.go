@@ -0,0 +1,234 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type hydrateMarker struct {
+	Project int64  `json:"project"`
+	Version int64  `json:"version"`
+	Count   uint32 `json:"count"`
+	Bytes   int64  `json:"bytes"`
+}
+
+// NewCmdHydrate is meant to run as a Kubernetes init container, every flag defaults to an environment variable so a pod spec
+// only needs `client hydrate`. Any failure exits non zero and leaves no ready file behind.
+func NewCmdHydrate() *cobra.Command {
+	var (
+		project          int64
+		to               int64
+		dir              string
+		prefix           string
+		ignores          string
+		cacheDir         string
+		readyFile        string
+		verifyPaths      string
+		progressInterval time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "hydrate",
+		Short: "Fetch the cache, rebuild a project with it, verify the checkout and write a ready file",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			err := checkEnvValues()
+			if err != nil {
+				return err
+			}
+
+			if project == -1 {
+				return fmt.Errorf("--project or DL_PROJECT is required")
+			}
+			if dir == "" {
+				return fmt.Errorf("--dir or DL_DIR is required")
+			}
+			if readyFile == "" {
+				readyFile = filepath.Join(dir, ".dl", "ready")
+			}
+
+			var toVersion *int64
+			if to != -1 {
+				toVersion = &to
+			}
+
+			var ignoreList []string
+			if len(ignores) > 0 {
+				ignoreList = strings.Split(ignores, ",")
+			}
+
+			var verifyList []string
+			if len(verifyPaths) > 0 {
+				verifyList = strings.Split(verifyPaths, ",")
+			}
+
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			err = os.Remove(readyFile)
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("could not remove stale ready file %v: %w", readyFile, err)
+			}
+
+			if cacheDir != "" {
+				cacheCtx, stopProgress := logProgress(ctx, "building cache", progressInterval)
+				version, count, err := c.GetCache(cacheCtx, cacheDir)
+				stopProgress()
+				if err != nil {
+					return fmt.Errorf("could not fetch cache: %w", err)
+				}
+
+				logger.Info(ctx, "cache built", key.Version.Field(version), key.DiffCount.Field(count))
+			}
+
+			rebuildCtx, stopProgress := logProgress(ctx, "rebuilding", progressInterval)
+			result, err := c.Rebuild(rebuildCtx, project, prefix, toVersion, dir, ignoreList, cacheDir, nil, true)
+			stopProgress()
+			if err != nil {
+				return fmt.Errorf("could not rebuild project: %w", err)
+			}
+
+			err = verifyHydrated(ctx, c, project, dir, result, verifyList)
+			if err != nil {
+				return fmt.Errorf("could not verify checkout: %w", err)
+			}
+
+			encoded, err := json.Marshal(hydrateMarker{Project: project, Version: result.Version, Count: result.Count, Bytes: result.Bytes})
+			if err != nil {
+				return fmt.Errorf("could not marshal ready file: %w", err)
+			}
+
+			err = writeFileAtomic(readyFile, encoded)
+			if err != nil {
+				return fmt.Errorf("could not write ready file: %w", err)
+			}
+
+			logger.Info(ctx, "hydrated",
+				key.Project.Field(project),
+				key.Directory.Field(dir),
+				key.Version.Field(result.Version),
+				key.DiffCount.Field(result.Count),
+				key.Bytes.Field(result.Bytes),
+			)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", envInt64("DL_PROJECT", -1), "Project ID (required, env DL_PROJECT)")
+	cmd.Flags().Int64Var(&to, "to", envInt64("DL_TO_VERSION", -1), "To version ID (optional, env DL_TO_VERSION)")
+	cmd.Flags().StringVar(&dir, "dir", os.Getenv("DL_DIR"), "Output directory (required, env DL_DIR)")
+	cmd.Flags().StringVar(&prefix, "prefix", os.Getenv("DL_PREFIX"), "Search prefix (env DL_PREFIX)")
+	cmd.Flags().StringVar(&ignores, "ignores", os.Getenv("DL_IGNORES"), "Comma separated list of ignore paths (env DL_IGNORES)")
+	cmd.Flags().StringVar(&cacheDir, "cachedir", os.Getenv("DL_CACHE_DIR"), "Cache directory fetched before the rebuild, the cache is skipped when unset (env DL_CACHE_DIR)")
+	cmd.Flags().StringVar(&readyFile, "ready-file", os.Getenv("DL_READY_FILE"), "File written once the checkout is verified, defaults to .dl/ready in the output directory (env DL_READY_FILE)")
+	cmd.Flags().StringVar(&verifyPaths, "verify-paths", os.Getenv("DL_VERIFY_PATHS"), "Comma separated list of paths checked against the server after the rebuild (env DL_VERIFY_PATHS)")
+	cmd.Flags().DurationVar(&progressInterval, "progress-interval", envDuration("DL_PROGRESS_INTERVAL", 5*time.Second), "Interval between progress log lines (0 to disable, env DL_PROGRESS_INTERVAL)")
+
+	return cmd
+}
+
+// verifyHydrated checks that dir records the rebuilt version, that no object was left out and that every verify path
+// exists locally exactly when it exists on the server, with the same size for regular files.
+func verifyHydrated(ctx context.Context, c *client.Client, project int64, dir string, result client.RebuildResult, paths []string) error {
+	version, err := client.ReadVersionFile(dir)
+	if err != nil {
+		return err
+	}
+	if version != result.Version {
+		return fmt.Errorf("%v is at version %v, expected %v", dir, version, result.Version)
+	}
+
+	if len(result.Omitted) > 0 {
+		return fmt.Errorf("%d objects were omitted, first %v", len(result.Omitted), result.Omitted[0])
+	}
+
+	if len(paths) == 0 {
+		return nil
+	}
+
+	stats, err := c.StatPaths(ctx, project, &version, paths)
+	if err != nil {
+		return err
+	}
+
+	for _, stat := range stats.Stats {
+		info, err := os.Lstat(filepath.Join(dir, stat.Path))
+		exists := err == nil
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("cannot stat %v: %w", stat.Path, err)
+		}
+
+		if exists != stat.Exists {
+			return fmt.Errorf("%v exists locally: %v, on the server: %v", stat.Path, exists, stat.Exists)
+		}
+
+		if exists && info.Mode().IsRegular() && info.Size() != stat.Size {
+			return fmt.Errorf("%v is %d bytes locally, %d bytes on the server", stat.Path, info.Size(), stat.Size)
+		}
+	}
+
+	return nil
+}
+
+// checkEnvValues fails on malformed environment values instead of silently falling back to the flag defaults.
+func checkEnvValues() error {
+	for _, name := range []string{"DL_PROJECT", "DL_TO_VERSION"} {
+		if value, ok := os.LookupEnv(name); ok {
+			_, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %v: %w", name, err)
+			}
+		}
+	}
+
+	if value, ok := os.LookupEnv("DL_PROGRESS_INTERVAL"); ok {
+		_, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid DL_PROGRESS_INTERVAL: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func writeFileAtomic(path string, content []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, content, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// envInt64 parses the integer in the environment variable name, fallback when it is unset or invalid.
+func envInt64(name string, fallback int64) int64 {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return fallback
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type InlineContentsResult struct {
+	Inlined  int64 `json:"inlined"`
+	Outlined int64 `json:"outlined"`
+}
+
+func NewCmdInlineContents() *cobra.Command {
+	var (
+		project int64
+		dryRun  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "inline-contents",
+		Short: "Move the small contents of a project into their object rows, following the server's inline threshold",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			inlined, outlined, err := c.InlineContents(ctx, project, dryRun)
+			if err != nil {
+				return fmt.Errorf("could not inline contents of project %v: %w", project, err)
+			}
+
+			encoded, err := json.Marshal(InlineContentsResult{Inlined: inlined, Outlined: outlined})
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only report how many objects would have their content moved")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
@@ -29,6 +29,7 @@ func NewCmdInspect() *cobra.Command {
 				key.LatestVersion.Field(inspect.LatestVersion),
 				key.LiveObjectsCount.Field(inspect.LiveObjectsCount),
 				key.TotalObjectsCount.Field(inspect.TotalObjectsCount),
+				key.TombstonesCount.Field(inspect.TombstonesCount),
 			)
 
 			return nil
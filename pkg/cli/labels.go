@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdLabels() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "labels",
+		Short: "Manage project labels",
+	}
+
+	cmd.AddCommand(NewCmdLabelsGet())
+	cmd.AddCommand(NewCmdLabelsSet())
+	cmd.AddCommand(NewCmdLabelsList())
+
+	return cmd
+}
+
+func NewCmdLabelsGet() *cobra.Command {
+	var (
+		project int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Print the labels of a project",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			labels, err := c.GetProjectLabels(ctx, project)
+			if err != nil {
+				return fmt.Errorf("could not get labels of project %v: %w", project, err)
+			}
+
+			encoded, err := json.Marshal(labels)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
+
+func NewCmdLabelsSet() *cobra.Command {
+	var (
+		project int64
+		labels  map[string]string
+		remove  []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Add, overwrite or remove labels of a project",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			result, err := c.SetProjectLabels(ctx, project, labels, remove)
+			if err != nil {
+				return fmt.Errorf("could not set labels of project %v: %w", project, err)
+			}
+
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringToStringVar(&labels, "label", nil, "Labels to set as key=value pairs (repeatable)")
+	cmd.Flags().StringSliceVar(&remove, "remove", nil, "Label keys to remove (repeatable)")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
+
+func NewCmdLabelsList() *cobra.Command {
+	var (
+		selector map[string]string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the projects matching a label selector",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			projects, err := c.ListProjects(ctx, selector)
+			if err != nil {
+				return fmt.Errorf("could not list projects: %w", err)
+			}
+
+			for _, project := range projects {
+				encoded, err := json.Marshal(project.Labels)
+				if err != nil {
+					return fmt.Errorf("could not marshal labels: %w", err)
+				}
+				fmt.Printf("%d\t%d\t%s\n", project.Id, project.Version, encoded)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringToStringVar(&selector, "selector", nil, "Only list projects having all these key=value labels")
+
+	return cmd
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdList() *cobra.Command {
+	var (
+		project int64
+		version *int64
+		prefix  string
+		limit   *int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the paths of a project under a prefix along with their size, mode and hash, packed paths included",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if *version == -1 {
+				version = nil
+			}
+			if *limit == -1 {
+				limit = nil
+			}
+
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			response, err := c.ListPaths(ctx, project, version, prefix, limit)
+			if err != nil {
+				return fmt.Errorf("could not list paths: %w", err)
+			}
+
+			for _, stat := range response.Stats {
+				fmt.Printf("%s\t%v\t%d\t%x\n", stat.Path, fs.FileMode(stat.Mode), stat.Size, stat.Hash)
+			}
+
+			if response.Truncated {
+				fmt.Fprintf(os.Stderr, "listing truncated at %d paths\n", len(response.Stats))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Only list paths under this prefix")
+	version = cmd.Flags().Int64("version", -1, "Version ID (optional)")
+	limit = cmd.Flags().Int64("limit", -1, "Maximum number of paths to list (optional)")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
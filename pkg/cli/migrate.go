@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/migrations"
+	"github.com/jackc/pgx/v5"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func newCmdMigrate(dbUri *string) *cobra.Command {
+	var (
+		to     int64
+		status bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply the schema migrations embedded in the server binary",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			if status {
+				return printMigrationStatus(ctx, cmd, *dbUri)
+			}
+
+			return runMigrations(ctx, *dbUri, to)
+		},
+	}
+
+	cmd.Flags().Int64Var(&to, "to", -1, "Migrate up or down to this version, 0 reverts every migration (defaults to the latest)")
+	cmd.Flags().BoolVar(&status, "status", false, "Print the current and latest versions without migrating")
+
+	return cmd
+}
+
+func runMigrations(ctx context.Context, dbUri string, to int64) error {
+	all, err := db.LoadMigrations(migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	conn, err := pgx.Connect(ctx, dbUri)
+	if err != nil {
+		return fmt.Errorf("cannot connect to DB %s: %w", dbUri, err)
+	}
+	defer conn.Close(context.Background())
+
+	from, err := db.Migrate(ctx, conn, all, to)
+	if err != nil {
+		return err
+	}
+
+	current, _, err := db.MigrationVersion(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(ctx, "schema migrated", zap.Int64("from", from), zap.Int64("to", current))
+	return nil
+}
+
+func printMigrationStatus(ctx context.Context, cmd *cobra.Command, dbUri string) error {
+	all, err := db.LoadMigrations(migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	conn, err := pgx.Connect(ctx, dbUri)
+	if err != nil {
+		return fmt.Errorf("cannot connect to DB %s: %w", dbUri, err)
+	}
+	defer conn.Close(context.Background())
+
+	current, dirty, err := db.MigrationVersion(ctx, conn)
+	if err != nil {
+		return err
+	}
+
+	latest := int64(0)
+	if len(all) > 0 {
+		latest = all[len(all)-1].Version
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "current: %d\nlatest: %d\ndirty: %t\n", current, latest, dirty)
+	return nil
+}
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/gadget-inc/dateilager/internal/key"
 	"github.com/gadget-inc/dateilager/internal/logger"
@@ -11,9 +12,11 @@ import (
 
 func NewCmdNew() *cobra.Command {
 	var (
-		id       int64
-		template int64
-		patterns string
+		id             int64
+		template       int64
+		patterns       string
+		transforms     string
+		idempotencyKey string
 	)
 
 	cmd := &cobra.Command{
@@ -21,14 +24,23 @@ func NewCmdNew() *cobra.Command {
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			ctx := cmd.Context()
 
-			client := client.FromContext(ctx)
+			c := client.FromContext(ctx)
 
-			var templatePtr *int64
+			var opts client.NewProjectOptions
 			if template != -1 {
-				templatePtr = &template
+				opts.Template = &template
+			}
+			if patterns != "" {
+				opts.PackPatterns = strings.Split(patterns, ",")
+			}
+			if transforms != "" {
+				opts.Transforms = strings.Split(transforms, ",")
+			}
+			if idempotencyKey != "" {
+				opts.IdempotencyKey = &idempotencyKey
 			}
 
-			err := client.NewProject(ctx, id, templatePtr, &patterns)
+			err := c.NewProjectWithOptions(ctx, id, opts)
 			if err != nil {
 				return fmt.Errorf("could not create new project: %w", err)
 			}
@@ -41,6 +53,8 @@ func NewCmdNew() *cobra.Command {
 	cmd.Flags().Int64Var(&id, "id", -1, "Project ID (required)")
 	cmd.Flags().Int64Var(&template, "template", -1, "Template ID")
 	cmd.Flags().StringVar(&patterns, "patterns", "", "Comma separated pack patterns")
+	cmd.Flags().StringVar(&transforms, "transforms", "", "Comma separated checkout transforms, e.g. strip-sourcemaps:**.js or lfs-pointer:assets/**")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "Retrying with the same key succeeds without creating the project again")
 
 	_ = cmd.MarkFlagRequired("id")
 
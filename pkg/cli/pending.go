@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdPending() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pending",
+		Short: "Inspect, send or drop the changes queued by updates that failed to reach the server",
+	}
+
+	cmd.AddCommand(NewCmdPendingList())
+	cmd.AddCommand(NewCmdPendingFlush())
+	cmd.AddCommand(NewCmdPendingDrop())
+
+	return cmd
+}
+
+func NewCmdPendingList() *cobra.Command {
+	var (
+		dir string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Print the queued diffs of a directory, oldest first",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			pending, err := client.ReadPending(dir)
+			if err != nil {
+				return err
+			}
+			if pending == nil {
+				pending = []client.PendingDiff{}
+			}
+
+			encoded, err := json.Marshal(pending)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory (required)")
+
+	_ = cmd.MarkFlagRequired("dir")
+
+	return cmd
+}
+
+func NewCmdPendingFlush() *cobra.Command {
+	var (
+		project int64
+		dir     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "flush",
+		Short: "Send the queued diffs along with any new change of the directory",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			version, count, err := c.Update(ctx, project, dir)
+			if err != nil {
+				return fmt.Errorf("could not flush pending updates: %w", err)
+			}
+
+			logger.Info(ctx, "flushed pending updates", key.Project.Field(project), key.Version.Field(version), key.DiffCount.Field(count))
+			fmt.Println(version)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory (required)")
+
+	_ = cmd.MarkFlagRequired("project")
+	_ = cmd.MarkFlagRequired("dir")
+
+	return cmd
+}
+
+func NewCmdPendingDrop() *cobra.Command {
+	var (
+		dir string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "drop",
+		Short: "Forget the queued diffs, their changes are only sent again if the files change once more",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			count, err := client.DropPending(dir)
+			if err != nil {
+				return err
+			}
+
+			logger.Info(ctx, "dropped pending updates", key.Directory.Field(dir), key.Count.Field(int64(count)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory (required)")
+
+	_ = cmd.MarkFlagRequired("dir")
+
+	return cmd
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type PolicyResult struct {
+	MaxFileSize    *int64   `json:"maxFileSize,omitempty"`
+	ForbiddenPaths []string `json:"forbiddenPaths"`
+}
+
+func printPolicy(policy *pb.ProjectPolicy) error {
+	result := PolicyResult{MaxFileSize: policy.MaxFileSize, ForbiddenPaths: policy.ForbiddenPaths}
+	if result.ForbiddenPaths == nil {
+		result.ForbiddenPaths = []string{}
+	}
+
+	encoded, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("could not marshal result: %w", err)
+	}
+
+	fmt.Println(string(encoded))
+	return nil
+}
+
+func NewCmdPolicy() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Manage the file size and forbidden path restrictions of project updates",
+	}
+
+	cmd.AddCommand(NewCmdPolicyGet())
+	cmd.AddCommand(NewCmdPolicySet())
+
+	return cmd
+}
+
+func NewCmdPolicyGet() *cobra.Command {
+	var (
+		project int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "get",
+		Short: "Print the policy of a project",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			policy, err := c.GetProjectPolicy(ctx, project)
+			if err != nil {
+				return fmt.Errorf("could not get policy of project %v: %w", project, err)
+			}
+
+			return printPolicy(policy)
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
+
+func NewCmdPolicySet() *cobra.Command {
+	var (
+		project        int64
+		maxFileSize    *int64
+		forbiddenPaths []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Replace the policy of a project, omitted restrictions are removed",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if *maxFileSize == -1 {
+				maxFileSize = nil
+			}
+
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			policy, err := c.SetProjectPolicy(ctx, project, &pb.ProjectPolicy{MaxFileSize: maxFileSize, ForbiddenPaths: forbiddenPaths})
+			if err != nil {
+				return fmt.Errorf("could not set policy of project %v: %w", project, err)
+			}
+
+			return printPolicy(policy)
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	maxFileSize = cmd.Flags().Int64("max-file-size", -1, "Largest accepted file in bytes (optional)")
+	cmd.Flags().StringSliceVar(&forbiddenPaths, "forbid", nil, "Glob of paths updates cannot write, e.g. **/*.pem (repeatable)")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
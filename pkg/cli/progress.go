@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/pkg/client"
+)
+
+// logProgress logs a progress line every interval for the client calls made with the returned context.
+// The returned function stops logging and returns the final totals. A non positive interval only collects the totals.
+func logProgress(ctx context.Context, msg string, interval time.Duration) (context.Context, func() client.Progress) {
+	var (
+		mu       sync.Mutex
+		progress client.Progress
+		changed  bool
+	)
+
+	ctx = client.WithProgress(ctx, func(p client.Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		progress = p
+		changed = true
+	})
+
+	snapshot := func() (client.Progress, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		wasChanged := changed
+		changed = false
+		return progress, wasChanged
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		if interval <= 0 {
+			<-done
+			return
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				p, wasChanged := snapshot()
+				if !wasChanged {
+					continue
+				}
+
+				logger.Info(ctx, msg,
+					key.Count.Field(p.Objects),
+					key.TotalObjectsCount.Field(p.TotalObjects),
+					key.Bytes.Field(p.Bytes),
+				)
+			}
+		}
+	}()
+
+	return ctx, func() client.Progress {
+		close(done)
+		<-stopped
+		p, _ := snapshot()
+		return p
+	}
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdProvision() *cobra.Command {
+	var (
+		id              int64
+		template        int64
+		templateVersion int64
+		patterns        string
+		transforms      string
+		labels          map[string]string
+		message         string
+		author          string
+		idempotencyKey  string
+		dir             string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "provision",
+		Short: "Create a project from a template and label it in a single transaction, optionally rebuilding it into a directory",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			opts := client.ProvisionOptions{Labels: labels}
+			if template != -1 {
+				opts.Template = &template
+			}
+			if templateVersion != -1 {
+				opts.TemplateVersion = &templateVersion
+			}
+			if patterns != "" {
+				opts.PackPatterns = strings.Split(patterns, ",")
+			}
+			if transforms != "" {
+				opts.Transforms = strings.Split(transforms, ",")
+			}
+			if message != "" {
+				opts.Message = &message
+			}
+			if author != "" {
+				opts.Author = &author
+			}
+			if idempotencyKey != "" {
+				opts.IdempotencyKey = &idempotencyKey
+			}
+
+			version, provisioned, err := c.ProvisionProject(ctx, id, opts)
+			if err != nil {
+				return fmt.Errorf("could not provision project %v: %w", id, err)
+			}
+
+			if dir != "" {
+				_, err = c.Rebuild(ctx, id, "", &version, dir, nil, "", nil, true)
+				if err != nil {
+					return fmt.Errorf("could not rebuild provisioned project %v: %w", id, err)
+				}
+			}
+
+			encoded, err := json.Marshal(map[string]any{"version": version, "labels": provisioned})
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&id, "id", -1, "Project ID (required)")
+	cmd.Flags().Int64Var(&template, "template", -1, "Template ID to clone into the project")
+	cmd.Flags().Int64Var(&templateVersion, "template-version", -1, "Version of the template to clone (defaults to its latest version)")
+	cmd.Flags().StringVar(&patterns, "patterns", "", "Comma separated pack patterns")
+	cmd.Flags().StringVar(&transforms, "transforms", "", "Comma separated checkout transforms, e.g. strip-sourcemaps:**.js or lfs-pointer:assets/**")
+	cmd.Flags().StringToStringVar(&labels, "label", nil, "Label to set on the project as key=value (repeatable)")
+	cmd.Flags().StringVar(&message, "message", "", "Message describing the cloned version (optional)")
+	cmd.Flags().StringVar(&author, "author", "", "Author of the cloned version (optional)")
+	cmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "Retrying with the same key returns the result of the first attempt")
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory to rebuild the provisioned project into (optional)")
+
+	_ = cmd.MarkFlagRequired("id")
+
+	return cmd
+}
@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/gadget-inc/dateilager/pkg/proxy"
+	"github.com/gadget-inc/dateilager/pkg/server"
+	"github.com/gadget-inc/dateilager/pkg/version"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func NewProxyCommand() *cobra.Command {
+	var (
+		shutdownTelemetry func()
+	)
+
+	var (
+		level         *zapcore.Level
+		encoding      string
+		tracing       bool
+		port          int
+		upstreamHost  string
+		upstreamPort  uint16
+		dialTimeout   time.Duration
+		certFile      string
+		keyFile       string
+		cacheDir      string
+		cacheMaxBytes int64
+		pruneInterval time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:               "proxy",
+		Short:             "DateiLager read-through proxy",
+		DisableAutoGenTag: true,
+		Version:           version.Version,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SilenceUsage = true // silence usage when an error occurs after flags have been parsed
+
+			config := zap.NewProductionConfig()
+			config.Encoding = encoding
+			config.Level = zap.NewAtomicLevelAt(*level)
+			config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+			err := logger.Init(config)
+			if err != nil {
+				return fmt.Errorf("could not initialize logger: %w", err)
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if port <= 0 || port > 65535 {
+				return fmt.Errorf("invalid port %d", port)
+			}
+			if upstreamHost == "" {
+				return fmt.Errorf("required flag(s) \"upstream-host\" not set")
+			}
+			if cacheMaxBytes < 0 {
+				return fmt.Errorf("cache-max-bytes cannot be negative")
+			}
+
+			ctx := cmd.Context()
+
+			if tracing {
+				shutdownTelemetry = telemetry.Init(ctx, telemetry.Server)
+			}
+
+			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			if err != nil {
+				return fmt.Errorf("cannot load TLS cert %v and key %v: %w", certFile, keyFile, err)
+			}
+
+			cache, err := proxy.NewCache(cacheDir, cacheMaxBytes)
+			if err != nil {
+				return err
+			}
+			if pruneInterval > 0 {
+				cache.PruneEvery(ctx, pruneInterval)
+			}
+
+			upstream, err := proxy.DialUpstream(ctx, upstreamHost, upstreamPort, dialTimeout)
+			if err != nil {
+				return fmt.Errorf("cannot connect to upstream %v:%d: %w", upstreamHost, upstreamPort, err)
+			}
+			defer upstream.Close()
+
+			p := proxy.New(upstream, cache)
+
+			options := []grpc.ServerOption{
+				grpc.Creds(credentials.NewTLS(&tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12})),
+				grpc.StreamInterceptor(
+					grpc_middleware.ChainStreamServer(
+						grpc_recovery.StreamServerInterceptor(),
+						otelgrpc.StreamServerInterceptor(),
+						logger.StreamServerInterceptor(),
+					),
+				),
+				grpc.ReadBufferSize(server.BUFFER_SIZE),
+				grpc.WriteBufferSize(server.BUFFER_SIZE),
+				grpc.InitialConnWindowSize(server.INITIAL_CONN_WINDOW_SIZE),
+				grpc.InitialWindowSize(server.INITIAL_WINDOW_SIZE),
+				grpc.MaxRecvMsgSize(server.MAX_MESSAGE_SIZE),
+				grpc.MaxSendMsgSize(server.MAX_MESSAGE_SIZE),
+			}
+			options = append(options, p.ServerOptions()...)
+
+			s := grpc.NewServer(options...)
+
+			healthServer := health.NewServer()
+			healthpb.RegisterHealthServer(s, healthServer)
+			healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+
+			listen, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+			if err != nil {
+				return fmt.Errorf("failed to listen on TCP port %d: %w", port, err)
+			}
+
+			osSignals := make(chan os.Signal, 1)
+			signal.Notify(osSignals, os.Interrupt, syscall.SIGTERM)
+			go func() {
+				<-osSignals
+				s.GracefulStop()
+			}()
+
+			logger.Info(ctx, "start proxy server", key.Port.Field(port), key.Server.Field(upstreamHost))
+			return s.Serve(listen)
+		},
+		PostRunE: func(cmd *cobra.Command, _ []string) error {
+			if shutdownTelemetry != nil {
+				shutdownTelemetry()
+			}
+			return nil
+		},
+	}
+
+	flags := cmd.PersistentFlags()
+
+	level = zap.LevelFlag("log-level", zap.DebugLevel, "Log level")
+	flags.AddGoFlag(flag.CommandLine.Lookup("log-level"))
+	flags.StringVar(&encoding, "log-encoding", "console", "Log encoding (console | json)")
+	flags.BoolVar(&tracing, "tracing", false, "Whether tracing is enabled")
+
+	flags.IntVar(&port, "port", 5051, "GRPC proxy port")
+	flags.StringVar(&upstreamHost, "upstream-host", "", "Hostname of the DateiLager server calls are forwarded to (required)")
+	flags.Uint16Var(&upstreamPort, "upstream-port", 5051, "Port of the DateiLager server calls are forwarded to")
+	flags.DurationVar(&dialTimeout, "dial-timeout", client.DEFAULT_DIAL_TIMEOUT, "How long to wait for the connection to the upstream server")
+	flags.StringVar(&certFile, "cert", "development/server.crt", "TLS cert file")
+	flags.StringVar(&keyFile, "key", "development/server.key", "TLS key file")
+	flags.StringVar(&cacheDir, "cache-dir", "", "Directory of the on-disk content cache (required)")
+	flags.Int64Var(&cacheMaxBytes, "cache-max-bytes", 0, "Prune the least recently read cached contents above this many bytes (0 for no limit)")
+	flags.DurationVar(&pruneInterval, "cache-prune-interval", time.Minute, "How often to prune the cache down to --cache-max-bytes")
+
+	_ = cmd.MarkPersistentFlagRequired("cache-dir")
+
+	return cmd
+}
+
+func ProxyExecute() {
+	ctx := context.Background()
+	cmd := NewProxyCommand()
+
+	err := cmd.ExecuteContext(ctx)
+
+	logger.Info(ctx, "shut down proxy")
+	_ = logger.Sync(ctx)
+
+	if err != nil {
+		logger.Fatal(ctx, "proxy failed", zap.Error(err))
+	}
+}
@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdPut() *cobra.Command {
+	var (
+		project     int64
+		path        string
+		file        string
+		baseVersion int64
+		message     string
+		author      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "put",
+		Short: "Write a single file in a new version, without diffing a whole directory",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			info, err := os.Stat(file)
+			if err != nil {
+				return fmt.Errorf("could not stat %v: %w", file, err)
+			}
+
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("could not read %v: %w", file, err)
+			}
+
+			object := &pb.Object{
+				Path:    path,
+				Mode:    int64(info.Mode()),
+				Size:    int64(len(content)),
+				Content: content,
+			}
+
+			version, err := c.PutObject(ctx, project, object, optionalVersion(baseVersion), optionalString(message), optionalString(author))
+			if err != nil {
+				return fmt.Errorf("could not put object: %w", err)
+			}
+
+			fmt.Println(version)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&path, "path", "", "Path of the object in the project (required)")
+	cmd.Flags().StringVar(&file, "file", "", "Local file holding the content (required)")
+	cmd.Flags().Int64Var(&baseVersion, "base-version", -1, "Fail if the path changed after this version (optional)")
+	cmd.Flags().StringVar(&message, "message", "", "Message describing the new version (optional)")
+	cmd.Flags().StringVar(&author, "author", "", "Author of the new version (optional)")
+
+	_ = cmd.MarkFlagRequired("project")
+	_ = cmd.MarkFlagRequired("path")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func NewCmdRemove() *cobra.Command {
+	var (
+		project     int64
+		path        string
+		baseVersion int64
+		message     string
+		author      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "remove",
+		Short: "Delete a single path in a new version, without diffing a whole directory",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			version, err := c.DeleteObject(ctx, project, path, optionalVersion(baseVersion), optionalString(message), optionalString(author))
+			if err != nil {
+				return fmt.Errorf("could not delete object: %w", err)
+			}
+
+			fmt.Println(version)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&path, "path", "", "Path of the object in the project (required)")
+	cmd.Flags().Int64Var(&baseVersion, "base-version", -1, "Fail if the path changed after this version (optional)")
+	cmd.Flags().StringVar(&message, "message", "", "Message describing the new version (optional)")
+	cmd.Flags().StringVar(&author, "author", "", "Author of the new version (optional)")
+
+	_ = cmd.MarkFlagRequired("project")
+	_ = cmd.MarkFlagRequired("path")
+
+	return cmd
+}
+
+func optionalVersion(version int64) *int64 {
+	if version == -1 {
+		return nil
+	}
+	return &version
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdReadSnapshot() *cobra.Command {
+	var project int64
+
+	cmd := &cobra.Command{
+		Use:   "read-snapshot",
+		Short: "Print the latest version of a project and a token pinning later reads to it",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			snapshot, err := c.ReadSnapshot(ctx, project)
+			if err != nil {
+				return fmt.Errorf("could not read snapshot: %w", err)
+			}
+
+			fmt.Printf("%d\t%s\n", snapshot.Version, snapshot.Token)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
@@ -1,13 +1,18 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/gadget-inc/dateilager/internal/files"
 	"github.com/gadget-inc/dateilager/internal/key"
 	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/pb"
 	"github.com/gadget-inc/dateilager/pkg/client"
 	"github.com/spf13/cobra"
 )
@@ -23,6 +28,22 @@ func NewCmdRebuild() *cobra.Command {
 		cacheDir         string
 		fileMatchInclude string
 		fileMatchExclude string
+		paths            string
+		pathsFile        string
+		lazyThreshold    int64
+		restoreMtimes    bool
+		atomic           bool
+		forceFullRebuild bool
+		progressInterval time.Duration
+		snapshot         string
+		output           string
+		compress         bool
+		workers          int
+		durability       string
+		syncBatchSize    int
+		ioUring          bool
+		transform        bool
+		transformVars    map[string]string
 	)
 
 	cmd := &cobra.Command{
@@ -33,7 +54,31 @@ func NewCmdRebuild() *cobra.Command {
 			}
 
 			ctx := cmd.Context()
-			client := client.FromContext(ctx)
+			c := client.FromContext(ctx)
+
+			if snapshot != "" {
+				ctx = client.WithReadSnapshot(ctx, &pb.ReadSnapshotResponse{Project: project, Token: snapshot})
+			}
+
+			if cmd.Flags().Changed("workers") {
+				ctx = client.WithWriteWorkers(ctx, workers)
+			}
+
+			if cmd.Flags().Changed("io-uring") {
+				ctx = client.WithIOUring(ctx, ioUring)
+			}
+
+			if transform || len(transformVars) > 0 {
+				ctx = client.WithTransforms(ctx, transformVars)
+			}
+
+			if cmd.Flags().Changed("durability") || cmd.Flags().Changed("sync-batch-size") {
+				level, err := files.ParseDurability(durability)
+				if err != nil {
+					return err
+				}
+				ctx = client.WithDurability(ctx, level, syncBatchSize)
+			}
 
 			var ignoreList []string
 			if len(ignores) > 0 {
@@ -45,7 +90,63 @@ func NewCmdRebuild() *cobra.Command {
 				return err
 			}
 
-			result, err := client.Rebuild(ctx, project, prefix, to, dir, ignoreList, cacheDir, matcher, summarize)
+			pathList, err := readPathList(paths, pathsFile)
+			if err != nil {
+				return err
+			}
+
+			ctx, stopProgress := logProgress(ctx, "rebuilding", progressInterval)
+			defer stopProgress()
+
+			if output != "" {
+				if dir != "" || len(pathList) > 0 || lazyThreshold > 0 || atomic || forceFullRebuild || cacheDir != "" {
+					return fmt.Errorf("--output cannot be combined with --dir, --paths, --paths-file, --lazy-threshold, --atomic, --force-full-rebuild or --cachedir")
+				}
+
+				return rebuildTar(ctx, c, project, prefix, ignoreList, to, output, compress)
+			}
+
+			if compress {
+				return fmt.Errorf("--gzip requires --output")
+			}
+
+			if forceFullRebuild {
+				if len(pathList) > 0 || lazyThreshold > 0 || atomic {
+					return fmt.Errorf("--force-full-rebuild cannot be combined with --paths, --paths-file, --lazy-threshold or --atomic")
+				}
+			} else if len(pathList) > 0 {
+				if prefix != "" || len(ignoreList) > 0 {
+					return fmt.Errorf("--paths and --paths-file cannot be combined with --prefix or --ignores")
+				}
+
+				if lazyThreshold > 0 {
+					return fmt.Errorf("--lazy-threshold cannot be combined with --paths or --paths-file")
+				}
+
+				if atomic {
+					return fmt.Errorf("--atomic cannot be combined with --paths or --paths-file")
+				}
+			} else if lazyThreshold > 0 && atomic {
+				return fmt.Errorf("--atomic cannot be combined with --lazy-threshold")
+			}
+
+			result, err := c.Project(project).Rebuild(ctx, client.RebuildOptions{
+				Dir:           dir,
+				ToVersion:     to,
+				Prefix:        prefix,
+				Ignores:       ignoreList,
+				Paths:         pathList,
+				CacheDir:      cacheDir,
+				Matcher:       matcher,
+				SkipSummary:   !summarize,
+				RestoreMtimes: restoreMtimes,
+				LazyThreshold: lazyThreshold,
+				Atomic:        atomic,
+				ForceFull:     forceFullRebuild,
+			})
+			if client.IsCorruptMetadata(err) {
+				return fmt.Errorf("could not rebuild project, rerun with --force-full-rebuild to rebuild %v from scratch: %w", dir, err)
+			}
 			if err != nil {
 				return fmt.Errorf("could not rebuild project: %w", err)
 			}
@@ -56,6 +157,7 @@ func NewCmdRebuild() *cobra.Command {
 					key.Directory.Field(dir),
 					key.Version.Field(result.Version),
 					key.DiffCount.Field(result.Count),
+					key.Bytes.Field(result.Bytes),
 				)
 			}
 
@@ -77,9 +179,82 @@ func NewCmdRebuild() *cobra.Command {
 	cmd.Flags().StringVar(&cacheDir, "cachedir", "", "Path where the cache folder is mounted")
 	cmd.Flags().StringVar(&fileMatchInclude, "matchinclude", "", "Set fileMatch to true if the written files are matched by this glob pattern")
 	cmd.Flags().StringVar(&fileMatchExclude, "matchexclude", "", "Set fileMatch to false if the written files are matched by this glob pattern")
+	cmd.Flags().StringVar(&paths, "paths", "", "Comma separated list of exact paths to rebuild")
+	cmd.Flags().StringVar(&pathsFile, "paths-file", "", "File containing exact paths to rebuild, one per line")
+	cmd.Flags().Int64Var(&lazyThreshold, "lazy-threshold", 0, "Write files larger than this many bytes as placeholders to be fetched later by the fetch command (0 to disable)")
+	cmd.Flags().BoolVar(&restoreMtimes, "restore-mtimes", false, "Set the modification time of written files to the one recorded when they were updated instead of the write time")
+	cmd.Flags().BoolVar(&atomic, "atomic", false, "Build the new version in a sibling directory and swap it into place so readers never see a partially updated tree")
+	cmd.Flags().BoolVar(&forceFullRebuild, "force-full-rebuild", false, "Ignore the directory's version and metadata and rebuild it from scratch, to recover from a corrupt .dl directory")
+	cmd.Flags().DurationVar(&progressInterval, "progress-interval", 5*time.Second, "Interval between progress log lines (0 to disable)")
 	to = cmd.Flags().Int64("to", -1, "To version ID (optional)")
+	cmd.Flags().StringVar(&snapshot, "snapshot-token", "", "Rebuild the version pinned by a read-snapshot token (optional)")
+	cmd.Flags().StringVar(&output, "output", "", "Write the project as a tar to this file instead of rebuilding a directory, - for stdout")
+	cmd.Flags().BoolVar(&compress, "gzip", false, "Gzip the tar written to --output")
+	cmd.Flags().IntVar(&workers, "workers", 0, "Number of concurrent file writers, 0 tunes it to the measured write throughput (defaults to DL_WRITE_WORKERS)")
+	cmd.Flags().BoolVar(&ioUring, "io-uring", false, "Write files in bulk through io_uring on Linux, falling back to direct writes where unavailable (defaults to DL_IO_URING)")
+	cmd.Flags().StringVar(&durability, "durability", "batched", "How written files are flushed to disk: none, batched or full (defaults to DL_DURABILITY)")
+	cmd.Flags().IntVar(&syncBatchSize, "sync-batch-size", files.DefaultSyncBatchSize, "Files written between flushes with batched durability (defaults to DL_SYNC_BATCH_SIZE)")
+	cmd.Flags().BoolVar(&transform, "transform", false, "Apply the project's checkout transforms to the written files")
+	cmd.Flags().StringToStringVar(&transformVars, "transform-var", nil, "Template variables used by the checkout transforms (implies --transform)")
 
 	_ = cmd.MarkFlagRequired("project")
 
 	return cmd
 }
+
+// rebuildTar writes the project as a tar to output, the result is logged instead of printed as stdout may hold the tar.
+func rebuildTar(ctx context.Context, c *client.Client, project int64, prefix string, ignores []string, to *int64, output string, compress bool) error {
+	writer := io.Writer(os.Stdout)
+	if output != "-" {
+		file, err := os.Create(output)
+		if err != nil {
+			return fmt.Errorf("could not create output %v: %w", output, err)
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	result, err := c.RebuildTar(ctx, project, prefix, ignores, to, writer, compress)
+	if err != nil {
+		return fmt.Errorf("could not rebuild project: %w", err)
+	}
+
+	if file, ok := writer.(*os.File); ok && file != os.Stdout {
+		err = file.Close()
+		if err != nil {
+			return fmt.Errorf("could not close output %v: %w", output, err)
+		}
+	}
+
+	logger.Info(ctx, "wrote tar",
+		key.Project.Field(project),
+		key.Version.Field(result.Version),
+		key.DiffCount.Field(result.Count),
+		key.Bytes.Field(result.Bytes),
+	)
+
+	return nil
+}
+
+func readPathList(paths string, pathsFile string) ([]string, error) {
+	var pathList []string
+	if len(paths) > 0 {
+		pathList = strings.Split(paths, ",")
+	}
+
+	if pathsFile != "" {
+		content, err := os.ReadFile(pathsFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read paths file %v: %w", pathsFile, err)
+		}
+
+		for _, line := range strings.Split(string(content), "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" {
+				pathList = append(pathList, line)
+			}
+		}
+	}
+
+	return pathList, nil
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type PathRepairResult struct {
+	Path          string `json:"path"`
+	CanonicalPath string `json:"canonicalPath"`
+	StartVersion  int64  `json:"startVersion"`
+	StopVersion   *int64 `json:"stopVersion,omitempty"`
+	Action        string `json:"action"`
+}
+
+type RepairPathsResult struct {
+	Checked int64              `json:"checked"`
+	Repairs []PathRepairResult `json:"repairs"`
+}
+
+func NewCmdRepairPaths() *cobra.Command {
+	var (
+		project int64
+		dryRun  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "repair-paths",
+		Short: "Merge the objects of a project stored under non canonical spellings of the same path",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			response, err := c.RepairPaths(ctx, project, dryRun)
+			if err != nil {
+				return fmt.Errorf("could not repair paths of project %v: %w", project, err)
+			}
+
+			result := RepairPathsResult{Checked: response.Checked, Repairs: []PathRepairResult{}}
+			for _, repair := range response.Repairs {
+				result.Repairs = append(result.Repairs, PathRepairResult{
+					Path:          repair.Path,
+					CanonicalPath: repair.CanonicalPath,
+					StartVersion:  repair.StartVersion,
+					StopVersion:   repair.StopVersion,
+					Action:        repair.Action.String(),
+				})
+			}
+
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only report the paths that are not canonical")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdRestore() *cobra.Command {
+	var (
+		project     int64
+		paths       string
+		fromVersion int64
+		message     string
+		author      string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Re-add deleted paths in a new version, as they were at the last version they existed at",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			var fromVersionPtr *int64
+			if fromVersion != -1 {
+				fromVersionPtr = &fromVersion
+			}
+
+			var messagePtr, authorPtr *string
+			if message != "" {
+				messagePtr = &message
+			}
+			if author != "" {
+				authorPtr = &author
+			}
+
+			version, restored, err := c.RestorePaths(ctx, project, strings.Split(paths, ","), fromVersionPtr, messagePtr, authorPtr)
+			if err != nil {
+				return fmt.Errorf("could not restore paths: %w", err)
+			}
+
+			fromVersions := make(map[string]int64, len(restored))
+			for _, path := range restored {
+				fromVersions[path.Path] = path.FromVersion
+			}
+
+			encoded, err := json.Marshal(map[string]any{"version": version, "restored": fromVersions})
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&paths, "paths", "", "Comma separated paths to restore (required)")
+	cmd.Flags().Int64Var(&fromVersion, "from-version", -1, "Version to restore the paths from (defaults to the last version each one existed at)")
+	cmd.Flags().StringVar(&message, "message", "", "Message describing the restored version (optional)")
+	cmd.Flags().StringVar(&author, "author", "", "Author of the restored version (optional)")
+
+	_ = cmd.MarkFlagRequired("project")
+	_ = cmd.MarkFlagRequired("paths")
+
+	return cmd
+}
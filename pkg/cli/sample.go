@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type SampledObject struct {
+	Project        int64  `json:"project"`
+	Path           string `json:"path"`
+	Mode           int64  `json:"mode"`
+	Size           int64  `json:"size"`
+	Content        []byte `json:"content,omitempty"`
+	ContentOmitted bool   `json:"contentOmitted,omitempty"`
+}
+
+func NewCmdSample() *cobra.Command {
+	var (
+		project        *int64
+		count          int64
+		includeContent bool
+		maxContentSize int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sample",
+		Short: "Print a random sample of live objects, one JSON document per line",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if *project == -1 {
+				project = nil
+			}
+
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			samples, err := c.SampleObjects(ctx, project, count, includeContent, maxContentSize)
+			if err != nil {
+				return fmt.Errorf("could not sample objects: %w", err)
+			}
+
+			for _, sample := range samples {
+				encoded, err := json.Marshal(SampledObject{
+					Project:        sample.Project,
+					Path:           sample.Object.Path,
+					Mode:           sample.Object.Mode,
+					Size:           sample.Object.Size,
+					Content:        sample.Object.Content,
+					ContentOmitted: sample.Object.ContentOmitted,
+				})
+				if err != nil {
+					return fmt.Errorf("could not marshal sample: %w", err)
+				}
+
+				fmt.Println(string(encoded))
+			}
+
+			return nil
+		},
+	}
+
+	project = cmd.Flags().Int64("project", -1, "Project ID, every project is sampled when unset (optional)")
+	cmd.Flags().Int64Var(&count, "count", 100, "Number of objects to sample")
+	cmd.Flags().BoolVar(&includeContent, "include-content", false, "Include the content of the sampled objects")
+	cmd.Flags().Int64Var(&maxContentSize, "max-content-size", 0, "Leave out contents larger than this many bytes, 0 disables the limit")
+
+	return cmd
+}
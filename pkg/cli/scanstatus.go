@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdScanStatus() *cobra.Command {
+	var (
+		project int64
+		version *int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "scan-status",
+		Short: "Report the content scan status of a project version along with its infected objects",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if *version == -1 {
+				version = nil
+			}
+
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			response, err := c.ScanStatus(ctx, project, version)
+			if err != nil {
+				return fmt.Errorf("could not get scan status: %w", err)
+			}
+
+			fmt.Printf("version %d: %d clean, %d infected, %d pending, %d failed, %d unscanned\n",
+				response.Version, response.Clean, response.Infected, response.Pending, response.Failed, response.Unscanned)
+			for _, finding := range response.Findings {
+				fmt.Printf("%s\t%s\t%s\n", finding.Path, hex.EncodeToString(finding.Hash), finding.Signature)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	version = cmd.Flags().Int64("version", -1, "Version ID (optional)")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
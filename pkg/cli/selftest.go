@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdSelfTest() *cobra.Command {
+	var (
+		project int64
+		dir     string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "selftest",
+		Short: "Run the lifecycle of a scratch project against the server and time each step",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			if dir == "" {
+				tmpDir, err := os.MkdirTemp("", "dl-selftest-")
+				if err != nil {
+					return fmt.Errorf("could not create scratch dir: %w", err)
+				}
+				defer os.RemoveAll(tmpDir)
+				dir = tmpDir
+			}
+
+			result, err := c.SelfTest(ctx, project, dir)
+			if err != nil {
+				return fmt.Errorf("could not run self test: %w", err)
+			}
+
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+
+			if !result.Ok {
+				return fmt.Errorf("self test of project %v failed", project)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Scratch project ID, must not exist (required)")
+	cmd.Flags().StringVar(&dir, "dir", "", "Scratch directory for the files of the project, a temporary one by default")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
@@ -2,30 +2,35 @@ package cli
 
 import (
 	"context"
-	"crypto/ed25519"
-	"crypto/tls"
-	"crypto/x509"
-	"encoding/pem"
+	"errors"
 	"flag"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"runtime"
 	"runtime/pprof"
 	"syscall"
+	"time"
 
+	"github.com/gadget-inc/dateilager/internal/compression"
 	"github.com/gadget-inc/dateilager/internal/db"
 	"github.com/gadget-inc/dateilager/internal/environment"
 	"github.com/gadget-inc/dateilager/internal/key"
 	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/policy"
 	"github.com/gadget-inc/dateilager/internal/telemetry"
 	"github.com/gadget-inc/dateilager/pkg/api"
+	"github.com/gadget-inc/dateilager/pkg/archive"
+	"github.com/gadget-inc/dateilager/pkg/scan"
 	"github.com/gadget-inc/dateilager/pkg/server"
 	"github.com/gadget-inc/dateilager/pkg/version"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"golang.org/x/sync/errgroup"
 )
 
 func NewServerCommand() *cobra.Command {
@@ -38,13 +43,61 @@ func NewServerCommand() *cobra.Command {
 		level          *zapcore.Level
 		encoding       string
 		tracing        bool
+		samplingFile   string
 		profilePath    string
 		memProfilePath string
 		port           int
+		adminPort      int
+		debugPort      int
 		dbUri          string
 		certFile       string
 		keyFile        string
 		pasetoFile     string
+		reloadInterval time.Duration
+		detectTypes    bool
+		maxSendSize    int64
+		precompute     bool
+		compressors    []string
+		transport      = server.DefaultTransportConfig()
+
+		maxInFlightUpdates int64
+		deletionGuard      policy.DeletionGuard
+		maxDbLatency       time.Duration
+		updateRetryAfter   time.Duration
+
+		cacheSchedule        string
+		cachePrefixes        []string
+		cacheIncludeProjects []int64
+		cacheExcludeProjects []int64
+		cacheCount           int64
+		cacheKeep            int64
+
+		archiveSchedule  string
+		archiveStoreURL  string
+		archiveSelector  map[string]string
+		archiveRetention time.Duration
+		archiveStore     archive.Store
+
+		scanClamdAddress string
+		scanInterval     time.Duration
+		scanBatchSize    int64
+
+		contentEncodingName string
+		contentEncoding     db.ContentEncoding
+		inlineThreshold     int64
+		reencodeContents    bool
+		reencodeInterval    time.Duration
+		reencodeBatchSize   int64
+
+		reconcileRefs bool
+		refsInterval  time.Duration
+		refsBatchSize int64
+
+		configFile  string
+		checkConfig bool
+		autoMigrate bool
+
+		env environment.Env
 	)
 
 	cmd := &cobra.Command{
@@ -52,10 +105,27 @@ func NewServerCommand() *cobra.Command {
 		Short:             "DateiLager server",
 		DisableAutoGenTag: true,
 		Version:           version.Version,
-		RunE: func(cmd *cobra.Command, _ []string) error {
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
 			cmd.SilenceUsage = true // silence usage when an error occurs after flags have been parsed
 
-			env, err := environment.LoadEnvironment()
+			if configFile != "" {
+				err := loadServerConfig(cmd.Flags(), configFile)
+				if err != nil {
+					return err
+				}
+			}
+
+			if encoding != "console" && encoding != "json" {
+				return fmt.Errorf("invalid log-encoding %q, expected console or json", encoding)
+			}
+
+			// checking the configuration needs neither the environment nor logs
+			if checkConfig {
+				return nil
+			}
+
+			var err error
+			env, err = environment.LoadEnvironment()
 			if err != nil {
 				return fmt.Errorf("could not load environment: %w", err)
 			}
@@ -76,6 +146,130 @@ func NewServerCommand() *cobra.Command {
 				return fmt.Errorf("could not initialize logger: %w", err)
 			}
 
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if port <= 0 || port > 65535 {
+				return fmt.Errorf("invalid port %d", port)
+			}
+			if adminPort < 0 || adminPort > 65535 || adminPort == port {
+				return fmt.Errorf("invalid admin-port %d", adminPort)
+			}
+			if debugPort < 0 || debugPort > 65535 || (debugPort != 0 && (debugPort == port || debugPort == adminPort)) {
+				return fmt.Errorf("invalid debug-port %d", debugPort)
+			}
+			if maxSendSize < 0 {
+				return fmt.Errorf("max-content-send-size cannot be negative")
+			}
+			for _, name := range compressors {
+				if !compression.Supported(name) {
+					return fmt.Errorf("invalid response-compressors %q, expected zstd or gzip", name)
+				}
+			}
+			if maxInFlightUpdates < 0 || maxDbLatency < 0 || updateRetryAfter < 0 {
+				return fmt.Errorf("max-inflight-updates, max-db-latency and update-retry-after cannot be negative")
+			}
+			err := transport.Validate()
+			if err != nil {
+				return err
+			}
+			if deletionGuard.MaxRatio != 0 {
+				err = deletionGuard.Validate()
+				if err != nil {
+					return err
+				}
+			}
+
+			_, err = pgxpool.ParseConfig(dbUri)
+			if err != nil {
+				return fmt.Errorf("invalid dburi: %w", err)
+			}
+
+			creds, err := server.LoadCredentials(certFile, keyFile, pasetoFile)
+			if err != nil {
+				return err
+			}
+
+			var cacheScheduleConfig *server.CacheScheduleConfig
+			if cacheSchedule != "" {
+				schedule, err := server.ParseCronSchedule(cacheSchedule)
+				if err != nil {
+					return fmt.Errorf("invalid cache-schedule: %w", err)
+				}
+				if cacheCount <= 0 || cacheKeep <= 0 {
+					return fmt.Errorf("cache-count and cache-keep must be positive")
+				}
+
+				cacheScheduleConfig = &server.CacheScheduleConfig{
+					Schedule: schedule,
+					Sources: db.CacheSources{
+						Prefixes:        cachePrefixes,
+						IncludeProjects: cacheIncludeProjects,
+						ExcludeProjects: cacheExcludeProjects,
+					},
+					Count: cacheCount,
+					Keep:  cacheKeep,
+				}
+			}
+
+			if archiveStoreURL != "" {
+				archiveStore, err = archive.Open(archiveStoreURL)
+				if err != nil {
+					return fmt.Errorf("invalid archive-store: %w", err)
+				}
+			}
+
+			var archiveScheduleConfig *server.ArchiveScheduleConfig
+			if archiveSchedule != "" {
+				schedule, err := server.ParseCronSchedule(archiveSchedule)
+				if err != nil {
+					return fmt.Errorf("invalid archive-schedule: %w", err)
+				}
+				if archiveStore == nil {
+					return fmt.Errorf("archive-schedule requires an archive-store")
+				}
+				if archiveRetention < 0 {
+					return fmt.Errorf("archive-retention cannot be negative")
+				}
+
+				archiveScheduleConfig = &server.ArchiveScheduleConfig{
+					Schedule:  schedule,
+					Store:     archiveStore,
+					Selector:  archiveSelector,
+					Retention: archiveRetention,
+				}
+			}
+
+			if scanClamdAddress != "" && (scanInterval <= 0 || scanBatchSize <= 0) {
+				return fmt.Errorf("scan-interval and scan-batch-size must be positive")
+			}
+
+			contentEncoding, err = db.ParseContentEncoding(contentEncodingName)
+			if err != nil {
+				return fmt.Errorf("invalid content-encoding: %w", err)
+			}
+			if inlineThreshold < 0 {
+				return fmt.Errorf("inline-content-threshold cannot be negative")
+			}
+			if reencodeContents && (reencodeInterval < 0 || reencodeBatchSize <= 0) {
+				return fmt.Errorf("reencode-interval cannot be negative and reencode-batch-size must be positive")
+			}
+			if reconcileRefs && (refsInterval < 0 || refsBatchSize <= 0) {
+				return fmt.Errorf("content-refs-interval cannot be negative and content-refs-batch-size must be positive")
+			}
+
+			if samplingFile != "" {
+				_, err = server.ParseTraceSampling(samplingFile)
+				if err != nil {
+					return err
+				}
+			}
+
+			if checkConfig {
+				fmt.Fprintln(cmd.OutOrStdout(), "server configuration is valid")
+				return nil
+			}
+
 			ctx := cmd.Context()
 
 			if profilePath != "" {
@@ -91,46 +285,167 @@ func NewServerCommand() *cobra.Command {
 				shutdownTelemetry = telemetry.Init(ctx, telemetry.Server)
 			}
 
+			var sampling *server.TraceSampling
+			if samplingFile != "" {
+				sampling, err = server.LoadTraceSampling(samplingFile)
+				if err != nil {
+					return err
+				}
+			}
+
 			listen, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 			if err != nil {
 				return fmt.Errorf("failed to listen on TCP port %d: %w", port, err)
 			}
 
+			var adminListen net.Listener
+			if adminPort != 0 {
+				adminListen, err = net.Listen("tcp", fmt.Sprintf(":%d", adminPort))
+				if err != nil {
+					return fmt.Errorf("failed to listen on TCP admin port %d: %w", adminPort, err)
+				}
+			}
+
+			if autoMigrate {
+				err = runMigrations(ctx, dbUri, -1)
+				if err != nil {
+					return err
+				}
+			}
+
 			dbConn, err := server.NewDbPoolConnector(ctx, dbUri)
 			if err != nil {
 				return fmt.Errorf("cannot connect to DB %s: %w", dbUri, err)
 			}
 			defer dbConn.Close()
 
-			cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+			contentLookup, err := db.NewContentLookup()
 			if err != nil {
-				return fmt.Errorf("cannot open TLS cert and key files (%s, %s): %w", certFile, keyFile, err)
+				return fmt.Errorf("cannot setup content lookup: %w", err)
 			}
 
-			pasetoKey, err := parsePublicKey(pasetoFile)
+			directoryRollups, err := db.NewDirectoryRollups()
 			if err != nil {
-				return fmt.Errorf("cannot parse Paseto public key %s: %w", pasetoFile, err)
+				return fmt.Errorf("cannot setup directory rollups: %w", err)
 			}
 
-			contentLookup, err := db.NewContentLookup()
-			if err != nil {
-				return fmt.Errorf("cannot setup content lookup: %w", err)
+			if debugPort != 0 {
+				debugListen, err := net.Listen("tcp", fmt.Sprintf(":%d", debugPort))
+				if err != nil {
+					return fmt.Errorf("failed to listen on TCP debug port %d: %w", debugPort, err)
+				}
+
+				debugServer := server.NewDebugServer()
+				defer debugServer.Close()
+
+				go func() {
+					logger.Info(ctx, "start debug server", key.Port.Field(debugPort))
+					err := debugServer.Serve(debugListen)
+					if err != nil && !errors.Is(err, http.ErrServerClosed) {
+						logger.Error(ctx, "debug server failed", zap.Error(err))
+					}
+				}()
 			}
 
-			s := server.NewServer(ctx, dbConn, &cert, pasetoKey)
+			s := server.NewServerWithCredentials(ctx, dbConn, creds, transport, adminListen != nil)
 			logger.Info(ctx, "register Fs")
 			fs := &api.Fs{
-				Env:           env,
-				DbConn:        dbConn,
-				ContentLookup: contentLookup,
+				Env:                    env,
+				DbConn:                 dbConn,
+				ContentLookup:          contentLookup,
+				DetectContentType:      detectTypes,
+				MaxContentSendSize:     maxSendSize,
+				PrecomputeCheckouts:    precompute,
+				QueueContentScans:      scanClamdAddress != "",
+				ResponseCompressors:    compressors,
+				ContentEncoding:        contentEncoding,
+				DirectoryRollups:       directoryRollups,
+				ArchiveStore:           archiveStore,
+				InlineContentThreshold: inlineThreshold,
+			}
+			if deletionGuard.MaxRatio != 0 {
+				fs.DeletionGuard = &deletionGuard
+			}
+			if maxInFlightUpdates > 0 || maxDbLatency > 0 {
+				fs.UpdateAdmission = &api.AdmissionController{
+					MaxInFlight:  maxInFlightUpdates,
+					MaxDbLatency: maxDbLatency,
+					RetryAfter:   updateRetryAfter,
+				}
+				fs.UpdateAdmission.MonitorDb(ctx, dbConn, time.Second)
 			}
 			s.RegisterFs(fs)
 
+			if cacheScheduleConfig != nil {
+				logger.Info(ctx, "schedule cache creation", zap.String("schedule", cacheSchedule))
+				s.ScheduleCacheCreation(ctx, dbConn, *cacheScheduleConfig)
+			}
+
+			if archiveScheduleConfig != nil {
+				logger.Info(ctx, "schedule project archives", zap.String("schedule", archiveSchedule), key.Location.Field(archiveStoreURL))
+				s.ScheduleArchives(ctx, dbConn, contentLookup, *archiveScheduleConfig)
+			}
+
+			if scanClamdAddress != "" {
+				logger.Info(ctx, "scan contents with clamd", zap.String("address", scanClamdAddress))
+				s.ScanContents(ctx, dbConn, contentLookup, server.ContentScanConfig{
+					Scanner:   scan.NewClamd(scanClamdAddress),
+					Interval:  scanInterval,
+					BatchSize: scanBatchSize,
+				})
+			}
+
+			if reencodeContents {
+				logger.Info(ctx, "reencode contents", key.Encoding.Field(contentEncoding.String()))
+				s.ReencodeContents(ctx, dbConn, server.ContentReencodeConfig{
+					Encoding:  contentEncoding,
+					Interval:  reencodeInterval,
+					BatchSize: reencodeBatchSize,
+				})
+			}
+
+			if reconcileRefs {
+				logger.Info(ctx, "reconcile content refs", zap.Duration("interval", refsInterval))
+				s.ReconcileContentRefs(ctx, dbConn, server.ContentRefsConfig{
+					Interval:  refsInterval,
+					BatchSize: refsBatchSize,
+				})
+			}
+
+			if reloadInterval > 0 {
+				creds.WatchFiles(ctx, reloadInterval)
+				if sampling != nil {
+					sampling.WatchFile(ctx, reloadInterval)
+				}
+			}
+
+			reloadSignals := make(chan os.Signal, 1)
+			signal.Notify(reloadSignals, syscall.SIGHUP)
+			go func() {
+				for range reloadSignals {
+					err := creds.Reload()
+					if err != nil {
+						logger.Error(ctx, "SIGHUP received, could not reload credentials", zap.Error(err))
+					} else {
+						logger.Info(ctx, "SIGHUP received, reloaded credentials")
+					}
+
+					if sampling != nil {
+						err = sampling.Reload()
+						if err != nil {
+							logger.Error(ctx, "SIGHUP received, could not reload trace sampling", zap.Error(err))
+						} else {
+							logger.Info(ctx, "SIGHUP received, reloaded trace sampling")
+						}
+					}
+				}
+			}()
+
 			osSignals := make(chan os.Signal, 1)
 			signal.Notify(osSignals, os.Interrupt, syscall.SIGTERM)
 			go func() {
 				<-osSignals
-				s.Grpc.GracefulStop()
+				s.GracefulStop()
 			}()
 
 			if memProfilePath != "" {
@@ -160,8 +475,22 @@ func NewServerCommand() *cobra.Command {
 				}()
 			}
 
-			logger.Info(ctx, "start fs server", key.Port.Field(port), key.Environment.Field(env.String()))
-			return s.Serve(listen)
+			// when either listener fails the other one is stopped too so the process exits
+			var group errgroup.Group
+			group.Go(func() error {
+				defer s.GracefulStop()
+				logger.Info(ctx, "start fs server", key.Port.Field(port), key.Environment.Field(env.String()))
+				return s.Serve(listen)
+			})
+			if adminListen != nil {
+				group.Go(func() error {
+					defer s.GracefulStop()
+					logger.Info(ctx, "start fs admin server", key.Port.Field(adminPort))
+					return s.ServeAdmin(adminListen)
+				})
+			}
+
+			return group.Wait()
 		},
 		PostRunE: func(cmd *cobra.Command, _ []string) error {
 			if shutdownTelemetry != nil {
@@ -178,18 +507,64 @@ func NewServerCommand() *cobra.Command {
 
 	flags := cmd.PersistentFlags()
 
+	flags.StringVar(&configFile, "config", "", "YAML file setting any of the other flags by name, flags passed explicitly take precedence")
+	flags.BoolVar(&autoMigrate, "auto-migrate", false, "Apply the schema migrations embedded in the binary before serving")
+	flags.BoolVar(&checkConfig, "check-config", false, "Validate the configuration, TLS files and Paseto key then exit without starting the server")
+
 	level = zap.LevelFlag("log-level", zap.DebugLevel, "Log level")
 	flags.AddGoFlag(flag.CommandLine.Lookup("log-level"))
 	flags.StringVar(&encoding, "log-encoding", "console", "Log encoding (console | json)")
 	flags.BoolVar(&tracing, "tracing", false, "Whether tracing is enabled")
+	flags.StringVar(&samplingFile, "trace-sampling", "", "YAML file setting the share of traces sampled by default and per project ID, reloaded like the credentials (every trace is sampled if empty)")
 	flags.StringVar(&profilePath, "profile", "", "CPU profile output path (CPU profiling enabled if set)")
 	flags.StringVar(&memProfilePath, "memprofile", "mem.pb.gz", "Memory profile output path")
 
 	flags.IntVar(&port, "port", 5051, "GRPC server port")
+	flags.IntVar(&debugPort, "debug-port", 0, "Serve pprof, runtime metrics and goroutine dumps over plain HTTP on this port, disabled if 0")
+	flags.IntVar(&adminPort, "admin-port", 0, "Serve admin RPCs (GC, reset, snapshots, cache management...) only on this port, all RPCs are served on --port if 0")
 	flags.StringVar(&dbUri, "dburi", "postgres://postgres@127.0.0.1:5432/dl", "Postgres URI")
 	flags.StringVar(&certFile, "cert", "development/server.crt", "TLS cert file")
 	flags.StringVar(&keyFile, "key", "development/server.key", "TLS key file")
-	flags.StringVar(&pasetoFile, "paseto", "development/paseto.pub", "Paseto public key file")
+	flags.StringVar(&pasetoFile, "paseto", "development/paseto.pub", "Paseto public key file, may list several keys while rotating")
+	flags.DurationVar(&reloadInterval, "credentials-reload-interval", 30*time.Second, "How often to check the TLS and Paseto files for changes to reload (0 to only reload on SIGHUP)")
+	flags.BoolVar(&detectTypes, "detect-content-type", false, "Detect and store the MIME type of updated objects")
+	flags.Int64Var(&maxSendSize, "max-content-send-size", 0, "Hard cap in bytes on object content sent by read RPCs (0 for no limit)")
+	flags.Int64Var(&maxInFlightUpdates, "max-inflight-updates", 0, "Reject new Update streams with RESOURCE_EXHAUSTED while this many are running (0 for no limit)")
+	flags.DurationVar(&maxDbLatency, "max-db-latency", 0, "Reject new Update streams with RESOURCE_EXHAUSTED while the smoothed database latency is above this (0 for no limit)")
+	flags.DurationVar(&updateRetryAfter, "update-retry-after", 5*time.Second, "Delay rejected Update clients are told to wait before retrying")
+	flags.StringSliceVar(&compressors, "response-compressors", []string{compression.Zstd, compression.Gzip}, "gRPC compressors GetUnary, ListProjects and History responses may use by preference, when the client supports them (empty to disable)")
+	flags.IntVar(&transport.MaxMessageSize, "max-message-size", transport.MaxMessageSize, "Largest gRPC message in bytes the server accepts or sends")
+	flags.Int32Var(&transport.InitialWindowSize, "initial-window-size", transport.InitialWindowSize, "HTTP/2 flow control window in bytes of each stream, at least 64KB")
+	flags.Int32Var(&transport.InitialConnWindowSize, "initial-conn-window-size", transport.InitialConnWindowSize, "HTTP/2 flow control window in bytes of each connection, at least the initial-window-size")
+	flags.Float64Var(&deletionGuard.MaxRatio, "deletion-guard-ratio", 0, "Reject updates removing more than this share of a project's objects unless they are forced, e.g. 0.5 (disabled if 0)")
+	flags.Int64Var(&deletionGuard.MinObjects, "deletion-guard-min-objects", 100, "Number of objects below which projects are not checked by the deletion guard")
+	flags.BoolVar(&precompute, "precompute-checkouts", false, "Precompute the full checkout of every committed version to serve identical GetCompress requests faster")
+
+	flags.StringVar(&cacheSchedule, "cache-schedule", "", "Cron spec on which to create a new cache version (disabled if empty)")
+	flags.StringSliceVar(&cachePrefixes, "cache-prefix", nil, "Only include packs under these path prefixes in scheduled caches (repeatable)")
+	flags.Int64SliceVar(&cacheIncludeProjects, "cache-include-projects", nil, "Comma separated list of the only project IDs scheduled caches take packs from")
+	flags.Int64SliceVar(&cacheExcludeProjects, "cache-exclude-projects", nil, "Comma separated list of project IDs scheduled caches never take packs from")
+	flags.Int64Var(&cacheCount, "cache-count", 100, "Number of packs to include in scheduled caches")
+	flags.Int64Var(&cacheKeep, "cache-keep", 3, "Number of cache versions kept when a scheduled cache is created")
+
+	flags.StringVar(&archiveSchedule, "archive-schedule", "", "Cron spec on which to export a snapshot of the selected projects to the archive-store (disabled if empty)")
+	flags.StringVar(&archiveStoreURL, "archive-store", "", "Directory, s3://bucket/prefix or gs://bucket/prefix URL holding project archives, buckets use the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY credentials")
+	flags.StringToStringVar(&archiveSelector, "archive-selector", nil, "Only archive projects having every one of these labels, e.g. tier=prod (defaults to all projects)")
+	flags.DurationVar(&archiveRetention, "archive-retention", 0, "How long archives are kept before the archiver deletes them, e.g. 720h (kept forever if 0)")
+
+	flags.StringVar(&scanClamdAddress, "scan-clamd-address", "", "Scan the content of committed versions with the clamd daemon at this host:port or unix socket path (disabled if empty)")
+	flags.DurationVar(&scanInterval, "scan-interval", 10*time.Second, "How often to poll the content scan queue once it is empty")
+	flags.Int64Var(&scanBatchSize, "scan-batch-size", 20, "Number of contents scanned per transaction")
+	flags.StringVar(&contentEncodingName, "content-encoding", "s2", "Encoding new contents are stored in (s2 | zstd), only switch once every server reads it")
+	flags.Int64Var(&inlineThreshold, "inline-content-threshold", 0, "Size in bytes up to which new contents are stored in their object row (0 disables inlining), only set once every server reads them")
+	flags.BoolVar(&reencodeContents, "reencode-contents", false, "Re-encode the stored contents to the content-encoding in the background, resuming where the last run stopped")
+	flags.DurationVar(&reencodeInterval, "reencode-interval", time.Second, "How long to wait between re-encode batches")
+	flags.Int64Var(&reencodeBatchSize, "reencode-batch-size", 100, "Number of contents walked per re-encode transaction")
+	flags.BoolVar(&reconcileRefs, "reconcile-content-refs", false, "Fold the content references recorded by updates into the contents in the background, keeping garbage collection cheap")
+	flags.DurationVar(&refsInterval, "content-refs-interval", 5*time.Second, "How long to wait between content reference batches")
+	flags.Int64Var(&refsBatchSize, "content-refs-batch-size", 1000, "Number of reference deltas folded and contents counted per transaction")
+
+	cmd.AddCommand(newCmdMigrate(&dbUri))
 
 	return cmd
 }
@@ -207,22 +582,3 @@ func ServerExecute() {
 		logger.Fatal(ctx, "server failed", zap.Error(err))
 	}
 }
-
-func parsePublicKey(path string) (ed25519.PublicKey, error) {
-	pubKeyBytes, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("cannot open Paseto public key file: %w", err)
-	}
-
-	block, _ := pem.Decode(pubKeyBytes)
-	if block == nil || block.Type != "PUBLIC KEY" {
-		return nil, fmt.Errorf("error decoding Paseto public key PEM")
-	}
-
-	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
-	if err != nil {
-		return nil, fmt.Errorf("error parsing Paseto public key: %w", err)
-	}
-
-	return pub.(ed25519.PublicKey), nil
-}
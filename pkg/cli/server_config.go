@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// loadServerConfig sets flags from the YAML file at path, the file uses flag names as keys:
+//
+//	port: 5051
+//	dburi: postgres://postgres@127.0.0.1:5432/dl
+//	cache-schedule: "0 */6 * * *"
+//	cache-prefix: [/node_modules/]
+//
+// Flags passed on the command line take precedence over the file.
+func loadServerConfig(flags *pflag.FlagSet, path string) error {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read config file %s: %w", path, err)
+	}
+
+	values := make(map[string]yaml.Node)
+	decoder := yaml.NewDecoder(bytes.NewReader(contents))
+	err = decoder.Decode(&values)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("cannot parse config file %s: %w", path, err)
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if name == "config" || name == "check-config" {
+			return fmt.Errorf("config file %s: %q cannot be set from a config file", path, name)
+		}
+
+		flag := flags.Lookup(name)
+		if flag == nil {
+			return fmt.Errorf("config file %s: unknown setting %q", path, name)
+		}
+		if flag.Changed {
+			continue
+		}
+
+		node := values[name]
+		err = setFlagFromNode(flag, &node)
+		if err != nil {
+			return fmt.Errorf("config file %s: invalid %q: %w", path, name, err)
+		}
+	}
+
+	return nil
+}
+
+func setFlagFromNode(flag *pflag.Flag, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return flag.Value.Set(node.Value)
+
+	case yaml.SequenceNode:
+		slice, ok := flag.Value.(pflag.SliceValue)
+		if !ok {
+			return fmt.Errorf("expected a single %s value, got a list", flag.Value.Type())
+		}
+
+		items := make([]string, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("list items must be plain values")
+			}
+			items = append(items, item.Value)
+		}
+		return slice.Replace(items)
+
+	default:
+		return fmt.Errorf("expected a %s value", flag.Value.Type())
+	}
+}
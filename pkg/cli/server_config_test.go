@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, contents string) string {
+	path := filepath.Join(t.TempDir(), "server.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+	return path
+}
+
+func newConfigFlags() (*pflag.FlagSet, *int, *string, *[]string, *[]int64) {
+	flags := pflag.NewFlagSet("server", pflag.ContinueOnError)
+	port := flags.Int("port", 5051, "")
+	dbUri := flags.String("dburi", "postgres://default", "")
+	prefixes := flags.StringSlice("cache-prefix", nil, "")
+	projects := flags.Int64Slice("cache-include-projects", nil, "")
+	return flags, port, dbUri, prefixes, projects
+}
+
+func TestLoadServerConfig(t *testing.T) {
+	flags, port, dbUri, prefixes, projects := newConfigFlags()
+	require.NoError(t, flags.Parse([]string{"--port", "6000"}))
+
+	path := writeConfig(t, `
+port: 5052
+dburi: postgres://file
+cache-prefix: [/a/, /b/]
+cache-include-projects: [1, 2]
+`)
+	require.NoError(t, loadServerConfig(flags, path))
+
+	assert.Equal(t, 6000, *port, "command line flags take precedence")
+	assert.Equal(t, "postgres://file", *dbUri)
+	assert.Equal(t, []string{"/a/", "/b/"}, *prefixes)
+	assert.Equal(t, []int64{1, 2}, *projects)
+}
+
+func TestLoadServerConfigEmpty(t *testing.T) {
+	flags, port, _, _, _ := newConfigFlags()
+	require.NoError(t, loadServerConfig(flags, writeConfig(t, "")))
+	assert.Equal(t, 5051, *port)
+}
+
+func TestLoadServerConfigInvalid(t *testing.T) {
+	for name, contents := range map[string]string{
+		"unknown setting": "prot: 5052",
+		"invalid value":   "port: fifty",
+		"list for scalar": "port: [1, 2]",
+		"nested list":     "cache-prefix: [[/a/]]",
+		"recursive":       "config: other.yaml",
+	} {
+		t.Run(name, func(t *testing.T) {
+			flags, _, _, _, _ := newConfigFlags()
+			flags.String("config", "", "")
+			require.Error(t, loadServerConfig(flags, writeConfig(t, contents)))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdSquash() *cobra.Command {
+	var (
+		project int64
+		version int64
+		keep    int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "squash",
+		Short: "Collapse the history of a project below a version into a single baseline version",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			var versionPtr, keepPtr *int64
+			if cmd.Flags().Changed("version") {
+				versionPtr = &version
+			}
+			if cmd.Flags().Changed("keep") {
+				keepPtr = &keep
+			}
+			if (versionPtr == nil) == (keepPtr == nil) {
+				return fmt.Errorf("exactly one of --version and --keep is required")
+			}
+
+			result, err := c.SquashHistory(ctx, project, versionPtr, keepPtr)
+			if err != nil {
+				return fmt.Errorf("could not squash history of project %v: %w", project, err)
+			}
+
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().Int64Var(&version, "version", 0, "Squash every version below this one into it")
+	cmd.Flags().Int64Var(&keep, "keep", 0, "Squash every version but the latest ones")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdStage() *cobra.Command {
+	var (
+		project int64
+		stage   string
+		path    string
+		file    string
+		deleted bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stage",
+		Short: "Add a file, or the deletion of a path, to a stage committed later as a single version",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			object := &pb.Object{Path: path, Deleted: deleted}
+			if !deleted {
+				if file == "" {
+					return fmt.Errorf("--file is required unless the path is deleted")
+				}
+
+				info, err := os.Stat(file)
+				if err != nil {
+					return fmt.Errorf("could not stat %v: %w", file, err)
+				}
+
+				content, err := os.ReadFile(file)
+				if err != nil {
+					return fmt.Errorf("could not read %v: %w", file, err)
+				}
+
+				object.Mode = int64(info.Mode())
+				object.Size = int64(len(content))
+				object.Content = content
+			}
+
+			_, err := c.Stage(ctx, project, stage, []*pb.Object{object})
+			if err != nil {
+				return fmt.Errorf("could not stage object: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&stage, "stage", "", "Name of the stage (required)")
+	cmd.Flags().StringVar(&path, "path", "", "Path of the object in the project (required)")
+	cmd.Flags().StringVar(&file, "file", "", "Local file holding the content")
+	cmd.Flags().BoolVar(&deleted, "delete", false, "Stage the deletion of the path")
+
+	_ = cmd.MarkFlagRequired("project")
+	_ = cmd.MarkFlagRequired("stage")
+	_ = cmd.MarkFlagRequired("path")
+
+	return cmd
+}
+
+func NewCmdStaged() *cobra.Command {
+	var (
+		project int64
+		stage   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "staged",
+		Short: "List the paths of a stage, deletions are marked with a minus",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			objects, err := c.ListStaged(ctx, project, stage)
+			if err != nil {
+				return fmt.Errorf("could not list staged objects: %w", err)
+			}
+
+			for _, object := range objects {
+				if object.Exists {
+					fmt.Printf("+\t%s\t%d\n", object.Path, object.Size)
+				} else {
+					fmt.Printf("-\t%s\n", object.Path)
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&stage, "stage", "", "Name of the stage (required)")
+
+	_ = cmd.MarkFlagRequired("project")
+	_ = cmd.MarkFlagRequired("stage")
+
+	return cmd
+}
+
+func NewCmdCommitStage() *cobra.Command {
+	var (
+		project int64
+		stage   string
+		message string
+		author  string
+		force   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "commit-stage",
+		Short: "Write every object of a stage in a single new version and drop the stage",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			version, count, err := c.CommitStage(ctx, project, stage, client.WriteOptions{
+				Message: optionalString(message),
+				Author:  optionalString(author),
+				Force:   force,
+			})
+			if err != nil {
+				return fmt.Errorf("could not commit stage: %w", err)
+			}
+
+			fmt.Printf("%d\t%d\n", version, count)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&stage, "stage", "", "Name of the stage (required)")
+	cmd.Flags().StringVar(&message, "message", "", "Message describing the new version (optional)")
+	cmd.Flags().StringVar(&author, "author", "", "Author of the new version (optional)")
+	cmd.Flags().BoolVar(&force, "force", false, "Commit even if the stage deletes most of the project")
+
+	_ = cmd.MarkFlagRequired("project")
+	_ = cmd.MarkFlagRequired("stage")
+
+	return cmd
+}
+
+func NewCmdDiscardStage() *cobra.Command {
+	var (
+		project int64
+		stage   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "discard-stage",
+		Short: "Drop every object of a stage without writing them",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			discarded, err := c.DiscardStage(ctx, project, stage)
+			if err != nil {
+				return fmt.Errorf("could not discard stage: %w", err)
+			}
+
+			fmt.Println(discarded)
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&stage, "stage", "", "Name of the stage (required)")
+
+	_ = cmd.MarkFlagRequired("project")
+	_ = cmd.MarkFlagRequired("stage")
+
+	return cmd
+}
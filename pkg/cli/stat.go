@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdStat() *cobra.Command {
+	var (
+		project   int64
+		version   *int64
+		paths     string
+		pathsFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "stat",
+		Short: "Show whether paths exist along with their size, mode and hash",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if *version == -1 {
+				version = nil
+			}
+
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			pathList, err := readPathList(paths, pathsFile)
+			if err != nil {
+				return err
+			}
+			if len(pathList) == 0 {
+				return fmt.Errorf("at least one path is required, use --paths or --paths-file")
+			}
+
+			response, err := c.StatPaths(ctx, project, version, pathList)
+			if err != nil {
+				return fmt.Errorf("could not stat paths: %w", err)
+			}
+
+			for _, stat := range response.Stats {
+				if !stat.Exists {
+					fmt.Printf("%s\tmissing\n", stat.Path)
+					continue
+				}
+				fmt.Printf("%s\t%v\t%d\t%x\n", stat.Path, fs.FileMode(stat.Mode), stat.Size, stat.Hash)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&paths, "paths", "", "Comma separated list of exact paths to stat")
+	cmd.Flags().StringVar(&pathsFile, "paths-file", "", "File containing exact paths to stat, one per line")
+	version = cmd.Flags().Int64("version", -1, "Version ID (optional)")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdTombstones() *cobra.Command {
+	var (
+		project int64
+		version *int64
+		prefix  string
+		limit   *int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "tombstones",
+		Short: "List the deleted paths of a project along with the version they were deleted in",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if *version == -1 {
+				version = nil
+			}
+			if *limit == -1 {
+				limit = nil
+			}
+
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			response, err := c.ListTombstones(ctx, project, version, prefix, limit)
+			if err != nil {
+				return fmt.Errorf("could not list tombstones: %w", err)
+			}
+
+			for _, tombstone := range response.Tombstones {
+				fmt.Printf("%s\t%v\t%d\tdeleted in %d\n", tombstone.Path, fs.FileMode(tombstone.Mode), tombstone.Size, tombstone.DeletedVersion)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Only list deleted paths under this prefix")
+	version = cmd.Flags().Int64("version", -1, "Version ID (optional)")
+	limit = cmd.Flags().Int64("limit", -1, "Maximum number of deleted paths to list (optional)")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
+
+type PurgeDeletedResult struct {
+	PurgedObjects int64 `json:"purgedObjects"`
+	Count         int64 `json:"count"`
+}
+
+func NewCmdPurgeDeleted() *cobra.Command {
+	var (
+		project int64
+		prefix  string
+		before  int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "purge-deleted",
+		Short: "Remove the tombstones of paths under a prefix deleted before a version",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			response, err := c.PurgeDeleted(ctx, project, prefix, before)
+			if err != nil {
+				return fmt.Errorf("could not purge deleted paths: %w", err)
+			}
+
+			encoded, err := json.Marshal(PurgeDeletedResult{PurgedObjects: response.PurgedObjects, Count: response.Count})
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&prefix, "prefix", "", "Prefix of the deleted paths to purge (required)")
+	cmd.Flags().Int64Var(&before, "before", -1, "Purge paths deleted before this version (required)")
+
+	_ = cmd.MarkFlagRequired("project")
+	_ = cmd.MarkFlagRequired("prefix")
+	_ = cmd.MarkFlagRequired("before")
+
+	return cmd
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewCmdSetTransforms() *cobra.Command {
+	var (
+		project    int64
+		transforms string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set-transforms",
+		Short: "Replace the checkout transforms of an existing project",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			var transformList []string
+			if transforms != "" {
+				transformList = strings.Split(transforms, ",")
+			}
+
+			err := c.SetProjectTransforms(ctx, project, transformList)
+			if err != nil {
+				return fmt.Errorf("could not set transforms of project %v: %w", project, err)
+			}
+
+			logger.Info(ctx, "project transforms set", key.Project.Field(project), key.Transforms.Field(transformList))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().StringVar(&transforms, "transforms", "", "Comma separated checkout transforms, e.g. strip-sourcemaps:**.js or lfs-pointer:assets/**, empty to remove them")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
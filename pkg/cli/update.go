@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gadget-inc/dateilager/internal/key"
 	"github.com/gadget-inc/dateilager/internal/logger"
@@ -11,18 +12,52 @@ import (
 
 func NewCmdUpdate() *cobra.Command {
 	var (
-		project int64
-		dir     string
+		project          int64
+		dir              string
+		message          *string
+		author           *string
+		idempotencyKey   *string
+		progressInterval time.Duration
+		include          []string
+		exclude          []string
+		batchSize        int
+		retries          int
+		force            bool
 	)
 
 	cmd := &cobra.Command{
 		Use: "update",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("message") {
+				message = nil
+			}
+			if !cmd.Flags().Changed("author") {
+				author = nil
+			}
+			if !cmd.Flags().Changed("idempotency-key") {
+				idempotencyKey = nil
+			}
+			options := client.WriteOptions{
+				Message:        message,
+				Author:         author,
+				IdempotencyKey: idempotencyKey,
+				Include:        include,
+				Exclude:        exclude,
+				BatchSize:      batchSize,
+				Retries:        retries,
+				Force:          force,
+			}
+
 			ctx := cmd.Context()
 
-			client := client.FromContext(ctx)
+			c := client.FromContext(ctx)
 
-			version, count, err := client.Update(ctx, project, dir)
+			ctx, stopProgress := logProgress(ctx, "updating", progressInterval)
+			version, count, err := c.UpdateWithOptions(ctx, project, dir, options)
+			progress := stopProgress()
+			for _, violation := range client.PolicyViolations(err) {
+				fmt.Fprintf(cmd.ErrOrStderr(), "%s\t%s\t%s\n", violation.Subject, violation.Type, violation.Description)
+			}
 			if err != nil {
 				return fmt.Errorf("update objects: %w", err)
 			}
@@ -31,6 +66,7 @@ func NewCmdUpdate() *cobra.Command {
 				key.Project.Field(project),
 				key.Version.Field(version),
 				key.DiffCount.Field(count),
+				key.Bytes.Field(progress.Bytes),
 			)
 			fmt.Println(version)
 
@@ -40,6 +76,15 @@ func NewCmdUpdate() *cobra.Command {
 
 	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
 	cmd.Flags().StringVar(&dir, "dir", "", "Directory containing updated files")
+	message = cmd.Flags().String("message", "", "Message describing the new version (optional)")
+	author = cmd.Flags().String("author", "", "Author of the new version (optional)")
+	idempotencyKey = cmd.Flags().String("idempotency-key", "", "Retrying with the same key returns the version created by the first attempt (optional)")
+	cmd.Flags().StringSliceVar(&include, "include", nil, "Only send changed paths matching these globs, other changes are left for a later update (repeatable)")
+	cmd.Flags().StringSliceVar(&exclude, "exclude", nil, "Do not send changed paths matching these globs, they are left for a later update (repeatable)")
+	cmd.Flags().IntVar(&batchSize, "batch-size", 0, "Commit the changes in versions of at most this many paths, a failure only resends its batch (0 for a single version)")
+	cmd.Flags().IntVar(&retries, "retries", 0, "How many times to resend a failed update stream")
+	cmd.Flags().BoolVar(&force, "force", false, "Apply the update even if the server flags it as removing too many files")
+	cmd.Flags().DurationVar(&progressInterval, "progress-interval", 5*time.Second, "Interval between progress log lines (0 to disable)")
 
 	_ = cmd.MarkFlagRequired("project")
 
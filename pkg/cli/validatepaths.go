@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+type InvalidPathResult struct {
+	Path        string `json:"path"`
+	Reason      string `json:"reason"`
+	Description string `json:"description"`
+	Live        bool   `json:"live"`
+}
+
+type ValidatePathsResult struct {
+	Checked int64               `json:"checked"`
+	Invalid []InvalidPathResult `json:"invalid"`
+}
+
+func NewCmdValidatePaths() *cobra.Command {
+	var (
+		project int64
+		limit   int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "validate-paths",
+		Short: "Report the paths stored in a project that are no longer accepted by updates",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			c := client.FromContext(ctx)
+
+			var limitPtr *int64
+			if limit > 0 {
+				limitPtr = &limit
+			}
+
+			response, err := c.ValidatePaths(ctx, project, limitPtr)
+			if err != nil {
+				return fmt.Errorf("could not validate paths of project %v: %w", project, err)
+			}
+
+			result := ValidatePathsResult{Checked: response.Checked, Invalid: []InvalidPathResult{}}
+			for _, invalid := range response.Invalid {
+				result.Invalid = append(result.Invalid, InvalidPathResult{
+					// quoted so paths with invalid UTF-8 or NUL bytes survive the JSON encoding
+					Path:        strconv.Quote(string(invalid.Path)),
+					Reason:      invalid.Reason,
+					Description: invalid.Description,
+					Live:        invalid.Live,
+				})
+			}
+
+			encoded, err := json.Marshal(result)
+			if err != nil {
+				return fmt.Errorf("could not marshal result: %w", err)
+			}
+
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64Var(&project, "project", -1, "Project ID (required)")
+	cmd.Flags().Int64Var(&limit, "limit", 0, "Maximum number of invalid paths to report (0 for the server default)")
+
+	_ = cmd.MarkFlagRequired("project")
+
+	return cmd
+}
@@ -1,22 +1,30 @@
 package client
 
 import (
+	"archive/tar"
 	"context"
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"net"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	_ "github.com/gadget-inc/dateilager/internal/compression"
 	"github.com/gadget-inc/dateilager/internal/db"
 	"github.com/gadget-inc/dateilager/internal/files"
 	"github.com/gadget-inc/dateilager/internal/key"
@@ -27,12 +35,14 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/oauth2"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/credentials/oauth"
-	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -44,6 +54,73 @@ const (
 	MAX_MESSAGE_SIZE         = 300 * MB
 )
 
+// ErrChecksumMismatch is returned when received bytes do not match the hash the server sent along with them.
+// Nothing is written from such a response and the operation can safely be retried.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// RetryAfter returns how long the server asked to wait before retrying a call it shed under load, false when err is not such a rejection.
+func RetryAfter(err error) (time.Duration, bool) {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.ResourceExhausted {
+		return 0, false
+	}
+
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.RetryDelay.AsDuration(), true
+		}
+	}
+
+	return 0, false
+}
+
+// PolicyViolations returns the objects an update was rejected for because they break the project's policy, nil for any other error.
+func PolicyViolations(err error) []*errdetails.PreconditionFailure_Violation {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.FailedPrecondition {
+		return nil
+	}
+
+	for _, detail := range st.Details() {
+		if failure, ok := detail.(*errdetails.PreconditionFailure); ok {
+			return failure.Violations
+		}
+	}
+
+	return nil
+}
+
+// InvalidPaths returns the paths an update was rejected for because the server cannot store them, nil for any other error.
+// Each description starts with the reason, one of the pb reason constants such as pb.TraversalSegment.
+func InvalidPaths(err error) []*errdetails.BadRequest_FieldViolation {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		return nil
+	}
+
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			return badRequest.FieldViolations
+		}
+	}
+
+	return nil
+}
+
+func verifyChecksum(bytes []byte, hash []byte) error {
+	// Older servers do not send hashes
+	if len(hash) == 0 {
+		return nil
+	}
+
+	actual := db.HashContent(bytes)
+	if !slices.Equal(actual.Bytes(), hash) {
+		return fmt.Errorf("%w: expected %x, received %v", ErrChecksumMismatch, hash, actual.Hex())
+	}
+
+	return nil
+}
+
 type VersionRange struct {
 	From *int64
 	To   *int64
@@ -52,6 +129,9 @@ type VersionRange struct {
 type Client struct {
 	conn *grpc.ClientConn
 	fs   pb.FsClient
+
+	maxContentSendSize *int64
+	retry              *RetryPolicy
 }
 
 type CachedClient struct {
@@ -61,8 +141,13 @@ type CachedClient struct {
 	node     csi.NodeClient
 }
 
-func NewClientConn(conn *grpc.ClientConn) *Client {
-	return &Client{conn: conn, fs: pb.NewFsClient(conn)}
+func NewClientConn(conn *grpc.ClientConn, opts ...func(*options)) *Client {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &Client{conn: conn, fs: pb.NewFsClient(conn), maxContentSendSize: o.maxContentSendSize, retry: o.retryPolicy}
 }
 
 func NewCachedClientConn(conn *grpc.ClientConn) *CachedClient {
@@ -75,8 +160,19 @@ func NewCachedClientConn(conn *grpc.ClientConn) *CachedClient {
 }
 
 type options struct {
-	headlessHost string
-	token        string
+	headlessHost       string
+	token              string
+	maxContentSendSize *int64
+	uploadLimiter      *RateLimiter
+	downloadLimiter    *RateLimiter
+	dialTimeout        time.Duration
+	rpcTimeout         time.Duration
+	keepaliveTime      time.Duration
+	keepaliveTimeout   time.Duration
+	retryPolicy        *RetryPolicy
+	maxMessageSize     int
+	windowSize         int32
+	connWindowSize     int32
 }
 
 func WithToken(token string) func(*options) {
@@ -91,6 +187,33 @@ func WithheadlessHost(host string) func(*options) {
 	}
 }
 
+// WithMaxContentSendSize asks the server to leave out the content of objects larger than size on every read, 0 means no limit.
+func WithMaxContentSendSize(size int64) func(*options) {
+	return func(o *options) {
+		if size > 0 {
+			o.maxContentSendSize = &size
+		}
+	}
+}
+
+// WithMaxUploadRate limits the bytes per second sent by all calls of the client, 0 means no limit.
+// The limit is shared by concurrent calls and can be overridden per call with WithRateLimits.
+func WithMaxUploadRate(bytesPerSecond int64) func(*options) {
+	limiter := NewRateLimiter(bytesPerSecond)
+	return func(o *options) {
+		o.uploadLimiter = limiter
+	}
+}
+
+// WithMaxDownloadRate limits the bytes per second received by all calls of the client, 0 means no limit.
+// The limit is shared by concurrent calls and can be overridden per call with WithRateLimits.
+func WithMaxDownloadRate(bytesPerSecond int64) func(*options) {
+	limiter := NewRateLimiter(bytesPerSecond)
+	return func(o *options) {
+		o.downloadLimiter = limiter
+	}
+}
+
 func grpcClientConn(ctx context.Context, host string, port uint16, opts ...func(*options)) (*grpc.ClientConn, error) {
 	pool, err := x509.SystemCertPool()
 	if err != nil {
@@ -102,6 +225,11 @@ func grpcClientConn(ctx context.Context, host string, port uint16, opts ...func(
 		opt(o)
 	}
 
+	transport, err := o.transportDialOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	sslVerification := os.Getenv("DL_SKIP_SSL_VERIFICATION")
 	creds := credentials.NewTLS(&tls.Config{
 		RootCAs:            pool,
@@ -122,7 +250,7 @@ func grpcClientConn(ctx context.Context, host string, port uint16, opts ...func(
 		}),
 	}
 
-	connectCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	connectCtx, cancel := context.WithTimeout(ctx, o.dialTimeoutOrDefault())
 	defer cancel()
 
 	server := fmt.Sprintf("%s:%d", host, port)
@@ -130,22 +258,11 @@ func grpcClientConn(ctx context.Context, host string, port uint16, opts ...func(
 		server = fmt.Sprintf("%s:%d", o.headlessHost, port)
 	}
 
-	return grpc.DialContext(connectCtx, server,
+	dialOptions := []grpc.DialOption{
 		grpc.WithTransportCredentials(creds),
 		grpc.WithPerRPCCredentials(auth),
 		grpc.WithReadBufferSize(BUFFER_SIZE),
 		grpc.WithWriteBufferSize(BUFFER_SIZE),
-		grpc.WithInitialConnWindowSize(INITIAL_CONN_WINDOW_SIZE),
-		grpc.WithInitialWindowSize(INITIAL_WINDOW_SIZE),
-		grpc.WithDefaultCallOptions(
-			grpc.MaxCallRecvMsgSize(MAX_MESSAGE_SIZE),
-			grpc.MaxCallSendMsgSize(MAX_MESSAGE_SIZE),
-		),
-		grpc.WithKeepaliveParams(keepalive.ClientParameters{
-			Time:                5 * time.Second,
-			Timeout:             1 * time.Second,
-			PermitWithoutStream: true,
-		}),
 		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
 		grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()),
 		grpc.WithDefaultServiceConfig(`
@@ -169,7 +286,13 @@ func grpcClientConn(ctx context.Context, host string, port uint16, opts ...func(
 				]
 			}
 		`),
-	)
+	}
+	dialOptions = append(dialOptions, transport...)
+	dialOptions = append(dialOptions, telemetry.BaggageDialOptions()...)
+	dialOptions = append(dialOptions, RateLimitDialOptions(opts...)...)
+	dialOptions = append(dialOptions, TimeoutDialOptions(opts...)...)
+
+	return grpc.DialContext(connectCtx, server, dialOptions...)
 }
 
 func NewClient(ctx context.Context, host string, port uint16, opts ...func(*options)) (*Client, error) {
@@ -183,7 +306,7 @@ func NewClient(ctx context.Context, host string, port uint16, opts ...func(*opti
 		return nil, err
 	}
 
-	return NewClientConn(conn), nil
+	return NewClientConn(conn, opts...), nil
 }
 
 func (c *Client) Close() {
@@ -193,11 +316,12 @@ func (c *Client) Close() {
 	c.conn.Close()
 }
 
-func (c *Client) ListProjects(ctx context.Context) ([]*pb.Project, error) {
+// ListProjects lists the projects having every label of selector, a nil selector lists every project.
+func (c *Client) ListProjects(ctx context.Context, selector map[string]string) ([]*pb.Project, error) {
 	ctx, span := telemetry.Start(ctx, "client.list-projects", trace.WithAttributes())
 	defer span.End()
 
-	resp, err := c.fs.ListProjects(ctx, &pb.ListProjectsRequest{})
+	resp, err := c.fs.ListProjects(ctx, &pb.ListProjectsRequest{LabelSelector: selector})
 	if err != nil {
 		return nil, fmt.Errorf("list projects: %w", err)
 	}
@@ -205,23 +329,148 @@ func (c *Client) ListProjects(ctx context.Context) ([]*pb.Project, error) {
 	return resp.Projects, nil
 }
 
-func (c *Client) NewProject(ctx context.Context, id int64, template *int64, packPatternsString *string) error {
-	var packPatterns []string
-	if packPatternsString != nil && *packPatternsString != "" {
-		packPatterns = strings.Split(*packPatternsString, ",")
+func (c *Client) SetProjectLabels(ctx context.Context, project int64, labels map[string]string, remove []string) (map[string]string, error) {
+	ctx, span := telemetry.Start(ctx, "client.set-project-labels", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
+
+	resp, err := c.fs.SetProjectLabels(ctx, &pb.SetProjectLabelsRequest{
+		Project: project,
+		Labels:  labels,
+		Remove:  remove,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("set project labels: %w", err)
+	}
+
+	return resp.Labels, nil
+}
+
+func (c *Client) GetProjectLabels(ctx context.Context, project int64) (map[string]string, error) {
+	ctx, span := telemetry.Start(ctx, "client.get-project-labels", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
+
+	resp, err := c.fs.GetProjectLabels(ctx, &pb.GetProjectLabelsRequest{Project: project})
+	if err != nil {
+		return nil, fmt.Errorf("get project labels: %w", err)
+	}
+
+	return resp.Labels, nil
+}
+
+// SetProjectCacheInclusion sets whether the packed objects of a project may be included in new cache versions.
+func (c *Client) SetProjectCacheInclusion(ctx context.Context, project int64, include bool) error {
+	ctx, span := telemetry.Start(ctx, "client.set-project-cache-inclusion", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.IncludeInCache.Attribute(include),
+	))
+	defer span.End()
+
+	_, err := c.fs.SetProjectCacheInclusion(ctx, &pb.SetProjectCacheInclusionRequest{Project: project, Include: include})
+	if err != nil {
+		return fmt.Errorf("set project cache inclusion: %w", err)
+	}
+
+	return nil
+}
+
+// SetProjectFrozen makes a project read-only or writable again. Writes to a frozen project fail with a
+// FailedPrecondition error mentioning the reason while reads go on.
+func (c *Client) SetProjectFrozen(ctx context.Context, project int64, frozen bool, reason *string) error {
+	ctx, span := telemetry.Start(ctx, "client.set-project-frozen", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Frozen.Attribute(frozen),
+	))
+	defer span.End()
+
+	_, err := c.fs.SetProjectFrozen(ctx, &pb.SetProjectFrozenRequest{Project: project, Frozen: frozen, Reason: reason})
+	if err != nil {
+		return fmt.Errorf("set project frozen: %w", err)
+	}
+
+	return nil
+}
+
+// SetProjectTransforms replaces the checkout transforms of a project, an empty list removes them.
+func (c *Client) SetProjectTransforms(ctx context.Context, project int64, transforms []string) error {
+	ctx, span := telemetry.Start(ctx, "client.set-project-transforms", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Transforms.Attribute(transforms),
+	))
+	defer span.End()
+
+	_, err := c.fs.SetProjectTransforms(ctx, &pb.SetProjectTransformsRequest{Project: project, Transforms: transforms})
+	if err != nil {
+		return fmt.Errorf("set project transforms: %w", err)
+	}
+
+	return nil
+}
+
+// SetProjectPolicy replaces the restrictions updates of a project must follow and returns the stored policy.
+func (c *Client) SetProjectPolicy(ctx context.Context, project int64, policy *pb.ProjectPolicy) (*pb.ProjectPolicy, error) {
+	ctx, span := telemetry.Start(ctx, "client.set-project-policy", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
+
+	resp, err := c.fs.SetProjectPolicy(ctx, &pb.SetProjectPolicyRequest{Project: project, Policy: policy})
+	if err != nil {
+		return nil, fmt.Errorf("set project policy %v: %w", project, err)
+	}
+
+	return resp.Policy, nil
+}
+
+func (c *Client) GetProjectPolicy(ctx context.Context, project int64) (*pb.ProjectPolicy, error) {
+	ctx, span := telemetry.Start(ctx, "client.get-project-policy", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
+
+	resp, err := c.fs.GetProjectPolicy(ctx, &pb.GetProjectPolicyRequest{Project: project})
+	if err != nil {
+		return nil, fmt.Errorf("get project policy %v: %w", project, err)
 	}
 
+	return resp.Policy, nil
+}
+
+func (c *Client) NewProject(ctx context.Context, id int64, template *int64, packPatternsString *string) error {
+	return c.NewProjectWithOptions(ctx, id, NewProjectOptions{
+		Template:     template,
+		PackPatterns: splitList(packPatternsString),
+	})
+}
+
+// NewProjectOptions configure Client.NewProjectWithOptions, the zero value creates an empty project.
+type NewProjectOptions struct {
+	// Template is cloned into the new project at its latest version
+	Template     *int64
+	PackPatterns []string
+	Transforms   []string
+	// IdempotencyKey makes retrying the creation with the same key succeed instead of failing on the existing project
+	IdempotencyKey *string
+}
+
+func (c *Client) NewProjectWithOptions(ctx context.Context, id int64, options NewProjectOptions) error {
 	ctx, span := telemetry.Start(ctx, "client.new-project", trace.WithAttributes(
 		key.Project.Attribute(id),
-		key.Template.Attribute(template),
-		key.PackPatterns.Attribute(packPatterns),
+		key.Template.Attribute(options.Template),
+		key.PackPatterns.Attribute(options.PackPatterns),
+		key.Transforms.Attribute(options.Transforms),
 	))
 	defer span.End()
 
 	request := &pb.NewProjectRequest{
-		Id:           id,
-		Template:     template,
-		PackPatterns: packPatterns,
+		Id:             id,
+		Template:       options.Template,
+		PackPatterns:   options.PackPatterns,
+		Transforms:     options.Transforms,
+		IdempotencyKey: options.IdempotencyKey,
 	}
 
 	_, err := c.fs.NewProject(ctx, request)
@@ -232,6 +481,61 @@ func (c *Client) NewProject(ctx context.Context, id int64, template *int64, pack
 	return nil
 }
 
+// splitList splits a comma separated list, nil and empty strings are empty lists.
+func splitList(list *string) []string {
+	if list == nil || *list == "" {
+		return nil
+	}
+	return strings.Split(*list, ",")
+}
+
+// ProvisionOptions configure Client.ProvisionProject, the zero value creates an empty project without labels.
+type ProvisionOptions struct {
+	// Template is cloned into the new project at TemplateVersion, which defaults to its latest version
+	Template        *int64
+	TemplateVersion *int64
+	PackPatterns    []string
+	Transforms      []string
+	Labels          map[string]string
+	IncludeInCache  *bool
+	Tenant          *string
+	// Message, Author and IdempotencyKey work as in WriteOptions, Message and Author annotate the cloned version
+	Message        *string
+	Author         *string
+	IdempotencyKey *string
+}
+
+// ProvisionProject creates, clones the template into and labels a project in a single server side transaction, it
+// returns the latest version and the labels of the new project.
+func (c *Client) ProvisionProject(ctx context.Context, id int64, opts ProvisionOptions) (int64, map[string]string, error) {
+	ctx, span := telemetry.Start(ctx, "client.provision-project", trace.WithAttributes(
+		key.Project.Attribute(id),
+		key.Template.Attribute(opts.Template),
+		key.PackPatterns.Attribute(opts.PackPatterns),
+		key.Transforms.Attribute(opts.Transforms),
+	))
+	defer span.End()
+
+	response, err := c.fs.ProvisionProject(ctx, &pb.ProvisionProjectRequest{
+		Id:              id,
+		Template:        opts.Template,
+		TemplateVersion: opts.TemplateVersion,
+		PackPatterns:    opts.PackPatterns,
+		Transforms:      opts.Transforms,
+		Labels:          opts.Labels,
+		IncludeInCache:  opts.IncludeInCache,
+		Tenant:          opts.Tenant,
+		Message:         opts.Message,
+		Author:          opts.Author,
+		IdempotencyKey:  opts.IdempotencyKey,
+	})
+	if err != nil {
+		return -1, nil, fmt.Errorf("provision project: %w", err)
+	}
+
+	return response.Version, response.Labels, nil
+}
+
 func (c *Client) DeleteProject(ctx context.Context, project int64) error {
 	ctx, span := telemetry.Start(ctx, "client.delete-project", trace.WithAttributes(
 		key.Project.Attribute(project),
@@ -251,6 +555,15 @@ func (c *Client) DeleteProject(ctx context.Context, project int64) error {
 }
 
 func (c *Client) Get(ctx context.Context, project int64, prefix string, ignores []string, vrange VersionRange) ([]*pb.Object, error) {
+	return c.get(ctx, project, prefix, ignores, vrange, false, nil)
+}
+
+// GetTransformed is like Get but applies the project's configured transformations to the returned objects.
+func (c *Client) GetTransformed(ctx context.Context, project int64, prefix string, ignores []string, vrange VersionRange, vars map[string]string) ([]*pb.Object, error) {
+	return c.get(ctx, project, prefix, ignores, vrange, true, vars)
+}
+
+func (c *Client) get(ctx context.Context, project int64, prefix string, ignores []string, vrange VersionRange, transform bool, vars map[string]string) ([]*pb.Object, error) {
 	ctx, span := telemetry.Start(ctx, "client.get", trace.WithAttributes(
 		key.Project.Attribute(project),
 		key.Prefix.Attribute(prefix),
@@ -269,167 +582,1783 @@ func (c *Client) Get(ctx context.Context, project int64, prefix string, ignores
 	}
 
 	request := &pb.GetRequest{
-		Project:     project,
-		FromVersion: vrange.From,
-		ToVersion:   vrange.To,
-		Queries:     []*pb.ObjectQuery{query},
-	}
+		Project:            project,
+		FromVersion:        vrange.From,
+		ToVersion:          vrange.To,
+		Queries:            []*pb.ObjectQuery{query},
+		Transform:          transform,
+		TransformVars:      vars,
+		MaxContentSendSize: c.maxContentSendSize,
+		SnapshotToken:      snapshotToken(ctx, project),
+	}
+
+	// attempts continue from the cursor of the last object received rather than starting over
+	err := c.retryPolicy(NoRetryPolicy).Do(ctx, func(_ int) error {
+		if request.ResumeCursor == nil {
+			objects = nil
+		}
+
+		for {
+			stream, err := c.fs.Get(ctx, request)
+			if err != nil {
+				return fmt.Errorf("connect fs.Get: %w", err)
+			}
+
+			received, err := receiveGet(stream, request, &objects)
+			if err == nil {
+				return nil
+			}
 
-	stream, err := c.fs.Get(ctx, request)
+			// a stream interrupted after making progress is resumed right away, one that fails before is left to the retry policy
+			if received == 0 || request.ResumeCursor == nil || !RetryableError(err) || ctx.Err() != nil {
+				return fmt.Errorf("receive fs.Get: %w", err)
+			}
+
+			span.AddEvent("resume fs.Get", trace.WithAttributes(key.ObjectsCount.Attribute(len(objects))))
+		}
+	})
 	if err != nil {
-		return nil, fmt.Errorf("connect fs.Get: %w", err)
+		return nil, err
 	}
 
+	return objects, nil
+}
+
+// receiveGet appends the objects of stream to objects and moves the resume cursor of request past them.
+// It returns how many objects were received before the stream ended or failed.
+func receiveGet(stream pb.Fs_GetClient, request *pb.GetRequest, objects *[]*pb.Object) (int, error) {
+	received := 0
+
 	for {
-		object, err := stream.Recv()
+		response, err := stream.Recv()
 		if err == io.EOF {
-			break
+			return received, nil
 		}
 		if err != nil {
-			return nil, fmt.Errorf("receive fs.Get: %w", err)
+			return received, err
 		}
 
-		objects = append(objects, object.GetObject())
+		*objects = append(*objects, response.GetObject())
+		if response.Cursor != "" {
+			request.ResumeCursor = &response.Cursor
+		}
+		received += 1
 	}
-
-	return objects, nil
 }
 
-type RebuildResult struct {
-	Version   int64  `json:"version"`
-	Count     uint32 `json:"count"`
-	FileMatch bool   `json:"fileMatch"`
-}
+// GetUnary fetches the objects matched by queries in a single response. ifNoneMatch holds the etags returned by a
+// previous call, one per query, the objects of queries that did not change are left out of the response.
+func (c *Client) GetUnary(ctx context.Context, project int64, queries []*pb.ObjectQuery, vrange VersionRange, ifNoneMatch []string) (*pb.GetUnaryResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.get-unary", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.FromVersion.Attribute(vrange.From),
+		key.ToVersion.Attribute(vrange.To),
+	))
+	defer span.End()
 
-func emptyResult(version int64) RebuildResult {
-	return RebuildResult{
-		Version:   version,
-		Count:     0,
-		FileMatch: false,
+	request := &pb.GetUnaryRequest{
+		Project:            project,
+		FromVersion:        vrange.From,
+		ToVersion:          vrange.To,
+		Queries:            queries,
+		MaxContentSendSize: c.maxContentSendSize,
+		IfNoneMatch:        ifNoneMatch,
+		SnapshotToken:      snapshotToken(ctx, project),
 	}
-}
 
-type rebuildResultTracker struct {
-	version atomic.Int64
-	count   atomic.Uint32
-	match   atomic.Bool
-	matcher *files.FileMatcher
+	response, err := c.fs.GetUnary(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("get unary project %v: %w", project, err)
+	}
+
+	return response, nil
 }
 
-func newResultTracker(matcher *files.FileMatcher) *rebuildResultTracker {
-	tracker := rebuildResultTracker{
-		version: atomic.Int64{},
-		count:   atomic.Uint32{},
-		match:   atomic.Bool{},
-		matcher: matcher,
-	}
+// ReadSnapshot returns the latest version of a project along with a token pinning reads to it.
+func (c *Client) ReadSnapshot(ctx context.Context, project int64) (*pb.ReadSnapshotResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.read-snapshot", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
 
-	tracker.match.Store(true)
+	response, err := c.fs.ReadSnapshot(ctx, &pb.ReadSnapshotRequest{Project: project})
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot project %v: %w", project, err)
+	}
 
-	return &tracker
+	return response, nil
 }
 
-func (t *rebuildResultTracker) checkVersion(version int64) error {
-	old := t.version.Swap(version)
-	if old != version && old != 0 {
-		return fmt.Errorf("invalid response version from GetComrpess, expected %v got %v", old, version)
+// PinReadSnapshot takes a snapshot of project and returns a context pinning every read of the project made with it to the snapshot's version.
+func (c *Client) PinReadSnapshot(ctx context.Context, project int64) (context.Context, int64, error) {
+	snapshot, err := c.ReadSnapshot(ctx, project)
+	if err != nil {
+		return ctx, -1, err
 	}
-	return nil
+
+	return WithReadSnapshot(ctx, snapshot), snapshot.Version, nil
 }
 
-func (t *rebuildResultTracker) add(count uint32, match bool) {
-	t.count.Add(count)
+// StatPaths describes many paths at once without downloading their content, version defaults to the latest one.
+func (c *Client) StatPaths(ctx context.Context, project int64, version *int64, paths []string) (*pb.StatPathsResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.stat-paths", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.ToVersion.Attribute(version),
+		key.ObjectsCount.Attribute(len(paths)),
+	))
+	defer span.End()
 
-	if count > 0 && !match {
-		t.match.Store(false)
+	response, err := c.fs.StatPaths(ctx, &pb.StatPathsRequest{Project: project, Version: version, Paths: paths, SnapshotToken: snapshotToken(ctx, project)})
+	if err != nil {
+		return nil, fmt.Errorf("stat paths project %v: %w", project, err)
 	}
+
+	return response, nil
 }
 
-func (t *rebuildResultTracker) result() RebuildResult {
-	count := t.count.Load()
-	if count == 0 {
-		t.match.Store(false)
-	}
+// ListPaths lists the paths under prefix existing at version, which defaults to the latest one, packed paths included.
+// A nil limit returns as many paths as the server allows, the response reports whether more matched.
+func (c *Client) ListPaths(ctx context.Context, project int64, version *int64, prefix string, limit *int64) (*pb.ListPathsResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.list-paths", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.ToVersion.Attribute(version),
+		key.Prefix.Attribute(prefix),
+	))
+	defer span.End()
 
-	return RebuildResult{
-		Version:   t.version.Load(),
-		Count:     count,
-		FileMatch: t.match.Load(),
+	response, err := c.fs.ListPaths(ctx, &pb.ListPathsRequest{Project: project, Version: version, Prefix: prefix, Limit: limit, SnapshotToken: snapshotToken(ctx, project)})
+	if err != nil {
+		return nil, fmt.Errorf("list paths project %v: %w", project, err)
 	}
+
+	return response, nil
 }
 
-func (c *Client) Rebuild(ctx context.Context, project int64, prefix string, toVersion *int64, dir string, ignores []string, cacheDir string, matcher *files.FileMatcher, summarize bool) (RebuildResult, error) {
-	ctx, span := telemetry.Start(ctx, "client.rebuild", trace.WithAttributes(
+// DirectorySizes returns the number of files and their total size for every entry directly under prefix at version.
+func (c *Client) DirectorySizes(ctx context.Context, project int64, version *int64, prefix string) (*pb.DirectorySizesResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.directory-sizes", trace.WithAttributes(
 		key.Project.Attribute(project),
+		key.ToVersion.Attribute(version),
 		key.Prefix.Attribute(prefix),
-		key.ToVersion.Attribute(toVersion),
-		key.Directory.Attribute(dir),
 	))
 	defer span.End()
 
-	fromVersion, err := ReadVersionFile(dir)
+	response, err := c.fs.DirectorySizes(ctx, &pb.DirectorySizesRequest{Project: project, Version: version, Prefix: prefix, SnapshotToken: snapshotToken(ctx, project)})
 	if err != nil {
-		return emptyResult(fromVersion), err
-	}
-	if toVersion != nil && fromVersion == *toVersion {
-		return emptyResult(fromVersion), nil
+		return nil, fmt.Errorf("directory sizes project %v: %w", project, err)
 	}
 
-	span.SetAttributes(key.FromVersion.Attribute(&fromVersion))
+	return response, nil
+}
 
-	query := &pb.ObjectQuery{
-		Path:     prefix,
-		IsPrefix: true,
+// PutObject writes a single object in a new version and returns it, the latest version when the content did not change.
+// With a baseVersion the write fails when the path changed after it.
+func (c *Client) PutObject(ctx context.Context, project int64, object *pb.Object, baseVersion *int64, message *string, author *string) (int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.put-object", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.ObjectPath.Attribute(object.Path),
+		key.BaseVersion.Attribute(baseVersion),
+	))
+	defer span.End()
+
+	response, err := c.fs.PutObject(ctx, &pb.PutObjectRequest{
+		Project:     project,
+		Object:      object,
+		BaseVersion: baseVersion,
+		Message:     message,
+		Author:      author,
+	})
+	if err != nil {
+		return -1, fmt.Errorf("put object %v project %v: %w", object.Path, project, err)
+	}
+
+	return response.Version, nil
+}
+
+// DeleteObject deletes a single path in a new version and returns it. With a baseVersion the deletion fails when the
+// path changed after it.
+func (c *Client) DeleteObject(ctx context.Context, project int64, path string, baseVersion *int64, message *string, author *string) (int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.delete-object", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.ObjectPath.Attribute(path),
+		key.BaseVersion.Attribute(baseVersion),
+	))
+	defer span.End()
+
+	response, err := c.fs.DeleteObject(ctx, &pb.DeleteObjectRequest{
+		Project:     project,
+		Path:        path,
+		BaseVersion: baseVersion,
+		Message:     message,
+		Author:      author,
+	})
+	if err != nil {
+		return -1, fmt.Errorf("delete object %v project %v: %w", path, project, err)
+	}
+
+	return response.Version, nil
+}
+
+// Stage adds objects to the named stage of a project without creating a version and returns how many were staged.
+// Objects staged at the same path replace each other, deleted objects stage the deletion of their path.
+func (c *Client) Stage(ctx context.Context, project int64, stage string, objects []*pb.Object) (int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.stage", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Stage.Attribute(stage),
+		key.ObjectsCount.Attribute(len(objects)),
+	))
+	defer span.End()
+
+	stream, err := c.fs.Stage(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("connect fs.Stage: %w", err)
+	}
+
+	for _, object := range objects {
+		err = stream.Send(&pb.StageRequest{
+			Project: project,
+			Stage:   stage,
+			Object:  object,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("send fs.Stage %v project %v: %w", object.Path, project, err)
+		}
+	}
+
+	response, err := stream.CloseAndRecv()
+	if err != nil {
+		return 0, fmt.Errorf("stage %v project %v: %w", stage, project, err)
+	}
+
+	return response.Staged, nil
+}
+
+// ListStaged describes the objects of a stage ordered by path, staged deletions are reported as missing paths.
+func (c *Client) ListStaged(ctx context.Context, project int64, stage string) ([]*pb.PathStat, error) {
+	ctx, span := telemetry.Start(ctx, "client.list-staged", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Stage.Attribute(stage),
+	))
+	defer span.End()
+
+	response, err := c.fs.ListStaged(ctx, &pb.ListStagedRequest{Project: project, Stage: stage})
+	if err != nil {
+		return nil, fmt.Errorf("list staged %v project %v: %w", stage, project, err)
+	}
+
+	return response.Objects, nil
+}
+
+// CommitStage writes the objects of a stage in a single new version and drops the stage. It returns the version and
+// the number of objects committed, the version is the latest one when the stage changed nothing.
+func (c *Client) CommitStage(ctx context.Context, project int64, stage string, options WriteOptions) (int64, int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.commit-stage", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Stage.Attribute(stage),
+	))
+	defer span.End()
+
+	response, err := c.fs.CommitStage(ctx, &pb.CommitStageRequest{
+		Project: project,
+		Stage:   stage,
+		Message: options.Message,
+		Author:  options.Author,
+		Force:   options.Force,
+	})
+	if err != nil {
+		return -1, 0, fmt.Errorf("commit stage %v project %v: %w", stage, project, err)
+	}
+
+	return response.Version, response.Count, nil
+}
+
+// DiscardStage drops the objects of a stage and returns how many there were.
+func (c *Client) DiscardStage(ctx context.Context, project int64, stage string) (int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.discard-stage", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Stage.Attribute(stage),
+	))
+	defer span.End()
+
+	response, err := c.fs.DiscardStage(ctx, &pb.DiscardStageRequest{Project: project, Stage: stage})
+	if err != nil {
+		return 0, fmt.Errorf("discard stage %v project %v: %w", stage, project, err)
+	}
+
+	return response.Discarded, nil
+}
+
+// RestorePaths re-adds deleted paths in a new version and returns it with the version each path was restored from.
+// Paths are restored from the last version they existed at unless fromVersion is set.
+func (c *Client) RestorePaths(ctx context.Context, project int64, paths []string, fromVersion *int64, message *string, author *string) (int64, []*pb.RestoredPath, error) {
+	ctx, span := telemetry.Start(ctx, "client.restore-paths", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.FromVersion.Attribute(fromVersion),
+		key.ObjectsCount.Attribute(len(paths)),
+	))
+	defer span.End()
+
+	response, err := c.fs.RestorePaths(ctx, &pb.RestorePathsRequest{
+		Project:     project,
+		Paths:       paths,
+		FromVersion: fromVersion,
+		Message:     message,
+		Author:      author,
+	})
+	if err != nil {
+		return -1, nil, fmt.Errorf("restore paths project %v: %w", project, err)
+	}
+
+	return response.Version, response.Restored, nil
+}
+
+// Diff lists the changes made to a project between two versions, binary or large contents are not returned inline.
+func (c *Client) Diff(ctx context.Context, project int64, prefix string, from int64, to *int64, maxInlineSize *int64) ([]*pb.DiffResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.diff", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Prefix.Attribute(prefix),
+		key.FromVersion.Attribute(&from),
+		key.ToVersion.Attribute(to),
+	))
+	defer span.End()
+
+	request := &pb.DiffRequest{
+		Project:       project,
+		FromVersion:   from,
+		ToVersion:     to,
+		Queries:       []*pb.ObjectQuery{{Path: prefix, IsPrefix: true}},
+		MaxInlineSize: maxInlineSize,
+	}
+
+	stream, err := c.fs.Diff(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("connect fs.Diff: %w", err)
+	}
+
+	var diffs []*pb.DiffResponse
+
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("receive fs.Diff: %w", err)
+		}
+
+		diffs = append(diffs, response)
+	}
+
+	return diffs, nil
+}
+
+type RebuildResult struct {
+	Version   int64    `json:"version"`
+	Count     uint32   `json:"count"`
+	Bytes     int64    `json:"bytes"`
+	FileMatch bool     `json:"fileMatch"`
+	Omitted   []string `json:"omitted,omitempty"`
+}
+
+func emptyResult(version int64) RebuildResult {
+	return RebuildResult{
+		Version:   version,
+		Count:     0,
+		FileMatch: false,
+	}
+}
+
+type rebuildResultTracker struct {
+	version atomic.Int64
+	count   atomic.Uint32
+	bytes   atomic.Int64
+	match   atomic.Bool
+	matcher *files.FileMatcher
+
+	omittedLock sync.Mutex
+	omitted     []*pb.Object
+}
+
+func newResultTracker(matcher *files.FileMatcher) *rebuildResultTracker {
+	tracker := rebuildResultTracker{
+		version: atomic.Int64{},
+		count:   atomic.Uint32{},
+		match:   atomic.Bool{},
+		matcher: matcher,
+	}
+
+	tracker.match.Store(true)
+
+	return &tracker
+}
+
+func (t *rebuildResultTracker) checkVersion(version int64) error {
+	old := t.version.Swap(version)
+	if old != version && old != 0 {
+		return fmt.Errorf("invalid response version from GetComrpess, expected %v got %v", old, version)
+	}
+	return nil
+}
+
+func (t *rebuildResultTracker) add(count uint32, bytes int64, match bool) {
+	t.count.Add(count)
+	t.bytes.Add(bytes)
+
+	if count > 0 && !match {
+		t.match.Store(false)
+	}
+}
+
+func (t *rebuildResultTracker) omit(objects []*pb.Object) {
+	t.omittedLock.Lock()
+	defer t.omittedLock.Unlock()
+	t.omitted = append(t.omitted, objects...)
+}
+
+func (t *rebuildResultTracker) result() RebuildResult {
+	count := t.count.Load()
+	if count == 0 {
+		t.match.Store(false)
+	}
+
+	return RebuildResult{
+		Version:   t.version.Load(),
+		Count:     count,
+		Bytes:     t.bytes.Load(),
+		FileMatch: t.match.Load(),
+		Omitted:   omittedPaths(t.omitted),
+	}
+}
+
+func omittedPaths(objects []*pb.Object) []string {
+	var paths []string
+	for _, object := range objects {
+		paths = append(paths, object.Path)
+	}
+	return paths
+}
+
+// Rebuild brings dir to toVersion of the project, Project.Rebuild takes the same settings and the less common ones,
+// like restoring mtimes, as options.
+func (c *Client) Rebuild(ctx context.Context, project int64, prefix string, toVersion *int64, dir string, ignores []string, cacheDir string, matcher *files.FileMatcher, summarize bool) (RebuildResult, error) {
+	return c.rebuildPrefix(ctx, project, rebuildOptions(prefix, toVersion, dir, ignores, cacheDir, matcher, summarize))
+}
+
+func (c *Client) rebuildPrefix(ctx context.Context, project int64, opts RebuildOptions) (RebuildResult, error) {
+	ctx, span := telemetry.Start(ctx, "client.rebuild", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Prefix.Attribute(opts.Prefix),
+		key.ToVersion.Attribute(opts.ToVersion),
+		key.Directory.Attribute(opts.Dir),
+	))
+	defer span.End()
+
+	query := &pb.ObjectQuery{
+		Path:     opts.Prefix,
+		IsPrefix: true,
+		Ignores:  opts.Ignores,
+	}
+
+	return c.rebuild(ctx, span, project, query, opts.ToVersion, opts.Dir, opts.CacheDir, opts.Matcher, !opts.SkipSummary, opts.RestoreMtimes, 0)
+}
+
+// RebuildLazy is like Rebuild but files larger than lazyThreshold are written as empty placeholders of the right size.
+// Their content is downloaded on demand with FetchOmitted.
+func (c *Client) RebuildLazy(ctx context.Context, project int64, prefix string, toVersion *int64, dir string, ignores []string, cacheDir string, matcher *files.FileMatcher, summarize bool, lazyThreshold int64) (RebuildResult, error) {
+	opts := rebuildOptions(prefix, toVersion, dir, ignores, cacheDir, matcher, summarize)
+	opts.LazyThreshold = lazyThreshold
+	return c.rebuildLazy(ctx, project, opts)
+}
+
+func (c *Client) rebuildLazy(ctx context.Context, project int64, opts RebuildOptions) (RebuildResult, error) {
+	ctx, span := telemetry.Start(ctx, "client.rebuild-lazy", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Prefix.Attribute(opts.Prefix),
+		key.ToVersion.Attribute(opts.ToVersion),
+		key.Directory.Attribute(opts.Dir),
+		key.MaxContentSize.Attribute(opts.LazyThreshold),
+	))
+	defer span.End()
+
+	query := &pb.ObjectQuery{
+		Path:     opts.Prefix,
+		IsPrefix: true,
+		Ignores:  opts.Ignores,
+	}
+
+	return c.rebuild(ctx, span, project, query, opts.ToVersion, opts.Dir, opts.CacheDir, opts.Matcher, !opts.SkipSummary, opts.RestoreMtimes, opts.LazyThreshold)
+}
+
+// RebuildAtomic is like Rebuild but the new version is materialized into a staging copy of dir which is then swapped into place,
+// so readers of dir never observe a partially updated tree. Unchanged files are hardlinked from dir into the staging copy.
+// The staging copy is a sibling of dir, dir must therefore not be a mount point.
+func (c *Client) RebuildAtomic(ctx context.Context, project int64, prefix string, toVersion *int64, dir string, ignores []string, cacheDir string, matcher *files.FileMatcher, summarize bool) (RebuildResult, error) {
+	return c.rebuildAtomic(ctx, project, rebuildOptions(prefix, toVersion, dir, ignores, cacheDir, matcher, summarize))
+}
+
+func (c *Client) rebuildAtomic(ctx context.Context, project int64, opts RebuildOptions) (RebuildResult, error) {
+	ctx, span := telemetry.Start(ctx, "client.rebuild-atomic", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Prefix.Attribute(opts.Prefix),
+		key.ToVersion.Attribute(opts.ToVersion),
+		key.Directory.Attribute(opts.Dir),
+	))
+	defer span.End()
+
+	dir := opts.Dir
+	fromVersion, err := ReadVersionFile(dir)
+	if err != nil {
+		return emptyResult(fromVersion), err
+	}
+	if opts.ToVersion != nil && fromVersion == *opts.ToVersion {
+		return emptyResult(fromVersion), nil
+	}
+
+	staging, err := stageDir(dir)
+	if err != nil {
+		return emptyResult(fromVersion), err
+	}
+	// Once swapped the staging path holds the previous tree
+	defer os.RemoveAll(staging)
+
+	query := &pb.ObjectQuery{
+		Path:     opts.Prefix,
+		IsPrefix: true,
+		Ignores:  opts.Ignores,
+	}
+
+	result, err := c.rebuild(ctx, span, project, query, opts.ToVersion, staging, opts.CacheDir, opts.Matcher, !opts.SkipSummary, opts.RestoreMtimes, 0)
+	if err != nil {
+		return emptyResult(fromVersion), err
+	}
+	if result.Count == 0 && result.Version == fromVersion {
+		return result, nil
+	}
+
+	err = swapStagedDir(staging, dir)
+	if err != nil {
+		return emptyResult(fromVersion), err
+	}
+
+	return result, nil
+}
+
+// ForceFullRebuild rebuilds dir from scratch, ignoring the version and metadata it holds, to recover from corrupt metadata.
+// The project is written to an empty staging copy swapped into place once complete, so stale files are dropped
+// and a failed rebuild leaves dir untouched. Local changes that were not sent with Update are lost.
+func (c *Client) ForceFullRebuild(ctx context.Context, project int64, prefix string, toVersion *int64, dir string, ignores []string, cacheDir string, matcher *files.FileMatcher, summarize bool, restoreMtimes bool) (RebuildResult, error) {
+	ctx, span := telemetry.Start(ctx, "client.force-full-rebuild", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Prefix.Attribute(prefix),
+		key.ToVersion.Attribute(toVersion),
+		key.Directory.Attribute(dir),
+	))
+	defer span.End()
+
+	staging, err := emptyStageDir(dir)
+	if err != nil {
+		return emptyResult(0), err
+	}
+	// Once swapped the staging path holds the previous tree
+	defer os.RemoveAll(staging)
+
+	// the sparse profile is the only metadata chosen by the user rather than derived from the files
+	sparse, err := os.ReadFile(filepath.Join(dir, sparseFile))
+	if err == nil {
+		err = ensureMetadataDir(staging)
+		if err == nil {
+			err = os.WriteFile(filepath.Join(staging, sparseFile), sparse, 0755)
+		}
+	}
+	// a metadata dir replaced by a file is as good as missing
+	if err != nil && !os.IsNotExist(err) && !errors.Is(err, syscall.ENOTDIR) {
+		return emptyResult(0), fmt.Errorf("cannot carry over sparse profile of %v: %w", dir, err)
+	}
+
+	query := &pb.ObjectQuery{
+		Path:     prefix,
+		IsPrefix: true,
 		Ignores:  ignores,
 	}
 
-	availableCacheVersions := ReadCacheVersionFile(cacheDir)
+	result, err := c.rebuild(ctx, span, project, query, toVersion, staging, cacheDir, matcher, summarize, restoreMtimes, 0)
+	if err != nil {
+		return emptyResult(0), err
+	}
+
+	// an empty project writes nothing, the version still has to be recorded
+	err = WriteVersionFile(staging, result.Version)
+	if err != nil {
+		return emptyResult(0), err
+	}
+
+	err = swapStagedDir(staging, dir)
+	if err != nil {
+		return emptyResult(0), err
+	}
+
+	return result, nil
+}
+
+// RebuildPaths is like Rebuild but only fetches an explicit list of paths instead of scanning a prefix.
+func (c *Client) RebuildPaths(ctx context.Context, project int64, paths []string, toVersion *int64, dir string, cacheDir string, matcher *files.FileMatcher, summarize bool) (RebuildResult, error) {
+	opts := rebuildOptions("", toVersion, dir, nil, cacheDir, matcher, summarize)
+	opts.Paths = paths
+	return c.rebuildPaths(ctx, project, opts)
+}
+
+func (c *Client) rebuildPaths(ctx context.Context, project int64, opts RebuildOptions) (RebuildResult, error) {
+	ctx, span := telemetry.Start(ctx, "client.rebuild-paths", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.ObjectsCount.Attribute(len(opts.Paths)),
+		key.ToVersion.Attribute(opts.ToVersion),
+		key.Directory.Attribute(opts.Dir),
+	))
+	defer span.End()
+
+	query := &pb.ObjectQuery{
+		Paths: opts.Paths,
+	}
+
+	return c.rebuild(ctx, span, project, query, opts.ToVersion, opts.Dir, opts.CacheDir, opts.Matcher, !opts.SkipSummary, opts.RestoreMtimes, 0)
+}
+
+func rebuildOptions(prefix string, toVersion *int64, dir string, ignores []string, cacheDir string, matcher *files.FileMatcher, summarize bool) RebuildOptions {
+	return RebuildOptions{
+		Dir:         dir,
+		ToVersion:   toVersion,
+		Prefix:      prefix,
+		Ignores:     ignores,
+		CacheDir:    cacheDir,
+		Matcher:     matcher,
+		SkipSummary: !summarize,
+	}
+}
+
+// rebuild runs rebuildAttempt under the client's retry policy, an interrupted attempt leaves dir at its previous version
+// so the next one fetches the same objects again. Directories with pending updates are not rebuilt.
+func (c *Client) rebuild(ctx context.Context, span trace.Span, project int64, query *pb.ObjectQuery, toVersion *int64, dir string, cacheDir string, matcher *files.FileMatcher, summarize bool, restoreMtimes bool, lazyThreshold int64) (RebuildResult, error) {
+	pending, err := hasPending(dir)
+	if err != nil {
+		return emptyResult(0), err
+	}
+	if pending {
+		return emptyResult(0), fmt.Errorf("%w in %v, send them with update or drop them first", ErrPendingUpdates, filepath.Join(dir, pendingDir))
+	}
+
+	progress := progressFromContext(ctx)
+	start := progress.snapshot()
+
+	var result RebuildResult
+	err = c.retryPolicy(NoRetryPolicy).Do(ctx, func(attempt int) error {
+		if attempt > 0 {
+			progress.rewind(start)
+		}
+
+		var err error
+		result, err = c.rebuildAttempt(ctx, span, project, query, toVersion, dir, cacheDir, matcher, summarize, restoreMtimes, lazyThreshold)
+		return err
+	})
+
+	return result, err
+}
+
+func (c *Client) rebuildAttempt(ctx context.Context, span trace.Span, project int64, query *pb.ObjectQuery, toVersion *int64, dir string, cacheDir string, matcher *files.FileMatcher, summarize bool, restoreMtimes bool, lazyThreshold int64) (RebuildResult, error) {
+	fromVersion, err := ReadVersionFile(dir)
+	if err != nil {
+		return emptyResult(fromVersion), err
+	}
+	if toVersion != nil && fromVersion == *toVersion {
+		return emptyResult(fromVersion), nil
+	}
+
+	span.SetAttributes(key.FromVersion.Attribute(&fromVersion))
+
+	sparse, err := ReadSparseProfile(dir)
+	if err != nil {
+		return emptyResult(fromVersion), err
+	}
+
+	availableCacheVersions := ReadCacheVersionFile(cacheDir)
+
+	var objectCache *files.ObjectCache
+	var knownHashes [][]byte
+	if cacheDir != "" {
+		objectCache, err = files.NewObjectCache(CacheLooseObjectsDir(cacheDir))
+		if err != nil {
+			return emptyResult(fromVersion), err
+		}
+
+		knownHashes, err = objectCache.Hashes()
+		if err != nil {
+			return emptyResult(fromVersion), err
+		}
+
+		if len(knownHashes) > db.MaxKnownHashes {
+			knownHashes = knownHashes[:db.MaxKnownHashes]
+		}
+	}
+
+	maxContentSendSize := c.maxContentSendSize
+	if lazyThreshold > 0 {
+		maxContentSendSize = &lazyThreshold
+	}
+
+	transform, vars := transformVars(ctx)
+
+	request := &pb.GetCompressRequest{
+		Project:                project,
+		FromVersion:            &fromVersion,
+		ToVersion:              toVersion,
+		Queries:                []*pb.ObjectQuery{query},
+		AvailableCacheVersions: availableCacheVersions,
+		MaxContentSendSize:     maxContentSendSize,
+		KnownHashes:            knownHashes,
+		SnapshotToken:          snapshotToken(ctx, project),
+		Transform:              transform,
+		TransformVars:          vars,
+	}
+
+	stream, err := c.fs.GetCompress(ctx, request)
+	if err != nil {
+		return emptyResult(fromVersion), fmt.Errorf("connect fs.GetCompress: %w", err)
+	}
+
+	// Pull one response before booting workers
+	// This is a short circuit for cases where there are no diffs to apply
+	response, err := stream.Recv()
+	if err == io.EOF {
+		return emptyResult(fromVersion), nil
+	}
+	if err != nil {
+		return emptyResult(fromVersion), fmt.Errorf("receive fs.GetCompress: %w", err)
+	}
+
+	err = ensureMetadataDir(dir)
+	if err != nil {
+		return emptyResult(fromVersion), err
+	}
+
+	tracker := newResultTracker(matcher)
+	progress := progressFromContext(ctx)
+
+	tarChan := make(chan *pb.GetCompressResponse, 32)
+	group, ctx := errgroup.WithContext(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+
+	group.Go(func() error {
+		ctx, span := telemetry.Start(ctx, "object-receiver")
+		defer span.End()
+		defer close(tarChan)
+
+		for {
+			err := tracker.checkVersion(response.Version)
+			if err != nil {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case tarChan <- response:
+				response, err = stream.Recv()
+				if err == io.EOF {
+					return nil
+				}
+				if err != nil {
+					cancel()
+					return fmt.Errorf("receive fs.GetCompress: %w", err)
+				}
+			}
+		}
+	})
+
+	pool := newWorkerPool(ctx)
+	stopTuning := pool.tune(ctx)
+	syncer := newSyncer(ctx, dir)
+
+	for i := 0; i < pool.size(); i++ {
+		// create the attribute here when `i` is different
+		attr := key.Worker.Attribute(i)
+
+		group.Go(func() error {
+			ctx, span := telemetry.Start(ctx, "object-writer", trace.WithAttributes(attr))
+			defer span.End()
+
+			tarReader := db.NewTarReader()
+			defer tarReader.Close()
+			uring := newUringWriter(ctx, syncer)
+			defer uring.Close()
+
+			write := func(response *pb.GetCompressResponse) error {
+				if len(response.Omitted) > 0 {
+					tracker.omit(response.Omitted)
+					return nil
+				}
+
+				err := verifyChecksum(response.Bytes, response.Hash)
+				if err != nil {
+					return fmt.Errorf("fs.GetCompress: %w", err)
+				}
+
+				tarReader.FromBytes(response.Bytes)
+
+				count, match, err := files.WriteTar(ctx, dir, CacheObjectsDir(cacheDir), tarReader, response.PackPath, files.WriteTarOptions{
+					ObjectCache:   objectCache,
+					Matcher:       matcher,
+					Sparse:        sparse,
+					RestoreMtimes: restoreMtimes,
+					Syncer:        syncer,
+					Uring:         uring,
+				})
+				if err != nil {
+					return err
+				}
+
+				tracker.add(count, int64(len(response.Bytes)), match)
+				progress.add(int64(count), int64(len(response.Bytes)))
+				return nil
+			}
+
+			for {
+				err := pool.acquire(ctx)
+				if err != nil {
+					return err
+				}
+
+				select {
+				case <-ctx.Done():
+					pool.release(0)
+					return ctx.Err()
+				case response, ok := <-tarChan:
+					if !ok {
+						pool.release(0)
+						return nil
+					}
+
+					err := write(response)
+					pool.release(int64(len(response.Bytes)))
+					if err != nil {
+						cancel()
+						return err
+					}
+				}
+			}
+		})
+	}
+
+	err = group.Wait()
+	stopTuning()
+	span.SetAttributes(key.WorkerCount.Attribute(pool.current()))
+	if err != nil {
+		return emptyResult(fromVersion), err
+	}
+
+	err = syncer.Finish()
+	if err != nil {
+		return emptyResult(fromVersion), err
+	}
+
+	result := tracker.result()
+
+	err = recordOmitted(dir, tracker.omitted, sparse, lazyThreshold > 0)
+	if err != nil {
+		return emptyResult(fromVersion), err
+	}
+
+	err = WriteVersionFile(dir, result.Version)
+	if err != nil {
+		return emptyResult(fromVersion), err
+	}
+
+	if summarize {
+		_, err = DiffAndSummarize(ctx, dir)
+		if err != nil {
+			return emptyResult(fromVersion), err
+		}
+	}
+
+	return result, nil
+}
+
+// recordOmitted adds the omitted objects to the omitted file so they can be fetched later.
+// When lazy is set they are written as placeholder stubs, otherwise any stale copy is removed.
+func recordOmitted(dir string, omitted []*pb.Object, sparse *files.SparseProfile, lazy bool) error {
+	if len(omitted) == 0 {
+		return nil
+	}
+
+	paths, err := ReadOmittedFile(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, object := range omitted {
+		if !sparse.Match(object.Path) {
+			continue
+		}
+
+		if lazy {
+			err = files.WriteStub(dir, object.Path, fs.FileMode(object.Mode), object.Size)
+			if err != nil {
+				return err
+			}
+		} else {
+			err = os.Remove(filepath.Join(dir, object.Path))
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("cannot remove omitted file %v: %w", object.Path, err)
+			}
+		}
+
+		paths = append(paths, object.Path)
+	}
+
+	slices.Sort(paths)
+	return WriteOmittedFile(dir, slices.Compact(paths))
+}
+
+// FetchOmitted downloads the content of paths left out by previous rebuilds, at the directory's current version.
+// Every omitted path is fetched when paths is empty. Paths that are still too large for the server stay omitted.
+func (c *Client) FetchOmitted(ctx context.Context, project int64, dir string, cacheDir string, paths []string) (RebuildResult, error) {
+	ctx, span := telemetry.Start(ctx, "client.fetch-omitted", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Directory.Attribute(dir),
+	))
+	defer span.End()
+
+	version, err := ReadVersionFile(dir)
+	if err != nil {
+		return emptyResult(version), err
+	}
+
+	omitted, err := ReadOmittedFile(dir)
+	if err != nil {
+		return emptyResult(version), err
+	}
+
+	var remaining []string
+	if len(paths) == 0 {
+		paths = omitted
+	} else {
+		for _, path := range paths {
+			if !slices.Contains(omitted, path) {
+				return emptyResult(version), fmt.Errorf("path %v was not omitted from %v", path, dir)
+			}
+		}
+
+		remaining = slices.DeleteFunc(omitted, func(path string) bool {
+			return slices.Contains(paths, path)
+		})
+	}
+	if len(paths) == 0 {
+		return emptyResult(version), nil
+	}
+
+	span.SetAttributes(key.ToVersion.Attribute(&version), key.OmittedCount.Attribute(len(paths)))
+
+	transform, vars := transformVars(ctx)
+
+	request := &pb.GetCompressRequest{
+		Project:                project,
+		ToVersion:              &version,
+		Queries:                []*pb.ObjectQuery{{Paths: paths}},
+		AvailableCacheVersions: ReadCacheVersionFile(cacheDir),
+		Transform:              transform,
+		TransformVars:          vars,
+	}
+
+	stream, err := c.fs.GetCompress(ctx, request)
+	if err != nil {
+		return emptyResult(version), fmt.Errorf("connect fs.GetCompress: %w", err)
+	}
+
+	result := RebuildResult{Version: version}
+	tarReader := db.NewTarReader()
+	defer tarReader.Close()
+
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return emptyResult(version), fmt.Errorf("receive fs.GetCompress: %w", err)
+		}
+
+		if len(response.Omitted) > 0 {
+			result.Omitted = append(result.Omitted, omittedPaths(response.Omitted)...)
+			continue
+		}
+
+		err = verifyChecksum(response.Bytes, response.Hash)
+		if err != nil {
+			return emptyResult(version), fmt.Errorf("fs.GetCompress: %w", err)
+		}
+
+		tarReader.FromBytes(response.Bytes)
+
+		count, _, err := files.WriteTar(ctx, dir, CacheObjectsDir(cacheDir), tarReader, response.PackPath, files.WriteTarOptions{})
+		if err != nil {
+			return emptyResult(version), err
+		}
+		result.Count += count
+		result.Bytes += int64(len(response.Bytes))
+	}
+
+	remaining = append(remaining, result.Omitted...)
+	slices.Sort(remaining)
+
+	err = WriteOmittedFile(dir, remaining)
+	if err != nil {
+		return emptyResult(version), err
+	}
+
+	// Refresh the summary so the fetched files are not seen as local changes by the next Update
+	_, err = DiffAndSummarize(ctx, dir)
+	if err != nil {
+		return emptyResult(version), err
+	}
+
+	return result, nil
+}
+
+// WriteOptions are sent along with a write, the zero value sends nothing.
+type WriteOptions struct {
+	// Message and Author annotate the version created by the write
+	Message *string
+	Author  *string
+	// IdempotencyKey makes retrying the write with the same key return the version created by the first attempt
+	IdempotencyKey *string
+	// Include and Exclude are globs limiting the changed paths sent by Update, changes of other paths stay pending for a later update
+	Include []string
+	Exclude []string
+	// BatchSize commits Update in versions of at most this many changed paths, so a failure only resends the batch it happened in.
+	// 0 sends every change in a single version.
+	BatchSize int
+	// Retries is how many more times a failed Update stream is sent before giving up, 0 uses the client's retry policy
+	Retries int
+	// Force applies Update even when the server's deletion guard flags it as removing too many objects
+	Force bool
+}
+
+func (o WriteOptions) pathFilter() (*files.SparseProfile, error) {
+	if len(o.Include) == 0 && len(o.Exclude) == 0 {
+		return nil, nil
+	}
+
+	patterns := slices.Clone(o.Include)
+	for _, exclude := range o.Exclude {
+		patterns = append(patterns, "!"+exclude)
+	}
+	return files.NewSparseProfile(patterns)
+}
+
+// Update sends the changes made in dir since it was last rebuilt or updated and returns the resulting version and the
+// number of changed paths.
+func (c *Client) Update(ctx context.Context, project int64, dir string) (int64, uint32, error) {
+	return c.UpdateWithOptions(ctx, project, dir, WriteOptions{})
+}
+
+// UpdateWithOptions is like Update but annotates, filters or batches the changes as options asks.
+func (c *Client) UpdateWithOptions(rootCtx context.Context, project int64, dir string, options WriteOptions) (int64, uint32, error) {
+	rootCtx, span := telemetry.Start(rootCtx, "client.update", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Directory.Attribute(dir),
+	))
+	defer span.End()
+
+	fromVersion, err := ReadVersionFile(dir)
+	if err != nil {
+		return -1, 0, err
+	}
+
+	sparse, err := ReadSparseProfile(dir)
+	if err != nil {
+		return -1, 0, err
+	}
+
+	filter, err := options.pathFilter()
+	if err != nil {
+		return -1, 0, err
+	}
+
+	diff, err := diffAndSummarize(rootCtx, dir, filter)
+	if err != nil {
+		return -1, 0, err
+	}
+
+	// Paths outside of the sparse profile are never sent to the server
+	if sparse != nil {
+		diff.Updates = slices.DeleteFunc(diff.Updates, func(update *fsdiff_pb.Update) bool {
+			return !sparse.Match(update.Path)
+		})
+	}
+
+	pending, err := ReadPending(dir)
+	if err != nil {
+		return -1, 0, err
+	}
+
+	updates := collapseUpdates(pending, diff.Updates)
+	if len(updates) == 0 {
+		return fromVersion, 0, nil
+	}
+
+	progressFromContext(rootCtx).setTotal(int64(len(updates)))
+
+	toVersion, caughtUp, err := c.sendUpdates(rootCtx, project, dir, updates, options, fromVersion)
+	if err != nil {
+		// The summary already recorded these changes, queue them so the next update sends them again
+		if len(diff.Updates) > 0 {
+			queueErr := queuePending(dir, pending, diff.Updates)
+			if queueErr != nil {
+				return -1, 0, errors.Join(err, queueErr)
+			}
+		}
+		return -1, 0, fmt.Errorf("%d changes queued in %v for the next update: %w", len(updates), pendingDir, err)
+	}
+
+	err = removePending(pending)
+	if err != nil {
+		return -1, 0, err
+	}
+
+	updateCount := uint32(len(updates))
+
+	if caughtUp {
+		err = WriteVersionFile(dir, toVersion)
+		if err != nil {
+			return -1, updateCount, err
+		}
+	} else {
+		// The catch up rebuild is not part of the progress reported for the update
+		result, err := c.Rebuild(WithProgress(rootCtx, nil), project, "", nil, dir, nil, "", nil, filter == nil)
+		if err != nil {
+			return -1, updateCount, err
+		}
+
+		// Summarize without forgetting the changes the filter left pending
+		if filter != nil {
+			_, err = diffAndSummarize(rootCtx, dir, filter)
+			if err != nil {
+				return -1, updateCount, err
+			}
+		}
+
+		toVersion = result.Version
+	}
+
+	return toVersion, updateCount, nil
+}
+
+const updateRetryDelay = 100 * time.Millisecond
+
+// sendUpdates commits the updates in batches of options.BatchSize and returns the last version created.
+// It also reports whether the batches created the only versions since fromVersion, otherwise dir needs to catch up.
+func (c *Client) sendUpdates(ctx context.Context, project int64, dir string, updates []*fsdiff_pb.Update, options WriteOptions, fromVersion int64) (int64, bool, error) {
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = len(updates)
+	}
+
+	// Without a key a batch committed right before its stream failed would be committed again by the retry
+	idempotencyKey := options.IdempotencyKey
+	if idempotencyKey == nil && (options.Retries > 0 || c.retryPolicy(NoRetryPolicy).MaxAttempts > 1) {
+		generated, err := randomIdempotencyKey()
+		if err != nil {
+			return -1, false, err
+		}
+		idempotencyKey = &generated
+	}
+
+	version := fromVersion
+	caughtUp := true
+
+	for start, batch := 0, 0; start < len(updates); start, batch = start+batchSize, batch+1 {
+		batchOptions := options
+		batchOptions.IdempotencyKey = idempotencyKey
+		if idempotencyKey != nil && batchSize < len(updates) {
+			batchKey := fmt.Sprintf("%s/%d", *idempotencyKey, batch)
+			batchOptions.IdempotencyKey = &batchKey
+		}
+
+		toVersion, err := c.sendUpdateBatch(ctx, project, dir, updates[start:min(start+batchSize, len(updates))], batchOptions)
+		if err != nil && batchSize < len(updates) {
+			return -1, false, fmt.Errorf("update batch %d after version %d: %w", batch, version, err)
+		}
+		if err != nil {
+			return -1, false, err
+		}
+
+		if toVersion != version+1 {
+			caughtUp = false
+		}
+		version = toVersion
+	}
+
+	return version, caughtUp, nil
+}
+
+// sendUpdateBatch commits updates as a single version, sending them again up to options.Retries times when the stream fails.
+func (c *Client) sendUpdateBatch(ctx context.Context, project int64, dir string, updates []*fsdiff_pb.Update, options WriteOptions) (int64, error) {
+	progress := progressFromContext(ctx)
+	start := progress.snapshot()
+
+	var toVersion int64
+	err := c.updateRetryPolicy(options).Do(ctx, func(attempt int) error {
+		if attempt > 0 {
+			progress.rewind(start)
+		}
+
+		var sentByHash int
+		var err error
+		toVersion, sentByHash, err = c.streamUpdate(ctx, project, dir, updates, options, true)
+		if sentByHash > 0 && status.Code(err) == codes.FailedPrecondition {
+			// a file sent by hash changed on the server in the meantime, send everything again with content
+			progress.rewind(start)
+			toVersion, _, err = c.streamUpdate(ctx, project, dir, updates, options, false)
+		}
+		return err
+	})
+
+	return toVersion, err
+}
+
+// updateRetryPolicy retries an update options.Retries times when it is set, with the client policy otherwise.
+// Updates are only retried with an idempotency key, a commit acknowledged right before the stream broke would be applied twice.
+func (c *Client) updateRetryPolicy(options WriteOptions) RetryPolicy {
+	if options.IdempotencyKey == nil {
+		return NoRetryPolicy
+	}
+
+	if options.Retries > 0 {
+		policy := c.retryPolicy(DefaultRetryPolicy)
+		policy.MaxAttempts = options.Retries + 1
+		policy.InitialBackoff = updateRetryDelay
+		return policy
+	}
+
+	return c.retryPolicy(NoRetryPolicy)
+}
+
+func randomIdempotencyKey() (string, error) {
+	bytes := make([]byte, 16)
+	_, err := rand.Read(bytes)
+	if err != nil {
+		return "", fmt.Errorf("generate idempotency key: %w", err)
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+const (
+	// checkUpdateMinSize is the smallest file whose content is only sent if the server needs it,
+	// smaller files are cheaper to send than to check first
+	checkUpdateMinSize = 64 * 1024
+	// checkUpdateBatchSize and checkUpdateBatchBytes bound the files held back while waiting on a CheckUpdate call
+	checkUpdateBatchSize  = 500
+	checkUpdateBatchBytes = 16 * 1024 * 1024
+)
+
+func worthChecking(object *pb.Object) bool {
+	return !object.Deleted && pb.TarTypeFromMode(fs.FileMode(object.Mode)) == tar.TypeReg && len(object.Content) >= checkUpdateMinSize
+}
+
+// streamUpdate sends the updated objects in a single Update stream and returns the resulting version.
+// When conditional is set, large files the server already has at the same path are sent by hash alone,
+// the number of such files is returned so a FAILED_PRECONDITION caused by a concurrent change can be retried without them.
+func (c *Client) streamUpdate(rootCtx context.Context, project int64, dir string, updates []*fsdiff_pb.Update, options WriteOptions, conditional bool) (int64, int, error) {
+	toVersion := int64(-1)
+
+	progress := progressFromContext(rootCtx)
+	sentByHash := 0
+	checkUpdate := conditional
+
+	updateChan := make(chan *fsdiff_pb.Update, len(updates))
+	objectChan := make(chan *pb.Object, 32)
+
+	group, ctx := errgroup.WithContext(rootCtx)
+	ctx, cancel := context.WithCancel(ctx)
+
+	workerCount := parallelWorkerCount()
+	trace.SpanFromContext(rootCtx).SetAttributes(key.WorkerCount.Attribute(workerCount))
+
+	for i := 0; i < workerCount; i++ {
+		// create the attribute here when `i` is different
+		attr := key.Worker.Attribute(i)
+
+		group.Go(func() error {
+			ctx, span := telemetry.Start(ctx, "object-reader", trace.WithAttributes(attr))
+			defer span.End()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case update, ok := <-updateChan:
+					if !ok {
+						return nil
+					}
+
+					var object *pb.Object
+					var err error
+
+					if update.Action == fsdiff_pb.Update_REMOVE {
+						object = &pb.Object{
+							Path:    update.Path,
+							Deleted: true,
+						}
+					} else {
+						object, err = pb.ObjectFromFilePath(dir, update.Path)
+						if err != nil {
+							cancel()
+							return fmt.Errorf("read file object: %w", err)
+						}
+					}
+
+					select {
+					case <-ctx.Done():
+						return nil
+					case objectChan <- object:
+						continue
+					}
+				}
+			}
+		})
+	}
+
+	group.Go(func() error {
+		ctx, span := telemetry.Start(ctx, "object-sender")
+		defer span.End()
+
+		stream, err := c.fs.Update(ctx)
+		if err != nil {
+			cancel()
+			return fmt.Errorf("connect fs.Update: %w", err)
+		}
+
+		sent := 0
+		send := func(object *pb.Object, hash []byte) error {
+			request := &pb.UpdateRequest{
+				Project: project,
+				Object:  object,
+				Hash:    hash,
+			}
+			if sent == 0 {
+				request.Message = options.Message
+				request.Author = options.Author
+				request.IdempotencyKey = options.IdempotencyKey
+				if options.Force {
+					request.Force = &options.Force
+				}
+			}
+
+			sent += 1
+
+			err := stream.Send(request)
+			if err != nil {
+				return fmt.Errorf("send fs.Update, path %v, size %v, mode %v, deleted %v: %w", object.Path, object.Size, object.Mode, object.Deleted, err)
+			}
+
+			progress.add(1, int64(len(object.Content)))
+			return nil
+		}
+
+		// large files are held back until the server says whether it needs their content
+		var pending []*pb.Object
+		pendingBytes := 0
+
+		flush := func() error {
+			if len(pending) == 0 {
+				return nil
+			}
+
+			hashes := make([][]byte, len(pending))
+			needed := make([]bool, len(pending))
+			request := &pb.CheckUpdateRequest{Project: project}
+			for idx, object := range pending {
+				hash := db.HashContent(object.Content)
+				hashes[idx] = hash.Bytes()
+				needed[idx] = true
+				request.Objects = append(request.Objects, &pb.ObjectHash{Path: object.Path, Mode: object.Mode, Hash: hashes[idx]})
+			}
+
+			response, err := c.fs.CheckUpdate(ctx, request)
+			if status.Code(err) == codes.Unimplemented {
+				// older servers always need the content
+				checkUpdate = false
+			} else if err != nil {
+				return fmt.Errorf("check fs.Update: %w", err)
+			} else if len(response.ContentNeeded) == len(pending) {
+				needed = response.ContentNeeded
+			}
+
+			for idx, object := range pending {
+				if needed[idx] {
+					err = send(object, nil)
+				} else {
+					sentByHash += 1
+					err = send(&pb.Object{Path: object.Path, Mode: object.Mode, Size: object.Size, Mtime: object.Mtime}, hashes[idx])
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			pending, pendingBytes = nil, 0
+			return nil
+		}
+
+		received := 0
+
+		for {
+			if received == len(updates) {
+				close(objectChan)
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case object, ok := <-objectChan:
+				if !ok {
+					err := flush()
+					if err != nil {
+						cancel()
+						return err
+					}
+
+					response, err := stream.CloseAndRecv()
+					if err != nil {
+						cancel()
+						return fmt.Errorf("close and receive fs.Update: %w", err)
+					}
+					toVersion = response.Version
+					return nil
+				}
+
+				received += 1
+
+				if checkUpdate && worthChecking(object) {
+					pending = append(pending, object)
+					pendingBytes += len(object.Content)
+
+					if pendingBytes < checkUpdateBatchBytes && len(pending) < checkUpdateBatchSize {
+						continue
+					}
+					err = flush()
+				} else {
+					err = send(object, nil)
+				}
+				if err != nil {
+					cancel()
+					return err
+				}
+			}
+		}
+	})
+
+	for _, update := range updates {
+		updateChan <- update
+	}
+	close(updateChan)
+
+	err := group.Wait()
+	if err != nil {
+		return -1, sentByHash, err
+	}
+
+	return toVersion, sentByHash, nil
+}
+
+func (c *Client) Inspect(ctx context.Context, project int64) (*pb.InspectResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.inspect", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
+
+	inspect, err := c.fs.Inspect(ctx, &pb.InspectRequest{Project: project})
+	if err != nil {
+		return nil, fmt.Errorf("inspect project %v: %w", project, err)
+	}
+
+	return inspect, nil
+}
+
+func (c *Client) Snapshot(ctx context.Context) (string, error) {
+	ctx, span := telemetry.Start(ctx, "client.snapshot")
+	defer span.End()
+
+	resp, err := c.fs.Snapshot(ctx, &pb.SnapshotRequest{})
+	if err != nil {
+		return "", fmt.Errorf("snapshot: %w", err)
+	}
+
+	var state []string
+	for _, projectSnapshot := range resp.Projects {
+		state = append(state, fmt.Sprintf("%v=%v", projectSnapshot.Id, projectSnapshot.Version))
+	}
+
+	return strings.Join(state, ","), nil
+}
+
+func (c *Client) Reset(ctx context.Context, state string) error {
+	ctx, span := telemetry.Start(ctx, "client.reset", trace.WithAttributes(
+		key.State.Attribute(state),
+	))
+	defer span.End()
+
+	var projects []*pb.Project
+
+	for _, projectSplit := range strings.Split(state, ",") {
+		parts := strings.Split(projectSplit, "=")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid state chunk: %v", projectSplit)
+		}
+
+		project, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid state int %v: %w", parts[0], err)
+		}
+
+		version, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid state int %v: %w", parts[1], err)
+		}
+
+		projects = append(projects, &pb.Project{
+			Id:      project,
+			Version: version,
+		})
+	}
+
+	_, err := c.fs.Reset(ctx, &pb.ResetRequest{
+		Projects: projects,
+	})
+	if err != nil {
+		return fmt.Errorf("reset to state: %w", err)
+	}
+
+	return nil
+}
+
+// getCacheRetryPolicy retries any failure, attempts after the first only fetch the objects that were not materialized yet
+var getCacheRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: time.Second,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+	Retryable:      func(error) bool { return true },
+}
+
+// cachedObjectHashes lists the hashes of the cache objects already materialized in objectDir.
+func cachedObjectHashes(objectDir string) ([][]byte, error) {
+	entries, err := os.ReadDir(objectDir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read cache object folder: %w", err)
+	}
+
+	var hashes [][]byte
+	for _, entry := range entries {
+		hash, err := hex.DecodeString(entry.Name())
+		if err != nil || len(hash) != 32 {
+			continue
+		}
+		hashes = append(hashes, hash)
+
+		if len(hashes) >= db.MaxKnownHashes {
+			break
+		}
+	}
+
+	return hashes, nil
+}
+
+// GetCache downloads the latest cache version into cacheRootDir.
+// Interrupted downloads are retried and only fetch the objects that were not materialized yet.
+func (c *Client) GetCache(ctx context.Context, cacheRootDir string) (int64, uint32, error) {
+	objectDir := CacheObjectsDir(cacheRootDir)
+	err := os.MkdirAll(objectDir, 0755)
+	if err != nil {
+		return -1, 0, fmt.Errorf("cannot create object folder: %w", err)
+	}
+
+	tmpObjectDir := CacheTmpDir(cacheRootDir)
+	os.RemoveAll(tmpObjectDir)
+
+	err = os.MkdirAll(tmpObjectDir, 0755)
+	if err != nil {
+		return -1, 0, fmt.Errorf("cannot create tmp folder to unpack cached objects: %w", err)
+	}
+	defer os.RemoveAll(tmpObjectDir)
+
+	lock, err := LockCache(cacheRootDir)
+	if err != nil {
+		return -1, 0, err
+	}
+	defer lock.Release()
+
+	ctx, span := telemetry.Start(ctx, "client.get_cache")
+	defer span.End()
+
+	version, err := c.GetCacheVersion(ctx)
+	if err != nil {
+		return -1, 0, err
+	}
+	if version == 0 {
+		return -1, 0, nil
+	}
+
+	if slices.Contains(ReadCacheVersionFile(cacheRootDir), version) {
+		return version, 0, nil
+	}
+
+	var count uint32
+
+	err = c.retryPolicy(getCacheRetryPolicy).Do(ctx, func(_ int) error {
+		written, err := c.getCacheAttempt(ctx, cacheRootDir, version)
+		count += written
+		return err
+	})
+	if err != nil {
+		return -1, count, err
+	}
+
+	versionFile, err := os.OpenFile(cacheVersionPath(cacheRootDir), os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return -1, count, fmt.Errorf("fs.GetCache cannot open cache versions file for writing: %w", err)
+	}
+	defer versionFile.Close()
+
+	_, err = versionFile.WriteString(fmt.Sprintf("%d\n", version))
+	if err != nil {
+		return -1, count, fmt.Errorf("fs.GetCache failed to update the versions file: %w", err)
+	}
+
+	return version, count, nil
+}
+
+// GetCacheVersionProjects returns the projects whose packed objects were included in a cache version.
+func (c *Client) GetCacheVersionProjects(ctx context.Context, version int64) ([]int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.get_cache_version_projects", trace.WithAttributes(
+		key.Version.Attribute(version),
+	))
+	defer span.End()
+
+	response, err := c.fs.GetCacheVersionProjects(ctx, &pb.GetCacheVersionProjectsRequest{Version: version})
+	if err != nil {
+		return nil, fmt.Errorf("fs.GetCacheVersionProjects: %w", err)
+	}
+
+	return response.Projects, nil
+}
+
+// CreateCache creates a new cache version from the count packs shared by the most projects among the packs under any of prefixes.
+// Only packs of includeProjects are considered when it is not empty, packs of excludeProjects never are.
+func (c *Client) CreateCache(ctx context.Context, prefixes []string, includeProjects []int64, excludeProjects []int64, count *int64) (int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.create_cache", trace.WithAttributes(
+		key.Prefixes.Attribute(prefixes),
+		key.IncludeProjects.Attribute(includeProjects),
+		key.ExcludeProjects.Attribute(excludeProjects),
+	))
+	defer span.End()
+
+	response, err := c.fs.CreateCache(ctx, &pb.CreateCacheRequest{
+		Prefixes:        prefixes,
+		IncludeProjects: includeProjects,
+		ExcludeProjects: excludeProjects,
+		Count:           count,
+	})
+	if err != nil {
+		return -1, fmt.Errorf("fs.CreateCache: %w", err)
+	}
+
+	return response.Version, nil
+}
+
+func (c *Client) ListCacheVersions(ctx context.Context) ([]*pb.CacheVersion, error) {
+	ctx, span := telemetry.Start(ctx, "client.list_cache_versions")
+	defer span.End()
+
+	response, err := c.fs.ListCacheVersions(ctx, &pb.ListCacheVersionsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("fs.ListCacheVersions: %w", err)
+	}
+
+	return response.Versions, nil
+}
+
+// DeleteCacheVersion removes a cache version from the server and returns how many contents were reclaimed.
+func (c *Client) DeleteCacheVersion(ctx context.Context, version int64) (int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.delete_cache_version", trace.WithAttributes(
+		key.Version.Attribute(version),
+	))
+	defer span.End()
+
+	response, err := c.fs.DeleteCacheVersion(ctx, &pb.DeleteCacheVersionRequest{Version: version})
+	if err != nil {
+		return 0, fmt.Errorf("fs.DeleteCacheVersion: %w", err)
+	}
+
+	return response.Count, nil
+}
+
+// GetCacheVersion returns the latest cache version available on the server, 0 when there is none.
+func (c *Client) GetCacheVersion(ctx context.Context) (int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.get_cache_version")
+	defer span.End()
+
+	response, err := c.fs.GetCacheVersion(ctx, &pb.GetCacheVersionRequest{})
+	if err != nil {
+		return -1, fmt.Errorf("fs.GetCacheVersion: %w", err)
+	}
+
+	return response.Version, nil
+}
 
-	request := &pb.GetCompressRequest{
-		Project:                project,
-		FromVersion:            &fromVersion,
-		ToVersion:              toVersion,
-		Queries:                []*pb.ObjectQuery{query},
-		AvailableCacheVersions: availableCacheVersions,
+// getCacheAttempt downloads a cache version, skipping objects already materialized in the cache dir by an earlier attempt or version.
+func (c *Client) getCacheAttempt(ctx context.Context, cacheRootDir string, version int64) (uint32, error) {
+	ctx, span := telemetry.Start(ctx, "client.get_cache_attempt")
+	defer span.End()
+
+	objectDir := CacheObjectsDir(cacheRootDir)
+	tmpObjectDir := CacheTmpDir(cacheRootDir)
+
+	knownHashes, err := cachedObjectHashes(objectDir)
+	if err != nil {
+		return 0, err
 	}
 
-	stream, err := c.fs.GetCompress(ctx, request)
+	request := &pb.GetCacheRequest{
+		KnownHashes: knownHashes,
+		Version:     &version,
+	}
+
+	stream, err := c.fs.GetCache(ctx, request)
 	if err != nil {
-		return emptyResult(fromVersion), fmt.Errorf("connect fs.GetCompress: %w", err)
+		return 0, fmt.Errorf("fs.GetCache connect: %w", err)
 	}
 
 	// Pull one response before booting workers
-	// This is a short circuit for cases where there are no diffs to apply
 	response, err := stream.Recv()
 	if err == io.EOF {
-		return emptyResult(fromVersion), nil
+		return 0, nil
 	}
 	if err != nil {
-		return emptyResult(fromVersion), fmt.Errorf("receive fs.GetCompress: %w", err)
+		return 0, fmt.Errorf("fs.GetCache receive: %w", err)
 	}
-
-	err = ensureMetadataDir(dir)
-	if err != nil {
-		return emptyResult(fromVersion), err
+	if response.Version != version {
+		return 0, fmt.Errorf("fs.GetCache version mismatch: %d, %d", response.Version, version)
 	}
 
-	tracker := newResultTracker(matcher)
-
-	tarChan := make(chan *pb.GetCompressResponse, 32)
+	tarChan := make(chan *pb.GetCacheResponse, 16)
 	group, ctx := errgroup.WithContext(ctx)
 	ctx, cancel := context.WithCancel(ctx)
 
 	group.Go(func() error {
-		ctx, span := telemetry.Start(ctx, "object-receiver")
+		ctx, span := telemetry.Start(ctx, "cache-object-receiver")
 		defer span.End()
 		defer close(tarChan)
 
 		for {
-			err := tracker.checkVersion(response.Version)
-			if err != nil {
-				return err
-			}
-
 			select {
 			case <-ctx.Done():
 				return nil
@@ -440,534 +2369,607 @@ func (c *Client) Rebuild(ctx context.Context, project int64, prefix string, toVe
 				}
 				if err != nil {
 					cancel()
-					return fmt.Errorf("receive fs.GetCompress: %w", err)
+					return fmt.Errorf("fs.GetCache receive: %w", err)
+				}
+				if response.Version != version {
+					cancel()
+					return fmt.Errorf("fs.GetCache version mismatch: %d, %d", response.Version, version)
 				}
 			}
 		}
 	})
 
-	workerCount := parallelWorkerCount()
-	span.SetAttributes(key.WorkerCount.Attribute(workerCount))
+	var writtenObjectCount atomic.Uint32
+	progress := progressFromContext(ctx)
 
-	for i := 0; i < workerCount; i++ {
+	pool := newWorkerPool(ctx)
+	stopTuning := pool.tune(ctx)
+
+	for i := 0; i < pool.size(); i++ {
 		// create the attribute here when `i` is different
 		attr := key.Worker.Attribute(i)
 
 		group.Go(func() error {
-			ctx, span := telemetry.Start(ctx, "object-writer", trace.WithAttributes(attr))
+			ctx, span := telemetry.Start(ctx, "cache-object-writer", trace.WithAttributes(attr))
 			defer span.End()
 
 			tarReader := db.NewTarReader()
+			defer tarReader.Close()
+
+			write := func(response *pb.GetCacheResponse) error {
+				// Sent when every object is already known
+				if len(response.Bytes) == 0 {
+					return nil
+				}
+
+				err := verifyChecksum(response.Bytes, response.Hash)
+				if err != nil {
+					return fmt.Errorf("fs.GetCache: %w", err)
+				}
+
+				tarReader.FromBytes(response.Bytes)
+				hashHex := hex.EncodeToString(response.Hash)
+				tempDest := filepath.Join(tmpObjectDir, hashHex)
+				finalDest := filepath.Join(objectDir, hashHex)
+
+				if fileExists(finalDest) {
+					return nil
+				}
+
+				if fileExists(tempDest) {
+					err := os.RemoveAll(tempDest)
+					if err != nil {
+						return fmt.Errorf("temporary cache folder exists for %s and couldn't be removed: %w", tempDest, err)
+					}
+				}
+
+				count, _, err := files.WriteTar(ctx, tempDest, CacheObjectsDir(cacheRootDir), tarReader, nil, files.WriteTarOptions{})
+				if err != nil {
+					return err
+				}
+
+				err = os.Rename(tempDest, finalDest)
+				if err != nil {
+					return fmt.Errorf("couldn't rename temporary folder (%s) to final folder (%s): %w", tempDest, finalDest, err)
+				}
+				writtenObjectCount.Add(count)
+				progress.add(int64(count), int64(len(response.Bytes)))
+				return nil
+			}
 
 			for {
+				err := pool.acquire(ctx)
+				if err != nil {
+					return err
+				}
+
 				select {
 				case <-ctx.Done():
+					pool.release(0)
 					return ctx.Err()
 				case response, ok := <-tarChan:
 					if !ok {
+						pool.release(0)
 						return nil
 					}
 
-					tarReader.FromBytes(response.Bytes)
-
-					count, match, err := files.WriteTar(dir, CacheObjectsDir(cacheDir), tarReader, response.PackPath, matcher)
+					err := write(response)
+					pool.release(int64(len(response.Bytes)))
 					if err != nil {
 						cancel()
 						return err
 					}
-
-					tracker.add(count, match)
 				}
 			}
 		})
 	}
 
 	err = group.Wait()
-	if err != nil {
-		return emptyResult(fromVersion), err
-	}
+	stopTuning()
+	span.SetAttributes(key.WorkerCount.Attribute(pool.current()))
+	return writtenObjectCount.Load(), err
+}
 
-	result := tracker.result()
+func (c *Client) GcProject(ctx context.Context, project int64, keep int64, from *int64, dryRun bool) (int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.gc-project", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
 
-	err = WriteVersionFile(dir, result.Version)
-	if err != nil {
-		return emptyResult(fromVersion), err
+	request := &pb.GcProjectRequest{
+		Project:      project,
+		KeepVersions: keep,
+		FromVersion:  from,
+		DryRun:       dryRun,
 	}
 
-	if summarize {
-		_, err = DiffAndSummarize(ctx, dir)
-		if err != nil {
-			return emptyResult(fromVersion), err
-		}
+	response, err := c.fs.GcProject(ctx, request)
+	if err != nil {
+		return 0, fmt.Errorf("gc project %v: %w", project, err)
 	}
 
-	return result, nil
+	return response.Count, nil
 }
 
-func (c *Client) Update(rootCtx context.Context, project int64, dir string) (int64, uint32, error) {
-	rootCtx, span := telemetry.Start(rootCtx, "client.update", trace.WithAttributes(
+// ListTombstones lists the paths under prefix deleted at or before version, which defaults to the latest one.
+// A nil limit returns as many tombstones as the server allows.
+func (c *Client) ListTombstones(ctx context.Context, project int64, version *int64, prefix string, limit *int64) (*pb.ListTombstonesResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.list-tombstones", trace.WithAttributes(
 		key.Project.Attribute(project),
-		key.Directory.Attribute(dir),
+		key.ToVersion.Attribute(version),
+		key.Prefix.Attribute(prefix),
 	))
 	defer span.End()
 
-	fromVersion, err := ReadVersionFile(dir)
-	if err != nil {
-		return -1, 0, err
-	}
-
-	diff, err := DiffAndSummarize(rootCtx, dir)
+	response, err := c.fs.ListTombstones(ctx, &pb.ListTombstonesRequest{Project: project, Version: version, Prefix: prefix, Limit: limit})
 	if err != nil {
-		return -1, 0, err
-	}
-
-	if len(diff.Updates) == 0 {
-		return fromVersion, 0, nil
+		return nil, fmt.Errorf("list tombstones project %v: %w", project, err)
 	}
 
-	toVersion := int64(-1)
-
-	updateChan := make(chan *fsdiff_pb.Update, len(diff.Updates))
-	objectChan := make(chan *pb.Object, 32)
-
-	group, ctx := errgroup.WithContext(rootCtx)
-	ctx, cancel := context.WithCancel(ctx)
-
-	workerCount := parallelWorkerCount()
-	span.SetAttributes(key.WorkerCount.Attribute(workerCount))
+	return response, nil
+}
 
-	for i := 0; i < workerCount; i++ {
-		// create the attribute here when `i` is different
-		attr := key.Worker.Attribute(i)
+// PurgeDeleted removes the tombstones of paths under prefix deleted before beforeVersion.
+func (c *Client) PurgeDeleted(ctx context.Context, project int64, prefix string, beforeVersion int64) (*pb.PurgeDeletedResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.purge-deleted", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Prefix.Attribute(prefix),
+		key.Version.Attribute(beforeVersion),
+	))
+	defer span.End()
 
-		group.Go(func() error {
-			ctx, span := telemetry.Start(ctx, "object-reader", trace.WithAttributes(attr))
-			defer span.End()
+	response, err := c.fs.PurgeDeleted(ctx, &pb.PurgeDeletedRequest{Project: project, Prefix: prefix, BeforeVersion: beforeVersion})
+	if err != nil {
+		return nil, fmt.Errorf("purge deleted project %v: %w", project, err)
+	}
 
-			for {
-				select {
-				case <-ctx.Done():
-					return nil
-				case update, ok := <-updateChan:
-					if !ok {
-						return nil
-					}
+	return response, nil
+}
 
-					var object *pb.Object
+// SquashResult describes a squashed project history.
+type SquashResult struct {
+	BaselineVersion  int64 `json:"baselineVersion"`
+	RemovedObjects   int64 `json:"removedObjects"`
+	RewrittenObjects int64 `json:"rewrittenObjects"`
+	Count            int64 `json:"count"`
+}
 
-					if update.Action == fsdiff_pb.Update_REMOVE {
-						object = &pb.Object{
-							Path:    update.Path,
-							Deleted: true,
-						}
-					} else {
-						object, err = pb.ObjectFromFilePath(dir, update.Path)
-						if err != nil {
-							cancel()
-							return fmt.Errorf("read file object: %w", err)
-						}
-					}
+// SquashHistory collapses the history of a project below version, or below its latest version minus keep when version is nil, into a single baseline version.
+func (c *Client) SquashHistory(ctx context.Context, project int64, version *int64, keep *int64) (SquashResult, error) {
+	ctx, span := telemetry.Start(ctx, "client.squash-history", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
 
-					select {
-					case <-ctx.Done():
-						return nil
-					case objectChan <- object:
-						continue
-					}
-				}
-			}
-		})
+	request := &pb.SquashHistoryRequest{
+		Project:      project,
+		Version:      version,
+		KeepVersions: keep,
 	}
 
-	group.Go(func() error {
-		ctx, span := telemetry.Start(ctx, "object-sender")
-		defer span.End()
+	response, err := c.fs.SquashHistory(ctx, request)
+	if err != nil {
+		return SquashResult{}, fmt.Errorf("squash history %v: %w", project, err)
+	}
 
-		stream, err := c.fs.Update(ctx)
-		if err != nil {
-			cancel()
-			return fmt.Errorf("connect fs.Update: %w", err)
-		}
+	return SquashResult{
+		BaselineVersion:  response.BaselineVersion,
+		RemovedObjects:   response.RemovedObjects,
+		RewrittenObjects: response.RewrittenObjects,
+		Count:            response.Count,
+	}, nil
+}
 
-		count := 0
+func (c *Client) GcRandomProjects(ctx context.Context, sample float32, keep int64, from *int64) (int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.gc-random-project", trace.WithAttributes(
+		key.SampleRate.Attribute(sample),
+	))
+	defer span.End()
 
-		for {
-			if count == len(diff.Updates) {
-				close(objectChan)
-			}
+	request := &pb.GcRandomProjectsRequest{
+		Sample:       sample,
+		KeepVersions: keep,
+		FromVersion:  from,
+	}
 
-			select {
-			case <-ctx.Done():
-				return nil
-			case object, ok := <-objectChan:
-				if !ok {
-					response, err := stream.CloseAndRecv()
-					if err != nil {
-						cancel()
-						return fmt.Errorf("close and receive fs.Update: %w", err)
-					}
-					toVersion = response.Version
-					return nil
-				}
+	response, err := c.fs.GcRandomProjects(ctx, request)
+	if err != nil {
+		return 0, fmt.Errorf("gc random projects %v: %w", sample, err)
+	}
 
-				count += 1
+	return response.Count, nil
+}
 
-				err := stream.Send(&pb.UpdateRequest{
-					Project: project,
-					Object:  object,
-				})
-				if err != nil {
-					cancel()
-					return fmt.Errorf("send fs.Update, path %v, size %v, mode %v, deleted %v: %w", object.Path, object.Size, object.Mode, object.Deleted, err)
-				}
-			}
-		}
-	})
+func (c *Client) GcContents(ctx context.Context, sample float32) (int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.gc-contents", trace.WithAttributes(
+		key.SampleRate.Attribute(sample),
+	))
+	defer span.End()
 
-	for _, update := range diff.Updates {
-		updateChan <- update
+	request := &pb.GcContentsRequest{
+		Sample: sample,
 	}
-	close(updateChan)
 
-	err = group.Wait()
+	response, err := c.fs.GcContents(ctx, request)
 	if err != nil {
-		return -1, 0, err
+		return 0, fmt.Errorf("gc contents %v: %w", sample, err)
 	}
 
-	updateCount := uint32(len(diff.Updates))
+	return response.Count, nil
+}
 
-	if (fromVersion + 1) == toVersion {
-		err = WriteVersionFile(dir, toVersion)
-		if err != nil {
-			return -1, updateCount, err
-		}
-	} else {
-		result, err := c.Rebuild(rootCtx, project, "", nil, dir, nil, "", nil, true)
-		if err != nil {
-			return -1, updateCount, err
-		}
+// CheckContentRefs validates the references tracked for a sample of the contents against the objects reading them,
+// repair resets the inconsistent ones.
+func (c *Client) CheckContentRefs(ctx context.Context, sample float32, repair bool) (*pb.CheckContentRefsResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.check-content-refs", trace.WithAttributes(
+		key.SampleRate.Attribute(sample),
+	))
+	defer span.End()
 
-		toVersion = result.Version
+	request := &pb.CheckContentRefsRequest{
+		Sample: sample,
+		Repair: repair,
 	}
 
-	return toVersion, updateCount, nil
+	response, err := c.fs.CheckContentRefs(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("check content refs %v: %w", sample, err)
+	}
+
+	return response, nil
 }
 
-func (c *Client) Inspect(ctx context.Context, project int64) (*pb.InspectResponse, error) {
-	ctx, span := telemetry.Start(ctx, "client.inspect", trace.WithAttributes(
-		key.Project.Attribute(project),
-	))
+// AuditContents audits the limit contents following the after cursor for inconsistencies between objects and contents,
+// the response holds the cursor of the next batch.
+func (c *Client) AuditContents(ctx context.Context, after []byte, limit int64, repair, quarantine bool) (*pb.AuditContentsResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.audit-contents")
 	defer span.End()
 
-	inspect, err := c.fs.Inspect(ctx, &pb.InspectRequest{Project: project})
+	request := &pb.AuditContentsRequest{
+		After:      after,
+		Limit:      limit,
+		Repair:     repair,
+		Quarantine: quarantine,
+	}
+
+	response, err := c.fs.AuditContents(ctx, request)
 	if err != nil {
-		return nil, fmt.Errorf("inspect project %v: %w", project, err)
+		return nil, fmt.Errorf("audit contents: %w", err)
 	}
 
-	return inspect, nil
+	return response, nil
 }
 
-func (c *Client) Snapshot(ctx context.Context) (string, error) {
-	ctx, span := telemetry.Start(ctx, "client.snapshot")
+// CaptureHeapProfile returns a gzipped pprof heap profile of the server, gc runs a garbage collection first.
+func (c *Client) CaptureHeapProfile(ctx context.Context, gc bool) ([]byte, error) {
+	ctx, span := telemetry.Start(ctx, "client.capture-heap-profile")
 	defer span.End()
 
-	resp, err := c.fs.Snapshot(ctx, &pb.SnapshotRequest{})
+	response, err := c.fs.CaptureHeapProfile(ctx, &pb.CaptureHeapProfileRequest{Gc: gc})
 	if err != nil {
-		return "", fmt.Errorf("snapshot: %w", err)
-	}
-
-	var state []string
-	for _, projectSnapshot := range resp.Projects {
-		state = append(state, fmt.Sprintf("%v=%v", projectSnapshot.Id, projectSnapshot.Version))
+		return nil, fmt.Errorf("capture heap profile: %w", err)
 	}
 
-	return strings.Join(state, ","), nil
+	return response.Profile, nil
 }
 
-func (c *Client) Reset(ctx context.Context, state string) error {
-	ctx, span := telemetry.Start(ctx, "client.reset", trace.WithAttributes(
-		key.State.Attribute(state),
+// SampleObjects returns a random sample of up to count live objects of project, or of every project when project is nil.
+func (c *Client) SampleObjects(ctx context.Context, project *int64, count int64, includeContent bool, maxContentSize int64) ([]*pb.SampleObjectsResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.sample-objects", trace.WithAttributes(
+		key.ObjectsCount.Attribute(int(count)),
+		key.MaxContentSize.Attribute(maxContentSize),
 	))
 	defer span.End()
 
-	var projects []*pb.Project
+	request := &pb.SampleObjectsRequest{
+		Project:        project,
+		Count:          count,
+		IncludeContent: includeContent,
+		MaxContentSize: maxContentSize,
+	}
 
-	for _, projectSplit := range strings.Split(state, ",") {
-		parts := strings.Split(projectSplit, "=")
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid state chunk: %v", projectSplit)
-		}
+	stream, err := c.fs.SampleObjects(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("connect fs.SampleObjects: %w", err)
+	}
 
-		project, err := strconv.ParseInt(parts[0], 10, 64)
-		if err != nil {
-			return fmt.Errorf("invalid state int %v: %w", parts[0], err)
-		}
+	var samples []*pb.SampleObjectsResponse
 
-		version, err := strconv.ParseInt(parts[1], 10, 64)
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
-			return fmt.Errorf("invalid state int %v: %w", parts[1], err)
+			return nil, fmt.Errorf("receive fs.SampleObjects: %w", err)
 		}
 
-		projects = append(projects, &pb.Project{
-			Id:      project,
-			Version: version,
-		})
+		samples = append(samples, response)
 	}
 
-	_, err := c.fs.Reset(ctx, &pb.ResetRequest{
-		Projects: projects,
-	})
+	return samples, nil
+}
+
+// ScanStatus counts the objects of a project version by the scan status of their content and lists the infected ones.
+func (c *Client) ScanStatus(ctx context.Context, project int64, version *int64) (*pb.ScanStatusResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.scan-status", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.ToVersion.Attribute(version),
+	))
+	defer span.End()
+
+	response, err := c.fs.ScanStatus(ctx, &pb.ScanStatusRequest{Project: project, Version: version})
 	if err != nil {
-		return fmt.Errorf("reset to state: %w", err)
+		return nil, fmt.Errorf("scan status project %v: %w", project, err)
 	}
 
-	return nil
+	return response, nil
 }
 
-func obtainCacheLockFile(cacheRootDir string) (*os.File, error) {
-	lockFile, err := os.OpenFile(filepath.Join(cacheRootDir, ".lock"), os.O_CREATE|os.O_EXCL, 0600)
+// ContentStats reports how much content is shared between projects along with the top most shared contents.
+func (c *Client) ContentStats(ctx context.Context, top int64) (*pb.ContentStatsResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.content-stats")
+	defer span.End()
+
+	response, err := c.fs.ContentStats(ctx, &pb.ContentStatsRequest{Top: &top})
 	if err != nil {
-		return nil, fmt.Errorf("unable to obtain cache lock file; another process may be using it: %w", err)
+		return nil, fmt.Errorf("content stats: %w", err)
 	}
-	lockFile.Close()
 
-	return lockFile, nil
+	return response, nil
 }
 
-func cleanupCacheLockFile(lockFile *os.File) {
-	os.Remove(lockFile.Name())
-}
+// FindContent lists the paths of every project holding the content with the sha256 hash, past holders included when
+// history is set, and whether more than limit references matched.
+func (c *Client) FindContent(ctx context.Context, hash []byte, history bool, limit *int64) ([]*pb.ContentReference, bool, error) {
+	ctx, span := telemetry.Start(ctx, "client.find-content", trace.WithAttributes(
+		key.ContentHash.Attribute(hex.EncodeToString(hash)),
+	))
+	defer span.End()
 
-func (c *Client) GetCache(ctx context.Context, cacheRootDir string) (int64, uint32, error) {
-	objectDir := CacheObjectsDir(cacheRootDir)
-	err := os.MkdirAll(objectDir, 0755)
+	response, err := c.fs.FindContent(ctx, &pb.FindContentRequest{Hash: hash, IncludeHistory: history, Limit: limit})
 	if err != nil {
-		return -1, 0, fmt.Errorf("cannot create object folder: %w", err)
+		return nil, false, fmt.Errorf("find content: %w", err)
 	}
 
-	tmpObjectDir := CacheTmpDir(cacheRootDir)
-	os.RemoveAll(tmpObjectDir)
+	return response.References, response.Truncated, nil
+}
 
-	err = os.MkdirAll(tmpObjectDir, 0755)
-	if err != nil {
-		return -1, 0, fmt.Errorf("cannot create tmp folder to unpack cached objects: %w", err)
-	}
-	defer os.RemoveAll(tmpObjectDir)
+// ContentEncodingStatus reports the encoding the server writes, how many contents are stored in each encoding and
+// the progress of the content re-encodes.
+func (c *Client) ContentEncodingStatus(ctx context.Context) (*pb.ContentEncodingStatusResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.content-encoding-status")
+	defer span.End()
 
-	lockFile, err := obtainCacheLockFile(cacheRootDir)
+	response, err := c.fs.ContentEncodingStatus(ctx, &pb.ContentEncodingStatusRequest{})
 	if err != nil {
-		return -1, 0, err
+		return nil, fmt.Errorf("content encoding status: %w", err)
 	}
-	defer cleanupCacheLockFile(lockFile)
 
-	ctx, span := telemetry.Start(ctx, "client.get_cache")
-	defer span.End()
+	return response, nil
+}
 
-	request := &pb.GetCacheRequest{}
+// CanonicalizePacks rewrites the packs of a project into their canonical form and returns how many were checked and rewritten.
+func (c *Client) CanonicalizePacks(ctx context.Context, project int64, dryRun bool) (int64, int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.canonicalize-packs", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
 
-	stream, err := c.fs.GetCache(ctx, request)
-	if err != nil {
-		return -1, 0, fmt.Errorf("fs.GetCache connect: %w", err)
+	request := &pb.CanonicalizePacksRequest{
+		Project: project,
+		DryRun:  dryRun,
 	}
 
-	// Pull one response before booting workers
-	// This is a short circuit in case the cache doesn't exist
-	response, err := stream.Recv()
-	if err == io.EOF {
-		return -1, 0, nil
-	}
+	response, err := c.fs.CanonicalizePacks(ctx, request)
 	if err != nil {
-		return -1, 0, fmt.Errorf("fs.GetCache receive: %w", err)
+		return 0, 0, fmt.Errorf("canonicalize packs %v: %w", project, err)
 	}
-	version := response.Version
 
-	// We cannot early exist here, even if `version` is already available locally
-	// since we've opened the GRPC stream we need to go ahead and read the whole thing
-	// this should be split into 2 requests, one to get the latest version and another to download it.
+	return response.Checked, response.Rewritten, nil
+}
 
-	tarChan := make(chan *pb.GetCacheResponse, 16)
-	group, ctx := errgroup.WithContext(ctx)
-	ctx, cancel := context.WithCancel(ctx)
+// IndexPacks records the entries of the packs of a project written before packs were indexed and returns how many
+// packs were checked and indexed.
+func (c *Client) IndexPacks(ctx context.Context, project int64, dryRun bool) (int64, int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.index-packs", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
 
-	group.Go(func() error {
-		ctx, span := telemetry.Start(ctx, "cache-object-receiver")
-		defer span.End()
-		defer close(tarChan)
+	request := &pb.IndexPacksRequest{
+		Project: project,
+		DryRun:  dryRun,
+	}
 
-		for {
-			select {
-			case <-ctx.Done():
-				return nil
-			case tarChan <- response:
-				response, err = stream.Recv()
-				if err == io.EOF {
-					return nil
-				}
-				if response.Version != version {
-					cancel()
-					return fmt.Errorf("fs.GetCache version mismatch: %d, %d", response.Version, version)
-				}
-				if err != nil {
-					cancel()
-					return fmt.Errorf("fs.GetCache receive: %w", err)
-				}
-			}
-		}
-	})
+	response, err := c.fs.IndexPacks(ctx, request)
+	if err != nil {
+		return 0, 0, fmt.Errorf("index packs %v: %w", project, err)
+	}
 
-	workerCount := parallelWorkerCount()
-	var writtenObjectCount atomic.Uint32
+	return response.Checked, response.Indexed, nil
+}
 
-	span.SetAttributes(key.WorkerCount.Attribute(workerCount))
+// InlineContents moves the loose contents of a project to where the server's inline threshold stores them and returns
+// how many objects had their content inlined and outlined.
+func (c *Client) InlineContents(ctx context.Context, project int64, dryRun bool) (int64, int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.inline-contents", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
 
-	for i := 0; i < workerCount; i++ {
-		// create the attribute here when `i` is different
-		attr := key.Worker.Attribute(i)
+	request := &pb.InlineContentsRequest{
+		Project: project,
+		DryRun:  dryRun,
+	}
 
-		group.Go(func() error {
-			ctx, span := telemetry.Start(ctx, "cache-object-writer", trace.WithAttributes(attr))
-			defer span.End()
+	response, err := c.fs.InlineContents(ctx, request)
+	if err != nil {
+		return 0, 0, fmt.Errorf("inline contents %v: %w", project, err)
+	}
 
-			tarReader := db.NewTarReader()
+	return response.Inlined, response.Outlined, nil
+}
 
-			for {
-				select {
-				case <-ctx.Done():
-					return ctx.Err()
-				case response, ok := <-tarChan:
-					if !ok {
-						return nil
-					}
+// RepairPaths moves the rows of a project stored under non canonical paths, such as `./foo` or `foo//bar`, to their
+// canonical path and returns every row it found with what was done about it.
+func (c *Client) RepairPaths(ctx context.Context, project int64, dryRun bool) (*pb.RepairPathsResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.repair-paths", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
 
-					tarReader.FromBytes(response.Bytes)
-					hashHex := hex.EncodeToString(response.Hash)
-					tempDest := filepath.Join(tmpObjectDir, hashHex)
-					finalDest := filepath.Join(objectDir, hashHex)
+	request := &pb.RepairPathsRequest{
+		Project: project,
+		DryRun:  dryRun,
+	}
 
-					if fileExists(finalDest) {
-						continue
-					}
+	response, err := c.fs.RepairPaths(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("repair paths %v: %w", project, err)
+	}
 
-					if fileExists(tempDest) {
-						err := os.RemoveAll(tempDest)
-						if err != nil {
-							cancel()
-							return fmt.Errorf("temporary cache folder exists for %s and couldn't be removed: %w", tempDest, err)
-						}
-					}
+	return response, nil
+}
 
-					count, _, err := files.WriteTar(tempDest, CacheObjectsDir(cacheRootDir), tarReader, nil, nil)
-					if err != nil {
-						cancel()
-						return err
-					}
+// ValidatePaths returns up to limit paths stored in a project that the server would now reject, a nil limit uses the server default.
+func (c *Client) ValidatePaths(ctx context.Context, project int64, limit *int64) (*pb.ValidatePathsResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.validate-paths", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
 
-					err = os.Rename(tempDest, finalDest)
-					if err != nil {
-						cancel()
-						return fmt.Errorf("couldn't rename temporary folder (%s) to final folder (%s): %w", tempDest, finalDest, err)
-					}
-					writtenObjectCount.Add(count)
-				}
-			}
-		})
+	request := &pb.ValidatePathsRequest{
+		Project: project,
+		Limit:   limit,
 	}
 
-	err = group.Wait()
+	response, err := c.fs.ValidatePaths(ctx, request)
 	if err != nil {
-		return -1, writtenObjectCount.Load(), err
+		return nil, fmt.Errorf("validate paths %v: %w", project, err)
 	}
 
-	versionFile, err := os.OpenFile(cacheVersionPath(cacheRootDir), os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
-	if err != nil {
-		return -1, writtenObjectCount.Load(), fmt.Errorf("fs.GetCache cannot open cache versions file for writing: %w", err)
-	}
-	defer versionFile.Close()
+	return response, nil
+}
 
-	_, err = versionFile.WriteString(fmt.Sprintf("%d\n", version))
+func (c *Client) CloneToProject(ctx context.Context, source int64, target int64, version int64) (*int64, error) {
+	return c.CloneToProjectWithOptions(ctx, source, target, version, WriteOptions{})
+}
+
+// CloneToProjectWithOptions is like CloneToProject but annotates the cloned version and makes retries idempotent as
+// options asks, the path options of WriteOptions only apply to Update.
+func (c *Client) CloneToProjectWithOptions(ctx context.Context, source int64, target int64, version int64, options WriteOptions) (*int64, error) {
+	ctx, span := telemetry.Start(ctx, "client.clone-to-project", trace.WithAttributes(
+		key.Project.Attribute(source),
+		key.ToVersion.Attribute(&version),
+		key.CloneToProject.Attribute(target),
+	))
+	defer span.End()
+
+	response, err := c.fs.CloneToProject(ctx, &pb.CloneToProjectRequest{
+		Source:         source,
+		Target:         target,
+		Version:        version,
+		Message:        options.Message,
+		Author:         options.Author,
+		IdempotencyKey: options.IdempotencyKey,
+	})
 	if err != nil {
-		return -1, writtenObjectCount.Load(), fmt.Errorf("fs.GetCache failed to update the versions file: %w", err)
+		return nil, fmt.Errorf("clone to project: %w", err)
 	}
 
-	return version, writtenObjectCount.Load(), nil
+	return &response.LatestVersion, nil
 }
 
-func (c *Client) GcProject(ctx context.Context, project int64, keep int64, from *int64) (int64, error) {
-	ctx, span := telemetry.Start(ctx, "client.gc-project", trace.WithAttributes(
+// History returns the annotated versions of a project within the version range, newest first.
+func (c *Client) History(ctx context.Context, project int64, vrange VersionRange) ([]*pb.VersionAnnotation, error) {
+	ctx, span := telemetry.Start(ctx, "client.history", trace.WithAttributes(
 		key.Project.Attribute(project),
+		key.FromVersion.Attribute(vrange.From),
+		key.ToVersion.Attribute(vrange.To),
 	))
 	defer span.End()
 
-	request := &pb.GcProjectRequest{
-		Project:      project,
-		KeepVersions: keep,
-		FromVersion:  from,
-	}
-
-	response, err := c.fs.GcProject(ctx, request)
+	response, err := c.fs.History(ctx, &pb.HistoryRequest{
+		Project:     project,
+		FromVersion: vrange.From,
+		ToVersion:   vrange.To,
+	})
 	if err != nil {
-		return 0, fmt.Errorf("gc project %v: %w", project, err)
+		return nil, fmt.Errorf("fs.History: %w", err)
 	}
 
-	return response.Count, nil
+	return response.Versions, nil
 }
 
-func (c *Client) GcRandomProjects(ctx context.Context, sample float32, keep int64, from *int64) (int64, error) {
-	ctx, span := telemetry.Start(ctx, "client.gc-random-project", trace.WithAttributes(
-		key.SampleRate.Attribute(sample),
-	))
+// ListArchives returns the archives of a project, or of every project when project is nil, newest first.
+func (c *Client) ListArchives(ctx context.Context, project *int64) ([]*pb.Archive, error) {
+	ctx, span := telemetry.Start(ctx, "client.list-archives")
 	defer span.End()
 
-	request := &pb.GcRandomProjectsRequest{
-		Sample:       sample,
-		KeepVersions: keep,
-		FromVersion:  from,
-	}
-
-	response, err := c.fs.GcRandomProjects(ctx, request)
+	response, err := c.fs.ListArchives(ctx, &pb.ListArchivesRequest{Project: project})
 	if err != nil {
-		return 0, fmt.Errorf("gc random projects %v: %w", sample, err)
+		return nil, fmt.Errorf("fs.ListArchives: %w", err)
 	}
 
-	return response.Count, nil
+	return response.Archives, nil
 }
 
-func (c *Client) GcContents(ctx context.Context, sample float32) (int64, error) {
-	ctx, span := telemetry.Start(ctx, "client.gc-contents", trace.WithAttributes(
-		key.SampleRate.Attribute(sample),
+// RestoreArchive recreates the project version of an archive in a project that does not exist, the archived project
+// when project is nil.
+func (c *Client) RestoreArchive(ctx context.Context, archive int64, project *int64) (*pb.RestoreArchiveResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.restore-archive", trace.WithAttributes(
+		key.Archive.Attribute(archive),
 	))
 	defer span.End()
 
-	request := &pb.GcContentsRequest{
-		Sample: sample,
-	}
-
-	response, err := c.fs.GcContents(ctx, request)
+	response, err := c.fs.RestoreArchive(ctx, &pb.RestoreArchiveRequest{Archive: archive, Project: project})
 	if err != nil {
-		return 0, fmt.Errorf("gc contents %v: %w", sample, err)
+		return nil, fmt.Errorf("restore archive %v: %w", archive, err)
 	}
 
-	return response.Count, nil
+	return response, nil
 }
 
-func (c *Client) CloneToProject(ctx context.Context, source int64, target int64, version int64) (*int64, error) {
-	ctx, span := telemetry.Start(ctx, "client.clone-to-project", trace.WithAttributes(
+// FanOutUpdate applies the changes made to the source project between two versions onto every target project.
+// The progress callback, if set, is called as soon as each target has been processed.
+func (c *Client) FanOutUpdate(ctx context.Context, source int64, from int64, to *int64, targets []int64, progress func(*pb.FanOutUpdateResponse)) ([]*pb.FanOutUpdateResponse, error) {
+	ctx, span := telemetry.Start(ctx, "client.fan-out-update", trace.WithAttributes(
 		key.Project.Attribute(source),
-		key.ToVersion.Attribute(&version),
-		key.CloneToProject.Attribute(target),
+		key.FromVersion.Attribute(&from),
+		key.ToVersion.Attribute(to),
+		key.Targets.Attribute(targets),
 	))
 	defer span.End()
 
-	response, err := c.fs.CloneToProject(ctx, &pb.CloneToProjectRequest{
-		Source:  source,
-		Target:  target,
-		Version: version,
+	stream, err := c.fs.FanOutUpdate(ctx, &pb.FanOutUpdateRequest{
+		Source:      source,
+		FromVersion: from,
+		ToVersion:   to,
+		Targets:     targets,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("clone to project: %w", err)
+		return nil, fmt.Errorf("connect fs.FanOutUpdate: %w", err)
 	}
 
-	return &response.LatestVersion, nil
+	var results []*pb.FanOutUpdateResponse
+
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return results, fmt.Errorf("receive fs.FanOutUpdate: %w", err)
+		}
+
+		if progress != nil {
+			progress(response)
+		}
+		results = append(results, response)
+	}
+
+	return results, nil
 }
 
 func NewCachedClient(ctx context.Context, host string, port uint16, opts ...func(*options)) (*CachedClient, error) {
@@ -2,10 +2,14 @@ package client
 
 import (
 	"context"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
 )
 
 type clientCtxKey struct{}
 type cachedClientCtxKey struct{}
+type readSnapshotCtxKey struct{}
+type transformsCtxKey struct{}
 
 func FromContext(ctx context.Context) *Client {
 	client, ok := ctx.Value(clientCtxKey{}).(*Client)
@@ -30,3 +34,31 @@ func CachedFromContext(ctx context.Context) *CachedClient {
 func CachedIntoContext(ctx context.Context, client *CachedClient) context.Context {
 	return context.WithValue(ctx, cachedClientCtxKey{}, client)
 }
+
+// WithReadSnapshot pins the Get, GetUnary, StatPaths and Rebuild calls made with the returned context
+// for the snapshot's project to the snapshot's version. Calls for other projects are not affected.
+func WithReadSnapshot(ctx context.Context, snapshot *pb.ReadSnapshotResponse) context.Context {
+	return context.WithValue(ctx, readSnapshotCtxKey{}, snapshot)
+}
+
+func snapshotToken(ctx context.Context, project int64) *string {
+	snapshot, ok := ctx.Value(readSnapshotCtxKey{}).(*pb.ReadSnapshotResponse)
+	if !ok || snapshot == nil || snapshot.Project != project {
+		return nil
+	}
+	return &snapshot.Token
+}
+
+// WithTransforms applies the project's checkout transforms to the files written by the Rebuild calls made with the
+// returned context, vars are the values of their template placeholders.
+func WithTransforms(ctx context.Context, vars map[string]string) context.Context {
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	return context.WithValue(ctx, transformsCtxKey{}, vars)
+}
+
+func transformVars(ctx context.Context) (bool, map[string]string) {
+	vars, ok := ctx.Value(transformsCtxKey{}).(map[string]string)
+	return ok, vars
+}
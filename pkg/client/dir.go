@@ -2,12 +2,15 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/gadget-inc/dateilager/internal/files"
 	"github.com/gadget-inc/dateilager/internal/key"
 	"github.com/gadget-inc/dateilager/internal/telemetry"
 	fsdiff "github.com/gadget-inc/fsdiff/pkg/diff"
@@ -21,9 +24,24 @@ var (
 	versionFile   = filepath.Join(metadataDir, "version")
 	summaryFile   = filepath.Join(metadataDir, "sum.s2")
 	diffFile      = filepath.Join(metadataDir, "diff.s2")
-	fsdiffIgnores = []string{metadataDir, versionFile, summaryFile, diffFile}
+	sparseFile    = filepath.Join(metadataDir, "sparse")
+	omittedFile   = filepath.Join(metadataDir, "omitted")
+	fsdiffIgnores = []string{metadataDir, versionFile, summaryFile, diffFile, sparseFile, omittedFile}
 )
 
+var (
+	// ErrCorruptVersionFile is returned when a directory's version file cannot be read or does not hold a valid version.
+	ErrCorruptVersionFile = errors.New("corrupt version file")
+	// ErrMissingVersionFile is returned when a directory holds metadata from a previous rebuild but no version file.
+	ErrMissingVersionFile = errors.New("missing version file")
+)
+
+// IsCorruptMetadata reports whether err comes from a directory whose metadata can no longer be trusted,
+// such a directory can only be recovered by rebuilding it from scratch with ForceFullRebuild.
+func IsCorruptMetadata(err error) bool {
+	return errors.Is(err, ErrCorruptVersionFile) || errors.Is(err, ErrMissingVersionFile)
+}
+
 func ensureMetadataDir(dir string) error {
 	path := filepath.Join(dir, metadataDir)
 	err := os.MkdirAll(path, 0775)
@@ -37,6 +55,11 @@ func CacheObjectsDir(cacheRootDir string) string {
 	return filepath.Join(cacheRootDir, "objects")
 }
 
+// CacheLooseObjectsDir holds regular file contents by hash, shared between rebuilds of every project.
+func CacheLooseObjectsDir(cacheRootDir string) string {
+	return filepath.Join(cacheRootDir, "loose")
+}
+
 func CacheTmpDir(cacheRootDir string) string {
 	return filepath.Join(cacheRootDir, "tmp")
 }
@@ -64,19 +87,28 @@ func ReadCacheVersionFile(cacheRootDir string) []int64 {
 	return availableVersions
 }
 
+// ReadVersionFile returns the version dir was last rebuilt or updated to, 0 for a directory that was never rebuilt.
 func ReadVersionFile(dir string) (int64, error) {
 	path := filepath.Join(dir, versionFile)
 	bytes, err := os.ReadFile(path)
 	if os.IsNotExist(err) {
+		// a summary without a version means the version file was lost, the files cannot be matched to a version anymore
+		_, statErr := os.Stat(filepath.Join(dir, summaryFile))
+		if statErr == nil {
+			return -1, fmt.Errorf("%w: %v has a summary but no version", ErrMissingVersionFile, filepath.Join(dir, metadataDir))
+		}
 		return 0, nil
 	}
 	if err != nil {
-		return -1, fmt.Errorf("cannot read version file %v: %w", path, err)
+		return -1, fmt.Errorf("%w: cannot read %v: %w", ErrCorruptVersionFile, path, err)
 	}
 
 	version, err := strconv.ParseInt(strings.TrimSpace(string(bytes)), 10, 64)
 	if err != nil {
-		return -1, fmt.Errorf("cannot convert version to int64 %v: %w", string(bytes), err)
+		return -1, fmt.Errorf("%w: cannot convert %v to int64 %q: %w", ErrCorruptVersionFile, path, string(bytes), err)
+	}
+	if version < 0 {
+		return -1, fmt.Errorf("%w: negative version %v in %v", ErrCorruptVersionFile, version, path)
 	}
 	return version, nil
 }
@@ -95,7 +127,73 @@ func WriteVersionFile(dir string, version int64) error {
 	return nil
 }
 
+// ReadSparseProfile returns the sparse checkout profile of a directory, or nil if there is none.
+func ReadSparseProfile(dir string) (*files.SparseProfile, error) {
+	path := filepath.Join(dir, sparseFile)
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read sparse profile %v: %w", path, err)
+	}
+
+	profile, err := files.NewSparseProfile(strings.Split(string(bytes), "\n"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid sparse profile %v: %w", path, err)
+	}
+	return profile, nil
+}
+
+// ReadOmittedFile returns the paths whose content was too large to be sent during previous rebuilds.
+func ReadOmittedFile(dir string) ([]string, error) {
+	path := filepath.Join(dir, omittedFile)
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read omitted file %v: %w", path, err)
+	}
+
+	var paths []string
+	for _, line := range strings.Split(string(bytes), "\n") {
+		if line != "" {
+			paths = append(paths, line)
+		}
+	}
+	return paths, nil
+}
+
+func WriteOmittedFile(dir string, paths []string) error {
+	path := filepath.Join(dir, omittedFile)
+	if len(paths) == 0 {
+		err := os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("cannot remove omitted file %v: %w", path, err)
+		}
+		return nil
+	}
+
+	err := ensureMetadataDir(dir)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(path, []byte(strings.Join(paths, "\n")+"\n"), 0755)
+	if err != nil {
+		return fmt.Errorf("cannot write omitted file to %v: %w", path, err)
+	}
+	return nil
+}
+
 func DiffAndSummarize(ctx context.Context, dir string) (*fsdiff_pb.Diff, error) {
+	return diffAndSummarize(ctx, dir, nil)
+}
+
+// diffAndSummarize only returns and records the changes of paths matched by filter when it is not nil.
+// Changes of other paths stay pending and are returned again by the next diff.
+func diffAndSummarize(ctx context.Context, dir string, filter *files.SparseProfile) (*fsdiff_pb.Diff, error) {
 	_, span := telemetry.Start(ctx, "diff-and-summarize", trace.WithAttributes(key.Directory.Attribute(dir)))
 	defer span.End()
 
@@ -115,11 +213,16 @@ func DiffAndSummarize(ctx context.Context, dir string) (*fsdiff_pb.Diff, error)
 		summary = &fsdiff_pb.Summary{}
 	}
 
-	diff, summary, err := fsdiff.Diff(dir, fsdiffIgnores, summary)
+	diff, newSummary, err := fsdiff.Diff(dir, fsdiffIgnores, summary)
 	if err != nil {
 		return nil, fmt.Errorf("fsdiff error: %w", err)
 	}
 
+	if filter != nil {
+		deferUnmatched(diff, summary, newSummary, filter)
+	}
+	summary = newSummary
+
 	err = fsdiff.WriteSummary(path, summary)
 	if err != nil {
 		return nil, fmt.Errorf("cannot write summary file to %v: %w", path, err)
@@ -127,3 +230,170 @@ func DiffAndSummarize(ctx context.Context, dir string) (*fsdiff_pb.Diff, error)
 
 	return diff, nil
 }
+
+// stageDir prepares a hidden sibling of dir holding the same files, hardlinked so that staging is cheap.
+// Metadata files are copied instead since they are rewritten in place.
+// An empty staging directory is returned when dir does not exist yet.
+func stageDir(dir string) (string, error) {
+	dir = filepath.Clean(dir)
+
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return emptyStageDir(dir)
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot stat %v: %w", dir, err)
+	}
+
+	staging, err := os.MkdirTemp(filepath.Dir(dir), "."+filepath.Base(dir)+".dl-staging-")
+	if err != nil {
+		return "", fmt.Errorf("cannot create staging dir for %v: %w", dir, err)
+	}
+
+	err = files.HardlinkDir(dir, staging)
+	if err == nil {
+		err = unshareMetadataFiles(staging)
+	}
+	if err == nil {
+		err = os.Chmod(staging, info.Mode().Perm())
+	}
+	if err != nil {
+		os.RemoveAll(staging)
+		return "", err
+	}
+
+	return staging, nil
+}
+
+// emptyStageDir prepares an empty hidden sibling of dir to rebuild it from scratch.
+func emptyStageDir(dir string) (string, error) {
+	dir = filepath.Clean(dir)
+
+	staging, err := os.MkdirTemp(filepath.Dir(dir), "."+filepath.Base(dir)+".dl-staging-")
+	if err != nil {
+		return "", fmt.Errorf("cannot create staging dir for %v: %w", dir, err)
+	}
+
+	err = os.Chmod(staging, 0775)
+	if err != nil {
+		os.RemoveAll(staging)
+		return "", fmt.Errorf("cannot chmod staging dir %v: %w", staging, err)
+	}
+
+	return staging, nil
+}
+
+// swapStagedDir moves the staging copy into place, afterwards staging holds the previous tree if there was one.
+func swapStagedDir(staging string, dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		err = os.Rename(staging, dir)
+		if err != nil {
+			return fmt.Errorf("cannot move staging dir %v to %v: %w", staging, dir, err)
+		}
+		return nil
+	}
+
+	return files.ExchangeDirs(staging, dir)
+}
+
+func unshareMetadataFiles(dir string) error {
+	path := filepath.Join(dir, metadataDir)
+	entries, err := os.ReadDir(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read metadata dir %v: %w", path, err)
+	}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		filePath := filepath.Join(path, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("cannot stat metadata file %v: %w", filePath, err)
+		}
+
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("cannot read metadata file %v: %w", filePath, err)
+		}
+
+		err = os.Remove(filePath)
+		if err != nil {
+			return fmt.Errorf("cannot unlink metadata file %v: %w", filePath, err)
+		}
+
+		err = os.WriteFile(filePath, content, info.Mode().Perm())
+		if err != nil {
+			return fmt.Errorf("cannot write metadata file %v: %w", filePath, err)
+		}
+	}
+
+	return nil
+}
+
+// deferUnmatched drops the updates of paths not matched by filter and rewinds their summary entries,
+// added and changed paths are forgotten and removed paths are restored so the next diff reports them again.
+// The latest mod time is left alone as the restored entries are never walked.
+func deferUnmatched(diff *fsdiff_pb.Diff, previous *fsdiff_pb.Summary, summary *fsdiff_pb.Summary, filter *files.SparseProfile) {
+	skipped := make(map[string]fsdiff_pb.Update_Action)
+	diff.Updates = slices.DeleteFunc(diff.Updates, func(update *fsdiff_pb.Update) bool {
+		if filter.Match(update.Path) {
+			return false
+		}
+		skipped[update.Path] = update.Action
+		return true
+	})
+
+	if len(skipped) == 0 {
+		return
+	}
+
+	var restored []*fsdiff_pb.Entry
+	for _, entry := range previous.Entries {
+		if action, ok := skipped[entry.Path]; ok && action == fsdiff_pb.Update_REMOVE {
+			restored = append(restored, entry)
+		}
+	}
+
+	entries := slices.DeleteFunc(summary.Entries, func(entry *fsdiff_pb.Entry) bool {
+		action, ok := skipped[entry.Path]
+		return ok && action != fsdiff_pb.Update_REMOVE
+	})
+
+	// Both lists are in walk order, merge them to keep the summary sorted
+	merged := make([]*fsdiff_pb.Entry, 0, len(entries)+len(restored))
+	for len(entries) > 0 && len(restored) > 0 {
+		if summaryPathLess(restored[0].Path, entries[0].Path) {
+			merged = append(merged, restored[0])
+			restored = restored[1:]
+		} else {
+			merged = append(merged, entries[0])
+			entries = entries[1:]
+		}
+	}
+	merged = append(merged, entries...)
+	summary.Entries = append(merged, restored...)
+}
+
+// summaryPathLess orders paths the way fsdiff walks and summarizes them, component by component.
+func summaryPathLess(left, right string) bool {
+	leftSplits := strings.Split(left, fsdiff.Separator)
+	rightSplits := strings.Split(right, fsdiff.Separator)
+
+	for idx, leftSplit := range leftSplits {
+		if idx >= len(rightSplits) {
+			return false
+		}
+
+		if leftSplit != rightSplits[idx] {
+			return leftSplit < rightSplits[idx]
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadVersionFile(t *testing.T) {
+	dir := t.TempDir()
+
+	version, err := ReadVersionFile(dir)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), version, "a directory that was never rebuilt is at version 0")
+
+	require.NoError(t, WriteVersionFile(dir, 3))
+	version, err = ReadVersionFile(dir)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), version)
+}
+
+func TestReadVersionFileCorrupt(t *testing.T) {
+	for name, contents := range map[string]string{
+		"garbage":  "not a version",
+		"empty":    "",
+		"negative": "-2",
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, ensureMetadataDir(dir))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, versionFile), []byte(contents), 0755))
+
+			_, err := ReadVersionFile(dir)
+			assert.ErrorIs(t, err, ErrCorruptVersionFile)
+			assert.True(t, IsCorruptMetadata(err))
+		})
+	}
+}
+
+func TestReadVersionFileMissing(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ensureMetadataDir(dir))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, summaryFile), nil, 0755))
+
+	_, err := ReadVersionFile(dir)
+	assert.ErrorIs(t, err, ErrMissingVersionFile)
+	assert.True(t, IsCorruptMetadata(err))
+}
+
+func TestReadVersionFileMetadataNotADir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, metadataDir), []byte("oops"), 0755))
+
+	_, err := ReadVersionFile(dir)
+	assert.ErrorIs(t, err, ErrCorruptVersionFile)
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/gadget-inc/dateilager/internal/files"
+)
+
+type durabilityCtxKey struct{}
+
+type durability struct {
+	level     files.Durability
+	batchSize int
+}
+
+// WithDurability sets how the files written by the Rebuild calls made with the returned context are flushed to disk,
+// batchSize is how many files are written between flushes with files.DurabilityBatched, 0 or less for the default.
+// It takes precedence over the DL_DURABILITY and DL_SYNC_BATCH_SIZE environment variables.
+func WithDurability(ctx context.Context, level files.Durability, batchSize int) context.Context {
+	return context.WithValue(ctx, durabilityCtxKey{}, durability{level: level, batchSize: batchSize})
+}
+
+// newSyncer returns the syncer for a checkout into dir, checkouts are flushed in batches unless asked otherwise.
+func newSyncer(ctx context.Context, dir string) *files.Syncer {
+	if d, ok := ctx.Value(durabilityCtxKey{}).(durability); ok {
+		return files.NewSyncer(dir, d.level, d.batchSize)
+	}
+
+	level, err := files.ParseDurability(os.Getenv("DL_DURABILITY"))
+	if err != nil {
+		level = files.DurabilityBatched
+	}
+
+	batchSize, err := strconv.Atoi(os.Getenv("DL_SYNC_BATCH_SIZE"))
+	if err != nil {
+		batchSize = 0
+	}
+
+	return files.NewSyncer(dir, level, batchSize)
+}
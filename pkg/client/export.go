@@ -0,0 +1,207 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ExportRow is the Parquet schema used when exporting project metadata.
+type ExportRow struct {
+	Project     int64   `parquet:"name=project, type=INT64"`
+	Version     int64   `parquet:"name=version, type=INT64"`
+	Path        string  `parquet:"name=path, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Mode        int64   `parquet:"name=mode, type=INT64"`
+	Size        int64   `parquet:"name=size, type=INT64"`
+	Deleted     bool    `parquet:"name=deleted, type=BOOLEAN"`
+	ContentType *string `parquet:"name=content_type, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Content     *string `parquet:"name=content, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+}
+
+type ExportResult struct {
+	Project int64  `json:"project"`
+	Version int64  `json:"version"`
+	Count   int64  `json:"count"`
+	Path    string `json:"path"`
+}
+
+// ExportPartitionDir returns the Hive style partition directory for a project's export files.
+func ExportPartitionDir(dir string, project int64) string {
+	return filepath.Join(dir, fmt.Sprintf("project=%d", project))
+}
+
+func isSmallText(content []byte, maxContentSize int64) bool {
+	if int64(len(content)) > maxContentSize {
+		return false
+	}
+
+	return utf8.Valid(content) && !bytes.Contains(content, []byte{0})
+}
+
+// Export writes the object metadata of every project as Parquet files partitioned by project into the local directory dir,
+// object stores are not written to directly: the directory is meant to be copied there with their own tools.
+// Text contents smaller than or equal to maxContentSize are included, a maxContentSize of 0 disables contents.
+func (c *Client) Export(ctx context.Context, projects []int64, vrange VersionRange, dir string, maxContentSize int64) ([]ExportResult, error) {
+	ctx, span := telemetry.Start(ctx, "client.export", trace.WithAttributes(
+		key.FromVersion.Attribute(vrange.From),
+		key.ToVersion.Attribute(vrange.To),
+		key.Directory.Attribute(dir),
+	))
+	defer span.End()
+
+	if strings.Contains(dir, "://") {
+		return nil, fmt.Errorf("cannot export to %v: only local directories are supported", dir)
+	}
+
+	if len(projects) == 0 {
+		listed, err := c.ListProjects(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, project := range listed {
+			projects = append(projects, project.Id)
+		}
+	}
+
+	var results []ExportResult
+
+	for _, project := range projects {
+		result, err := c.exportProject(ctx, project, vrange, dir, maxContentSize)
+		if err != nil {
+			return results, err
+		}
+
+		if result != nil {
+			results = append(results, *result)
+		}
+	}
+
+	return results, nil
+}
+
+func (c *Client) exportProject(ctx context.Context, project int64, vrange VersionRange, dir string, maxContentSize int64) (*ExportResult, error) {
+	ctx, span := telemetry.Start(ctx, "client.export-project", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
+
+	// the server sends every content when the size is not positive, contents of a single byte are cheap enough to skip
+	// when they are not exported
+	contentSize := max(maxContentSize, 1)
+	if c.maxContentSendSize != nil {
+		contentSize = min(contentSize, *c.maxContentSendSize)
+	}
+
+	request := &pb.GetRequest{
+		Project:     project,
+		FromVersion: vrange.From,
+		ToVersion:   vrange.To,
+		Queries: []*pb.ObjectQuery{{
+			Path:     "",
+			IsPrefix: true,
+		}},
+		MaxContentSendSize: &contentSize,
+	}
+
+	stream, err := c.fs.Get(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("connect fs.Get: %w", err)
+	}
+
+	partitionDir := ExportPartitionDir(dir, project)
+	err = os.MkdirAll(partitionDir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create export dir %v: %w", partitionDir, err)
+	}
+
+	file, err := os.CreateTemp(partitionDir, ".export_")
+	if err != nil {
+		return nil, fmt.Errorf("cannot create export file in %v: %w", partitionDir, err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	parquetWriter, err := writer.NewParquetWriterFromWriter(file, new(ExportRow), 1)
+	if err != nil {
+		return nil, fmt.Errorf("create parquet writer for project %v: %w", project, err)
+	}
+	parquetWriter.CompressionType = parquet.CompressionCodec_ZSTD
+
+	version := int64(-1)
+	count := int64(0)
+
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("receive fs.Get: %w", err)
+		}
+
+		version = response.Version
+		object := response.GetObject()
+
+		row := ExportRow{
+			Project:     project,
+			Version:     response.Version,
+			Path:        object.Path,
+			Mode:        object.Mode,
+			Size:        object.Size,
+			Deleted:     object.Deleted,
+			ContentType: object.ContentType,
+		}
+
+		if maxContentSize > 0 && !object.Deleted && !object.ContentOmitted && isSmallText(object.Content, maxContentSize) {
+			content := string(object.Content)
+			row.Content = &content
+		}
+
+		err = parquetWriter.Write(row)
+		if err != nil {
+			return nil, fmt.Errorf("write parquet row for project %v: %w", project, err)
+		}
+		count += 1
+	}
+
+	// The stream is empty when the project has no objects in the version range, there is nothing to export
+	if count == 0 {
+		return nil, nil
+	}
+
+	err = parquetWriter.WriteStop()
+	if err != nil {
+		return nil, fmt.Errorf("close parquet writer for project %v: %w", project, err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return nil, fmt.Errorf("close export file for project %v: %w", project, err)
+	}
+
+	path := filepath.Join(partitionDir, fmt.Sprintf("v%d.parquet", version))
+	err = os.Rename(file.Name(), path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot rename export file to %v: %w", path, err)
+	}
+
+	return &ExportResult{
+		Project: project,
+		Version: version,
+		Count:   count,
+		Path:    path,
+	}, nil
+}
@@ -0,0 +1,325 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	cacheLockHeartbeat = 10 * time.Second
+	// A holder that has not refreshed its heartbeat for this long is assumed to be gone, even if its flock survived
+	cacheLockStaleAfter = 2 * time.Minute
+)
+
+var ErrCacheLocked = errors.New("cache is locked by another process")
+
+// CacheLockInfo is written into the lock file by the process holding the lock.
+type CacheLockInfo struct {
+	Pid        int       `json:"pid"`
+	Hostname   string    `json:"hostname"`
+	AcquiredAt time.Time `json:"acquiredAt"`
+	Heartbeat  time.Time `json:"heartbeat"`
+}
+
+// Stale reports whether the holder stopped refreshing its heartbeat or, when it runs on this host, no longer exists.
+// A holder that has not written its info yet, or an older client that never does, is assumed alive.
+func (i *CacheLockInfo) Stale(now time.Time) bool {
+	if i.Heartbeat.IsZero() {
+		return false
+	}
+
+	if now.Sub(i.Heartbeat) > cacheLockStaleAfter {
+		return true
+	}
+
+	hostname, _ := os.Hostname()
+	return i.Hostname == hostname && !processAlive(i.Pid)
+}
+
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// CacheLock is an exclusive flock on the cache directory, kept fresh by a heartbeat until Release is called.
+type CacheLock struct {
+	file *os.File
+	size int
+	info CacheLockInfo
+	mu   sync.Mutex
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func cacheLockPath(cacheRootDir string) string {
+	return filepath.Join(cacheRootDir, ".lock")
+}
+
+// LockCache takes the cache lock, a lock held by a stale process is taken over.
+func LockCache(cacheRootDir string) (*CacheLock, error) {
+	path := cacheLockPath(cacheRootDir)
+
+	lock, err := tryLockCache(path)
+	if !errors.Is(err, ErrCacheLocked) {
+		return lock, err
+	}
+
+	// Takeovers are serialized by a second lock file which is never removed, so a process cannot remove the lock file
+	// another process just created when taking over the same stale holder
+	takeover, takeoverErr := os.OpenFile(path+".takeover", os.O_CREATE|os.O_RDWR, 0600)
+	if takeoverErr != nil {
+		return nil, fmt.Errorf("cannot open cache lock takeover file %v: %w", path, takeoverErr)
+	}
+	defer takeover.Close()
+
+	takeoverErr = unix.Flock(int(takeover.Fd()), unix.LOCK_EX)
+	if takeoverErr != nil {
+		return nil, fmt.Errorf("unable to obtain cache lock takeover file %v: %w", path, takeoverErr)
+	}
+
+	// The lock may have been released or taken over while we waited
+	lock, err = tryLockCache(path)
+	if !errors.Is(err, ErrCacheLocked) {
+		return lock, err
+	}
+
+	removed, removeErr := removeStaleLock(path)
+	if removeErr != nil {
+		return nil, removeErr
+	}
+	if !removed {
+		return nil, err
+	}
+
+	return tryLockCache(path)
+}
+
+// removeStaleLock removes the lock file when its holder is stale. The holder is read through the same open file
+// that is removed, so a fresh lock file created in between is left alone.
+func removeStaleLock(path string) (bool, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("cannot open cache lock file %v: %w", path, err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return false, fmt.Errorf("cannot read cache lock file %v: %w", path, err)
+	}
+
+	// A lock file that cannot be decoded is being written by a live holder
+	info, err := decodeCacheLockInfo(content)
+	if err != nil || !info.Stale(time.Now()) {
+		return false, nil
+	}
+
+	same, err := sameFile(file, path)
+	if err != nil || !same {
+		return false, err
+	}
+
+	// The holder is gone but its lock survived (e.g. a hung process or a network filesystem), replace the file so we lock a fresh inode
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return false, fmt.Errorf("cannot remove stale cache lock %v: %w", path, err)
+	}
+
+	return true, nil
+}
+
+// sameFile reports whether path still names the open file, it is false once the file was removed or replaced.
+func sameFile(file *os.File, path string) (bool, error) {
+	var opened, current unix.Stat_t
+
+	err := unix.Fstat(int(file.Fd()), &opened)
+	if err != nil {
+		return false, fmt.Errorf("cannot stat cache lock file %v: %w", path, err)
+	}
+
+	err = unix.Stat(path, &current)
+	if errors.Is(err, unix.ENOENT) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("cannot stat cache lock file %v: %w", path, err)
+	}
+
+	return opened.Dev == current.Dev && opened.Ino == current.Ino, nil
+}
+
+func tryLockCache(path string) (*CacheLock, error) {
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("cannot open cache lock file %v: %w", path, err)
+		}
+
+		err = unix.Flock(int(file.Fd()), unix.LOCK_EX|unix.LOCK_NB)
+		if errors.Is(err, unix.EWOULDBLOCK) {
+			file.Close()
+			return nil, fmt.Errorf("unable to obtain cache lock file %v: %w", path, ErrCacheLocked)
+		}
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("unable to obtain cache lock file %v: %w", path, err)
+		}
+
+		// The file was released or taken over between our open and flock, the flock we got guards nothing
+		same, err := sameFile(file, path)
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		if !same {
+			file.Close()
+			continue
+		}
+
+		stat, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("cannot stat cache lock file %v: %w", path, err)
+		}
+
+		hostname, _ := os.Hostname()
+		now := time.Now()
+		lock := &CacheLock{
+			file: file,
+			size: int(stat.Size()),
+			info: CacheLockInfo{Pid: os.Getpid(), Hostname: hostname, AcquiredAt: now, Heartbeat: now},
+			done: make(chan struct{}),
+		}
+
+		err = lock.writeInfo(now)
+		if err != nil {
+			lock.Release()
+			return nil, err
+		}
+
+		lock.wg.Add(1)
+		go lock.heartbeat()
+
+		return lock, nil
+	}
+}
+
+func (l *CacheLock) writeInfo(heartbeat time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.info.Heartbeat = heartbeat
+
+	encoded, err := json.Marshal(l.info)
+	if err != nil {
+		return fmt.Errorf("cannot encode cache lock info: %w", err)
+	}
+
+	// The file is overwritten in place and never truncated, which would let others read it empty. Shorter info is
+	// padded with spaces over the previous one, JSON ignores them.
+	if len(encoded) < l.size {
+		encoded = append(encoded, bytes.Repeat([]byte(" "), l.size-len(encoded))...)
+	}
+
+	_, err = l.file.WriteAt(encoded, 0)
+	if err != nil {
+		return fmt.Errorf("cannot write cache lock file: %w", err)
+	}
+	l.size = len(encoded)
+
+	return nil
+}
+
+func (l *CacheLock) heartbeat() {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(cacheLockHeartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.done:
+			return
+		case now := <-ticker.C:
+			// A failed heartbeat only makes the lock look stale sooner, the flock is still held
+			_ = l.writeInfo(now)
+		}
+	}
+}
+
+// Release stops the heartbeat, removes the lock file and drops the flock. The file is removed while still locked,
+// processes that opened it before then notice it is gone once they get the flock.
+func (l *CacheLock) Release() {
+	select {
+	case <-l.done:
+		return
+	default:
+		close(l.done)
+	}
+	l.wg.Wait()
+
+	os.Remove(l.file.Name())
+	_ = unix.Flock(int(l.file.Fd()), unix.LOCK_UN)
+	l.file.Close()
+}
+
+// ReadCacheLockInfo returns the metadata of the current lock holder, nil when the cache is not locked.
+func ReadCacheLockInfo(cacheRootDir string) (*CacheLockInfo, error) {
+	content, err := os.ReadFile(cacheLockPath(cacheRootDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read cache lock file: %w", err)
+	}
+
+	return decodeCacheLockInfo(content)
+}
+
+func decodeCacheLockInfo(content []byte) (*CacheLockInfo, error) {
+	var info CacheLockInfo
+	// Lock files written by older clients, or by a holder that has not written its info yet, are empty
+	if len(bytes.TrimSpace(content)) > 0 {
+		err := json.Unmarshal(content, &info)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode cache lock file: %w", err)
+		}
+	}
+
+	return &info, nil
+}
+
+// UnlockCache removes the cache lock file. A lock whose holder is still alive is only removed with force.
+func UnlockCache(cacheRootDir string, force bool) (*CacheLockInfo, error) {
+	info, err := ReadCacheLockInfo(cacheRootDir)
+	if err != nil || info == nil {
+		return info, err
+	}
+
+	if !force && !info.Stale(time.Now()) {
+		return info, fmt.Errorf("%w: pid %v on %v, last heartbeat %v", ErrCacheLocked, info.Pid, info.Hostname, info.Heartbeat.Format(time.RFC3339))
+	}
+
+	err = os.Remove(cacheLockPath(cacheRootDir))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return info, fmt.Errorf("cannot remove cache lock file: %w", err)
+	}
+
+	return info, nil
+}
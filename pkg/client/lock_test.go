@@ -0,0 +1,107 @@
+package client
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+// holdCacheLock flocks the lock file like a holder in another process would and writes info into it.
+func holdCacheLock(t *testing.T, dir string, info *CacheLockInfo) *os.File {
+	file, err := os.OpenFile(cacheLockPath(dir), os.O_CREATE|os.O_RDWR, 0600)
+	require.NoError(t, err)
+	require.NoError(t, unix.Flock(int(file.Fd()), unix.LOCK_EX|unix.LOCK_NB))
+
+	if info != nil {
+		encoded, err := json.Marshal(info)
+		require.NoError(t, err)
+		_, err = file.WriteAt(encoded, 0)
+		require.NoError(t, err)
+	}
+
+	return file
+}
+
+func TestLockCacheHeldWithoutInfo(t *testing.T) {
+	dir := t.TempDir()
+
+	// a holder between its flock and its first write
+	holder := holdCacheLock(t, dir, nil)
+	defer holder.Close()
+
+	_, err := LockCache(dir)
+	assert.ErrorIs(t, err, ErrCacheLocked, "an empty lock file is held")
+
+	_, err = os.Stat(cacheLockPath(dir))
+	assert.NoError(t, err, "the held lock file is left in place")
+}
+
+func TestLockCacheConcurrentTakeover(t *testing.T) {
+	dir := t.TempDir()
+
+	holder := holdCacheLock(t, dir, &CacheLockInfo{Pid: -1, Heartbeat: time.Now().Add(-time.Hour)})
+	defer holder.Close()
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		locks []*CacheLock
+	)
+
+	for idx := 0; idx < 16; idx++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			lock, err := LockCache(dir)
+			if err != nil {
+				assert.ErrorIs(t, err, ErrCacheLocked)
+				return
+			}
+
+			mu.Lock()
+			locks = append(locks, lock)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, locks, 1, "exactly one process takes over the stale lock")
+
+	info, err := ReadCacheLockInfo(dir)
+	require.NoError(t, err)
+	require.NotNil(t, info)
+	assert.Equal(t, os.Getpid(), info.Pid)
+	assert.False(t, info.Stale(time.Now()))
+
+	locks[0].Release()
+
+	lock, err := LockCache(dir)
+	require.NoError(t, err, "the lock is free once released")
+	lock.Release()
+}
+
+func TestCacheLockInfoShrinks(t *testing.T) {
+	dir := t.TempDir()
+
+	lock, err := LockCache(dir)
+	require.NoError(t, err)
+	defer lock.Release()
+
+	lock.info.Hostname = "a-much-longer-hostname-than-the-real-one"
+	require.NoError(t, lock.writeInfo(time.Now()))
+
+	hostname, _ := os.Hostname()
+	lock.info.Hostname = hostname
+	require.NoError(t, lock.writeInfo(time.Now()))
+
+	info, err := ReadCacheLockInfo(dir)
+	require.NoError(t, err, "shorter info is still decoded")
+	assert.Equal(t, hostname, info.Hostname)
+}
@@ -0,0 +1,344 @@
+package client
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ociLayoutVersion      = "1.0.0"
+	ociManifestMediaType  = "application/vnd.oci.image.manifest.v1+json"
+	ociConfigMediaType    = "application/vnd.oci.image.config.v1+json"
+	ociLayerMediaType     = "application/vnd.oci.image.layer.v1.tar+gzip"
+	ociRefNameAnnotation  = "org.opencontainers.image.ref.name"
+	ociProjectAnnotation  = "com.gadget.dateilager.project"
+	ociVersionAnnotation  = "com.gadget.dateilager.version"
+	ociLayoutFile         = "oci-layout"
+	ociIndexFile          = "index.json"
+	ociBlobsDir           = "blobs/sha256"
+	ociHistoryCreatedBy   = "dateilager export-oci"
+	ociDefaultOS          = "linux"
+	ociDigestAlgorithmSep = "sha256:"
+)
+
+var ErrInvalidOCILayout = errors.New("invalid OCI image layout")
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type ociManifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	Config        ociDescriptor     `json:"config"`
+	Layers        []ociDescriptor   `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+type ociIndex struct {
+	SchemaVersion int             `json:"schemaVersion"`
+	MediaType     string          `json:"mediaType,omitempty"`
+	Manifests     []ociDescriptor `json:"manifests"`
+}
+
+type OCIExportResult struct {
+	Project        int64  `json:"project"`
+	Version        int64  `json:"version"`
+	Count          uint32 `json:"count"`
+	LayerDigest    string `json:"layerDigest"`
+	LayerDiffID    string `json:"layerDiffId"`
+	LayerSize      int64  `json:"layerSize"`
+	ManifestDigest string `json:"manifestDigest"`
+}
+
+func ociDigest(h hash.Hash) string {
+	return ociDigestAlgorithmSep + hex.EncodeToString(h.Sum(nil))
+}
+
+func ociBlobPath(layoutDir string, digest string) (string, error) {
+	if len(digest) <= len(ociDigestAlgorithmSep) || digest[:len(ociDigestAlgorithmSep)] != ociDigestAlgorithmSep {
+		return "", fmt.Errorf("%w: unsupported digest %v", ErrInvalidOCILayout, digest)
+	}
+	return filepath.Join(layoutDir, ociBlobsDir, digest[len(ociDigestAlgorithmSep):]), nil
+}
+
+// writeOCIBlob stores content in the layout's blob directory and returns its descriptor.
+func writeOCIBlob(layoutDir string, mediaType string, content []byte) (ociDescriptor, error) {
+	sum := sha256.Sum256(content)
+	digest := ociDigestAlgorithmSep + hex.EncodeToString(sum[:])
+
+	path, err := ociBlobPath(layoutDir, digest)
+	if err != nil {
+		return ociDescriptor{}, err
+	}
+
+	err = os.WriteFile(path, content, 0644)
+	if err != nil {
+		return ociDescriptor{}, fmt.Errorf("cannot write OCI blob %v: %w", path, err)
+	}
+
+	return ociDescriptor{MediaType: mediaType, Digest: digest, Size: int64(len(content))}, nil
+}
+
+func readOCIJSON(path string, value any) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("cannot read %v: %w", path, err)
+	}
+
+	err = json.Unmarshal(content, value)
+	if err != nil {
+		return fmt.Errorf("%w: cannot decode %v: %v", ErrInvalidOCILayout, path, err)
+	}
+	return nil
+}
+
+// copyOCIBlob copies a blob from the base layout into the output layout, hardlinking it when possible.
+func copyOCIBlob(baseDir string, layoutDir string, digest string) error {
+	source, err := ociBlobPath(baseDir, digest)
+	if err != nil {
+		return err
+	}
+
+	target, err := ociBlobPath(layoutDir, digest)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(target); err == nil {
+		return nil
+	}
+
+	if os.Link(source, target) == nil {
+		return nil
+	}
+
+	in, err := os.Open(source)
+	if err != nil {
+		return fmt.Errorf("cannot open base blob %v: %w", source, err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(target)
+	if err != nil {
+		return fmt.Errorf("cannot create OCI blob %v: %w", target, err)
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		return fmt.Errorf("cannot copy base blob %v: %w", source, err)
+	}
+	return out.Close()
+}
+
+// readBaseImage returns the manifest and config of the single image stored in the OCI layout at baseDir.
+func readBaseImage(baseDir string) (*ociManifest, map[string]any, error) {
+	var index ociIndex
+	err := readOCIJSON(filepath.Join(baseDir, ociIndexFile), &index)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(index.Manifests) != 1 || index.Manifests[0].MediaType != ociManifestMediaType {
+		return nil, nil, fmt.Errorf("%w: base %v must hold exactly one image manifest, found %d manifests", ErrInvalidOCILayout, baseDir, len(index.Manifests))
+	}
+
+	manifestPath, err := ociBlobPath(baseDir, index.Manifests[0].Digest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var manifest ociManifest
+	err = readOCIJSON(manifestPath, &manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configPath, err := ociBlobPath(baseDir, manifest.Config.Digest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var config map[string]any
+	err = readOCIJSON(configPath, &config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &manifest, config, nil
+}
+
+// ExportOCI writes a project version as a gzip tar layer into an OCI image layout at layoutDir. When baseDir is set it
+// must be an OCI image layout holding a single image, the layer is appended to that image's layers. The resulting image
+// is tagged ref in the layout's index when ref is set, tools such as skopeo or crane can then push it to a registry.
+func (c *Client) ExportOCI(ctx context.Context, project int64, prefix string, toVersion *int64, layoutDir string, baseDir string, ref string) (OCIExportResult, error) {
+	ctx, span := telemetry.Start(ctx, "client.export-oci", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Prefix.Attribute(prefix),
+		key.ToVersion.Attribute(toVersion),
+		key.Directory.Attribute(layoutDir),
+	))
+	defer span.End()
+
+	result := OCIExportResult{Project: project}
+
+	blobsDir := filepath.Join(layoutDir, ociBlobsDir)
+	err := os.MkdirAll(blobsDir, 0755)
+	if err != nil {
+		return result, fmt.Errorf("cannot create OCI blobs dir %v: %w", blobsDir, err)
+	}
+
+	manifest := &ociManifest{}
+	config := map[string]any{
+		"architecture": runtime.GOARCH,
+		"os":           ociDefaultOS,
+		"rootfs":       map[string]any{"type": "layers", "diff_ids": []any{}},
+	}
+
+	if baseDir != "" {
+		manifest, config, err = readBaseImage(baseDir)
+		if err != nil {
+			return result, err
+		}
+
+		for _, descriptor := range append([]ociDescriptor{manifest.Config}, manifest.Layers...) {
+			err = copyOCIBlob(baseDir, layoutDir, descriptor.Digest)
+			if err != nil {
+				return result, err
+			}
+		}
+	}
+
+	layerFile, err := os.CreateTemp(blobsDir, ".layer_")
+	if err != nil {
+		return result, fmt.Errorf("cannot create OCI layer in %v: %w", blobsDir, err)
+	}
+	defer os.Remove(layerFile.Name())
+	defer layerFile.Close()
+
+	diffHash := sha256.New()
+	digestHash := sha256.New()
+	compressed := &countingWriter{w: io.MultiWriter(layerFile, digestHash)}
+	gzipWriter := gzip.NewWriter(compressed)
+
+	rebuilt, err := c.RebuildTar(ctx, project, prefix, nil, toVersion, io.MultiWriter(gzipWriter, diffHash), false)
+	if err != nil {
+		return result, err
+	}
+
+	err = gzipWriter.Close()
+	if err != nil {
+		return result, fmt.Errorf("close gzip writer: %w", err)
+	}
+
+	err = layerFile.Close()
+	if err != nil {
+		return result, fmt.Errorf("close OCI layer %v: %w", layerFile.Name(), err)
+	}
+
+	result.Version = rebuilt.Version
+	result.Count = rebuilt.Count
+	result.LayerDigest = ociDigest(digestHash)
+	result.LayerDiffID = ociDigest(diffHash)
+	result.LayerSize = compressed.count
+
+	layerPath, err := ociBlobPath(layoutDir, result.LayerDigest)
+	if err != nil {
+		return result, err
+	}
+
+	err = os.Rename(layerFile.Name(), layerPath)
+	if err != nil {
+		return result, fmt.Errorf("cannot rename OCI layer to %v: %w", layerPath, err)
+	}
+
+	annotations := map[string]string{
+		ociProjectAnnotation: strconv.FormatInt(project, 10),
+		ociVersionAnnotation: strconv.FormatInt(result.Version, 10),
+	}
+
+	rootfs, ok := config["rootfs"].(map[string]any)
+	if !ok {
+		return result, fmt.Errorf("%w: base config has no rootfs", ErrInvalidOCILayout)
+	}
+	diffIDs, _ := rootfs["diff_ids"].([]any)
+	rootfs["diff_ids"] = append(diffIDs, result.LayerDiffID)
+
+	history, _ := config["history"].([]any)
+	config["history"] = append(history, map[string]any{
+		"created":    time.Now().UTC().Format(time.RFC3339),
+		"created_by": fmt.Sprintf("%s --project %d --to %d", ociHistoryCreatedBy, project, result.Version),
+	})
+
+	encodedConfig, err := json.Marshal(config)
+	if err != nil {
+		return result, fmt.Errorf("cannot encode OCI config: %w", err)
+	}
+
+	configDescriptor, err := writeOCIBlob(layoutDir, ociConfigMediaType, encodedConfig)
+	if err != nil {
+		return result, err
+	}
+
+	manifest.SchemaVersion = 2
+	manifest.MediaType = ociManifestMediaType
+	manifest.Config = configDescriptor
+	manifest.Layers = append(manifest.Layers, ociDescriptor{
+		MediaType:   ociLayerMediaType,
+		Digest:      result.LayerDigest,
+		Size:        result.LayerSize,
+		Annotations: annotations,
+	})
+
+	encodedManifest, err := json.Marshal(manifest)
+	if err != nil {
+		return result, fmt.Errorf("cannot encode OCI manifest: %w", err)
+	}
+
+	manifestDescriptor, err := writeOCIBlob(layoutDir, ociManifestMediaType, encodedManifest)
+	if err != nil {
+		return result, err
+	}
+	result.ManifestDigest = manifestDescriptor.Digest
+
+	if ref != "" {
+		manifestDescriptor.Annotations = map[string]string{ociRefNameAnnotation: ref}
+	}
+
+	encodedIndex, err := json.Marshal(ociIndex{SchemaVersion: 2, Manifests: []ociDescriptor{manifestDescriptor}})
+	if err != nil {
+		return result, fmt.Errorf("cannot encode OCI index: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(layoutDir, ociLayoutFile), []byte(`{"imageLayoutVersion":"`+ociLayoutVersion+`"}`), 0644)
+	if err != nil {
+		return result, fmt.Errorf("cannot write OCI layout file: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(layoutDir, ociIndexFile), encodedIndex, 0644)
+	if err != nil {
+		return result, fmt.Errorf("cannot write OCI index: %w", err)
+	}
+
+	return result, nil
+}
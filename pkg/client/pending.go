@@ -0,0 +1,149 @@
+package client
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	fsdiff "github.com/gadget-inc/fsdiff/pkg/diff"
+	fsdiff_pb "github.com/gadget-inc/fsdiff/pkg/pb"
+)
+
+// pendingDir queues the diffs of updates that could not be sent. The summary already recorded those changes,
+// so without the queue the next diff would not return them again.
+var pendingDir = filepath.Join(metadataDir, "pending")
+
+const pendingExt = ".s2"
+
+// ErrPendingUpdates is returned by rebuilds of a directory holding queued changes, a rebuild could overwrite them.
+var ErrPendingUpdates = errors.New("directory has pending updates")
+
+// PendingDiff is a diff queued by a failed Update, Seq orders the queue.
+type PendingDiff struct {
+	Seq     int64               `json:"seq"`
+	Paths   []string            `json:"paths"`
+	Updates []*fsdiff_pb.Update `json:"-"`
+	file    string
+}
+
+// ReadPending returns the queued diffs of dir, oldest first.
+func ReadPending(dir string) ([]PendingDiff, error) {
+	path := filepath.Join(dir, pendingDir)
+	entries, err := os.ReadDir(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read pending dir %v: %w", path, err)
+	}
+
+	var pending []PendingDiff
+	for _, entry := range entries {
+		name := entry.Name()
+		seq, err := strconv.ParseInt(strings.TrimSuffix(name, pendingExt), 10, 64)
+		if err != nil || !strings.HasSuffix(name, pendingExt) {
+			continue
+		}
+
+		file := filepath.Join(path, name)
+		diff, err := fsdiff.ReadDiff(file)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read pending diff %v: %w", file, err)
+		}
+
+		paths := make([]string, 0, len(diff.Updates))
+		for _, update := range diff.Updates {
+			paths = append(paths, update.Path)
+		}
+
+		pending = append(pending, PendingDiff{Seq: seq, Paths: paths, Updates: diff.Updates, file: file})
+	}
+
+	slices.SortFunc(pending, func(a, b PendingDiff) int {
+		return cmp.Compare(a.Seq, b.Seq)
+	})
+
+	return pending, nil
+}
+
+// queuePending appends updates to the queue of dir.
+func queuePending(dir string, pending []PendingDiff, updates []*fsdiff_pb.Update) error {
+	path := filepath.Join(dir, pendingDir)
+	err := os.MkdirAll(path, 0775)
+	if err != nil {
+		return fmt.Errorf("cannot create pending dir %v: %w", path, err)
+	}
+
+	seq := int64(1)
+	if len(pending) > 0 {
+		seq = pending[len(pending)-1].Seq + 1
+	}
+
+	file := filepath.Join(path, fmt.Sprintf("%020d%s", seq, pendingExt))
+	err = fsdiff.WriteDiff(file, &fsdiff_pb.Diff{Updates: updates})
+	if err != nil {
+		return fmt.Errorf("cannot write pending diff %v: %w", file, err)
+	}
+
+	return nil
+}
+
+// removePending deletes the given queued diffs once they were sent.
+func removePending(pending []PendingDiff) error {
+	for _, diff := range pending {
+		err := os.Remove(diff.file)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("cannot remove pending diff %v: %w", diff.file, err)
+		}
+	}
+	return nil
+}
+
+// DropPending forgets every queued diff of dir and returns how many were dropped.
+// The dropped changes are only sent again if the files change once more.
+func DropPending(dir string) (int, error) {
+	pending, err := ReadPending(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(pending), removePending(pending)
+}
+
+func hasPending(dir string) (bool, error) {
+	pending, err := ReadPending(dir)
+	return len(pending) > 0, err
+}
+
+// collapseUpdates merges the queued diffs and a new diff into a single list with one update per path.
+// Contents are read from disk when sent, so only the last action of a path matters and it keeps the position of that action.
+func collapseUpdates(pending []PendingDiff, updates []*fsdiff_pb.Update) []*fsdiff_pb.Update {
+	if len(pending) == 0 {
+		return updates
+	}
+
+	var all []*fsdiff_pb.Update
+	for _, diff := range pending {
+		all = append(all, diff.Updates...)
+	}
+	all = append(all, updates...)
+
+	last := make(map[string]int, len(all))
+	for idx, update := range all {
+		last[update.Path] = idx
+	}
+
+	collapsed := make([]*fsdiff_pb.Update, 0, len(last))
+	for idx, update := range all {
+		if last[update.Path] == idx {
+			collapsed = append(collapsed, update)
+		}
+	}
+
+	return collapsed
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"testing"
+
+	fsdiff_pb "github.com/gadget-inc/fsdiff/pkg/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func diffUpdate(path string, action fsdiff_pb.Update_Action) *fsdiff_pb.Update {
+	return &fsdiff_pb.Update{Path: path, Action: action}
+}
+
+func TestPendingQueue(t *testing.T) {
+	dir := t.TempDir()
+
+	pending, err := ReadPending(dir)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	require.NoError(t, queuePending(dir, pending, []*fsdiff_pb.Update{diffUpdate("a", fsdiff_pb.Update_ADD)}))
+	pending, err = ReadPending(dir)
+	require.NoError(t, err)
+	require.NoError(t, queuePending(dir, pending, []*fsdiff_pb.Update{diffUpdate("b", fsdiff_pb.Update_CHANGE)}))
+
+	pending, err = ReadPending(dir)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, []string{"a"}, pending[0].Paths)
+	assert.Equal(t, []string{"b"}, pending[1].Paths)
+
+	count, err := DropPending(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	exists, err := hasPending(dir)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestCollapseUpdates(t *testing.T) {
+	pending := []PendingDiff{
+		{Seq: 1, Updates: []*fsdiff_pb.Update{diffUpdate("a", fsdiff_pb.Update_ADD), diffUpdate("b", fsdiff_pb.Update_ADD)}},
+		{Seq: 2, Updates: []*fsdiff_pb.Update{diffUpdate("a", fsdiff_pb.Update_CHANGE), diffUpdate("c", fsdiff_pb.Update_ADD)}},
+	}
+
+	collapsed := collapseUpdates(pending, []*fsdiff_pb.Update{diffUpdate("b", fsdiff_pb.Update_REMOVE)})
+
+	assert.Equal(t, []*fsdiff_pb.Update{
+		diffUpdate("a", fsdiff_pb.Update_CHANGE),
+		diffUpdate("c", fsdiff_pb.Update_ADD),
+		diffUpdate("b", fsdiff_pb.Update_REMOVE),
+	}, collapsed)
+}
@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// Progress is a snapshot of a long running transfer.
+// TotalObjects is 0 when the number of objects is not known upfront, as when objects are received in packs.
+type Progress struct {
+	Objects      int64 `json:"objects"`
+	TotalObjects int64 `json:"totalObjects,omitempty"`
+	Bytes        int64 `json:"bytes"`
+}
+
+// ProgressFunc is called every time objects are written or sent, calls are never concurrent.
+type ProgressFunc func(Progress)
+
+type progressCtxKey struct{}
+
+// WithProgress reports the progress of the Rebuild, Update and GetCache calls made with the returned context to fn.
+// A nil fn disables reporting.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	if fn == nil {
+		return context.WithValue(ctx, progressCtxKey{}, (*progressTracker)(nil))
+	}
+	return context.WithValue(ctx, progressCtxKey{}, &progressTracker{fn: fn})
+}
+
+// progressTracker accumulates progress, a nil *progressTracker ignores every update.
+type progressTracker struct {
+	mu       sync.Mutex
+	fn       ProgressFunc
+	progress Progress
+}
+
+func progressFromContext(ctx context.Context) *progressTracker {
+	tracker, _ := ctx.Value(progressCtxKey{}).(*progressTracker)
+	return tracker
+}
+
+func (p *progressTracker) setTotal(total int64) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.progress.TotalObjects = total
+	p.fn(p.progress)
+}
+
+func (p *progressTracker) snapshot() Progress {
+	if p == nil {
+		return Progress{}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.progress
+}
+
+// rewind goes back to an earlier snapshot, for a call sending the same objects again after a failed attempt
+func (p *progressTracker) rewind(to Progress) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.progress.Objects = to.Objects
+	p.progress.Bytes = to.Bytes
+	p.fn(p.progress)
+}
+
+func (p *progressTracker) add(objects int64, bytes int64) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.progress.Objects += objects
+	p.progress.Bytes += bytes
+	p.fn(p.progress)
+}
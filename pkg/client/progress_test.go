@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgressTracker(t *testing.T) {
+	var reports []Progress
+	ctx := WithProgress(context.Background(), func(p Progress) {
+		reports = append(reports, p)
+	})
+
+	progress := progressFromContext(ctx)
+	progress.setTotal(3)
+	progress.add(1, 10)
+	progress.add(2, 5)
+
+	assert.Equal(t, []Progress{
+		{Objects: 0, TotalObjects: 3, Bytes: 0},
+		{Objects: 1, TotalObjects: 3, Bytes: 10},
+		{Objects: 3, TotalObjects: 3, Bytes: 15},
+	}, reports)
+}
+
+func TestProgressTrackerDisabled(t *testing.T) {
+	ctx := WithProgress(context.Background(), func(p Progress) {
+		t.Fatal("progress should not be reported")
+	})
+
+	progress := progressFromContext(WithProgress(ctx, nil))
+	assert.Nil(t, progress)
+
+	progress.setTotal(3)
+	progress.add(1, 10)
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/files"
+	"github.com/gadget-inc/dateilager/internal/pb"
+)
+
+// Project is a handle on a single project of a Client, it gathers the client calls about the project behind option structs
+// whose zero values are sane defaults.
+type Project struct {
+	client *Client
+	id     int64
+}
+
+// Project returns a handle on the project id, it does not check that the project exists.
+func (c *Client) Project(id int64) *Project {
+	return &Project{client: c, id: id}
+}
+
+func (p *Project) ID() int64 {
+	return p.id
+}
+
+// RebuildOptions configure Project.Rebuild, only Dir is required.
+type RebuildOptions struct {
+	// Dir is the directory the project is checked out into
+	Dir string
+	// ToVersion defaults to the latest version
+	ToVersion *int64
+	// Prefix and Ignores limit the rebuilt paths, Paths rebuilds only the listed paths instead
+	Prefix  string
+	Ignores []string
+	Paths   []string
+	// CacheDir is the local cache directory used to hardlink cached objects
+	CacheDir string
+	// Matcher reports whether every written file matched it in RebuildResult.FileMatch
+	Matcher *files.FileMatcher
+	// SkipSummary does not record the state of Dir, a later Update then has to diff against the whole directory
+	SkipSummary   bool
+	RestoreMtimes bool
+	// LazyThreshold writes files larger than this as placeholders to be fetched with FetchOmitted, 0 writes every file
+	LazyThreshold int64
+	// Atomic swaps a fully rebuilt copy of Dir into place so readers never see a partial tree
+	Atomic bool
+	// ForceFull ignores the metadata of Dir and rebuilds it from scratch
+	ForceFull bool
+}
+
+var ErrIncompatibleRebuildOptions = errors.New("incompatible rebuild options")
+
+// Rebuild brings opts.Dir to a version of the project, picking the rebuild mode the options ask for.
+func (p *Project) Rebuild(ctx context.Context, opts RebuildOptions) (RebuildResult, error) {
+	c := p.client
+
+	switch {
+	case opts.ForceFull:
+		if len(opts.Paths) > 0 || opts.LazyThreshold > 0 || opts.Atomic {
+			return RebuildResult{}, fmt.Errorf("%w: ForceFull with Paths, LazyThreshold or Atomic", ErrIncompatibleRebuildOptions)
+		}
+		return c.ForceFullRebuild(ctx, p.id, opts.Prefix, opts.ToVersion, opts.Dir, opts.Ignores, opts.CacheDir, opts.Matcher, !opts.SkipSummary, opts.RestoreMtimes)
+	case len(opts.Paths) > 0:
+		if opts.Prefix != "" || len(opts.Ignores) > 0 || opts.LazyThreshold > 0 || opts.Atomic {
+			return RebuildResult{}, fmt.Errorf("%w: Paths with Prefix, Ignores, LazyThreshold or Atomic", ErrIncompatibleRebuildOptions)
+		}
+		return c.rebuildPaths(ctx, p.id, opts)
+	case opts.LazyThreshold > 0:
+		if opts.Atomic {
+			return RebuildResult{}, fmt.Errorf("%w: LazyThreshold with Atomic", ErrIncompatibleRebuildOptions)
+		}
+		return c.rebuildLazy(ctx, p.id, opts)
+	case opts.Atomic:
+		return c.rebuildAtomic(ctx, p.id, opts)
+	default:
+		return c.rebuildPrefix(ctx, p.id, opts)
+	}
+}
+
+// Update sends the changes made in dir since it was last rebuilt or updated and returns the resulting version and the number of changed paths.
+func (p *Project) Update(ctx context.Context, dir string, opts WriteOptions) (int64, uint32, error) {
+	return p.client.UpdateWithOptions(ctx, p.id, dir, opts)
+}
+
+// Get returns the objects under prefix, an empty prefix returns every object.
+func (p *Project) Get(ctx context.Context, prefix string, vrange VersionRange) ([]*pb.Object, error) {
+	return p.client.Get(ctx, p.id, prefix, nil, vrange)
+}
+
+// History lists the annotated versions of the project within vrange.
+func (p *Project) History(ctx context.Context, vrange VersionRange) ([]*pb.VersionAnnotation, error) {
+	return p.client.History(ctx, p.id, vrange)
+}
+
+// Pin returns a context pinning the reads of the project made with it to its latest version, along with that version.
+func (p *Project) Pin(ctx context.Context) (context.Context, int64, error) {
+	return p.client.PinReadSnapshot(ctx, p.id)
+}
+
+const defaultWatchInterval = 5 * time.Second
+
+// Watch polls the latest version of the project every interval and calls fn with every new version, starting with the current one.
+// It returns when ctx is done or fn returns an error, an interval of 0 polls every 5 seconds.
+func (p *Project) Watch(ctx context.Context, interval time.Duration, fn func(version int64) error) error {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := int64(-1)
+	for {
+		snapshot, err := p.client.ReadSnapshot(ctx, p.id)
+		if err != nil {
+			return err
+		}
+
+		if snapshot.Version != last {
+			last = snapshot.Version
+			err = fn(last)
+			if err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
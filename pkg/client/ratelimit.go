@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// RateLimiter is a token bucket measured in bytes, it holds at most one second worth of tokens.
+// A nil *RateLimiter never waits.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a limiter allowing bytesPerSecond on average, nil when bytesPerSecond is not positive.
+func NewRateLimiter(bytesPerSecond int64) *RateLimiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+
+	return &RateLimiter{
+		rate:   float64(bytesPerSecond),
+		tokens: float64(bytesPerSecond),
+		last:   time.Now(),
+	}
+}
+
+// WaitN blocks until n bytes may be transferred. Messages larger than the bucket go into debt that later calls pay back.
+func (r *RateLimiter) WaitN(ctx context.Context, n int) error {
+	if r == nil || n <= 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	now := time.Now()
+	r.tokens += now.Sub(r.last).Seconds() * r.rate
+	if r.tokens > r.rate {
+		r.tokens = r.rate
+	}
+	r.last = now
+
+	r.tokens -= float64(n)
+	wait := time.Duration(-r.tokens / r.rate * float64(time.Second))
+	r.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type rateLimitsCtxKey struct{}
+
+type callRateLimits struct {
+	upload   *RateLimiter
+	download *RateLimiter
+}
+
+// WithRateLimits overrides the client's upload and download rates, in bytes per second, for the calls made with the returned context.
+// A rate that is not positive removes the limit.
+func WithRateLimits(ctx context.Context, maxUploadRate int64, maxDownloadRate int64) context.Context {
+	return context.WithValue(ctx, rateLimitsCtxKey{}, &callRateLimits{
+		upload:   NewRateLimiter(maxUploadRate),
+		download: NewRateLimiter(maxDownloadRate),
+	})
+}
+
+func (l *callRateLimits) forContext(ctx context.Context) *callRateLimits {
+	if override, ok := ctx.Value(rateLimitsCtxKey{}).(*callRateLimits); ok {
+		return override
+	}
+	return l
+}
+
+func messageSize(msg any) int {
+	if m, ok := msg.(proto.Message); ok {
+		return proto.Size(m)
+	}
+	return 0
+}
+
+func (l *callRateLimits) unaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		limits := l.forContext(ctx)
+
+		err := limits.upload.WaitN(ctx, messageSize(req))
+		if err != nil {
+			return err
+		}
+
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		if err != nil {
+			return err
+		}
+
+		return limits.download.WaitN(ctx, messageSize(reply))
+	}
+}
+
+func (l *callRateLimits) streamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rateLimitedStream{ClientStream: stream, ctx: ctx, limits: l.forContext(ctx)}, nil
+	}
+}
+
+type rateLimitedStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	limits *callRateLimits
+}
+
+func (s *rateLimitedStream) SendMsg(msg any) error {
+	err := s.limits.upload.WaitN(s.ctx, messageSize(msg))
+	if err != nil {
+		return err
+	}
+	return s.ClientStream.SendMsg(msg)
+}
+
+func (s *rateLimitedStream) RecvMsg(msg any) error {
+	err := s.ClientStream.RecvMsg(msg)
+	if err != nil {
+		return err
+	}
+	return s.limits.download.WaitN(s.ctx, messageSize(msg))
+}
+
+// RateLimitDialOptions returns the interceptors enforcing the rate limit options, for connections given to NewClientConn.
+// NewClient installs them itself.
+func RateLimitDialOptions(opts ...func(*options)) []grpc.DialOption {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	limits := &callRateLimits{upload: o.uploadLimiter, download: o.downloadLimiter}
+
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(limits.unaryInterceptor()),
+		grpc.WithChainStreamInterceptor(limits.streamInterceptor()),
+	}
+}
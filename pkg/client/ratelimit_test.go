@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiterWaitN(t *testing.T) {
+	ctx := context.Background()
+	limiter := NewRateLimiter(1000)
+
+	start := time.Now()
+	require.NoError(t, limiter.WaitN(ctx, 1000))
+	assert.Less(t, time.Since(start), 100*time.Millisecond, "a full bucket does not wait")
+
+	start = time.Now()
+	require.NoError(t, limiter.WaitN(ctx, 500))
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond, "an empty bucket waits for the tokens to refill")
+}
+
+func TestRateLimiterWaitNCancelled(t *testing.T) {
+	limiter := NewRateLimiter(10)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := limiter.WaitN(ctx, 1000)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRateLimiterUnlimited(t *testing.T) {
+	limiter := NewRateLimiter(0)
+	assert.Nil(t, limiter)
+
+	start := time.Now()
+	require.NoError(t, limiter.WaitN(context.Background(), 1<<30))
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy retries whole client operations, such as a full Rebuild or the commit of an Update batch, when they fail
+// with a transient error. It complements the GRPC service config retries which only cover the start of Get calls.
+// Only operations that are safe to run again use it: reads, rebuilds, cache downloads and updates sent with an idempotency key.
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt, 0 or 1 disables retries
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, multiplied by Multiplier for every further retry up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Budget stops retrying once this much time went by since the first attempt, 0 means no limit
+	Budget time.Duration
+	// Retryable classifies errors, RetryableError is used when it is nil
+	Retryable func(error) bool
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+}
+
+// NoRetryPolicy runs operations a single time.
+var NoRetryPolicy = RetryPolicy{MaxAttempts: 1}
+
+// WithRetryPolicy replaces the per operation defaults with policy for every retried operation of the client.
+// A policy with a MaxAttempts of 0 keeps the defaults.
+func WithRetryPolicy(policy RetryPolicy) func(*options) {
+	return func(o *options) {
+		if policy.MaxAttempts > 0 {
+			o.retryPolicy = &policy
+		}
+	}
+}
+
+// RetryableError reports whether err is transient: the server was unreachable, shed the call under load or aborted it,
+// or a response failed its checksum on the way.
+func RetryableError(err error) bool {
+	if errors.Is(err, ErrChecksumMismatch) {
+		return true
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return RetryableError(err)
+}
+
+// backoff returns the delay before retry number attempt, starting at 0. A delay asked by the server takes precedence.
+func (p RetryPolicy) backoff(attempt int, err error) time.Duration {
+	if delay, ok := RetryAfter(err); ok {
+		return delay
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(p.InitialBackoff)
+	for i := 0; i < attempt; i++ {
+		delay *= multiplier
+		if p.MaxBackoff > 0 && delay >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+
+	return time.Duration(delay)
+}
+
+// Do runs fn until it succeeds, fails with an error that is not retryable, or the attempts or budget run out.
+// fn receives the attempt number, starting at 0, and the error of the last attempt is returned.
+func (p RetryPolicy) Do(ctx context.Context, fn func(attempt int) error) error {
+	start := time.Now()
+	span := trace.SpanFromContext(ctx)
+
+	for attempt := 0; ; attempt++ {
+		err := fn(attempt)
+		if err == nil || attempt+1 >= p.MaxAttempts || !p.retryable(err) || ctx.Err() != nil {
+			return err
+		}
+
+		delay := p.backoff(attempt, err)
+		if p.Budget > 0 && time.Since(start)+delay > p.Budget {
+			return err
+		}
+
+		span.RecordError(err, trace.WithAttributes(key.Attempt.Attribute(attempt)))
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// retryPolicy returns the policy configured with WithRetryPolicy, fallback when none was.
+func (c *Client) retryPolicy(fallback RetryPolicy) RetryPolicy {
+	if c.retry != nil {
+		return *c.retry
+	}
+	return fallback
+}
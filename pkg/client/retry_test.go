@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+	err := status.Error(codes.Unavailable, "down")
+
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(0, err))
+	assert.Equal(t, 400*time.Millisecond, policy.backoff(2, err))
+	assert.Equal(t, time.Second, policy.backoff(10, err), "the backoff is capped")
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	unavailable := status.Error(codes.Unavailable, "down")
+
+	attempts := 0
+	err := policy.Do(context.Background(), func(attempt int) error {
+		attempts += 1
+		if attempt < 2 {
+			return unavailable
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = policy.Do(context.Background(), func(_ int) error {
+		attempts += 1
+		return unavailable
+	})
+	assert.Equal(t, unavailable, err, "the last error is returned once the attempts run out")
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	notFound := status.Error(codes.NotFound, "missing")
+	err = policy.Do(context.Background(), func(_ int) error {
+		attempts += 1
+		return notFound
+	})
+	assert.Equal(t, notFound, err)
+	assert.Equal(t, 1, attempts, "errors that are not retryable are returned right away")
+}
+
+func TestRetryPolicyBudget(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Hour, Budget: time.Second}
+
+	attempts := 0
+	err := policy.Do(context.Background(), func(_ int) error {
+		attempts += 1
+		return status.Error(codes.Unavailable, "down")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts, "a retry that would exceed the budget is not attempted")
+}
+
+func TestRetryableError(t *testing.T) {
+	assert.True(t, RetryableError(status.Error(codes.ResourceExhausted, "shed")))
+	assert.True(t, RetryableError(ErrChecksumMismatch))
+	assert.False(t, RetryableError(status.Error(codes.PermissionDenied, "denied")))
+	assert.False(t, RetryableError(errors.New("local failure")))
+}
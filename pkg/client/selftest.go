@@ -0,0 +1,232 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SelfTestStep is the outcome of one step of SelfTest, Error is empty when the step passed.
+type SelfTestStep struct {
+	Name       string `json:"name"`
+	DurationMS int64  `json:"durationMs"`
+	Error      string `json:"error,omitempty"`
+}
+
+type SelfTestResult struct {
+	Project int64          `json:"project"`
+	Ok      bool           `json:"ok"`
+	Steps   []SelfTestStep `json:"steps"`
+}
+
+// selfTestVersions are the changes written by the update step, one version each. An empty content removes the path.
+// Contents are suffixed with a random nonce so they are not shared with any other project.
+var selfTestVersions = []map[string]string{
+	{"a.txt": "a v1", "b/c.txt": "c v1"},
+	{"a.txt": "a v2", "b/c.txt": "", "d.txt": "d v2"},
+	{"a.txt": "a v3"},
+}
+
+// SelfTest runs the lifecycle of a scratch project against the server: it is created, updated three times, rebuilt,
+// diffed, garbage collected in a dry run and deleted, checking the result of every step. The project must not exist
+// and is deleted once created, even when a later step fails. dir is a scratch directory for the files of the project.
+// The returned error is only set when SelfTest itself could not run, failed steps are reported in the result.
+func (c *Client) SelfTest(ctx context.Context, project int64, dir string) (SelfTestResult, error) {
+	ctx, span := telemetry.Start(ctx, "client.self-test", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Directory.Attribute(dir),
+	))
+	defer span.End()
+
+	result := SelfTestResult{Project: project, Ok: true}
+
+	nonce, err := randomIdempotencyKey()
+	if err != nil {
+		return result, err
+	}
+
+	updateDir := filepath.Join(dir, "update")
+	rebuildDir := filepath.Join(dir, "rebuild")
+	for _, path := range []string{updateDir, rebuildDir} {
+		err = os.MkdirAll(path, 0755)
+		if err != nil {
+			return result, fmt.Errorf("cannot create self test dir %v: %w", path, err)
+		}
+	}
+
+	run := func(name string, step func() error) bool {
+		start := time.Now()
+		err := step()
+
+		outcome := SelfTestStep{Name: name, DurationMS: time.Since(start).Milliseconds()}
+		if err != nil {
+			outcome.Error = err.Error()
+			result.Ok = false
+		}
+		result.Steps = append(result.Steps, outcome)
+
+		return err == nil
+	}
+
+	if !run("new", func() error {
+		return c.NewProject(ctx, project, nil, nil)
+	}) {
+		// the project was not created by the self test, it must not be deleted
+		return result, nil
+	}
+
+	expected := make(map[string]string)
+	var versions []int64
+
+	_ = run("update", func() error {
+		for idx, tree := range selfTestVersions {
+			for path, content := range tree {
+				fullPath := filepath.Join(updateDir, path)
+				if content == "" {
+					delete(expected, path)
+					err := os.Remove(fullPath)
+					if err != nil {
+						return fmt.Errorf("cannot remove %v: %w", fullPath, err)
+					}
+					continue
+				}
+
+				expected[path] = content + " " + nonce
+				err := os.MkdirAll(filepath.Dir(fullPath), 0755)
+				if err == nil {
+					err = os.WriteFile(fullPath, []byte(expected[path]), 0644)
+				}
+				if err != nil {
+					return fmt.Errorf("cannot write %v: %w", fullPath, err)
+				}
+			}
+
+			version, _, err := c.Update(ctx, project, updateDir)
+			if err != nil {
+				return err
+			}
+			if version != int64(idx+1) {
+				return fmt.Errorf("update %d created version %d, expected version %d", idx+1, version, idx+1)
+			}
+			versions = append(versions, version)
+		}
+		return nil
+	}) && run("rebuild", func() error {
+		rebuilt, err := c.Rebuild(ctx, project, "", nil, rebuildDir, nil, "", nil, true)
+		if err != nil {
+			return err
+		}
+		if rebuilt.Version != versions[len(versions)-1] {
+			return fmt.Errorf("rebuilt version %d, expected version %d", rebuilt.Version, versions[len(versions)-1])
+		}
+		return compareSelfTestDir(rebuildDir, expected)
+	}) && run("diff", func() error {
+		diffs, err := c.Diff(ctx, project, "", versions[0], &versions[len(versions)-1], nil)
+		if err != nil {
+			return err
+		}
+
+		changes := make(map[string]pb.DiffResponse_Change)
+		for _, diff := range diffs {
+			if !pb.IsDirPath(diff.Path) {
+				changes[diff.Path] = diff.Change
+			}
+		}
+
+		want := map[string]pb.DiffResponse_Change{
+			"a.txt":   pb.DiffResponse_MODIFIED,
+			"b/c.txt": pb.DiffResponse_DELETED,
+			"d.txt":   pb.DiffResponse_ADDED,
+		}
+		if len(changes) != len(want) {
+			return fmt.Errorf("diff from version %d returned %v, expected %v", versions[0], changes, want)
+		}
+		for path, change := range want {
+			if actual, ok := changes[path]; !ok || actual != change {
+				return fmt.Errorf("diff from version %d returned %v, expected %v", versions[0], changes, want)
+			}
+		}
+		return nil
+	}) && run("gc-dry-run", func() error {
+		count, err := c.GcProject(ctx, project, 1, nil, true)
+		if err != nil {
+			return err
+		}
+		// the first contents of a.txt and b/c.txt are only live before the last version
+		if count < 2 {
+			return fmt.Errorf("gc dry run would remove %d contents, expected at least 2", count)
+		}
+		return nil
+	})
+
+	_ = run("delete", func() error {
+		err := c.DeleteProject(ctx, project)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.StatPaths(ctx, project, nil, []string{"a.txt"})
+		if status.Code(err) != codes.NotFound {
+			return fmt.Errorf("deleted project still readable: %v", err)
+		}
+		return nil
+	})
+
+	return result, nil
+}
+
+// compareSelfTestDir checks that dir holds exactly the expected files.
+func compareSelfTestDir(dir string, expected map[string]string) error {
+	found := make(map[string]bool)
+
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		if relative == metadataDir || strings.HasPrefix(relative, metadataDir+string(filepath.Separator)) || entry.IsDir() {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		want, ok := expected[filepath.ToSlash(relative)]
+		if !ok {
+			return fmt.Errorf("unexpected file %v", relative)
+		}
+		if string(content) != want {
+			return fmt.Errorf("file %v contains %q, expected %q", relative, content, want)
+		}
+
+		found[filepath.ToSlash(relative)] = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for path := range expected {
+		if !found[path] {
+			return fmt.Errorf("missing file %v", path)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// countingWriter counts the bytes written through it, before any compression.
+type countingWriter struct {
+	w     io.Writer
+	count int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.count += int64(n)
+	return n, err
+}
+
+func tarHeaderFromObject(object *pb.Object) *tar.Header {
+	mode := fs.FileMode(object.Mode)
+	typeFlag := pb.TarTypeFromMode(mode)
+
+	header := &tar.Header{
+		Name:     object.Path,
+		Mode:     int64(mode.Perm()),
+		Typeflag: typeFlag,
+		ModTime:  time.Unix(0, 0),
+		Format:   tar.FormatPAX,
+	}
+
+	if object.Mtime != nil {
+		header.ModTime = time.Unix(0, *object.Mtime)
+	}
+
+	switch typeFlag {
+	case tar.TypeSymlink:
+		header.Linkname = string(object.Content)
+	case tar.TypeReg:
+		header.Size = int64(len(object.Content))
+	}
+
+	return header
+}
+
+// RebuildTar writes the objects of a project under prefix to w as a single tar, gzip compressed when compress is set,
+// instead of checking them out into a directory. No metadata directory is written so the output cannot be updated later.
+func (c *Client) RebuildTar(ctx context.Context, project int64, prefix string, ignores []string, toVersion *int64, w io.Writer, compress bool) (RebuildResult, error) {
+	ctx, span := telemetry.Start(ctx, "client.rebuild-tar", trace.WithAttributes(
+		key.Project.Attribute(project),
+		key.Prefix.Attribute(prefix),
+		key.ToVersion.Attribute(toVersion),
+	))
+	defer span.End()
+
+	transform, vars := transformVars(ctx)
+
+	request := &pb.GetRequest{
+		Project:   project,
+		ToVersion: toVersion,
+		Queries: []*pb.ObjectQuery{{
+			Path:     prefix,
+			IsPrefix: true,
+			Ignores:  ignores,
+		}},
+		SnapshotToken: snapshotToken(ctx, project),
+		Transform:     transform,
+		TransformVars: vars,
+	}
+
+	stream, err := c.fs.Get(ctx, request)
+	if err != nil {
+		return emptyResult(0), fmt.Errorf("connect fs.Get: %w", err)
+	}
+
+	counter := &countingWriter{w: w}
+	output := io.Writer(counter)
+
+	var gzipWriter *gzip.Writer
+	if compress {
+		gzipWriter = gzip.NewWriter(counter)
+		output = gzipWriter
+	}
+
+	tarWriter := tar.NewWriter(output)
+	progress := progressFromContext(ctx)
+
+	version := int64(0)
+	count := uint32(0)
+
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return emptyResult(version), fmt.Errorf("receive fs.Get: %w", err)
+		}
+
+		version = response.Version
+		object := response.GetObject()
+		if object.Deleted {
+			continue
+		}
+
+		header := tarHeaderFromObject(object)
+		err = tarWriter.WriteHeader(header)
+		if err != nil {
+			return emptyResult(version), fmt.Errorf("write tar header %v: %w", object.Path, err)
+		}
+
+		if header.Size > 0 {
+			_, err = tarWriter.Write(object.Content)
+			if err != nil {
+				return emptyResult(version), fmt.Errorf("write tar content %v: %w", object.Path, err)
+			}
+		}
+
+		count += 1
+		progress.add(1, header.Size)
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return emptyResult(version), fmt.Errorf("close tar writer: %w", err)
+	}
+
+	if gzipWriter != nil {
+		err = gzipWriter.Close()
+		if err != nil {
+			return emptyResult(version), fmt.Errorf("close gzip writer: %w", err)
+		}
+	}
+
+	return RebuildResult{
+		Version: version,
+		Count:   count,
+		Bytes:   counter.count,
+	}, nil
+}
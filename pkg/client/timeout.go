@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	DEFAULT_DIAL_TIMEOUT      = 10 * time.Second
+	DEFAULT_KEEPALIVE_TIME    = 5 * time.Second
+	DEFAULT_KEEPALIVE_TIMEOUT = 1 * time.Second
+)
+
+// WithDialTimeout bounds how long NewClient waits for the connection to the server, 0 keeps the default of 10 seconds.
+func WithDialTimeout(timeout time.Duration) func(*options) {
+	return func(o *options) {
+		o.dialTimeout = timeout
+	}
+}
+
+// WithRPCTimeout gives every call made without a deadline on its context this long to complete, 0 means no deadline.
+// Streams must be fully consumed within the timeout.
+func WithRPCTimeout(timeout time.Duration) func(*options) {
+	return func(o *options) {
+		o.rpcTimeout = timeout
+	}
+}
+
+// WithKeepalive sets how often the connection is pinged when idle and how long to wait for the ping's ack before closing it.
+// A zero value keeps the default for that setting.
+func WithKeepalive(interval time.Duration, timeout time.Duration) func(*options) {
+	return func(o *options) {
+		o.keepaliveTime = interval
+		o.keepaliveTimeout = timeout
+	}
+}
+
+func (o *options) dialTimeoutOrDefault() time.Duration {
+	if o.dialTimeout > 0 {
+		return o.dialTimeout
+	}
+	return DEFAULT_DIAL_TIMEOUT
+}
+
+func (o *options) keepaliveParams() keepalive.ClientParameters {
+	params := keepalive.ClientParameters{
+		Time:                DEFAULT_KEEPALIVE_TIME,
+		Timeout:             DEFAULT_KEEPALIVE_TIMEOUT,
+		PermitWithoutStream: true,
+	}
+	if o.keepaliveTime > 0 {
+		params.Time = o.keepaliveTime
+	}
+	if o.keepaliveTimeout > 0 {
+		params.Timeout = o.keepaliveTimeout
+	}
+	return params
+}
+
+type rpcTimeout time.Duration
+
+func (t rpcTimeout) unaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); ok || t <= 0 {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, time.Duration(t))
+		defer cancel()
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func (t rpcTimeout) streamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if _, ok := ctx.Deadline(); ok || t <= 0 {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, time.Duration(t))
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		return &timeoutStream{ClientStream: stream, cancel: cancel}, nil
+	}
+}
+
+// timeoutStream releases the call's deadline once the stream ends, which is signaled by RecvMsg returning an error.
+type timeoutStream struct {
+	grpc.ClientStream
+	cancel context.CancelFunc
+}
+
+func (s *timeoutStream) RecvMsg(msg any) error {
+	err := s.ClientStream.RecvMsg(msg)
+	if err != nil {
+		s.cancel()
+	}
+	return err
+}
+
+// TimeoutDialOptions returns the interceptors enforcing WithRPCTimeout and the keepalive options, for connections given to NewClientConn.
+// NewClient installs them itself.
+func TimeoutDialOptions(opts ...func(*options)) []grpc.DialOption {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	timeout := rpcTimeout(o.rpcTimeout)
+
+	return []grpc.DialOption{
+		grpc.WithKeepaliveParams(o.keepaliveParams()),
+		grpc.WithChainUnaryInterceptor(timeout.unaryInterceptor()),
+		grpc.WithChainStreamInterceptor(timeout.streamInterceptor()),
+	}
+}
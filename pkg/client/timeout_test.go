@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestRPCTimeoutSetsDeadline(t *testing.T) {
+	interceptor := rpcTimeout(time.Minute).unaryInterceptor()
+
+	var deadline time.Time
+	var hasDeadline bool
+	invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		deadline, hasDeadline = ctx.Deadline()
+		return nil
+	}
+
+	assert.NoError(t, interceptor(context.Background(), "/pb.Fs/Get", nil, nil, nil, invoker))
+	assert.True(t, hasDeadline)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	assert.NoError(t, interceptor(ctx, "/pb.Fs/Get", nil, nil, nil, invoker))
+	assert.WithinDuration(t, time.Now().Add(time.Hour), deadline, time.Second, "an existing deadline is kept")
+}
+
+func TestKeepaliveParamsDefaults(t *testing.T) {
+	o := &options{}
+	WithKeepalive(30*time.Second, 0)(o)
+
+	params := o.keepaliveParams()
+	assert.Equal(t, 30*time.Second, params.Time)
+	assert.Equal(t, DEFAULT_KEEPALIVE_TIMEOUT, params.Timeout)
+	assert.Equal(t, DEFAULT_DIAL_TIMEOUT, o.dialTimeoutOrDefault())
+}
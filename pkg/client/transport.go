@@ -0,0 +1,63 @@
+package client
+
+import (
+	"fmt"
+	"math"
+
+	"google.golang.org/grpc"
+)
+
+// MIN_WINDOW_SIZE is the smallest flow control window gRPC applies, it silently keeps its defaults below it.
+const MIN_WINDOW_SIZE = 64 * KB
+
+// WithMaxMessageSize sets the largest message in bytes the client sends or accepts, 0 keeps the default of 300MB.
+// The server enforces its own limit, raising this one past it only moves where large messages are refused.
+func WithMaxMessageSize(size int) func(*options) {
+	return func(o *options) {
+		o.maxMessageSize = size
+	}
+}
+
+// WithWindowSizes sets the HTTP/2 flow control windows in bytes of each stream and of the whole connection, bounding how
+// much unread data the server can send ahead. A zero value keeps the default for that window.
+func WithWindowSizes(stream int32, conn int32) func(*options) {
+	return func(o *options) {
+		o.windowSize = stream
+		o.connWindowSize = conn
+	}
+}
+
+// transportDialOptions validates the message size and windows and returns the dial options applying them.
+func (o *options) transportDialOptions() ([]grpc.DialOption, error) {
+	maxMessageSize := MAX_MESSAGE_SIZE
+	if o.maxMessageSize != 0 {
+		maxMessageSize = o.maxMessageSize
+	}
+	windowSize := int32(INITIAL_WINDOW_SIZE)
+	if o.windowSize != 0 {
+		windowSize = o.windowSize
+	}
+	connWindowSize := int32(INITIAL_CONN_WINDOW_SIZE)
+	if o.connWindowSize != 0 {
+		connWindowSize = o.connWindowSize
+	}
+
+	if maxMessageSize < 0 || maxMessageSize > math.MaxInt32 {
+		return nil, fmt.Errorf("invalid max message size %d, expected between 1 and %d bytes", maxMessageSize, math.MaxInt32)
+	}
+	if windowSize < MIN_WINDOW_SIZE {
+		return nil, fmt.Errorf("invalid window size %d, expected at least %d bytes", windowSize, MIN_WINDOW_SIZE)
+	}
+	if connWindowSize < windowSize {
+		return nil, fmt.Errorf("invalid connection window size %d, expected at least the window size of %d bytes", connWindowSize, windowSize)
+	}
+
+	return []grpc.DialOption{
+		grpc.WithInitialConnWindowSize(connWindowSize),
+		grpc.WithInitialWindowSize(windowSize),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(maxMessageSize),
+			grpc.MaxCallSendMsgSize(maxMessageSize),
+		),
+	}, nil
+}
@@ -0,0 +1,33 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransportDialOptions(t *testing.T) {
+	o := &options{}
+	dialOptions, err := o.transportDialOptions()
+	assert.NoError(t, err, "defaults")
+	assert.Len(t, dialOptions, 3)
+
+	o = &options{}
+	WithMaxMessageSize(1 * KB)(o)
+	WithWindowSizes(4*MB, 0)(o)
+	_, err = o.transportDialOptions()
+	assert.Error(t, err, "stream window above the default connection window")
+
+	WithWindowSizes(4*MB, 8*MB)(o)
+	_, err = o.transportDialOptions()
+	assert.NoError(t, err)
+
+	WithWindowSizes(1*KB, 0)(o)
+	_, err = o.transportDialOptions()
+	assert.Error(t, err, "window below the gRPC minimum")
+
+	o = &options{}
+	WithMaxMessageSize(-1)(o)
+	_, err = o.transportDialOptions()
+	assert.Error(t, err, "negative max message size")
+}
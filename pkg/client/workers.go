@@ -0,0 +1,239 @@
+package client
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/files"
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// tuneInterval is how long the write throughput is measured before the worker count is adjusted
+	tuneInterval = 500 * time.Millisecond
+	// tuneTolerance is the relative throughput change below which a measurement counts as flat
+	tuneTolerance = 0.05
+)
+
+type writeWorkersCtxKey struct{}
+
+// WithWriteWorkers fixes the number of workers writing files in the Rebuild and GetCache calls made with the returned context.
+// It takes precedence over the DL_WRITE_WORKERS environment variable, a count of 0 or less tunes the count automatically.
+func WithWriteWorkers(ctx context.Context, count int) context.Context {
+	return context.WithValue(ctx, writeWorkersCtxKey{}, count)
+}
+
+// writeWorkerCount returns the fixed worker count asked for by the context or the environment, 0 when it should be tuned.
+func writeWorkerCount(ctx context.Context) int {
+	if count, ok := ctx.Value(writeWorkersCtxKey{}).(int); ok {
+		if count > 0 {
+			return count
+		}
+		return 0
+	}
+
+	count, err := strconv.Atoi(os.Getenv("DL_WRITE_WORKERS"))
+	if err == nil && count > 0 {
+		return count
+	}
+	return 0
+}
+
+type ioUringCtxKey struct{}
+
+// WithIOUring makes the Rebuild calls made with the returned context write files in bulk through io_uring, falling back
+// to direct writes where it is unavailable. It takes precedence over the DL_IO_URING environment variable.
+func WithIOUring(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, ioUringCtxKey{}, enabled)
+}
+
+func ioUringEnabled(ctx context.Context) bool {
+	if enabled, ok := ctx.Value(ioUringCtxKey{}).(bool); ok {
+		return enabled
+	}
+
+	enabled, err := strconv.ParseBool(os.Getenv("DL_IO_URING"))
+	return err == nil && enabled
+}
+
+// newUringWriter returns the io_uring writer of a write worker, nil when files are written directly.
+func newUringWriter(ctx context.Context, syncer *files.Syncer) *files.UringWriter {
+	if !ioUringEnabled(ctx) {
+		return nil
+	}
+
+	uring, err := files.NewUringWriter(syncer)
+	if err != nil {
+		logger.Debug(ctx, "io_uring unavailable, writing files directly", zap.Error(err))
+		return nil
+	}
+	return uring
+}
+
+// workerPool bounds how many of its workers write at once. Checkouts are bound by IO rather than CPU, so unless a count
+// is fixed the bound starts from the CPU count and is moved while writing, towards the count with the highest throughput:
+// fast local disks end up with more concurrent writers, network filesystems with fewer.
+type workerPool struct {
+	mu    sync.Mutex
+	limit int
+	busy  int
+	wake  chan struct{}
+
+	min   int
+	max   int
+	tuned bool
+	bytes atomic.Int64
+}
+
+func newWorkerPool(ctx context.Context) *workerPool {
+	if count := writeWorkerCount(ctx); count > 0 {
+		return &workerPool{limit: count, min: count, max: count, wake: make(chan struct{})}
+	}
+
+	return &workerPool{
+		limit: parallelWorkerCount(),
+		min:   1,
+		max:   4 * runtime.NumCPU(),
+		tuned: true,
+		wake:  make(chan struct{}),
+	}
+}
+
+// size is the number of workers to start, enough for the largest bound the pool can reach.
+func (p *workerPool) size() int {
+	return p.max
+}
+
+func (p *workerPool) current() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.limit
+}
+
+// broadcast wakes every worker waiting in acquire, p.mu must be held.
+func (p *workerPool) broadcast() {
+	close(p.wake)
+	p.wake = make(chan struct{})
+}
+
+// acquire waits until the worker may write.
+func (p *workerPool) acquire(ctx context.Context) error {
+	for {
+		p.mu.Lock()
+		if p.busy < p.limit {
+			p.busy++
+			p.mu.Unlock()
+			return nil
+		}
+		wake := p.wake
+		p.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wake:
+		}
+	}
+}
+
+// release ends a write of the given number of bytes.
+func (p *workerPool) release(bytes int64) {
+	p.bytes.Add(bytes)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.busy--
+	p.broadcast()
+}
+
+func (p *workerPool) setLimit(limit int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.limit = limit
+	p.broadcast()
+}
+
+// tune adjusts the bound every tuneInterval until the returned function is called.
+func (p *workerPool) tune(ctx context.Context) func() {
+	if !p.tuned {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(tuneInterval)
+		defer ticker.Stop()
+
+		var last float64
+		direction := 1
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			throughput := float64(p.bytes.Swap(0))
+			if throughput == 0 {
+				// nothing was received to write, the worker count is not what limits the rebuild
+				continue
+			}
+
+			limit := p.current()
+			next, nextDirection := nextWorkerLimit(limit, direction, last, throughput, p.min, p.max)
+			last = throughput
+			direction = nextDirection
+
+			if next != limit {
+				logger.Debug(ctx, "adjust write workers", key.WorkerCount.Field(next))
+				p.setLimit(next)
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// nextWorkerLimit climbs towards the worker count with the highest throughput. It keeps stepping in the same direction
+// while throughput improves, turns around when it drops and holds when it is flat.
+func nextWorkerLimit(limit, direction int, last, throughput float64, min, max int) (int, int) {
+	switch {
+	case last == 0 || throughput > last*(1+tuneTolerance):
+	case throughput < last*(1-tuneTolerance):
+		direction = -direction
+	default:
+		return limit, direction
+	}
+
+	step := limit / 4
+	if step < 1 {
+		step = 1
+	}
+
+	next := limit + direction*step
+	if next < min {
+		next, direction = min, 1
+	}
+	if next > max {
+		next, direction = max, -1
+	}
+
+	return next, direction
+}
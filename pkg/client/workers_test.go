@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteWorkerCount(t *testing.T) {
+	t.Setenv("DL_WRITE_WORKERS", "3")
+
+	ctx := context.Background()
+	assert.Equal(t, 3, writeWorkerCount(ctx))
+	assert.Equal(t, 7, writeWorkerCount(WithWriteWorkers(ctx, 7)))
+	assert.Equal(t, 0, writeWorkerCount(WithWriteWorkers(ctx, 0)), "an explicit 0 should tune even with DL_WRITE_WORKERS set")
+
+	t.Setenv("DL_WRITE_WORKERS", "")
+	assert.Equal(t, 0, writeWorkerCount(ctx))
+}
+
+func TestNewWorkerPoolFixed(t *testing.T) {
+	pool := newWorkerPool(WithWriteWorkers(context.Background(), 5))
+
+	assert.False(t, pool.tuned)
+	assert.Equal(t, 5, pool.size())
+	assert.Equal(t, 5, pool.current())
+}
+
+func TestWorkerPoolBoundsConcurrency(t *testing.T) {
+	ctx := context.Background()
+	pool := newWorkerPool(WithWriteWorkers(ctx, 2))
+
+	var active, peak atomic.Int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, pool.acquire(ctx))
+
+			now := active.Add(1)
+			for {
+				seen := peak.Load()
+				if now <= seen || peak.CompareAndSwap(seen, now) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			active.Add(-1)
+
+			pool.release(1)
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, int32(2), peak.Load())
+	assert.Equal(t, int64(8), pool.bytes.Load())
+}
+
+func TestWorkerPoolAcquireCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool := newWorkerPool(WithWriteWorkers(ctx, 1))
+
+	require.NoError(t, pool.acquire(ctx))
+
+	cancel()
+	assert.ErrorIs(t, pool.acquire(ctx), context.Canceled)
+}
+
+func TestWorkerPoolSetLimitWakesWaiters(t *testing.T) {
+	ctx := context.Background()
+	pool := newWorkerPool(WithWriteWorkers(ctx, 1))
+
+	require.NoError(t, pool.acquire(ctx))
+
+	acquired := make(chan error)
+	go func() {
+		acquired <- pool.acquire(ctx)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("acquire should wait while the pool is full")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	pool.setLimit(2)
+	assert.NoError(t, <-acquired)
+}
+
+func TestNextWorkerLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		limit         int
+		direction     int
+		last          float64
+		throughput    float64
+		wantLimit     int
+		wantDirection int
+	}{
+		{"first measurement steps up", 4, 1, 0, 100, 5, 1},
+		{"improvement keeps climbing", 8, 1, 100, 150, 10, 1},
+		{"drop turns around", 8, 1, 150, 100, 6, -1},
+		{"drop while descending climbs again", 8, -1, 150, 100, 10, 1},
+		{"flat holds", 8, 1, 100, 102, 8, 1},
+		{"bounded by max", 15, 1, 100, 200, 16, -1},
+		{"bounded by min", 1, -1, 100, 120, 1, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limit, direction := nextWorkerLimit(test.limit, test.direction, test.last, test.throughput, 1, 16)
+			assert.Equal(t, test.wantLimit, limit)
+			assert.Equal(t, test.wantDirection, direction)
+		})
+	}
+}
@@ -0,0 +1,407 @@
+package devtools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	charset = "abcdefghijklmnopqrstuvwxyz0123456789 "
+	// dirFanout is how many sibling directories each level of the generated tree spreads files over
+	dirFanout = 8
+	// packFiles is roughly how many files each generated pack directory holds
+	packFiles = 32
+	// fileMode is the mode of every generated file
+	fileMode = 0o644
+	// generateStage is the stage each version is accumulated in before being committed through the API
+	generateStage = "devtools-generate"
+)
+
+var generateMessage = "devtools generate"
+
+// Shape describes the synthetic project built by a Generator
+type Shape struct {
+	// Files is how many files the first version holds
+	Files int
+	// MinSize and MaxSize bound the file sizes, sizes are log uniform so small files outnumber large ones
+	MinSize int64
+	MaxSize int64
+	// Depth is the deepest directory level files are placed at, 0 keeps every file at the root
+	Depth int
+	// PackRatio is the fraction of the files placed within packed directories
+	PackRatio float64
+	// Versions is how many versions are written, the first one adds every file
+	Versions int
+	// Churn is the fraction of the live files changed by each version after the first
+	Churn float64
+	// Seed drives every random choice, the same shape and seed always generate the same project
+	Seed int64
+}
+
+// Validate reports the first field of the shape out of its range
+func (s Shape) Validate() error {
+	if s.Files < 1 {
+		return errors.New("files must be at least 1")
+	}
+	if s.MinSize < 0 || s.MaxSize < s.MinSize {
+		return fmt.Errorf("invalid size range %v to %v", s.MinSize, s.MaxSize)
+	}
+	if s.Depth < 0 {
+		return errors.New("depth cannot be negative")
+	}
+	if s.PackRatio < 0 || s.PackRatio > 1 {
+		return fmt.Errorf("pack ratio %v is not between 0 and 1", s.PackRatio)
+	}
+	if s.Versions < 1 {
+		return errors.New("versions must be at least 1")
+	}
+	if s.Churn < 0 || s.Churn > 1 {
+		return fmt.Errorf("churn %v is not between 0 and 1", s.Churn)
+	}
+	return nil
+}
+
+// Generator produces the objects of each version of a synthetic project, deterministically for a given Shape
+type Generator struct {
+	shape   Shape
+	rng     *rand.Rand
+	version int
+	next    int
+	packs   int
+	live    []string
+	index   map[string]int
+}
+
+// NewGenerator returns a generator positioned before the first version of the shape
+func NewGenerator(shape Shape) (*Generator, error) {
+	err := shape.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	packs := 0
+	if shape.PackRatio > 0 {
+		packs = max(1, int(math.Ceil(float64(shape.Files)*shape.PackRatio/packFiles)))
+	}
+
+	return &Generator{
+		shape: shape,
+		rng:   rand.New(rand.NewSource(shape.Seed)),
+		packs: packs,
+		index: make(map[string]int),
+	}, nil
+}
+
+// PackPatterns returns the pack patterns the project must be created with so the pack directories are packed
+func (g *Generator) PackPatterns() []string {
+	if g.packs == 0 {
+		return nil
+	}
+	return []string{"^packs/[^/]+/"}
+}
+
+// Live returns how many files the last generated version holds
+func (g *Generator) Live() int {
+	return len(g.live)
+}
+
+// Next returns the objects of the next version ordered as generated, it returns false once every version was generated
+func (g *Generator) Next() ([]*pb.Object, bool) {
+	if g.version >= g.shape.Versions {
+		return nil, false
+	}
+	g.version++
+
+	if g.version == 1 {
+		objects := make([]*pb.Object, 0, g.shape.Files)
+		for range g.shape.Files {
+			objects = append(objects, g.addFile())
+		}
+		return objects, true
+	}
+
+	changes := int(math.Round(float64(len(g.live)) * g.shape.Churn))
+	if g.shape.Churn > 0 {
+		changes = max(1, changes)
+	}
+
+	touched := make(map[string]bool, changes)
+	objects := make([]*pb.Object, 0, changes)
+	for range changes {
+		roll := g.rng.Float64()
+
+		switch {
+		case roll < 0.15 || len(g.live) == 0:
+			object := g.addFile()
+			touched[object.Path] = true
+			objects = append(objects, object)
+		case roll < 0.3:
+			path := g.live[g.rng.Intn(len(g.live))]
+			if touched[path] {
+				continue
+			}
+			touched[path] = true
+			g.removeLive(path)
+			objects = append(objects, &pb.Object{Path: path, Mode: fileMode, Deleted: true})
+		default:
+			path := g.live[g.rng.Intn(len(g.live))]
+			if touched[path] {
+				continue
+			}
+			touched[path] = true
+			objects = append(objects, g.file(path))
+		}
+	}
+
+	return objects, true
+}
+
+func (g *Generator) addFile() *pb.Object {
+	var builder strings.Builder
+
+	depth := 0
+	if g.shape.Depth > 0 {
+		depth = g.rng.Intn(g.shape.Depth + 1)
+	}
+
+	if g.packs > 0 && g.rng.Float64() < g.shape.PackRatio {
+		fmt.Fprintf(&builder, "packs/p%03d/", g.rng.Intn(g.packs))
+		// packs/pNNN/ takes up two levels of the depth
+		depth = max(0, depth-2)
+	}
+
+	for range depth {
+		fmt.Fprintf(&builder, "d%d/", g.rng.Intn(dirFanout))
+	}
+
+	fmt.Fprintf(&builder, "f%06d.txt", g.next)
+	g.next++
+
+	path := builder.String()
+	g.index[path] = len(g.live)
+	g.live = append(g.live, path)
+
+	return g.file(path)
+}
+
+func (g *Generator) removeLive(path string) {
+	idx := g.index[path]
+	last := g.live[len(g.live)-1]
+
+	g.live[idx] = last
+	g.index[last] = idx
+	g.live = g.live[:len(g.live)-1]
+	delete(g.index, path)
+}
+
+func (g *Generator) file(path string) *pb.Object {
+	size := g.size()
+	content := make([]byte, size)
+	for idx := range content {
+		if idx%80 == 79 {
+			content[idx] = '\n'
+		} else {
+			content[idx] = charset[g.rng.Intn(len(charset))]
+		}
+	}
+
+	return &pb.Object{
+		Path:    path,
+		Mode:    fileMode,
+		Size:    size,
+		Content: content,
+	}
+}
+
+func (g *Generator) size() int64 {
+	if g.shape.MaxSize == g.shape.MinSize {
+		return g.shape.MinSize
+	}
+
+	low := math.Log1p(float64(g.shape.MinSize))
+	high := math.Log1p(float64(g.shape.MaxSize))
+	size := int64(math.Expm1(low + g.rng.Float64()*(high-low)))
+
+	return min(max(size, g.shape.MinSize), g.shape.MaxSize)
+}
+
+// GenerateResult summarizes a generated project
+type GenerateResult struct {
+	Project       int64 `json:"project"`
+	LatestVersion int64 `json:"latestVersion"`
+	Objects       int64 `json:"objects"`
+	Bytes         int64 `json:"bytes"`
+	Live          int   `json:"live"`
+}
+
+func (r *GenerateResult) add(objects []*pb.Object) {
+	r.Objects += int64(len(objects))
+	for _, object := range objects {
+		r.Bytes += object.Size
+	}
+}
+
+// GenerateWithClient creates the project and writes every version of the shape through the API, one committed stage per version
+func GenerateWithClient(ctx context.Context, c *client.Client, project int64, shape Shape) (GenerateResult, error) {
+	ctx, span := telemetry.Start(ctx, "devtools.generate-with-client", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
+
+	result := GenerateResult{Project: project}
+
+	generator, err := NewGenerator(shape)
+	if err != nil {
+		return result, err
+	}
+
+	patterns := strings.Join(generator.PackPatterns(), ",")
+	err = c.NewProject(ctx, project, nil, &patterns)
+	if err != nil {
+		return result, fmt.Errorf("generate project %v: %w", project, err)
+	}
+
+	for objects, ok := generator.Next(); ok; objects, ok = generator.Next() {
+		if len(objects) == 0 {
+			continue
+		}
+
+		_, err = c.Stage(ctx, project, generateStage, objects)
+		if err != nil {
+			return result, fmt.Errorf("generate project %v: %w", project, err)
+		}
+
+		version, _, err := c.CommitStage(ctx, project, generateStage, client.WriteOptions{Message: &generateMessage, Force: true})
+		if err != nil {
+			return result, fmt.Errorf("generate project %v: %w", project, err)
+		}
+
+		result.LatestVersion = version
+		result.add(objects)
+	}
+
+	result.Live = generator.Live()
+	return result, nil
+}
+
+// GenerateWithDb creates the project and writes every version of the shape directly to the database, one transaction per version
+func GenerateWithDb(ctx context.Context, conn db.DbConnector, project int64, shape Shape) (GenerateResult, error) {
+	ctx, span := telemetry.Start(ctx, "devtools.generate-with-db", trace.WithAttributes(
+		key.Project.Attribute(project),
+	))
+	defer span.End()
+
+	result := GenerateResult{Project: project}
+
+	generator, err := NewGenerator(shape)
+	if err != nil {
+		return result, err
+	}
+
+	tx, close, err := conn.Connect(ctx)
+	if err != nil {
+		return result, fmt.Errorf("generate project %v connect: %w", project, err)
+	}
+
+	err = db.CreateProject(ctx, tx, project, generator.PackPatterns(), nil)
+	if err == nil {
+		err = tx.Commit(ctx)
+	}
+	close(ctx)
+	if err != nil {
+		return result, fmt.Errorf("generate project %v: %w", project, err)
+	}
+
+	for objects, ok := generator.Next(); ok; objects, ok = generator.Next() {
+		if len(objects) == 0 {
+			continue
+		}
+
+		version, err := writeVersion(ctx, conn, project, objects)
+		if err != nil {
+			return result, fmt.Errorf("generate project %v: %w", project, err)
+		}
+
+		result.LatestVersion = version
+		result.add(objects)
+	}
+
+	result.Live = generator.Live()
+	return result, nil
+}
+
+func writeVersion(ctx context.Context, conn db.DbConnector, project int64, objects []*pb.Object) (int64, error) {
+	tx, close, err := conn.Connect(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("connect: %w", err)
+	}
+	defer close(ctx)
+
+	latestVersion, err := db.LockLatestVersion(ctx, tx, project)
+	if err != nil {
+		return -1, err
+	}
+
+	packManager, err := db.NewPackManager(ctx, tx, project)
+	if err != nil {
+		return -1, err
+	}
+
+	contentEncoder := db.NewContentEncoder()
+	defer contentEncoder.Close()
+
+	nextVersion := latestVersion + 1
+
+	packedBuffer := make(map[string][]*pb.Object)
+	for _, object := range objects {
+		packParent := packManager.IsPathPacked(object.Path)
+		if packParent != nil {
+			packedBuffer[*packParent] = append(packedBuffer[*packParent], object)
+			continue
+		}
+
+		if object.Deleted {
+			err = db.DeleteObject(ctx, tx, project, nextVersion, object.Path)
+		} else {
+			_, err = db.UpdateObject(ctx, tx, conn, contentEncoder, project, nextVersion, object)
+		}
+		if err != nil {
+			return -1, err
+		}
+	}
+
+	for parent, packedObjects := range packedBuffer {
+		_, err = db.UpdatePackedObjects(ctx, tx, conn, project, nextVersion, parent, packedObjects)
+		if err != nil {
+			return -1, fmt.Errorf("packed objects for %v: %w", parent, err)
+		}
+	}
+
+	err = db.UpdateLatestVersion(ctx, tx, project, nextVersion)
+	if err != nil {
+		return -1, err
+	}
+
+	err = db.AnnotateVersion(ctx, tx, project, nextVersion, &generateMessage, nil)
+	if err != nil {
+		return -1, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nextVersion, nil
+}
@@ -0,0 +1,74 @@
+package devtools
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateAll(t *testing.T, shape Shape) [][]*pb.Object {
+	generator, err := NewGenerator(shape)
+	require.NoError(t, err)
+
+	var versions [][]*pb.Object
+	for objects, ok := generator.Next(); ok; objects, ok = generator.Next() {
+		versions = append(versions, objects)
+	}
+	return versions
+}
+
+func TestGeneratorIsDeterministic(t *testing.T) {
+	shape := Shape{Files: 200, MinSize: 1, MaxSize: 4096, Depth: 3, PackRatio: 0.25, Versions: 5, Churn: 0.1, Seed: 42}
+
+	first := generateAll(t, shape)
+	second := generateAll(t, shape)
+	require.Len(t, first, 5)
+	assert.Equal(t, first, second)
+
+	shape.Seed = 43
+	assert.NotEqual(t, first, generateAll(t, shape))
+}
+
+func TestGeneratorShape(t *testing.T) {
+	shape := Shape{Files: 400, MinSize: 10, MaxSize: 1000, Depth: 4, PackRatio: 0.5, Versions: 3, Churn: 0.1, Seed: 7}
+
+	versions := generateAll(t, shape)
+	require.Len(t, versions, 3)
+	require.Len(t, versions[0], 400)
+
+	packed := 0
+	for _, object := range versions[0] {
+		assert.GreaterOrEqual(t, object.Size, int64(10))
+		assert.LessOrEqual(t, object.Size, int64(1000))
+		assert.Equal(t, object.Size, int64(len(object.Content)))
+		assert.LessOrEqual(t, strings.Count(object.Path, "/"), 4)
+
+		if strings.HasPrefix(object.Path, "packs/") {
+			packed++
+		}
+	}
+	assert.InDelta(t, 200, packed, 60)
+
+	for _, objects := range versions[1:] {
+		assert.NotEmpty(t, objects)
+		assert.LessOrEqual(t, len(objects), 45)
+	}
+}
+
+func TestShapeValidate(t *testing.T) {
+	valid := Shape{Files: 1, MaxSize: 10, Versions: 1}
+	require.NoError(t, valid.Validate())
+
+	for _, shape := range []Shape{
+		{Files: 0, MaxSize: 10, Versions: 1},
+		{Files: 1, MinSize: 20, MaxSize: 10, Versions: 1},
+		{Files: 1, MaxSize: 10, Versions: 1, PackRatio: 1.5},
+		{Files: 1, MaxSize: 10, Versions: 0},
+		{Files: 1, MaxSize: 10, Versions: 1, Churn: -0.1},
+	} {
+		assert.Error(t, shape.Validate(), "shape %+v", shape)
+	}
+}
@@ -0,0 +1,296 @@
+package proxy
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"go.uber.org/zap"
+)
+
+type contentKind string
+
+const (
+	// fileContents are the contents of regular files, advertised upstream as known hashes
+	fileContents contentKind = "files"
+	// packContents are whole packs, advertised upstream as known packs
+	packContents contentKind = "packs"
+)
+
+var contentKinds = []contentKind{fileContents, packContents}
+
+// Cache stores the contents of files and packs on disk under their content hash, so every project and version holding
+// the same content shares it. Contents never change for a given hash, so nothing is invalidated when projects are
+// rolled back or recreated: the object listings are always read upstream and only name the hashes to use.
+type Cache struct {
+	dir      string
+	maxBytes int64
+
+	// pins is held for reading by the reads relying on the hashes they advertised upstream, Prune holds it for writing
+	pins sync.RWMutex
+
+	lock   sync.Mutex
+	hashes map[contentKind]map[db.Hash]bool
+}
+
+// NewCache creates a cache in dir, maxBytes bounds the size of the stored contents and 0 means no bound.
+// Contents already stored in dir are indexed so they are advertised upstream right away.
+func NewCache(dir string, maxBytes int64) (*Cache, error) {
+	cache := &Cache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		hashes:   make(map[contentKind]map[db.Hash]bool),
+	}
+
+	for _, kind := range contentKinds {
+		cache.hashes[kind] = make(map[db.Hash]bool)
+
+		path := filepath.Join(dir, string(kind))
+		err := os.MkdirAll(path, 0755)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create proxy cache dir %v: %w", path, err)
+		}
+
+		err = filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+
+			bytes, err := hex.DecodeString(entry.Name())
+			if err != nil {
+				return nil
+			}
+			hash, err := db.HashFromBytes(bytes)
+			if err == nil {
+				cache.hashes[kind][hash] = true
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("cannot index proxy cache dir %v: %w", path, err)
+		}
+	}
+
+	return cache, nil
+}
+
+func (c *Cache) contentPath(kind contentKind, hash db.Hash) string {
+	name := hex.EncodeToString(hash.Bytes())
+	return filepath.Join(c.dir, string(kind), name[:2], name)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".tmp_")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+// pin keeps the stored contents from being pruned until the returned function is called.
+func (c *Cache) pin() func() {
+	c.pins.RLock()
+	return c.pins.RUnlock
+}
+
+// Hashes returns up to limit hashes of the stored contents of a kind.
+func (c *Cache) Hashes(kind contentKind, limit int) [][]byte {
+	if limit <= 0 {
+		return nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	hashes := make([][]byte, 0, min(limit, len(c.hashes[kind])))
+	for hash := range c.hashes[kind] {
+		if len(hashes) >= limit {
+			break
+		}
+		hashes = append(hashes, hash.Bytes())
+	}
+	return hashes
+}
+
+// Get returns the stored content of hash. Contents that went missing or no longer match their hash are forgotten,
+// so they are not advertised upstream again.
+func (c *Cache) Get(kind contentKind, hash db.Hash) ([]byte, bool) {
+	path := c.contentPath(kind, hash)
+
+	data, err := os.ReadFile(path)
+	if err != nil || db.HashContent(data) != hash {
+		c.forget(kind, hash)
+		_ = os.Remove(path)
+		return nil, false
+	}
+
+	// the modification time orders the contents pruned first
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return data, true
+}
+
+// Put stores data under its hash, contents already stored are left as is.
+func (c *Cache) Put(kind contentKind, hash db.Hash, data []byte) error {
+	c.lock.Lock()
+	stored := c.hashes[kind][hash]
+	c.lock.Unlock()
+	if stored {
+		return nil
+	}
+
+	path := c.contentPath(kind, hash)
+	err := writeFileAtomic(path, data)
+	if err != nil {
+		return fmt.Errorf("cannot write proxy cache content %v: %w", path, err)
+	}
+
+	c.lock.Lock()
+	c.hashes[kind][hash] = true
+	c.lock.Unlock()
+
+	return nil
+}
+
+func (c *Cache) forget(kind contentKind, hash db.Hash) {
+	c.lock.Lock()
+	delete(c.hashes[kind], hash)
+	c.lock.Unlock()
+}
+
+type cachedContent struct {
+	kind    contentKind
+	hash    db.Hash
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// Prune removes the least recently used contents until the stored contents fit in maxBytes.
+// It waits for the reads relying on the stored contents to finish.
+func (c *Cache) Prune() (int64, error) {
+	if c.maxBytes <= 0 {
+		return 0, nil
+	}
+
+	c.pins.Lock()
+	defer c.pins.Unlock()
+
+	var contents []cachedContent
+	var total int64
+
+	for _, kind := range contentKinds {
+		err := filepath.WalkDir(filepath.Join(c.dir, string(kind)), func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || strings.HasPrefix(entry.Name(), ".tmp_") {
+				return nil
+			}
+
+			bytes, err := hex.DecodeString(entry.Name())
+			if err != nil {
+				return nil
+			}
+			hash, err := db.HashFromBytes(bytes)
+			if err != nil {
+				return nil
+			}
+
+			info, err := entry.Info()
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			contents = append(contents, cachedContent{kind: kind, hash: hash, path: path, size: info.Size(), modTime: info.ModTime()})
+			total += info.Size()
+			return nil
+		})
+		if err != nil {
+			return 0, fmt.Errorf("cannot walk proxy cache: %w", err)
+		}
+	}
+
+	if total <= c.maxBytes {
+		return 0, nil
+	}
+
+	slices.SortFunc(contents, func(a, b cachedContent) int {
+		return a.modTime.Compare(b.modTime)
+	})
+
+	var removed int64
+	for _, content := range contents {
+		if total <= c.maxBytes {
+			break
+		}
+
+		c.forget(content.kind, content.hash)
+		err := os.Remove(content.path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, fmt.Errorf("cannot remove proxy cache content %v: %w", content.path, err)
+		}
+
+		total -= content.size
+		removed += content.size
+	}
+
+	return removed, nil
+}
+
+// PruneEvery prunes the cache every interval until ctx is done.
+func (c *Cache) PruneEvery(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				removed, err := c.Prune()
+				if err != nil {
+					logger.Error(ctx, "could not prune proxy cache", zap.Error(err))
+					continue
+				}
+				if removed > 0 {
+					logger.Info(ctx, "pruned proxy cache", key.Bytes.Field(removed))
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,45 @@
+package proxy
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// frame is a message forwarded without being decoded.
+type frame struct {
+	payload []byte
+}
+
+// codec passes frames through untouched and falls back to protobuf for every other message,
+// so services registered next to the proxy handler keep working.
+type codec struct{}
+
+func (codec) Marshal(v any) ([]byte, error) {
+	if f, ok := v.(*frame); ok {
+		return f.payload, nil
+	}
+
+	message, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("proxy codec cannot marshal %T", v)
+	}
+	return proto.Marshal(message)
+}
+
+func (codec) Unmarshal(data []byte, v any) error {
+	if f, ok := v.(*frame); ok {
+		f.payload = append(f.payload[:0], data...)
+		return nil
+	}
+
+	message, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("proxy codec cannot unmarshal into %T", v)
+	}
+	return proto.Unmarshal(data, message)
+}
+
+func (codec) Name() string {
+	return "proto"
+}
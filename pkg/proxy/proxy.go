@@ -0,0 +1,308 @@
+package proxy
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/klauspost/compress/s2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const getCompressMethod = "/pb.Fs/GetCompress"
+
+// Proxy serves the Fs API from an upstream server and keeps the contents read through GetCompress in its cache.
+// Every GetCompress call is still made upstream, so the objects listed always match the upstream project, but the
+// cached contents are advertised as known hashes and known packs and the upstream server only sends their hash.
+// Every other call is forwarded upstream untouched. Authentication is left to the upstream server: the caller's
+// metadata is forwarded and cached contents are only served within a response the upstream server sent to the caller.
+type Proxy struct {
+	upstream *grpc.ClientConn
+	fs       pb.FsClient
+	cache    *Cache
+}
+
+func New(upstream *grpc.ClientConn, cache *Cache) *Proxy {
+	return &Proxy{upstream: upstream, fs: pb.NewFsClient(upstream), cache: cache}
+}
+
+// ServerOptions route every call without a registered service through the proxy.
+func (p *Proxy) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ForceServerCodec(codec{}),
+		grpc.UnknownServiceHandler(p.handle),
+	}
+}
+
+func outgoingContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return metadata.NewOutgoingContext(ctx, md.Copy())
+}
+
+func (p *Proxy) handle(_ any, stream grpc.ServerStream) error {
+	method, ok := grpc.MethodFromServerStream(stream)
+	if !ok {
+		return status.Error(codes.Internal, "proxy: unknown method")
+	}
+
+	if method == getCompressMethod {
+		return p.getCompress(stream)
+	}
+	return p.forward(stream, method)
+}
+
+var streamDesc = &grpc.StreamDesc{ServerStreams: true, ClientStreams: true}
+
+// forward pipes the call to the upstream server in both directions.
+func (p *Proxy) forward(stream grpc.ServerStream, method string) error {
+	ctx, cancel := context.WithCancel(outgoingContext(stream.Context()))
+	defer cancel()
+
+	upstream, err := p.upstream.NewStream(ctx, streamDesc, method, grpc.ForceCodec(codec{}))
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			request := &frame{}
+			err := stream.RecvMsg(request)
+			if err == io.EOF {
+				_ = upstream.CloseSend()
+				return
+			}
+			if err != nil {
+				// the caller went away, stop the upstream call too
+				cancel()
+				return
+			}
+
+			// a failed send is reported by the RecvMsg calls below
+			if upstream.SendMsg(request) != nil {
+				return
+			}
+		}
+	}()
+
+	header, err := upstream.Header()
+	if err == nil {
+		err = stream.SendHeader(header)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		response := &frame{}
+		err := upstream.RecvMsg(response)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			stream.SetTrailer(upstream.Trailer())
+			return err
+		}
+
+		err = stream.SendMsg(response)
+		if err != nil {
+			return err
+		}
+	}
+
+	stream.SetTrailer(upstream.Trailer())
+	return nil
+}
+
+func hashSet(hashes [][]byte) map[db.Hash]bool {
+	set := make(map[db.Hash]bool, len(hashes))
+	for _, bytes := range hashes {
+		hash, err := db.HashFromBytes(bytes)
+		if err == nil {
+			set[hash] = true
+		}
+	}
+	return set
+}
+
+// getCompress reads the objects upstream with the cached contents advertised as known, the responses are then
+// completed from the cache so the caller only receives hash only entries for the contents it advertised itself.
+func (p *Proxy) getCompress(stream grpc.ServerStream) error {
+	request := &pb.GetCompressRequest{}
+	err := stream.RecvMsg(request)
+	if err != nil {
+		return err
+	}
+
+	release := p.cache.pin()
+	defer release()
+
+	callerHashes := hashSet(request.KnownHashes)
+	callerPacks := hashSet(request.KnownPacks)
+
+	request.KnownHashes = append(request.KnownHashes, p.cache.Hashes(fileContents, db.MaxKnownHashes-len(request.KnownHashes))...)
+	request.KnownPacks = append(request.KnownPacks, p.cache.Hashes(packContents, db.MaxKnownHashes-len(request.KnownPacks))...)
+
+	ctx, cancel := context.WithCancel(outgoingContext(stream.Context()))
+	defer cancel()
+
+	upstream, err := p.fs.GetCompress(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	header, err := upstream.Header()
+	if err == nil {
+		err = stream.SendHeader(header)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		response, err := upstream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			stream.SetTrailer(upstream.Trailer())
+			return err
+		}
+
+		err = p.complete(response, callerHashes, callerPacks)
+		if err != nil {
+			return err
+		}
+
+		err = stream.SendMsg(response)
+		if err != nil {
+			return err
+		}
+	}
+
+	stream.SetTrailer(upstream.Trailer())
+	return nil
+}
+
+// complete fills in the contents the upstream server left out because the proxy advertised them and stores the
+// contents it sent. A failed cache write only costs a cache miss on the next read.
+func (p *Proxy) complete(response *pb.GetCompressResponse, callerHashes, callerPacks map[db.Hash]bool) error {
+	if response.Known {
+		hash, err := db.HashFromBytes(response.Hash)
+		if err != nil {
+			return status.Errorf(codes.Internal, "proxy: known pack %v: %v", response.GetPackPath(), err)
+		}
+		if callerPacks[hash] {
+			return nil
+		}
+
+		content, ok := p.cache.Get(packContents, hash)
+		if !ok {
+			return status.Errorf(codes.Unavailable, "proxy: cached pack %v is missing", response.GetPackPath())
+		}
+
+		response.Bytes = content
+		response.Known = false
+		return nil
+	}
+
+	if len(response.Bytes) == 0 {
+		return nil
+	}
+
+	if response.PackPath != nil {
+		hash := db.HashContent(response.Bytes)
+		if bytes.Equal(hash.Bytes(), response.Hash) {
+			_ = p.cache.Put(packContents, hash, response.Bytes)
+		}
+		return nil
+	}
+
+	rewritten, err := p.completeTar(response.Bytes, callerHashes)
+	if err != nil {
+		return err
+	}
+	if rewritten != nil {
+		hash := db.HashContent(rewritten)
+		response.Bytes = rewritten
+		response.Hash = hash.Bytes()
+	}
+
+	return nil
+}
+
+// completeTar stores the regular files of a tar and replaces the hash only entries the caller did not advertise with
+// their cached content. It returns nil when the tar is left as is.
+func (p *Proxy) completeTar(content []byte, callerHashes map[db.Hash]bool) ([]byte, error) {
+	reader := db.NewTarReader()
+	defer reader.Close()
+	reader.FromBytes(content)
+
+	var buffer bytes.Buffer
+	s2Writer := s2.NewWriter(&buffer)
+	defer s2Writer.Close()
+	tarWriter := tar.NewWriter(s2Writer)
+	changed := false
+
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "proxy: read tar: %v", err)
+		}
+
+		entry, err := reader.ReadContent()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "proxy: read tar %v: %v", header.Name, err)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeReg:
+			_ = p.cache.Put(fileContents, db.HashContent(entry), entry)
+		case pb.TarKnown:
+			hash, err := db.HashFromBytes(entry)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "proxy: known object %v: %v", header.Name, err)
+			}
+			if callerHashes[hash] {
+				break
+			}
+
+			cached, ok := p.cache.Get(fileContents, hash)
+			if !ok {
+				return nil, status.Errorf(codes.Unavailable, "proxy: cached content of %v is missing", header.Name)
+			}
+
+			header.Typeflag = tar.TypeReg
+			header.Size = int64(len(cached))
+			entry = cached
+			changed = true
+		}
+
+		err = tarWriter.WriteHeader(header)
+		if err == nil && len(entry) > 0 {
+			_, err = tarWriter.Write(entry)
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "proxy: write tar %v: %v", header.Name, err)
+		}
+	}
+
+	err := errors.Join(tarWriter.Close(), s2Writer.Close())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "proxy: close tar: %v", err)
+	}
+
+	if !changed {
+		return nil, nil
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,47 @@
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// DialUpstream connects to the upstream server without credentials of its own, the proxy forwards those of its callers.
+func DialUpstream(ctx context.Context, host string, port uint16, dialTimeout time.Duration) (*grpc.ClientConn, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, fmt.Errorf("load system cert pool: %w", err)
+	}
+
+	creds := credentials.NewTLS(&tls.Config{
+		RootCAs:            pool,
+		InsecureSkipVerify: os.Getenv("DL_SKIP_SSL_VERIFICATION") == "1",
+		ServerName:         host,
+	})
+
+	connectCtx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+
+	return grpc.DialContext(connectCtx, fmt.Sprintf("%s:%d", host, port),
+		grpc.WithTransportCredentials(creds),
+		grpc.WithReadBufferSize(client.BUFFER_SIZE),
+		grpc.WithWriteBufferSize(client.BUFFER_SIZE),
+		grpc.WithInitialConnWindowSize(client.INITIAL_CONN_WINDOW_SIZE),
+		grpc.WithInitialWindowSize(client.INITIAL_WINDOW_SIZE),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(client.MAX_MESSAGE_SIZE),
+			grpc.MaxCallSendMsgSize(client.MAX_MESSAGE_SIZE),
+		),
+		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()),
+		grpc.WithDefaultServiceConfig(`{"loadBalancingConfig": [{ "round_robin": {} }]}`),
+	)
+}
@@ -0,0 +1,96 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamdChunkSize stays well below clamd's default StreamMaxLength chunking
+const clamdChunkSize = 64 * 1024
+
+// Clamd scans contents with a clamd daemon through its INSTREAM command.
+type Clamd struct {
+	// Address is either host:port or the path of a unix socket
+	Address string
+	Timeout time.Duration
+}
+
+func NewClamd(address string) *Clamd {
+	return &Clamd{Address: address, Timeout: time.Minute}
+}
+
+func (c *Clamd) Name() string {
+	return "clamd"
+}
+
+func (c *Clamd) Scan(ctx context.Context, content []byte) (Verdict, error) {
+	network := "tcp"
+	if strings.HasPrefix(c.Address, "/") {
+		network = "unix"
+	}
+
+	dialer := net.Dialer{Timeout: c.Timeout}
+	conn, err := dialer.DialContext(ctx, network, c.Address)
+	if err != nil {
+		return Verdict{}, fmt.Errorf("clamd connect %v: %w", c.Address, err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(c.Timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	_ = conn.SetDeadline(deadline)
+
+	writer := bufio.NewWriter(conn)
+	_, err = writer.WriteString("zINSTREAM\x00")
+	if err != nil {
+		return Verdict{}, fmt.Errorf("clamd write command: %w", err)
+	}
+
+	// every chunk is prefixed by its length, a zero length chunk ends the stream
+	var size [4]byte
+	for start := 0; start < len(content); start += clamdChunkSize {
+		chunk := content[start:min(start+clamdChunkSize, len(content))]
+		binary.BigEndian.PutUint32(size[:], uint32(len(chunk)))
+		_, _ = writer.Write(size[:])
+		_, err = writer.Write(chunk)
+		if err != nil {
+			return Verdict{}, fmt.Errorf("clamd write content: %w", err)
+		}
+	}
+	binary.BigEndian.PutUint32(size[:], 0)
+	_, _ = writer.Write(size[:])
+
+	err = writer.Flush()
+	if err != nil {
+		return Verdict{}, fmt.Errorf("clamd write content: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadBytes(0)
+	if err != nil {
+		return Verdict{}, fmt.Errorf("clamd read reply: %w", err)
+	}
+
+	return parseClamdReply(string(bytes.TrimRight(reply, "\x00")))
+}
+
+// parseClamdReply parses replies such as "stream: OK" or "stream: Eicar-Signature FOUND".
+func parseClamdReply(reply string) (Verdict, error) {
+	result := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+
+	switch {
+	case result == "OK":
+		return Verdict{}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return Verdict{Infected: true, Signature: strings.TrimSuffix(result, " FOUND")}, nil
+	default:
+		return Verdict{}, fmt.Errorf("clamd scan failed: %v", reply)
+	}
+}
@@ -0,0 +1,93 @@
+package scan
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClamd answers INSTREAM commands, flagging every stream containing "EICAR"
+func fakeClamd(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+
+				command, err := reader.ReadString(0)
+				if err != nil || command != "zINSTREAM\x00" {
+					_, _ = conn.Write([]byte("UNKNOWN COMMAND\x00"))
+					return
+				}
+
+				var content bytes.Buffer
+				for {
+					var size uint32
+					err = binary.Read(reader, binary.BigEndian, &size)
+					if err != nil {
+						return
+					}
+					if size == 0 {
+						break
+					}
+					_, err = io.CopyN(&content, reader, int64(size))
+					if err != nil {
+						return
+					}
+				}
+
+				if bytes.Contains(content.Bytes(), []byte("EICAR")) {
+					_, _ = conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00"))
+				} else {
+					_, _ = conn.Write([]byte("stream: OK\x00"))
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestClamdScan(t *testing.T) {
+	clamd := NewClamd(fakeClamd(t))
+
+	verdict, err := clamd.Scan(context.Background(), []byte("hello world"))
+	require.NoError(t, err)
+	assert.False(t, verdict.Infected)
+
+	// spans several chunks with the marker in the last one
+	content := append(bytes.Repeat([]byte("a"), 3*clamdChunkSize), []byte("EICAR")...)
+	verdict, err = clamd.Scan(context.Background(), content)
+	require.NoError(t, err)
+	assert.True(t, verdict.Infected)
+	assert.Equal(t, "Eicar-Test-Signature", verdict.Signature)
+
+	verdict, err = clamd.Scan(context.Background(), nil)
+	require.NoError(t, err)
+	assert.False(t, verdict.Infected)
+}
+
+func TestParseClamdReply(t *testing.T) {
+	_, err := parseClamdReply("INSTREAM size limit exceeded. ERROR")
+	assert.Error(t, err)
+
+	verdict, err := parseClamdReply("stream: Win.Test.EICAR_HDB-1 FOUND")
+	require.NoError(t, err)
+	assert.Equal(t, Verdict{Infected: true, Signature: "Win.Test.EICAR_HDB-1"}, verdict)
+}
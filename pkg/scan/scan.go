@@ -0,0 +1,17 @@
+package scan
+
+import "context"
+
+// Verdict is the outcome of scanning a single content.
+type Verdict struct {
+	Infected bool
+	// Signature names what was found, only set when Infected
+	Signature string
+}
+
+// Scanner inspects contents for malware, implementations must be safe for concurrent use.
+// Scan returns an error when the content could not be scanned, it is then retried later.
+type Scanner interface {
+	Name() string
+	Scan(ctx context.Context, content []byte) (Verdict, error)
+}
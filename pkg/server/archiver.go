@@ -0,0 +1,216 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"github.com/gadget-inc/dateilager/pkg/archive"
+	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
+)
+
+type ArchiveScheduleConfig struct {
+	Schedule *CronSchedule
+	Store    archive.Store
+	// only projects having every one of these labels are archived
+	Selector map[string]string
+	// how long archives are kept, they are deleted from the store by the first run after they expire, 0 keeps them forever
+	Retention time.Duration
+}
+
+type ScheduledArchiveResult struct {
+	Archived int64
+	Failed   int64
+	Expired  int64
+}
+
+// ScheduleArchives exports a snapshot of every selected project each time the schedule fires until ctx is done.
+func (s *Server) ScheduleArchives(ctx context.Context, dbConn db.DbConnector, lookup *db.ContentLookup, config ArchiveScheduleConfig) {
+	go func() {
+		for {
+			next := config.Schedule.Next(time.Now())
+			if next.IsZero() {
+				logger.Warn(ctx, "archive schedule never fires again, stopping the archiver")
+				return
+			}
+
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				result, err := RunScheduledArchives(ctx, dbConn, lookup, config, next)
+				if err != nil {
+					logger.Error(ctx, "scheduled archives failed", zap.Error(err))
+					continue
+				}
+
+				logger.Info(ctx, "scheduled archives done",
+					key.Count.Field(result.Archived),
+					key.FailedCount.Field(result.Failed),
+					key.PrunedCount.Field(result.Expired),
+				)
+			}
+		}
+	}()
+}
+
+// RunScheduledArchives archives the selected projects not archived since scheduledAt, then deletes the expired archives.
+// Replicas sharing a database coordinate through an advisory lock so each project is archived once per run. A project
+// failing to archive is logged and retried by the next run.
+func RunScheduledArchives(ctx context.Context, dbConn db.DbConnector, lookup *db.ContentLookup, config ArchiveScheduleConfig, scheduledAt time.Time) (ScheduledArchiveResult, error) {
+	ctx, span := telemetry.Start(ctx, "server.scheduled-archives")
+	defer span.End()
+
+	result, err := runScheduledArchives(ctx, dbConn, lookup, config, scheduledAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return result, err
+	}
+
+	span.SetAttributes(
+		key.Count.Attribute(result.Archived),
+		key.FailedCount.Attribute(result.Failed),
+		key.PrunedCount.Attribute(result.Expired),
+	)
+
+	return result, nil
+}
+
+func runScheduledArchives(ctx context.Context, dbConn db.DbConnector, lookup *db.ContentLookup, config ArchiveScheduleConfig, scheduledAt time.Time) (ScheduledArchiveResult, error) {
+	var result ScheduledArchiveResult
+
+	tx, close, err := dbConn.Connect(ctx)
+	if err != nil {
+		return result, fmt.Errorf("scheduled archives connect: %w", err)
+	}
+	defer close(ctx)
+
+	locked, err := db.TryLockArchiver(ctx, tx)
+	if err != nil || !locked {
+		return result, err
+	}
+
+	candidates, err := db.ArchiveCandidates(ctx, tx, config.Selector, scheduledAt)
+	if err != nil {
+		return result, err
+	}
+
+	// tx only holds the advisory lock while projects are archived, each one reads and records through its own short
+	// transactions so uploads never run inside one
+	for _, candidate := range candidates {
+		err = archiveProject(ctx, dbConn, lookup, config, candidate)
+		if err != nil {
+			logger.Error(ctx, "archive project failed", key.Project.Field(candidate.Project), key.Version.Field(candidate.Version), zap.Error(err))
+			result.Failed += 1
+			continue
+		}
+		result.Archived += 1
+	}
+
+	expired, err := db.ExpiredArchives(ctx, tx, time.Now())
+	if err != nil {
+		return result, err
+	}
+
+	for _, record := range expired {
+		err = config.Store.Delete(ctx, record.Name)
+		if err != nil {
+			logger.Error(ctx, "delete expired archive failed", key.Archive.Field(record.Archive.Id), key.Location.Field(record.Archive.Location), zap.Error(err))
+			continue
+		}
+
+		err = db.DeleteArchive(ctx, tx, record.Archive.Id)
+		if err != nil {
+			return result, err
+		}
+		result.Expired += 1
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return result, fmt.Errorf("scheduled archives commit tx: %w", err)
+	}
+
+	return result, nil
+}
+
+// archiveProject spools the snapshot of a project to a temporary file within its own transaction, uploads it with no
+// transaction open so a slow store never holds one, then records it. The upload happens before the record so a failure
+// leaves at worst an unrecorded artifact behind, never a record without its artifact.
+func archiveProject(ctx context.Context, dbConn db.DbConnector, lookup *db.ContentLookup, config ArchiveScheduleConfig, candidate db.ArchiveCandidate) error {
+	spool, err := os.CreateTemp("", "dl-archive-*.tar.s2")
+	if err != nil {
+		return fmt.Errorf("archive project %v create spool: %w", candidate.Project, err)
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	objects, err := spoolArchive(ctx, dbConn, lookup, candidate, spool)
+	if err != nil {
+		return err
+	}
+
+	size, err := spool.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("archive project %v spool size: %w", candidate.Project, err)
+	}
+
+	_, err = spool.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("archive project %v rewind spool: %w", candidate.Project, err)
+	}
+
+	now := time.Now().UTC()
+	name := fmt.Sprintf("%d/%d-%s.tar.s2", candidate.Project, candidate.Version, now.Format("20060102T150405Z"))
+
+	location, err := config.Store.Put(ctx, name, spool)
+	if err != nil {
+		return err
+	}
+
+	var expiresAt *time.Time
+	if config.Retention > 0 {
+		expires := now.Add(config.Retention)
+		expiresAt = &expires
+	}
+
+	tx, close, err := dbConn.Connect(ctx)
+	if err != nil {
+		return fmt.Errorf("archive project %v connect: %w", candidate.Project, err)
+	}
+	defer close(ctx)
+
+	id, err := db.RecordArchive(ctx, tx, candidate, name, location, size, objects, expiresAt)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("archive project %v commit tx: %w", candidate.Project, err)
+	}
+
+	logger.Info(ctx, "archived project", key.Project.Field(candidate.Project), key.Version.Field(candidate.Version), key.Archive.Field(id), key.Location.Field(location))
+	return nil
+}
+
+// spoolArchive writes the snapshot of a project to w and returns the number of objects in it.
+func spoolArchive(ctx context.Context, dbConn db.DbConnector, lookup *db.ContentLookup, candidate db.ArchiveCandidate, w io.Writer) (int64, error) {
+	tx, close, err := dbConn.Connect(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("archive project %v connect: %w", candidate.Project, err)
+	}
+	defer close(ctx)
+
+	return db.WriteArchive(ctx, tx, lookup, candidate.Project, candidate.Version, w)
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
+)
+
+type CacheScheduleConfig struct {
+	Schedule *CronSchedule
+	Sources  db.CacheSources
+	// number of packs to include in each cache version
+	Count int64
+	// number of cache versions to keep, older ones are deleted after each run
+	Keep int64
+}
+
+type ScheduledCacheResult struct {
+	// false when another replica already created a cache version for this run
+	Created   bool
+	Version   int64
+	Pruned    int64
+	Reclaimed int64
+}
+
+// ScheduleCacheCreation creates a cache version each time the schedule fires until ctx is done.
+func (s *Server) ScheduleCacheCreation(ctx context.Context, dbConn db.DbConnector, config CacheScheduleConfig) {
+	go func() {
+		for {
+			next := config.Schedule.Next(time.Now())
+			if next.IsZero() {
+				logger.Warn(ctx, "cache schedule never fires again, stopping the cache scheduler")
+				return
+			}
+
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				result, err := RunScheduledCache(ctx, dbConn, config, next)
+				if err != nil {
+					logger.Error(ctx, "scheduled cache creation failed", zap.Error(err))
+					continue
+				}
+
+				logger.Info(ctx, "scheduled cache creation done",
+					key.CacheCreated.Field(result.Created),
+					key.Version.Field(result.Version),
+					key.PrunedCount.Field(result.Pruned),
+					key.Count.Field(result.Reclaimed),
+				)
+			}
+		}
+	}()
+}
+
+// RunScheduledCache creates a cache version for the run scheduled at scheduledAt, then prunes old versions and reclaims their content.
+// Replicas sharing a database coordinate through an advisory lock so each run creates a single cache version.
+func RunScheduledCache(ctx context.Context, dbConn db.DbConnector, config CacheScheduleConfig, scheduledAt time.Time) (ScheduledCacheResult, error) {
+	ctx, span := telemetry.Start(ctx, "server.scheduled-cache")
+	defer span.End()
+
+	result, err := runScheduledCache(ctx, dbConn, config, scheduledAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return result, err
+	}
+
+	span.SetAttributes(
+		key.CacheCreated.Attribute(result.Created),
+		key.Version.Attribute(result.Version),
+		key.PrunedCount.Attribute(result.Pruned),
+		key.Count.Attribute(result.Reclaimed),
+	)
+
+	return result, nil
+}
+
+func runScheduledCache(ctx context.Context, dbConn db.DbConnector, config CacheScheduleConfig, scheduledAt time.Time) (ScheduledCacheResult, error) {
+	var result ScheduledCacheResult
+
+	tx, close, err := dbConn.Connect(ctx)
+	if err != nil {
+		return result, fmt.Errorf("scheduled cache connect: %w", err)
+	}
+	defer close(ctx)
+
+	locked, err := db.TryLockCacheScheduler(ctx, tx)
+	if err != nil || !locked {
+		return result, err
+	}
+
+	done, err := db.CacheVersionCreatedSince(ctx, tx, scheduledAt)
+	if err != nil || done {
+		return result, err
+	}
+
+	result.Version, err = db.CreateCacheFromSources(ctx, tx, config.Sources, config.Count)
+	if err != nil {
+		return result, err
+	}
+	result.Created = true
+
+	var hashes []db.Hash
+	result.Pruned, hashes, err = db.PruneCacheVersions(ctx, tx, config.Keep)
+	if err != nil {
+		return result, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return result, fmt.Errorf("scheduled cache commit tx: %w", err)
+	}
+
+	// pruned versions share most of their packs with the kept ones, GcContentHashes only removes what nothing references anymore
+	result.Reclaimed, err = db.GcContentHashes(ctx, dbConn, hashes)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
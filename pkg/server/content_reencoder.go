@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
+)
+
+type ContentReencodeConfig struct {
+	// encoding every loose content is re-encoded to, it should be the encoding servers write
+	Encoding db.ContentEncoding
+	// how long to wait between batches, so the re-encode does not compete with requests for the database
+	Interval time.Duration
+	// number of contents walked per transaction
+	BatchSize int64
+}
+
+// ReencodeContents re-encodes the stored contents to config.Encoding, one batch every config.Interval, until every
+// content was walked or ctx is done. Replicas sharing a database take turns, the progress survives restarts.
+func (s *Server) ReencodeContents(ctx context.Context, dbConn db.DbConnector, config ContentReencodeConfig) {
+	go func() {
+		decoder := db.NewContentDecoder()
+
+		for {
+			progress, err := RunContentReencode(ctx, dbConn, decoder, config)
+			if errors.Is(err, db.ErrReencodeLocked) {
+				logger.Debug(ctx, "content reencode batch running on another server")
+			} else if err != nil {
+				logger.Error(ctx, "content reencode failed", zap.Error(err), key.Encoding.Field(config.Encoding.String()))
+			} else if progress.Done() {
+				logger.Info(ctx, "content reencode done",
+					key.Encoding.Field(config.Encoding.String()),
+					key.Count.Field(progress.Scanned),
+					key.ReencodedCount.Field(progress.Reencoded),
+				)
+				return
+			} else {
+				logger.Info(ctx, "content reencode progress",
+					key.Encoding.Field(config.Encoding.String()),
+					key.Count.Field(progress.Scanned),
+					key.ReencodedCount.Field(progress.Reencoded),
+				)
+			}
+
+			timer := time.NewTimer(config.Interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+}
+
+// RunContentReencode re-encodes one batch of contents and returns the progress of the whole re-encode.
+func RunContentReencode(ctx context.Context, dbConn db.DbConnector, decoder *db.ContentDecoder, config ContentReencodeConfig) (db.ReencodeProgress, error) {
+	ctx, span := telemetry.Start(ctx, "server.content-reencode")
+	defer span.End()
+
+	tx, close, err := dbConn.Connect(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return db.ReencodeProgress{}, fmt.Errorf("content reencode connect: %w", err)
+	}
+	defer close(ctx)
+
+	progress, err := db.ReencodeContents(ctx, tx, decoder, config.Encoding, config.BatchSize)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return progress, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return progress, fmt.Errorf("content reencode commit tx: %w", err)
+	}
+
+	span.SetAttributes(
+		key.Encoding.Attribute(config.Encoding.String()),
+		key.Count.Attribute(progress.Scanned),
+		key.ReencodedCount.Attribute(progress.Reencoded),
+	)
+
+	return progress, nil
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
+)
+
+type ContentRefsConfig struct {
+	// how long to wait between batches, so the reconciler does not compete with requests for the database
+	Interval time.Duration
+	// number of deltas folded and of contents counted per transaction
+	BatchSize int64
+}
+
+type ContentRefsResult struct {
+	// false when another replica held the reconciler lock, nothing was done
+	Locked  bool
+	Folded  int64
+	Counted int64
+}
+
+// ReconcileContentRefs folds the reference deltas recorded by updates into the contents, one batch every
+// config.Interval, until ctx is done. Garbage collection reads pending deltas too, the reconciler only keeps them few.
+func (s *Server) ReconcileContentRefs(ctx context.Context, dbConn db.DbConnector, config ContentRefsConfig) {
+	go func() {
+		for {
+			result, err := RunContentRefsReconcile(ctx, dbConn, config)
+			if err != nil {
+				logger.Error(ctx, "content refs reconcile failed", zap.Error(err))
+			} else if !result.Locked {
+				logger.Debug(ctx, "content refs reconcile running on another server")
+			} else if result.Folded > 0 || result.Counted > 0 {
+				logger.Debug(ctx, "content refs reconciled",
+					key.FoldedCount.Field(result.Folded),
+					key.CountedCount.Field(result.Counted),
+				)
+			}
+
+			timer := time.NewTimer(config.Interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+}
+
+// RunContentRefsReconcile folds one batch of reference deltas and counts one batch of contents stored before
+// references were tracked. Replicas sharing a database take turns through an advisory lock.
+func RunContentRefsReconcile(ctx context.Context, dbConn db.DbConnector, config ContentRefsConfig) (ContentRefsResult, error) {
+	ctx, span := telemetry.Start(ctx, "server.content-refs-reconcile")
+	defer span.End()
+
+	result, err := runContentRefsReconcile(ctx, dbConn, config)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return result, err
+	}
+
+	span.SetAttributes(
+		key.FoldedCount.Attribute(result.Folded),
+		key.CountedCount.Attribute(result.Counted),
+	)
+
+	return result, nil
+}
+
+func runContentRefsReconcile(ctx context.Context, dbConn db.DbConnector, config ContentRefsConfig) (ContentRefsResult, error) {
+	var result ContentRefsResult
+
+	tx, close, err := dbConn.Connect(ctx)
+	if err != nil {
+		return result, fmt.Errorf("content refs reconcile connect: %w", err)
+	}
+	defer close(ctx)
+
+	result.Locked, err = db.TryLockContentRefs(ctx, tx)
+	if err != nil {
+		return result, err
+	}
+	if !result.Locked {
+		return result, nil
+	}
+
+	result.Folded, result.Counted, err = db.ReconcileContentRefs(ctx, tx, config.BatchSize)
+	if err != nil {
+		return result, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return result, fmt.Errorf("content refs reconcile commit tx: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/key"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"github.com/gadget-inc/dateilager/pkg/scan"
+	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
+)
+
+type ContentScanConfig struct {
+	Scanner scan.Scanner
+	// how long to wait before polling the queue again once it is empty
+	Interval time.Duration
+	// number of contents claimed and scanned per transaction
+	BatchSize int64
+}
+
+type ContentScanResult struct {
+	Scanned  int64
+	Infected int64
+	Failed   int64
+}
+
+// ScanContents drains the content scan queue until ctx is done, then polls it every config.Interval.
+func (s *Server) ScanContents(ctx context.Context, dbConn db.DbConnector, lookup *db.ContentLookup, config ContentScanConfig) {
+	go func() {
+		for {
+			result, err := RunContentScans(ctx, dbConn, lookup, config)
+			if err != nil {
+				logger.Error(ctx, "content scan failed", zap.Error(err), key.Scanner.Field(config.Scanner.Name()))
+			} else if result.Scanned > 0 {
+				logger.Info(ctx, "content scan done",
+					key.Scanner.Field(config.Scanner.Name()),
+					key.Count.Field(result.Scanned),
+					key.InfectedCount.Field(result.Infected),
+					key.FailedCount.Field(result.Failed),
+				)
+			}
+
+			// keep going while the queue has a backlog
+			if err == nil && result.Scanned == config.BatchSize {
+				if ctx.Err() != nil {
+					return
+				}
+				continue
+			}
+
+			timer := time.NewTimer(config.Interval)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+}
+
+// RunContentScans scans one batch of queued contents and records the verdicts.
+// Replicas sharing a database claim distinct batches, a content is retried until db.MaxScanAttempts when the scanner fails.
+func RunContentScans(ctx context.Context, dbConn db.DbConnector, lookup *db.ContentLookup, config ContentScanConfig) (ContentScanResult, error) {
+	ctx, span := telemetry.Start(ctx, "server.content-scan")
+	defer span.End()
+
+	result, err := runContentScans(ctx, dbConn, lookup, config)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return result, err
+	}
+
+	span.SetAttributes(
+		key.Count.Attribute(result.Scanned),
+		key.InfectedCount.Attribute(result.Infected),
+		key.FailedCount.Attribute(result.Failed),
+	)
+
+	return result, nil
+}
+
+func runContentScans(ctx context.Context, dbConn db.DbConnector, lookup *db.ContentLookup, config ContentScanConfig) (ContentScanResult, error) {
+	var result ContentScanResult
+	name := config.Scanner.Name()
+
+	tx, close, err := dbConn.Connect(ctx)
+	if err != nil {
+		return result, fmt.Errorf("content scan connect: %w", err)
+	}
+	defer close(ctx)
+
+	scans, err := db.ClaimContentScans(ctx, tx, config.BatchSize)
+	if err != nil || len(scans) == 0 {
+		return result, err
+	}
+
+	contents, err := db.LoadScanContents(ctx, tx, lookup, scans)
+	if err != nil {
+		return result, err
+	}
+
+	for _, queued := range scans {
+		objects, ok := contents[queued.Hash]
+		if !ok {
+			err = db.DropContentScan(ctx, tx, queued.Hash)
+			if err != nil {
+				return result, err
+			}
+			continue
+		}
+
+		verdict, scanErr := scanObjects(ctx, config.Scanner, objects)
+		result.Scanned += 1
+
+		if scanErr != nil {
+			result.Failed += 1
+			logger.Warn(ctx, "content scan error", zap.Error(scanErr), key.Scanner.Field(name), zap.String("hash", queued.Hash.Hex()))
+			err = db.RecordContentScanError(ctx, tx, queued.Hash, name, scanErr)
+		} else {
+			if verdict.Infected {
+				result.Infected += 1
+			}
+			err = db.RecordContentScan(ctx, tx, queued.Hash, name, verdict.Infected, verdict.Signature)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return result, fmt.Errorf("content scan commit tx: %w", err)
+	}
+
+	return result, nil
+}
+
+// scanObjects scans the objects of a single content, the content is infected as soon as one of its objects is.
+func scanObjects(ctx context.Context, scanner scan.Scanner, objects []*pb.Objekt) (scan.Verdict, error) {
+	for _, object := range objects {
+		verdict, err := scanner.Scan(ctx, object.Content)
+		if err != nil {
+			return scan.Verdict{}, err
+		}
+
+		if verdict.Infected {
+			if object.Path != "" {
+				verdict.Signature = fmt.Sprintf("%s in %s", verdict.Signature, object.Path)
+			}
+			return verdict, nil
+		}
+	}
+
+	return scan.Verdict{}, nil
+}
@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/auth"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// CallerCost is the total cost of the requests made with the tokens of one caller.
+type CallerCost struct {
+	Requests    int64 `json:"requests"`
+	DbTimeMS    int64 `json:"dbTimeMs"`
+	DbQueries   int64 `json:"dbQueries"`
+	DbRows      int64 `json:"dbRows"`
+	SentBytes   int64 `json:"sentBytes"`
+	dbTimeTotal time.Duration
+}
+
+// CostAccounts aggregate the cost of requests per caller, a caller being the role and scope of the request's token,
+// e.g. admin or project[42]. Requests rejected before their token was validated are accounted to "none".
+type CostAccounts struct {
+	mu      sync.Mutex
+	callers map[string]*CallerCost
+}
+
+func NewCostAccounts() *CostAccounts {
+	return &CostAccounts{callers: make(map[string]*CallerCost)}
+}
+
+// costAccounts are shared by every server of the process and published under /debug/vars as costs.
+var costAccounts = NewCostAccounts()
+
+func (a *CostAccounts) Add(caller string, cost telemetry.CostSnapshot) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	total, ok := a.callers[caller]
+	if !ok {
+		total = &CallerCost{}
+		a.callers[caller] = total
+	}
+
+	total.Requests += 1
+	total.dbTimeTotal += cost.DbTime
+	total.DbTimeMS = total.dbTimeTotal.Milliseconds()
+	total.DbQueries += cost.DbQueries
+	total.DbRows += cost.DbRows
+	total.SentBytes += cost.SentBytes
+}
+
+// Snapshot copies the cost of every caller.
+func (a *CostAccounts) Snapshot() map[string]CallerCost {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	snapshot := make(map[string]CallerCost, len(a.callers))
+	for caller, total := range a.callers {
+		snapshot[caller] = *total
+	}
+	return snapshot
+}
+
+// callerHolder receives the caller of a request from the token validation interceptors, the auth they store in the
+// context is not visible to the cost interceptors wrapping them.
+type callerHolder struct {
+	caller string
+}
+
+type callerCtxKey struct{}
+
+func withCallerHolder(ctx context.Context) (context.Context, *callerHolder) {
+	holder := &callerHolder{caller: auth.Auth{Role: auth.None}.String()}
+	return context.WithValue(ctx, callerCtxKey{}, holder), holder
+}
+
+// recordCaller attributes the cost of the request served with ctx to the caller authenticated by reqAuth.
+func recordCaller(ctx context.Context, reqAuth auth.Auth) {
+	if holder, ok := ctx.Value(callerCtxKey{}).(*callerHolder); ok {
+		holder.caller = reqAuth.String()
+	}
+}
+
+// costUnary accounts the database time, rows and response bytes of unary calls. The cost is sent to the client as
+// response trailers, logged with the call and added to the caller's totals.
+func costUnary(accounts *CostAccounts) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if info.FullMethod == "/grpc.health.v1.Health/Check" {
+			return handler(ctx, req)
+		}
+
+		ctx, cost := telemetry.WithCost(ctx)
+		ctx, holder := withCallerHolder(ctx)
+
+		resp, err := handler(ctx, req)
+		if message, ok := resp.(proto.Message); ok && err == nil {
+			cost.AddSentBytes(proto.Size(message))
+		}
+
+		snapshot := cost.Snapshot()
+		_ = grpc.SetTrailer(ctx, snapshot.Trailer())
+		accounts.Add(holder.caller, snapshot)
+		logger.Info(ctx, "unary call cost", append(snapshot.Fields(), zap.String("cost.caller", holder.caller))...)
+
+		return resp, err
+	}
+}
+
+type costServerStream struct {
+	*grpc_middleware.WrappedServerStream
+	cost *telemetry.Cost
+}
+
+func (s *costServerStream) SendMsg(m interface{}) error {
+	err := s.WrappedServerStream.SendMsg(m)
+	if message, ok := m.(proto.Message); ok && err == nil {
+		s.cost.AddSentBytes(proto.Size(message))
+	}
+	return err
+}
+
+func costStream(accounts *CostAccounts) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cost := telemetry.WithCost(stream.Context())
+		ctx, holder := withCallerHolder(ctx)
+
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = ctx
+
+		err := handler(srv, &costServerStream{WrappedServerStream: wrapped, cost: cost})
+
+		snapshot := cost.Snapshot()
+		stream.SetTrailer(snapshot.Trailer())
+		accounts.Add(holder.caller, snapshot)
+		logger.Info(ctx, "streaming call cost", append(snapshot.Fields(), zap.String("cost.caller", holder.caller))...)
+
+		return err
+	}
+}
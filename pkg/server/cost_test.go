@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/auth"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCostUnary(t *testing.T) {
+	accounts := NewCostAccounts()
+	interceptor := costUnary(accounts)
+
+	response := &pb.GetUnaryResponse{Version: 3, Objects: []*pb.Object{{Path: "a", Content: []byte("a v1")}}}
+	project := int64(1)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		require.NotNil(t, telemetry.CostFromContext(ctx), "handlers should see the request's cost")
+		recordCaller(ctx, auth.Auth{Role: auth.Project, Project: &project})
+		return response, nil
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: pb.Fs_GetUnary_FullMethodName}
+	for range 2 {
+		_, err := interceptor(context.Background(), nil, info, handler)
+		require.NoError(t, err)
+	}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	costs := accounts.Snapshot()
+	assert.Equal(t, int64(2), costs["project[1]"].Requests)
+	assert.Equal(t, int64(2*proto.Size(response)), costs["project[1]"].SentBytes)
+	assert.Equal(t, int64(1), costs["none"].Requests, "requests without a validated token")
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/auth"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"go.uber.org/zap"
+)
+
+// Credentials are the TLS keypair and Paseto public keys a server authenticates with.
+// Reload reads the files again and swaps them in place, new handshakes and tokens use the new credentials
+// while established connections keep running.
+type Credentials struct {
+	certFile   string
+	keyFile    string
+	pasetoFile string
+
+	mu        sync.Mutex
+	cert      atomic.Pointer[tls.Certificate]
+	validator *auth.AuthValidator
+	stamps    []fileStamp
+}
+
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+func LoadCredentials(certFile, keyFile, pasetoFile string) (*Credentials, error) {
+	c := &Credentials{
+		certFile:   certFile,
+		keyFile:    keyFile,
+		pasetoFile: pasetoFile,
+		validator:  auth.NewAuthValidator(),
+	}
+
+	err := c.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// NewStaticCredentials returns credentials that are not backed by files, they never change and must not be reloaded.
+func NewStaticCredentials(cert *tls.Certificate, pasetoKeys ...ed25519.PublicKey) *Credentials {
+	c := &Credentials{
+		validator: auth.NewAuthValidator(pasetoKeys...),
+	}
+	c.cert.Store(cert)
+	c.stamps = c.statFiles()
+
+	return c
+}
+
+// Reload reads every credential file, nothing is swapped unless all of them are valid.
+func (c *Credentials) Reload() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stamps := c.statFiles()
+
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return fmt.Errorf("cannot open TLS cert and key files (%s, %s): %w", c.certFile, c.keyFile, err)
+	}
+
+	pasetoKeys, err := ParsePublicKeys(c.pasetoFile)
+	if err != nil {
+		return fmt.Errorf("cannot parse Paseto public key %s: %w", c.pasetoFile, err)
+	}
+
+	c.cert.Store(&cert)
+	c.validator.SetKeys(pasetoKeys...)
+	c.stamps = stamps
+
+	return nil
+}
+
+// WatchFiles reloads the credentials when any of their files changes, checking every interval until ctx is done.
+// Files are polled rather than watched so that secrets remounted through symlink swaps are picked up too.
+func (c *Credentials) WatchFiles(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !c.changed() {
+					continue
+				}
+
+				err := c.Reload()
+				if err != nil {
+					logger.Error(ctx, "credentials changed but could not be reloaded", zap.Error(err))
+					continue
+				}
+				logger.Info(ctx, "reloaded credentials")
+			}
+		}
+	}()
+}
+
+func (c *Credentials) Validator() *auth.AuthValidator {
+	return c.validator
+}
+
+func (c *Credentials) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return c.cert.Load(), nil
+		},
+	}
+}
+
+func (c *Credentials) changed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stamps := c.statFiles()
+	for idx := range stamps {
+		if stamps[idx] != c.stamps[idx] {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Credentials) statFiles() []fileStamp {
+	var stamps []fileStamp
+	for _, path := range []string{c.certFile, c.keyFile, c.pasetoFile} {
+		stamps = append(stamps, statFile(path))
+	}
+	return stamps
+}
+
+// statFile returns the zero stamp for a missing file so that its creation counts as a change.
+func statFile(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
+
+// ParsePublicKeys reads every PEM encoded Ed25519 public key in path, a file lists several keys while one is being rotated.
+func ParsePublicKeys(path string) ([]ed25519.PublicKey, error) {
+	pubKeyBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open Paseto public key file: %w", err)
+	}
+
+	var keys []ed25519.PublicKey
+	for {
+		var block *pem.Block
+		block, pubKeyBytes = pem.Decode(pubKeyBytes)
+		if block == nil {
+			break
+		}
+		if block.Type != "PUBLIC KEY" {
+			return nil, fmt.Errorf("error decoding Paseto public key PEM: unexpected %s block", block.Type)
+		}
+
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing Paseto public key: %w", err)
+		}
+
+		key, ok := pub.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("error parsing Paseto public key: not an Ed25519 key")
+		}
+		keys = append(keys, key)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("error decoding Paseto public key PEM")
+	}
+
+	return keys, nil
+}
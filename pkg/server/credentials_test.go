@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/auth"
+	"github.com/o1egl/paseto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCert(t *testing.T, certFile, keyFile, name string) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pub, priv)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}), 0600))
+}
+
+func writePasetoKeys(t *testing.T, path string, count int) []ed25519.PrivateKey {
+	var contents []byte
+	var privs []ed25519.PrivateKey
+	for range count {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		der, err := x509.MarshalPKIXPublicKey(pub)
+		require.NoError(t, err)
+
+		contents = append(contents, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})...)
+		privs = append(privs, priv)
+	}
+
+	require.NoError(t, os.WriteFile(path, contents, 0600))
+	return privs
+}
+
+func adminToken(t *testing.T, priv ed25519.PrivateKey) string {
+	token, err := paseto.NewV2().Sign(priv, paseto.JSONToken{Subject: "admin"}, nil)
+	require.NoError(t, err)
+	return token
+}
+
+func certName(t *testing.T, creds *Credentials) string {
+	cert, err := creds.TLSConfig().GetCertificate(nil)
+	require.NoError(t, err)
+
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	return parsed.Subject.CommonName
+}
+
+func TestCredentialsReload(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	certFile, keyFile, pasetoFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "paseto.pub")
+
+	writeCert(t, certFile, keyFile, "old")
+	oldKeys := writePasetoKeys(t, pasetoFile, 1)
+
+	creds, err := LoadCredentials(certFile, keyFile, pasetoFile)
+	require.NoError(t, err)
+	assert.Equal(t, "old", certName(t, creds))
+
+	reqAuth, err := creds.Validator().Validate(ctx, adminToken(t, oldKeys[0]))
+	require.NoError(t, err)
+	assert.Equal(t, auth.Admin, reqAuth.Role)
+
+	writeCert(t, certFile, keyFile, "new")
+	newKeys := writePasetoKeys(t, pasetoFile, 2)
+	require.NoError(t, creds.Reload())
+
+	assert.Equal(t, "new", certName(t, creds))
+
+	_, err = creds.Validator().Validate(ctx, adminToken(t, oldKeys[0]))
+	assert.Error(t, err, "tokens signed by a removed key are rejected")
+	assert.ErrorContains(t, err, "key 0:", "the failure of every key is reported")
+	assert.ErrorContains(t, err, "key 1:", "the failure of every key is reported")
+
+	for _, key := range newKeys {
+		_, err = creds.Validator().Validate(ctx, adminToken(t, key))
+		assert.NoError(t, err, "tokens signed by any listed key are accepted")
+	}
+
+	require.NoError(t, os.WriteFile(pasetoFile, []byte("not a key"), 0600))
+	require.Error(t, creds.Reload())
+
+	_, err = creds.Validator().Validate(ctx, adminToken(t, newKeys[0]))
+	assert.NoError(t, err, "a failed reload keeps the previous credentials")
+}
+
+func TestCredentialsWatchFiles(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	dir := t.TempDir()
+	certFile, keyFile, pasetoFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "paseto.pub")
+
+	writeCert(t, certFile, keyFile, "old")
+	writePasetoKeys(t, pasetoFile, 1)
+
+	creds, err := LoadCredentials(certFile, keyFile, pasetoFile)
+	require.NoError(t, err)
+
+	creds.WatchFiles(ctx, 10*time.Millisecond)
+
+	writeCert(t, certFile, keyFile, "renamed")
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+
+	assert.Eventually(t, func() bool { return certName(t, creds) == "renamed" }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestStaticCredentials(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	certFile, keyFile, pasetoFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "paseto.pub")
+
+	writeCert(t, certFile, keyFile, "static")
+	keys := writePasetoKeys(t, pasetoFile, 1)
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	require.NoError(t, err)
+
+	creds := NewStaticCredentials(&cert, keys[0].Public().(ed25519.PublicKey))
+	assert.Equal(t, "static", certName(t, creds))
+	assert.Equal(t, uint16(tls.VersionTLS12), creds.TLSConfig().MinVersion)
+	assert.False(t, creds.changed(), "static credentials never change")
+
+	reqAuth, err := creds.Validator().Validate(ctx, adminToken(t, keys[0]))
+	require.NoError(t, err)
+	assert.Equal(t, auth.Admin, reqAuth.Role)
+}
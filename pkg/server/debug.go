@@ -0,0 +1,32 @@
+package server
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+)
+
+func init() {
+	expvar.Publish("goroutines", expvar.Func(func() any { return runtime.NumGoroutine() }))
+	expvar.Publish("costs", expvar.Func(func() any { return costAccounts.Snapshot() }))
+}
+
+// NewDebugServer serves net/http/pprof under /debug/pprof/ and runtime metrics as JSON under /debug/vars, including
+// the cost of the requests served so far per caller.
+// Goroutine dumps are available at /debug/pprof/goroutine?debug=2. It must only be exposed to trusted networks.
+func NewDebugServer() *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+
+	return &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
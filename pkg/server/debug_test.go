@@ -0,0 +1,31 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugServer(t *testing.T) {
+	server := httptest.NewServer(NewDebugServer().Handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/debug/vars")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var vars map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&vars))
+	assert.Contains(t, vars, "goroutines")
+	assert.Contains(t, vars, "memstats")
+
+	resp, err = http.Get(server.URL + "/debug/pprof/goroutine?debug=2")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
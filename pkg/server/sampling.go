@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gadget-inc/dateilager/internal/auth"
+	"github.com/gadget-inc/dateilager/internal/logger"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"gopkg.in/yaml.v3"
+)
+
+// TraceSampling loads per project trace sampling rates from a YAML file:
+//
+//	default: 0.01
+//	projects:
+//	  1234: 1
+//
+// Reload reads the file again and swaps the rates in place for the spans started afterwards.
+type TraceSampling struct {
+	path string
+
+	mu    sync.Mutex
+	stamp fileStamp
+}
+
+func LoadTraceSampling(path string) (*TraceSampling, error) {
+	s := &TraceSampling{path: path}
+
+	err := s.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// ParseTraceSampling reads and validates a sampling file without applying it.
+func ParseTraceSampling(path string) (*telemetry.SamplingConfig, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read trace sampling file %s: %w", path, err)
+	}
+
+	var config telemetry.SamplingConfig
+	err = yaml.Unmarshal(contents, &config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse trace sampling file %s: %w", path, err)
+	}
+
+	err = config.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("trace sampling file %s: %w", path, err)
+	}
+
+	return &config, nil
+}
+
+// Reload reads the sampling file, the current rates are kept unless it is valid.
+func (s *TraceSampling) Reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stamp := statFile(s.path)
+
+	config, err := ParseTraceSampling(s.path)
+	if err != nil {
+		return err
+	}
+
+	telemetry.SetSampling(config)
+	s.stamp = stamp
+
+	return nil
+}
+
+// WatchFile reloads the sampling rates when the file changes, checking every interval until ctx is done.
+func (s *TraceSampling) WatchFile(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.mu.Lock()
+				changed := statFile(s.path) != s.stamp
+				s.mu.Unlock()
+				if !changed {
+					continue
+				}
+
+				err := s.Reload()
+				if err != nil {
+					logger.Error(ctx, "trace sampling changed but could not be reloaded", zap.Error(err))
+					continue
+				}
+				logger.Info(ctx, "reloaded trace sampling")
+			}
+		}
+	}()
+}
+
+type projectRequest interface {
+	GetProject() int64
+}
+
+// traceProject finds the project of a call before its span is started, from the request when it names one and from
+// the project of the token otherwise. Tokens are only checked here when per project sampling rates are configured,
+// they are validated again by the auth interceptors.
+func traceProject(ctx context.Context, validator *auth.AuthValidator, req interface{}) (int64, bool) {
+	if request, ok := req.(projectRequest); ok {
+		return request.GetProject(), true
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, false
+	}
+
+	token, err := getToken(md["authorization"])
+	if err != nil {
+		return 0, false
+	}
+
+	reqAuth, err := validator.Validate(ctx, token)
+	if err != nil || reqAuth.Role != auth.Project || reqAuth.Project == nil {
+		return 0, false
+	}
+
+	return *reqAuth.Project, true
+}
+
+func traceProjectUnary(validator *auth.AuthValidator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		if !telemetry.SamplesProjects() {
+			return handler(ctx, req)
+		}
+
+		if project, ok := traceProject(ctx, validator, req); ok {
+			ctx = telemetry.WithProject(ctx, project)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func traceProjectStream(validator *auth.AuthValidator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !telemetry.SamplesProjects() {
+			return handler(srv, stream)
+		}
+
+		project, ok := traceProject(stream.Context(), validator, nil)
+		if !ok {
+			return handler(srv, stream)
+		}
+
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = telemetry.WithProject(stream.Context(), project)
+		return handler(srv, wrapped)
+	}
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/auth"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func TestTraceSamplingReload(t *testing.T) {
+	defer telemetry.SetSampling(nil)
+
+	path := filepath.Join(t.TempDir(), "sampling.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("default: 0.01\nprojects:\n  1234: 1\n"), 0600))
+
+	sampling, err := LoadTraceSampling(path)
+	require.NoError(t, err)
+	assert.True(t, telemetry.SamplesProjects())
+
+	require.NoError(t, os.WriteFile(path, []byte("default: 2\n"), 0600))
+	assert.Error(t, sampling.Reload(), "rates above 1 are refused")
+	assert.True(t, telemetry.SamplesProjects(), "invalid files keep the current rates")
+
+	require.NoError(t, os.WriteFile(path, []byte("default: 0.5\n"), 0600))
+	require.NoError(t, sampling.Reload())
+	assert.False(t, telemetry.SamplesProjects())
+}
+
+func TestTraceProjectUnary(t *testing.T) {
+	defer telemetry.SetSampling(nil)
+	telemetry.SetSampling(&telemetry.SamplingConfig{Projects: map[int64]float64{1234: 1}})
+
+	var tagged context.Context
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		tagged = ctx
+		return nil, nil
+	}
+
+	interceptor := traceProjectUnary(auth.NewAuthValidator())
+	_, err := interceptor(context.Background(), &pb.GetUnaryRequest{Project: 1234}, &grpc.UnaryServerInfo{FullMethod: pb.Fs_GetUnary_FullMethodName}, handler)
+	require.NoError(t, err)
+
+	project, ok := telemetry.ProjectFromContext(tagged)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1234), project)
+}
@@ -5,12 +5,14 @@ import (
 	"crypto/ed25519"
 	"crypto/tls"
 	"fmt"
+	"math"
 	"net"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/gadget-inc/dateilager/internal/auth"
+	_ "github.com/gadget-inc/dateilager/internal/compression"
 	"github.com/gadget-inc/dateilager/internal/db"
 	"github.com/gadget-inc/dateilager/internal/logger"
 	"github.com/gadget-inc/dateilager/internal/pb"
@@ -113,9 +115,19 @@ func (d *DbPoolConnector) Connect(ctx context.Context) (pgx.Tx, db.CloseFunc, er
 
 	tx, err := conn.Begin(ctx)
 	if err != nil {
+		conn.Release()
 		return nil, nil, err
 	}
 
+	if ctxAuth, ok := ctx.Value(auth.AuthCtxKey).(auth.Auth); ok && ctxAuth.Tenant != nil {
+		err = db.ScopeToTenant(ctx, tx, *ctxAuth.Tenant)
+		if err != nil {
+			_ = tx.Rollback(ctx)
+			conn.Release()
+			return nil, nil, err
+		}
+	}
+
 	return tx, func(ctx context.Context) { _ = tx.Rollback(ctx); conn.Release() }, nil
 }
 
@@ -127,20 +139,114 @@ func (d *DbPoolConnector) Exec(ctx context.Context, sql string, args ...interfac
 	return d.pool.Exec(ctx, sql, args...)
 }
 
+// AdminMethods are the operational RPCs that are only served by the admin listener when it is split from the public one.
+var AdminMethods = map[string]bool{
+	pb.Fs_DeleteProject_FullMethodName:            true,
+	pb.Fs_SetProjectCacheInclusion_FullMethodName: true,
+	pb.Fs_SetProjectPolicy_FullMethodName:         true,
+	pb.Fs_SetProjectFrozen_FullMethodName:         true,
+	pb.Fs_SetProjectTransforms_FullMethodName:     true,
+	pb.Fs_Inspect_FullMethodName:                  true,
+	pb.Fs_Snapshot_FullMethodName:                 true,
+	pb.Fs_Reset_FullMethodName:                    true,
+	pb.Fs_GcProject_FullMethodName:                true,
+	pb.Fs_GcRandomProjects_FullMethodName:         true,
+	pb.Fs_SquashHistory_FullMethodName:            true,
+	pb.Fs_ListTombstones_FullMethodName:           true,
+	pb.Fs_PurgeDeleted_FullMethodName:             true,
+	pb.Fs_GcContents_FullMethodName:               true,
+	pb.Fs_CanonicalizePacks_FullMethodName:        true,
+	pb.Fs_CreateCache_FullMethodName:              true,
+	pb.Fs_ListCacheVersions_FullMethodName:        true,
+	pb.Fs_DeleteCacheVersion_FullMethodName:       true,
+	pb.Fs_GetCacheVersionProjects_FullMethodName:  true,
+	pb.Fs_CaptureHeapProfile_FullMethodName:       true,
+	pb.Fs_ContentStats_FullMethodName:             true,
+	pb.Fs_FindContent_FullMethodName:              true,
+	pb.Fs_ContentEncodingStatus_FullMethodName:    true,
+}
+
+// minWindowSize is the smallest flow control window gRPC applies, it silently keeps its defaults below it.
+const minWindowSize = 64 * KB
+
+// TransportConfig tunes how much data a connection buffers: the largest message accepted or sent and the HTTP/2 flow
+// control windows of each stream and of the whole connection.
+type TransportConfig struct {
+	MaxMessageSize        int
+	InitialWindowSize     int32
+	InitialConnWindowSize int32
+}
+
+func DefaultTransportConfig() TransportConfig {
+	return TransportConfig{
+		MaxMessageSize:        MAX_MESSAGE_SIZE,
+		InitialWindowSize:     INITIAL_WINDOW_SIZE,
+		InitialConnWindowSize: INITIAL_CONN_WINDOW_SIZE,
+	}
+}
+
+func (c TransportConfig) Validate() error {
+	if c.MaxMessageSize <= 0 || c.MaxMessageSize > math.MaxInt32 {
+		return fmt.Errorf("invalid max message size %d, expected between 1 and %d bytes", c.MaxMessageSize, math.MaxInt32)
+	}
+	if c.InitialWindowSize < minWindowSize {
+		return fmt.Errorf("invalid initial window size %d, expected at least %d bytes", c.InitialWindowSize, minWindowSize)
+	}
+	if c.InitialConnWindowSize < c.InitialWindowSize {
+		return fmt.Errorf("invalid initial connection window size %d, expected at least the initial window size of %d bytes", c.InitialConnWindowSize, c.InitialWindowSize)
+	}
+	return nil
+}
+
 type Server struct {
-	Grpc   *grpc.Server
+	Grpc *grpc.Server
+	// Admin serves every RPC including AdminMethods, which Grpc then refuses, nil unless the admin listener is split
+	Admin  *grpc.Server
 	Health *health.Server
 }
 
+// NewServer serves with a fixed TLS certificate and Paseto key and the default transport settings.
 func NewServer(ctx context.Context, dbConn *DbPoolConnector, cert *tls.Certificate, pasetoKey ed25519.PublicKey) *Server {
-	creds := credentials.NewServerTLSFromCert(cert)
-	validator := auth.NewAuthValidator(pasetoKey)
+	return NewServerWithCredentials(ctx, dbConn, NewStaticCredentials(cert, pasetoKey), DefaultTransportConfig(), false)
+}
+
+// NewServerWithCredentials serves with credentials that may be reloaded while running. When splitAdmin is set the admin
+// RPCs are only served by Server.Admin.
+func NewServerWithCredentials(ctx context.Context, dbConn *DbPoolConnector, serverCreds *Credentials, transport TransportConfig, splitAdmin bool) *Server {
+	healthServer := health.NewServer()
+
+	server := &Server{
+		Grpc:   newGrpcServer(ctx, serverCreds, healthServer, transport, splitAdmin),
+		Health: healthServer,
+	}
+
+	if splitAdmin {
+		server.Admin = newGrpcServer(ctx, serverCreds, healthServer, transport, false)
+	}
+
+	server.monitorDbPool(ctx, dbConn)
+
+	return server
+}
+
+func newGrpcServer(ctx context.Context, serverCreds *Credentials, healthServer *health.Server, transport TransportConfig, refuseAdmin bool) *grpc.Server {
+	creds := credentials.NewTLS(serverCreds.TLSConfig())
+	validator := serverCreds.Validator()
+
+	allowed := func(string) bool { return true }
+	if refuseAdmin {
+		allowed = func(method string) bool { return !AdminMethods[method] }
+	}
 
 	grpcServer := grpc.NewServer(
 		grpc.UnaryInterceptor(
 			grpc_middleware.ChainUnaryServer(
 				grpc_recovery.UnaryServerInterceptor(),
+				restrictMethodsUnary(allowed),
+				traceProjectUnary(validator),
 				otelgrpc.UnaryServerInterceptor(),
+				telemetry.BaggageUnaryServerInterceptor(),
+				costUnary(costAccounts),
 				logger.UnaryServerInterceptor(),
 				ValidateTokenUnary(validator),
 			),
@@ -148,17 +254,21 @@ func NewServer(ctx context.Context, dbConn *DbPoolConnector, cert *tls.Certifica
 		grpc.StreamInterceptor(
 			grpc_middleware.ChainStreamServer(
 				grpc_recovery.StreamServerInterceptor(),
+				restrictMethodsStream(allowed),
+				traceProjectStream(validator),
 				otelgrpc.StreamServerInterceptor(),
+				telemetry.BaggageStreamServerInterceptor(),
+				costStream(costAccounts),
 				logger.StreamServerInterceptor(),
 				validateTokenStream(validator),
 			),
 		),
 		grpc.ReadBufferSize(BUFFER_SIZE),
 		grpc.WriteBufferSize(BUFFER_SIZE),
-		grpc.InitialConnWindowSize(INITIAL_CONN_WINDOW_SIZE),
-		grpc.InitialWindowSize(INITIAL_WINDOW_SIZE),
-		grpc.MaxRecvMsgSize(MAX_MESSAGE_SIZE),
-		grpc.MaxSendMsgSize(MAX_MESSAGE_SIZE),
+		grpc.InitialConnWindowSize(transport.InitialConnWindowSize),
+		grpc.InitialWindowSize(transport.InitialWindowSize),
+		grpc.MaxRecvMsgSize(transport.MaxMessageSize),
+		grpc.MaxSendMsgSize(transport.MaxMessageSize),
 		grpc.Creds(creds),
 		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
 			MinTime:             2 * time.Second,
@@ -167,17 +277,9 @@ func NewServer(ctx context.Context, dbConn *DbPoolConnector, cert *tls.Certifica
 	)
 
 	logger.Info(ctx, "register HealthServer")
-	healthServer := health.NewServer()
 	healthpb.RegisterHealthServer(grpcServer, healthServer)
 
-	server := &Server{
-		Grpc:   grpcServer,
-		Health: healthServer,
-	}
-
-	server.monitorDbPool(ctx, dbConn)
-
-	return server
+	return grpcServer
 }
 
 func (s *Server) monitorDbPool(ctx context.Context, dbConn *DbPoolConnector) {
@@ -206,12 +308,48 @@ func (s *Server) monitorDbPool(ctx context.Context, dbConn *DbPoolConnector) {
 
 func (s *Server) RegisterFs(fs *api.Fs) {
 	pb.RegisterFsServer(s.Grpc, fs)
+	if s.Admin != nil {
+		pb.RegisterFsServer(s.Admin, fs)
+	}
 }
 
 func (s *Server) Serve(lis net.Listener) error {
 	return s.Grpc.Serve(lis)
 }
 
+// ServeAdmin serves the admin RPCs on lis, the server must have been created with splitAdmin.
+func (s *Server) ServeAdmin(lis net.Listener) error {
+	if s.Admin == nil {
+		return fmt.Errorf("admin listener is not split from the public one")
+	}
+	return s.Admin.Serve(lis)
+}
+
+func (s *Server) GracefulStop() {
+	if s.Admin != nil {
+		s.Admin.GracefulStop()
+	}
+	s.Grpc.GracefulStop()
+}
+
+func restrictMethodsUnary(allowed func(string) bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		if !allowed(info.FullMethod) {
+			return nil, status.Errorf(codes.PermissionDenied, "%s is only served on the admin port", info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+}
+
+func restrictMethodsStream(allowed func(string) bool) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !allowed(info.FullMethod) {
+			return status.Errorf(codes.PermissionDenied, "%s is only served on the admin port", info.FullMethod)
+		}
+		return handler(srv, stream)
+	}
+}
+
 func ValidateTokenUnary(validator *auth.AuthValidator) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		md, ok := metadata.FromIncomingContext(ctx)
@@ -236,6 +374,7 @@ func ValidateTokenUnary(validator *auth.AuthValidator) grpc.UnaryServerIntercept
 		}
 
 		ctx = context.WithValue(ctx, auth.AuthCtxKey, reqAuth)
+		recordCaller(ctx, reqAuth)
 
 		return handler(ctx, req)
 	}
@@ -263,6 +402,7 @@ func validateTokenStream(validator *auth.AuthValidator) grpc.StreamServerInterce
 
 		wrapped := grpc_middleware.WrapServerStream(stream)
 		wrapped.WrappedContext = context.WithValue(ctx, auth.AuthCtxKey, reqAuth)
+		recordCaller(ctx, reqAuth)
 
 		return handler(srv, stream)
 	}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/internal/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestRestrictMethodsUnary(t *testing.T) {
+	interceptor := restrictMethodsUnary(func(method string) bool { return !AdminMethods[method] })
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	resp, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: pb.Fs_GetUnary_FullMethodName}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: pb.Fs_Reset_FullMethodName}, handler)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestRestrictMethodsStream(t *testing.T) {
+	interceptor := restrictMethodsStream(func(method string) bool { return !AdminMethods[method] })
+	handler := func(srv interface{}, stream grpc.ServerStream) error { return nil }
+
+	err := interceptor(nil, nil, &grpc.StreamServerInfo{FullMethod: pb.Fs_Get_FullMethodName}, handler)
+	require.NoError(t, err)
+
+	err = interceptor(nil, nil, &grpc.StreamServerInfo{FullMethod: pb.Fs_FanOutUpdate_FullMethodName}, handler)
+	require.NoError(t, err, "fan out updates are served publicly")
+}
+
+func TestAdminMethodsExist(t *testing.T) {
+	methods := make(map[string]bool)
+	for _, method := range pb.Fs_ServiceDesc.Methods {
+		methods["/"+pb.Fs_ServiceDesc.ServiceName+"/"+method.MethodName] = true
+	}
+	for _, stream := range pb.Fs_ServiceDesc.Streams {
+		methods["/"+pb.Fs_ServiceDesc.ServiceName+"/"+stream.StreamName] = true
+	}
+
+	for method := range AdminMethods {
+		assert.True(t, methods[method], "unknown admin method %s", method)
+	}
+}
+
+func TestTransportConfigValidate(t *testing.T) {
+	assert.NoError(t, DefaultTransportConfig().Validate())
+
+	config := DefaultTransportConfig()
+	config.MaxMessageSize = 0
+	assert.Error(t, config.Validate(), "empty max message size")
+
+	config = DefaultTransportConfig()
+	config.InitialWindowSize = 1 * KB
+	assert.Error(t, config.Validate(), "window below the gRPC minimum")
+
+	config = DefaultTransportConfig()
+	config.InitialConnWindowSize = config.InitialWindowSize / 2
+	assert.Error(t, config.Validate(), "connection window smaller than the stream window")
+}
+
+func TestBaggagePropagation(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+
+	var received baggage.Baggage
+	capture := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		received = baggage.FromContext(ctx)
+		return handler(ctx, req)
+	}
+
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(telemetry.BaggageUnaryServerInterceptor(), capture))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, telemetry.BaggageDialOptions()...)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet", dialOptions...)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, err := telemetry.WithBaggage(context.Background(), map[string]string{"deploy-id": "abc", "sandbox-id": "123"})
+	require.NoError(t, err)
+
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc", received.Member("deploy-id").Value())
+	assert.Equal(t, "123", received.Member("sandbox-id").Value())
+}
@@ -0,0 +1,3 @@
+from .client import EXPORT_COLUMNS, Client, Object, Project
+
+__all__ = ["EXPORT_COLUMNS", "Client", "Object", "Project"]
@@ -0,0 +1,223 @@
+"""A thin read only wrapper around the DateiLager GRPC API.
+
+The stubs in ``dateilager.pb`` are generated from ``internal/pb/fs.proto`` by ``make install-py``.
+"""
+
+from __future__ import annotations
+
+import os
+from dataclasses import dataclass, field
+from typing import TYPE_CHECKING, Callable, Dict, Iterable, Iterator, List, Optional, Sequence, Union
+
+import grpc
+
+from .pb import fs_pb2, fs_pb2_grpc
+
+if TYPE_CHECKING:
+    import pandas
+
+# The columns of the Parquet files written by the Go client's export command.
+EXPORT_COLUMNS = ["project", "version", "path", "mode", "size", "deleted", "content_type", "content"]
+
+
+@dataclass
+class Project:
+    id: int
+    version: int
+    labels: Dict[str, str] = field(default_factory=dict)
+    tenant: Optional[str] = None
+
+
+@dataclass
+class Object:
+    path: str
+    mode: int
+    size: int
+    deleted: bool
+    version: int
+    content: Optional[bytes] = None
+    content_type: Optional[str] = None
+    content_omitted: bool = False
+
+    def text(self, encoding: str = "utf-8") -> str:
+        """Decode the content of the object, raises ValueError when the content was not fetched."""
+        if self.content is None:
+            raise ValueError(f"content of {self.path} was not fetched")
+        return self.content.decode(encoding)
+
+
+def _is_small_text(content: Optional[bytes], max_content_size: int) -> bool:
+    if content is None or len(content) > max_content_size or b"\x00" in content:
+        return False
+    try:
+        content.decode("utf-8")
+    except UnicodeDecodeError:
+        return False
+    return True
+
+
+class Client:
+    """A client to read DateiLager projects.
+
+    The underlying channel connects lazily, creating a client does not raise an error
+    even if there is no server listening at ``target``.
+    """
+
+    def __init__(
+        self,
+        target: str,
+        token: Union[str, Callable[[], str], None] = None,
+        *,
+        insecure: bool = False,
+        root_certificates: Optional[bytes] = None,
+        timeout: Optional[float] = None,
+        options: Optional[Sequence[tuple]] = None,
+    ):
+        """
+        :param target: The ``host:port`` address of the dateilager server.
+        :param token: The token sent as authorization metadata, defaults to the ``DL_TOKEN`` environment variable.
+        :param insecure: Connect without TLS, only meant for local development servers.
+        :param root_certificates: PEM encoded root certificates used to verify the server.
+        :param timeout: Deadline in seconds of each call, streams included.
+        :param options: Options passed to the underlying GRPC channel.
+        """
+        if token is None:
+            token = os.environ.get("DL_TOKEN")
+        self._token = token
+        self._timeout = timeout
+
+        if insecure:
+            self._channel = grpc.insecure_channel(target, options=options)
+        else:
+            credentials = grpc.ssl_channel_credentials(root_certificates=root_certificates)
+            self._channel = grpc.secure_channel(target, credentials, options=options)
+
+        self._fs = fs_pb2_grpc.FsStub(self._channel)
+
+    def close(self) -> None:
+        self._channel.close()
+
+    def __enter__(self) -> "Client":
+        return self
+
+    def __exit__(self, *_exc) -> None:
+        self.close()
+
+    def _metadata(self) -> List[tuple]:
+        token = self._token() if callable(self._token) else self._token
+        if not token:
+            return []
+        return [("authorization", f"Bearer {token}")]
+
+    def list_projects(self, labels: Optional[Dict[str, str]] = None) -> List[Project]:
+        """List the projects, only those having every one of ``labels`` when it is given."""
+        request = fs_pb2.ListProjectsRequest(label_selector=labels or {})
+        response = self._fs.ListProjects(request, metadata=self._metadata(), timeout=self._timeout)
+
+        return [
+            Project(
+                id=project.id,
+                version=project.version,
+                labels=dict(project.labels),
+                tenant=project.tenant if project.HasField("tenant") else None,
+            )
+            for project in response.projects
+        ]
+
+    def iter_objects(
+        self,
+        project: int,
+        prefix: str = "",
+        *,
+        paths: Optional[Iterable[str]] = None,
+        ignores: Optional[Iterable[str]] = None,
+        from_version: Optional[int] = None,
+        to_version: Optional[int] = None,
+        max_content_size: Optional[int] = None,
+    ) -> Iterator[Object]:
+        """Stream the objects of a project under ``prefix``, or exactly ``paths`` when it is given.
+
+        Objects whose content is larger than ``max_content_size`` are returned without content
+        and with ``content_omitted`` set.
+        """
+        if paths is not None:
+            query = fs_pb2.ObjectQuery(paths=list(paths), ignores=list(ignores or []))
+        else:
+            query = fs_pb2.ObjectQuery(path=prefix, is_prefix=True, ignores=list(ignores or []))
+
+        request = fs_pb2.GetRequest(
+            project=project,
+            from_version=from_version,
+            to_version=to_version,
+            queries=[query],
+            max_content_send_size=max_content_size,
+        )
+
+        for response in self._fs.Get(request, metadata=self._metadata(), timeout=self._timeout):
+            obj = response.object
+            yield Object(
+                path=obj.path,
+                mode=obj.mode,
+                size=obj.size,
+                deleted=obj.deleted,
+                version=response.version,
+                content=obj.content if obj.HasField("content") else None,
+                content_type=obj.content_type if obj.HasField("content_type") else None,
+                content_omitted=obj.content_omitted,
+            )
+
+    def get(self, project: int, prefix: str = "", **kwargs) -> List[Object]:
+        """Fetch the objects of a project under ``prefix``, see :meth:`iter_objects` for the options."""
+        return list(self.iter_objects(project, prefix, **kwargs))
+
+    def get_object(self, project: int, path: str, *, to_version: Optional[int] = None) -> Optional[Object]:
+        """Fetch a single object, ``None`` when the path does not exist."""
+        for obj in self.iter_objects(project, paths=[path], to_version=to_version):
+            return obj
+        return None
+
+    def export_rows(
+        self,
+        projects: Optional[Iterable[int]] = None,
+        *,
+        prefix: str = "",
+        from_version: Optional[int] = None,
+        to_version: Optional[int] = None,
+        max_content_size: int = 0,
+    ) -> Iterator[dict]:
+        """Yield one row per object with the columns of the Go client's export command.
+
+        Every project is exported when ``projects`` is not given. Text contents smaller than or equal
+        to ``max_content_size`` are included, a ``max_content_size`` of 0 disables contents.
+        """
+        if projects is None:
+            projects = [project.id for project in self.list_projects()]
+
+        for project in projects:
+            for obj in self.iter_objects(project, prefix, from_version=from_version, to_version=to_version):
+                content = None
+                if max_content_size > 0 and not obj.deleted and _is_small_text(obj.content, max_content_size):
+                    content = obj.content.decode("utf-8")
+
+                yield {
+                    "project": project,
+                    "version": obj.version,
+                    "path": obj.path,
+                    "mode": obj.mode,
+                    "size": obj.size,
+                    "deleted": obj.deleted,
+                    "content_type": obj.content_type,
+                    "content": content,
+                }
+
+    def to_dataframe(self, projects: Optional[Iterable[int]] = None, **kwargs) -> "pandas.DataFrame":
+        """Build a pandas DataFrame of :meth:`export_rows`, requires the ``pandas`` extra."""
+        import pandas
+
+        return pandas.DataFrame.from_records(list(self.export_rows(projects, **kwargs)), columns=EXPORT_COLUMNS)
+
+    def export(self, path: str, projects: Optional[Iterable[int]] = None, **kwargs) -> "pandas.DataFrame":
+        """Write :meth:`to_dataframe` as Parquet files partitioned by project, like the Go client's export command."""
+        df = self.to_dataframe(projects, **kwargs)
+        df.to_parquet(path, partition_cols=["project"], index=False)
+        return df
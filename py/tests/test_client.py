@@ -0,0 +1,61 @@
+import os
+
+import pytest
+
+from dateilager import EXPORT_COLUMNS, Client
+from dateilager.pb import fs_pb2
+
+DEV_ADMIN_TOKEN = "v2.public.eyJzdWIiOiJhZG1pbiJ9yt40HNkcyOUtDeFa_WPS6vi0WiE4zWngDGJLh17TuYvssTudCbOdQEkVDRD-mSNTXLgSRDXUkO-AaEr4ZLO4BQ"
+PROJECT = 1338
+
+
+def root_certificates():
+    path = os.environ.get("DL_ROOT_CERT")
+    if not path:
+        return None
+    with open(path, "rb") as f:
+        return f.read()
+
+
+@pytest.fixture
+def client():
+    client = Client("localhost:5051", DEV_ADMIN_TOKEN, root_certificates=root_certificates())
+    metadata = client._metadata()
+
+    client._fs.NewProject(fs_pb2.NewProjectRequest(id=PROJECT), metadata=metadata)
+    updates = [
+        fs_pb2.UpdateRequest(
+            project=PROJECT,
+            object=fs_pb2.Objekt(path=path, mode=0o644, size=len(content), content=content),
+        )
+        for path, content in [("a.txt", b"a v1"), ("data/b.csv", b"x,y\n1,2\n"), ("data/c.bin", b"\x00\x01")]
+    ]
+    client._fs.Update(iter(updates), metadata=metadata)
+
+    yield client
+
+    client._fs.DeleteProject(fs_pb2.DeleteProjectRequest(project=PROJECT), metadata=metadata)
+    client.close()
+
+
+def test_list_projects(client):
+    assert PROJECT in [project.id for project in client.list_projects()]
+
+
+def test_get_prefix(client):
+    objects = client.get(PROJECT, "data/")
+    assert sorted(obj.path for obj in objects) == ["data/b.csv", "data/c.bin"]
+
+
+def test_get_object(client):
+    assert client.get_object(PROJECT, "a.txt").text() == "a v1"
+    assert client.get_object(PROJECT, "missing.txt") is None
+
+
+def test_to_dataframe(client):
+    df = client.to_dataframe([PROJECT], max_content_size=1024)
+    assert list(df.columns) == EXPORT_COLUMNS
+
+    rows = df.set_index("path")
+    assert rows.loc["data/b.csv", "content"] == "x,y\n1,2\n"
+    assert rows.loc["data/c.bin", "content"] is None
@@ -10,6 +10,7 @@ import (
 	"github.com/gadget-inc/dateilager/internal/auth"
 	"github.com/gadget-inc/dateilager/internal/db"
 	util "github.com/gadget-inc/dateilager/internal/testutil"
+	"github.com/gadget-inc/dateilager/pkg/api"
 	"github.com/kubernetes-csi/csi-test/pkg/sanity"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -151,6 +152,68 @@ func TestCachedCSIDriverMountsCacheAtSuffix(t *testing.T) {
 	require.Equal(t, formatFileMode(os.FileMode(0755)), formatFileMode(cacheFileInfo.Mode()))
 }
 
+func TestCachedCSIDriverLayersTemplate(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	aHash := writePackedFiles(tc, 1, 1, nil, "pack/a")
+	version, err := db.CreateCache(tc.Context(), tc.Connect(), "", 100)
+	require.NoError(t, err)
+
+	writeProject(tc, 2, 2)
+	writeObject(tc, 2, 1, i(2), "app.js", "app v1")
+	writeObject(tc, 2, 2, nil, "app.js", "app v2")
+	writeObject(tc, 2, 1, nil, "package.json", "{}")
+
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	cached, _, close := createTestCachedServer(tc, tmpDir)
+	defer close()
+
+	cached.Template = &api.CachedTemplate{Project: 2, Version: 1}
+
+	err = cached.Prepare(tc.Context())
+	require.NoError(t, err, "cached.Prepare must succeed")
+
+	targetDir := path.Join(tmpDir, "vol-target")
+	_, err = cached.NodePublishVolume(tc.Context(), &csi.NodePublishVolumeRequest{
+		VolumeId:          "foobar",
+		StagingTargetPath: path.Join(tmpDir, "vol-staging-target"),
+		TargetPath:        targetDir,
+		VolumeCapability:  &csi.VolumeCapability{},
+		VolumeContext:     map[string]string{"placeCacheAtPath": "inner_mount"},
+	})
+	require.NoError(t, err)
+
+	// the template is pinned to version 1 even though the project moved on
+	verifyDir(t, targetDir, 1, map[string]expectedFile{
+		"app.js":       {content: "app v1"},
+		"package.json": {content: "{}"},
+		fmt.Sprintf("inner_mount/objects/%v/pack/a/1", aHash): {content: "pack/a/1 v1"},
+		fmt.Sprintf("inner_mount/objects/%v/pack/a/2", aHash): {content: "pack/a/2 v1"},
+		"inner_mount/versions":                                {content: fmt.Sprintf("%v\n", version)},
+	})
+
+	// writes to the template files of a volume do not reach the staged template or other volumes
+	require.NoError(t, os.WriteFile(path.Join(targetDir, "app.js"), []byte("changed"), 0755))
+
+	otherDir := path.Join(tmpDir, "vol-other")
+	_, err = cached.NodePublishVolume(tc.Context(), &csi.NodePublishVolumeRequest{
+		VolumeId:          "other",
+		StagingTargetPath: path.Join(tmpDir, "vol-other-staging-target"),
+		TargetPath:        otherDir,
+		VolumeCapability:  &csi.VolumeCapability{},
+		VolumeContext:     map[string]string{"placeCacheAtPath": "inner_mount"},
+	})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(path.Join(otherDir, "app.js"))
+	require.NoError(t, err)
+	assert.Equal(t, "app v1", string(content))
+}
+
 func TestCachedCSIDriverProbeFailsUntilPrepared(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Admin, 1)
 	defer tc.Close()
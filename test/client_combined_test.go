@@ -1,6 +1,7 @@
 package test
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/gadget-inc/dateilager/internal/auth"
 	util "github.com/gadget-inc/dateilager/internal/testutil"
+	"github.com/gadget-inc/dateilager/pkg/client"
 	"github.com/stretchr/testify/require"
 )
 
@@ -621,3 +623,48 @@ func TestCombinedWithPrefixDirectoryBug(t *testing.T) {
 		"abc": {content: "abc v2"},
 	})
 }
+
+func TestCombinedWithProjectHandle(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	project := c.Project(1)
+
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	result, err := project.Rebuild(tc.Context(), client.RebuildOptions{Dir: tmpDir})
+	require.NoError(t, err, "Project.Rebuild")
+	require.Equal(t, int64(1), result.Version)
+
+	writeFile(t, tmpDir, "b", "b v2")
+	message := "add b"
+	version, count, err := project.Update(tc.Context(), tmpDir, client.WriteOptions{Message: &message})
+	require.NoError(t, err, "Project.Update")
+	require.Equal(t, int64(2), version)
+	require.Equal(t, uint32(1), count)
+
+	history, err := project.History(tc.Context(), client.VersionRange{})
+	require.NoError(t, err, "Project.History")
+	require.Len(t, history, 1)
+	require.Equal(t, message, history[0].GetMessage())
+
+	_, err = project.Rebuild(tc.Context(), client.RebuildOptions{Dir: tmpDir, Paths: []string{"a"}, Atomic: true})
+	require.ErrorIs(t, err, client.ErrIncompatibleRebuildOptions)
+
+	var watched []int64
+	err = project.Watch(tc.Context(), time.Millisecond, func(version int64) error {
+		watched = append(watched, version)
+		return errStopWatching
+	})
+	require.ErrorIs(t, err, errStopWatching)
+	require.Equal(t, []int64{2}, watched)
+}
+
+var errStopWatching = errors.New("stop watching")
@@ -0,0 +1,106 @@
+package test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/auth"
+	util "github.com/gadget-inc/dateilager/internal/testutil"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func readExport(t *testing.T, path string) map[string]client.ExportRow {
+	file, err := local.NewLocalFileReader(path)
+	require.NoError(t, err, "open export file")
+	defer file.Close()
+
+	parquetReader, err := reader.NewParquetReader(file, new(client.ExportRow), 1)
+	require.NoError(t, err, "read export file")
+	defer parquetReader.ReadStop()
+
+	rows := make([]client.ExportRow, parquetReader.GetNumRows())
+	require.NoError(t, parquetReader.Read(&rows), "read export rows")
+
+	byPath := make(map[string]client.ExportRow)
+	for _, row := range rows {
+		byPath[row.Path] = row
+	}
+	return byPath
+}
+
+func TestExport(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+	writeObject(tc, 1, 2, nil, "b", "b v2 with a longer content")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	testCases := []struct {
+		name           string
+		maxContentSize int64
+		expected       map[string]*string
+	}{
+		{
+			name:           "without contents",
+			maxContentSize: 0,
+			expected:       map[string]*string{"a": nil, "b": nil},
+		},
+		{
+			name:           "with small contents",
+			maxContentSize: 4,
+			expected:       map[string]*string{"a": s("a v1"), "b": nil},
+		},
+		{
+			name:           "with every content",
+			maxContentSize: 1024,
+			expected:       map[string]*string{"a": s("a v1"), "b": s("b v2 with a longer content")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			tmpDir := emptyTmpDir(t)
+			defer os.RemoveAll(tmpDir)
+
+			results, err := c.Export(tc.Context(), []int64{1}, emptyVersionRange, tmpDir, testCase.maxContentSize)
+			require.NoError(t, err, "client.Export")
+			require.Len(t, results, 1)
+
+			assert.Equal(t, int64(1), results[0].Project)
+			assert.Equal(t, int64(2), results[0].Version)
+			assert.Equal(t, int64(2), results[0].Count)
+
+			rows := readExport(t, results[0].Path)
+			require.Len(t, rows, len(testCase.expected))
+
+			for path, content := range testCase.expected {
+				row := rows[path]
+				assert.Equal(t, int64(1), row.Project, "project of %v", path)
+				assert.False(t, row.Deleted, "%v deleted", path)
+				assert.Equal(t, content, row.Content, "content of %v", path)
+			}
+			assert.Equal(t, int64(len("b v2 with a longer content")), rows["b"].Size, "size of b without its content")
+		})
+	}
+}
+
+func TestExportRejectsObjectStores(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	_, err := c.Export(tc.Context(), []int64{1}, emptyVersionRange, "gs://bucket/exports", 0)
+	require.Error(t, err, "client.Export to an object store")
+}
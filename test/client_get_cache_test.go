@@ -1,14 +1,20 @@
 package test
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/pkg/client"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/gadget-inc/dateilager/internal/auth"
 	util "github.com/gadget-inc/dateilager/internal/testutil"
@@ -83,14 +89,70 @@ func TestClientGetCacheFailsIfLockCannotBeObtained(t *testing.T) {
 	_, err = db.CreateCache(tc.Context(), tc.Connect(), "node_modules", 100)
 	require.NoError(t, err)
 
-	_, err = os.OpenFile(filepath.Join(tmpCacheDir, ".lock"), os.O_CREATE|os.O_EXCL, 0600)
+	lock, err := client.LockCache(tmpCacheDir)
 	require.NoError(t, err)
+	defer lock.Release()
 
 	_, _, err = c.GetCache(tc.Context(), tmpCacheDir)
 	assert.Error(t, err, "expected an error")
+	assert.ErrorIs(t, err, client.ErrCacheLocked)
 	assert.Contains(t, err.Error(), "unable to obtain cache lock file")
 }
 
+func TestClientGetCacheRecoversLeftoverLock(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writePackedFiles(tc, 1, 1, nil, "pack/a")
+	_, err := db.CreateCache(tc.Context(), tc.Connect(), "pack/", 100)
+	require.NoError(t, err)
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpCacheDir, err := os.MkdirTemp("", "dl_cache_test_tmp")
+	require.NoError(t, err)
+
+	// Lock file left behind by a process that crashed, its flock went away with it
+	leftover, err := json.Marshal(client.CacheLockInfo{Pid: -1, Heartbeat: time.Now().Add(-time.Hour)})
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(tmpCacheDir, ".lock"), leftover, 0600)
+	require.NoError(t, err)
+
+	_, count, err := c.GetCache(tc.Context(), tmpCacheDir)
+	require.NoError(t, err, "client.GetCache")
+	assert.Equal(t, uint32(2), count)
+
+	_, err = os.Stat(filepath.Join(tmpCacheDir, ".lock"))
+	assert.ErrorIs(t, err, os.ErrNotExist, "lock file is removed on release")
+}
+
+func TestUnlockCache(t *testing.T) {
+	tmpCacheDir, err := os.MkdirTemp("", "dl_cache_test_tmp")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpCacheDir)
+
+	info, err := client.UnlockCache(tmpCacheDir, false)
+	require.NoError(t, err, "unlocking an unlocked cache")
+	assert.Nil(t, info)
+
+	lock, err := client.LockCache(tmpCacheDir)
+	require.NoError(t, err)
+	defer lock.Release()
+
+	info, err = client.UnlockCache(tmpCacheDir, false)
+	assert.ErrorIs(t, err, client.ErrCacheLocked, "the holder is alive")
+	require.NotNil(t, info)
+	assert.Equal(t, os.Getpid(), info.Pid)
+
+	_, err = client.UnlockCache(tmpCacheDir, true)
+	require.NoError(t, err, "forced unlock")
+
+	_, err = os.Stat(filepath.Join(tmpCacheDir, ".lock"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
 func TestClientCanHaveMultipleCacheVersions(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Project, 1)
 	defer tc.Close()
@@ -122,36 +184,142 @@ func TestClientCanHaveMultipleCacheVersions(t *testing.T) {
 	assert.Equal(t, fmt.Sprintf("%d\n%d\n", version1, version2), string(versionsFileContent))
 }
 
-// This needs to remain skipped until we split GetCache into two requests
-// If we start a request and then early exit we leave the GRPC request hanging
-//
-// func TestDownloadingTheSameVersionTwice(t *testing.T) {
-// 	tc := util.NewTestCtx(t, auth.Project, 1)
-// 	defer tc.Close()
+func TestClientGetCacheOnlyFetchesMissingObjects(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	hashA := writePackedFiles(tc, 1, 1, nil, "node_modules/a")
+	writePackedFiles(tc, 1, 1, nil, "node_modules/b")
+	_, err := db.CreateCache(tc.Context(), tc.Connect(), "node_modules/", 100)
+	require.NoError(t, err)
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpCacheDir, err := os.MkdirTemp("", "dl_cache_test_tmp")
+	require.NoError(t, err)
+
+	_, count, err := c.GetCache(tc.Context(), tmpCacheDir)
+	require.NoError(t, err, "client.GetCache")
+	assert.Equal(t, uint32(4), count)
+
+	// Simulate a download interrupted before the second object was materialized, the version is only recorded on success
+	err = os.RemoveAll(filepath.Join(tmpCacheDir, "objects", hashA))
+	require.NoError(t, err)
+	err = os.Remove(filepath.Join(tmpCacheDir, "versions"))
+	require.NoError(t, err)
+
+	_, count, err = c.GetCache(tc.Context(), tmpCacheDir)
+	require.NoError(t, err, "client.GetCache resume")
+	assert.Equal(t, uint32(2), count, "only the missing object is downloaded")
+
+	names, err := filepath.Glob(filepath.Join(tmpCacheDir, "objects/*/node_modules/*"))
+	require.NoError(t, err)
+	assert.Len(t, names, 2)
+}
+
+func TestDownloadingTheSameVersionTwice(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writePackedFiles(tc, 1, 1, nil, "pack/a")
+	_, err := db.CreateCache(tc.Context(), tc.Connect(), "pack/", 100)
+	require.NoError(t, err)
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpCacheDir, err := os.MkdirTemp("", "dl_cache_test_tmp")
+	require.NoError(t, err)
+
+	version1, count, err := c.GetCache(tc.Context(), tmpCacheDir)
+	require.NoError(t, err, "client.GetCache after GetCache")
+	assert.Equal(t, uint32(2), count)
+
+	_, count, err = c.GetCache(tc.Context(), tmpCacheDir)
+	require.NoError(t, err, "client.GetCache after GetCache")
+	assert.Equal(t, uint32(0), count)
+
+	versionsFileContent, err := os.ReadFile(filepath.Join(tmpCacheDir, "versions"))
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%d\n", version1), string(versionsFileContent))
+}
+
+func TestClientCreateAndListCacheVersions(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writePackedFiles(tc, 1, 1, nil, "pack/a")
+	writePackedFiles(tc, 1, 1, nil, "pack/b")
+	writePackedFiles(tc, 1, 1, nil, "other/c")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	versions, err := c.ListCacheVersions(tc.Context())
+	require.NoError(t, err, "client.ListCacheVersions")
+	assert.Empty(t, versions)
+
+	version1, err := c.CreateCache(tc.Context(), []string{"pack/"}, nil, nil, nil)
+	require.NoError(t, err, "client.CreateCache")
+
+	version2, err := c.CreateCache(tc.Context(), nil, nil, nil, i(1))
+	require.NoError(t, err, "client.CreateCache with count")
+
+	versions, err = c.ListCacheVersions(tc.Context())
+	require.NoError(t, err, "client.ListCacheVersions")
+	require.Len(t, versions, 2)
+	assert.Equal(t, version1, versions[0].Version)
+	assert.Equal(t, int64(2), versions[0].ObjectsCount)
+	assert.Equal(t, version2, versions[1].Version)
+	assert.Equal(t, int64(1), versions[1].ObjectsCount)
+
+	_, err = c.CreateCache(tc.Context(), nil, nil, nil, i(0))
+	assert.Error(t, err, "a cache needs at least one object")
+}
+
+func TestClientDeleteCacheVersion(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
 
-// 	writeProject(tc, 1, 1)
-// 	writePackedFiles(tc, 1, 1, nil, "pack/a")
-// 	_, err := db.CreateCache(tc.Context(), tc.Connect(), "pack/", 100)
-// 	require.NoError(t, err)
+	writeProject(tc, 1, 1)
+	writePackedFiles(tc, 1, 1, nil, "pack/a")
+	writePackedFiles(tc, 1, 1, nil, "pack/b")
 
-// 	c, _, close := createTestClient(tc)
-// 	defer close()
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	version1, err := c.CreateCache(tc.Context(), []string{"pack/"}, nil, nil, nil)
+	require.NoError(t, err, "client.CreateCache")
+
+	version2, err := c.CreateCache(tc.Context(), []string{"pack/"}, nil, nil, i(1))
+	require.NoError(t, err, "client.CreateCache with count")
 
-// 	tmpCacheDir, err := os.MkdirTemp("", "dl_cache_test_tmp")
-// 	require.NoError(t, err)
+	// the packs are now only referenced by the cache versions
+	_, err = tc.FsApi().DeleteProject(tc.Context(), &pb.DeleteProjectRequest{Project: 1})
+	require.NoError(t, err, "fs.DeleteProject")
 
-// 	version1, count, err := c.GetCache(tc.Context(), tmpCacheDir)
-// 	require.NoError(t, err, "client.GetCache after GetCache")
-// 	assert.Equal(t, uint32(2), count)
+	contentsCount := countContents(tc)
 
-// 	_, count, err = c.GetCache(tc.Context(), tmpCacheDir)
-// 	require.NoError(t, err, "client.GetCache after GetCache")
-// 	assert.Equal(t, uint32(0), count)
+	_, err = c.DeleteCacheVersion(tc.Context(), version2)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "the latest cache version cannot be deleted")
 
-// 	versionsFileContent, err := os.ReadFile(filepath.Join(tmpCacheDir, "versions"))
-// 	require.NoError(t, err)
-// 	assert.Equal(t, fmt.Sprintf("%d\n", version1), string(versionsFileContent))
-// }
+	_, err = c.DeleteCacheVersion(tc.Context(), 999)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	count, err := c.DeleteCacheVersion(tc.Context(), version1)
+	require.NoError(t, err, "client.DeleteCacheVersion")
+	assert.Equal(t, int64(1), count, "only the pack missing from the latest version is reclaimed")
+	assert.Equal(t, contentsCount-1, countContents(tc))
+
+	versions, err := c.ListCacheVersions(tc.Context())
+	require.NoError(t, err, "client.ListCacheVersions")
+	require.Len(t, versions, 1)
+	assert.Equal(t, version2, versions[0].Version)
+}
 
 func dirFileNames(t *testing.T, path string) []string {
 	cacheRootFiles, err := os.ReadDir(path)
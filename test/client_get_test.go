@@ -1,6 +1,7 @@
 package test
 
 import (
+	"os"
 	"testing"
 
 	"github.com/gadget-inc/dateilager/internal/auth"
@@ -135,3 +136,47 @@ func TestGetVersionMissingProject(t *testing.T) {
 	objects, err := c.Get(tc.Context(), 1, "", nil, toVersion(1))
 	require.Error(t, err, "client.GetLatest didn't error accessing objects: %v", objects)
 }
+
+func TestGetWithReadSnapshot(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	ctx, version, err := c.PinReadSnapshot(tc.Context(), 1)
+	require.NoError(t, err, "client.PinReadSnapshot")
+	require.Equal(t, int64(1), version)
+
+	tmpDir := writeTmpFiles(t, 1, map[string]string{
+		"a": "a v1",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, "a", "a v2")
+	writeFile(t, tmpDir, "b", "b v2")
+	update(tc, c, 1, tmpDir, expectedResponse{version: 2, count: 2})
+
+	objects, err := c.Get(ctx, 1, "", nil, emptyVersionRange)
+	require.NoError(t, err, "client.Get pinned to the snapshot")
+	verifyObjects(t, objects, map[string]string{
+		"a": "a v1",
+	})
+
+	stats, err := c.StatPaths(ctx, 1, nil, []string{"b"})
+	require.NoError(t, err, "client.StatPaths pinned to the snapshot")
+	require.Equal(t, int64(1), stats.Version)
+
+	_, err = c.Get(ctx, 1, "", nil, toVersion(2))
+	require.Error(t, err, "an explicit version conflicting with the snapshot is rejected")
+
+	objects, err = c.Get(tc.Context(), 1, "", nil, emptyVersionRange)
+	require.NoError(t, err, "client.Get without a snapshot")
+	verifyObjects(t, objects, map[string]string{
+		"a": "a v2",
+		"b": "b v2",
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gadget-inc/dateilager/internal/auth"
 	util "github.com/gadget-inc/dateilager/internal/testutil"
+	"github.com/gadget-inc/dateilager/pkg/client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -65,3 +66,23 @@ func TestClientNewProjectDuplicateReportsError(t *testing.T) {
 
 	require.Error(t, errSecond, "NewProject already exists error")
 }
+
+func TestClientNewProjectRetryWithIdempotencyKey(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin, 1)
+	defer tc.Close()
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	idempotencyKey := "new-project-1"
+
+	err := c.NewProjectWithOptions(tc.Context(), 1, client.NewProjectOptions{IdempotencyKey: &idempotencyKey})
+	require.NoError(t, err, "NewProject")
+
+	err = c.NewProjectWithOptions(tc.Context(), 1, client.NewProjectOptions{IdempotencyKey: &idempotencyKey})
+	require.NoError(t, err, "NewProject retried with the same idempotency key")
+
+	otherKey := "new-project-2"
+	err = c.NewProjectWithOptions(tc.Context(), 1, client.NewProjectOptions{IdempotencyKey: &otherKey})
+	require.Error(t, err, "NewProject with another idempotency key already exists")
+}
@@ -0,0 +1,146 @@
+package test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/auth"
+	util "github.com/gadget-inc/dateilager/internal/testutil"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClientProvisionProjectFromTemplate(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	writeObject(tc, 1, 1, i(2), "a", "a v1")
+	writeObject(tc, 1, 1, nil, "b", "b v1")
+	writeObject(tc, 1, 2, nil, "a", "a v2")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	version, labels, err := c.ProvisionProject(tc.Context(), 2, client.ProvisionOptions{
+		Template: i(1),
+		Labels:   map[string]string{"env": "staging"},
+		Message:  s("provisioned"),
+	})
+	require.NoError(t, err, "client.ProvisionProject")
+	assert.Equal(t, int64(1), version, "provisioned version")
+	assert.Equal(t, map[string]string{"env": "staging"}, labels, "provisioned labels")
+
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	rebuild(tc, c, 2, nil, tmpDir, nil, expectedResponse{
+		version: 1,
+		count:   2,
+	})
+
+	verifyDir(t, tmpDir, 1, map[string]expectedFile{
+		"a": {content: "a v2"},
+		"b": {content: "b v1"},
+	})
+
+	history, err := c.History(tc.Context(), 2, client.VersionRange{})
+	require.NoError(t, err, "client.History")
+	require.Len(t, history, 1, "history of the provisioned project")
+	assert.Equal(t, "provisioned", history[0].GetMessage(), "annotation of the cloned version")
+}
+
+func TestClientProvisionProjectAtTemplateVersion(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	writeObject(tc, 1, 1, i(2), "a", "a v1")
+	writeObject(tc, 1, 2, nil, "a", "a v2")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	version, _, err := c.ProvisionProject(tc.Context(), 2, client.ProvisionOptions{
+		Template:        i(1),
+		TemplateVersion: i(1),
+	})
+	require.NoError(t, err, "client.ProvisionProject")
+
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	rebuild(tc, c, 2, &version, tmpDir, nil, expectedResponse{
+		version: 1,
+		count:   1,
+	})
+
+	verifyDir(t, tmpDir, 1, map[string]expectedFile{
+		"a": {content: "a v1"},
+	})
+}
+
+func TestClientProvisionProjectWithoutTemplate(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin, 1)
+	defer tc.Close()
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	version, labels, err := c.ProvisionProject(tc.Context(), 1, client.ProvisionOptions{
+		Labels: map[string]string{"team": "core"},
+	})
+	require.NoError(t, err, "client.ProvisionProject")
+	assert.Equal(t, int64(0), version, "provisioned version")
+	assert.Equal(t, map[string]string{"team": "core"}, labels, "provisioned labels")
+
+	_, _, err = c.ProvisionProject(tc.Context(), 1, client.ProvisionOptions{})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err), "provisioning an existing project")
+}
+
+func TestClientProvisionProjectFailureLeavesNothing(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1, "node_modules/")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	_, _, err := c.ProvisionProject(tc.Context(), 2, client.ProvisionOptions{
+		Template: i(1),
+		Labels:   map[string]string{"env": "staging"},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "template with other pack patterns")
+
+	_, err = c.GetProjectLabels(tc.Context(), 2)
+	assert.Equal(t, codes.NotFound, status.Code(err), "failed provisioning created the project")
+}
+
+func TestClientProvisionProjectRetryWithIdempotencyKey(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	opts := client.ProvisionOptions{
+		Template:       i(1),
+		Labels:         map[string]string{"env": "staging"},
+		IdempotencyKey: s("provision-2"),
+	}
+
+	version, labels, err := c.ProvisionProject(tc.Context(), 2, opts)
+	require.NoError(t, err, "client.ProvisionProject")
+
+	retriedVersion, retriedLabels, err := c.ProvisionProject(tc.Context(), 2, opts)
+	require.NoError(t, err, "client.ProvisionProject retry")
+	assert.Equal(t, version, retriedVersion, "retried version")
+	assert.Equal(t, labels, retriedLabels, "retried labels")
+}
@@ -1,17 +1,27 @@
 package test
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gadget-inc/dateilager/pkg/client"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/gadget-inc/dateilager/internal/db"
 	"github.com/gadget-inc/dateilager/internal/files"
+	"github.com/gadget-inc/dateilager/internal/pb"
 
 	"github.com/gadget-inc/dateilager/internal/auth"
 	util "github.com/gadget-inc/dateilager/internal/testutil"
@@ -249,6 +259,203 @@ func TestRebuildWithPackedObjects(t *testing.T) {
 	})
 }
 
+func TestRebuildWithExplicitPaths(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+	writeObject(tc, 1, 1, nil, "b/c", "b/c v1")
+	writeObject(tc, 1, 1, nil, "b/d", "b/d v1")
+	writeObject(tc, 1, 1, nil, "e", "e v1")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	cacheDir := emptyTmpDir(t)
+	defer os.RemoveAll(cacheDir)
+
+	result, err := c.RebuildPaths(tc.Context(), 1, []string{"a", "b/d", "missing"}, nil, tmpDir, cacheDir, nil, true)
+	require.NoError(t, err, "client.RebuildPaths")
+
+	assert.Equal(t, int64(1), result.Version, "mismatch rebuild version")
+	assert.Equal(t, uint32(2), result.Count, "mismatch rebuild count")
+
+	verifyDir(t, tmpDir, 1, map[string]expectedFile{
+		"a":   {content: "a v1"},
+		"b/d": {content: "b/d v1"},
+	})
+}
+
+func TestRebuildWithSparseProfile(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "packages/api/a", "api a v1")
+	writeObject(tc, 1, 1, nil, "packages/api/test/b", "api b v1")
+	writeObject(tc, 1, 1, nil, "packages/web/c", "web c v1")
+	writeObject(tc, 1, 1, nil, "d", "d v1")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, ".dl/sparse", "# api only\npackages/api/**\n!packages/api/test/**\n")
+
+	rebuild(tc, c, 1, nil, tmpDir, nil, expectedResponse{
+		version: 1,
+		count:   1,
+	})
+
+	verifyDir(t, tmpDir, 1, map[string]expectedFile{
+		"packages/api/a": {content: "api a v1"},
+	})
+}
+
+func TestRebuildWithOmittedContent(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "small")
+	writeObject(tc, 1, 1, nil, "b", "this content is too large")
+
+	c, fs, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	fs.MaxContentSendSize = 10
+
+	result, err := c.Rebuild(tc.Context(), 1, "", nil, tmpDir, nil, "", nil, true)
+	require.NoError(t, err, "client.Rebuild")
+	assert.Equal(t, []string{"b"}, result.Omitted)
+
+	verifyDir(t, tmpDir, 1, map[string]expectedFile{
+		"a": {content: "small"},
+	})
+
+	omitted, err := client.ReadOmittedFile(tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, omitted)
+
+	fs.MaxContentSendSize = 0
+
+	result, err = c.FetchOmitted(tc.Context(), 1, tmpDir, "", nil)
+	require.NoError(t, err, "client.FetchOmitted")
+	assert.Equal(t, uint32(1), result.Count)
+	assert.Empty(t, result.Omitted)
+
+	verifyDir(t, tmpDir, 1, map[string]expectedFile{
+		"a": {content: "small"},
+		"b": {content: "this content is too large"},
+	})
+
+	omitted, err = client.ReadOmittedFile(tmpDir)
+	require.NoError(t, err)
+	assert.Empty(t, omitted)
+}
+
+func TestRebuildLazy(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "small")
+	writeObject(tc, 1, 1, nil, "b", "large content b")
+	writeObject(tc, 1, 1, nil, "c/d", "large content d")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	result, err := c.RebuildLazy(tc.Context(), 1, "", nil, tmpDir, nil, "", nil, true, 10)
+	require.NoError(t, err, "client.RebuildLazy")
+	assert.ElementsMatch(t, []string{"b", "c/d"}, result.Omitted)
+
+	verifyDir(t, tmpDir, 1, map[string]expectedFile{
+		"a":   {content: "small"},
+		"b":   {content: string(make([]byte, 15))},
+		"c/d": {content: string(make([]byte, 15))},
+	})
+
+	_, err = c.FetchOmitted(tc.Context(), 1, tmpDir, "", []string{"a"})
+	require.Error(t, err, "fetching a path that was not omitted should fail")
+
+	result, err = c.FetchOmitted(tc.Context(), 1, tmpDir, "", []string{"c/d"})
+	require.NoError(t, err, "client.FetchOmitted")
+	assert.Equal(t, uint32(1), result.Count)
+
+	verifyDir(t, tmpDir, 1, map[string]expectedFile{
+		"a":   {content: "small"},
+		"b":   {content: string(make([]byte, 15))},
+		"c/d": {content: "large content d"},
+	})
+
+	omitted, err := client.ReadOmittedFile(tmpDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, omitted)
+
+	// Neither the remaining stub nor the fetched file are local changes
+	update(tc, c, 1, tmpDir, expectedResponse{
+		version: 1,
+		count:   0,
+	})
+}
+
+func TestRebuildSharesObjectCacheBetweenProjects(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	shared := strings.Repeat("shared template content\n", 512)
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", shared)
+	writeObject(tc, 1, 1, nil, "b", "small a")
+
+	writeProject(tc, 2, 1)
+	writeObject(tc, 2, 1, nil, "a", shared)
+	writeObject(tc, 2, 1, nil, "b", "small b")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	cacheDir := emptyTmpDir(t)
+	defer os.RemoveAll(cacheDir)
+
+	firstDir := emptyTmpDir(t)
+	defer os.RemoveAll(firstDir)
+
+	_, err := c.Rebuild(tc.Context(), 1, "", nil, firstDir, nil, cacheDir, nil, true)
+	require.NoError(t, err, "client.Rebuild")
+
+	hash := db.HashContent([]byte(shared))
+	cached, err := os.ReadFile(filepath.Join(client.CacheLooseObjectsDir(cacheDir), hash.Hex()))
+	require.NoError(t, err, "large object should be stored in the object cache")
+	assert.Equal(t, shared, string(cached))
+
+	secondDir := emptyTmpDir(t)
+	defer os.RemoveAll(secondDir)
+
+	result, err := c.Rebuild(tc.Context(), 2, "", nil, secondDir, nil, cacheDir, nil, true)
+	require.NoError(t, err, "client.Rebuild")
+	assert.Equal(t, uint32(2), result.Count)
+
+	verifyDir(t, secondDir, 1, map[string]expectedFile{
+		"a": {content: shared},
+		"b": {content: "small b"},
+	})
+}
+
 func TestRebuildWithCache(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Project, 1)
 	defer tc.Close()
@@ -528,3 +735,380 @@ func TestRebuildFileBecomesADir(t *testing.T) {
 		"a.html/foo": {content: "a v2"},
 	})
 }
+
+func TestRebuildRestoreMtimes(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	updateDir := writeTmpFiles(t, 1, map[string]string{})
+	defer os.RemoveAll(updateDir)
+
+	mtime := time.Date(2020, time.January, 2, 3, 4, 5, 6000, time.UTC)
+	writeFile(t, updateDir, "a", "a v2")
+	err := os.Chtimes(filepath.Join(updateDir, "a"), mtime, mtime)
+	require.NoError(t, err)
+
+	update(tc, c, 1, updateDir, expectedResponse{version: 2, count: 1})
+
+	restoredDir := emptyTmpDir(t)
+	defer os.RemoveAll(restoredDir)
+
+	_, err = c.Project(1).Rebuild(tc.Context(), client.RebuildOptions{Dir: restoredDir, RestoreMtimes: true})
+	require.NoError(t, err, "client.Rebuild")
+
+	info, err := os.Stat(filepath.Join(restoredDir, "a"))
+	require.NoError(t, err)
+	assert.True(t, mtime.Equal(info.ModTime()), "expected mtime %v, got %v", mtime, info.ModTime())
+
+	writtenDir := emptyTmpDir(t)
+	defer os.RemoveAll(writtenDir)
+
+	_, err = c.Rebuild(tc.Context(), 1, "", nil, writtenDir, nil, "", nil, true)
+	require.NoError(t, err, "client.Rebuild")
+
+	info, err = os.Stat(filepath.Join(writtenDir, "a"))
+	require.NoError(t, err)
+	assert.True(t, info.ModTime().After(mtime), "expected the write time, got %v", info.ModTime())
+}
+
+func TestRebuildCancelledMidStream(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	v1Files := make(map[string]string)
+	v2Files := make(map[string]expectedFile)
+
+	writeProject(tc, 1, 2)
+	for idx := 0; idx < 100; idx++ {
+		path := fmt.Sprintf("%d", idx)
+		v1 := make([]byte, 50000)
+		v2 := make([]byte, 50000)
+		_, err := rand.Read(v1)
+		require.NoError(t, err, "could not generate random bytes")
+		_, err = rand.Read(v2)
+		require.NoError(t, err, "could not generate random bytes")
+
+		writeObject(tc, 1, 1, i(2), path, string(v1))
+		writeObject(tc, 1, 2, nil, path, string(v2))
+		v1Files[path] = string(v1)
+		v2Files[path] = expectedFile{content: string(v2)}
+	}
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := writeTmpFiles(t, 1, v1Files)
+	defer os.RemoveAll(tmpDir)
+
+	ctx, cancel := context.WithCancel(tc.Context())
+	defer cancel()
+
+	// Cancel as soon as the first pack is written, while the others are still in flight
+	ctx = client.WithProgress(ctx, func(client.Progress) { cancel() })
+
+	_, err := c.Rebuild(ctx, 1, "", nil, tmpDir, nil, "", nil, true)
+	require.Error(t, err, "client.Rebuild should fail once cancelled")
+
+	version, err := client.ReadVersionFile(tmpDir)
+	require.NoError(t, err, "read version file")
+	assert.Equal(t, int64(1), version, "a cancelled rebuild must not move the version")
+
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err, "read dir")
+
+	for _, entry := range entries {
+		if entry.Name() == ".dl" {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(tmpDir, entry.Name()))
+		require.NoError(t, err, "read %v", entry.Name())
+
+		if string(content) != v1Files[entry.Name()] && string(content) != v2Files[entry.Name()].content {
+			t.Errorf("file %v holds partial content after a cancelled rebuild (%d bytes)", entry.Name(), len(content))
+		}
+	}
+	assert.Len(t, entries, len(v1Files)+1, "no temporary file should be left behind")
+
+	rebuild(tc, c, 1, nil, tmpDir, nil, expectedResponse{
+		version: 2,
+		count:   100,
+	})
+
+	verifyDir(t, tmpDir, 2, v2Files)
+}
+
+func TestRebuildAtomic(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	writeObject(tc, 1, 1, i(2), "a", "a v1")
+	writeObject(tc, 1, 1, i(2), "b", "b v1")
+	writeObject(tc, 1, 1, nil, "c/d", "c/d v1")
+	writeObject(tc, 1, 2, nil, "a", "a v2")
+	writeObject(tc, 1, 2, nil, "e", "e v2")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := writeTmpFiles(t, 1, map[string]string{
+		"a":   "a v1",
+		"b":   "b v1",
+		"c/d": "c/d v1",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	before, err := os.Stat(filepath.Join(tmpDir, "c/d"))
+	require.NoError(t, err, "stat c/d")
+
+	result, err := c.RebuildAtomic(tc.Context(), 1, "", nil, tmpDir, nil, "", nil, true)
+	require.NoError(t, err, "client.RebuildAtomic")
+	assert.Equal(t, int64(2), result.Version)
+
+	verifyDir(t, tmpDir, 2, map[string]expectedFile{
+		"a":   {content: "a v2"},
+		"c/d": {content: "c/d v1"},
+		"e":   {content: "e v2"},
+	})
+
+	after, err := os.Stat(filepath.Join(tmpDir, "c/d"))
+	require.NoError(t, err, "stat c/d")
+	assert.True(t, os.SameFile(before, after), "unchanged files should be hardlinked from the previous tree")
+
+	staging, err := filepath.Glob(filepath.Join(filepath.Dir(tmpDir), "."+filepath.Base(tmpDir)+".dl-staging-*"))
+	require.NoError(t, err, "glob staging dirs")
+	assert.Empty(t, staging, "the staging dir should be removed")
+
+	update(tc, c, 1, tmpDir, expectedResponse{
+		version: 2,
+		count:   0,
+	})
+}
+
+func TestRebuildAtomicIntoMissingDir(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+	dir := filepath.Join(tmpDir, "checkout")
+
+	result, err := c.RebuildAtomic(tc.Context(), 1, "", nil, dir, nil, "", nil, true)
+	require.NoError(t, err, "client.RebuildAtomic")
+	assert.Equal(t, int64(1), result.Version)
+
+	verifyDir(t, dir, 1, map[string]expectedFile{
+		"a": {content: "a v1"},
+	})
+}
+
+func TestForceFullRebuildWithCorruptVersionFile(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+	writeObject(tc, 1, 1, i(2), "b", "b v1")
+	writeObject(tc, 1, 2, nil, "c", "c v2")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := writeTmpFiles(t, 1, map[string]string{
+		"a": "a v1",
+		"b": "b v1",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, ".dl/version", "garbage")
+
+	_, err := c.Rebuild(tc.Context(), 1, "", nil, tmpDir, nil, "", nil, true)
+	require.ErrorIs(t, err, client.ErrCorruptVersionFile)
+	assert.True(t, client.IsCorruptMetadata(err))
+
+	result, err := c.ForceFullRebuild(tc.Context(), 1, "", nil, tmpDir, nil, "", nil, true, false)
+	require.NoError(t, err, "client.ForceFullRebuild")
+	assert.Equal(t, int64(2), result.Version)
+
+	verifyDir(t, tmpDir, 2, map[string]expectedFile{
+		"a": {content: "a v1"},
+		"c": {content: "c v2"},
+	})
+
+	update(tc, c, 1, tmpDir, expectedResponse{
+		version: 2,
+		count:   0,
+	})
+}
+
+func TestRebuildTar(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	writeObject(tc, 1, 1, i(2), "a", "a v1")
+	writeObject(tc, 1, 1, nil, "b/c", "c v1")
+	writeObject(tc, 1, 2, nil, "a", "a v2")
+	writeSymlink(tc, 1, 2, nil, "d", "a")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	var buffer bytes.Buffer
+	result, err := c.RebuildTar(tc.Context(), 1, "", nil, nil, &buffer, true)
+	require.NoError(t, err, "client.RebuildTar")
+
+	assert.Equal(t, int64(2), result.Version)
+	assert.Equal(t, uint32(3), result.Count)
+
+	gzipReader, err := gzip.NewReader(&buffer)
+	require.NoError(t, err, "gzip.NewReader")
+
+	entries := make(map[string]string)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err, "tarReader.Next")
+
+		if header.Typeflag == tar.TypeSymlink {
+			entries[header.Name] = "-> " + header.Linkname
+			continue
+		}
+
+		content, err := io.ReadAll(tarReader)
+		require.NoError(t, err, "io.ReadAll")
+		entries[header.Name] = string(content)
+	}
+
+	assert.Equal(t, map[string]string{
+		"a":   "a v2",
+		"b/c": "c v1",
+		"d":   "-> a",
+	}, entries)
+}
+
+func TestExportOCIOntoBase(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+	writeObject(tc, 1, 2, nil, "b", "b v2")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	baseDir := emptyTmpDir(t)
+	defer os.RemoveAll(baseDir)
+
+	base, err := c.ExportOCI(tc.Context(), 1, "", i(1), baseDir, "", "")
+	require.NoError(t, err, "client.ExportOCI base")
+	assert.Equal(t, int64(1), base.Version)
+	assert.Equal(t, uint32(1), base.Count)
+
+	layer, err := os.ReadFile(filepath.Join(baseDir, "blobs", "sha256", strings.TrimPrefix(base.LayerDigest, "sha256:")))
+	require.NoError(t, err, "read base layer")
+	assert.Equal(t, base.LayerDigest, fmt.Sprintf("sha256:%x", sha256.Sum256(layer)))
+	assert.Equal(t, base.LayerSize, int64(len(layer)))
+
+	outDir := emptyTmpDir(t)
+	defer os.RemoveAll(outDir)
+
+	result, err := c.ExportOCI(tc.Context(), 1, "", nil, outDir, baseDir, "v2")
+	require.NoError(t, err, "client.ExportOCI")
+	assert.Equal(t, int64(2), result.Version)
+	assert.Equal(t, uint32(2), result.Count)
+
+	readJSON := func(path string) map[string]any {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err, "read %v", path)
+
+		var value map[string]any
+		require.NoError(t, json.Unmarshal(content, &value), "decode %v", path)
+		return value
+	}
+	blob := func(digest string) string {
+		return filepath.Join(outDir, "blobs", "sha256", strings.TrimPrefix(digest, "sha256:"))
+	}
+
+	index := readJSON(filepath.Join(outDir, "index.json"))
+	manifests := index["manifests"].([]any)
+	require.Len(t, manifests, 1)
+	assert.Equal(t, result.ManifestDigest, manifests[0].(map[string]any)["digest"])
+	assert.Equal(t, "v2", manifests[0].(map[string]any)["annotations"].(map[string]any)["org.opencontainers.image.ref.name"])
+
+	manifest := readJSON(blob(result.ManifestDigest))
+	layers := manifest["layers"].([]any)
+	require.Len(t, layers, 2)
+	assert.Equal(t, base.LayerDigest, layers[0].(map[string]any)["digest"])
+	assert.Equal(t, result.LayerDigest, layers[1].(map[string]any)["digest"])
+	assert.FileExists(t, blob(base.LayerDigest))
+
+	config := readJSON(blob(manifest["config"].(map[string]any)["digest"].(string)))
+	diffIDs := config["rootfs"].(map[string]any)["diff_ids"].([]any)
+	assert.Equal(t, []any{base.LayerDiffID, result.LayerDiffID}, diffIDs)
+}
+
+func TestRebuildWithTransforms(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	_, err := tc.FsApi().NewProject(tc.Context(), &pb.NewProjectRequest{
+		Id:         1,
+		Transforms: []string{"strip-sourcemaps:**.js", "template:**.json"},
+	})
+	require.NoError(t, err, "fs.NewProject")
+
+	writeObject(tc, 1, 1, nil, "app.js", "console.log(1);\n//# sourceMappingURL=app.js.map\n")
+	writeObject(tc, 1, 1, nil, "raw.json", `{"env": "${ENV}"}`)
+	writePackedObjects(tc, 1, 1, nil, "pack/a", map[string]expectedObject{
+		"pack/a/env.json": {content: `{"env": "${ENV}"}`},
+		"pack/a/raw.txt":  {content: "env: ${ENV}"},
+	})
+	_, err = tc.Connect().Exec(tc.Context(), "UPDATE dl.projects SET latest_version = 1 WHERE id = 1")
+	require.NoError(t, err, "update latest version")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	ctx := client.WithTransforms(tc.Context(), map[string]string{"ENV": "production"})
+	result, err := c.Rebuild(ctx, 1, "", nil, tmpDir, nil, "", nil, true)
+	require.NoError(t, err, "client.Rebuild")
+	assert.Equal(t, int64(1), result.Version, "mismatch rebuild version")
+
+	verifyDir(t, tmpDir, 1, map[string]expectedFile{
+		"app.js":          {content: "console.log(1);\n"},
+		"raw.json":        {content: `{"env": "production"}`},
+		"pack/a/env.json": {content: `{"env": "production"}`},
+		"pack/a/raw.txt":  {content: "env: ${ENV}"},
+	})
+
+	otherDir := emptyTmpDir(t)
+	defer os.RemoveAll(otherDir)
+
+	rebuild(tc, c, 1, nil, otherDir, nil, expectedResponse{version: 1, count: 4})
+	verifyDir(t, otherDir, 1, map[string]expectedFile{
+		"app.js":          {content: "console.log(1);\n//# sourceMappingURL=app.js.map\n"},
+		"raw.json":        {content: `{"env": "${ENV}"}`},
+		"pack/a/env.json": {content: `{"env": "${ENV}"}`},
+		"pack/a/raw.txt":  {content: "env: ${ENV}"},
+	})
+}
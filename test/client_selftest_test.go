@@ -0,0 +1,58 @@
+package test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/auth"
+	util "github.com/gadget-inc/dateilager/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfTest(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	result, err := c.SelfTest(tc.Context(), 1, tmpDir)
+	require.NoError(t, err, "client.SelfTest")
+
+	var names []string
+	for _, step := range result.Steps {
+		assert.Empty(t, step.Error, "step %v", step.Name)
+		names = append(names, step.Name)
+	}
+
+	assert.True(t, result.Ok)
+	assert.Equal(t, []string{"new", "update", "rebuild", "diff", "gc-dry-run", "delete"}, names)
+	assert.Equal(t, 0, countObjectsByProject(tc, 1), "self test project was deleted")
+}
+
+func TestSelfTestLeavesExistingProjects(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	result, err := c.SelfTest(tc.Context(), 1, tmpDir)
+	require.NoError(t, err, "client.SelfTest")
+
+	assert.False(t, result.Ok)
+	require.Len(t, result.Steps, 1)
+	assert.Equal(t, "new", result.Steps[0].Name)
+	assert.NotEmpty(t, result.Steps[0].Error)
+	assert.Equal(t, 1, countObjectsByProject(tc, 1), "existing project was left alone")
+}
@@ -5,11 +5,16 @@ import (
 	"crypto/rand"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gadget-inc/dateilager/internal/auth"
+	"github.com/gadget-inc/dateilager/internal/pb"
 	util "github.com/gadget-inc/dateilager/internal/testutil"
+	"github.com/gadget-inc/dateilager/pkg/api"
+	"github.com/gadget-inc/dateilager/pkg/client"
 	"github.com/gadget-inc/dateilager/pkg/server"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -54,6 +59,362 @@ func TestUpdateObjects(t *testing.T) {
 	})
 }
 
+func TestUpdateWithIncludeAndExclude(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+	writeObject(tc, 1, 1, nil, "b", "b v1")
+	writeObject(tc, 1, 1, nil, "config/c", "c v1")
+	writeObject(tc, 1, 1, nil, "config/d.tmp", "d v1")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := writeTmpFiles(t, 1, map[string]string{
+		"a":            "a v1",
+		"b":            "b v1",
+		"config/c":     "c v1",
+		"config/d.tmp": "d v1",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, "a", "a v2")
+	writeFile(t, tmpDir, "config/c", "c v2")
+	writeFile(t, tmpDir, "config/d.tmp", "d v2")
+	writeFile(t, tmpDir, "config/e", "e v2")
+	err := os.Remove(filepath.Join(tmpDir, "b"))
+	require.NoError(t, err, "remove b")
+
+	version, count, err := c.UpdateWithOptions(tc.Context(), 1, tmpDir, client.WriteOptions{
+		Include: []string{"config/**"},
+		Exclude: []string{"**.tmp"},
+	})
+	require.NoError(t, err, "client.Update")
+	assert.Equal(t, int64(2), version)
+	assert.Equal(t, uint32(2), count)
+
+	objects, err := c.Get(tc.Context(), 1, "", nil, emptyVersionRange)
+	require.NoError(t, err, "client.GetLatest after filtered update")
+
+	verifyObjects(t, objects, map[string]string{
+		"a":            "a v1",
+		"b":            "b v1",
+		"config/c":     "c v2",
+		"config/d.tmp": "d v1",
+		"config/e":     "e v2",
+	})
+
+	// The changes left out by the filters are sent by the next update
+	update(tc, c, 1, tmpDir, expectedResponse{
+		version: 3,
+		count:   3,
+	})
+
+	objects, err = c.Get(tc.Context(), 1, "", nil, emptyVersionRange)
+	require.NoError(t, err, "client.GetLatest after update")
+
+	verifyObjects(t, objects, map[string]string{
+		"a":            "a v2",
+		"config/c":     "c v2",
+		"config/d.tmp": "d v2",
+		"config/e":     "e v2",
+	})
+}
+
+func TestUpdateWithAnnotation(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeProject(tc, 2, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+
+	c, fs, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := writeTmpFiles(t, 1, map[string]string{
+		"a": "a v1",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	message, author := "synced from editor", "jane"
+
+	writeFile(t, tmpDir, "a", "a v2")
+	version, _, err := c.UpdateWithOptions(tc.Context(), 1, tmpDir, client.WriteOptions{Message: &message, Author: &author})
+	require.NoError(t, err, "client.Update")
+	assert.Equal(t, int64(2), version)
+
+	writeFile(t, tmpDir, "b", "b v3")
+	version, _, err = c.Update(tc.Context(), 1, tmpDir)
+	require.NoError(t, err, "client.Update")
+	assert.Equal(t, int64(3), version)
+
+	history, err := c.History(tc.Context(), 1, emptyVersionRange)
+	require.NoError(t, err, "client.History")
+	require.Len(t, history, 1, "unannotated versions have no history entry")
+	assert.Equal(t, int64(2), history[0].Version)
+	assert.Equal(t, message, history[0].GetMessage())
+	assert.Equal(t, author, history[0].GetAuthor())
+
+	cloneMessage := "deploy pipeline"
+	cloned, err := c.CloneToProjectWithOptions(tc.Context(), 1, 2, 3, client.WriteOptions{Message: &cloneMessage})
+	require.NoError(t, err, "client.CloneToProject")
+
+	history, err = c.History(tc.Context(), 2, emptyVersionRange)
+	require.NoError(t, err, "client.History")
+	require.Len(t, history, 1)
+	assert.Equal(t, *cloned, history[0].Version)
+	assert.Equal(t, cloneMessage, history[0].GetMessage())
+	assert.Nil(t, history[0].Author)
+
+	_, err = fs.Rollback(tc.Context(), &pb.RollbackRequest{Project: 1, Version: 1})
+	require.NoError(t, err, "fs.Rollback")
+
+	writeFile(t, tmpDir, "c", "c v2")
+	version, _, err = c.Update(tc.Context(), 1, tmpDir)
+	require.NoError(t, err, "client.Update")
+	assert.Equal(t, int64(2), version)
+
+	history, err = c.History(tc.Context(), 1, emptyVersionRange)
+	require.NoError(t, err, "client.History")
+	assert.Empty(t, history, "rolled back versions lose their annotations")
+}
+
+func TestUpdateRetryWithIdempotencyKey(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := writeTmpFiles(t, 1, map[string]string{
+		"a": "a v1",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	idempotencyKey := "update-1"
+
+	writeFile(t, tmpDir, "a", "a v2")
+	version, _, err := c.UpdateWithOptions(tc.Context(), 1, tmpDir, client.WriteOptions{IdempotencyKey: &idempotencyKey})
+	require.NoError(t, err, "client.Update")
+	assert.Equal(t, int64(2), version)
+
+	// a retry of the same write is not applied again
+	writeFile(t, tmpDir, "b", "b v2")
+	version, _, err = c.UpdateWithOptions(tc.Context(), 1, tmpDir, client.WriteOptions{IdempotencyKey: &idempotencyKey})
+	require.NoError(t, err, "client.Update retried with the same idempotency key")
+	assert.Equal(t, int64(2), version)
+
+	objects, err := c.Get(tc.Context(), 1, "", nil, emptyVersionRange)
+	require.NoError(t, err, "client.Get")
+	verifyObjects(t, objects, map[string]string{
+		"a": "a v2",
+	})
+
+	otherKey := "update-2"
+	writeFile(t, tmpDir, "c", "c v3")
+	version, _, err = c.UpdateWithOptions(tc.Context(), 1, tmpDir, client.WriteOptions{IdempotencyKey: &otherKey})
+	require.NoError(t, err, "client.Update")
+	assert.Equal(t, int64(3), version)
+
+	empty := ""
+	writeFile(t, tmpDir, "d", "d v4")
+	_, _, err = c.UpdateWithOptions(tc.Context(), 1, tmpDir, client.WriteOptions{IdempotencyKey: &empty})
+	assert.Error(t, err, "empty idempotency keys are rejected")
+}
+
+func TestUpdateSendsUnchangedLargeFilesByHash(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	big := strings.Repeat("big content ", 10_000)
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+	writeObject(tc, 1, 1, nil, "big", big)
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := writeTmpFiles(t, 1, map[string]string{
+		"a":   "a v1",
+		"big": big,
+	})
+	defer os.RemoveAll(tmpDir)
+
+	// rewriting the same content marks the file as changed locally
+	writeFile(t, tmpDir, "big", big)
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(filepath.Join(tmpDir, "big"), future, future))
+	writeFile(t, tmpDir, "a", "a v2")
+
+	var progress client.Progress
+	ctx := client.WithProgress(tc.Context(), func(p client.Progress) { progress = p })
+
+	version, count, err := c.Update(ctx, 1, tmpDir)
+	require.NoError(t, err, "client.Update")
+	assert.Equal(t, int64(2), version)
+	assert.Equal(t, uint32(2), count)
+
+	assert.Equal(t, int64(2), progress.Objects)
+	assert.Equal(t, int64(len("a v2")), progress.Bytes, "the unchanged large file is sent by hash alone")
+
+	objects, err := c.Get(tc.Context(), 1, "", nil, emptyVersionRange)
+	require.NoError(t, err, "client.Get")
+	verifyObjects(t, objects, map[string]string{
+		"a":   "a v2",
+		"big": big,
+	})
+}
+
+func TestUpdateShedUnderDbPressure(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+
+	c, fs, close := createTestClient(tc)
+	defer close()
+
+	fs.UpdateAdmission = &api.AdmissionController{MaxDbLatency: 100 * time.Millisecond, RetryAfter: 3 * time.Second}
+	fs.UpdateAdmission.ObserveDbLatency(time.Second)
+
+	tmpDir := writeTmpFiles(t, 1, map[string]string{
+		"a": "a v1",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, "a", "a v2")
+	_, _, err := c.Update(tc.Context(), 1, tmpDir)
+	require.Error(t, err, "client.Update while the database is slow")
+
+	retryAfter, ok := client.RetryAfter(err)
+	require.True(t, ok, "shed updates carry a retry hint")
+	assert.Equal(t, 3*time.Second, retryAfter)
+	assert.Equal(t, int64(0), fs.UpdateAdmission.InFlight())
+
+	for range 10 {
+		fs.UpdateAdmission.ObserveDbLatency(0)
+	}
+
+	version, _, err := c.Update(tc.Context(), 1, tmpDir)
+	require.NoError(t, err, "client.Update once the database recovered")
+	assert.Equal(t, int64(2), version)
+	assert.Equal(t, int64(0), fs.UpdateAdmission.InFlight())
+}
+
+func TestUpdateRetriesShedStream(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+
+	c, fs, close := createTestClient(tc)
+	defer close()
+
+	fs.UpdateAdmission = &api.AdmissionController{MaxDbLatency: 100 * time.Millisecond, RetryAfter: 200 * time.Millisecond}
+	fs.UpdateAdmission.ObserveDbLatency(time.Second)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		for range 10 {
+			fs.UpdateAdmission.ObserveDbLatency(0)
+		}
+	}()
+
+	tmpDir := writeTmpFiles(t, 1, map[string]string{
+		"a": "a v1",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, "a", "a v2")
+	version, _, err := c.UpdateWithOptions(tc.Context(), 1, tmpDir, client.WriteOptions{Retries: 3})
+	require.NoError(t, err, "client.Update retried after being shed")
+	assert.Equal(t, int64(2), version)
+}
+
+func TestUpdateInBatches(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := writeTmpFiles(t, 1, map[string]string{
+		"a": "a v1",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, "a", "a v2")
+	writeFile(t, tmpDir, "b", "b v2")
+	writeFile(t, tmpDir, "c", "c v2")
+	writeFile(t, tmpDir, "d", "d v2")
+	writeFile(t, tmpDir, "e", "e v2")
+
+	version, count, err := c.UpdateWithOptions(tc.Context(), 1, tmpDir, client.WriteOptions{BatchSize: 2, Retries: 1})
+	require.NoError(t, err, "client.Update")
+	assert.Equal(t, int64(4), version, "every batch is committed as its own version")
+	assert.Equal(t, uint32(5), count)
+
+	fileVersion, err := client.ReadVersionFile(tmpDir)
+	require.NoError(t, err, "client.ReadVersionFile")
+	assert.Equal(t, int64(4), fileVersion)
+
+	objects, err := c.Get(tc.Context(), 1, "", nil, emptyVersionRange)
+	require.NoError(t, err, "client.Get")
+	verifyObjects(t, objects, map[string]string{
+		"a": "a v2",
+		"b": "b v2",
+		"c": "c v2",
+		"d": "d v2",
+		"e": "e v2",
+	})
+}
+
+func TestUpdateWithSparseProfile(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "api/a", "api a v1")
+	writeObject(tc, 1, 1, nil, "web/b", "web b v1")
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	tmpDir := writeTmpFiles(t, 1, map[string]string{
+		"api/a":      "api a v1",
+		".dl/sparse": "api/**",
+	})
+	defer os.RemoveAll(tmpDir)
+
+	writeFile(t, tmpDir, "api/a", "api a v2")
+	writeFile(t, tmpDir, "web/c", "web c v2")
+
+	update(tc, c, 1, tmpDir, expectedResponse{
+		version: 2,
+		count:   1,
+	})
+
+	objects, err := c.Get(tc.Context(), 1, "", nil, emptyVersionRange)
+	require.NoError(t, err, "client.GetLatest after update")
+
+	verifyObjects(t, objects, map[string]string{
+		"api/a": "api a v2",
+		"web/b": "web b v1",
+	})
+}
+
 func TestUpdatePackedObjectsConsistentHashing(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Project, 1)
 	defer tc.Close()
@@ -0,0 +1,177 @@
+package test
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/auth"
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	util "github.com/gadget-inc/dateilager/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func packFixture(t testing.TB, count int) []byte {
+	writer := db.NewTarWriter()
+	defer writer.Close()
+
+	for idx := 0; idx < count; idx++ {
+		content := []byte(fmt.Sprintf("export const value%d = %d;\n", idx, idx))
+		object := db.NewUncachedTarObject(fmt.Sprintf("src/file%d.ts", idx), 0644, int64(len(content)), false, content)
+		require.NoError(t, writer.WriteObject(&object), "write content to TAR")
+	}
+
+	pack, err := writer.BytesAndReset()
+	require.NoError(t, err, "write content TAR to bytes")
+	return append([]byte(nil), pack...)
+}
+
+func readPack(t testing.TB, pack []byte) {
+	reader := db.NewTarReader()
+	defer reader.Close()
+	reader.FromBytes(pack)
+
+	for {
+		_, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err, "next TAR header")
+
+		_, err = reader.ReadContent()
+		require.NoError(t, err, "read TAR content")
+	}
+}
+
+func writePack(t testing.TB, count int) {
+	writer := db.NewTarWriter()
+	defer writer.Close()
+
+	content := []byte("export const value = 1;\n")
+	for idx := 0; idx < count; idx++ {
+		object := db.NewUncachedTarObject("src/file.ts", 0644, int64(len(content)), false, content)
+		require.NoError(t, writer.WriteObject(&object), "write content to TAR")
+	}
+
+	_, err := writer.BytesAndReset()
+	require.NoError(t, err, "write content TAR to bytes")
+}
+
+func encodeContent(t testing.TB, content []byte) {
+	encoder := db.NewContentEncoder()
+	defer encoder.Close()
+
+	_, err := encoder.Encode(content)
+	require.NoError(t, err, "encode content")
+}
+
+// bytesPerRun is the average number of bytes allocated by a call to fn, like testing.AllocsPerRun counts allocations.
+func bytesPerRun(runs int, fn func()) uint64 {
+	fn()
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	for run := 0; run < runs; run++ {
+		fn()
+	}
+	runtime.ReadMemStats(&after)
+
+	return (after.TotalAlloc - before.TotalAlloc) / uint64(runs)
+}
+
+// TestContentAllocations guards the pooling of s2 readers and writers, each of them allocates over a megabyte of
+// buffers when it is not reused.
+func TestContentAllocations(t *testing.T) {
+	pack := packFixture(t, 200)
+	content := []byte("export const value = 1;\n")
+
+	assert.Less(t, bytesPerRun(100, func() { readPack(t, pack) }), uint64(256*db.KB), "bytes allocated reading a pack")
+	assert.Less(t, bytesPerRun(100, func() { writePack(t, 200) }), uint64(128*db.KB), "bytes allocated writing a pack")
+	assert.Less(t, bytesPerRun(100, func() { encodeContent(t, content) }), uint64(16*db.KB), "bytes allocated encoding content")
+}
+
+func BenchmarkTarReader(b *testing.B) {
+	pack := packFixture(b, 200)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(pack)))
+
+	for n := 0; n < b.N; n++ {
+		readPack(b, pack)
+	}
+}
+
+func BenchmarkTarWriter(b *testing.B) {
+	b.ReportAllocs()
+
+	for n := 0; n < b.N; n++ {
+		writePack(b, 200)
+	}
+}
+
+func BenchmarkContentEncoder(b *testing.B) {
+	content := []byte("export const value = 1;\n")
+	b.ReportAllocs()
+
+	for n := 0; n < b.N; n++ {
+		encodeContent(b, content)
+	}
+}
+
+func BenchmarkContentDecoder(b *testing.B) {
+	encoder := db.NewContentEncoder()
+	defer encoder.Close()
+
+	encoded, err := encoder.Encode([]byte("export const value = 1;\n"))
+	require.NoError(b, err, "encode content")
+
+	decoder := db.NewContentDecoder()
+	b.ReportAllocs()
+
+	for n := 0; n < b.N; n++ {
+		_, err := decoder.Decode(encoded)
+		require.NoError(b, err, "decode content")
+	}
+}
+
+// BenchmarkInlineContentsRead measures cold reads of a project of small files with their contents stored in
+// dl.contents and then inline in their rows.
+func BenchmarkInlineContentsRead(b *testing.B) {
+	tc := util.NewTestCtx(b, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	for idx := 0; idx < 500; idx++ {
+		writeObject(tc, 1, 1, nil, fmt.Sprintf("/src/file%d.ts", idx), fmt.Sprintf("export const value%d = %d;\n", idx, idx))
+	}
+
+	fs := tc.FsApi()
+	fs.InlineContentThreshold = 4 * db.KB
+
+	readProject := func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			// a fresh lookup reads every content from the database rather than from its cache
+			b.StopTimer()
+			lookup, err := db.NewContentLookup()
+			require.NoError(b, err, "create content lookup")
+			fs.ContentLookup = lookup
+			b.StartTimer()
+
+			stream := &mockGetServer{ctx: tc.Context()}
+			err = fs.Get(prefixQuery(1, nil, ""), stream)
+			require.NoError(b, err, "fs.Get")
+			require.Len(b, stream.results, 500)
+		}
+	}
+
+	b.Run("stored", readProject)
+
+	response, err := fs.InlineContents(tc.Context(), &pb.InlineContentsRequest{Project: 1})
+	require.NoError(b, err, "fs.InlineContents")
+	require.Equal(b, int64(500), response.Inlined)
+
+	b.Run("inline", readProject)
+}
@@ -1,10 +1,12 @@
 package test
 
 import (
+	"context"
 	"encoding/hex"
 	"io"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/gadget-inc/dateilager/internal/pb"
 	"github.com/jackc/pgx/v5"
@@ -14,7 +16,12 @@ import (
 	"github.com/gadget-inc/dateilager/internal/auth"
 	"github.com/gadget-inc/dateilager/internal/db"
 	util "github.com/gadget-inc/dateilager/internal/testutil"
+	"github.com/gadget-inc/dateilager/migrations"
+	"github.com/gadget-inc/dateilager/pkg/archive"
+	"github.com/gadget-inc/dateilager/pkg/server"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func latestCacheVersionHashes(tc util.TestCtx) (int64, []db.Hash) {
@@ -112,6 +119,95 @@ func TestCreateCacheOnlyUsesPacksWithThePrefix(t *testing.T) {
 	assert.Equal(t, 2, len(hashes), "cache hash count")
 }
 
+func TestCreateCacheFromMultiplePrefixesAndProjects(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2, "pack/")
+	writePackedFiles(tc, 1, 1, nil, "pack/a")
+	writePackedFiles(tc, 1, 1, nil, "vendor/b")
+
+	writeProject(tc, 2, 2, "pack/")
+	writePackedFiles(tc, 2, 1, nil, "pack/c")
+
+	writeProject(tc, 3, 2, "pack/")
+	writePackedFiles(tc, 3, 1, nil, "pack/d")
+	writePackedFiles(tc, 3, 1, nil, "private/e")
+
+	cacheVersion, err := db.CreateCacheFromSources(tc.Context(), tc.Connect(), db.CacheSources{
+		Prefixes: []string{"pack/", "vendor/"},
+	}, 100)
+	require.NoError(t, err)
+
+	version, hashes := latestCacheVersionHashes(tc)
+	assert.Equal(t, cacheVersion, version, "latest cache version matches newly created cache")
+	assert.Equal(t, 4, len(hashes), "cache hash count with every prefix")
+
+	_, err = db.CreateCacheFromSources(tc.Context(), tc.Connect(), db.CacheSources{
+		Prefixes:        []string{"pack/", "vendor/"},
+		IncludeProjects: []int64{1, 3},
+	}, 100)
+	require.NoError(t, err)
+
+	_, hashes = latestCacheVersionHashes(tc)
+	assert.Equal(t, 3, len(hashes), "cache hash count with included projects")
+
+	_, err = db.CreateCacheFromSources(tc.Context(), tc.Connect(), db.CacheSources{
+		IncludeProjects: []int64{1, 3},
+		ExcludeProjects: []int64{3},
+	}, 100)
+	require.NoError(t, err)
+
+	_, hashes = latestCacheVersionHashes(tc)
+	assert.Equal(t, 2, len(hashes), "cache hash count with excluded projects")
+}
+
+func TestCreateCacheSkipsOptedOutProjects(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2, "pack/")
+	writePackedFiles(tc, 1, 1, nil, "pack/a")
+
+	writeProject(tc, 2, 2, "pack/")
+	writePackedFiles(tc, 2, 1, nil, "pack/b")
+
+	writeProject(tc, 3, 2, "pack/")
+	writePackedFiles(tc, 3, 1, nil, "pack/a")
+
+	err := db.SetProjectCacheInclusion(tc.Context(), tc.Connect(), 2, false)
+	require.NoError(t, err)
+
+	err = db.SetProjectCacheInclusion(tc.Context(), tc.Connect(), 4, false)
+	assert.ErrorIs(t, err, db.ErrNotFound)
+
+	cacheVersion, err := db.CreateCache(tc.Context(), tc.Connect(), "pack/", 100)
+	require.NoError(t, err)
+
+	_, hashes := latestCacheVersionHashes(tc)
+	assert.Equal(t, 1, len(hashes), "opted out project packs are not cached")
+
+	projects, err := db.CacheVersionProjects(tc.Context(), tc.Connect(), cacheVersion)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 3}, projects, "contributing projects are recorded")
+
+	err = db.SetProjectCacheInclusion(tc.Context(), tc.Connect(), 2, true)
+	require.NoError(t, err)
+
+	cacheVersion, err = db.CreateCache(tc.Context(), tc.Connect(), "pack/", 100)
+	require.NoError(t, err)
+
+	_, hashes = latestCacheVersionHashes(tc)
+	assert.Equal(t, 2, len(hashes), "opted in project packs are cached again")
+
+	projects, err = db.CacheVersionProjects(tc.Context(), tc.Connect(), cacheVersion)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, projects)
+
+	_, err = db.CacheVersionProjects(tc.Context(), tc.Connect(), cacheVersion+1)
+	assert.ErrorIs(t, err, db.ErrNotFound)
+}
+
 func TestCreateCacheIgnoresModulesNoLongerUsed(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Admin)
 	defer tc.Close()
@@ -128,6 +224,308 @@ func TestCreateCacheIgnoresModulesNoLongerUsed(t *testing.T) {
 	assert.Equal(t, 1, len(hashes), "cache hash count")
 }
 
+func TestRunScheduledCache(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2, "pack/")
+	writePackedFiles(tc, 1, 1, nil, "pack/a")
+	writePackedFiles(tc, 1, 1, nil, "pack/b")
+
+	config := server.CacheScheduleConfig{
+		Sources: db.CacheSources{Prefixes: []string{"pack/"}},
+		Count:   100,
+		Keep:    2,
+	}
+
+	scheduledAt := time.Now().Add(-time.Hour)
+
+	result, err := server.RunScheduledCache(tc.Context(), tc.Connector(), config, scheduledAt)
+	require.NoError(t, err, "RunScheduledCache")
+	assert.True(t, result.Created)
+	assert.Equal(t, int64(0), result.Pruned)
+
+	result, err = server.RunScheduledCache(tc.Context(), tc.Connector(), config, scheduledAt)
+	require.NoError(t, err, "RunScheduledCache for the same run")
+	assert.False(t, result.Created, "a run only creates one cache version")
+
+	for idx := 1; idx <= 2; idx++ {
+		result, err = server.RunScheduledCache(tc.Context(), tc.Connector(), config, time.Now().Add(time.Duration(idx)*time.Hour))
+		require.NoError(t, err, "RunScheduledCache")
+		assert.True(t, result.Created)
+	}
+	assert.Equal(t, int64(1), result.Pruned, "only the keep latest versions are kept")
+	assert.Equal(t, int64(0), result.Reclaimed, "packs still referenced by objects are not reclaimed")
+
+	versions, err := db.ListCacheVersions(tc.Context(), tc.Connect())
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	assert.Equal(t, result.Version, versions[1].Version)
+	assert.Equal(t, int64(2), versions[1].ObjectsCount)
+}
+
+func TestRunScheduledArchivesAndRestore(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2, "/pack/")
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writeObject(tc, 1, 2, nil, "/b", "b v2")
+	writePackedObjects(tc, 1, 1, nil, "/pack/", map[string]expectedObject{
+		"/pack/c": {content: "pack/c v1"},
+	})
+	writeProject(tc, 2, 1)
+	writeObject(tc, 2, 1, nil, "/unlabeled", "unlabeled")
+
+	_, err := db.SetProjectLabels(tc.Context(), tc.Connect(), 1, map[string]string{"tier": "prod"}, nil)
+	require.NoError(t, err, "SetProjectLabels")
+
+	store := archive.NewDir(t.TempDir())
+	config := server.ArchiveScheduleConfig{
+		Store:     store,
+		Selector:  map[string]string{"tier": "prod"},
+		Retention: time.Hour,
+	}
+
+	scheduledAt := time.Now().Add(-time.Minute)
+
+	result, err := server.RunScheduledArchives(tc.Context(), tc.Connector(), tc.ContentLookup(), config, scheduledAt)
+	require.NoError(t, err, "RunScheduledArchives")
+	assert.Equal(t, int64(1), result.Archived, "only labeled projects are archived")
+
+	result, err = server.RunScheduledArchives(tc.Context(), tc.Connector(), tc.ContentLookup(), config, scheduledAt)
+	require.NoError(t, err, "RunScheduledArchives for the same run")
+	assert.Equal(t, int64(0), result.Archived, "a run archives each project once")
+
+	fs := tc.FsApi()
+	fs.ArchiveStore = store
+
+	archives, err := fs.ListArchives(tc.Context(), &pb.ListArchivesRequest{Project: i(1)})
+	require.NoError(t, err, "fs.ListArchives")
+	require.Len(t, archives.Archives, 1)
+	assert.Equal(t, int64(2), archives.Archives[0].Version)
+	assert.Equal(t, int64(3), archives.Archives[0].Objects)
+	require.NotNil(t, archives.Archives[0].ExpiresAt)
+
+	restored, err := fs.RestoreArchive(tc.Context(), &pb.RestoreArchiveRequest{Archive: archives.Archives[0].Id, Project: i(5)})
+	require.NoError(t, err, "fs.RestoreArchive")
+	assert.Equal(t, int64(5), restored.Project)
+	assert.Equal(t, int64(3), restored.Objects)
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(5, nil, ""), stream)
+	require.NoError(t, err, "fs.Get of the restored project")
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/a":      {content: "a v1"},
+		"/b":      {content: "b v2"},
+		"/pack/c": {content: "pack/c v1"},
+	})
+
+	_, err = fs.RestoreArchive(tc.Context(), &pb.RestoreArchiveRequest{Archive: archives.Archives[0].Id})
+	require.Equal(t, codes.AlreadyExists, status.Code(err), "restoring over an existing project")
+
+	_, err = tc.Connect().Exec(tc.Context(), "UPDATE dl.archives SET expires_at = now() - interval '1 minute'")
+	require.NoError(t, err)
+
+	config.Selector = map[string]string{"tier": "none"}
+	result, err = server.RunScheduledArchives(tc.Context(), tc.Connector(), tc.ContentLookup(), config, time.Now())
+	require.NoError(t, err, "RunScheduledArchives after the retention")
+	assert.Equal(t, int64(1), result.Expired)
+
+	_, err = fs.RestoreArchive(tc.Context(), &pb.RestoreArchiveRequest{Archive: archives.Archives[0].Id, Project: i(6)})
+	require.Equal(t, codes.NotFound, status.Code(err), "expired archives are deleted")
+}
+
+func TestReconcileAndCheckContentRefs(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	writeObject(tc, 1, 1, i(2), "/a", "a v1")
+	writeObject(tc, 1, 2, nil, "/a", "shared")
+	writeObject(tc, 1, 1, nil, "/b", "shared")
+
+	shared := db.HashContent([]byte("shared"))
+	refs := func() (*int64, int64) {
+		var refs *int64
+		var pending int64
+		err := tc.Connect().QueryRow(tc.Context(), `
+			SELECT c.refs, coalesce((SELECT sum(delta) FROM dl.content_ref_deltas d WHERE d.hash = c.hash), 0)::bigint
+			FROM dl.contents c
+			WHERE c.hash = ($1, $2)
+		`, shared.H1, shared.H2).Scan(&refs, &pending)
+		require.NoError(t, err, "select content refs")
+		return refs, pending
+	}
+
+	folded, pending := refs()
+	require.NotNil(t, folded)
+	assert.Equal(t, int64(0), *folded, "new contents start without folded references")
+	assert.Equal(t, int64(2), pending, "references recorded by the triggers")
+
+	fs := tc.FsApi()
+
+	check, err := fs.CheckContentRefs(tc.Context(), &pb.CheckContentRefsRequest{Sample: 100})
+	require.NoError(t, err, "fs.CheckContentRefs")
+	assert.Equal(t, int64(2), check.Checked)
+	assert.Empty(t, check.Mismatches, "pending references are consistent")
+
+	config := server.ContentRefsConfig{BatchSize: 100}
+
+	result, err := server.RunContentRefsReconcile(tc.Context(), tc.Connector(), config)
+	require.NoError(t, err, "RunContentRefsReconcile")
+	assert.True(t, result.Locked)
+	assert.Equal(t, int64(2), result.Folded, "both contents had deltas folded")
+
+	folded, pending = refs()
+	require.NotNil(t, folded)
+	assert.Equal(t, int64(2), *folded)
+	assert.Equal(t, int64(0), pending)
+
+	_, err = tc.Connect().Exec(tc.Context(), `UPDATE dl.contents SET refs = NULL WHERE hash = ($1, $2)`, shared.H1, shared.H2)
+	require.NoError(t, err, "forget content refs")
+
+	check, err = fs.CheckContentRefs(tc.Context(), &pb.CheckContentRefsRequest{Sample: 100})
+	require.NoError(t, err, "fs.CheckContentRefs uncounted")
+	assert.Equal(t, int64(1), check.Uncounted)
+
+	result, err = server.RunContentRefsReconcile(tc.Context(), tc.Connector(), config)
+	require.NoError(t, err, "RunContentRefsReconcile uncounted")
+	assert.Equal(t, int64(1), result.Counted)
+
+	folded, _ = refs()
+	require.NotNil(t, folded)
+	assert.Equal(t, int64(2), *folded, "references counted from the objects")
+
+	_, err = tc.Connect().Exec(tc.Context(), `UPDATE dl.contents SET refs = 5 WHERE hash = ($1, $2)`, shared.H1, shared.H2)
+	require.NoError(t, err, "corrupt content refs")
+
+	check, err = fs.CheckContentRefs(tc.Context(), &pb.CheckContentRefsRequest{Sample: 100, Repair: true})
+	require.NoError(t, err, "fs.CheckContentRefs repair")
+	require.Len(t, check.Mismatches, 1)
+	assert.Equal(t, shared.Bytes(), check.Mismatches[0].Hash)
+	assert.Equal(t, int64(5), check.Mismatches[0].Refs)
+	assert.Equal(t, int64(2), check.Mismatches[0].Actual)
+	assert.Equal(t, int64(1), check.Repaired)
+
+	check, err = fs.CheckContentRefs(tc.Context(), &pb.CheckContentRefsRequest{Sample: 100})
+	require.NoError(t, err, "fs.CheckContentRefs after repair")
+	assert.Empty(t, check.Mismatches)
+
+	// the folded references are what gc trusts, /a v1 is orphaned while the shared content is kept
+	response, err := fs.GcProject(tc.Context(), &pb.GcProjectRequest{Project: 1, KeepVersions: 0})
+	require.NoError(t, err, "fs.GcProject")
+	assert.Equal(t, int64(1), response.Count)
+	assert.Equal(t, 1, countContents(tc))
+
+	projectCtx := context.WithValue(tc.Context(), auth.AuthCtxKey, auth.Auth{Role: auth.Project, Project: i(1)})
+	_, err = fs.CheckContentRefs(projectCtx, &pb.CheckContentRefsRequest{Sample: 100})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func auditAll(t *testing.T, tc util.TestCtx, repair, quarantine bool) map[string][]*pb.AuditFinding {
+	fs := tc.FsApi()
+	findings := make(map[string][]*pb.AuditFinding)
+
+	var after []byte
+	for {
+		response, err := fs.AuditContents(tc.Context(), &pb.AuditContentsRequest{After: after, Limit: 2, Repair: repair, Quarantine: quarantine})
+		require.NoError(t, err, "fs.AuditContents")
+
+		for _, finding := range response.Findings {
+			findings[finding.Kind] = append(findings[finding.Kind], finding)
+		}
+
+		if len(response.Next) == 0 {
+			return findings
+		}
+		after = response.Next
+	}
+}
+
+func TestAuditContents(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writeObject(tc, 1, 1, nil, "/b", "b v1")
+	writeObject(tc, 1, 1, nil, "/c", "c v1")
+	writeObject(tc, 1, 1, nil, "/d", "d v1")
+
+	conn := tc.Connect()
+
+	encoder := db.NewContentEncoder()
+	defer encoder.Close()
+
+	orphan := db.HashContent([]byte("orphan"))
+	encoded, err := encoder.Encode([]byte("orphan"))
+	require.NoError(t, err, "encode orphan")
+	_, err = conn.Exec(tc.Context(), `INSERT INTO dl.contents (hash, bytes) VALUES (($1, $2), $3)`, orphan.H1, orphan.H2, encoded)
+	require.NoError(t, err, "insert orphaned content")
+
+	missing := db.HashContent([]byte("missing"))
+	_, err = conn.Exec(tc.Context(), `
+		INSERT INTO dl.objects (project, start_version, stop_version, path, hash, mode, size, packed)
+		VALUES (1, 1, NULL, '/missing', ($1, $2), 0755, 7, false)
+	`, missing.H1, missing.H2)
+	require.NoError(t, err, "insert object without content")
+
+	_, err = conn.Exec(tc.Context(), `UPDATE dl.objects SET size = 99 WHERE path = '/b'`)
+	require.NoError(t, err, "corrupt object size")
+
+	tampered := db.HashContent([]byte("c v1"))
+	encoded, err = encoder.Encode([]byte("tampered"))
+	require.NoError(t, err, "encode tampered content")
+	_, err = conn.Exec(tc.Context(), `UPDATE dl.contents SET bytes = $1 WHERE hash = ($2, $3)`, encoded, tampered.H1, tampered.H2)
+	require.NoError(t, err, "corrupt content bytes")
+
+	recounted := db.HashContent([]byte("d v1"))
+	_, err = conn.Exec(tc.Context(), `UPDATE dl.contents SET refs = 7 WHERE hash = ($1, $2)`, recounted.H1, recounted.H2)
+	require.NoError(t, err, "corrupt content refs")
+
+	findings := auditAll(t, tc, false, false)
+	require.Len(t, findings[db.AuditOrphanedContent], 1)
+	assert.Equal(t, orphan.Bytes(), findings[db.AuditOrphanedContent][0].Hash)
+	require.Len(t, findings[db.AuditMissingContent], 1)
+	assert.Equal(t, "/missing", findings[db.AuditMissingContent][0].GetPath())
+	require.Len(t, findings[db.AuditSizeMismatch], 1)
+	assert.Equal(t, "/b", findings[db.AuditSizeMismatch][0].GetPath())
+	assert.Equal(t, int64(len("b v1")), findings[db.AuditSizeMismatch][0].Expected)
+	assert.Equal(t, int64(99), findings[db.AuditSizeMismatch][0].Actual)
+	require.Len(t, findings[db.AuditHashMismatch], 1)
+	assert.Equal(t, tampered.Bytes(), findings[db.AuditHashMismatch][0].Hash)
+	require.Len(t, findings[db.AuditRefsMismatch], 1)
+	assert.Equal(t, recounted.Bytes(), findings[db.AuditRefsMismatch][0].Hash)
+	assert.Equal(t, int64(1), findings[db.AuditRefsMismatch][0].Expected)
+	for _, kind := range findings {
+		for _, finding := range kind {
+			assert.Empty(t, finding.Action, "a report only audit changes nothing")
+		}
+	}
+
+	findings = auditAll(t, tc, true, true)
+	assert.Equal(t, db.AuditQuarantined, findings[db.AuditOrphanedContent][0].Action)
+	assert.Equal(t, db.AuditQuarantined, findings[db.AuditHashMismatch][0].Action)
+	assert.Equal(t, db.AuditResized, findings[db.AuditSizeMismatch][0].Action)
+	assert.Equal(t, db.AuditRecounted, findings[db.AuditRefsMismatch][0].Action)
+	assert.Empty(t, findings[db.AuditMissingContent][0].Action, "no inline copy to restore from")
+
+	var quarantined int
+	err = conn.QueryRow(tc.Context(), "SELECT count(*) FROM dl.quarantined_contents").Scan(&quarantined)
+	require.NoError(t, err, "count quarantined contents")
+	assert.Equal(t, 2, quarantined)
+
+	// the quarantined content of /c is now missing, everything else was repaired
+	findings = auditAll(t, tc, false, false)
+	assert.Len(t, findings, 1)
+	var paths []string
+	for _, finding := range findings[db.AuditMissingContent] {
+		paths = append(paths, finding.GetPath())
+	}
+	assert.ElementsMatch(t, []string{"/c", "/missing"}, paths)
+}
+
 func TestGetCacheWithMultipleVersions(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Admin)
 	defer tc.Close()
@@ -157,7 +555,7 @@ func TestGetCacheWithMultipleVersions(t *testing.T) {
 		Path:     "pack",
 		IsPrefix: true,
 	}
-	tars, err := db.GetTars(tc.Context(), tc.Connect(), tc.ContentLookup(), 1, availableVersions, vrange, query)
+	tars, err := db.GetTars(tc.Context(), tc.Connect(), tc.ContentLookup(), 1, availableVersions, nil, nil, vrange, query, 0, nil, nil, nil, nil)
 	require.NoError(t, err)
 
 	var paths []string
@@ -193,3 +591,104 @@ func TestGetCacheWithMultipleVersions(t *testing.T) {
 	}
 	assert.Equal(t, []string{"pack/a", "pack/b"}, paths)
 }
+
+func TestGetTarsWithKnownHashes(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "known content")
+	writeObject(tc, 1, 1, nil, "b", "unknown content")
+
+	vrange, err := db.NewVersionRange(tc.Context(), tc.Connect(), 1, i(0), i(1))
+	require.NoError(t, err)
+
+	knownHash := db.HashContent([]byte("known content"))
+	knownHashes := [][]byte{knownHash.Bytes(), []byte("invalid")}
+
+	query := &pb.ObjectQuery{
+		Path:     "",
+		IsPrefix: true,
+	}
+	tars, err := db.GetTars(tc.Context(), tc.Connect(), tc.ContentLookup(), 1, nil, knownHashes, nil, vrange, query, 0, nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	contents := make(map[string][]byte)
+
+	for {
+		tar, _, err := tars()
+		if err == io.EOF {
+			break
+		}
+		if err == db.SKIP {
+			continue
+		}
+		require.NoError(t, err)
+
+		tarReader := db.NewTarReader()
+		tarReader.FromBytes(tar)
+
+		for {
+			header, err := tarReader.Next()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+
+			content, err := tarReader.ReadContent()
+			require.NoError(t, err)
+
+			if header.Name == "a" {
+				assert.Equal(t, pb.TarKnown, int32(header.Typeflag))
+			}
+
+			contents[header.Name] = content
+		}
+	}
+
+	assert.Equal(t, knownHash.Bytes(), contents["a"], "known objects are sent as their hash")
+	assert.Equal(t, []byte("unknown content"), contents["b"])
+}
+
+func TestLoadEmbeddedMigrations(t *testing.T) {
+	all, err := db.LoadMigrations(migrations.FS)
+	require.NoError(t, err, "db.LoadMigrations")
+	require.NotEmpty(t, all)
+
+	for idx, migration := range all {
+		assert.Equal(t, int64(idx+1), migration.Version, "migrations are numbered without gaps")
+		assert.NotEmpty(t, migration.Up, "migration %v has an up file", migration.Version)
+		assert.NotEmpty(t, migration.Down, "migration %v has a down file", migration.Version)
+	}
+}
+
+// Tables holding per project rows must be covered by a dl_tenant policy, otherwise tenant scoped tokens see every tenant's rows
+func TestTenantPoliciesCoverProjectTables(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	rows, err := tc.Connect().Query(tc.Context(), `
+		SELECT c.table_name
+		FROM information_schema.columns c
+		JOIN pg_class r ON r.relname = c.table_name AND r.relnamespace = 'dl'::regnamespace
+		WHERE c.table_schema = 'dl'
+		  AND c.column_name = 'project'
+		  AND r.relkind = 'r'
+		  AND NOT (
+			r.relrowsecurity
+			AND EXISTS (
+				SELECT 1
+				FROM pg_policies p
+				WHERE p.schemaname = 'dl'
+				  AND p.tablename = c.table_name
+				  AND 'dl_tenant' = ANY(p.roles)
+			)
+		  )
+		ORDER BY c.table_name
+	`)
+	require.NoError(t, err, "select project tables")
+
+	uncovered, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	require.NoError(t, err, "collect project tables")
+	assert.Empty(t, uncovered, "tables with a project column and no dl_tenant row level security policy")
+}
@@ -0,0 +1,47 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/auth"
+	util "github.com/gadget-inc/dateilager/internal/testutil"
+	"github.com/gadget-inc/dateilager/pkg/devtools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevtoolsGenerateThroughDbAndApiMatch(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	c, _, close := createTestClient(tc)
+	defer close()
+
+	shape := devtools.Shape{Files: 60, MinSize: 1, MaxSize: 2048, Depth: 3, PackRatio: 0.3, Versions: 4, Churn: 0.2, Seed: 5}
+
+	dbResult, err := devtools.GenerateWithDb(tc.Context(), tc.Connector(), 1, shape)
+	require.NoError(t, err, "GenerateWithDb")
+
+	apiResult, err := devtools.GenerateWithClient(tc.Context(), c, 2, shape)
+	require.NoError(t, err, "GenerateWithClient")
+
+	assert.Equal(t, int64(4), dbResult.LatestVersion)
+	assert.Equal(t, dbResult.LatestVersion, apiResult.LatestVersion)
+	assert.Equal(t, dbResult.Objects, apiResult.Objects)
+	assert.Equal(t, dbResult.Bytes, apiResult.Bytes)
+
+	contents := func(project int64) map[string]string {
+		objects, err := c.Get(tc.Context(), project, "", nil, emptyVersionRange)
+		require.NoError(t, err, "client.Get")
+
+		contents := make(map[string]string)
+		for _, object := range objects {
+			contents[object.Path] = string(object.Content)
+		}
+		return contents
+	}
+
+	dbContents := contents(1)
+	assert.Len(t, dbContents, dbResult.Live)
+	assert.Equal(t, dbContents, contents(2))
+}
@@ -0,0 +1,96 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/auth"
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	util "github.com/gadget-inc/dateilager/internal/testutil"
+	"github.com/gadget-inc/dateilager/pkg/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodingCounts(response *pb.ContentEncodingStatusResponse) map[string]int64 {
+	counts := make(map[string]int64)
+	for _, stats := range response.Encodings {
+		counts[stats.Encoding] = stats.Contents
+	}
+	return counts
+}
+
+func TestContentEncodingDualRead(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+
+	fs := tc.FsApi()
+	fs.ContentEncoding = db.EncodingZstd
+
+	err := fs.Update(newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"/b": {content: "b v2"},
+	}))
+	require.NoError(t, err, "fs.Update")
+
+	status, err := fs.ContentEncodingStatus(tc.Context(), &pb.ContentEncodingStatusRequest{})
+	require.NoError(t, err, "fs.ContentEncodingStatus")
+	assert.Equal(t, "zstd", status.WriteEncoding)
+	assert.Equal(t, map[string]int64{"s2": 1, "zstd": 1}, encodingCounts(status))
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get")
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/a": {content: "a v1"},
+		"/b": {content: "b v2"},
+	})
+}
+
+func TestContentReencode(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1, "/p/")
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writeObject(tc, 1, 1, nil, "/b", "b v1")
+	writeObject(tc, 1, 1, nil, "/c", "c v1")
+	writePackedFiles(tc, 1, 1, nil, "/p/")
+
+	fs := tc.FsApi()
+	config := server.ContentReencodeConfig{Encoding: db.EncodingZstd, BatchSize: 2}
+	decoder := db.NewContentDecoder()
+
+	progress, err := server.RunContentReencode(tc.Context(), tc.Connector(), decoder, config)
+	require.NoError(t, err, "RunContentReencode")
+	assert.False(t, progress.Done(), "a full batch should not finish the reencode")
+	assert.Equal(t, int64(2), progress.Scanned)
+
+	for !progress.Done() {
+		progress, err = server.RunContentReencode(tc.Context(), tc.Connector(), decoder, config)
+		require.NoError(t, err, "RunContentReencode")
+	}
+
+	assert.Equal(t, int64(4), progress.Scanned, "every content should be walked once")
+	assert.Equal(t, int64(3), progress.Reencoded, "the pack should be left as is")
+
+	status, err := fs.ContentEncodingStatus(tc.Context(), &pb.ContentEncodingStatusRequest{})
+	require.NoError(t, err, "fs.ContentEncodingStatus")
+	assert.Equal(t, map[string]int64{"s2": 1, "zstd": 3}, encodingCounts(status))
+	require.Len(t, status.Reencodes, 1)
+	assert.Equal(t, "zstd", status.Reencodes[0].Encoding)
+	assert.NotNil(t, status.Reencodes[0].FinishedAt)
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get")
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/a":   {content: "a v1"},
+		"/b":   {content: "b v1"},
+		"/c":   {content: "c v1"},
+		"/p/1": {content: "/p/1 v1"},
+		"/p/2": {content: "/p/2 v1"},
+	})
+}
@@ -1,15 +1,23 @@
 package test
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gadget-inc/dateilager/internal/db"
 
 	"github.com/gadget-inc/dateilager/internal/auth"
 	"github.com/gadget-inc/dateilager/internal/pb"
+	"github.com/gadget-inc/dateilager/internal/policy"
 	util "github.com/gadget-inc/dateilager/internal/testutil"
+	"github.com/gadget-inc/dateilager/pkg/client"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestNewProject(t *testing.T) {
@@ -59,6 +67,97 @@ func TestNewProjectWithTemplate(t *testing.T) {
 	assert.Equal(t, 2, countObjectsByProject(tc, 2))
 }
 
+func TestTenantScopedAdmin(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	fs := tc.FsApi()
+
+	_, err := fs.NewProject(tc.Context(), &pb.NewProjectRequest{Id: 1, Tenant: s("a")})
+	require.NoError(t, err, "fs.NewProject")
+	_, err = fs.NewProject(tc.Context(), &pb.NewProjectRequest{Id: 2, Tenant: s("b")})
+	require.NoError(t, err, "fs.NewProject")
+
+	tenantCtx := context.WithValue(tc.Context(), auth.AuthCtxKey, auth.Auth{Role: auth.Admin, Tenant: s("a")})
+
+	_, err = fs.NewProject(tenantCtx, &pb.NewProjectRequest{Id: 3})
+	require.NoError(t, err, "fs.NewProject in tenant")
+
+	_, err = fs.NewProject(tenantCtx, &pb.NewProjectRequest{Id: 4, Tenant: s("b")})
+	require.Equal(t, codes.PermissionDenied, status.Code(err), "creating a project for another tenant")
+
+	listResponse, err := fs.ListProjects(tenantCtx, &pb.ListProjectsRequest{})
+	require.NoError(t, err, "fs.ListProjects")
+
+	var ids []int64
+	for _, project := range listResponse.Projects {
+		ids = append(ids, project.Id)
+		assert.Equal(t, "a", project.GetTenant())
+	}
+	assert.ElementsMatch(t, []int64{1, 3}, ids)
+
+	_, err = fs.GetProjectLabels(tenantCtx, &pb.GetProjectLabelsRequest{Project: 2})
+	require.Equal(t, codes.NotFound, status.Code(err), "reading a project of another tenant")
+
+	_, err = fs.GcContents(tenantCtx, &pb.GcContentsRequest{Sample: 100})
+	require.Equal(t, codes.PermissionDenied, status.Code(err), "global endpoint with a tenant scoped token")
+
+	listResponse, err = fs.ListProjects(tc.Context(), &pb.ListProjectsRequest{})
+	require.NoError(t, err, "fs.ListProjects")
+	assert.Len(t, listResponse.Projects, 3)
+}
+
+func TestProjectLabels(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeProject(tc, 2, 1)
+	writeProject(tc, 3, 1)
+
+	fs := tc.FsApi()
+
+	_, err := fs.SetProjectLabels(tc.Context(), &pb.SetProjectLabelsRequest{Project: 1, Labels: map[string]string{"framework": "remix", "region": "us"}})
+	require.NoError(t, err, "fs.SetProjectLabels")
+
+	_, err = fs.SetProjectLabels(tc.Context(), &pb.SetProjectLabelsRequest{Project: 2, Labels: map[string]string{"framework": "remix", "region": "eu"}})
+	require.NoError(t, err, "fs.SetProjectLabels")
+
+	response, err := fs.SetProjectLabels(tc.Context(), &pb.SetProjectLabelsRequest{Project: 2, Labels: map[string]string{"tenant": "42"}, Remove: []string{"region"}})
+	require.NoError(t, err, "fs.SetProjectLabels")
+	assert.Equal(t, map[string]string{"framework": "remix", "tenant": "42"}, response.Labels)
+
+	labels, err := fs.GetProjectLabels(tc.Context(), &pb.GetProjectLabelsRequest{Project: 1})
+	require.NoError(t, err, "fs.GetProjectLabels")
+	assert.Equal(t, map[string]string{"framework": "remix", "region": "us"}, labels.Labels)
+
+	listed, err := fs.ListProjects(tc.Context(), &pb.ListProjectsRequest{LabelSelector: map[string]string{"framework": "remix"}})
+	require.NoError(t, err, "fs.ListProjects")
+	assert.ElementsMatch(t, []int64{1, 2}, projectIds(listed.Projects))
+
+	listed, err = fs.ListProjects(tc.Context(), &pb.ListProjectsRequest{LabelSelector: map[string]string{"framework": "remix", "region": "us"}})
+	require.NoError(t, err, "fs.ListProjects")
+	assert.ElementsMatch(t, []int64{1}, projectIds(listed.Projects))
+
+	listed, err = fs.ListProjects(tc.Context(), &pb.ListProjectsRequest{})
+	require.NoError(t, err, "fs.ListProjects")
+	assert.ElementsMatch(t, []int64{1, 2, 3}, projectIds(listed.Projects))
+
+	_, err = fs.GetProjectLabels(tc.Context(), &pb.GetProjectLabelsRequest{Project: 4})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = fs.SetProjectLabels(tc.Context(), &pb.SetProjectLabelsRequest{Project: 1, Labels: map[string]string{"": "x"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func projectIds(projects []*pb.Project) []int64 {
+	var ids []int64
+	for _, project := range projects {
+		ids = append(ids, project.Id)
+	}
+	return ids
+}
+
 func TestGetEmpty(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Project, 1)
 	defer tc.Close()
@@ -115,6 +214,57 @@ func TestGetPrefix(t *testing.T) {
 	})
 }
 
+// slowGetServer is a client reading the Get stream slowly, it records how many cursors are open on every Send
+type slowGetServer struct {
+	mockGetServer
+	tc          util.TestCtx
+	openCursors []int
+}
+
+func (m *slowGetServer) Send(resp *pb.GetResponse) error {
+	time.Sleep(time.Millisecond)
+
+	var count int
+	err := m.tc.Connect().QueryRow(m.tc.Context(), "SELECT count(*) FROM pg_cursors WHERE name LIKE 'dl_objects_%'").Scan(&count)
+	if err != nil {
+		return err
+	}
+	m.openCursors = append(m.openCursors, count)
+
+	return m.mockGetServer.Send(resp)
+}
+
+func TestGetSlowClient(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+
+	expected := make(map[string]expectedObject)
+	for idx := range 1000 {
+		path := fmt.Sprintf("/a/%04d", idx)
+		writeObject(tc, 1, 1, nil, path, path)
+		expected[path] = expectedObject{content: path}
+	}
+
+	fs := tc.FsApi()
+	stream := &slowGetServer{mockGetServer: mockGetServer{ctx: tc.Context()}, tc: tc}
+
+	err := fs.Get(prefixQuery(1, nil, "/a"), stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, expected)
+
+	for idx, count := range stream.openCursors {
+		assert.Equal(t, 1, count, "objects are read through a single cursor while object %d is sent", idx)
+	}
+
+	var remaining int
+	err = tc.Connect().QueryRow(tc.Context(), "SELECT count(*) FROM pg_cursors WHERE name LIKE 'dl_objects_%'").Scan(&remaining)
+	require.NoError(t, err)
+	assert.Equal(t, 0, remaining, "the cursor is closed once the stream is done")
+}
+
 func TestGetWithIgnorePattern(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Project, 1)
 	defer tc.Close()
@@ -218,6 +368,92 @@ func TestGetRange(t *testing.T) {
 	}
 }
 
+func TestGetResumeCursor(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 3, "/a/")
+	writePackedObjects(tc, 1, 1, nil, "/a/", map[string]expectedObject{
+		"/a/c": {content: "a/c v1"},
+		"/a/d": {content: "a/d v1"},
+		"/a/e": {content: "a/e v1"},
+	})
+	writeObject(tc, 1, 1, i(3), "/b", "b v1")
+	writeObject(tc, 1, 1, i(3), "/c", "c v1")
+	writeObject(tc, 1, 2, nil, "/d", "d v2")
+	writeObject(tc, 1, 3, nil, "/e", "e v3")
+
+	fs := tc.FsApi()
+
+	testCases := []struct {
+		name string
+		req  func() *pb.GetRequest
+	}{
+		{
+			name: "full read with a pack",
+			req:  func() *pb.GetRequest { return prefixQuery(1, nil, "") },
+		},
+		{
+			name: "range with removals",
+			req:  func() *pb.GetRequest { return rangeQuery(1, i(1), i(3), "") },
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			full := &mockGetServer{ctx: tc.Context()}
+			err := fs.Get(testCase.req(), full)
+			require.NoError(t, err, "fs.Get")
+			require.Len(t, full.cursors, len(full.results))
+
+			for idx, cursor := range full.cursors {
+				req := testCase.req()
+				req.ResumeCursor = &cursor
+
+				resumed := &mockGetServer{ctx: tc.Context()}
+				err := fs.Get(req, resumed)
+				require.NoError(t, err, "fs.Get resumed after %v", full.results[idx].Path)
+
+				var expected, actual []string
+				for _, object := range full.results[idx+1:] {
+					expected = append(expected, object.Path)
+				}
+				for _, object := range resumed.results {
+					actual = append(actual, object.Path)
+				}
+				assert.Equal(t, expected, actual, "objects resumed after %v", full.results[idx].Path)
+			}
+		})
+	}
+}
+
+func TestGetResumeCursorMismatch(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writeObject(tc, 1, 2, nil, "/b", "b v2")
+
+	fs := tc.FsApi()
+
+	full := &mockGetServer{ctx: tc.Context()}
+	err := fs.Get(prefixQuery(1, i(1), ""), full)
+	require.NoError(t, err, "fs.Get")
+	require.Len(t, full.cursors, 1)
+
+	req := prefixQuery(1, i(2), "")
+	req.ResumeCursor = &full.cursors[0]
+	err = fs.Get(req, &mockGetServer{ctx: tc.Context()})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "resume at another version")
+
+	malformed := "not a cursor"
+	req = prefixQuery(1, nil, "")
+	req.ResumeCursor = &malformed
+	err = fs.Get(req, &mockGetServer{ctx: tc.Context()})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "malformed cursor")
+}
+
 func TestGetDeleteAll(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Project, 1)
 	defer tc.Close()
@@ -361,6 +597,27 @@ func TestGetCompress(t *testing.T) {
 	})
 }
 
+func TestGetCompressSendsHashes(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1, "pack/")
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writePackedFiles(tc, 1, 1, nil, "pack/")
+
+	fs := tc.FsApi()
+
+	stream := &mockGetCompressServer{ctx: tc.Context()}
+	err := fs.GetCompress(buildCompressRequest(1, nil, nil, ""), stream)
+	require.NoError(t, err, "fs.GetCompress")
+
+	require.Len(t, stream.hashes, len(stream.results))
+	for idx, tar := range stream.results {
+		hash := db.HashContent(tar)
+		assert.Equal(t, hash.Bytes(), stream.hashes[idx], "hash of TAR %d", idx)
+	}
+}
+
 func TestGetCompressWithIgnorePattern(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Project, 1)
 	defer tc.Close()
@@ -432,6 +689,55 @@ func TestGetObjectWithinPack(t *testing.T) {
 	})
 }
 
+func TestGetCompressExactPathsWithinPack(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1, "a/")
+	writePackedObjects(tc, 1, 1, nil, "a/", map[string]expectedObject{
+		"a/b": {content: "a/b v1"},
+		"a/c": {content: "a/c v1"},
+		"a/d": {content: "a/d v1"},
+	})
+	writeObject(tc, 1, 1, nil, "e", "e v1")
+
+	fs := tc.FsApi()
+
+	request := &pb.GetCompressRequest{
+		Project: 1,
+		Queries: []*pb.ObjectQuery{{Paths: []string{"a/b", "a/d", "e"}}},
+	}
+
+	stream := &mockGetCompressServer{ctx: tc.Context()}
+	err := fs.GetCompress(request, stream)
+	require.NoError(t, err, "fs.GetCompress")
+
+	for _, packPath := range stream.packPaths {
+		assert.Nil(t, packPath, "only the requested entries should be sent, not the whole pack")
+	}
+
+	verifyTarResults(t, stream.results, map[string]expectedObject{
+		"a/b": {content: "a/b v1"},
+		"a/d": {content: "a/d v1"},
+		"e":   {content: "e v1"},
+	})
+
+	request.Queries = []*pb.ObjectQuery{{Paths: []string{"a/", "a/b"}}}
+
+	stream = &mockGetCompressServer{ctx: tc.Context()}
+	err = fs.GetCompress(request, stream)
+	require.NoError(t, err, "fs.GetCompress of the whole pack")
+
+	require.Len(t, stream.packPaths, 1)
+	assert.Equal(t, "a/", *stream.packPaths[0])
+
+	verifyTarResults(t, stream.results, map[string]expectedObject{
+		"a/b": {content: "a/b v1"},
+		"a/c": {content: "a/c v1"},
+		"a/d": {content: "a/d v1"},
+	})
+}
+
 func TestGetObjectWithinPatternPack(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Project, 1)
 	defer tc.Close()
@@ -517,170 +823,654 @@ func TestGetCompressWithCacheVersions(t *testing.T) {
 	})
 }
 
-func TestUpdate(t *testing.T) {
+func TestGetCompressWithKnownHashes(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Project, 1)
 	defer tc.Close()
 
 	writeProject(tc, 1, 1)
-	writeObject(tc, 1, 1, nil, "/a", "v1")
-	writeObject(tc, 1, 1, nil, "/b", "v1")
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writeObject(tc, 1, 1, nil, "/b", "b v1")
 
 	fs := tc.FsApi()
 
-	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
-		"/a": {content: "v2"},
-	})
-	err := fs.Update(updateStream)
-	require.NoError(t, err, "fs.Update")
+	known := db.HashContent([]byte("a v1"))
 
-	assert.Equal(t, int64(2), updateStream.response.Version, "expected version 2")
+	stream := &mockGetCompressServer{ctx: tc.Context()}
+	request := buildCompressRequest(1, nil, nil, "")
+	request.KnownHashes = [][]byte{known.Bytes()}
 
-	stream := &mockGetServer{ctx: tc.Context()}
-	err = fs.Get(prefixQuery(1, nil, "/"), stream)
-	require.NoError(t, err, "fs.Get")
+	err := fs.GetCompress(request, stream)
+	require.NoError(t, err, "fs.GetCompress")
 
-	verifyStreamResults(t, stream.results, map[string]expectedObject{
-		"/a": {content: "v2"},
-		"/b": {content: "v1"},
+	verifyTarResults(t, stream.results, map[string]expectedObject{
+		"/a": {content: string(known.Bytes())},
+		"/b": {content: "b v1"},
 	})
 }
 
-func TestEmptyUpdate(t *testing.T) {
+func TestGetCompressWithInvalidKnownHash(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Project, 1)
 	defer tc.Close()
 
 	writeProject(tc, 1, 1)
-	writeObject(tc, 1, 1, nil, "/a", "v1")
-	writeObject(tc, 1, 1, nil, "/b", "v1")
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
 
 	fs := tc.FsApi()
 
-	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{})
-	err := fs.Update(updateStream)
-	require.NoError(t, err, "fs.Update")
-
-	assert.Equal(t, int64(-1), updateStream.response.Version, "expected version -1")
-
-	stream := &mockGetServer{ctx: tc.Context()}
-	err = fs.Get(prefixQuery(1, nil, "/"), stream)
-	require.NoError(t, err, "fs.Get")
+	stream := &mockGetCompressServer{ctx: tc.Context()}
+	request := buildCompressRequest(1, nil, nil, "")
+	request.KnownHashes = [][]byte{[]byte("too short")}
 
-	verifyStreamResults(t, stream.results, map[string]expectedObject{
-		"/a": {content: "v1"},
-		"/b": {content: "v1"},
-	})
+	err := fs.GetCompress(request, stream)
+	require.Error(t, err, "fs.GetCompress with an invalid known hash")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
 }
 
-func TestIdenticalUpdate(t *testing.T) {
+func TestGetCompressFromCheckoutArtifact(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Project, 1)
 	defer tc.Close()
 
-	writeProject(tc, 1, 1)
-	writeObject(tc, 1, 1, nil, "/a", "v1")
+	writeProject(tc, 1, 1, "/pack/")
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writePackedObjects(tc, 1, 1, nil, "/pack/", map[string]expectedObject{
+		"/pack/b": {content: "pack/b v1"},
+	})
+
+	count, err := db.BuildCheckoutArtifact(tc.Context(), tc.Connect(), tc.ContentLookup(), 1, 1)
+	require.NoError(t, err, "db.BuildCheckoutArtifact")
+	assert.Equal(t, 2, count, "expected one loose and one packed TAR")
+
+	// Written behind the artifact's back to tell which requests are served from it
+	writeObject(tc, 1, 1, nil, "/c", "c v1")
 
 	fs := tc.FsApi()
 
-	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
-		"/a": {content: "v1"},
-	})
-	err := fs.Update(updateStream)
-	require.NoError(t, err, "fs.Update")
+	stream := &mockGetCompressServer{ctx: tc.Context()}
+	err = fs.GetCompress(buildCompressRequest(1, nil, nil, ""), stream)
+	require.NoError(t, err, "fs.GetCompress")
 
-	assert.Equal(t, int64(1), updateStream.response.Version, "expected version 1")
+	verifyTarResults(t, stream.results, map[string]expectedObject{
+		"/a":      {content: "a v1"},
+		"/pack/b": {content: "pack/b v1"},
+	})
 
-	stream := &mockGetServer{ctx: tc.Context()}
-	err = fs.Get(prefixQuery(1, nil, "/"), stream)
-	require.NoError(t, err, "fs.Get")
+	stream = &mockGetCompressServer{ctx: tc.Context()}
+	err = fs.GetCompress(buildCompressRequest(1, nil, nil, "/c"), stream)
+	require.NoError(t, err, "fs.GetCompress")
 
-	verifyStreamResults(t, stream.results, map[string]expectedObject{
-		"/a": {content: "v1"},
+	verifyTarResults(t, stream.results, map[string]expectedObject{
+		"/c": {content: "c v1"},
 	})
 }
 
-func TestUpdatePackedObject(t *testing.T) {
+func TestGetUnaryIfNoneMatch(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Project, 1)
 	defer tc.Close()
 
-	writeProject(tc, 1, 1, "/a/")
-	writePackedObjects(tc, 1, 1, nil, "/a/", map[string]expectedObject{
-		"/a/c": {content: "a/c v1"},
-		"/a/d": {content: "a/d v1"},
-	})
-	writeObject(tc, 1, 1, nil, "/b", "b v1")
+	writeProject(tc, 1, 2)
+	writeObject(tc, 1, 1, nil, "/config/a", "a v1")
+	writeObject(tc, 1, 1, i(2), "/config/b", "b v1")
+	writeObject(tc, 1, 2, nil, "/config/b", "b v2")
+	writeObject(tc, 1, 1, nil, "/other", "other v1")
 
 	fs := tc.FsApi()
 
-	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
-		"/a/c": {content: "a/c v2"},
-	})
-	err := fs.Update(updateStream)
-	require.NoError(t, err, "fs.Update")
-
-	assert.Equal(t, int64(2), updateStream.response.Version, "expected version 2")
+	queries := []*pb.ObjectQuery{
+		{Path: "/config/a", IsPrefix: false},
+		{Path: "/config/b", IsPrefix: false},
+	}
 
-	stream := &mockGetServer{ctx: tc.Context()}
-	err = fs.Get(exactQuery(1, nil, "/a/c"), stream)
-	require.NoError(t, err, "fs.Get")
+	first, err := fs.GetUnary(tc.Context(), &pb.GetUnaryRequest{Project: 1, ToVersion: i(1), Queries: queries})
+	require.NoError(t, err, "fs.GetUnary")
+	require.Len(t, first.Etags, 2)
+	assert.False(t, first.NotModified)
+	verifyStreamResults(t, first.Objects, map[string]expectedObject{
+		"/config/a": {content: "a v1"},
+		"/config/b": {content: "b v1"},
+	})
 
-	verifyStreamResults(t, stream.results, map[string]expectedObject{
-		"/a/c": {content: "a/c v2"},
+	unchanged, err := fs.GetUnary(tc.Context(), &pb.GetUnaryRequest{Project: 1, ToVersion: i(1), Queries: queries, IfNoneMatch: first.Etags})
+	require.NoError(t, err, "fs.GetUnary")
+	assert.True(t, unchanged.NotModified)
+	assert.Empty(t, unchanged.Objects)
+	assert.Equal(t, first.Etags, unchanged.Etags)
+
+	partial, err := fs.GetUnary(tc.Context(), &pb.GetUnaryRequest{Project: 1, Queries: queries, IfNoneMatch: first.Etags})
+	require.NoError(t, err, "fs.GetUnary")
+	assert.False(t, partial.NotModified)
+	assert.Equal(t, first.Etags[0], partial.Etags[0], "unchanged query keeps its etag across versions")
+	assert.NotEqual(t, first.Etags[1], partial.Etags[1])
+	verifyStreamResults(t, partial.Objects, map[string]expectedObject{
+		"/config/b": {content: "b v2"},
 	})
 }
 
-func TestEmptyUpdatePackedObject(t *testing.T) {
+func TestGetUnaryEtagCoversMetadata(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Project, 1)
 	defer tc.Close()
 
-	writeProject(tc, 1, 1, "/a/")
-	writePackedObjects(tc, 1, 1, nil, "/a/", map[string]expectedObject{
-		"/a/c": {content: "a/c v1"},
-		"/a/d": {content: "a/d v1"},
-	})
-	writeObject(tc, 1, 1, nil, "/b", "b v1")
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
 
 	fs := tc.FsApi()
+	queries := []*pb.ObjectQuery{{Path: "/a", IsPrefix: false}}
 
-	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{})
-	err := fs.Update(updateStream)
-	require.NoError(t, err, "fs.Update")
+	first, err := fs.GetUnary(tc.Context(), &pb.GetUnaryRequest{Project: 1, Queries: queries})
+	require.NoError(t, err, "fs.GetUnary")
 
-	assert.Equal(t, int64(-1), updateStream.response.Version, "expected version -1")
+	_, err = tc.Connect().Exec(tc.Context(), "UPDATE dl.objects SET mtime = 1 WHERE project = 1 AND path = '/a'")
+	require.NoError(t, err, "set mtime")
 
-	stream := &mockGetServer{ctx: tc.Context()}
-	err = fs.Get(exactQuery(1, nil, "/a/c"), stream)
-	require.NoError(t, err, "fs.Get")
+	withMtime, err := fs.GetUnary(tc.Context(), &pb.GetUnaryRequest{Project: 1, Queries: queries, IfNoneMatch: first.Etags})
+	require.NoError(t, err, "fs.GetUnary")
+	assert.False(t, withMtime.NotModified, "an mtime change modifies the response")
+	assert.NotEqual(t, first.Etags, withMtime.Etags)
 
-	verifyStreamResults(t, stream.results, map[string]expectedObject{
-		"/a/c": {content: "a/c v1"},
-	})
+	_, err = tc.Connect().Exec(tc.Context(), "UPDATE dl.objects SET content_type = 'text/plain' WHERE project = 1 AND path = '/a'")
+	require.NoError(t, err, "set content type")
+
+	withContentType, err := fs.GetUnary(tc.Context(), &pb.GetUnaryRequest{Project: 1, Queries: queries, IfNoneMatch: withMtime.Etags})
+	require.NoError(t, err, "fs.GetUnary")
+	assert.False(t, withContentType.NotModified, "a content type change modifies the response")
+	assert.NotEqual(t, withMtime.Etags, withContentType.Etags)
 }
 
-func TestIdenticalUpdatePackedObject(t *testing.T) {
+func TestStatPaths(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Project, 1)
 	defer tc.Close()
 
-	writeProject(tc, 1, 1, "/a/")
-	writePackedObjects(tc, 1, 1, nil, "/a/", map[string]expectedObject{
-		"/a/b": {content: "a/b v1"},
-		"/a/c": {content: "a/c v1"},
+	writeProject(tc, 1, 2, "/pack/")
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writeObject(tc, 1, 1, i(2), "/b", "b v1")
+	writePackedObjects(tc, 1, 1, nil, "/pack/", map[string]expectedObject{
+		"/pack/c": {content: "pack/c v1"},
 	})
 
 	fs := tc.FsApi()
 
-	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
-		"/a/b": {content: "a/b v1"},
-		"/a/c": {content: "a/c v1"},
+	response, err := fs.StatPaths(tc.Context(), &pb.StatPathsRequest{
+		Project: 1,
+		Paths:   []string{"/b", "/pack/c", "/a", "/missing", "/pack/missing"},
 	})
-	err := fs.Update(updateStream)
-	require.NoError(t, err, "fs.Update")
+	require.NoError(t, err, "fs.StatPaths")
+	assert.Equal(t, int64(2), response.Version)
+	require.Len(t, response.Stats, 5)
 
-	assert.Equal(t, int64(1), updateStream.response.Version, "expected version 1")
+	aHash := db.HashContent([]byte("a v1"))
+	cHash := db.HashContent([]byte("pack/c v1"))
 
-	stream := &mockGetServer{ctx: tc.Context()}
-	err = fs.Get(prefixQuery(1, nil, "/a"), stream)
-	require.NoError(t, err, "fs.Get")
+	assert.Equal(t, "/b", response.Stats[0].Path)
+	assert.False(t, response.Stats[0].Exists, "deleted paths do not exist")
 
-	verifyStreamResults(t, stream.results, map[string]expectedObject{
+	assert.Equal(t, "/pack/c", response.Stats[1].Path)
+	assert.True(t, response.Stats[1].Exists)
+	assert.Equal(t, int64(len("pack/c v1")), response.Stats[1].Size)
+	assert.Equal(t, cHash.Bytes(), response.Stats[1].Hash)
+
+	assert.Equal(t, "/a", response.Stats[2].Path)
+	assert.True(t, response.Stats[2].Exists)
+	assert.Equal(t, int64(len("a v1")), response.Stats[2].Size)
+	assert.Equal(t, int64(0755), response.Stats[2].Mode)
+	assert.Equal(t, aHash.Bytes(), response.Stats[2].Hash)
+
+	assert.False(t, response.Stats[3].Exists)
+	assert.False(t, response.Stats[4].Exists)
+
+	response, err = fs.StatPaths(tc.Context(), &pb.StatPathsRequest{Project: 1, Version: i(1), Paths: []string{"/b"}})
+	require.NoError(t, err, "fs.StatPaths")
+	assert.True(t, response.Stats[0].Exists, "/b exists at version 1")
+}
+
+func TestListPaths(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1, "/pack/")
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writeObject(tc, 1, 1, nil, "/pack-sibling", "sibling v1")
+	writePackedObjects(tc, 1, 1, nil, "/pack/", map[string]expectedObject{
+		"/pack/c":     {content: "pack/c v1"},
+		"/pack/sub/d": {content: "pack/sub/d v1"},
+	})
+
+	fs := tc.FsApi()
+
+	paths := func(stats []*pb.PathStat) []string {
+		var result []string
+		for _, stat := range stats {
+			result = append(result, stat.Path)
+		}
+		return result
+	}
+
+	// the pack was written without entries, it is decoded
+	response, err := fs.ListPaths(tc.Context(), &pb.ListPathsRequest{Project: 1, Prefix: "/"})
+	require.NoError(t, err, "fs.ListPaths")
+	assert.Equal(t, int64(1), response.Version)
+	assert.Equal(t, []string{"/a", "/pack-sibling", "/pack/c", "/pack/sub/d"}, paths(response.Stats))
+	assert.False(t, response.Truncated)
+
+	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"/pack/e": {content: "pack/e v2"},
+	})
+	err = fs.Update(updateStream)
+	require.NoError(t, err, "fs.Update")
+
+	// the updated pack was written with its entries
+	response, err = fs.ListPaths(tc.Context(), &pb.ListPathsRequest{Project: 1, Prefix: "/pack/"})
+	require.NoError(t, err, "fs.ListPaths")
+	assert.Equal(t, int64(2), response.Version)
+	assert.Equal(t, []string{"/pack/c", "/pack/e", "/pack/sub/d"}, paths(response.Stats))
+
+	eHash := db.HashContent([]byte("pack/e v2"))
+	assert.Equal(t, int64(len("pack/e v2")), response.Stats[1].Size)
+	assert.Equal(t, eHash.Bytes(), response.Stats[1].Hash)
+
+	response, err = fs.ListPaths(tc.Context(), &pb.ListPathsRequest{Project: 1, Prefix: "/pack/sub/"})
+	require.NoError(t, err, "fs.ListPaths")
+	assert.Equal(t, []string{"/pack/sub/d"}, paths(response.Stats))
+
+	response, err = fs.ListPaths(tc.Context(), &pb.ListPathsRequest{Project: 1, Prefix: "/", Limit: i(2)})
+	require.NoError(t, err, "fs.ListPaths")
+	assert.Equal(t, []string{"/a", "/pack-sibling"}, paths(response.Stats))
+	assert.True(t, response.Truncated)
+
+	response, err = fs.ListPaths(tc.Context(), &pb.ListPathsRequest{Project: 1, Version: i(1), Prefix: "/pack/"})
+	require.NoError(t, err, "fs.ListPaths")
+	assert.Equal(t, []string{"/pack/c", "/pack/sub/d"}, paths(response.Stats))
+
+	stats, err := fs.StatPaths(tc.Context(), &pb.StatPathsRequest{Project: 1, Paths: []string{"/pack/e", "/pack/missing"}})
+	require.NoError(t, err, "fs.StatPaths")
+	assert.True(t, stats.Stats[0].Exists)
+	assert.Equal(t, eHash.Bytes(), stats.Stats[0].Hash)
+	assert.False(t, stats.Stats[1].Exists)
+}
+
+func TestDirectorySizes(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2, "/pack/")
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writeObject(tc, 1, 1, nil, "/src/b", "src/b v1")
+	writeObject(tc, 1, 1, nil, "/src/lib/c", "src/lib/c v1")
+	writeObject(tc, 1, 1, i(2), "/src/old", "src/old v1")
+	writeObject(tc, 1, 1, nil, "/empty/", "")
+	writePackedObjects(tc, 1, 1, nil, "/pack/", map[string]expectedObject{
+		"/pack/c":     {content: "pack/c v1"},
+		"/pack/sub/d": {content: "pack/sub/d v1"},
+	})
+
+	fs := tc.FsApi()
+	fs.DirectoryRollups, _ = db.NewDirectoryRollups()
+
+	sizes := func(response *pb.DirectorySizesResponse) map[string][2]int64 {
+		result := make(map[string][2]int64)
+		for _, directory := range response.Directories {
+			result[directory.Path] = [2]int64{directory.Files, directory.Bytes}
+		}
+		return result
+	}
+
+	response, err := fs.DirectorySizes(tc.Context(), &pb.DirectorySizesRequest{Project: 1})
+	require.NoError(t, err, "fs.DirectorySizes")
+	assert.Equal(t, int64(2), response.Version)
+	assert.Equal(t, map[string][2]int64{
+		"/a":      {1, int64(len("a v1"))},
+		"/empty/": {0, 0},
+		"/pack/":  {2, int64(len("pack/c v1") + len("pack/sub/d v1"))},
+		"/src/":   {2, int64(len("src/b v1") + len("src/lib/c v1"))},
+	}, sizes(response))
+	assert.Equal(t, int64(5), response.Files)
+
+	response, err = fs.DirectorySizes(tc.Context(), &pb.DirectorySizesRequest{Project: 1, Version: i(1), Prefix: "/src/"})
+	require.NoError(t, err, "fs.DirectorySizes")
+	assert.Equal(t, map[string][2]int64{
+		"/src/b":    {1, int64(len("src/b v1"))},
+		"/src/lib/": {1, int64(len("src/lib/c v1"))},
+		"/src/old":  {1, int64(len("src/old v1"))},
+	}, sizes(response))
+
+	response, err = fs.DirectorySizes(tc.Context(), &pb.DirectorySizesRequest{Project: 1, Prefix: "/pack/"})
+	require.NoError(t, err, "fs.DirectorySizes")
+	assert.Equal(t, map[string][2]int64{
+		"/pack/c":    {1, int64(len("pack/c v1"))},
+		"/pack/sub/": {1, int64(len("pack/sub/d v1"))},
+	}, sizes(response))
+}
+
+func TestIndexPacks(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1, "/pack/")
+	writePackedObjects(tc, 1, 1, nil, "/pack/", map[string]expectedObject{
+		"/pack/a": {content: "pack/a v1"},
+		"/pack/b": {content: "pack/b v1"},
+	})
+
+	fs := tc.FsApi()
+
+	response, err := fs.IndexPacks(tc.Context(), &pb.IndexPacksRequest{Project: 1, DryRun: true})
+	require.NoError(t, err, "fs.IndexPacks dry run")
+	assert.Equal(t, int64(1), response.Checked)
+	assert.Equal(t, int64(1), response.Indexed)
+
+	response, err = fs.IndexPacks(tc.Context(), &pb.IndexPacksRequest{Project: 1})
+	require.NoError(t, err, "fs.IndexPacks")
+	assert.Equal(t, int64(1), response.Indexed)
+
+	response, err = fs.IndexPacks(tc.Context(), &pb.IndexPacksRequest{Project: 1, DryRun: true})
+	require.NoError(t, err, "fs.IndexPacks after indexing")
+	assert.Equal(t, int64(0), response.Indexed)
+
+	var entries int
+	err = tc.Connect().QueryRow(tc.Context(), "SELECT count(*) FROM dl.pack_entries").Scan(&entries)
+	require.NoError(t, err, "count pack entries")
+	assert.Equal(t, 2, entries)
+
+	listed, err := fs.ListPaths(tc.Context(), &pb.ListPathsRequest{Project: 1, Prefix: "/pack/"})
+	require.NoError(t, err, "fs.ListPaths")
+	require.Len(t, listed.Stats, 2)
+	assert.Equal(t, "/pack/a", listed.Stats[0].Path)
+	assert.Equal(t, "/pack/b", listed.Stats[1].Path)
+
+	projectCtx := context.WithValue(tc.Context(), auth.AuthCtxKey, auth.Auth{Role: auth.Project, Project: i(1)})
+	_, err = fs.IndexPacks(projectCtx, &pb.IndexPacksRequest{Project: 1})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestInlineContents(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writeObject(tc, 1, 1, nil, "/large", "large content v1")
+
+	fs := tc.FsApi()
+	fs.InlineContentThreshold = 8
+
+	inlineCount := func(hash db.Hash) (int64, int64) {
+		var inline, stored int64
+		err := tc.Connect().QueryRow(tc.Context(), `
+			SELECT (SELECT count(*) FROM dl.objects WHERE hash = ($1, $2) AND inline_content IS NOT NULL),
+			       (SELECT count(*) FROM dl.contents WHERE hash = ($1, $2))
+		`, hash.H1, hash.H2).Scan(&inline, &stored)
+		require.NoError(t, err, "count inline contents")
+		return inline, stored
+	}
+
+	response, err := fs.InlineContents(tc.Context(), &pb.InlineContentsRequest{Project: 1, DryRun: true})
+	require.NoError(t, err, "fs.InlineContents dry run")
+	assert.Equal(t, int64(1), response.Inlined)
+	assert.Equal(t, int64(0), response.Outlined)
+
+	response, err = fs.InlineContents(tc.Context(), &pb.InlineContentsRequest{Project: 1})
+	require.NoError(t, err, "fs.InlineContents")
+	assert.Equal(t, int64(1), response.Inlined)
+
+	inline, stored := inlineCount(db.HashContent([]byte("a v1")))
+	assert.Equal(t, int64(1), inline, "small content stored in its row")
+	assert.Equal(t, int64(0), stored, "small content collected from dl.contents")
+
+	put, err := fs.PutObject(tc.Context(), &pb.PutObjectRequest{Project: 1, Object: &pb.Object{Path: "/b", Mode: 0755, Content: []byte("b v2")}})
+	require.NoError(t, err, "fs.PutObject")
+	assert.Equal(t, int64(2), put.Version)
+
+	inline, stored = inlineCount(db.HashContent([]byte("b v2")))
+	assert.Equal(t, int64(1), inline, "small update stored in its row")
+	assert.Equal(t, int64(0), stored, "small update never reaches dl.contents")
+
+	expected := map[string]expectedObject{
+		"/a":     {content: "a v1"},
+		"/b":     {content: "b v2"},
+		"/large": {content: "large content v1"},
+	}
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get")
+	verifyStreamResults(t, stream.results, expected)
+
+	fs.InlineContentThreshold = 0
+
+	response, err = fs.InlineContents(tc.Context(), &pb.InlineContentsRequest{Project: 1})
+	require.NoError(t, err, "fs.InlineContents outline")
+	assert.Equal(t, int64(0), response.Inlined)
+	assert.Equal(t, int64(2), response.Outlined)
+
+	inline, stored = inlineCount(db.HashContent([]byte("a v1")))
+	assert.Equal(t, int64(0), inline, "content moved out of its row")
+	assert.Equal(t, int64(1), stored, "content stored in dl.contents again")
+
+	stream = &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get after outlining")
+	verifyStreamResults(t, stream.results, expected)
+
+	projectCtx := context.WithValue(tc.Context(), auth.AuthCtxKey, auth.Auth{Role: auth.Project, Project: i(1)})
+	_, err = fs.InlineContents(projectCtx, &pb.InlineContentsRequest{Project: 1})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestUpdate(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "/a", "v1")
+	writeObject(tc, 1, 1, nil, "/b", "v1")
+
+	fs := tc.FsApi()
+
+	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"/a": {content: "v2"},
+	})
+	err := fs.Update(updateStream)
+	require.NoError(t, err, "fs.Update")
+
+	assert.Equal(t, int64(2), updateStream.response.Version, "expected version 2")
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, "/"), stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/a": {content: "v2"},
+		"/b": {content: "v1"},
+	})
+}
+
+func TestEmptyUpdate(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "/a", "v1")
+	writeObject(tc, 1, 1, nil, "/b", "v1")
+
+	fs := tc.FsApi()
+
+	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{})
+	err := fs.Update(updateStream)
+	require.NoError(t, err, "fs.Update")
+
+	assert.Equal(t, int64(-1), updateStream.response.Version, "expected version -1")
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, "/"), stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/a": {content: "v1"},
+		"/b": {content: "v1"},
+	})
+}
+
+func TestIdenticalUpdate(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "/a", "v1")
+
+	fs := tc.FsApi()
+
+	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"/a": {content: "v1"},
+	})
+	err := fs.Update(updateStream)
+	require.NoError(t, err, "fs.Update")
+
+	assert.Equal(t, int64(1), updateStream.response.Version, "expected version 1")
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, "/"), stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/a": {content: "v1"},
+	})
+}
+
+func TestUpdatePackedObject(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1, "/a/")
+	writePackedObjects(tc, 1, 1, nil, "/a/", map[string]expectedObject{
+		"/a/c": {content: "a/c v1"},
+		"/a/d": {content: "a/d v1"},
+	})
+	writeObject(tc, 1, 1, nil, "/b", "b v1")
+
+	fs := tc.FsApi()
+
+	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"/a/c": {content: "a/c v2"},
+	})
+	err := fs.Update(updateStream)
+	require.NoError(t, err, "fs.Update")
+
+	assert.Equal(t, int64(2), updateStream.response.Version, "expected version 2")
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(exactQuery(1, nil, "/a/c"), stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/a/c": {content: "a/c v2"},
+	})
+}
+
+func TestCheckUpdate(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1, "/p/")
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writeObject(tc, 1, 1, nil, "/b", "b v1")
+	writePackedObjects(tc, 1, 1, nil, "/p/", map[string]expectedObject{
+		"/p/a": {content: "p/a v1"},
+	})
+
+	fs := tc.FsApi()
+
+	hash := func(content string) []byte {
+		h := db.HashContent([]byte(content))
+		return h.Bytes()
+	}
+
+	response, err := fs.CheckUpdate(tc.Context(), &pb.CheckUpdateRequest{
+		Project: 1,
+		Objects: []*pb.ObjectHash{
+			{Path: "/a", Mode: 0755, Hash: hash("a v1")},
+			{Path: "/b", Mode: 0755, Hash: hash("b v2")},
+			{Path: "/a", Mode: 0644, Hash: hash("a v1")},
+			{Path: "/c", Mode: 0755, Hash: hash("c v1")},
+			{Path: "/p/a", Mode: 0755, Hash: hash("p/a v1")},
+		},
+	})
+	require.NoError(t, err, "fs.CheckUpdate")
+
+	assert.Equal(t, []bool{false, true, true, true, true}, response.ContentNeeded)
+
+	updateStream := &mockUpdateServer{
+		ctx:     tc.Context(),
+		project: 1,
+		updates: []*pb.Object{{Path: "/a", Mode: 0755, Size: 4}},
+		hashes:  map[string][]byte{"/a": hash("a v1")},
+	}
+	err = fs.Update(updateStream)
+	require.NoError(t, err, "fs.Update")
+
+	assert.Equal(t, int64(1), updateStream.response.Version, "an identical object sent by hash does not create a version")
+
+	updateStream = &mockUpdateServer{
+		ctx:     tc.Context(),
+		project: 1,
+		updates: []*pb.Object{{Path: "/b", Mode: 0755, Size: 4}},
+		hashes:  map[string][]byte{"/b": hash("b v2")},
+	}
+	err = fs.Update(updateStream)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "a changed object sent by hash must be resent with its content")
+}
+
+func TestEmptyUpdatePackedObject(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1, "/a/")
+	writePackedObjects(tc, 1, 1, nil, "/a/", map[string]expectedObject{
+		"/a/c": {content: "a/c v1"},
+		"/a/d": {content: "a/d v1"},
+	})
+	writeObject(tc, 1, 1, nil, "/b", "b v1")
+
+	fs := tc.FsApi()
+
+	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{})
+	err := fs.Update(updateStream)
+	require.NoError(t, err, "fs.Update")
+
+	assert.Equal(t, int64(-1), updateStream.response.Version, "expected version -1")
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(exactQuery(1, nil, "/a/c"), stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/a/c": {content: "a/c v1"},
+	})
+}
+
+func TestIdenticalUpdatePackedObject(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1, "/a/")
+	writePackedObjects(tc, 1, 1, nil, "/a/", map[string]expectedObject{
+		"/a/b": {content: "a/b v1"},
+		"/a/c": {content: "a/c v1"},
+	})
+
+	fs := tc.FsApi()
+
+	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"/a/b": {content: "a/b v1"},
+		"/a/c": {content: "a/c v1"},
+	})
+	err := fs.Update(updateStream)
+	require.NoError(t, err, "fs.Update")
+
+	assert.Equal(t, int64(1), updateStream.response.Version, "expected version 1")
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, "/a"), stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
 		"/a/b": {content: "a/b v1"},
 		"/a/c": {content: "a/c v1"},
 	})
@@ -1145,3 +1935,1200 @@ func TestProjectRollback(t *testing.T) {
 		"c": {content: "c v2"},
 	})
 }
+
+func TestFanOutUpdate(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	writeObject(tc, 1, 1, i(2), "a", "a v1")
+	writeObject(tc, 1, 1, i(2), "b", "b v1")
+	writeObject(tc, 1, 1, i(2), "c", "c v1")
+	writeObject(tc, 1, 2, nil, "a", "a v2")
+	writeObject(tc, 1, 2, nil, "b", "b v2")
+	writeObject(tc, 1, 2, nil, "d", "d v2")
+
+	writeProject(tc, 2, 1)
+	writeObject(tc, 2, 1, nil, "a", "a v1")
+	writeObject(tc, 2, 1, nil, "b", "b custom")
+	writeObject(tc, 2, 1, nil, "c", "c v1")
+
+	fs := tc.FsApi()
+
+	stream := &mockFanOutUpdateServer{ctx: tc.Context()}
+	err := fs.FanOutUpdate(&pb.FanOutUpdateRequest{
+		Source:      1,
+		FromVersion: 1,
+		Targets:     []int64{2, 3},
+	}, stream)
+	require.NoError(t, err, "fs.FanOutUpdate")
+
+	require.Len(t, stream.results, 2)
+
+	assert.Equal(t, int64(2), stream.results[0].Version)
+	assert.Equal(t, int64(3), stream.results[0].UpdatedCount)
+	assert.Equal(t, []string{"b"}, stream.results[0].SkippedPaths)
+	assert.Nil(t, stream.results[0].Error)
+
+	assert.Equal(t, int64(3), stream.results[1].Project)
+	assert.NotNil(t, stream.results[1].Error, "expected missing project to report an error")
+
+	getStream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(2, nil, ""), getStream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, getStream.results, map[string]expectedObject{
+		"a": {content: "a v2"},
+		"b": {content: "b custom"},
+		"d": {content: "d v2"},
+	})
+}
+
+func TestGetWithTransforms(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	fs := tc.FsApi()
+
+	_, err := fs.NewProject(tc.Context(), &pb.NewProjectRequest{
+		Id:         1,
+		Transforms: []string{"strip-sourcemaps:**.js", "template:config/*.json"},
+	})
+	require.NoError(t, err, "fs.NewProject")
+
+	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"app.js":           {content: "console.log(1);\n//# sourceMappingURL=app.js.map\n"},
+		"config/env.json":  {content: `{"env": "${ENV}", "other": "${OTHER}"}`},
+		"config/raw.yaml":  {content: "env: ${ENV}"},
+		"lib/vendor.js.gz": {content: "//# sourceMappingURL=vendor.js.map"},
+	})
+	err = fs.Update(updateStream)
+	require.NoError(t, err, "fs.Update")
+
+	request := prefixQuery(1, nil, "")
+	request.Transform = true
+	request.TransformVars = map[string]string{"ENV": "production"}
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(request, stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"app.js":           {content: "console.log(1);\n"},
+		"config/env.json":  {content: `{"env": "production", "other": "${OTHER}"}`},
+		"config/raw.yaml":  {content: "env: ${ENV}"},
+		"lib/vendor.js.gz": {content: "//# sourceMappingURL=vendor.js.map"},
+	})
+
+	stream = &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"app.js":           {content: "console.log(1);\n//# sourceMappingURL=app.js.map\n"},
+		"config/env.json":  {content: `{"env": "${ENV}", "other": "${OTHER}"}`},
+		"config/raw.yaml":  {content: "env: ${ENV}"},
+		"lib/vendor.js.gz": {content: "//# sourceMappingURL=vendor.js.map"},
+	})
+}
+
+func TestGetWithLfsPointerTransform(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	fs := tc.FsApi()
+
+	_, err := fs.NewProject(tc.Context(), &pb.NewProjectRequest{
+		Id:         1,
+		Transforms: []string{"lfs-pointer:assets/**"},
+	})
+	require.NoError(t, err, "fs.NewProject")
+
+	pointer := "version https://git-lfs.github.com/spec/v1\noid sha256:a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447\nsize 12\n"
+
+	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"assets/logo.png":    {content: "hello world\n"},
+		"assets/pointer.bin": {content: pointer},
+		"app.js":             {content: "hello world\n"},
+	})
+	err = fs.Update(updateStream)
+	require.NoError(t, err, "fs.Update")
+
+	request := prefixQuery(1, nil, "")
+	request.Transform = true
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(request, stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"assets/logo.png":    {content: pointer},
+		"assets/pointer.bin": {content: pointer},
+		"app.js":             {content: "hello world\n"},
+	})
+}
+
+func TestUpdateResolvesLfsPointers(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	fs := tc.FsApi()
+
+	_, err := fs.NewProject(tc.Context(), &pb.NewProjectRequest{
+		Id:         1,
+		Transforms: []string{"lfs-pointer:assets/**"},
+	})
+	require.NoError(t, err, "fs.NewProject")
+
+	pointer := "version https://git-lfs.github.com/spec/v1\noid sha256:a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447\nsize 12\n"
+
+	err = fs.Update(newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"assets/logo.png": {content: "hello world\n"},
+	}))
+	require.NoError(t, err, "fs.Update")
+
+	err = fs.Update(newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"assets/logo.png": {content: pointer},
+		"assets/copy.png": {content: pointer},
+		"pointer.txt":     {content: pointer},
+	}))
+	require.NoError(t, err, "fs.Update with lfs pointers")
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"assets/logo.png": {content: "hello world\n"},
+		"assets/copy.png": {content: "hello world\n"},
+		"pointer.txt":     {content: pointer},
+	})
+
+	unknown := "version https://git-lfs.github.com/spec/v1\noid sha256:0000000000000000000000000000000000000000000000000000000000000000\nsize 5\n"
+
+	err = fs.Update(newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"assets/other.png": {content: unknown},
+	}))
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "fs.Update with a pointer to unknown content")
+}
+
+func TestNewProjectWithInvalidTransform(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	fs := tc.FsApi()
+
+	_, err := fs.NewProject(tc.Context(), &pb.NewProjectRequest{
+		Id:         1,
+		Transforms: []string{"exec:**"},
+	})
+	require.Error(t, err, "fs.NewProject with unknown transform")
+}
+
+func TestSetProjectTransforms(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	fs := tc.FsApi()
+
+	_, err := fs.NewProject(tc.Context(), &pb.NewProjectRequest{Id: 1})
+	require.NoError(t, err, "fs.NewProject")
+
+	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"config/env.json": {content: `{"env": "${ENV}"}`},
+	})
+	err = fs.Update(updateStream)
+	require.NoError(t, err, "fs.Update")
+
+	get := func() []*pb.Object {
+		request := prefixQuery(1, nil, "")
+		request.Transform = true
+		request.TransformVars = map[string]string{"ENV": "production"}
+
+		stream := &mockGetServer{ctx: tc.Context()}
+		err := fs.Get(request, stream)
+		require.NoError(t, err, "fs.Get")
+		return stream.results
+	}
+
+	verifyStreamResults(t, get(), map[string]expectedObject{
+		"config/env.json": {content: `{"env": "${ENV}"}`},
+	})
+
+	_, err = fs.SetProjectTransforms(tc.Context(), &pb.SetProjectTransformsRequest{Project: 1, Transforms: []string{"template:config/*.json"}})
+	require.NoError(t, err, "fs.SetProjectTransforms")
+
+	verifyStreamResults(t, get(), map[string]expectedObject{
+		"config/env.json": {content: `{"env": "production"}`},
+	})
+
+	_, err = fs.SetProjectTransforms(tc.Context(), &pb.SetProjectTransformsRequest{Project: 1, Transforms: []string{"exec:**"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "fs.SetProjectTransforms with unknown transform")
+
+	_, err = fs.SetProjectTransforms(tc.Context(), &pb.SetProjectTransformsRequest{Project: 2, Transforms: []string{"template:**"}})
+	require.Equal(t, codes.NotFound, status.Code(err), "fs.SetProjectTransforms on missing project")
+
+	_, err = fs.SetProjectTransforms(tc.Context(), &pb.SetProjectTransformsRequest{Project: 1})
+	require.NoError(t, err, "fs.SetProjectTransforms")
+
+	verifyStreamResults(t, get(), map[string]expectedObject{
+		"config/env.json": {content: `{"env": "${ENV}"}`},
+	})
+}
+
+func TestUpdateDetectsContentType(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+
+	fs := tc.FsApi()
+	fs.DetectContentType = true
+
+	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"index.html": {content: "<html><body></body></html>"},
+		"styles.css": {content: "body {}"},
+		"app.js":     {content: "export {}"},
+		"DATA.JSON":  {content: "{}"},
+		"unknown":    {content: "plain text content"},
+	})
+	err := fs.Update(updateStream)
+	require.NoError(t, err, "fs.Update")
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get")
+
+	contentTypes := make(map[string]string)
+	for _, object := range stream.results {
+		require.NotNil(t, object.ContentType, "missing content type for %v", object.Path)
+		contentTypes[object.Path] = *object.ContentType
+	}
+
+	assert.Equal(t, map[string]string{
+		"index.html": "text/html; charset=utf-8",
+		"styles.css": "text/css; charset=utf-8",
+		"app.js":     "text/javascript; charset=utf-8",
+		"DATA.JSON":  "application/json",
+		"unknown":    "text/plain; charset=utf-8",
+	}, contentTypes)
+}
+
+func TestDiffBinaryAndLargeFiles(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	writeObject(tc, 1, 1, i(2), "text", "text v1")
+	writeObject(tc, 1, 1, i(2), "binary", "binary\x00v1")
+	writeObject(tc, 1, 1, i(2), "large", "large v1")
+	writeObject(tc, 1, 1, i(2), "removed", "removed v1")
+	writeObject(tc, 1, 1, nil, "unchanged", "unchanged v1")
+	writeObject(tc, 1, 2, nil, "text", "text v2")
+	writeObject(tc, 1, 2, nil, "binary", "binary\x00v2 longer")
+	writeObject(tc, 1, 2, nil, "large", "large v2 which is way too long")
+	writeObject(tc, 1, 2, nil, "added", "added v2")
+
+	fs := tc.FsApi()
+
+	stream := &mockDiffServer{ctx: tc.Context()}
+	err := fs.Diff(&pb.DiffRequest{
+		Project:       1,
+		FromVersion:   1,
+		MaxInlineSize: i(20),
+	}, stream)
+	require.NoError(t, err, "fs.Diff")
+
+	diffs := make(map[string]*pb.DiffResponse)
+	for _, diff := range stream.results {
+		diffs[diff.Path] = diff
+	}
+
+	require.Len(t, diffs, 5)
+
+	assert.Equal(t, pb.DiffResponse_MODIFIED, diffs["text"].Change)
+	assert.True(t, diffs["text"].Inline)
+	assert.Equal(t, "text v1", string(diffs["text"].OldContent))
+	assert.Equal(t, "text v2", string(diffs["text"].NewContent))
+
+	assert.True(t, diffs["binary"].Binary)
+	assert.False(t, diffs["binary"].Inline)
+	assert.Nil(t, diffs["binary"].NewContent)
+	assert.Equal(t, int64(9), diffs["binary"].OldSize)
+	assert.Equal(t, int64(16), diffs["binary"].NewSize)
+
+	assert.False(t, diffs["large"].Binary)
+	assert.False(t, diffs["large"].Inline)
+	assert.Nil(t, diffs["large"].NewContent)
+
+	assert.Equal(t, pb.DiffResponse_ADDED, diffs["added"].Change)
+	assert.Equal(t, pb.DiffResponse_DELETED, diffs["removed"].Change)
+}
+
+func TestDiffModeOnlyChange(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	writeObjectFull(tc, 1, 1, i(2), "script", "echo hi", 0644)
+	writeObjectFull(tc, 1, 2, nil, "script", "echo hi", 0755)
+
+	fs := tc.FsApi()
+
+	stream := &mockDiffServer{ctx: tc.Context()}
+	err := fs.Diff(&pb.DiffRequest{Project: 1, FromVersion: 1}, stream)
+	require.NoError(t, err, "fs.Diff")
+
+	require.Len(t, stream.results, 1)
+	diff := stream.results[0]
+	assert.Equal(t, pb.DiffResponse_MODE_CHANGED, diff.Change)
+	assert.Equal(t, int64(0644), diff.OldMode)
+	assert.Equal(t, int64(0755), diff.NewMode)
+}
+
+func TestUpdateNormalizesDirectories(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+
+	fs := tc.FsApi()
+
+	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"dir": {content: "", mode: directoryMode},
+	})
+	err := fs.Update(updateStream)
+	require.NoError(t, err, "fs.Update")
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"a":    {content: "a v1"},
+		"dir/": {content: "", mode: directoryMode},
+	})
+
+	updateStream = newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"a/": {content: "a v2"},
+	})
+	err = fs.Update(updateStream)
+	require.Error(t, err, "fs.Update with a trailing slash on a file")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUpdateCanonicalizesPaths(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+
+	fs := tc.FsApi()
+
+	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"./a":    {content: "a v2"},
+		"/b//c":  {content: "c v1"},
+		"d/./e/": {content: "", mode: directoryMode},
+	})
+	err := fs.Update(updateStream)
+	require.NoError(t, err, "fs.Update")
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"a":    {content: "a v2"},
+		"/b/c": {content: "c v1"},
+		"d/e/": {content: "", mode: directoryMode},
+	})
+
+	updateStream = newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"f":   {content: "f v1"},
+		"./f": {content: "f v2"},
+	})
+	err = fs.Update(updateStream)
+	require.Error(t, err, "fs.Update with two spellings of a path")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	updateStream = newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"./": {content: "", mode: directoryMode},
+	})
+	err = fs.Update(updateStream)
+	require.Error(t, err, "fs.Update with an empty path")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUpdateRejectsInvalidPaths(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+
+	fs := tc.FsApi()
+
+	updateStream := newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"b":                        {content: "b v1"},
+		"../escape":                {content: "x"},
+		"c/\x00d":                  {content: "x"},
+		strings.Repeat("e", 256):   {content: "x"},
+		strings.Repeat("f/", 2049): {content: "x"},
+	})
+	err := fs.Update(updateStream)
+	require.Error(t, err, "fs.Update with invalid paths")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	reasons := make(map[string]int)
+	for _, violation := range client.InvalidPaths(err) {
+		reason, _, _ := strings.Cut(violation.Description, ":")
+		reasons[reason] += 1
+	}
+	assert.Equal(t, map[string]int{
+		pb.TraversalSegment: 1,
+		pb.NulByte:          1,
+		pb.SegmentTooLong:   1,
+		pb.PathTooLong:      1,
+	}, reasons)
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"a": {content: "a v1"},
+	})
+}
+
+func TestValidatePaths(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+	writeObject(tc, 1, 1, i(2), "b/../c", "c v1")
+	writeObject(tc, 1, 2, nil, strings.Repeat("d", 300), "d v1")
+
+	fs := tc.FsApi()
+
+	response, err := fs.ValidatePaths(tc.Context(), &pb.ValidatePathsRequest{Project: 1})
+	require.NoError(t, err, "fs.ValidatePaths")
+	assert.Equal(t, int64(3), response.Checked)
+	require.Len(t, response.Invalid, 2)
+
+	assert.Equal(t, "b/../c", string(response.Invalid[0].Path))
+	assert.Equal(t, pb.TraversalSegment, response.Invalid[0].Reason)
+	assert.False(t, response.Invalid[0].Live)
+
+	assert.Equal(t, strings.Repeat("d", 300), string(response.Invalid[1].Path))
+	assert.Equal(t, pb.SegmentTooLong, response.Invalid[1].Reason)
+	assert.True(t, response.Invalid[1].Live)
+
+	response, err = fs.ValidatePaths(tc.Context(), &pb.ValidatePathsRequest{Project: 1, Limit: i(1)})
+	require.NoError(t, err, "fs.ValidatePaths with a limit")
+	assert.Equal(t, int64(3), response.Checked)
+	assert.Len(t, response.Invalid, 1)
+}
+
+func TestRepairPaths(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+	writeObject(tc, 1, 1, nil, "./a", "a v1")
+	writeObject(tc, 1, 1, nil, "./b", "b v1")
+	writeObject(tc, 1, 1, nil, "c", "c v1")
+	writeObject(tc, 1, 2, nil, "./c", "c v2")
+	writeObject(tc, 1, 1, nil, "d", "d v1")
+	writeObject(tc, 1, 2, nil, "/d", "d v2")
+	writeObject(tc, 1, 1, i(2), "e", "e v1")
+	writeObject(tc, 1, 2, nil, "/e", "e v2")
+
+	fs := tc.FsApi()
+
+	actions := func(response *pb.RepairPathsResponse) map[string]pb.PathRepair_Action {
+		result := make(map[string]pb.PathRepair_Action)
+		for _, repair := range response.Repairs {
+			result[repair.Path] = repair.Action
+		}
+		return result
+	}
+
+	response, err := fs.RepairPaths(tc.Context(), &pb.RepairPathsRequest{Project: 1, DryRun: true})
+	require.NoError(t, err, "fs.RepairPaths dry run")
+	assert.Equal(t, int64(3), response.Checked)
+	assert.Equal(t, map[string]pb.PathRepair_Action{
+		"./a": pb.PathRepair_MERGED,
+		"./b": pb.PathRepair_RENAMED,
+		"./c": pb.PathRepair_CONFLICT,
+		"/d":  pb.PathRepair_AMBIGUOUS,
+	}, actions(response))
+
+	response, err = fs.RepairPaths(tc.Context(), &pb.RepairPathsRequest{Project: 1})
+	require.NoError(t, err, "fs.RepairPaths")
+	assert.Equal(t, int64(3), response.Checked)
+
+	response, err = fs.RepairPaths(tc.Context(), &pb.RepairPathsRequest{Project: 1, DryRun: true})
+	require.NoError(t, err, "fs.RepairPaths after repair")
+	assert.Equal(t, map[string]pb.PathRepair_Action{
+		"./c": pb.PathRepair_CONFLICT,
+		"/d":  pb.PathRepair_AMBIGUOUS,
+	}, actions(response))
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"a":   {content: "a v1"},
+		"b":   {content: "b v1"},
+		"c":   {content: "c v1"},
+		"./c": {content: "c v2"},
+		"d":   {content: "d v1"},
+		"/d":  {content: "d v2"},
+		"/e":  {content: "e v2"},
+	})
+}
+
+func TestCanonicalizePacks(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1, "pack/")
+
+	// Entries written out of order, as older servers could when appending to an existing pack
+	contentWriter := db.NewTarWriter()
+	for _, path := range []string{"pack/b", "pack/a"} {
+		object := db.NewUncachedTarObject(path, 0755, int64(len(path)), false, []byte(path))
+		err := contentWriter.WriteObject(&object)
+		require.NoError(t, err, "write content to TAR")
+	}
+	contentTar, err := contentWriter.BytesAndReset()
+	require.NoError(t, err, "write content TAR to bytes")
+
+	hash := db.HashContent(contentTar)
+	conn := tc.Connect()
+	_, err = conn.Exec(tc.Context(), `
+		INSERT INTO dl.objects (project, start_version, stop_version, path, hash, mode, size, packed)
+		VALUES ($1, $2, NULL, $3, ($4, $5), $6, $7, $8)
+	`, 1, 1, "pack/", hash.H1, hash.H2, 0755, len(contentTar), true)
+	require.NoError(t, err, "insert object")
+	_, err = conn.Exec(tc.Context(), `
+		INSERT INTO dl.contents (hash, bytes)
+		VALUES (($1, $2), $3)
+	`, hash.H1, hash.H2, contentTar)
+	require.NoError(t, err, "insert contents")
+
+	fs := tc.FsApi()
+
+	response, err := fs.CanonicalizePacks(tc.Context(), &pb.CanonicalizePacksRequest{Project: 1, DryRun: true})
+	require.NoError(t, err, "fs.CanonicalizePacks dry run")
+	assert.Equal(t, int64(1), response.Checked)
+	assert.Equal(t, int64(1), response.Rewritten)
+
+	response, err = fs.CanonicalizePacks(tc.Context(), &pb.CanonicalizePacksRequest{Project: 1})
+	require.NoError(t, err, "fs.CanonicalizePacks")
+	assert.Equal(t, int64(1), response.Rewritten)
+
+	response, err = fs.CanonicalizePacks(tc.Context(), &pb.CanonicalizePacksRequest{Project: 1, DryRun: true})
+	require.NoError(t, err, "fs.CanonicalizePacks after rewrite")
+	assert.Equal(t, int64(0), response.Rewritten)
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get")
+
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"pack/a": {content: "pack/a"},
+		"pack/b": {content: "pack/b"},
+	})
+}
+
+func TestCaptureHeapProfile(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	fs := tc.FsApi()
+
+	response, err := fs.CaptureHeapProfile(tc.Context(), &pb.CaptureHeapProfileRequest{Gc: true})
+	require.NoError(t, err, "fs.CaptureHeapProfile")
+	assert.NotEmpty(t, response.Profile)
+
+	projectCtx := context.WithValue(tc.Context(), auth.AuthCtxKey, auth.Auth{Role: auth.Project, Project: i(1)})
+	_, err = fs.CaptureHeapProfile(projectCtx, &pb.CaptureHeapProfileRequest{})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestContentStats(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2)
+	writeObject(tc, 1, 1, i(2), "/a", "a v1")
+	writeObject(tc, 1, 2, nil, "/a", "a v2")
+	writeObject(tc, 1, 2, nil, "/b", "shared")
+
+	writeProject(tc, 3, 1)
+	writeObject(tc, 3, 1, nil, "/c", "c v1")
+
+	fs := tc.FsApi()
+
+	_, err := fs.NewProject(tc.Context(), &pb.NewProjectRequest{Id: 2, Template: i(1)})
+	require.NoError(t, err, "fs.NewProject")
+
+	response, err := fs.ContentStats(tc.Context(), &pb.ContentStatsRequest{Top: i(1)})
+	require.NoError(t, err, "fs.ContentStats")
+
+	assert.Equal(t, int64(3), response.Total.Projects)
+	assert.Equal(t, int64(5), response.Total.Objects)
+	assert.Equal(t, int64(24), response.Total.LogicalBytes)
+	assert.Equal(t, int64(14), response.Total.UniqueBytes)
+
+	require.Len(t, response.TopContents, 1)
+	assert.Equal(t, int64(2), response.TopContents[0].Projects)
+	assert.Equal(t, int64(2), response.TopContents[0].References)
+
+	require.Len(t, response.Families, 1)
+	family := response.Families[0]
+	assert.Equal(t, int64(1), family.Template)
+	assert.Equal(t, int64(2), family.Stats.Projects)
+	assert.Equal(t, int64(4), family.Stats.Objects)
+	assert.Equal(t, int64(20), family.Stats.LogicalBytes)
+	assert.Equal(t, int64(10), family.Stats.UniqueBytes)
+	assert.Equal(t, 2.0, family.Stats.DedupRatio)
+
+	projectCtx := context.WithValue(tc.Context(), auth.AuthCtxKey, auth.Auth{Role: auth.Project, Project: i(1)})
+	_, err = fs.ContentStats(projectCtx, &pb.ContentStatsRequest{})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestFindContent(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 3, "/p/")
+	writeObject(tc, 1, 1, i(2), "/a", "vulnerable")
+	writeObject(tc, 1, 2, nil, "/a", "patched")
+	writeObject(tc, 1, 3, nil, "/b", "vulnerable")
+	writePackedObjects(tc, 1, 1, i(2), "/p/", map[string]expectedObject{
+		"/p/1": {content: "vulnerable"},
+		"/p/2": {content: "p2 v1"},
+	})
+	writePackedObjects(tc, 1, 2, nil, "/p/", map[string]expectedObject{
+		"/p/1": {content: "vulnerable"},
+		"/p/2": {content: "p2 v2"},
+	})
+
+	writeProject(tc, 2, 1)
+	writeObject(tc, 2, 1, nil, "/c", "vulnerable")
+	writeObject(tc, 2, 1, nil, "/d", "safe")
+
+	fs := tc.FsApi()
+
+	_, err := fs.IndexPacks(tc.Context(), &pb.IndexPacksRequest{Project: 1})
+	require.NoError(t, err, "fs.IndexPacks")
+
+	hash := db.HashContent([]byte("vulnerable"))
+
+	response, err := fs.FindContent(tc.Context(), &pb.FindContentRequest{Hash: hash.Bytes()})
+	require.NoError(t, err, "fs.FindContent")
+	assert.False(t, response.Truncated)
+
+	var live []string
+	for _, reference := range response.References {
+		live = append(live, fmt.Sprintf("%d:%s", reference.Project, reference.Path))
+		assert.Nil(t, reference.StopVersion, "live reference %v", reference.Path)
+	}
+	assert.Equal(t, []string{"1:/b", "1:/p/1", "2:/c"}, live)
+
+	response, err = fs.FindContent(tc.Context(), &pb.FindContentRequest{Hash: hash.Bytes(), IncludeHistory: true})
+	require.NoError(t, err, "fs.FindContent with history")
+	require.Len(t, response.References, 4)
+
+	past := response.References[0]
+	assert.Equal(t, "/a", past.Path)
+	assert.Equal(t, int64(1), past.StartVersion)
+	assert.Equal(t, int64(2), past.GetStopVersion())
+
+	packed := response.References[2]
+	assert.Equal(t, "/p/1", packed.Path)
+	assert.True(t, packed.Packed)
+	assert.Equal(t, int64(1), packed.StartVersion, "pack versions holding the content should be merged")
+	assert.Nil(t, packed.StopVersion)
+
+	response, err = fs.FindContent(tc.Context(), &pb.FindContentRequest{Hash: hash.Bytes(), Limit: i(1)})
+	require.NoError(t, err, "fs.FindContent with limit")
+	assert.Len(t, response.References, 1)
+	assert.True(t, response.Truncated)
+
+	_, err = fs.FindContent(tc.Context(), &pb.FindContentRequest{Hash: []byte("short")})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	projectCtx := context.WithValue(tc.Context(), auth.AuthCtxKey, auth.Auth{Role: auth.Project, Project: i(1)})
+	_, err = fs.FindContent(projectCtx, &pb.FindContentRequest{Hash: hash.Bytes()})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestSampleObjects(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2, "/p/")
+	writeObject(tc, 1, 1, i(2), "/a", "a v1")
+	writeObject(tc, 1, 2, nil, "/a", "a v2")
+	writeObject(tc, 1, 1, nil, "/large", "a much larger content")
+	writePackedObjects(tc, 1, 1, nil, "/p/", map[string]expectedObject{
+		"/p/1": {content: "p1"},
+	})
+
+	writeProject(tc, 2, 1)
+	writeObject(tc, 2, 1, nil, "/b", "b v1")
+
+	fs := tc.FsApi()
+
+	stream := &mockSampleObjectsServer{ctx: tc.Context()}
+	err := fs.SampleObjects(&pb.SampleObjectsRequest{Project: i(1), Count: 10, IncludeContent: true, MaxContentSize: 4}, stream)
+	require.NoError(t, err, "fs.SampleObjects")
+
+	sampled := make(map[string]*pb.Objekt)
+	for _, result := range stream.results {
+		assert.Equal(t, int64(1), result.Project)
+		sampled[result.Object.Path] = result.Object
+	}
+
+	require.Len(t, sampled, 3, "every live object of project 1 should be sampled")
+	assert.Equal(t, "a v2", string(sampled["/a"].Content))
+	assert.Equal(t, "p1", string(sampled["/p/1"].Content))
+	assert.True(t, sampled["/large"].ContentOmitted, "large content should be omitted")
+	assert.Empty(t, sampled["/large"].Content)
+
+	stream = &mockSampleObjectsServer{ctx: tc.Context()}
+	err = fs.SampleObjects(&pb.SampleObjectsRequest{Count: 2}, stream)
+	require.NoError(t, err, "fs.SampleObjects")
+
+	require.Len(t, stream.results, 2)
+	for _, result := range stream.results {
+		assert.Nil(t, result.Object.Content, "content should only be sent when requested")
+	}
+
+	err = fs.SampleObjects(&pb.SampleObjectsRequest{Count: 0}, &mockSampleObjectsServer{ctx: tc.Context()})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	projectCtx := context.WithValue(tc.Context(), auth.AuthCtxKey, auth.Auth{Role: auth.Project, Project: i(1)})
+	err = fs.SampleObjects(&pb.SampleObjectsRequest{Count: 1}, &mockSampleObjectsServer{ctx: projectCtx})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	err = fs.SampleObjects(&pb.SampleObjectsRequest{Project: i(2), Count: 1}, &mockSampleObjectsServer{ctx: projectCtx})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestProjectPolicy(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "/keys/old.pem", "old key")
+
+	fs := tc.FsApi()
+
+	_, err := fs.SetProjectPolicy(tc.Context(), &pb.SetProjectPolicyRequest{Project: 1, Policy: &pb.ProjectPolicy{ForbiddenPaths: []string{"["}}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "invalid globs should be rejected")
+
+	response, err := fs.SetProjectPolicy(tc.Context(), &pb.SetProjectPolicyRequest{
+		Project: 1,
+		Policy:  &pb.ProjectPolicy{MaxFileSize: i(8), ForbiddenPaths: []string{"**.pem"}},
+	})
+	require.NoError(t, err, "fs.SetProjectPolicy")
+	assert.Equal(t, int64(8), response.Policy.GetMaxFileSize())
+	assert.Equal(t, []string{"**.pem"}, response.Policy.ForbiddenPaths)
+
+	err = fs.Update(newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"/ok":           {content: "ok"},
+		"/large":        {content: "larger than eight bytes"},
+		"/keys/new.pem": {content: "key"},
+	}))
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	violations := client.PolicyViolations(err)
+	require.Len(t, violations, 2, "every violation should be listed")
+	subjects := []string{violations[0].Subject, violations[1].Subject}
+	assert.ElementsMatch(t, []string{"/large", "/keys/new.pem"}, subjects)
+	assert.Equal(t, 1, countObjectsByProject(tc, 1), "a rejected update writes nothing")
+
+	err = fs.Update(newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"/ok":           {content: "ok"},
+		"/keys/old.pem": {deleted: true},
+	}))
+	require.NoError(t, err, "deleting forbidden paths is allowed")
+
+	projectCtx := context.WithValue(tc.Context(), auth.AuthCtxKey, auth.Auth{Role: auth.Project, Project: i(1)})
+	policy, err := fs.GetProjectPolicy(projectCtx, &pb.GetProjectPolicyRequest{Project: 1})
+	require.NoError(t, err, "fs.GetProjectPolicy")
+	assert.Equal(t, int64(8), policy.Policy.GetMaxFileSize())
+
+	_, err = fs.SetProjectPolicy(projectCtx, &pb.SetProjectPolicyRequest{Project: 1, Policy: &pb.ProjectPolicy{}})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = fs.SetProjectPolicy(tc.Context(), &pb.SetProjectPolicyRequest{Project: 1})
+	require.NoError(t, err, "fs.SetProjectPolicy")
+
+	err = fs.Update(newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"/large": {content: "larger than eight bytes"},
+	}))
+	require.NoError(t, err, "an empty policy removes every restriction")
+}
+
+func TestProjectFreeze(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeProject(tc, 2, 1)
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+
+	fs := tc.FsApi()
+
+	_, err := fs.SetProjectFrozen(tc.Context(), &pb.SetProjectFrozenRequest{Project: 3, Frozen: true})
+	require.Equal(t, codes.NotFound, status.Code(err), "freezing a missing project")
+
+	_, err = fs.SetProjectFrozen(tc.Context(), &pb.SetProjectFrozenRequest{Project: 1, Frozen: true, Reason: s("incident 42")})
+	require.NoError(t, err, "fs.SetProjectFrozen")
+
+	err = fs.Update(newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"/a": {content: "a v2"},
+	}))
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "updating a frozen project")
+	assert.Contains(t, err.Error(), "incident 42", "the reason should be in the error")
+
+	_, err = fs.Rollback(tc.Context(), &pb.RollbackRequest{Project: 1, Version: 0})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "rolling back a frozen project")
+
+	_, err = fs.CloneToProject(tc.Context(), &pb.CloneToProjectRequest{Source: 2, Version: 1, Target: 1})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "cloning into a frozen project")
+
+	_, err = fs.CloneToProject(tc.Context(), &pb.CloneToProjectRequest{Source: 1, Version: 1, Target: 2})
+	require.NoError(t, err, "cloning from a frozen project")
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get")
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/a": {content: "a v1"},
+	})
+
+	inspect, err := fs.Inspect(tc.Context(), &pb.InspectRequest{Project: 1})
+	require.NoError(t, err, "fs.Inspect")
+	assert.True(t, inspect.Frozen, "inspect should report the freeze")
+	assert.Equal(t, "incident 42", inspect.GetFrozenReason())
+
+	list, err := fs.ListProjects(tc.Context(), &pb.ListProjectsRequest{})
+	require.NoError(t, err, "fs.ListProjects")
+	for _, project := range list.Projects {
+		assert.Equal(t, project.Id == 1, project.Frozen, "frozen state of project %v", project.Id)
+	}
+
+	_, err = fs.SetProjectFrozen(tc.Context(), &pb.SetProjectFrozenRequest{Project: 1, Frozen: false})
+	require.NoError(t, err, "fs.SetProjectFrozen")
+
+	err = fs.Update(newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"/a": {content: "a v2"},
+	}))
+	require.NoError(t, err, "updating an unfrozen project")
+
+	inspect, err = fs.Inspect(tc.Context(), &pb.InspectRequest{Project: 1})
+	require.NoError(t, err, "fs.Inspect")
+	assert.False(t, inspect.Frozen, "inspect should report the unfreeze")
+	assert.Nil(t, inspect.FrozenReason, "unfreezing clears the reason")
+}
+
+func TestUpdateDeletionGuard(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	for _, path := range []string{"/a", "/b", "/c", "/d"} {
+		writeObject(tc, 1, 1, nil, path, path+" v1")
+	}
+
+	fs := tc.FsApi()
+	fs.DeletionGuard = &policy.DeletionGuard{MaxRatio: 0.5, MinObjects: 4}
+
+	deletions := map[string]expectedObject{
+		"/a": {deleted: true},
+		"/b": {deleted: true},
+		"/c": {deleted: true},
+	}
+
+	err := fs.Update(newMockUpdateServer(tc.Context(), 1, deletions))
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "removing 3 of 4 objects")
+
+	violations := client.PolicyViolations(err)
+	require.Len(t, violations, 1, "the deletion guard violation should be detailed")
+	assert.Equal(t, policy.MassDeletion, violations[0].Type)
+	assert.Equal(t, 4, countObjectsByProject(tc, 1), "a rejected update writes nothing")
+
+	err = fs.Update(newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"/a": {deleted: true},
+		"/e": {content: "e v2"},
+	}))
+	require.NoError(t, err, "replacing objects does not remove any")
+
+	force := true
+	forced := newMockUpdateServer(tc.Context(), 1, deletions)
+	forced.force = &force
+	err = fs.Update(forced)
+	require.NoError(t, err, "a forced update is applied")
+	assert.Equal(t, int64(3), forced.response.Version)
+}
+
+func TestGetUnaryCompressedResponse(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	for idx := 0; idx < 50; idx++ {
+		writeObject(tc, 1, 1, nil, fmt.Sprintf("/routes/route-%d.js", idx), fmt.Sprintf("export default function route%d() {}", idx))
+	}
+
+	c, fs, close := createTestClient(tc)
+	defer close()
+
+	for _, compressors := range [][]string{nil, {"zstd", "gzip"}, {"gzip"}} {
+		fs.ResponseCompressors = compressors
+
+		response, err := c.GetUnary(tc.Context(), 1, []*pb.ObjectQuery{{Path: "/routes/", IsPrefix: true}}, client.VersionRange{}, nil)
+		require.NoError(t, err, "client.GetUnary with compressors %v", compressors)
+		require.Len(t, response.Objects, 50)
+		assert.Equal(t, "export default function route0() {}", string(response.Objects[0].Content))
+	}
+}
+
+func TestRestorePaths(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 3)
+	writeObject(tc, 1, 1, i(2), "/a", "a v1")
+	writeObject(tc, 1, 2, i(3), "/a", "a v2")
+	writeObject(tc, 1, 1, nil, "/b", "b v1")
+	writeObject(tc, 1, 1, i(2), "/c", "c v1")
+	writeObject(tc, 1, 2, i(3), "/c", "c v2")
+
+	fs := tc.FsApi()
+
+	_, err := fs.RestorePaths(tc.Context(), &pb.RestorePathsRequest{Project: 1, Paths: []string{"/b"}})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "restoring a live path")
+
+	_, err = fs.RestorePaths(tc.Context(), &pb.RestorePathsRequest{Project: 1, Paths: []string{"/d"}})
+	require.Equal(t, codes.NotFound, status.Code(err), "restoring a path that never existed")
+
+	response, err := fs.RestorePaths(tc.Context(), &pb.RestorePathsRequest{Project: 1, Paths: []string{"/a"}, Message: s("undelete a")})
+	require.NoError(t, err, "fs.RestorePaths")
+	assert.Equal(t, int64(4), response.Version, "restored version")
+	require.Len(t, response.Restored, 1)
+	assert.Equal(t, int64(2), response.Restored[0].FromVersion, "/a should be restored from its last live version")
+
+	response, err = fs.RestorePaths(tc.Context(), &pb.RestorePathsRequest{Project: 1, Paths: []string{"/c"}, FromVersion: i(1)})
+	require.NoError(t, err, "fs.RestorePaths from version")
+	assert.Equal(t, int64(5), response.Version, "restored version")
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get")
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/a": {content: "a v2"},
+		"/b": {content: "b v1"},
+		"/c": {content: "c v1"},
+	})
+
+	history, err := fs.History(tc.Context(), &pb.HistoryRequest{Project: 1, FromVersion: i(3), ToVersion: i(4)})
+	require.NoError(t, err, "fs.History")
+	require.NotEmpty(t, history.Versions)
+	assert.Equal(t, "undelete a", history.Versions[0].GetMessage(), "annotation of the restored version")
+}
+
+func TestPutAndDeleteObject(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 2, "/pack/")
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writeObject(tc, 1, 2, nil, "/b", "b v2")
+	writePackedObjects(tc, 1, 1, nil, "/pack/", map[string]expectedObject{
+		"/pack/c": {content: "pack/c v1"},
+	})
+
+	fs := tc.FsApi()
+
+	put, err := fs.PutObject(tc.Context(), &pb.PutObjectRequest{
+		Project:     1,
+		Object:      &pb.Object{Path: "/a", Mode: 0755, Content: []byte("a v3")},
+		BaseVersion: i(1),
+		Message:     s("edit a"),
+	})
+	require.NoError(t, err, "fs.PutObject")
+	assert.Equal(t, int64(3), put.Version)
+
+	put, err = fs.PutObject(tc.Context(), &pb.PutObjectRequest{Project: 1, Object: &pb.Object{Path: "/a", Mode: 0755, Content: []byte("a v3")}})
+	require.NoError(t, err, "fs.PutObject without changes")
+	assert.Equal(t, int64(3), put.Version, "an identical write keeps the latest version")
+
+	_, err = fs.PutObject(tc.Context(), &pb.PutObjectRequest{Project: 1, Object: &pb.Object{Path: "/b", Mode: 0755, Content: []byte("b v4")}, BaseVersion: i(1)})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "writing a path changed after the base version")
+
+	put, err = fs.PutObject(tc.Context(), &pb.PutObjectRequest{Project: 1, Object: &pb.Object{Path: "/pack/d", Mode: 0755, Content: []byte("pack/d v4")}, BaseVersion: i(3)})
+	require.NoError(t, err, "fs.PutObject packed")
+	assert.Equal(t, int64(4), put.Version)
+
+	_, err = fs.DeleteObject(tc.Context(), &pb.DeleteObjectRequest{Project: 1, Path: "/pack/c", BaseVersion: i(3)})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "deleting from a pack changed after the base version")
+
+	_, err = fs.DeleteObject(tc.Context(), &pb.DeleteObjectRequest{Project: 1, Path: "/missing"})
+	require.Equal(t, codes.NotFound, status.Code(err), "deleting a missing path")
+
+	deleted, err := fs.DeleteObject(tc.Context(), &pb.DeleteObjectRequest{Project: 1, Path: "/b", BaseVersion: i(4)})
+	require.NoError(t, err, "fs.DeleteObject")
+	assert.Equal(t, int64(5), deleted.Version)
+
+	deleted, err = fs.DeleteObject(tc.Context(), &pb.DeleteObjectRequest{Project: 1, Path: "/pack/c"})
+	require.NoError(t, err, "fs.DeleteObject packed")
+	assert.Equal(t, int64(6), deleted.Version)
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get")
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/a":      {content: "a v3"},
+		"/pack/d": {content: "pack/d v4"},
+	})
+
+	history, err := fs.History(tc.Context(), &pb.HistoryRequest{Project: 1, FromVersion: i(2), ToVersion: i(3)})
+	require.NoError(t, err, "fs.History")
+	require.NotEmpty(t, history.Versions)
+	assert.Equal(t, "edit a", history.Versions[0].GetMessage(), "annotation of the edited version")
+}
+
+func TestStageCommitAndDiscard(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1, "/pack/")
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writeObject(tc, 1, 1, nil, "/b", "b v1")
+	writePackedObjects(tc, 1, 1, nil, "/pack/", map[string]expectedObject{
+		"/pack/c": {content: "pack/c v1"},
+	})
+
+	fs := tc.FsApi()
+
+	stage := newMockStageServer(tc.Context(), 1, "draft", map[string]expectedObject{
+		"/a":      {content: "a v2"},
+		"/b":      {deleted: true},
+		"/pack/d": {content: "pack/d v2"},
+	})
+	err := fs.Stage(stage)
+	require.NoError(t, err, "fs.Stage")
+	assert.Equal(t, int64(3), stage.response.Staged)
+
+	// restaging a path replaces it
+	stage = newMockStageServer(tc.Context(), 1, "draft", map[string]expectedObject{
+		"/a": {content: "a v2 again"},
+	})
+	err = fs.Stage(stage)
+	require.NoError(t, err, "fs.Stage again")
+
+	listed, err := fs.ListStaged(tc.Context(), &pb.ListStagedRequest{Project: 1, Stage: "draft"})
+	require.NoError(t, err, "fs.ListStaged")
+	require.Len(t, listed.Objects, 3)
+	assert.Equal(t, "/a", listed.Objects[0].Path)
+	assert.Equal(t, int64(len("a v2 again")), listed.Objects[0].Size)
+	assert.False(t, listed.Objects[1].Exists, "a staged deletion does not exist")
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get before committing")
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/a":      {content: "a v1"},
+		"/b":      {content: "b v1"},
+		"/pack/c": {content: "pack/c v1"},
+	})
+
+	committed, err := fs.CommitStage(tc.Context(), &pb.CommitStageRequest{Project: 1, Stage: "draft", Message: s("from draft")})
+	require.NoError(t, err, "fs.CommitStage")
+	assert.Equal(t, int64(2), committed.Version, "a stage is committed in a single version")
+	assert.Equal(t, int64(3), committed.Count)
+
+	stream = &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get after committing")
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/a":      {content: "a v2 again"},
+		"/pack/c": {content: "pack/c v1"},
+		"/pack/d": {content: "pack/d v2"},
+	})
+
+	_, err = fs.CommitStage(tc.Context(), &pb.CommitStageRequest{Project: 1, Stage: "draft"})
+	require.Equal(t, codes.NotFound, status.Code(err), "a committed stage is dropped")
+
+	stage = newMockStageServer(tc.Context(), 1, "other", map[string]expectedObject{
+		"/e": {content: "e"},
+	})
+	err = fs.Stage(stage)
+	require.NoError(t, err, "fs.Stage other")
+
+	discarded, err := fs.DiscardStage(tc.Context(), &pb.DiscardStageRequest{Project: 1, Stage: "other"})
+	require.NoError(t, err, "fs.DiscardStage")
+	assert.Equal(t, int64(1), discarded.Discarded)
+
+	listed, err = fs.ListStaged(tc.Context(), &pb.ListStagedRequest{Project: 1, Stage: "other"})
+	require.NoError(t, err, "fs.ListStaged after discarding")
+	assert.Empty(t, listed.Objects)
+
+	err = fs.Stage(newMockStageServer(tc.Context(), 1, "", map[string]expectedObject{"/f": {content: "f"}}))
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "staging without a stage name")
+}
+
+func TestStageScopedToTenantAndWritableProjects(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	fs := tc.FsApi()
+
+	_, err := fs.NewProject(tc.Context(), &pb.NewProjectRequest{Id: 1, Tenant: s("a")})
+	require.NoError(t, err, "fs.NewProject")
+	_, err = fs.NewProject(tc.Context(), &pb.NewProjectRequest{Id: 2, Tenant: s("b")})
+	require.NoError(t, err, "fs.NewProject")
+
+	err = fs.Stage(newMockStageServer(tc.Context(), 2, "draft", map[string]expectedObject{"/a": {content: "a"}}))
+	require.NoError(t, err, "fs.Stage")
+
+	tenantCtx := context.WithValue(tc.Context(), auth.AuthCtxKey, auth.Auth{Role: auth.Admin, Tenant: s("a")})
+
+	err = fs.Stage(newMockStageServer(tenantCtx, 2, "draft", map[string]expectedObject{"/b": {content: "b"}}))
+	require.Equal(t, codes.NotFound, status.Code(err), "staging in a project of another tenant")
+
+	listed, err := fs.ListStaged(tenantCtx, &pb.ListStagedRequest{Project: 2, Stage: "draft"})
+	require.NoError(t, err, "fs.ListStaged")
+	assert.Empty(t, listed.Objects, "stages of another tenant are not visible")
+
+	_, err = fs.DiscardStage(tenantCtx, &pb.DiscardStageRequest{Project: 2, Stage: "draft"})
+	require.Equal(t, codes.NotFound, status.Code(err), "discarding a stage of another tenant")
+
+	listed, err = fs.ListStaged(tc.Context(), &pb.ListStagedRequest{Project: 2, Stage: "draft"})
+	require.NoError(t, err, "fs.ListStaged")
+	assert.Len(t, listed.Objects, 1)
+
+	err = fs.Stage(newMockStageServer(tc.Context(), 3, "draft", map[string]expectedObject{"/a": {content: "a"}}))
+	require.Equal(t, codes.NotFound, status.Code(err), "staging in a missing project")
+
+	_, err = fs.SetProjectFrozen(tc.Context(), &pb.SetProjectFrozenRequest{Project: 1, Frozen: true})
+	require.NoError(t, err, "fs.SetProjectFrozen")
+
+	err = fs.Stage(newMockStageServer(tc.Context(), 1, "draft", map[string]expectedObject{"/a": {content: "a"}}))
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "staging in a frozen project")
+
+	_, err = fs.DiscardStage(tc.Context(), &pb.DiscardStageRequest{Project: 1, Stage: "draft"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "discarding a stage of a frozen project")
+}
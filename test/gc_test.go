@@ -9,6 +9,8 @@ import (
 	util "github.com/gadget-inc/dateilager/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestGcProjectRemovesObjectsAndContent(t *testing.T) {
@@ -41,6 +43,38 @@ func TestGcProjectRemovesObjectsAndContent(t *testing.T) {
 	verifyStreamResults(t, stream.results, map[string]expectedObject{})
 }
 
+func TestGcProjectDryRun(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 4)
+	writeObject(tc, 1, 1, i(2), "/a", "a v1")
+	writeObject(tc, 1, 2, i(3), "/b", "b v2")
+	writeObject(tc, 1, 3, i(4), "/b", "b v3")
+	writeObject(tc, 1, 3, nil, "/c", "c v3")
+	writeObject(tc, 1, 4, nil, "/d", "d v4")
+
+	fs := tc.FsApi()
+
+	contents := countContents(tc)
+
+	response, err := fs.GcProject(tc.Context(), &pb.GcProjectRequest{
+		Project:      1,
+		KeepVersions: 1,
+		DryRun:       true,
+	})
+	require.NoError(t, err, "fs.GcProject dry run")
+	assert.Equal(t, int64(2), response.Count, "Gc dry run count")
+	assert.Equal(t, contents, countContents(tc), "Gc dry run removed contents")
+
+	response, err = fs.GcProject(tc.Context(), &pb.GcProjectRequest{
+		Project:      1,
+		KeepVersions: 1,
+	})
+	require.NoError(t, err, "fs.GcProject")
+	assert.Equal(t, int64(2), response.Count, "Gc result count")
+}
+
 func TestGcProjectWithoutDeletes(t *testing.T) {
 	tc := util.NewTestCtx(t, auth.Admin)
 	defer tc.Close()
@@ -208,3 +242,113 @@ func TestGcContentsRemovesContent(t *testing.T) {
 	assert.Equal(t, objectsCount, countObjects(tc), "Gc same objects")
 	assert.Less(t, countContents(tc), contentsCount, "Gc fewer contents")
 }
+
+func TestSquashHistory(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 5)
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writeObject(tc, 1, 1, i(2), "/b", "b v1")
+	writeObject(tc, 1, 2, nil, "/b", "b v2")
+	writeObject(tc, 1, 1, i(3), "/c", "c v1")
+	writeObject(tc, 1, 4, nil, "/d", "d v4")
+	writeObject(tc, 1, 4, i(5), "/e", "e v4")
+
+	fs := tc.FsApi()
+
+	response, err := fs.SquashHistory(tc.Context(), &pb.SquashHistoryRequest{Project: 1, KeepVersions: i(2)})
+	require.NoError(t, err, "fs.SquashHistory")
+
+	assert.Equal(t, int64(3), response.BaselineVersion)
+	assert.Equal(t, int64(2), response.RemovedObjects, "/b v1 and /c only existed below the baseline")
+	assert.Equal(t, int64(2), response.RewrittenObjects, "/a and /b v2 now start at the baseline")
+	assert.Equal(t, int64(2), response.Count)
+	assert.Equal(t, 4, countObjectsByProject(tc, 1))
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, i(3), ""), stream)
+	require.NoError(t, err, "fs.Get baseline")
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/a": {content: "a v1"},
+		"/b": {content: "b v2"},
+	})
+
+	stream = &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(rangeQuery(1, i(3), nil, ""), stream)
+	require.NoError(t, err, "fs.Get from baseline")
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/d": {content: "d v4"},
+		"/e": {deleted: true},
+	})
+
+	stream = &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(rangeQuery(1, i(1), nil, ""), stream)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "ranges starting below the baseline")
+
+	_, err = fs.Rollback(tc.Context(), &pb.RollbackRequest{Project: 1, Version: 2})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err), "rollback below the baseline")
+
+	response, err = fs.SquashHistory(tc.Context(), &pb.SquashHistoryRequest{Project: 1, Version: i(2)})
+	require.NoError(t, err, "fs.SquashHistory below the current baseline")
+	assert.Equal(t, int64(3), response.BaselineVersion)
+	assert.Equal(t, int64(0), response.RemovedObjects)
+
+	_, err = fs.SquashHistory(tc.Context(), &pb.SquashHistoryRequest{Project: 1, Version: i(6)})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "baseline above the latest version")
+
+	_, err = fs.SquashHistory(tc.Context(), &pb.SquashHistoryRequest{Project: 1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "neither version nor keep")
+}
+
+func TestTombstones(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 4)
+	writeObject(tc, 1, 1, nil, "/a", "a v1")
+	writeObject(tc, 1, 1, i(2), "/b", "b v1")
+	writeObject(tc, 1, 2, nil, "/b", "b v2")
+	writeObject(tc, 1, 1, i(2), "/tmp/c", "c v1")
+	writeObject(tc, 1, 2, i(3), "/tmp/d", "d v2")
+	writeObject(tc, 1, 1, i(2), "/tmp/e", "e v1")
+	writeObject(tc, 1, 3, i(4), "/tmp/e", "e v3")
+
+	fs := tc.FsApi()
+
+	inspect, err := fs.Inspect(tc.Context(), &pb.InspectRequest{Project: 1})
+	require.NoError(t, err, "fs.Inspect")
+	assert.Equal(t, int64(4), inspect.TombstonesCount, "/b v1 was replaced rather than deleted")
+
+	tombstones, err := fs.ListTombstones(tc.Context(), &pb.ListTombstonesRequest{Project: 1, Prefix: "/tmp/"})
+	require.NoError(t, err, "fs.ListTombstones")
+	assert.Equal(t, int64(4), tombstones.Version)
+	require.Len(t, tombstones.Tombstones, 3)
+	assert.Equal(t, "/tmp/c", tombstones.Tombstones[0].Path)
+	assert.Equal(t, int64(2), tombstones.Tombstones[0].DeletedVersion)
+	assert.Equal(t, "/tmp/e", tombstones.Tombstones[2].Path)
+	assert.Equal(t, int64(4), tombstones.Tombstones[2].DeletedVersion, "the latest deletion is listed")
+
+	tombstones, err = fs.ListTombstones(tc.Context(), &pb.ListTombstonesRequest{Project: 1, Prefix: "/tmp/", Version: i(3)})
+	require.NoError(t, err, "fs.ListTombstones at version 3")
+	require.Len(t, tombstones.Tombstones, 2, "/tmp/e exists at version 3")
+	assert.Equal(t, "/tmp/c", tombstones.Tombstones[0].Path)
+	assert.Equal(t, "/tmp/d", tombstones.Tombstones[1].Path)
+
+	purged, err := fs.PurgeDeleted(tc.Context(), &pb.PurgeDeletedRequest{Project: 1, Prefix: "/tmp/", BeforeVersion: 3})
+	require.NoError(t, err, "fs.PurgeDeleted")
+	assert.Equal(t, int64(2), purged.PurgedObjects, "/tmp/c and /tmp/e v1 were deleted before version 3")
+	assert.Equal(t, int64(2), purged.Count)
+	assert.Equal(t, 5, countObjectsByProject(tc, 1))
+
+	stream := &mockGetServer{ctx: tc.Context()}
+	err = fs.Get(prefixQuery(1, nil, ""), stream)
+	require.NoError(t, err, "fs.Get")
+	verifyStreamResults(t, stream.results, map[string]expectedObject{
+		"/a": {content: "a v1"},
+		"/b": {content: "b v2"},
+	})
+
+	_, err = fs.PurgeDeleted(tc.Context(), &pb.PurgeDeletedRequest{Project: 1, BeforeVersion: 3})
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "a prefix is required")
+}
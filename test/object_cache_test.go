@@ -0,0 +1,81 @@
+package test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/files"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReflink(t *testing.T) {
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	source := filepath.Join(tmpDir, "source")
+	require.NoError(t, os.WriteFile(source, []byte("source content"), 0644))
+
+	clone := filepath.Join(tmpDir, "clone")
+	err := files.Reflink(source, clone)
+	if errors.Is(err, files.ErrReflinkUnsupported) {
+		t.Skipf("reflinks unsupported in %v: %v", tmpDir, err)
+	}
+	require.NoError(t, err, "Reflink")
+
+	content, err := os.ReadFile(clone)
+	require.NoError(t, err, "read clone")
+	assert.Equal(t, "source content", string(content))
+
+	// clones are copy on write, editing one leaves the other untouched
+	require.NoError(t, os.WriteFile(clone, []byte("clone content"), 0644))
+	content, err = os.ReadFile(source)
+	require.NoError(t, err, "read source")
+	assert.Equal(t, "source content", string(content))
+
+	assert.Error(t, files.Reflink(source, clone), "Reflink onto an existing file")
+}
+
+func TestObjectCacheCopyTo(t *testing.T) {
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	cache, err := files.NewObjectCache(filepath.Join(tmpDir, "objects"))
+	require.NoError(t, err, "NewObjectCache")
+
+	content := []byte(strings.Repeat("cached content\n", 1024))
+	require.NoError(t, cache.Store(content), "store object")
+	contentHash := db.HashContent(content)
+	hash := contentHash.Bytes()
+
+	// the copy replaces whatever is in the way, whether the object is cloned or copied
+	path := filepath.Join(tmpDir, "checkout", "a", "b.txt")
+	require.NoError(t, os.MkdirAll(filepath.Join(path, "nested"), 0755))
+
+	for _, mode := range []os.FileMode{0755, 0600} {
+		require.NoError(t, cache.CopyTo(hash, path, mode, nil), "CopyTo")
+
+		info, err := os.Stat(path)
+		require.NoError(t, err, "stat copy")
+		assert.Equal(t, mode, info.Mode().Perm())
+
+		copied, err := os.ReadFile(path)
+		require.NoError(t, err, "read copy")
+		assert.Equal(t, content, copied)
+	}
+
+	// edits to the checkout never reach the cached object
+	require.NoError(t, os.WriteFile(path, []byte("edited"), 0644))
+	require.NoError(t, cache.CopyTo(hash, filepath.Join(tmpDir, "checkout", "c.txt"), 0644, nil), "CopyTo")
+	copied, err := os.ReadFile(filepath.Join(tmpDir, "checkout", "c.txt"))
+	require.NoError(t, err, "read copy")
+	assert.Equal(t, content, copied)
+
+	entries, err := os.ReadDir(filepath.Join(tmpDir, "checkout", "a"))
+	require.NoError(t, err, "read checkout dir")
+	assert.Len(t, entries, 1, "no temporary file left behind")
+}
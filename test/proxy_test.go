@@ -0,0 +1,196 @@
+package test
+
+import (
+	"context"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/auth"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	util "github.com/gadget-inc/dateilager/internal/testutil"
+	"github.com/gadget-inc/dateilager/pkg/client"
+	"github.com/gadget-inc/dateilager/pkg/proxy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func createTestProxyClient(tc util.TestCtx, cacheDir string) (*client.Client, func()) {
+	lis, s, getConn := createTestGRPCServer(tc)
+	pb.RegisterFsServer(s, tc.FsApi())
+
+	go func() {
+		err := s.Serve(lis)
+		require.NoError(tc.T(), err, "Server exited")
+	}()
+
+	upstream := getConn()
+
+	cache, err := proxy.NewCache(cacheDir, 0)
+	require.NoError(tc.T(), err, "proxy.NewCache")
+
+	p := proxy.New(upstream, cache)
+	ps := grpc.NewServer(p.ServerOptions()...)
+
+	proxyLis := bufconn.Listen(bufSize)
+	go func() {
+		err := ps.Serve(proxyLis)
+		require.NoError(tc.T(), err, "Proxy exited")
+	}()
+
+	conn, err := grpc.DialContext(tc.Context(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return proxyLis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(tc.T(), err, "Failed to dial proxy")
+
+	c := client.NewClientConn(conn)
+
+	return c, func() { c.Close(); ps.Stop(); upstream.Close(); s.Stop() }
+}
+
+func countProxyContents(t *testing.T, cacheDir string, kind string) int {
+	count := 0
+	err := filepath.WalkDir(filepath.Join(cacheDir, kind), func(_ string, entry fs.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			count++
+		}
+		return err
+	})
+	require.NoError(t, err, "walk proxy cache")
+	return count
+}
+
+func TestProxyCachesRebuilds(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+	writeObject(tc, 1, 1, nil, "b/c", "c v1")
+
+	cacheDir := emptyTmpDir(t)
+	defer os.RemoveAll(cacheDir)
+
+	c, close := createTestProxyClient(tc, cacheDir)
+	defer close()
+
+	firstDir := emptyTmpDir(t)
+	defer os.RemoveAll(firstDir)
+
+	rebuild(tc, c, 1, nil, firstDir, nil, expectedResponse{version: 1, count: 2})
+	assert.Equal(t, 2, countProxyContents(t, cacheDir, "files"), "rebuilt file contents should be cached")
+
+	secondDir := emptyTmpDir(t)
+	defer os.RemoveAll(secondDir)
+
+	rebuild(tc, c, 1, nil, secondDir, nil, expectedResponse{version: 1, count: 2})
+	assert.Equal(t, 2, countProxyContents(t, cacheDir, "files"), "repeat rebuild should be served from the cache")
+
+	verifyDir(t, secondDir, 1, map[string]expectedFile{
+		"a":   {content: "a v1"},
+		"b/c": {content: "c v1"},
+	})
+}
+
+func TestProxyCachesPacks(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+	writePackedFiles(tc, 1, 1, nil, "pack/a")
+
+	cacheDir := emptyTmpDir(t)
+	defer os.RemoveAll(cacheDir)
+
+	c, close := createTestProxyClient(tc, cacheDir)
+	defer close()
+
+	firstDir := emptyTmpDir(t)
+	defer os.RemoveAll(firstDir)
+
+	rebuild(tc, c, 1, nil, firstDir, nil, expectedResponse{version: 1, count: 3})
+	assert.Equal(t, 1, countProxyContents(t, cacheDir, "packs"), "rebuilt pack should be cached")
+
+	secondDir := emptyTmpDir(t)
+	defer os.RemoveAll(secondDir)
+
+	rebuild(tc, c, 1, nil, secondDir, nil, expectedResponse{version: 1, count: 3})
+	assert.Equal(t, 1, countProxyContents(t, cacheDir, "packs"), "repeat rebuild should be served from the cache")
+
+	verifyDir(t, secondDir, 1, map[string]expectedFile{
+		"a":        {content: "a v1"},
+		"pack/a/1": {content: "pack/a/1 v1"},
+		"pack/a/2": {content: "pack/a/2 v1"},
+	})
+}
+
+func TestProxyServesRecreatedVersions(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+
+	cacheDir := emptyTmpDir(t)
+	defer os.RemoveAll(cacheDir)
+
+	c, close := createTestProxyClient(tc, cacheDir)
+	defer close()
+
+	firstDir := emptyTmpDir(t)
+	defer os.RemoveAll(firstDir)
+
+	rebuild(tc, c, 1, i(1), firstDir, nil, expectedResponse{version: 1, count: 1})
+
+	// the project is recreated with different objects at the same version
+	_, err := tc.Connect().Exec(tc.Context(), "DELETE FROM dl.objects WHERE project = 1")
+	require.NoError(t, err, "delete objects")
+	writeObject(tc, 1, 1, nil, "a", "a recreated")
+	writeObject(tc, 1, 1, nil, "b", "b recreated")
+
+	secondDir := emptyTmpDir(t)
+	defer os.RemoveAll(secondDir)
+
+	rebuild(tc, c, 1, i(1), secondDir, nil, expectedResponse{version: 1, count: 2})
+	verifyDir(t, secondDir, 1, map[string]expectedFile{
+		"a": {content: "a recreated"},
+		"b": {content: "b recreated"},
+	})
+}
+
+func TestProxyForwardsUpdates(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Project, 1)
+	defer tc.Close()
+
+	writeProject(tc, 1, 1)
+	writeObject(tc, 1, 1, nil, "a", "a v1")
+
+	cacheDir := emptyTmpDir(t)
+	defer os.RemoveAll(cacheDir)
+
+	c, close := createTestProxyClient(tc, cacheDir)
+	defer close()
+
+	dir := emptyTmpDir(t)
+	defer os.RemoveAll(dir)
+
+	rebuild(tc, c, 1, nil, dir, nil, expectedResponse{version: 1, count: 1})
+
+	writeFile(t, dir, "a", "a v2")
+	update(tc, c, 1, dir, expectedResponse{version: 2, count: 1})
+
+	otherDir := emptyTmpDir(t)
+	defer os.RemoveAll(otherDir)
+
+	rebuild(tc, c, 1, nil, otherDir, nil, expectedResponse{version: 2, count: 1})
+	verifyDir(t, otherDir, 2, map[string]expectedFile{
+		"a": {content: "a v2"},
+	})
+}
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/auth"
+	"github.com/gadget-inc/dateilager/internal/pb"
+	util "github.com/gadget-inc/dateilager/internal/testutil"
+	"github.com/gadget-inc/dateilager/pkg/scan"
+	"github.com/gadget-inc/dateilager/pkg/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeScanner struct {
+	err error
+}
+
+func (s *fakeScanner) Name() string {
+	return "fake"
+}
+
+func (s *fakeScanner) Scan(_ context.Context, content []byte) (scan.Verdict, error) {
+	if s.err != nil {
+		return scan.Verdict{}, s.err
+	}
+	if bytes.Contains(content, []byte("EICAR")) {
+		return scan.Verdict{Infected: true, Signature: "Eicar-Test-Signature"}, nil
+	}
+	return scan.Verdict{}, nil
+}
+
+func TestContentScans(t *testing.T) {
+	tc := util.NewTestCtx(t, auth.Admin)
+	defer tc.Close()
+
+	writeProject(tc, 1, 0, "/p/")
+
+	fs := tc.FsApi()
+	fs.QueueContentScans = true
+
+	err := fs.Update(newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"/a":   {content: "a v1"},
+		"/b":   {content: "EICAR b v1"},
+		"/p/x": {content: "EICAR p/x v1"},
+		"/p/y": {content: "p/y v1"},
+	}))
+	require.NoError(t, err, "fs.Update")
+
+	status, err := fs.ScanStatus(tc.Context(), &pb.ScanStatusRequest{Project: 1})
+	require.NoError(t, err, "fs.ScanStatus")
+	assert.Equal(t, int64(1), status.Version)
+	assert.Equal(t, int64(3), status.Pending, "loose objects and the pack should be queued")
+
+	config := server.ContentScanConfig{Scanner: &fakeScanner{}, BatchSize: 10}
+	result, err := server.RunContentScans(tc.Context(), tc.Connector(), tc.ContentLookup(), config)
+	require.NoError(t, err, "RunContentScans")
+	assert.Equal(t, int64(3), result.Scanned)
+	assert.Equal(t, int64(2), result.Infected)
+
+	status, err = fs.ScanStatus(tc.Context(), &pb.ScanStatusRequest{Project: 1})
+	require.NoError(t, err, "fs.ScanStatus")
+	assert.Equal(t, int64(0), status.Pending)
+	assert.Equal(t, int64(1), status.Clean)
+	assert.Equal(t, int64(2), status.Infected)
+	require.Len(t, status.Findings, 2)
+	assert.Equal(t, "/b", status.Findings[0].Path)
+	assert.Equal(t, "Eicar-Test-Signature", status.Findings[0].Signature)
+	assert.Equal(t, "/p/", status.Findings[1].Path)
+	assert.Equal(t, "Eicar-Test-Signature in /p/x", status.Findings[1].Signature)
+
+	err = fs.Update(newMockUpdateServer(tc.Context(), 1, map[string]expectedObject{
+		"/c": {content: "c v2"},
+	}))
+	require.NoError(t, err, "fs.Update")
+
+	config.Scanner = &fakeScanner{err: errors.New("scanner unavailable")}
+	for attempt := 1; attempt <= 3; attempt++ {
+		result, err = server.RunContentScans(tc.Context(), tc.Connector(), tc.ContentLookup(), config)
+		require.NoError(t, err, "RunContentScans")
+		assert.Equal(t, int64(1), result.Failed)
+	}
+
+	status, err = fs.ScanStatus(tc.Context(), &pb.ScanStatusRequest{Project: 1})
+	require.NoError(t, err, "fs.ScanStatus")
+	assert.Equal(t, int64(2), status.Version)
+	assert.Equal(t, int64(1), status.Failed, "scans are given up on after repeated failures")
+
+	result, err = server.RunContentScans(tc.Context(), tc.Connector(), tc.ContentLookup(), config)
+	require.NoError(t, err, "RunContentScans")
+	assert.Equal(t, int64(0), result.Scanned, "failed scans are no longer retried")
+}
@@ -52,6 +52,10 @@ func i(i int64) *int64 {
 	return &i
 }
 
+func s(s string) *string {
+	return &s
+}
+
 type expectedObject struct {
 	content string
 	deleted bool
@@ -515,6 +519,7 @@ type mockGetServer struct {
 	grpc.ServerStream
 	ctx     context.Context
 	results []*pb.Object
+	cursors []string
 }
 
 func (m *mockGetServer) Context() context.Context {
@@ -523,13 +528,16 @@ func (m *mockGetServer) Context() context.Context {
 
 func (m *mockGetServer) Send(resp *pb.GetResponse) error {
 	m.results = append(m.results, resp.Object)
+	m.cursors = append(m.cursors, resp.Cursor)
 	return nil
 }
 
 type mockGetCompressServer struct {
 	grpc.ServerStream
-	ctx     context.Context
-	results [][]byte
+	ctx       context.Context
+	results   [][]byte
+	hashes    [][]byte
+	packPaths []*string
 }
 
 func (m *mockGetCompressServer) Context() context.Context {
@@ -538,6 +546,8 @@ func (m *mockGetCompressServer) Context() context.Context {
 
 func (m *mockGetCompressServer) Send(resp *pb.GetCompressResponse) error {
 	m.results = append(m.results, resp.Bytes)
+	m.hashes = append(m.hashes, resp.Hash)
+	m.packPaths = append(m.packPaths, resp.PackPath)
 	return nil
 }
 
@@ -546,6 +556,8 @@ type mockUpdateServer struct {
 	ctx      context.Context
 	project  int64
 	updates  []*pb.Object
+	hashes   map[string][]byte
+	force    *bool
 	idx      int
 	response *pb.UpdateResponse
 }
@@ -595,6 +607,52 @@ func (m *mockUpdateServer) Recv() (*pb.UpdateRequest, error) {
 	return &pb.UpdateRequest{
 		Project: m.project,
 		Object:  object,
+		Hash:    m.hashes[object.Path],
+		Force:   m.force,
+	}, nil
+}
+
+type mockStageServer struct {
+	grpc.ServerStream
+	ctx      context.Context
+	project  int64
+	stage    string
+	objects  []*pb.Object
+	idx      int
+	response *pb.StageResponse
+}
+
+func newMockStageServer(ctx context.Context, project int64, stage string, objects map[string]expectedObject) *mockStageServer {
+	updates := newMockUpdateServer(ctx, project, objects).updates
+
+	return &mockStageServer{
+		ctx:     ctx,
+		project: project,
+		stage:   stage,
+		objects: updates,
+	}
+}
+
+func (m *mockStageServer) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockStageServer) SendAndClose(res *pb.StageResponse) error {
+	m.response = res
+	return nil
+}
+
+func (m *mockStageServer) Recv() (*pb.StageRequest, error) {
+	if m.idx >= len(m.objects) {
+		return nil, io.EOF
+	}
+
+	object := m.objects[m.idx]
+	m.idx += 1
+	return &pb.StageRequest{
+		Project: m.project,
+		Stage:   m.stage,
+		Object:  object,
 	}, nil
 }
 
@@ -613,6 +671,51 @@ func (m *mockGetCacheServer) Send(resp *pb.GetCacheResponse) error {
 	return nil
 }
 
+type mockFanOutUpdateServer struct {
+	grpc.ServerStream
+	ctx     context.Context
+	results []*pb.FanOutUpdateResponse
+}
+
+func (m *mockFanOutUpdateServer) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockFanOutUpdateServer) Send(resp *pb.FanOutUpdateResponse) error {
+	m.results = append(m.results, resp)
+	return nil
+}
+
+type mockDiffServer struct {
+	grpc.ServerStream
+	ctx     context.Context
+	results []*pb.DiffResponse
+}
+
+func (m *mockDiffServer) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockDiffServer) Send(resp *pb.DiffResponse) error {
+	m.results = append(m.results, resp)
+	return nil
+}
+
+type mockSampleObjectsServer struct {
+	grpc.ServerStream
+	ctx     context.Context
+	results []*pb.SampleObjectsResponse
+}
+
+func (m *mockSampleObjectsServer) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockSampleObjectsServer) Send(resp *pb.SampleObjectsResponse) error {
+	m.results = append(m.results, resp)
+	return nil
+}
+
 func buildRequest(project int64, fromVersion, toVersion *int64, prefix bool, paths ...string) *pb.GetRequest {
 	path, ignores := paths[0], paths[1:]
 
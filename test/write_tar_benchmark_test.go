@@ -0,0 +1,83 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/files"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTarFixture(b *testing.B, count int) []byte {
+	writer := db.NewTarWriter()
+	defer writer.Close()
+
+	for idx := 0; idx < count; idx++ {
+		content := []byte(fmt.Sprintf("file %d content", idx))
+		object := db.NewUncachedTarObject(fmt.Sprintf("dir%d/file%d.txt", idx%50, idx), 0644, int64(len(content)), false, content)
+		require.NoError(b, writer.WriteObject(&object), "write content to TAR")
+	}
+
+	contentTar, err := writer.BytesAndReset()
+	require.NoError(b, err, "write content TAR to bytes")
+	return contentTar
+}
+
+func BenchmarkWriteTarDurability(b *testing.B) {
+	contentTar := writeTarFixture(b, 5000)
+
+	for _, durability := range []files.Durability{files.DurabilityNone, files.DurabilityBatched, files.DurabilityFull} {
+		b.Run(durability.String(), func(b *testing.B) {
+			tmpDir := emptyTmpDir(b)
+			defer os.RemoveAll(tmpDir)
+
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				dir := filepath.Join(tmpDir, fmt.Sprint(n))
+				syncer := files.NewSyncer(tmpDir, durability, files.DefaultSyncBatchSize)
+
+				reader := db.NewTarReader()
+				reader.FromBytes(contentTar)
+
+				_, _, err := files.WriteTar(context.Background(), dir, "", reader, nil, files.WriteTarOptions{Syncer: syncer})
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				err = syncer.Finish()
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkWriteTarUring(b *testing.B) {
+	contentTar := writeTarFixture(b, 5000)
+
+	tmpDir := emptyTmpDir(b)
+	defer os.RemoveAll(tmpDir)
+
+	syncer := files.NewSyncer(tmpDir, files.DurabilityNone, 0)
+	uring, err := files.NewUringWriter(syncer)
+	if err != nil {
+		b.Skipf("io_uring unavailable: %v", err)
+	}
+	defer uring.Close()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		reader := db.NewTarReader()
+		reader.FromBytes(contentTar)
+
+		_, _, err := files.WriteTar(context.Background(), filepath.Join(tmpDir, fmt.Sprint(n)), "", reader, nil, files.WriteTarOptions{Syncer: syncer, Uring: uring})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
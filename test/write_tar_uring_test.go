@@ -0,0 +1,114 @@
+package test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gadget-inc/dateilager/internal/db"
+	"github.com/gadget-inc/dateilager/internal/files"
+	"github.com/klauspost/compress/s2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTarWithUring(t *testing.T) {
+	syncer := files.NewSyncer(os.TempDir(), files.DurabilityFull, 0)
+	uring, err := files.NewUringWriter(syncer)
+	if err != nil {
+		t.Skipf("io_uring unavailable: %v", err)
+	}
+	defer uring.Close()
+
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	// a directory in the way of a file is replaced like with direct writes
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "c", "nested"), 0755))
+
+	writer := db.NewTarWriter()
+	defer writer.Close()
+
+	objects := []db.TarObject{
+		db.NewUncachedTarObject("a", 0644, 4, false, []byte("a v1")),
+		db.NewUncachedTarObject("b/d", 0600, 4, false, []byte("d v1")),
+		db.NewUncachedTarObject("c", 0755, 4, false, []byte("c v1")),
+		db.NewUncachedTarObject("e/", int64(0755|fs.ModeDir), 0, false, nil),
+		db.NewUncachedTarObject("f", 0644, 0, false, []byte{}),
+	}
+	for _, object := range objects {
+		require.NoError(t, writer.WriteObject(&object), "write content to TAR")
+	}
+
+	contentTar, err := writer.BytesAndReset()
+	require.NoError(t, err, "write content TAR to bytes")
+
+	reader := db.NewTarReader()
+	reader.FromBytes(contentTar)
+
+	count, _, err := files.WriteTar(context.Background(), tmpDir, "", reader, nil, files.WriteTarOptions{Syncer: syncer, Uring: uring})
+	require.NoError(t, err, "WriteTar")
+	assert.Equal(t, uint32(5), count)
+
+	verifyDir(t, tmpDir, -1, map[string]expectedFile{
+		"a":   {content: "a v1", fileType: typeRegular},
+		"b/d": {content: "d v1", fileType: typeRegular},
+		"c":   {content: "c v1", fileType: typeRegular},
+		"e/":  {fileType: typeDirectory},
+		"f":   {content: "", fileType: typeRegular},
+	})
+
+	info, err := os.Stat(filepath.Join(tmpDir, "b/d"))
+	require.NoError(t, err, "stat b/d")
+	assert.Equal(t, fs.FileMode(0600), info.Mode().Perm())
+
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err, "read dir")
+	for _, entry := range entries {
+		assert.False(t, strings.HasPrefix(entry.Name(), ".dl-partial-"), "partial file %v left behind", entry.Name())
+	}
+}
+
+func TestWriteTarWithUringDiscardsOnError(t *testing.T) {
+	syncer := files.NewSyncer(os.TempDir(), files.DurabilityNone, 0)
+	uring, err := files.NewUringWriter(syncer)
+	if err != nil {
+		t.Skipf("io_uring unavailable: %v", err)
+	}
+	defer uring.Close()
+
+	tmpDir := emptyTmpDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	var buffer bytes.Buffer
+	s2Writer := s2.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(s2Writer)
+	for _, name := range []string{"a", "b"} {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: 2, Typeflag: tar.TypeReg}))
+		_, err = tarWriter.Write([]byte(name + "1"))
+		require.NoError(t, err, "write content to TAR")
+	}
+	require.NoError(t, tarWriter.Flush())
+	require.NoError(t, s2Writer.Close())
+
+	// a chunk of a reserved type, the TAR cannot be read past the queued files
+	buffer.Write([]byte{0x02, 0x01, 0x00, 0x00, 0x00})
+
+	reader := db.NewTarReader()
+	defer reader.Close()
+	reader.FromBytes(buffer.Bytes())
+
+	_, _, err = files.WriteTar(context.Background(), tmpDir, "", reader, nil, files.WriteTarOptions{Syncer: syncer, Uring: uring})
+	require.Error(t, err, "WriteTar of a corrupted TAR")
+
+	require.NoError(t, uring.Flush(), "flush after a failed WriteTar")
+
+	entries, err := os.ReadDir(tmpDir)
+	require.NoError(t, err, "read dir")
+	assert.Empty(t, entries, "files queued before the error were written")
+}